@@ -22,7 +22,9 @@ package zap
 
 import (
 	"fmt"
+	"reflect"
 	"time"
+	"unsafe"
 
 	"go.uber.org/zap/zapcore"
 )
@@ -193,6 +195,51 @@ func (os objectValues[T, P]) MarshalLogArray(arr zapcore.ArrayEncoder) error {
 	return nil
 }
 
+// Vals constructs a field that carries a slice of booleans, strings, or
+// numbers, choosing the array type from the elements' underlying type. Like
+// Val, it handles slices of named types without reflection or copying.
+func Vals[T Primitive](key string, vs []T) Field {
+	// T's underlying type is one of the types in Primitive, so []T has the
+	// same layout as a slice of that type.
+	p := unsafe.Pointer(&vs)
+	switch primitiveKind[T]() {
+	case reflect.Bool:
+		return Bools(key, *(*[]bool)(p))
+	case reflect.String:
+		return Strings(key, *(*[]string)(p))
+	case reflect.Int:
+		return Ints(key, *(*[]int)(p))
+	case reflect.Int64:
+		return Int64s(key, *(*[]int64)(p))
+	case reflect.Int32:
+		return Int32s(key, *(*[]int32)(p))
+	case reflect.Int16:
+		return Int16s(key, *(*[]int16)(p))
+	case reflect.Int8:
+		return Int8s(key, *(*[]int8)(p))
+	case reflect.Uint:
+		return Uints(key, *(*[]uint)(p))
+	case reflect.Uint64:
+		return Uint64s(key, *(*[]uint64)(p))
+	case reflect.Uint32:
+		return Uint32s(key, *(*[]uint32)(p))
+	case reflect.Uint16:
+		return Uint16s(key, *(*[]uint16)(p))
+	case reflect.Uint8:
+		return Uint8s(key, *(*[]uint8)(p))
+	case reflect.Uintptr:
+		return Uintptrs(key, *(*[]uintptr)(p))
+	case reflect.Float64:
+		return Float64s(key, *(*[]float64)(p))
+	case reflect.Float32:
+		return Float32s(key, *(*[]float32)(p))
+	case reflect.Complex128:
+		return Complex128s(key, *(*[]complex128)(p))
+	default: // reflect.Complex64
+		return Complex64s(key, *(*[]complex64)(p))
+	}
+}
+
 // Strings constructs a field that carries a slice of strings.
 func Strings(key string, ss []string) Field {
 	return Array(key, stringArray(ss))
@@ -314,3 +314,44 @@ func TestStringers(t *testing.T) {
 		})
 	}
 }
+
+func TestVals(t *testing.T) {
+	type (
+		myString string
+		myInt    int
+		myUint8  uint8
+	)
+
+	tests := []struct {
+		desc     string
+		field    Field
+		expected []interface{}
+	}{
+		{"bools", Vals("", []bool{true}), []interface{}{true}},
+		{"named strings", Vals("", []myString{"a", "b"}), []interface{}{"a", "b"}},
+		{"named ints", Vals("", []myInt{1, 2}), []interface{}{1, 2}},
+		{"int64s", Vals("", []int64{1}), []interface{}{int64(1)}},
+		{"int32s", Vals("", []int32{1}), []interface{}{int32(1)}},
+		{"int16s", Vals("", []int16{1}), []interface{}{int16(1)}},
+		{"int8s", Vals("", []int8{1}), []interface{}{int8(1)}},
+		{"uints", Vals("", []uint{1}), []interface{}{uint(1)}},
+		{"uint64s", Vals("", []uint64{1}), []interface{}{uint64(1)}},
+		{"uint32s", Vals("", []uint32{1}), []interface{}{uint32(1)}},
+		{"uint16s", Vals("", []uint16{1}), []interface{}{uint16(1)}},
+		{"named uint8s", Vals("", []myUint8{1}), []interface{}{uint8(1)}},
+		{"uintptrs", Vals("", []uintptr{1}), []interface{}{uintptr(1)}},
+		{"float64s", Vals("", []float64{1.5}), []interface{}{1.5}},
+		{"float32s", Vals("", []float32{1.5}), []interface{}{float32(1.5)}},
+		{"complex128s", Vals("", []complex128{1 + 2i}), []interface{}{1 + 2i}},
+		{"complex64s", Vals("", []complex64{1 + 2i}), []interface{}{complex64(1 + 2i)}},
+		{"nil", Vals[myInt]("", nil), []interface{}{}},
+	}
+
+	for _, tt := range tests {
+		enc := zapcore.NewMapObjectEncoder()
+		tt.field.Key = "k"
+		tt.field.AddTo(enc)
+		assert.Equal(t, tt.expected, enc.Fields["k"], "%s: unexpected map contents.", tt.desc)
+		assert.Equal(t, 1, len(enc.Fields), "%s: found extra keys in map: %v", tt.desc, enc.Fields)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"sync"
+
+	"go.uber.org/zap/internal/bufferpool"
+	"go.uber.org/zap/zapcore"
+)
+
+// BinaryReader constructs a field that reads up to limit bytes from r and
+// writes them base64-encoded, for logging snippets of payloads that may be
+// large, such as request bodies. Unlike Binary, it never holds more than
+// limit bytes of the payload, and it encodes them straight into the
+// encoder's output rather than into a string of their own. If r holds more
+// than limit bytes, the field is annotated with ${key}Truncated, and if
+// reading fails, with ${key}Error.
+//
+// r is read once, the first time the field is encoded; later encodings,
+// such as those of the other cores of a Tee, reuse what was read then.
+func BinaryReader(key string, r io.Reader, limit int) Field {
+	return binaryReaderField(key, r, limit, false)
+}
+
+// HexReader is like BinaryReader, but writes the bytes hex-encoded.
+func HexReader(key string, r io.Reader, limit int) Field {
+	return binaryReaderField(key, r, limit, true)
+}
+
+func binaryReaderField(key string, r io.Reader, limit int, hex bool) Field {
+	if limit < 0 {
+		limit = 0
+	}
+	return Field{
+		Key:       key,
+		Type:      zapcore.InlineMarshalerType,
+		Interface: &binaryReader{key: key, r: r, limit: limit, hex: hex},
+	}
+}
+
+type binaryReader struct {
+	key   string
+	r     io.Reader
+	limit int
+	hex   bool
+
+	once      sync.Once
+	data      []byte
+	truncated bool
+	err       error
+}
+
+func (br *binaryReader) read() {
+	// Read one byte more than the limit to tell whether r is truncated.
+	data, err := io.ReadAll(io.LimitReader(br.r, int64(br.limit)+1))
+	if len(data) > br.limit {
+		data = data[:br.limit]
+		br.truncated = true
+	}
+	br.data, br.err, br.r = data, err, nil
+}
+
+func (br *binaryReader) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	br.once.Do(br.read)
+
+	buf := bufferpool.Get()
+	defer buf.Free()
+	if br.hex {
+		w := hex.NewEncoder(buf)
+		_, _ = w.Write(br.data)
+	} else {
+		w := base64.NewEncoder(base64.StdEncoding, buf)
+		_, _ = w.Write(br.data)
+		_ = w.Close() // flush any partial block
+	}
+	// Encoders copy byte strings rather than keep them, so buf can go back
+	// to the pool.
+	enc.AddByteString(br.key, buf.Bytes())
+
+	if br.truncated {
+		enc.AddBool(br.key+"Truncated", true)
+	}
+	return br.err
+}
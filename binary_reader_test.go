@@ -0,0 +1,90 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestBinaryReader(t *testing.T) {
+	tests := []struct {
+		desc  string
+		field Field
+		want  map[string]interface{}
+	}{
+		{
+			desc:  "base64",
+			field: BinaryReader("k", strings.NewReader("abcd"), 10),
+			want:  map[string]interface{}{"k": "YWJjZA=="},
+		},
+		{
+			desc:  "exact limit",
+			field: BinaryReader("k", strings.NewReader("abcd"), 4),
+			want:  map[string]interface{}{"k": "YWJjZA=="},
+		},
+		{
+			desc:  "truncated",
+			field: BinaryReader("k", strings.NewReader("abcdef"), 3),
+			want:  map[string]interface{}{"k": "YWJj", "kTruncated": true},
+		},
+		{
+			desc:  "hex",
+			field: HexReader("k", strings.NewReader("abcdef"), 2),
+			want:  map[string]interface{}{"k": "6162", "kTruncated": true},
+		},
+		{
+			desc:  "negative limit",
+			field: HexReader("k", strings.NewReader("a"), -1),
+			want:  map[string]interface{}{"k": "", "kTruncated": true},
+		},
+		{
+			desc:  "read error",
+			field: BinaryReader("k", iotest.DataErrReader(iotest.ErrReader(errors.New("fail"))), 10),
+			want:  map[string]interface{}{"k": "", "kError": "fail"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			for i := 0; i < 2; i++ {
+				enc := zapcore.NewMapObjectEncoder()
+				tt.field.AddTo(enc)
+				assert.Equal(t, tt.want, enc.Fields, "Unexpected fields on encoding %d.", i+1)
+			}
+		})
+	}
+}
+
+func TestBinaryReaderJSON(t *testing.T) {
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"})
+	buf, err := enc.EncodeEntry(zapcore.Entry{Message: "req"}, []Field{
+		BinaryReader("body", strings.NewReader("hello, world"), 5),
+	})
+	assert.NoError(t, err, "Unexpected encoding error.")
+	assert.Equal(t, `{"msg":"req","body":"aGVsbG8=","bodyTruncated":true}`+"\n", buf.String(), "Unexpected output.")
+	buf.Free()
+}
@@ -24,6 +24,8 @@
 package buffer // import "go.uber.org/zap/buffer"
 
 import (
+	"io"
+	"math"
 	"strconv"
 	"time"
 	"unsafe"
@@ -104,6 +106,101 @@ func (b *Buffer) AppendTime(t time.Time, layout string) {
 	b.bs = t.AppendFormat(b.bs, layout)
 }
 
+// AppendDuration appends d expressed as a decimal number of units, as in
+// "1.5" for 1500ms in seconds. When unit is a power of ten nanoseconds, the
+// value is exact and formatted without floating-point arithmetic; other
+// units fall back to AppendFloat. Units below a nanosecond are treated as
+// nanoseconds.
+func (b *Buffer) AppendDuration(d, unit time.Duration) {
+	if unit < 1 {
+		unit = 1
+	}
+	digits, ok := decimalDigits(unit)
+	if !ok {
+		b.AppendFloat(float64(d)/float64(unit), 64)
+		return
+	}
+
+	// Work with the magnitude as a uint64 so that math.MinInt64 doesn't
+	// overflow.
+	mag := uint64(d)
+	if d < 0 {
+		b.bs = append(b.bs, '-')
+		mag = -mag
+	}
+	b.appendDecimal(mag/uint64(unit), mag%uint64(unit), digits)
+}
+
+// AppendTimeUnix appends the time elapsed since the Unix epoch, expressed as
+// a decimal number of units, as in "1.5" for 1500ms after the epoch in
+// seconds. Like AppendDuration, it's exact for units that are powers of ten
+// nanoseconds up to a second.
+func (b *Buffer) AppendTimeUnix(t time.Time, unit time.Duration) {
+	if unit < 1 {
+		unit = 1
+	}
+	digits, ok := decimalDigits(unit)
+	sec, nsec := t.Unix(), int64(t.Nanosecond())
+	perSec := int64(time.Second / unit)
+	if !ok || unit > time.Second || sec > math.MaxInt64/perSec || sec < -math.MaxInt64/perSec {
+		b.AppendFloat((float64(sec)*1e9+float64(nsec))/float64(unit), 64)
+		return
+	}
+
+	if sec < 0 {
+		// Express times before the epoch as a negative magnitude.
+		b.bs = append(b.bs, '-')
+		if nsec > 0 {
+			sec, nsec = sec+1, int64(time.Second)-nsec
+		}
+		sec = -sec
+	}
+
+	// The unit divides a second evenly, so whole seconds contribute only to
+	// the integer part.
+	whole := uint64(sec)*uint64(perSec) + uint64(nsec)/uint64(unit)
+	b.appendDecimal(whole, uint64(nsec)%uint64(unit), digits)
+}
+
+// decimalDigits reports how many decimal digits a fraction of unit has, if
+// unit is a power of ten nanoseconds.
+func decimalDigits(unit time.Duration) (int, bool) {
+	digits := 0
+	for unit > 1 {
+		if unit%10 != 0 {
+			return 0, false
+		}
+		unit /= 10
+		digits++
+	}
+	return digits, true
+}
+
+// appendDecimal appends whole, followed by frac as a fraction with the given
+// number of digits, without trailing zeros.
+func (b *Buffer) appendDecimal(whole, frac uint64, digits int) {
+	b.bs = strconv.AppendUint(b.bs, whole, 10)
+	if frac == 0 {
+		return
+	}
+	for frac%10 == 0 {
+		frac /= 10
+		digits--
+	}
+	b.bs = append(b.bs, '.')
+	start := len(b.bs)
+	b.bs = strconv.AppendUint(b.bs, frac, 10)
+	if pad := digits - (len(b.bs) - start); pad > 0 {
+		// Shift the digits right to make room for leading zeros.
+		b.bs = append(b.bs, _zeros[:pad]...)
+		copy(b.bs[start+pad:], b.bs[start:len(b.bs)-pad])
+		copy(b.bs[start:start+pad], _zeros[:pad])
+	}
+}
+
+// _zeros holds enough zeros to pad any fraction of a time.Duration.
+var _zeros = []byte("0000000000000000000")
+
 // AppendUint appends an unsigned integer to the underlying buffer (assuming
 // base 10).
 func (b *Buffer) AppendUint(i uint64) {
@@ -118,9 +215,96 @@ func (b *Buffer) AppendBool(v bool) {
 // AppendFloat appends a float to the underlying buffer. It doesn't quote NaN
 // or +/- Inf.
 func (b *Buffer) AppendFloat(f float64, bitSize int) {
+	if appendShortFloat(b, f, bitSize) {
+		return
+	}
 	b.bs = strconv.AppendFloat(b.bs, f, 'f', -1, bitSize)
 }
 
+// AppendFloatFormat appends a float to the underlying buffer using the given
+// strconv format ('f', 'e', 'g', etc.) and precision. Like AppendFloat, it
+// doesn't quote NaN or +/- Inf.
+func (b *Buffer) AppendFloatFormat(f float64, fmt byte, prec, bitSize int) {
+	if prec < 0 && fmt == 'f' && appendShortFloat(b, f, bitSize) {
+		return
+	}
+	b.bs = strconv.AppendFloat(b.bs, f, fmt, prec, bitSize)
+}
+
+const (
+	// _maxExactFloat is the largest magnitude below which every integral
+	// float64 converts to an int64 without loss.
+	_maxExactFloat = 1 << 53
+	// _maxShortFloat bounds the magnitude of the fractional values that
+	// appendShortFloat formats. Below it, the gap between float64s is much
+	// smaller than 10^-_maxShortDecimals, so at most one decimal with that
+	// many places converts to a given float64.
+	_maxShortFloat = 1e9
+	// _maxShortDecimals is the most decimal places appendShortFloat writes,
+	// and _shortScale is 10^_maxShortDecimals.
+	_maxShortDecimals = 6
+	_shortScale       = 1e6
+)
+
+// appendShortFloat is a fast path for the floats that are common in logs:
+// whole numbers (counts, sizes, rounded durations) and values with a few
+// decimal places (ratios, prices, latencies in milliseconds). Their shortest
+// 'f' representation is an integer m scaled by 10^-d for a small d, which
+// we can find and format without the general-purpose float formatting.
+// Longer fractions pay for one rejected check before falling back. It
+// reports whether it wrote anything.
+func appendShortFloat(b *Buffer, f float64, bitSize int) bool {
+	if f <= -_maxExactFloat || f >= _maxExactFloat {
+		return false
+	}
+	if i := int64(f); float64(i) == f {
+		if i == 0 && math.Signbit(f) {
+			// Negative zero takes the slow path.
+			return false
+		}
+		b.bs = strconv.AppendInt(b.bs, i, 10)
+		return true
+	}
+	if bitSize != 64 || f <= -_maxShortFloat || f >= _maxShortFloat || f != f {
+		// The shortest representation of a float32 depends on float32
+		// precision, so only the integral fast path applies to it.
+		return false
+	}
+
+	// If f has a short decimal form, it's m/10^_maxShortDecimals for the
+	// nearest integer m: both are exact, so the division is correctly
+	// rounded, just like parsing the decimal would be. Checking once rejects
+	// long fractions cheaply.
+	m := math.Round(f * _shortScale)
+	if m/_shortScale != f {
+		return false
+	}
+	appendDecimal(b, int64(m))
+	return true
+}
+
+// appendDecimal appends m scaled by 10^-_maxShortDecimals, where m isn't a
+// multiple of 10^_maxShortDecimals, without trailing zeros.
+func appendDecimal(b *Buffer, m int64) {
+	if m < 0 {
+		b.bs = append(b.bs, '-')
+		m = -m
+	}
+	b.bs = strconv.AppendInt(b.bs, m/_shortScale, 10)
+	b.bs = append(b.bs, '.')
+	var digits [_maxShortDecimals]byte
+	frac := m % _shortScale
+	for i := len(digits) - 1; i >= 0; i-- {
+		digits[i] = '0' + byte(frac%10)
+		frac /= 10
+	}
+	n := len(digits)
+	for digits[n-1] == '0' {
+		n--
+	}
+	b.bs = append(b.bs, digits[:n]...)
+}
+
 // Len returns the length of the underlying byte slice.
 func (b *Buffer) Len() int {
 	return len(b.bs)
@@ -170,6 +354,12 @@ func (b *Buffer) Reset() {
 	b.bs = b.bs[:0]
 }
 
+// Truncate discards all but the first n bytes of the buffer. n must be
+// between zero and the buffer's length.
+func (b *Buffer) Truncate(n int) {
+	b.bs = b.bs[:n]
+}
+
 // Write implements io.Writer.
 func (b *Buffer) Write(bs []byte) (int, error) {
 	b.bs = append(b.bs, bs...)
@@ -194,6 +384,13 @@ func (b *Buffer) WriteString(s string) (int, error) {
 	return len(s), nil
 }
 
+// WriteTo implements io.WriterTo, writing the contents of the Buffer to w
+// without copying them.
+func (b *Buffer) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(b.bs)
+	return int64(n), err
+}
+
 // TrimNewline trims any final "\n" byte from the end of the buffer.
 func (b *Buffer) TrimNewline() {
 	if i := len(b.bs) - 1; i >= 0 {
@@ -22,6 +22,9 @@ package buffer
 
 import (
 	"bytes"
+	"math"
+	"math/rand"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -46,8 +49,28 @@ func TestBufferWrites(t *testing.T) {
 		{"AppendFloat64", func() { buf.AppendFloat(3.14, 64) }, "3.14"},
 		// Intentionally introduce some floating-point error.
 		{"AppendFloat32", func() { buf.AppendFloat(float64(float32(3.14)), 32) }, "3.14"},
+		{"AppendFloatIntegral", func() { buf.AppendFloat(-42, 64) }, "-42"},
+		{"AppendFloatNegativeZero", func() { buf.AppendFloat(math.Copysign(0, -1), 64) }, "-0"},
+		{"AppendFloatLarge", func() { buf.AppendFloat(1e20, 64) }, "100000000000000000000"},
+		{"AppendFloatFraction", func() { buf.AppendFloat(-0.05, 64) }, "-0.05"},
+		{"AppendFloatLongFraction", func() { buf.AppendFloat(math.Pi, 64) }, "3.141592653589793"},
+		{"AppendFloatFormatExponent", func() { buf.AppendFloatFormat(1e20, 'e', -1, 64) }, "1e+20"},
+		{"AppendFloatFormatPrecision", func() { buf.AppendFloatFormat(3.14159, 'f', 2, 64) }, "3.14"},
+		{"AppendFloatFormatIntegral", func() { buf.AppendFloatFormat(7, 'f', -1, 64) }, "7"},
 		{"AppendWrite", func() { buf.Write([]byte("foo")) }, "foo"},
 		{"AppendTime", func() { buf.AppendTime(time.Date(2000, 1, 2, 3, 4, 5, 6, time.UTC), time.RFC3339) }, "2000-01-02T03:04:05Z"},
+		{"AppendDurationSeconds", func() { buf.AppendDuration(time.Second+500*time.Nanosecond, time.Second) }, "1.0000005"},
+		{"AppendDurationWhole", func() { buf.AppendDuration(3*time.Millisecond, time.Millisecond) }, "3"},
+		{"AppendDurationNegative", func() { buf.AppendDuration(-1500*time.Microsecond, time.Millisecond) }, "-1.5"},
+		{"AppendDurationMin", func() { buf.AppendDuration(math.MinInt64, time.Second) }, "-9223372036.854775808"},
+		{"AppendDurationNanos", func() { buf.AppendDuration(42, 0) }, "42"},
+		{"AppendDurationMinutes", func() { buf.AppendDuration(90*time.Second, time.Minute) }, "1.5"},
+		{"AppendTimeUnixSeconds", func() { buf.AppendTimeUnix(time.Unix(100, 50005000), time.Second) }, "100.050005"},
+		{"AppendTimeUnixMillis", func() { buf.AppendTimeUnix(time.Unix(1700000000, 123456789), time.Millisecond) }, "1700000000123.456789"},
+		{"AppendTimeUnixNanos", func() { buf.AppendTimeUnix(time.Unix(1, 2), time.Nanosecond) }, "1000000002"},
+		{"AppendTimeUnixBeforeEpoch", func() { buf.AppendTimeUnix(time.Unix(-2, 500000000), time.Second) }, "-1.5"},
+		{"AppendTimeUnixJustBeforeEpoch", func() { buf.AppendTimeUnix(time.Unix(-1, 999000000), time.Millisecond) }, "-1"},
+		{"AppendTimeUnixFarFuture", func() { buf.AppendTimeUnix(time.Unix(1<<40, 0), time.Nanosecond) }, "1099511627776000000000"},
 		{"WriteByte", func() { buf.WriteByte('v') }, "v"},
 		{"WriteString", func() { buf.WriteString("foo") }, "foo"},
 	}
@@ -65,6 +88,85 @@ func TestBufferWrites(t *testing.T) {
 	}
 }
 
+func TestBufferWriteTo(t *testing.T) {
+	buf := NewPool().Get()
+	defer buf.Free()
+	buf.AppendString("foo")
+
+	var out bytes.Buffer
+	n, err := buf.WriteTo(&out)
+	assert.NoError(t, err, "Unexpected error from WriteTo.")
+	assert.Equal(t, int64(3), n, "Unexpected number of bytes written.")
+	assert.Equal(t, "foo", out.String(), "Unexpected output.")
+	assert.Equal(t, "foo", buf.String(), "WriteTo shouldn't consume the buffer.")
+}
+
+func TestBufferTruncate(t *testing.T) {
+	buf := NewPool().Get()
+	defer buf.Free()
+	buf.AppendString("foobar")
+
+	buf.Truncate(3)
+	assert.Equal(t, "foo", buf.String(), "Unexpected buffer contents after Truncate.")
+	buf.AppendString("baz")
+	assert.Equal(t, "foobaz", buf.String(), "Unexpected buffer contents after appending.")
+	buf.Truncate(0)
+	assert.Equal(t, 0, buf.Len(), "Expected Truncate(0) to empty the buffer.")
+}
+
+func TestBufferAppendFloatMatchesStrconv(t *testing.T) {
+	values := []float64{
+		0.5, -0.5, 0.1, 0.001, math.Nextafter(0.3, 1), 0.000001, 0.0000001, 1.25, 99.99, 123456.789012,
+		999999999.999999, -999999999.5, 1e9 + 0.5, math.Pi, math.SmallestNonzeroFloat64,
+		math.MaxFloat64, math.Inf(1), math.Inf(-1), math.NaN(),
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		// Decimals with a few places, which take the fast path.
+		values = append(values, float64(r.Int63n(2e12)-1e12)/math.Pow10(r.Intn(8)))
+		// Arbitrary values, which mostly don't.
+		values = append(values, math.Float64frombits(r.Uint64()))
+	}
+
+	buf := NewPool().Get()
+	defer buf.Free()
+	for _, f := range values {
+		buf.Reset()
+		buf.AppendFloat(f, 64)
+		if !assert.Equal(t, strconv.FormatFloat(f, 'f', -1, 64), buf.String(), "Unexpected encoding of %v.", f) {
+			break
+		}
+	}
+}
+
+func BenchmarkAppendFloat(b *testing.B) {
+	tests := []struct {
+		desc string
+		f    float64
+	}{
+		{"Integral", 1024},
+		{"Fraction", 12.375},
+		{"SmallFraction", 0.004},
+		{"LongFraction", math.Pi},
+	}
+	buf := NewPool().Get()
+	defer buf.Free()
+	for _, tt := range tests {
+		b.Run(tt.desc, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				buf.AppendFloat(tt.f, 64)
+				buf.Reset()
+			}
+		})
+		b.Run(tt.desc+"/strconv", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				buf.bs = strconv.AppendFloat(buf.bs, tt.f, 'f', -1, 64)
+				buf.Reset()
+			}
+		})
+	}
+}
+
 func BenchmarkBuffers(b *testing.B) {
 	// Because we use the strconv.AppendFoo functions so liberally, we can't
 	// use the standard library's bytes.Buffer anyways (without incurring a
@@ -0,0 +1,154 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package buffer
+
+import "unicode/utf8"
+
+const _hex = "0123456789abcdef"
+
+// _jsonSafe reports, for each ASCII byte, whether it may appear unescaped in
+// a JSON string.
+var _jsonSafe = func() (safe [utf8.RuneSelf]bool) {
+	for c := 0x20; c < utf8.RuneSelf; c++ {
+		safe[c] = c != '"' && c != '\\'
+	}
+	return safe
+}()
+
+// AppendJSONString appends s to the Buffer, escaped for use inside a JSON
+// string; it doesn't add the surrounding quotes. Invalid UTF-8 is replaced
+// with the Unicode replacement character. Unlike the standard library's
+// encoder, it doesn't escape HTML characters or attempt to protect the user
+// from JSONP-related problems.
+//
+// Runs of characters that need no escaping, the common case in logs, are
+// found eight bytes at a time and copied as-is.
+func (b *Buffer) AppendJSONString(s string) {
+	appendJSONEscaped(b, s, utf8.DecodeRuneInString, false)
+}
+
+// AppendJSONBytes is a no-alloc equivalent of AppendJSONString(string(s)).
+func (b *Buffer) AppendJSONBytes(s []byte) {
+	appendJSONEscaped(b, s, utf8.DecodeRune, false)
+}
+
+// AppendJSONStringEscapingInvalid is like AppendJSONString, but writes each
+// byte of invalid UTF-8 as the text \xHH, so that the original bytes can be
+// recovered from the decoded string.
+func (b *Buffer) AppendJSONStringEscapingInvalid(s string) {
+	appendJSONEscaped(b, s, utf8.DecodeRuneInString, true)
+}
+
+// AppendJSONBytesEscapingInvalid is a no-alloc equivalent of
+// AppendJSONStringEscapingInvalid(string(s)).
+func (b *Buffer) AppendJSONBytesEscapingInvalid(s []byte) {
+	appendJSONEscaped(b, s, utf8.DecodeRune, true)
+}
+
+// appendJSONEscaped is the implementation of AppendJSONString and
+// AppendJSONBytes. decodeRune decodes the next rune from a string-like
+// value, returning its value and width in bytes. If hexInvalid is set,
+// invalid UTF-8 is written as hex escapes rather than replaced.
+func appendJSONEscaped[S []byte | string](b *Buffer, s S, decodeRune func(S) (rune, int), hexInvalid bool) {
+	// Skip over characters that can be copied as-is until one needs special
+	// handling, then copy everything seen so far and handle that character.
+	//
+	// last is the index of the first byte not yet copied to the buffer.
+	last := 0
+	for i := 0; i < len(s); {
+		for i+8 <= len(s) && !needsEscape(load64(s, i)) {
+			i += 8
+		}
+		if i == len(s) {
+			break
+		}
+
+		c := s[i]
+		if c < utf8.RuneSelf {
+			if _jsonSafe[c] {
+				i++
+				continue
+			}
+
+			b.bs = append(b.bs, s[last:i]...)
+			switch c {
+			case '\\', '"':
+				b.bs = append(b.bs, '\\', c)
+			case '\n':
+				b.bs = append(b.bs, '\\', 'n')
+			case '\r':
+				b.bs = append(b.bs, '\\', 'r')
+			case '\t':
+				b.bs = append(b.bs, '\\', 't')
+			default:
+				// Encode bytes < 0x20, except for the escape sequences above.
+				b.bs = append(b.bs, '\\', 'u', '0', '0', _hex[c>>4], _hex[c&0xF])
+			}
+			i++
+			last = i
+			continue
+		}
+
+		// Bytes >= RuneSelf may be part of a multi-byte rune, so they need
+		// to be decoded before we can decide how to handle them.
+		r, size := decodeRune(s[i:])
+		if r != utf8.RuneError || size != 1 {
+			i += size
+			continue
+		}
+
+		// Invalid UTF-8 sequence.
+		b.bs = append(b.bs, s[last:i]...)
+		if hexInvalid {
+			b.bs = append(b.bs, '\\', '\\', 'x', _hex[s[i]>>4], _hex[s[i]&0xF])
+		} else {
+			b.bs = append(b.bs, `\ufffd`...)
+		}
+		i++
+		last = i
+	}
+	b.bs = append(b.bs, s[last:]...)
+}
+
+// load64 reads the eight bytes of s starting at i as a little-endian word.
+// The compiler merges the byte loads into a single load.
+func load64[S []byte | string](s S, i int) uint64 {
+	_ = s[i+7] // bounds check hint
+	return uint64(s[i]) | uint64(s[i+1])<<8 | uint64(s[i+2])<<16 |
+		uint64(s[i+3])<<24 | uint64(s[i+4])<<32 | uint64(s[i+5])<<40 |
+		uint64(s[i+6])<<48 | uint64(s[i+7])<<56
+}
+
+// needsEscape reports whether any of the eight bytes packed in x is a
+// control character, a quote, a backslash, or not ASCII. It uses the
+// classic SWAR tricks for finding bytes below a bound and zero bytes.
+func needsEscape(x uint64) bool {
+	const (
+		lo = 0x0101010101010101
+		hi = 0x8080808080808080
+	)
+	quote := x ^ (lo * '"')
+	backslash := x ^ (lo * '\\')
+	control := (x - lo*0x20) & ^x
+	quotes := (quote - lo) & ^quote
+	backslashes := (backslash - lo) & ^backslash
+	return (control|quotes|backslashes|x)&hi != 0
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package buffer
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendJSONString(t *testing.T) {
+	tests := []struct {
+		give string
+		want string
+	}{
+		{"", ""},
+		{"plain", "plain"},
+		{`"`, `\"`},
+		{`\`, `\\`},
+		{"\n\r\t", `\n\r\t`},
+		{"\x00\x07\x1f", `\u0000\u0007\u001f`},
+		{"<>&", "<>&"},
+		{"\x7f", "\x7f"},
+		{"☃", "☃"},
+		{"\xed\xa0\x80", `\ufffd\ufffd\ufffd`},
+		{"foo\xffbar", `foo\ufffdbar`},
+		{"exactly8", "exactly8"},
+		{"sixteen bytes ok", "sixteen bytes ok"},
+		{"eight ok\nthen a newline", `eight ok\nthen a newline`},
+		{"long prefix before a ☃ and a \"quote\"", `long prefix before a ☃ and a \"quote\"`},
+	}
+
+	for _, tt := range tests {
+		buf := NewPool().Get()
+		buf.AppendJSONString(tt.give)
+		assert.Equal(t, tt.want, buf.String(), "Unexpected output from AppendJSONString(%q).", tt.give)
+
+		buf.Reset()
+		buf.AppendJSONBytes([]byte(tt.give))
+		assert.Equal(t, tt.want, buf.String(), "Unexpected output from AppendJSONBytes(%q).", tt.give)
+		buf.Free()
+	}
+}
+
+func TestAppendJSONStringEscapingInvalid(t *testing.T) {
+	tests := []struct {
+		give string
+		want string
+	}{
+		{"plain", "plain"},
+		{"☃\n", `☃\n`},
+		{"foo\xffbar", `foo\\xffbar`},
+		{"\xed\xa0\x80", `\\xed\\xa0\\x80`},
+	}
+
+	for _, tt := range tests {
+		buf := NewPool().Get()
+		buf.AppendJSONStringEscapingInvalid(tt.give)
+		assert.Equal(t, tt.want, buf.String(), "Unexpected output from AppendJSONStringEscapingInvalid(%q).", tt.give)
+
+		buf.Reset()
+		buf.AppendJSONBytesEscapingInvalid([]byte(tt.give))
+		assert.Equal(t, tt.want, buf.String(), "Unexpected output from AppendJSONBytesEscapingInvalid(%q).", tt.give)
+		buf.Free()
+	}
+}
+
+func TestAppendJSONStringEveryOffset(t *testing.T) {
+	// Put each special byte at every position of a string long enough for
+	// several eight-byte words, so both the word scan and the byte loop see
+	// it.
+	for _, special := range []string{"\"", "\\", "\n", "\x01", "\x1f", "é"} {
+		for i := 0; i < 24; i++ {
+			s := strings.Repeat("a", i) + special + strings.Repeat("b", 24-i)
+			buf := NewPool().Get()
+			buf.AppendByte('"')
+			buf.AppendJSONString(s)
+			buf.AppendByte('"')
+
+			var decoded string
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded), "Invalid JSON for %q.", s)
+			assert.Equal(t, s, decoded, "Unexpected round trip.")
+			buf.Free()
+		}
+	}
+}
+
+func TestNeedsEscape(t *testing.T) {
+	word := func(s string) uint64 { return load64(s, 0) }
+
+	assert.False(t, needsEscape(word("abcdefgh")), "Plain ASCII needs no escaping.")
+	assert.False(t, needsEscape(word(" ~!#$%&'")), "Printable ASCII needs no escaping.")
+	for c := 0; c < 256; c++ {
+		want := c < 0x20 || c == '"' || c == '\\' || c >= 0x80
+		for i := 0; i < 8; i++ {
+			bs := []byte("abcdefgh")
+			bs[i] = byte(c)
+			assert.Equal(t, want, needsEscape(word(string(bs))), "Unexpected result for byte %#x at %d.", c, i)
+		}
+	}
+}
+
+func BenchmarkAppendJSONString(b *testing.B) {
+	inputs := map[string]string{
+		"short":      "hello",
+		"plain":      "GET /api/v1/users/12345/profile completed in 12ms with status 200",
+		"escapes":    "line one\nline two\t\"quoted\" and a \\ backslash",
+		"multi-byte": "ログメッセージ with some non-ASCII ☃ characters",
+	}
+	for name, s := range inputs {
+		b.Run(name, func(b *testing.B) {
+			buf := NewPool().Get()
+			b.SetBytes(int64(len(s)))
+			for i := 0; i < b.N; i++ {
+				buf.Reset()
+				buf.AppendJSONString(s)
+			}
+		})
+	}
+}
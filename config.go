@@ -22,7 +22,8 @@ package zap
 
 import (
 	"errors"
-	"sort"
+	"fmt"
+	"math"
 	"time"
 
 	"go.uber.org/zap/zapcore"
@@ -72,13 +73,22 @@ type Config struct {
 	DisableStacktrace bool `json:"disableStacktrace" yaml:"disableStacktrace"`
 	// Sampling sets a sampling policy. A nil SamplingConfig disables sampling.
 	Sampling *SamplingConfig `json:"sampling" yaml:"sampling"`
-	// Encoding sets the logger's encoding. Valid values are "json" and
-	// "console", as well as any third-party encodings registered via
-	// RegisterEncoder.
+	// Encoding sets the logger's encoding. Valid values are "json",
+	// "console", "cbor", "gelf", and "ecs", as well as any third-party
+	// encodings registered via RegisterEncoder. The "gelf" encoding accepts a host
+	// option in EncoderOptions.
 	Encoding string `json:"encoding" yaml:"encoding"`
 	// EncoderConfig sets options for the chosen encoder. See
 	// zapcore.EncoderConfig for details.
 	EncoderConfig zapcore.EncoderConfig `json:"encoderConfig" yaml:"encoderConfig"`
+	// EncoderOptions sets options specific to the chosen encoder, if it was
+	// registered with RegisterEncoderWithOptions. The built-in encoders don't
+	// accept any.
+	EncoderOptions EncoderOptions `json:"encoderOptions" yaml:"encoderOptions"`
+	// MaxEntryBytes, if positive, limits the size of encoded entries, which
+	// some log backends reject when they're too large. Oversized entries are
+	// trimmed as described by zapcore.MaxEntryBytes.
+	MaxEntryBytes int `json:"maxEntryBytes" yaml:"maxEntryBytes"`
 	// OutputPaths is a list of URLs or file paths to write logging output to.
 	// See Open for details.
 	OutputPaths []string `json:"outputPaths" yaml:"outputPaths"`
@@ -90,7 +100,117 @@ type Config struct {
 	// logs, see the package-level AdvancedConfiguration example.
 	ErrorOutputPaths []string `json:"errorOutputPaths" yaml:"errorOutputPaths"`
 	// InitialFields is a collection of fields to add to the root logger.
+	// Nested objects and arrays are logged as objects and arrays, and
+	// integral numbers as integers. A key may end in a type hint, as in
+	// "port:int" or "timeout:duration", to convert its value (or the
+	// elements of an array value) from a string; the supported hints are
+	// string, int, float, bool, duration, and time (RFC 3339). The hint is
+	// dropped from the logged key.
 	InitialFields map[string]interface{} `json:"initialFields" yaml:"initialFields"`
+	// Outputs lists destinations that each have their own encoding and
+	// level range. If any are specified, the logger writes to every one of
+	// them and OutputPaths is ignored.
+	Outputs []OutputConfig `json:"outputs" yaml:"outputs"`
+	// CollectStats makes the logger maintain the counters returned by
+	// Logger.Stats, including the bytes written to its outputs and the
+	// entries dropped by sampling. Counting costs an atomic operation per
+	// write, so it's off by default.
+	CollectStats bool `json:"collectStats" yaml:"collectStats"`
+}
+
+// OutputConfig configures one destination of a logger built from a Config.
+// Unset fields inherit their values from the enclosing Config.
+//
+// For example, the following writes JSON to a file, human-readable output
+// to standard out, and errors to standard error:
+//
+//	outputs:
+//	  - paths: [/var/log/app.log]
+//	    encoding: json
+//	  - paths: [stdout]
+//	    encoding: console
+//	    maxLevel: warn
+//	  - paths: [stderr]
+//	    encoding: console
+//	    minLevel: error
+type OutputConfig struct {
+	// Paths is a list of URLs or file paths to write to. See Open for
+	// details.
+	Paths []string `json:"paths" yaml:"paths"`
+	// Encoding overrides Config.Encoding for this output.
+	Encoding string `json:"encoding" yaml:"encoding"`
+	// MinLevel and MaxLevel restrict this output to entries within the given
+	// range, inclusive. Entries must also be enabled by Config.Level, so
+	// MinLevel can only raise the minimum level of the logger.
+	MinLevel *zapcore.Level `json:"minLevel" yaml:"minLevel"`
+	MaxLevel *zapcore.Level `json:"maxLevel" yaml:"maxLevel"`
+	// Levels further restricts this output to the levels enabled by an
+	// expression such as "warn" or "debug..error & !info". See
+	// zapcore.ParseLevelEnabler for the syntax.
+	Levels string `json:"levels" yaml:"levels"`
+	// EncoderConfig replaces Config.EncoderConfig for this output.
+	EncoderConfig *zapcore.EncoderConfig `json:"encoderConfig" yaml:"encoderConfig"`
+	// EncoderOptions, if not nil, replaces Config.EncoderOptions for this
+	// output.
+	EncoderOptions EncoderOptions `json:"encoderOptions" yaml:"encoderOptions"`
+	// MaxEntryBytes, if positive, replaces Config.MaxEntryBytes for this
+	// output.
+	MaxEntryBytes int `json:"maxEntryBytes" yaml:"maxEntryBytes"`
+}
+
+// build builds the core for a single output. lvl is the level of the
+// enclosing Config.
+func (out OutputConfig) build(cfg Config, lvl zapcore.LevelEnabler, stats *statsCounter) (zapcore.Core, func(), error) {
+	if out.Encoding != "" {
+		cfg.Encoding = out.Encoding
+	}
+	if out.EncoderConfig != nil {
+		cfg.EncoderConfig = *out.EncoderConfig
+	}
+	if out.EncoderOptions != nil {
+		cfg.EncoderOptions = out.EncoderOptions
+	}
+	if out.MaxEntryBytes > 0 {
+		cfg.MaxEntryBytes = out.MaxEntryBytes
+	}
+	enab, err := out.levelEnabler(lvl)
+	if err != nil {
+		return nil, nil, err
+	}
+	enc, err := cfg.buildEncoder()
+	if err != nil {
+		return nil, nil, err
+	}
+	sink, closeOut, err := Open(out.Paths...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return zapcore.NewCore(enc, stats.countBytes(sink), enab), closeOut, nil
+}
+
+func (out OutputConfig) levelEnabler(lvl zapcore.LevelEnabler) (zapcore.LevelEnabler, error) {
+	enabs := []zapcore.LevelEnabler{lvl}
+	if out.MinLevel != nil || out.MaxLevel != nil {
+		min, max := zapcore.Level(math.MinInt8), zapcore.Level(math.MaxInt8)
+		if out.MinLevel != nil {
+			min = *out.MinLevel
+		}
+		if out.MaxLevel != nil {
+			max = *out.MaxLevel
+		}
+		enabs = append(enabs, zapcore.LevelRange(min, max))
+	}
+	if out.Levels != "" {
+		enab, err := zapcore.ParseLevelEnabler(out.Levels)
+		if err != nil {
+			return nil, err
+		}
+		enabs = append(enabs, enab)
+	}
+	if len(enabs) == 1 {
+		return lvl, nil
+	}
+	return zapcore.AndEnablers(enabs...), nil
 }
 
 // NewProductionEncoderConfig returns an opinionated EncoderConfig for
@@ -105,6 +225,8 @@ type Config struct {
 //   - "caller": If available, a short path to the file and line number
 //     where the log statement was issued.
 //     The logger configuration determines whether this field is captured.
+//   - "eventID": If set, the event ID assigned to the log statement
+//     (see Logger.WithEventID).
 //   - "stacktrace": If available, a stack trace from the line
 //     where the log statement was issued.
 //     The logger configuration determines whether this field is captured.
@@ -128,6 +250,7 @@ func NewProductionEncoderConfig() zapcore.EncoderConfig {
 		NameKey:        "logger",
 		CallerKey:      "caller",
 		FunctionKey:    zapcore.OmitKey,
+		EventIDKey:     "eventID",
 		MessageKey:     "msg",
 		StacktraceKey:  "stacktrace",
 		LineEnding:     zapcore.DefaultLineEnding,
@@ -138,6 +261,33 @@ func NewProductionEncoderConfig() zapcore.EncoderConfig {
 	}
 }
 
+// NewECSEncoderConfig returns an encoder configuration that uses Elastic
+// Common Schema (ECS) keys: the time is written as @timestamp in ISO8601
+// format, the level as log.level, the logger name as log.logger, the event
+// ID as event.code, and the message as message. Durations are written in nanoseconds, as ECS's
+// event.duration expects.
+//
+// Use it with the "ecs" encoding (see [zapcore.NewECSEncoder]) to also
+// split the caller into ECS fields and map errors and well-known field keys
+// into the ECS namespace.
+func NewECSEncoderConfig() zapcore.EncoderConfig {
+	return zapcore.EncoderConfig{
+		TimeKey:        "@timestamp",
+		LevelKey:       "log.level",
+		NameKey:        "log.logger",
+		CallerKey:      "log.origin.file.name",
+		FunctionKey:    zapcore.OmitKey,
+		EventIDKey:     "event.code",
+		MessageKey:     "message",
+		StacktraceKey:  "log.origin.stack_trace",
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeLevel:    zapcore.LowercaseLevelEncoder,
+		EncodeTime:     zapcore.TimeEncoderOfLayout("2006-01-02T15:04:05.000Z07:00"),
+		EncodeDuration: zapcore.NanosDurationEncoder,
+		EncodeCaller:   zapcore.ShortCallerEncoder,
+	}
+}
+
 // NewProductionConfig builds a reasonable default production logging
 // configuration.
 // Logging is enabled at InfoLevel and above, and uses a JSON encoder.
@@ -178,6 +328,7 @@ func NewProductionConfig() Config {
 //
 //   - The log level (e.g. "INFO", "ERROR").
 //   - The time in ISO8601 format (e.g. "2017-01-01T12:00:00Z").
+//   - If set, the event ID assigned to the log statement.
 //   - The message passed to the log statement.
 //   - If available, a short path to the file and line number
 //     where the log statement was issued.
@@ -205,6 +356,7 @@ func NewDevelopmentEncoderConfig() zapcore.EncoderConfig {
 		NameKey:        "N",
 		CallerKey:      "C",
 		FunctionKey:    zapcore.OmitKey,
+		EventIDKey:     "E",
 		MessageKey:     "M",
 		StacktraceKey:  "S",
 		LineEnding:     zapcore.DefaultLineEnding,
@@ -237,13 +389,23 @@ func NewDevelopmentConfig() Config {
 
 // Build constructs a logger from the Config and Options.
 func (cfg Config) Build(opts ...Option) (*Logger, error) {
-	enc, err := cfg.buildEncoder()
+	fields, err := cfg.initialFields()
 	if err != nil {
 		return nil, err
 	}
 
-	sink, errSink, err := cfg.openSinks()
+	var stats *statsCounter
+	if cfg.CollectStats {
+		stats = new(statsCounter)
+	}
+	core, closeOut, err := cfg.buildCore(cfg.Level, stats)
+	if err != nil {
+		return nil, err
+	}
+
+	errSink, _, err := Open(cfg.ErrorOutputPaths...)
 	if err != nil {
+		closeOut()
 		return nil, err
 	}
 
@@ -252,8 +414,8 @@ func (cfg Config) Build(opts ...Option) (*Logger, error) {
 	}
 
 	log := New(
-		zapcore.NewCore(enc, sink, cfg.Level),
-		cfg.buildOptions(errSink)...,
+		core,
+		cfg.buildOptions(errSink, stats, fields)...,
 	)
 	if len(opts) > 0 {
 		log = log.WithOptions(opts...)
@@ -261,7 +423,28 @@ func (cfg Config) Build(opts ...Option) (*Logger, error) {
 	return log, nil
 }
 
-func (cfg Config) buildOptions(errSink zapcore.WriteSyncer) []Option {
+func (cfg Config) buildOptions(errSink zapcore.WriteSyncer, stats *statsCounter, fields []Field) []Option {
+	opts := cfg.loggerOptions(errSink)
+	if stats != nil {
+		opts = append(opts, withStats(stats))
+	}
+
+	if cfg.Sampling != nil {
+		opts = append(opts, WrapCore(func(core zapcore.Core) zapcore.Core {
+			return cfg.wrapSampler(core, stats)
+		}))
+	}
+
+	if len(fields) > 0 {
+		opts = append(opts, Fields(fields...))
+	}
+
+	return opts
+}
+
+// loggerOptions returns the options that configure the Logger itself, as
+// opposed to its core.
+func (cfg Config) loggerOptions(errSink zapcore.WriteSyncer) []Option {
 	opts := []Option{ErrorOutput(errSink)}
 
 	if cfg.Development {
@@ -280,51 +463,68 @@ func (cfg Config) buildOptions(errSink zapcore.WriteSyncer) []Option {
 		opts = append(opts, AddStacktrace(stackLevel))
 	}
 
-	if scfg := cfg.Sampling; scfg != nil {
-		opts = append(opts, WrapCore(func(core zapcore.Core) zapcore.Core {
-			var samplerOpts []zapcore.SamplerOption
-			if scfg.Hook != nil {
-				samplerOpts = append(samplerOpts, zapcore.SamplerHook(scfg.Hook))
-			}
-			return zapcore.NewSamplerWithOptions(
-				core,
-				time.Second,
-				cfg.Sampling.Initial,
-				cfg.Sampling.Thereafter,
-				samplerOpts...,
-			)
-		}))
+	return opts
+}
+
+// wrapSampler wraps core with the configured sampling policy, if any. If
+// stats is non-nil, dropped entries are counted into it.
+func (cfg Config) wrapSampler(core zapcore.Core, stats *statsCounter) zapcore.Core {
+	scfg := cfg.Sampling
+	if scfg == nil {
+		return core
+	}
+	var samplerOpts []zapcore.SamplerOption
+	if hook := stats.samplerHook(scfg.Hook); hook != nil {
+		samplerOpts = append(samplerOpts, zapcore.SamplerHook(hook))
 	}
+	return zapcore.NewSamplerWithOptions(
+		core,
+		time.Second,
+		scfg.Initial,
+		scfg.Thereafter,
+		samplerOpts...,
+	)
+}
 
-	if len(cfg.InitialFields) > 0 {
-		fs := make([]Field, 0, len(cfg.InitialFields))
-		keys := make([]string, 0, len(cfg.InitialFields))
-		for k := range cfg.InitialFields {
-			keys = append(keys, k)
+// buildCore builds a core that writes to OutputPaths, or to every entry of
+// Outputs if there are any, gated by lvl. The returned function closes the
+// opened sinks.
+func (cfg Config) buildCore(lvl zapcore.LevelEnabler, stats *statsCounter) (zapcore.Core, func(), error) {
+	if len(cfg.Outputs) == 0 {
+		enc, err := cfg.buildEncoder()
+		if err != nil {
+			return nil, nil, err
 		}
-		sort.Strings(keys)
-		for _, k := range keys {
-			fs = append(fs, Any(k, cfg.InitialFields[k]))
+		sink, closeOut, err := Open(cfg.OutputPaths...)
+		if err != nil {
+			return nil, nil, err
 		}
-		opts = append(opts, Fields(fs...))
+		return zapcore.NewCore(enc, stats.countBytes(sink), lvl), closeOut, nil
 	}
 
-	return opts
-}
-
-func (cfg Config) openSinks() (zapcore.WriteSyncer, zapcore.WriteSyncer, error) {
-	sink, closeOut, err := Open(cfg.OutputPaths...)
-	if err != nil {
-		return nil, nil, err
+	cores := make([]zapcore.Core, 0, len(cfg.Outputs))
+	closers := make([]func(), 0, len(cfg.Outputs))
+	closeAll := func() {
+		for _, c := range closers {
+			c()
+		}
 	}
-	errSink, _, err := Open(cfg.ErrorOutputPaths...)
-	if err != nil {
-		closeOut()
-		return nil, nil, err
+	for i, out := range cfg.Outputs {
+		core, closeOut, err := out.build(cfg, lvl, stats)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("outputs[%d]: %w", i, err)
+		}
+		cores = append(cores, core)
+		closers = append(closers, closeOut)
 	}
-	return sink, errSink, nil
+	return zapcore.NewTee(cores...), closeAll, nil
 }
 
 func (cfg Config) buildEncoder() (zapcore.Encoder, error) {
-	return newEncoder(cfg.Encoding, cfg.EncoderConfig)
+	enc, err := newEncoder(cfg.Encoding, cfg.EncoderConfig, cfg.EncoderOptions)
+	if err != nil || cfg.MaxEntryBytes <= 0 {
+		return enc, err
+	}
+	return zapcore.MaxEntryBytes(enc, cfg.MaxEntryBytes, nil), nil
 }
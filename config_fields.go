@@ -0,0 +1,279 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// _configTypeHints are the type hints that may follow a key in
+// Config.InitialFields, separated from it by a colon.
+var _configTypeHints = map[string]struct{}{
+	"string":   {},
+	"int":      {},
+	"float":    {},
+	"bool":     {},
+	"duration": {},
+	"time":     {},
+}
+
+// initialFields returns InitialFields as a slice of fields, sorted by key.
+func (cfg Config) initialFields() ([]Field, error) {
+	if len(cfg.InitialFields) == 0 {
+		return nil, nil
+	}
+	obj, err := newConfigObject(cfg.InitialFields, "")
+	if err != nil {
+		return nil, fmt.Errorf("invalid initialFields: %w", err)
+	}
+	fs := make([]Field, len(obj))
+	for i, f := range obj {
+		fs[i] = Any(f.key, f.value)
+	}
+	return fs, nil
+}
+
+// configObject is a nested object from Config.InitialFields, with its
+// values normalized by normalizeConfigValue and sorted by key.
+type configObject []configField
+
+type configField struct {
+	key   string
+	value interface{}
+}
+
+func newConfigObject(m map[string]interface{}, path string) (configObject, error) {
+	obj := make(configObject, 0, len(m))
+	seen := make(map[string]struct{}, len(m))
+	for k, v := range m {
+		key, hint := splitTypeHint(k)
+		fieldPath := key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+		if _, ok := seen[key]; ok {
+			return nil, fmt.Errorf("%v: duplicate key", fieldPath)
+		}
+		seen[key] = struct{}{}
+
+		value, err := normalizeConfigValue(v, hint, fieldPath)
+		if err != nil {
+			return nil, err
+		}
+		obj = append(obj, configField{key: key, value: value})
+	}
+	sort.Slice(obj, func(i, j int) bool { return obj[i].key < obj[j].key })
+	return obj, nil
+}
+
+func (obj configObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, f := range obj {
+		if err := addConfigValue(enc, f.key, f.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// configArray is an array from Config.InitialFields, with its elements
+// normalized by normalizeConfigValue.
+type configArray []interface{}
+
+func (arr configArray) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, v := range arr {
+		if err := appendConfigValue(enc, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitTypeHint splits a key of the form "name:type" into its name and
+// type hint. Keys whose suffix isn't a known type hint are left alone.
+func splitTypeHint(key string) (name, hint string) {
+	i := strings.LastIndexByte(key, ':')
+	if i <= 0 {
+		return key, ""
+	}
+	if _, ok := _configTypeHints[key[i+1:]]; !ok {
+		return key, ""
+	}
+	return key[:i], key[i+1:]
+}
+
+// normalizeConfigValue converts a value decoded from JSON or YAML to the
+// type that it should be logged as. Objects and arrays are converted
+// recursively, integral numbers become int64s, and values with a type hint
+// are converted to that type. Other values are left as they are.
+func normalizeConfigValue(v interface{}, hint, path string) (interface{}, error) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if hint != "" {
+			return nil, fmt.Errorf("%v: can't convert an object to %v", path, hint)
+		}
+		return newConfigObject(v, path)
+	case []interface{}:
+		arr := make(configArray, len(v))
+		for i, e := range v {
+			var err error
+			arr[i], err = normalizeConfigValue(e, hint, path+"["+strconv.Itoa(i)+"]")
+			if err != nil {
+				return nil, err
+			}
+		}
+		return arr, nil
+	}
+
+	if hint != "" {
+		converted, ok := convertConfigValue(v, hint)
+		if !ok {
+			return nil, fmt.Errorf("%v: can't convert %#v to %v", path, v, hint)
+		}
+		return converted, nil
+	}
+	if i, ok := configInt(v); ok {
+		return i, nil
+	}
+	return v, nil
+}
+
+// convertConfigValue converts a scalar to the type named by hint.
+func convertConfigValue(v interface{}, hint string) (interface{}, bool) {
+	s, isString := v.(string)
+	switch hint {
+	case "string":
+		if isString {
+			return s, true
+		}
+		return fmt.Sprint(v), v != nil
+	case "int":
+		if isString {
+			i, err := strconv.ParseInt(s, 10, 64)
+			return i, err == nil
+		}
+		return configInt(v)
+	case "float":
+		if isString {
+			f, err := strconv.ParseFloat(s, 64)
+			return f, err == nil
+		}
+		switch v := v.(type) {
+		case float64:
+			return v, true
+		case int:
+			return float64(v), true
+		}
+	case "bool":
+		if isString {
+			b, err := strconv.ParseBool(s)
+			return b, err == nil
+		}
+		b, ok := v.(bool)
+		return b, ok
+	case "duration":
+		if isString {
+			d, err := time.ParseDuration(s)
+			return d, err == nil
+		}
+	case "time":
+		if isString {
+			t, err := time.Parse(time.RFC3339Nano, s)
+			return t, err == nil
+		}
+		// YAML decodes unquoted timestamps itself.
+		t, ok := v.(time.Time)
+		return t, ok
+	}
+	return nil, false
+}
+
+// configInt reports the value of a decoded number as an int64, if it's
+// integral. JSON decodes all numbers as float64s.
+func configInt(v interface{}) (int64, bool) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint64:
+		return int64(v), v <= math.MaxInt64
+	case float64:
+		if v == math.Trunc(v) && v >= math.MinInt64 && v < math.MaxInt64 {
+			return int64(v), true
+		}
+	}
+	return 0, false
+}
+
+func addConfigValue(enc zapcore.ObjectEncoder, key string, v interface{}) error {
+	switch v := v.(type) {
+	case configObject:
+		return enc.AddObject(key, v)
+	case configArray:
+		return enc.AddArray(key, v)
+	case string:
+		enc.AddString(key, v)
+	case int64:
+		enc.AddInt64(key, v)
+	case float64:
+		enc.AddFloat64(key, v)
+	case bool:
+		enc.AddBool(key, v)
+	case time.Duration:
+		enc.AddDuration(key, v)
+	case time.Time:
+		enc.AddTime(key, v)
+	default:
+		return enc.AddReflected(key, v)
+	}
+	return nil
+}
+
+func appendConfigValue(enc zapcore.ArrayEncoder, v interface{}) error {
+	switch v := v.(type) {
+	case configObject:
+		return enc.AppendObject(v)
+	case configArray:
+		return enc.AppendArray(v)
+	case string:
+		enc.AppendString(v)
+	case int64:
+		enc.AppendInt64(v)
+	case float64:
+		enc.AppendFloat64(v)
+	case bool:
+		enc.AppendBool(v)
+	case time.Duration:
+		enc.AppendDuration(v)
+	case time.Time:
+		enc.AppendTime(v)
+	default:
+		return enc.AppendReflected(v)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadConfig reads a logging configuration from the JSON or YAML file at
+// path. Files with a ".json" extension are decoded as JSON and files with a
+// ".yaml" or ".yml" extension as YAML; for any other extension, the format
+// is detected from the contents. Settings absent from the file take their
+// values from NewProductionConfig.
+//
+// References of the form ${NAME} in OutputPaths, ErrorOutputPaths, the paths
+// of Outputs, and the string values of InitialFields are replaced with the
+// value of the environment variable NAME, or the empty string if it isn't
+// set. This allows the same file to be used across environments:
+//
+//	outputPaths: ["/var/log/${SERVICE}/out.log"]
+//	initialFields:
+//	  region: ${REGION}
+func LoadConfig(path string) (Config, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+	return decodeConfig(path, bs)
+}
+
+// decodeConfig decodes the contents of the configuration file at path and
+// expands environment variable references in it.
+func decodeConfig(path string, bs []byte) (Config, error) {
+	cfg := NewProductionConfig()
+
+	var err error
+	if isYAMLConfig(path, bs) {
+		err = yaml.Unmarshal(bs, &cfg)
+	} else {
+		err = json.Unmarshal(bs, &cfg)
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("decode %v: %w", path, err)
+	}
+
+	cfg.expandEnv(os.Getenv)
+	return cfg, nil
+}
+
+func isYAMLConfig(path string, bs []byte) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return false
+	case ".yaml", ".yml":
+		return true
+	default:
+		// JSON configurations are objects, whereas a YAML document
+		// starting with '{' is a flow mapping that JSON can also decode.
+		return !bytes.HasPrefix(bytes.TrimSpace(bs), []byte("{"))
+	}
+}
+
+// expandEnv replaces ${NAME} references in output paths and initial fields
+// with the result of getenv(NAME).
+func (cfg *Config) expandEnv(getenv func(string) string) {
+	for i, p := range cfg.OutputPaths {
+		cfg.OutputPaths[i] = expandEnvRefs(p, getenv)
+	}
+	for i, p := range cfg.ErrorOutputPaths {
+		cfg.ErrorOutputPaths[i] = expandEnvRefs(p, getenv)
+	}
+	for _, out := range cfg.Outputs {
+		for i, p := range out.Paths {
+			out.Paths[i] = expandEnvRefs(p, getenv)
+		}
+	}
+	for k, v := range cfg.InitialFields {
+		cfg.InitialFields[k] = expandEnvValue(v, getenv)
+	}
+}
+
+// expandEnvValue expands references in strings nested anywhere inside a
+// decoded JSON or YAML value.
+func expandEnvValue(v interface{}, getenv func(string) string) interface{} {
+	switch v := v.(type) {
+	case string:
+		return expandEnvRefs(v, getenv)
+	case []interface{}:
+		for i, e := range v {
+			v[i] = expandEnvValue(e, getenv)
+		}
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = expandEnvValue(e, getenv)
+		}
+	}
+	return v
+}
+
+// expandEnvRefs replaces ${NAME} references in s. Unlike os.ExpandEnv, it
+// leaves bare $NAME alone, since '$' is legal in file paths and URLs.
+func expandEnvRefs(s string, getenv func(string) string) string {
+	if !strings.Contains(s, "${") {
+		return s
+	}
+
+	var sb strings.Builder
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(s[start+2:], '}')
+		if end < 0 {
+			break
+		}
+		sb.WriteString(s[:start])
+		sb.WriteString(getenv(s[start+2 : start+2+end]))
+		s = s[start+2+end+1:]
+	}
+	sb.WriteString(s)
+	return sb.String()
+}
@@ -21,8 +21,10 @@
 package zap
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync/atomic"
 	"testing"
 
@@ -201,3 +203,459 @@ func TestConfigWithSamplingHook(t *testing.T) {
 	assert.Equal(t, int64(expectDropped), dcount.Load())
 	assert.Equal(t, int64(expectSampled), scount.Load())
 }
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("ZAP_TEST_SERVICE", "billing")
+	t.Setenv("ZAP_TEST_REGION", "us-east")
+
+	tests := []struct {
+		desc string
+		name string
+		body string
+	}{
+		{
+			desc: "json",
+			name: "log.json",
+			body: `{
+				"level": "warn",
+				"encoding": "console",
+				"outputPaths": ["/var/log/${ZAP_TEST_SERVICE}/out.log"],
+				"errorOutputPaths": ["stderr"],
+				"initialFields": {"region": "${ZAP_TEST_REGION}", "tags": ["$HOME", "${ZAP_TEST_UNSET}"]}
+			}`,
+		},
+		{
+			desc: "yaml",
+			name: "log.yml",
+			body: `
+level: warn
+encoding: console
+outputPaths: ["/var/log/${ZAP_TEST_SERVICE}/out.log"]
+errorOutputPaths: [stderr]
+initialFields:
+  region: ${ZAP_TEST_REGION}
+  tags: [$HOME, "${ZAP_TEST_UNSET}"]
+`,
+		},
+		{
+			desc: "detected json",
+			name: "log.conf",
+			body: `{"level": "warn", "encoding": "console", "outputPaths": ["/var/log/${ZAP_TEST_SERVICE}/out.log"],
+				"errorOutputPaths": ["stderr"], "initialFields": {"region": "${ZAP_TEST_REGION}", "tags": ["$HOME", ""]}}`,
+		},
+		{
+			desc: "detected yaml",
+			name: "log",
+			body: "level: warn\nencoding: console\noutputPaths: ['/var/log/${ZAP_TEST_SERVICE}/out.log']\n" +
+				"errorOutputPaths: [stderr]\ninitialFields: {region: '${ZAP_TEST_REGION}', tags: [$HOME, '']}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.name)
+			require.NoError(t, os.WriteFile(path, []byte(tt.body), 0o644))
+
+			cfg, err := LoadConfig(path)
+			require.NoError(t, err)
+
+			assert.Equal(t, WarnLevel, cfg.Level.Level())
+			assert.Equal(t, "console", cfg.Encoding)
+			assert.Equal(t, []string{"/var/log/billing/out.log"}, cfg.OutputPaths)
+			assert.Equal(t, []string{"stderr"}, cfg.ErrorOutputPaths)
+			assert.Equal(t, map[string]interface{}{
+				"region": "us-east",
+				"tags":   []interface{}{"$HOME", ""},
+			}, cfg.InitialFields)
+
+			// Unset settings fall back to the production defaults.
+			assert.Equal(t, NewProductionConfig().Sampling, cfg.Sampling)
+			assert.Equal(t, "msg", cfg.EncoderConfig.MessageKey)
+		})
+	}
+}
+
+func TestConfigNestedInitialFields(t *testing.T) {
+	tests := []struct {
+		desc string
+		path string
+		give string
+		want string
+	}{
+		{
+			desc: "json",
+			path: "log.json",
+			give: `{"initialFields": {
+				"service": {"name": "x", "version": "y", "replicas": 3},
+				"tags": ["a", {"b": 1.5}, [true]],
+				"port:int": "${ZAP_TEST_PORT}",
+				"timeout:duration": "1.5s",
+				"ratios:float": ["0.5", 2],
+				"url:with:colons": "u"
+			}}`,
+			want: `{"level":"info","msg":"hello",` +
+				`"port":8080,"ratios":[0.5,2],"service":{"name":"x","replicas":3,"version":"y"},` +
+				`"tags":["a",{"b":1.5},[true]],"timeout":1.5,"url:with:colons":"u"}`,
+		},
+		{
+			desc: "yaml",
+			path: "log.yaml",
+			give: `
+initialFields:
+  service:
+    name: x
+    version: "1.0"
+    build:
+      debug:bool: "true"
+  started:time: 2026-01-02T03:04:05Z
+  big: 12345678901
+`,
+			want: `{"level":"info","msg":"hello","big":12345678901,` +
+				`"service":{"build":{"debug":true},"name":"x","version":"1.0"},"started":1767323045}`,
+		},
+	}
+
+	t.Setenv("ZAP_TEST_PORT", "8080")
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dir := t.TempDir()
+			cfgPath := filepath.Join(dir, tt.path)
+			require.NoError(t, os.WriteFile(cfgPath, []byte(tt.give), 0o644))
+
+			cfg, err := LoadConfig(cfgPath)
+			require.NoError(t, err)
+			out := filepath.Join(dir, "out.log")
+			cfg.OutputPaths = []string{out}
+			cfg.EncoderConfig.TimeKey = ""
+			cfg.DisableCaller = true
+
+			logger, err := cfg.Build()
+			require.NoError(t, err)
+			logger.Info("hello")
+			require.NoError(t, logger.Sync())
+
+			bs, err := os.ReadFile(out)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want+"\n", string(bs), "Unexpected initial fields.")
+		})
+	}
+}
+
+func TestConfigInitialFieldsErrors(t *testing.T) {
+	tests := []struct {
+		give map[string]interface{}
+		want string
+	}{
+		{
+			give: map[string]interface{}{"a": map[string]interface{}{"port:int": "eighty"}},
+			want: `a.port: can't convert "eighty" to int`,
+		},
+		{
+			give: map[string]interface{}{"a:duration": []interface{}{"1s", 2.0}},
+			want: `a[1]: can't convert 2 to duration`,
+		},
+		{
+			give: map[string]interface{}{"a:string": map[string]interface{}{}},
+			want: `a: can't convert an object to string`,
+		},
+		{
+			give: map[string]interface{}{"a": 1.0, "a:int": "2"},
+			want: `a: duplicate key`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			cfg := NewProductionConfig()
+			cfg.InitialFields = tt.give
+			_, err := cfg.Build()
+			require.Error(t, err)
+			assert.Equal(t, "invalid initialFields: "+tt.want, err.Error(), "Unexpected error.")
+		})
+	}
+}
+
+func TestConfigEncoderOptions(t *testing.T) {
+	testEncoders(func() {
+		var got []EncoderOptions
+		require.NoError(t, RegisterEncoderWithOptions("opts", func(cfg zapcore.EncoderConfig, opts EncoderOptions) (zapcore.Encoder, error) {
+			got = append(got, opts)
+			return zapcore.NewJSONEncoder(cfg), nil
+		}))
+
+		path := filepath.Join(t.TempDir(), "log.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`
+encoding: opts
+encoderOptions:
+  indent: 2
+  names: {info: INF}
+outputs:
+  - paths: [stdout]
+  - paths: [stderr]
+    encoderOptions: {indent: 4}
+`), 0o644))
+
+		cfg, err := LoadConfig(path)
+		require.NoError(t, err)
+		_, err = cfg.Build()
+		require.NoError(t, err)
+
+		assert.Equal(t, []EncoderOptions{
+			{"indent": 2, "names": EncoderOptions{"info": "INF"}},
+			{"indent": 4},
+		}, got, "Unexpected encoder options.")
+	})
+}
+
+func TestConfigReflectedEncoder(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out.log")
+	cfgPath := filepath.Join(dir, "log.yaml")
+	require.NoError(t, os.WriteFile(cfgPath, []byte(`
+encoding: json
+encoderConfig:
+  messageKey: msg
+  reflectedEncoder: strict
+outputPaths: ["${ZAP_TEST_OUT}"]
+`), 0o644))
+	t.Setenv("ZAP_TEST_OUT", out)
+
+	cfg, err := LoadConfig(cfgPath)
+	require.NoError(t, err)
+	logger, err := cfg.Build()
+	require.NoError(t, err)
+	logger.Info("hello", Reflect("obj", struct{ A int }{1}))
+	require.NoError(t, logger.Sync())
+
+	bs, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Contains(t, string(bs), `"msg":"hello","objError":"reflected values are disabled"}`,
+		"Expected reflection to be disabled.")
+
+	require.NoError(t, os.WriteFile(cfgPath, []byte("encoderConfig: {reflectedEncoder: missing}\n"), 0o644))
+	_, err = LoadConfig(cfgPath)
+	assert.ErrorContains(t, err, `no reflected encoder registered for name "missing"`, "Expected unknown names to be rejected.")
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := LoadConfig(filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err, "Expected error for missing file.")
+
+	path := filepath.Join(dir, "log.json")
+	require.NoError(t, os.WriteFile(path, []byte("level: info\n"), 0o644))
+	_, err = LoadConfig(path)
+	assert.ErrorContains(t, err, "decode "+path)
+}
+
+func TestExpandEnvRefs(t *testing.T) {
+	getenv := func(name string) string { return "<" + name + ">" }
+	tests := []struct {
+		give string
+		want string
+	}{
+		{"", ""},
+		{"plain", "plain"},
+		{"${A}", "<A>"},
+		{"x${A}y${B}z", "x<A>y<B>z"},
+		{"$A and ${", "$A and ${"},
+		{"${A} ${unterminated", "<A> ${unterminated"},
+		{"${}", "<>"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, expandEnvRefs(tt.give, getenv), "expandEnvRefs(%q)", tt.give)
+	}
+}
+
+func TestConfigOutputs(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "all.json")
+	infoPath := filepath.Join(dir, "info.log")
+	errPath := filepath.Join(dir, "errors.log")
+
+	t.Setenv("ZAP_TEST_DIR", dir)
+	cfgPath := filepath.Join(dir, "log.yaml")
+	require.NoError(t, os.WriteFile(cfgPath, []byte(`
+level: debug
+encoding: json
+encoderConfig:
+  messageKey: msg
+  levelKey: level
+  timeKey: ""
+outputs:
+  - paths: ["${ZAP_TEST_DIR}/all.json"]
+  - paths: ["${ZAP_TEST_DIR}/info.log"]
+    encoding: console
+    minLevel: info
+    maxLevel: warn
+    encoderConfig:
+      messageKey: M
+      levelKey: L
+      levelEncoder: capital
+  - paths: ["${ZAP_TEST_DIR}/errors.log"]
+    minLevel: error
+outputPaths: ["${ZAP_TEST_DIR}/ignored.log"]
+`), 0o644))
+
+	cfg, err := LoadConfig(cfgPath)
+	require.NoError(t, err)
+	cfg.DisableCaller = true
+	cfg.DisableStacktrace = true
+
+	logger, err := cfg.Build()
+	require.NoError(t, err)
+	logger.Debug("debug")
+	logger.Info("info")
+	logger.Warn("warn")
+	logger.Error("error")
+	require.NoError(t, logger.Sync())
+
+	read := func(path string) string {
+		bs, err := os.ReadFile(path)
+		require.NoError(t, err)
+		return string(bs)
+	}
+	assert.Equal(t, `{"level":"debug","msg":"debug"}
+{"level":"info","msg":"info"}
+{"level":"warn","msg":"warn"}
+{"level":"error","msg":"error"}
+`, read(jsonPath))
+	assert.Equal(t, "INFO\tinfo\nWARN\twarn\n", read(infoPath))
+	assert.Equal(t, `{"level":"error","msg":"error"}`+"\n", read(errPath))
+	assert.NoFileExists(t, filepath.Join(dir, "ignored.log"), "OutputPaths should be ignored.")
+
+	cfg.Level.SetLevel(ErrorLevel)
+	logger.Warn("suppressed")
+	require.NoError(t, logger.Sync())
+	assert.NotContains(t, read(infoPath), "suppressed", "Outputs should respect the dynamic level.")
+}
+
+func TestConfigOutputLevels(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.log")
+
+	cfg := NewDevelopmentConfig()
+	cfg.Development = false // don't panic at DPanicLevel
+	cfg.EncoderConfig.TimeKey = ""
+	cfg.DisableCaller = true
+	cfg.DisableStacktrace = true
+	cfg.Outputs = []OutputConfig{{
+		Paths:    []string{path},
+		MaxLevel: levelPtr(ErrorLevel),
+		Levels:   "!info & !(warn)",
+	}}
+	logger, err := cfg.Build()
+	require.NoError(t, err)
+	logger.Debug("debug")
+	logger.Info("info")
+	logger.Warn("warn")
+	logger.Error("error")
+	logger.DPanic("dpanic")
+	require.NoError(t, logger.Sync())
+
+	bs, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "DEBUG\tdebug\nERROR\terror\n", string(bs), "Unexpected output.")
+
+	cfg.Outputs[0].Levels = "info &"
+	_, err = cfg.Build()
+	assert.ErrorContains(t, err, "invalid level expression", "Expected an error for an invalid expression.")
+}
+
+func levelPtr(lvl zapcore.Level) *zapcore.Level {
+	return &lvl
+}
+
+func TestConfigMaxEntryBytes(t *testing.T) {
+	dir := t.TempDir()
+	limited := filepath.Join(dir, "limited.log")
+	unlimited := filepath.Join(dir, "unlimited.log")
+
+	cfg := NewProductionConfig()
+	cfg.EncoderConfig.TimeKey = ""
+	cfg.DisableCaller = true
+	cfg.MaxEntryBytes = 1 << 20
+	cfg.Outputs = []OutputConfig{
+		{Paths: []string{limited}, MaxEntryBytes: 64},
+		{Paths: []string{unlimited}},
+	}
+	logger, err := cfg.Build()
+	require.NoError(t, err)
+	logger.Info("big", String("payload", strings.Repeat("x", 100)))
+	require.NoError(t, logger.Sync())
+
+	bs, err := os.ReadFile(limited)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(bs), 64, "Expected the entry to be truncated.")
+	assert.Contains(t, string(bs), `"truncated":true`, "Expected a truncation marker.")
+
+	bs, err = os.ReadFile(unlimited)
+	require.NoError(t, err)
+	assert.Contains(t, string(bs), strings.Repeat("x", 100), "Expected the entry to be written in full.")
+}
+
+func TestConfigOutputsErrors(t *testing.T) {
+	cfg := NewProductionConfig()
+	cfg.Outputs = []OutputConfig{
+		{Paths: []string{"stdout"}},
+		{Paths: []string{"stdout"}, Encoding: "bogus"},
+	}
+	_, err := cfg.Build()
+	assert.ErrorContains(t, err, "outputs[1]: no encoder registered")
+}
+
+func TestConfigECSEncoding(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ecs.log")
+	cfg := NewProductionConfig()
+	cfg.Encoding = "ecs"
+	cfg.EncoderConfig = NewECSEncoderConfig()
+	cfg.OutputPaths = []string{path}
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Failed to build ECS logger.")
+
+	logger.Info("hello", String("trace_id", "abc"))
+	require.NoError(t, logger.Sync(), "Failed to sync.")
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err, "Failed to read log file.")
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(contents, &got), "Invalid JSON: %s", contents)
+	assert.Equal(t, "hello", got["message"])
+	assert.Equal(t, "info", got["log.level"])
+	assert.Equal(t, "abc", got["trace.id"])
+	assert.True(t, strings.HasSuffix(got["log.origin.file.name"].(string), "/config_test.go"), "Unexpected caller file.")
+	assert.Contains(t, got, "@timestamp")
+	assert.Contains(t, got, "ecs.version")
+}
+
+func TestConfigCustomLevel(t *testing.T) {
+	// Registrations are global, so use a level no other test does.
+	const verbose = zapcore.DebugLevel - 10
+	require.NoError(t, zapcore.RegisterLevel(verbose, "verbose"), "Failed to register level.")
+
+	var cfg Config
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"level": "VERBOSE",
+		"encoding": "json",
+		"encoderConfig": {"messageKey": "msg", "levelKey": "level", "levelEncoder": "lowercase"}
+	}`), &cfg), "Failed to unmarshal config.")
+	assert.Equal(t, verbose, cfg.Level.Level(), "Unexpected level.")
+
+	text, err := cfg.Level.MarshalText()
+	require.NoError(t, err, "Failed to marshal level.")
+	assert.Equal(t, "verbose", string(text), "Unexpected marshaled level.")
+
+	temp := filepath.Join(t.TempDir(), "log.json")
+	cfg.OutputPaths = []string{temp}
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Failed to build logger.")
+	logger.Log(verbose, "hello")
+	logger.Log(verbose-1, "dropped")
+	require.NoError(t, logger.Sync())
+
+	out, err := os.ReadFile(temp)
+	require.NoError(t, err, "Failed to read log.")
+	assert.Equal(t, `{"level":"verbose","msg":"hello"}`+"\n", string(out), "Unexpected output.")
+}
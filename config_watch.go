@@ -0,0 +1,403 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// _defaultWatchGracePeriod is how long a ConfigWatcher keeps replaced
+// outputs open by default.
+const _defaultWatchGracePeriod = 5 * time.Second
+
+// A WatchOption configures a ConfigWatcher.
+type WatchOption interface {
+	applyWatch(*ConfigWatcher)
+}
+
+type watchOptionFunc func(*ConfigWatcher)
+
+func (f watchOptionFunc) applyWatch(w *ConfigWatcher) {
+	f(w)
+}
+
+// WatchInterval sets how often the ConfigWatcher checks the configuration
+// file for changes. Defaults to one second. A non-positive interval disables
+// polling, leaving only signals and explicit calls to Reload.
+func WatchInterval(d time.Duration) WatchOption {
+	return watchOptionFunc(func(w *ConfigWatcher) {
+		w.interval = d
+	})
+}
+
+// WatchSignals makes the ConfigWatcher reload its configuration file when
+// the process receives one of the given signals, typically SIGHUP. By
+// default, the watcher doesn't handle signals.
+func WatchSignals(sigs ...os.Signal) WatchOption {
+	return watchOptionFunc(func(w *ConfigWatcher) {
+		w.signals = sigs
+	})
+}
+
+// WatchGracePeriod sets how long the ConfigWatcher keeps the outputs of a
+// replaced configuration open after a reload, so that entries checked
+// before the reload can finish writing. Defaults to five seconds.
+func WatchGracePeriod(d time.Duration) WatchOption {
+	return watchOptionFunc(func(w *ConfigWatcher) {
+		w.grace = d
+	})
+}
+
+// WatchOptions sets options applied to the Logger built by the
+// ConfigWatcher, as with Config.Build.
+func WatchOptions(opts ...Option) WatchOption {
+	return watchOptionFunc(func(w *ConfigWatcher) {
+		w.opts = append(w.opts, opts...)
+	})
+}
+
+// ConfigWatcher builds a Logger from a configuration file and keeps it in
+// sync with the file as it changes.
+//
+// On every change, the file is read and decoded again and the following
+// settings are applied to the existing Logger and every Logger derived from
+// it: Level, Sampling, Encoding, EncoderConfig, OutputPaths, Outputs, and
+// InitialFields. The remaining settings configure the Logger itself rather
+// than its core, so they keep the values they had when the watcher was
+// created.
+//
+// If a reloaded file is invalid, the Logger keeps its previous configuration
+// and the error is logged.
+type ConfigWatcher struct {
+	path     string
+	interval time.Duration
+	signals  []os.Signal
+	grace    time.Duration
+	opts     []Option
+
+	level  AtomicLevel
+	core   *reloadableCore
+	logger *Logger
+	stats  *statsCounter // shared by every version of the core; nil unless collected
+	// closeErr closes the error output, which isn't replaced on reload.
+	closeErr func()
+
+	mu       sync.Mutex // guards the fields below and serializes reloads
+	modTime  time.Time
+	size     int64
+	closeOut func()
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// WatchConfig reads the JSON or YAML logging configuration at path, builds a
+// Logger from it, and starts watching the file for changes. The file is
+// decoded as with LoadConfig.
+//
+// The file is reloaded when its modification time or size changes, and
+// whenever the process receives one of the signals set with WatchSignals.
+// Call Stop to stop watching.
+func WatchConfig(path string, opts ...WatchOption) (*ConfigWatcher, error) {
+	w := &ConfigWatcher{
+		path:     path,
+		interval: time.Second,
+		grace:    _defaultWatchGracePeriod,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt.applyWatch(w)
+	}
+
+	cfg, err := w.read()
+	if err != nil {
+		return nil, err
+	}
+	w.level = cfg.Level
+	if cfg.CollectStats {
+		// Stats are shared by every version of the core, so only the
+		// initial configuration decides whether they're collected.
+		w.stats = new(statsCounter)
+	}
+
+	core, closeOut, err := w.buildCore(cfg)
+	if err != nil {
+		return nil, err
+	}
+	errSink, closeErr, err := Open(cfg.ErrorOutputPaths...)
+	if err != nil {
+		closeOut()
+		return nil, err
+	}
+	w.closeOut = closeOut
+	w.closeErr = closeErr
+	w.core = newReloadableCore(core)
+	logOpts := cfg.loggerOptions(errSink)
+	if w.stats != nil {
+		logOpts = append(logOpts, withStats(w.stats))
+	}
+	w.logger = New(w.core, logOpts...).WithOptions(w.opts...)
+
+	go w.watch()
+	return w, nil
+}
+
+// Logger returns the Logger managed by the watcher.
+func (w *ConfigWatcher) Logger() *Logger {
+	return w.logger
+}
+
+// Level returns the AtomicLevel shared by the managed Logger. Reloads set
+// it to the level in the file.
+func (w *ConfigWatcher) Level() AtomicLevel {
+	return w.level
+}
+
+// Reload reads the configuration file and applies it immediately, whether or
+// not it has changed.
+func (w *ConfigWatcher) Reload() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	cfg, err := w.read()
+	if err != nil {
+		return err
+	}
+	core, closeOut, err := w.buildCore(cfg)
+	if err != nil {
+		return err
+	}
+
+	w.level.SetLevel(cfg.Level.Level())
+	old := w.core.swap(core)
+	w.retire(old, w.closeOut)
+	w.closeOut = closeOut
+	return nil
+}
+
+// retire syncs and closes the outputs of a replaced core once the grace
+// period has passed. Entries checked before the swap hold on to the old
+// core, so closing its outputs right away would fail their writes.
+func (w *ConfigWatcher) retire(old zapcore.Core, closeOut func()) {
+	time.AfterFunc(w.grace, func() {
+		_ = old.Sync()
+		closeOut()
+	})
+}
+
+// Stop stops watching the configuration file and closes the Logger's error
+// output. The Logger remains usable with its last configuration, but
+// internal errors are no longer reported.
+func (w *ConfigWatcher) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+		<-w.done
+		w.closeErr()
+	})
+}
+
+func (w *ConfigWatcher) watch() {
+	defer close(w.done)
+
+	var sigc chan os.Signal
+	if len(w.signals) > 0 {
+		sigc = make(chan os.Signal, 1)
+		signal.Notify(sigc, w.signals...)
+		defer signal.Stop(sigc)
+	}
+
+	var tick <-chan time.Time
+	if w.interval > 0 {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-sigc:
+			w.reportError(w.Reload())
+		case <-tick:
+			if w.changed() {
+				w.reportError(w.Reload())
+			}
+		}
+	}
+}
+
+// changed reports whether the configuration file's modification time or
+// size differ from the last time it was read.
+func (w *ConfigWatcher) changed() bool {
+	fi, err := os.Stat(w.path)
+	if err != nil {
+		// Files are often replaced by renaming over them, so treat a
+		// missing file as unchanged until it reappears.
+		return false
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return !fi.ModTime().Equal(w.modTime) || fi.Size() != w.size
+}
+
+func (w *ConfigWatcher) reportError(err error) {
+	if err != nil {
+		w.logger.Error("failed to reload logging configuration", String("path", w.path), Error(err))
+	}
+}
+
+// read reads and decodes the configuration file, recording its modification
+// time and size.
+func (w *ConfigWatcher) read() (Config, error) {
+	f, err := os.Open(w.path)
+	if err != nil {
+		return Config{}, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return Config{}, err
+	}
+	w.modTime, w.size = fi.ModTime(), fi.Size()
+
+	bs, err := io.ReadAll(f)
+	if err != nil {
+		return Config{}, err
+	}
+	return decodeConfig(w.path, bs)
+}
+
+// buildCore builds the part of a Logger that can be replaced on reload.
+// The returned core is always gated by the watcher's AtomicLevel.
+func (w *ConfigWatcher) buildCore(cfg Config) (zapcore.Core, func(), error) {
+	lvl := w.level
+	if lvl == (AtomicLevel{}) {
+		// Building the initial core, before the level is known.
+		lvl = cfg.Level
+	}
+	fields, err := cfg.initialFields()
+	if err != nil {
+		return nil, nil, err
+	}
+	core, closeOut, err := cfg.buildCore(lvl, w.stats)
+	if err != nil {
+		return nil, nil, err
+	}
+	core = cfg.wrapSampler(core, w.stats)
+	if len(fields) > 0 {
+		core = core.With(fields)
+	}
+	return core, closeOut, nil
+}
+
+// reloadableCore is a zapcore.Core whose underlying core can be replaced at
+// runtime. Cores derived from it with With follow replacements, re-applying
+// their fields to the new core the first time it's used.
+type reloadableCore struct {
+	root   *atomic.Pointer[reloadedCore] // shared by all derived cores
+	fields []zapcore.Field
+	cached atomic.Pointer[reloadedCore] // root version with fields applied
+}
+
+// reloadedCore is a single version of a reloadableCore's underlying core.
+type reloadedCore struct {
+	base *reloadedCore // version this was derived from; nil for root versions
+	core zapcore.Core
+}
+
+var _ zapcore.Core = (*reloadableCore)(nil)
+
+func newReloadableCore(core zapcore.Core) *reloadableCore {
+	root := new(atomic.Pointer[reloadedCore])
+	root.Store(&reloadedCore{core: core})
+	return &reloadableCore{root: root}
+}
+
+// swap replaces the underlying core, returning the previous one.
+func (c *reloadableCore) swap(core zapcore.Core) zapcore.Core {
+	return c.root.Swap(&reloadedCore{core: core}).core
+}
+
+// current returns the underlying core with this core's fields applied.
+func (c *reloadableCore) current() zapcore.Core {
+	root := c.root.Load()
+	if len(c.fields) == 0 {
+		return root.core
+	}
+	if v := c.cached.Load(); v != nil && v.base == root {
+		return v.core
+	}
+	v := &reloadedCore{base: root, core: root.core.With(c.fields)}
+	c.cached.Store(v)
+	return v.core
+}
+
+func (c *reloadableCore) Enabled(lvl zapcore.Level) bool {
+	return c.current().Enabled(lvl)
+}
+
+// Level returns the minimum enabled level of the underlying core.
+func (c *reloadableCore) Level() zapcore.Level {
+	return zapcore.LevelOf(c.current())
+}
+
+// AccumulatedFields returns the fields bound to the underlying core,
+// followed by those bound to this core with With.
+func (c *reloadableCore) AccumulatedFields() []zapcore.Field {
+	base := zapcore.AccumulatedFields(c.root.Load().core)
+	if len(c.fields) == 0 {
+		return base
+	}
+	return append(base[:len(base):len(base)], c.fields...)
+}
+
+func (c *reloadableCore) With(fields []zapcore.Field) zapcore.Core {
+	if len(fields) == 0 {
+		return c
+	}
+	all := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	all = append(all, c.fields...)
+	all = append(all, fields...)
+	return &reloadableCore{root: c.root, fields: all}
+}
+
+func (c *reloadableCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return c.current().Check(ent, ce)
+}
+
+func (c *reloadableCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.current().Write(ent, fields)
+}
+
+func (c *reloadableCore) Sync() error {
+	return c.current().Sync()
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func writeWatchedConfig(t *testing.T, path, body string) {
+	require.NoError(t, os.WriteFile(path, []byte(body), 0o644))
+}
+
+func readLines(t *testing.T, path string) []string {
+	bs, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return strings.Split(strings.TrimSpace(string(bs)), "\n")
+}
+
+func TestWatchConfigReload(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "log.json")
+	first := filepath.Join(dir, "first.log")
+	second := filepath.Join(dir, "second.log")
+
+	writeWatchedConfig(t, cfgPath, `{
+		"level": "info",
+		"encoding": "json",
+		"encoderConfig": {"messageKey": "msg", "levelKey": "level", "timeKey": ""},
+		"outputPaths": [`+quoteJSON(first)+`],
+		"disableCaller": true
+	}`)
+
+	w, err := WatchConfig(cfgPath, WatchInterval(0), WatchSignals())
+	require.NoError(t, err)
+	defer w.Stop()
+
+	logger := w.Logger()
+	child := logger.With(String("component", "db"))
+	lvl := w.Level()
+
+	logger.Debug("dropped")
+	child.Info("before")
+	require.NoError(t, logger.Sync())
+	assert.Equal(t, []string{`{"level":"info","msg":"before","component":"db"}`}, readLines(t, first))
+
+	writeWatchedConfig(t, cfgPath, `
+level: debug
+encoding: json
+encoderConfig:
+  messageKey: message
+  levelKey: ""
+  timeKey: ""
+outputPaths: [`+quoteJSON(second)+`]
+initialFields:
+  env: test
+`)
+	assert.Error(t, w.Reload(), "Expected YAML in a .json file to fail.")
+	assert.Equal(t, InfoLevel, lvl.Level(), "Failed reload must not change the level.")
+
+	yamlPath := filepath.Join(dir, "log.yaml")
+	require.NoError(t, os.Rename(cfgPath, yamlPath))
+	w.path = yamlPath
+	require.NoError(t, w.Reload())
+
+	assert.Equal(t, DebugLevel, lvl.Level(), "Level should be updated in place.")
+	assert.Equal(t, DebugLevel, zapcore.LevelOf(logger.Core()))
+	child.Debug("after")
+	require.NoError(t, logger.Sync())
+
+	assert.Equal(t, []string{`{"level":"info","msg":"before","component":"db"}`}, readLines(t, first),
+		"Old output should not receive new entries.")
+	assert.Equal(t, []string{`{"message":"after","env":"test","component":"db"}`}, readLines(t, second))
+	assert.Equal(t, []Field{String("component", "db")}, zapcore.AccumulatedFields(child.Core()))
+}
+
+func TestWatchConfigReloadDrainsOldOutputs(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "log.json")
+	first := filepath.Join(dir, "first.log")
+	second := filepath.Join(dir, "second.log")
+
+	const tmpl = `{
+		"level": "info",
+		"encoding": "json",
+		"encoderConfig": {"messageKey": "msg", "levelKey": "", "timeKey": ""},
+		"outputPaths": [%s],
+		"disableCaller": true
+	}`
+	writeWatchedConfig(t, cfgPath, fmt.Sprintf(tmpl, quoteJSON(first)))
+	w, err := WatchConfig(cfgPath, WatchInterval(0), WatchGracePeriod(time.Hour))
+	require.NoError(t, err)
+	defer w.Stop()
+
+	// Entries checked before a reload are written to the old outputs, which
+	// stay open during the grace period.
+	ce := w.Logger().Check(InfoLevel, "in flight")
+	require.NotNil(t, ce, "Expected entry to be enabled.")
+
+	writeWatchedConfig(t, cfgPath, fmt.Sprintf(tmpl, quoteJSON(second)))
+	require.NoError(t, w.Reload())
+	ce.Write()
+	w.Logger().Info("after")
+
+	assert.Equal(t, []string{`{"msg":"in flight"}`}, readLines(t, first))
+	assert.Equal(t, []string{`{"msg":"after"}`}, readLines(t, second))
+}
+
+func TestWatchConfigPolling(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "log.json")
+	writeWatchedConfig(t, cfgPath, `{"level": "warn", "outputPaths": [], "errorOutputPaths": []}`)
+
+	w, err := WatchConfig(cfgPath, WatchInterval(time.Millisecond))
+	require.NoError(t, err)
+	defer w.Stop()
+
+	writeWatchedConfig(t, cfgPath, `{"level": "error", "outputPaths": [], "errorOutputPaths": []}`)
+	// Guard against coarse file system timestamps.
+	future := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(cfgPath, future, future))
+
+	assert.Eventually(t, func() bool {
+		return w.Level().Level() == ErrorLevel
+	}, time.Second, time.Millisecond, "Expected change to be picked up.")
+
+	w.Stop()
+	w.Stop() // idempotent
+}
+
+func TestWatchConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := WatchConfig(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err, "Expected error for missing file.")
+
+	bad := filepath.Join(dir, "bad.json")
+	writeWatchedConfig(t, bad, `{"encoding": "bogus"}`)
+	_, err = WatchConfig(bad)
+	assert.Error(t, err, "Expected error for unknown encoding.")
+
+	badLevel := filepath.Join(dir, "bad.yaml")
+	writeWatchedConfig(t, badLevel, "level: loud\n")
+	_, err = WatchConfig(badLevel)
+	assert.Error(t, err, "Expected error for invalid level.")
+}
+
+func quoteJSON(s string) string {
+	return `"` + strings.ReplaceAll(s, `\`, `\\`) + `"`
+}
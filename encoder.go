@@ -21,6 +21,7 @@
 package zap
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sync"
@@ -31,24 +32,108 @@ import (
 var (
 	errNoEncoderNameSpecified = errors.New("no encoder name specified")
 
-	_encoderNameToConstructor = map[string]func(zapcore.EncoderConfig) (zapcore.Encoder, error){
-		"console": func(encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
+	_encoderNameToConstructor = map[string]encoderConstructor{
+		"cbor": withoutOptions(func(encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
+			return zapcore.NewCBOREncoder(encoderConfig), nil
+		}),
+		"console": withoutOptions(func(encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
 			return zapcore.NewConsoleEncoder(encoderConfig), nil
-		},
-		"json": func(encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
+		}),
+		"csv": withoutOptions(func(encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
+			return zapcore.NewCSVEncoder(encoderConfig), nil
+		}),
+		"ecs": withoutOptions(func(encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
+			return zapcore.NewECSEncoder(encoderConfig), nil
+		}),
+		"gelf": {fn: newGELFEncoder, acceptsOptions: true},
+		"json": withoutOptions(func(encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
 			return zapcore.NewJSONEncoder(encoderConfig), nil
-		},
+		}),
+		"tsv": withoutOptions(func(encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
+			encoderConfig.CSVSeparator = "\t"
+			return zapcore.NewCSVEncoder(encoderConfig), nil
+		}),
 	}
 	_encoderMutex sync.RWMutex
 )
 
+// EncoderOptions holds encoder-specific settings from a Config, for
+// encoders registered with RegisterEncoderWithOptions. They're decoded from
+// the encoderOptions key of a JSON or YAML configuration, so the exact types
+// of numbers and nested maps depend on the format; Decode is the simplest
+// way to read them.
+type EncoderOptions map[string]interface{}
+
+// Decode stores the options in the value pointed to by v, as if they'd been
+// decoded from JSON. This lets encoders declare their settings as a struct:
+//
+//	var settings struct {
+//		Indent int  `json:"indent"`
+//		Color  bool `json:"color"`
+//	}
+//	if err := opts.Decode(&settings); err != nil {
+//		return nil, err
+//	}
+func (o EncoderOptions) Decode(v interface{}) error {
+	bs, err := json.Marshal(o)
+	if err != nil {
+		return fmt.Errorf("can't marshal encoder options: %v", err)
+	}
+	return json.Unmarshal(bs, v)
+}
+
+// encoderConstructor is a registered encoder constructor.
+type encoderConstructor struct {
+	fn             func(zapcore.EncoderConfig, EncoderOptions) (zapcore.Encoder, error)
+	acceptsOptions bool
+}
+
+func withoutOptions(constructor func(zapcore.EncoderConfig) (zapcore.Encoder, error)) encoderConstructor {
+	return encoderConstructor{
+		fn: func(encoderConfig zapcore.EncoderConfig, _ EncoderOptions) (zapcore.Encoder, error) {
+			return constructor(encoderConfig)
+		},
+	}
+}
+
+// newGELFEncoder builds the "gelf" encoder, which accepts a host option to
+// override the hostname reported with each message.
+func newGELFEncoder(encoderConfig zapcore.EncoderConfig, opts EncoderOptions) (zapcore.Encoder, error) {
+	var settings struct {
+		Host string `json:"host"`
+	}
+	if err := opts.Decode(&settings); err != nil {
+		return nil, fmt.Errorf("invalid gelf encoder options: %v", err)
+	}
+	return zapcore.NewGELFEncoder(encoderConfig, settings.Host), nil
+}
+
 // RegisterEncoder registers an encoder constructor, which the Config struct
-// can then reference. By default, the "json" and "console" encoders are
-// registered.
+// can then reference. By default, the "json", "console", "cbor", "gelf", and
+// "ecs" encoders are registered.
 //
 // Attempting to register an encoder whose name is already taken returns an
 // error.
 func RegisterEncoder(name string, constructor func(zapcore.EncoderConfig) (zapcore.Encoder, error)) error {
+	return registerEncoder(name, withoutOptions(constructor))
+}
+
+// RegisterEncoderWithOptions registers an encoder constructor that also
+// receives the EncoderOptions of the Config using it, so that encoders with
+// settings beyond those of zapcore.EncoderConfig can be configured entirely
+// from configuration files:
+//
+//	encoding: logfmt
+//	encoderOptions:
+//	  quoteEmpty: true
+//
+// Encoders registered with RegisterEncoder reject configurations that set
+// EncoderOptions. Names are shared with RegisterEncoder.
+func RegisterEncoderWithOptions(name string, constructor func(zapcore.EncoderConfig, EncoderOptions) (zapcore.Encoder, error)) error {
+	return registerEncoder(name, encoderConstructor{fn: constructor, acceptsOptions: true})
+}
+
+func registerEncoder(name string, constructor encoderConstructor) error {
 	_encoderMutex.Lock()
 	defer _encoderMutex.Unlock()
 	if name == "" {
@@ -61,7 +146,7 @@ func RegisterEncoder(name string, constructor func(zapcore.EncoderConfig) (zapco
 	return nil
 }
 
-func newEncoder(name string, encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
+func newEncoder(name string, encoderConfig zapcore.EncoderConfig, opts EncoderOptions) (zapcore.Encoder, error) {
 	if encoderConfig.TimeKey != "" && encoderConfig.EncodeTime == nil {
 		return nil, errors.New("missing EncodeTime in EncoderConfig")
 	}
@@ -75,5 +160,8 @@ func newEncoder(name string, encoderConfig zapcore.EncoderConfig) (zapcore.Encod
 	if !ok {
 		return nil, fmt.Errorf("no encoder registered for name %q", name)
 	}
-	return constructor(encoderConfig)
+	if len(opts) > 0 && !constructor.acceptsOptions {
+		return nil, fmt.Errorf("encoder %q doesn't accept encoder options", name)
+	}
+	return constructor.fn(encoderConfig, opts)
 }
@@ -26,10 +26,11 @@ import (
 	"go.uber.org/zap/zapcore"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRegisterDefaultEncoders(t *testing.T) {
-	testEncodersRegistered(t, "console", "json")
+	testEncodersRegistered(t, "cbor", "console", "csv", "ecs", "gelf", "json", "tsv")
 }
 
 func TestRegisterEncoder(t *testing.T) {
@@ -53,25 +54,73 @@ func TestRegisterEncoderNoName(t *testing.T) {
 func TestNewEncoder(t *testing.T) {
 	testEncoders(func() {
 		assert.NoError(t, RegisterEncoder("foo", newNilEncoder), "expected to be able to register the encoder foo")
-		encoder, err := newEncoder("foo", zapcore.EncoderConfig{})
+		encoder, err := newEncoder("foo", zapcore.EncoderConfig{}, nil)
 		assert.NoError(t, err, "could not create an encoder for the registered name foo")
 		assert.Nil(t, encoder, "the encoder from newNilEncoder is not nil")
 	})
 }
 
 func TestNewEncoderNotRegistered(t *testing.T) {
-	_, err := newEncoder("foo", zapcore.EncoderConfig{})
+	_, err := newEncoder("foo", zapcore.EncoderConfig{}, nil)
 	assert.Error(t, err, "expected an error when trying to create an encoder of an unregistered name")
 }
 
 func TestNewEncoderNoName(t *testing.T) {
-	_, err := newEncoder("", zapcore.EncoderConfig{})
+	_, err := newEncoder("", zapcore.EncoderConfig{}, nil)
 	assert.Equal(t, errNoEncoderNameSpecified, err, "expected an error when creating an encoder with no name")
 }
 
+func TestRegisterEncoderWithOptions(t *testing.T) {
+	testEncoders(func() {
+		var got EncoderOptions
+		require.NoError(t, RegisterEncoderWithOptions("foo", func(_ zapcore.EncoderConfig, opts EncoderOptions) (zapcore.Encoder, error) {
+			got = opts
+			return nil, nil
+		}))
+		assert.Error(t, RegisterEncoder("foo", newNilEncoder), "expected names to be shared with RegisterEncoder")
+		testEncodersRegistered(t, "foo")
+
+		opts := EncoderOptions{"indent": 2.0}
+		_, err := newEncoder("foo", zapcore.EncoderConfig{}, opts)
+		require.NoError(t, err)
+		assert.Equal(t, opts, got, "expected the encoder to receive its options")
+	})
+}
+
+func TestNewEncoderRejectsOptions(t *testing.T) {
+	testEncoders(func() {
+		require.NoError(t, RegisterEncoder("foo", newNilEncoder))
+		_, err := newEncoder("foo", zapcore.EncoderConfig{}, EncoderOptions{"indent": 2.0})
+		assert.EqualError(t, err, `encoder "foo" doesn't accept encoder options`)
+
+		_, err = newEncoder("foo", zapcore.EncoderConfig{}, EncoderOptions{})
+		assert.NoError(t, err, "expected empty options to be allowed")
+	})
+}
+
+func TestEncoderOptionsDecode(t *testing.T) {
+	var settings struct {
+		Indent int               `json:"indent"`
+		Color  bool              `json:"color"`
+		Names  map[string]string `json:"names"`
+	}
+	opts := EncoderOptions{
+		"indent": 2.0,
+		"color":  true,
+		"names":  map[string]interface{}{"info": "INF"},
+	}
+	require.NoError(t, opts.Decode(&settings))
+	assert.Equal(t, 2, settings.Indent)
+	assert.True(t, settings.Color)
+	assert.Equal(t, map[string]string{"info": "INF"}, settings.Names)
+
+	assert.Error(t, EncoderOptions{"indent": "two"}.Decode(&settings), "expected type mismatches to fail")
+	assert.Error(t, EncoderOptions{"bad": func() {}}.Decode(&settings), "expected unmarshalable options to fail")
+}
+
 func testEncoders(f func()) {
 	existing := _encoderNameToConstructor
-	_encoderNameToConstructor = make(map[string]func(zapcore.EncoderConfig) (zapcore.Encoder, error))
+	_encoderNameToConstructor = make(map[string]encoderConstructor)
 	defer func() { _encoderNameToConstructor = existing }()
 	f()
 }
@@ -79,10 +128,19 @@ func testEncoders(f func()) {
 func testEncodersRegistered(t *testing.T, names ...string) {
 	assert.Len(t, _encoderNameToConstructor, len(names), "the expected number of registered encoders does not match the actual number")
 	for _, name := range names {
-		assert.NotNil(t, _encoderNameToConstructor[name], "no encoder is registered for name %s", name)
+		assert.NotNil(t, _encoderNameToConstructor[name].fn, "no encoder is registered for name %s", name)
 	}
 }
 
 func newNilEncoder(_ zapcore.EncoderConfig) (zapcore.Encoder, error) {
 	return nil, nil
 }
+
+func TestGELFEncoding(t *testing.T) {
+	opts := EncoderOptions{"host": "configured"}
+	enc, err := newEncoder("gelf", NewProductionEncoderConfig(), opts)
+	require.NoError(t, err, "Failed to build gelf encoder.")
+	buf, err := enc.EncodeEntry(zapcore.Entry{Message: "hi"}, nil)
+	require.NoError(t, err, "Failed to encode.")
+	assert.Contains(t, buf.String(), `"host":"configured"`)
+}
@@ -21,6 +21,9 @@
 package zap
 
 import (
+	"errors"
+	"fmt"
+
 	"go.uber.org/zap/internal/pool"
 	"go.uber.org/zap/zapcore"
 )
@@ -80,3 +83,127 @@ func (e *errArrayElem) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	Error(e.error).AddTo(enc)
 	return nil
 }
+
+// A StackTracer is an error that knows the stack trace of where it was
+// created or wrapped. GroupedErrors logs it when ErrorStacks is used.
+type StackTracer interface {
+	StackTrace() string
+}
+
+// An ErrorsOption configures a field built by GroupedErrors.
+type ErrorsOption interface {
+	applyErrors(*groupedErrors)
+}
+
+type errorsOptionFunc func(*groupedErrors)
+
+func (f errorsOptionFunc) applyErrors(g *groupedErrors) {
+	f(g)
+}
+
+// ErrorTypes adds each error's dynamic type, as in "*fs.PathError", under
+// the "errorType" key. Errors with the same message but different types are
+// then kept apart.
+func ErrorTypes() ErrorsOption {
+	return errorsOptionFunc(func(g *groupedErrors) {
+		g.types = true
+	})
+}
+
+// ErrorStacks adds the stack trace of errors that carry one under the
+// "errorStack" key. An error carries a stack trace if it, or any error it
+// wraps, implements StackTracer.
+func ErrorStacks() ErrorsOption {
+	return errorsOptionFunc(func(g *groupedErrors) {
+		g.stacks = true
+	})
+}
+
+// GroupedErrors constructs a field that carries a slice of errors, like
+// Errors, but collapses errors with identical messages into a single element
+// with a "count" key. This keeps entries compact when many goroutines fail
+// the same way, as with the error returned from errgroup.Group.Wait or a
+// batch of retries. Elements appear in the order in which their messages
+// first occur; each one is encoded from the first error with that message.
+//
+//	[{"error": "connection refused", "count": 12}, {"error": "timeout"}]
+func GroupedErrors(key string, errs []error, opts ...ErrorsOption) Field {
+	g := &groupedErrors{errs: errs}
+	for _, opt := range opts {
+		opt.applyErrors(g)
+	}
+	return Array(key, g)
+}
+
+type groupedErrors struct {
+	errs   []error
+	types  bool
+	stacks bool
+}
+
+func (g *groupedErrors) MarshalLogArray(arr zapcore.ArrayEncoder) error {
+	type groupKey struct{ typ, msg string }
+
+	var (
+		groups []*errGroupElem
+		index  = make(map[groupKey]int, len(g.errs))
+	)
+	for _, err := range g.errs {
+		if err == nil {
+			continue
+		}
+		key := groupKey{msg: errorMessage(err)}
+		if g.types {
+			key.typ = fmt.Sprintf("%T", err)
+		}
+		if i, ok := index[key]; ok {
+			groups[i].count++
+			continue
+		}
+		index[key] = len(groups)
+		groups = append(groups, &errGroupElem{err: err, typ: key.typ, count: 1, stack: g.stacks})
+	}
+
+	for _, elem := range groups {
+		if err := arr.AppendObject(elem); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// errorMessage returns err.Error(), guarding against errors that panic, such
+// as nil pointers with value receivers. Encoding such errors reports the
+// panic.
+func errorMessage(err error) (msg string) {
+	defer func() {
+		if r := recover(); r != nil {
+			msg = fmt.Sprintf("PANIC=%v", r)
+		}
+	}()
+	return err.Error()
+}
+
+type errGroupElem struct {
+	err   error
+	typ   string // empty unless ErrorTypes is used
+	count int
+	stack bool
+}
+
+func (e *errGroupElem) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	Error(e.err).AddTo(enc)
+	if e.count > 1 {
+		enc.AddInt("count", e.count)
+	}
+	if e.typ != "" {
+		enc.AddString("errorType", e.typ)
+	}
+	if e.stack {
+		var st StackTracer
+		if errors.As(e.err, &st) {
+			enc.AddString("errorStack", st.StackTrace())
+		}
+	}
+	return nil
+}
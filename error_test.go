@@ -131,3 +131,53 @@ func (enc brokenArrayObjectEncoder) AddArray(key string, marshaler zapcore.Array
 func (enc brokenArrayObjectEncoder) AppendObject(zapcore.ObjectMarshaler) error {
 	return enc.Err
 }
+
+type stackError struct{ msg string }
+
+func (e *stackError) Error() string      { return e.msg }
+func (e *stackError) StackTrace() string { return "main.go:1" }
+
+func TestGroupedErrors(t *testing.T) {
+	refused := errors.New("connection refused")
+	errs := []error{
+		refused,
+		nil,
+		errors.New("timeout"),
+		refused,
+		fmt.Errorf("wrapped: %w", &stackError{"boom"}),
+		&stackError{"connection refused"},
+	}
+
+	tests := []struct {
+		desc string
+		opts []ErrorsOption
+		want []interface{}
+	}{
+		{
+			desc: "messages only",
+			want: []interface{}{
+				map[string]interface{}{"error": "connection refused", "count": 3},
+				map[string]interface{}{"error": "timeout"},
+				map[string]interface{}{"error": "wrapped: boom"},
+			},
+		},
+		{
+			desc: "types and stacks",
+			opts: []ErrorsOption{ErrorTypes(), ErrorStacks()},
+			want: []interface{}{
+				map[string]interface{}{"error": "connection refused", "count": 2, "errorType": "*errors.errorString"},
+				map[string]interface{}{"error": "timeout", "errorType": "*errors.errorString"},
+				map[string]interface{}{"error": "wrapped: boom", "errorType": "*fmt.wrapError", "errorStack": "main.go:1"},
+				map[string]interface{}{"error": "connection refused", "errorType": "*zap.stackError", "errorStack": "main.go:1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			enc := zapcore.NewMapObjectEncoder()
+			GroupedErrors("k", errs, tt.opts...).AddTo(enc)
+			assert.Equal(t, tt.want, enc.Fields["k"], "Unexpected grouped errors.")
+		})
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zapotlp provides a zapcore.Core that exports entries as
+// OpenTelemetry LogRecords using the OTLP protocol.
+//
+// Entries are converted directly to OTLP protobufs, without an intermediate
+// encoding, and exported in batches. Levels map onto OpenTelemetry severity
+// numbers, fields become attributes, logger names become instrumentation
+// scopes, and fields passed to WithResource describe the resource producing
+// the logs.
+package zapotlp // import "go.uber.org/zap/exp/zapotlp"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	_defaultMaxBatchSize  = 512
+	_defaultFlushInterval = time.Second
+	_defaultExportTimeout = 10 * time.Second
+)
+
+// Core is a zapcore.Core that batches entries and sends them to an
+// Exporter.
+//
+// Entries are exported when a batch fills up, when the flush interval
+// elapses, and when Sync or Stop is called. Call Stop before exiting to
+// export any remaining entries and release the background goroutine.
+type Core struct {
+	zapcore.LevelEnabler
+
+	b     *batcher
+	attrs []byte // encoded LogRecord attributes from With
+}
+
+var _ zapcore.Core = (*Core)(nil)
+
+// NewCore builds a Core that exports entries enabled by enab to exporter.
+func NewCore(enab zapcore.LevelEnabler, exporter Exporter, opts ...Option) *Core {
+	b := &batcher{
+		exporter:      exporter,
+		maxBatchSize:  _defaultMaxBatchSize,
+		flushInterval: _defaultFlushInterval,
+		exportTimeout: _defaultExportTimeout,
+		clock:         zapcore.DefaultClock,
+	}
+	for _, opt := range opts {
+		opt.apply(b)
+	}
+	return &Core{
+		LevelEnabler: enab,
+		b:            b,
+	}
+}
+
+// Level returns the minimum enabled level for this core.
+func (c *Core) Level() zapcore.Level {
+	return zapcore.LevelOf(c.LevelEnabler)
+}
+
+// With adds structured context to the Core. The fields are converted to
+// attributes once, rather than on every entry.
+func (c *Core) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.attrs = appendAttributes(c.attrs[:len(c.attrs):len(c.attrs)], _logRecordAttributes, fields)
+	return &clone
+}
+
+// Check determines whether the supplied Entry should be logged.
+func (c *Core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write adds the entry to the current batch, exporting the batch if it's
+// full.
+func (c *Core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	rec := appendLogRecord(nil, ent, c.b.clock.Now(), c.attrs, fields)
+	return c.b.add(ent.LoggerName, rec)
+}
+
+// Sync exports any buffered entries.
+func (c *Core) Sync() error {
+	return c.b.flush()
+}
+
+// Stop exports any buffered entries and stops the background flush
+// goroutine. Entries written after Stop are exported synchronously, one
+// batch at a time, as batches fill up or Sync is called.
+func (c *Core) Stop() error {
+	return c.b.stop()
+}
+
+type record struct {
+	scope string
+	data  []byte // encoded LogRecord
+}
+
+// batcher holds the state shared by a Core and its children.
+type batcher struct {
+	exporter      Exporter
+	resource      []byte // encoded Resource attributes
+	maxBatchSize  int
+	flushInterval time.Duration
+	exportTimeout time.Duration
+	clock         zapcore.Clock
+
+	mu          sync.Mutex
+	records     []record
+	initialized bool
+	stopped     bool
+	ticker      *time.Ticker
+	stopC       chan struct{}
+	done        chan struct{}
+
+	// exportMu serializes exports so batches arrive in order.
+	exportMu sync.Mutex
+}
+
+func (b *batcher) add(scope string, data []byte) error {
+	b.mu.Lock()
+	if !b.initialized && !b.stopped {
+		b.initialize()
+	}
+	b.records = append(b.records, record{scope: scope, data: data})
+	if len(b.records) < b.maxBatchSize {
+		b.mu.Unlock()
+		return nil
+	}
+	return b.exportLocked()
+}
+
+// initialize starts the background flush goroutine. It must be called with
+// mu held.
+func (b *batcher) initialize() {
+	b.initialized = true
+	if b.flushInterval <= 0 {
+		return
+	}
+	b.ticker = b.clock.NewTicker(b.flushInterval)
+	b.stopC = make(chan struct{})
+	b.done = make(chan struct{})
+	go b.flushLoop()
+}
+
+func (b *batcher) flushLoop() {
+	defer close(b.done)
+	for {
+		select {
+		case <-b.ticker.C:
+			// Like BufferedWriteSyncer, we can't report errors from
+			// background flushes. They're surfaced by the next Sync if the
+			// exporter is still failing.
+			_ = b.flush()
+		case <-b.stopC:
+			return
+		}
+	}
+}
+
+func (b *batcher) flush() error {
+	b.mu.Lock()
+	return b.exportLocked()
+}
+
+// exportLocked takes the current batch and exports it. It must be called
+// with mu held, and releases it before exporting.
+func (b *batcher) exportLocked() error {
+	records := b.records
+	b.records = nil
+	b.exportMu.Lock()
+	defer b.exportMu.Unlock()
+	b.mu.Unlock()
+
+	if len(records) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), b.exportTimeout)
+	defer cancel()
+	return b.exporter.Export(ctx, b.encodeRequest(records))
+}
+
+func (b *batcher) stop() error {
+	b.mu.Lock()
+	if b.stopped {
+		b.mu.Unlock()
+		return nil
+	}
+	b.stopped = true
+	started := b.ticker != nil
+	b.mu.Unlock()
+
+	if started {
+		b.ticker.Stop()
+		close(b.stopC)
+		<-b.done
+	}
+	return b.flush()
+}
+
+// encodeRequest builds an ExportLogsServiceRequest holding records, grouped
+// into one ScopeLogs per logger name in order of first appearance.
+func (b *batcher) encodeRequest(records []record) []byte {
+	var scopes []string
+	byScope := make(map[string][]record)
+	for _, r := range records {
+		if _, ok := byScope[r.scope]; !ok {
+			scopes = append(scopes, r.scope)
+		}
+		byScope[r.scope] = append(byScope[r.scope], r)
+	}
+
+	return appendMessageField(nil, _requestResourceLogs, func(buf []byte) []byte {
+		buf = appendMessageField(buf, _resourceLogsResource, func(buf []byte) []byte {
+			return append(buf, b.resource...)
+		})
+		for _, scope := range scopes {
+			buf = appendMessageField(buf, _resourceLogsScopeLogs, func(buf []byte) []byte {
+				buf = appendMessageField(buf, _scopeLogsScope, func(buf []byte) []byte {
+					if scope == "" {
+						return buf
+					}
+					return appendStringField(buf, _scopeName, scope)
+				})
+				for _, r := range byScope[scope] {
+					buf = appendBytesField(buf, _scopeLogsLogRecords, r.data)
+				}
+				return buf
+			})
+		}
+		return buf
+	})
+}
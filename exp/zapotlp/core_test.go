@@ -0,0 +1,275 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapotlp
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type recordingExporter struct {
+	mu       sync.Mutex
+	requests [][]byte
+	err      error
+}
+
+func (e *recordingExporter) Export(_ context.Context, req []byte) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.requests = append(e.requests, req)
+	return e.err
+}
+
+func (e *recordingExporter) Requests() [][]byte {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.requests
+}
+
+func TestCoreExport(t *testing.T) {
+	exp := &recordingExporter{}
+	core := NewCore(zapcore.InfoLevel, exp,
+		WithFlushInterval(0),
+		WithResource(zap.String("service.name", "svc")),
+	)
+	logger := zap.New(core).Named("db").With(zap.Int("shard", 3))
+
+	logger.Debug("dropped")
+	logger.Warn("slow query",
+		zap.Duration("elapsed", time.Second),
+		zap.Bools("flags", []bool{true}),
+		zap.Float64("ratio", 0.5),
+		zap.Binary("raw", []byte{1}),
+	)
+	zap.New(core).Error("no scope")
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+
+	reqs := exp.Requests()
+	require.Len(t, reqs, 1, "Expected a single export.")
+
+	resourceLogs := decodeMessages(t, reqs[0], _requestResourceLogs)
+	require.Len(t, resourceLogs, 1, "Expected one ResourceLogs.")
+	resource := decodeMessages(t, resourceLogs[0], _resourceLogsResource)[0]
+	assert.Equal(t, map[string]interface{}{"service.name": "svc"},
+		decodeAttributes(t, resource, _resourceAttributes), "Unexpected resource attributes.")
+
+	scopeLogs := decodeMessages(t, resourceLogs[0], _resourceLogsScopeLogs)
+	require.Len(t, scopeLogs, 2, "Expected one ScopeLogs per logger name.")
+
+	scope := decodeMessages(t, scopeLogs[0], _scopeLogsScope)[0]
+	assert.Equal(t, "db", string(decodeField(t, scope, _scopeName)[0].bytes), "Unexpected scope name.")
+
+	records := decodeMessages(t, scopeLogs[0], _scopeLogsLogRecords)
+	require.Len(t, records, 1, "Expected one record in the db scope.")
+	rec := records[0]
+	assert.Equal(t, uint64(_severityWarn), decodeField(t, rec, _logRecordSeverityNumber)[0].varint)
+	assert.Equal(t, "WARN", string(decodeField(t, rec, _logRecordSeverityText)[0].bytes))
+	assert.Equal(t, "slow query", decodeAnyValue(t, decodeMessages(t, rec, _logRecordBody)[0]))
+	assert.Len(t, decodeField(t, rec, _logRecordObservedTimeUnixNano), 1, "Expected observed timestamp.")
+	assert.Equal(t, map[string]interface{}{
+		"shard":   int64(3),
+		"elapsed": "1s",
+		"flags":   []interface{}{true},
+		"ratio":   0.5,
+		"raw":     []byte{1},
+	}, decodeAttributes(t, rec, _logRecordAttributes), "Unexpected record attributes.")
+
+	scope = decodeMessages(t, scopeLogs[1], _scopeLogsScope)[0]
+	assert.Empty(t, decodeField(t, scope, _scopeName), "Expected unnamed scope.")
+	rec = decodeMessages(t, scopeLogs[1], _scopeLogsLogRecords)[0]
+	assert.Equal(t, uint64(_severityError), decodeField(t, rec, _logRecordSeverityNumber)[0].varint)
+}
+
+func TestCoreBatching(t *testing.T) {
+	exp := &recordingExporter{err: errors.New("fail")}
+	core := NewCore(zapcore.DebugLevel, exp, WithFlushInterval(0), WithMaxBatchSize(2))
+
+	ent := zapcore.Entry{Level: zapcore.InfoLevel, Message: "msg"}
+	require.NoError(t, core.Write(ent, nil), "First write shouldn't export.")
+	assert.Empty(t, exp.Requests(), "Unexpected export before batch is full.")
+	assert.Error(t, core.Write(ent, nil), "Expected export error once batch is full.")
+	assert.Len(t, exp.Requests(), 1, "Expected export once batch is full.")
+
+	require.NoError(t, core.Sync(), "Syncing an empty batch shouldn't export.")
+	assert.Len(t, exp.Requests(), 1, "Unexpected export of empty batch.")
+}
+
+func TestCoreFlushInterval(t *testing.T) {
+	exp := &recordingExporter{}
+	core := NewCore(zapcore.DebugLevel, exp, WithFlushInterval(time.Millisecond))
+	require.NoError(t, core.Write(zapcore.Entry{Message: "msg"}, nil))
+
+	assert.Eventually(t, func() bool {
+		return len(exp.Requests()) == 1
+	}, time.Second, time.Millisecond, "Expected periodic export.")
+	require.NoError(t, core.Stop(), "Unexpected error stopping.")
+	require.NoError(t, core.Stop(), "Stop should be idempotent.")
+}
+
+func TestCoreStopFlushes(t *testing.T) {
+	exp := &recordingExporter{}
+	core := NewCore(zapcore.DebugLevel, exp, WithFlushInterval(time.Hour))
+	require.NoError(t, core.Write(zapcore.Entry{Message: "msg"}, nil))
+	require.NoError(t, core.Stop(), "Unexpected error stopping.")
+	assert.Len(t, exp.Requests(), 1, "Expected Stop to export buffered entries.")
+}
+
+func TestSeverityNumber(t *testing.T) {
+	tests := []struct {
+		lvl  zapcore.Level
+		want uint64
+	}{
+		{zapcore.TraceLevel - 1, _severityTrace},
+		{zapcore.TraceLevel, _severityTrace},
+		{zapcore.DebugLevel, _severityDebug},
+		{zapcore.InfoLevel, _severityInfo},
+		{zapcore.WarnLevel, _severityWarn},
+		{zapcore.ErrorLevel, _severityError},
+		{zapcore.DPanicLevel, _severityFatal},
+		{zapcore.PanicLevel, _severityFatal2},
+		{zapcore.FatalLevel, _severityFatal3},
+		{zapcore.FatalLevel + 1, _severityFatal3},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, severityNumber(tt.lvl), "Unexpected severity for %v.", tt.lvl)
+	}
+}
+
+func TestHTTPExporter(t *testing.T) {
+	var status int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Unexpected method.")
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"), "Unexpected content type.")
+		assert.Equal(t, "secret", r.Header.Get("Api-Key"), "Expected custom header.")
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	exp := &HTTPExporter{URL: srv.URL, Header: http.Header{"Api-Key": {"secret"}}}
+
+	status = http.StatusOK
+	assert.NoError(t, exp.Export(context.Background(), []byte{1}), "Unexpected error on success.")
+
+	status = http.StatusServiceUnavailable
+	assert.ErrorContains(t, exp.Export(context.Background(), []byte{1}), "503", "Expected error on failure.")
+}
+
+type pbField struct {
+	num    int
+	varint uint64
+	bytes  []byte
+}
+
+// decodeFields parses a protobuf message into its top-level fields.
+func decodeFields(t testing.TB, b []byte) []pbField {
+	var fields []pbField
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		require.Positive(t, n, "Invalid tag.")
+		b = b[n:]
+		f := pbField{num: int(tag >> 3)}
+		switch tag & 7 {
+		case _wireVarint:
+			f.varint, n = binary.Uvarint(b)
+			require.Positive(t, n, "Invalid varint.")
+			b = b[n:]
+		case _wireFixed64:
+			f.varint, b = binary.LittleEndian.Uint64(b), b[8:]
+		case _wireBytes:
+			l, n := binary.Uvarint(b)
+			require.Positive(t, n, "Invalid length.")
+			f.bytes, b = b[n:n+int(l)], b[n+int(l):]
+		default:
+			require.FailNow(t, "Unexpected wire type.")
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func decodeField(t testing.TB, b []byte, num int) []pbField {
+	var matched []pbField
+	for _, f := range decodeFields(t, b) {
+		if f.num == num {
+			matched = append(matched, f)
+		}
+	}
+	return matched
+}
+
+func decodeMessages(t testing.TB, b []byte, num int) [][]byte {
+	var msgs [][]byte
+	for _, f := range decodeField(t, b, num) {
+		msgs = append(msgs, f.bytes)
+	}
+	return msgs
+}
+
+func decodeAttributes(t testing.TB, b []byte, num int) map[string]interface{} {
+	attrs := make(map[string]interface{})
+	for _, kv := range decodeMessages(t, b, num) {
+		key := string(decodeField(t, kv, _keyValueKey)[0].bytes)
+		attrs[key] = decodeAnyValue(t, decodeMessages(t, kv, _keyValueValue)[0])
+	}
+	return attrs
+}
+
+func decodeAnyValue(t testing.TB, b []byte) interface{} {
+	fields := decodeFields(t, b)
+	if len(fields) == 0 {
+		return nil
+	}
+	f := fields[0]
+	switch f.num {
+	case _anyValueString:
+		return string(f.bytes)
+	case _anyValueBool:
+		return f.varint == 1
+	case _anyValueInt:
+		return int64(f.varint)
+	case _anyValueDouble:
+		return math.Float64frombits(f.varint)
+	case _anyValueBytes:
+		return f.bytes
+	case _anyValueArray:
+		arr := []interface{}{}
+		for _, v := range decodeMessages(t, f.bytes, _listValues) {
+			arr = append(arr, decodeAnyValue(t, v))
+		}
+		return arr
+	case _anyValueKVList:
+		return decodeAttributes(t, f.bytes, _listValues)
+	}
+	require.FailNow(t, "Unexpected AnyValue field.")
+	return nil
+}
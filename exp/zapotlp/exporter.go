@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapotlp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Exporter sends serialized OTLP ExportLogsServiceRequest protobufs to a
+// collector.
+//
+// HTTPExporter implements OTLP/HTTP. To export over OTLP/gRPC, implement
+// Exporter with a gRPC client that sends the request bytes as-is to the
+// opentelemetry.proto.collector.logs.v1.LogsService/Export method.
+type Exporter interface {
+	Export(ctx context.Context, request []byte) error
+}
+
+// ExporterFunc adapts a function into an Exporter.
+type ExporterFunc func(ctx context.Context, request []byte) error
+
+// Export calls f(ctx, request).
+func (f ExporterFunc) Export(ctx context.Context, request []byte) error {
+	return f(ctx, request)
+}
+
+// HTTPExporter exports logs using OTLP/HTTP with binary protobuf encoding.
+type HTTPExporter struct {
+	// URL is the full URL of the logs endpoint, usually ending in
+	// "/v1/logs". For example, "http://localhost:4318/v1/logs".
+	URL string
+
+	// Header holds extra headers to send with each request, such as
+	// authentication.
+	Header http.Header
+
+	// Client sends requests. Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+var _ Exporter = (*HTTPExporter)(nil)
+
+// Export POSTs the request to the configured URL. Responses other than 2xx
+// are reported as errors.
+func (e *HTTPExporter) Export(ctx context.Context, request []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL, bytes.NewReader(request))
+	if err != nil {
+		return err
+	}
+	for k, vs := range e.Header {
+		req.Header[k] = vs
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+
+	client := e.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("otlp export to %v failed: %v", e.URL, resp.Status)
+	}
+	return nil
+}
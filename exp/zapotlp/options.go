@@ -0,0 +1,85 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapotlp
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// An Option configures a Core.
+type Option interface {
+	apply(*batcher)
+}
+
+// optionFunc wraps a func so it satisfies the Option interface.
+type optionFunc func(*batcher)
+
+func (f optionFunc) apply(b *batcher) {
+	f(b)
+}
+
+// WithResource sets the attributes of the resource producing the logs, such
+// as service.name or host.name. They're sent once per batch rather than with
+// every entry.
+func WithResource(fields ...zapcore.Field) Option {
+	return optionFunc(func(b *batcher) {
+		b.resource = appendAttributes(nil, _resourceAttributes, fields)
+	})
+}
+
+// WithMaxBatchSize sets the number of entries that triggers an export.
+// Defaults to 512.
+func WithMaxBatchSize(n int) Option {
+	return optionFunc(func(b *batcher) {
+		if n > 0 {
+			b.maxBatchSize = n
+		}
+	})
+}
+
+// WithFlushInterval sets how often buffered entries are exported, regardless
+// of batch size. Defaults to one second. A zero or negative interval disables
+// periodic exports.
+func WithFlushInterval(d time.Duration) Option {
+	return optionFunc(func(b *batcher) {
+		b.flushInterval = d
+	})
+}
+
+// WithExportTimeout bounds each call to the Exporter. Defaults to ten
+// seconds.
+func WithExportTimeout(d time.Duration) Option {
+	return optionFunc(func(b *batcher) {
+		if d > 0 {
+			b.exportTimeout = d
+		}
+	})
+}
+
+// WithClock configures the clock used to record observed timestamps and to
+// schedule periodic exports. Defaults to the system clock.
+func WithClock(clock zapcore.Clock) Option {
+	return optionFunc(func(b *batcher) {
+		b.clock = clock
+	})
+}
@@ -0,0 +1,306 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapotlp
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Field numbers from the OTLP protobuf definitions in
+// opentelemetry/proto/collector/logs/v1, logs/v1, resource/v1, and common/v1.
+const (
+	// ExportLogsServiceRequest
+	_requestResourceLogs = 1
+
+	// ResourceLogs
+	_resourceLogsResource  = 1
+	_resourceLogsScopeLogs = 2
+
+	// Resource
+	_resourceAttributes = 1
+
+	// ScopeLogs
+	_scopeLogsScope      = 1
+	_scopeLogsLogRecords = 2
+
+	// InstrumentationScope
+	_scopeName = 1
+
+	// LogRecord
+	_logRecordTimeUnixNano         = 1
+	_logRecordSeverityNumber       = 2
+	_logRecordSeverityText         = 3
+	_logRecordBody                 = 5
+	_logRecordAttributes           = 6
+	_logRecordObservedTimeUnixNano = 11
+
+	// KeyValue
+	_keyValueKey   = 1
+	_keyValueValue = 2
+
+	// AnyValue
+	_anyValueString = 1
+	_anyValueBool   = 2
+	_anyValueInt    = 3
+	_anyValueDouble = 4
+	_anyValueArray  = 5
+	_anyValueKVList = 6
+	_anyValueBytes  = 7
+
+	// ArrayValue and KeyValueList
+	_listValues = 1
+)
+
+// Protobuf wire types.
+const (
+	_wireVarint  = 0
+	_wireFixed64 = 1
+	_wireBytes   = 2
+)
+
+// Severity numbers from the OpenTelemetry log data model.
+const (
+	_severityTrace  = 1
+	_severityDebug  = 5
+	_severityInfo   = 9
+	_severityWarn   = 13
+	_severityError  = 17
+	_severityFatal  = 21
+	_severityFatal2 = 22
+	_severityFatal3 = 23
+)
+
+// severityNumber maps a zap level onto the OpenTelemetry severity range.
+func severityNumber(lvl zapcore.Level) uint64 {
+	switch lvl {
+	case zapcore.TraceLevel:
+		return _severityTrace
+	case zapcore.DebugLevel:
+		return _severityDebug
+	case zapcore.InfoLevel:
+		return _severityInfo
+	case zapcore.WarnLevel:
+		return _severityWarn
+	case zapcore.ErrorLevel:
+		return _severityError
+	case zapcore.DPanicLevel:
+		return _severityFatal
+	case zapcore.PanicLevel:
+		return _severityFatal2
+	case zapcore.FatalLevel:
+		return _severityFatal3
+	}
+	if lvl < zapcore.TraceLevel {
+		return _severityTrace
+	}
+	return _severityFatal3
+}
+
+func appendTag(b []byte, field int, wireType int) []byte {
+	return binary.AppendUvarint(b, uint64(field)<<3|uint64(wireType))
+}
+
+func appendVarintField(b []byte, field int, v uint64) []byte {
+	return binary.AppendUvarint(appendTag(b, field, _wireVarint), v)
+}
+
+func appendFixed64Field(b []byte, field int, v uint64) []byte {
+	return binary.LittleEndian.AppendUint64(appendTag(b, field, _wireFixed64), v)
+}
+
+func appendStringField(b []byte, field int, s string) []byte {
+	b = binary.AppendUvarint(appendTag(b, field, _wireBytes), uint64(len(s)))
+	return append(b, s...)
+}
+
+func appendBytesField(b []byte, field int, bs []byte) []byte {
+	b = binary.AppendUvarint(appendTag(b, field, _wireBytes), uint64(len(bs)))
+	return append(b, bs...)
+}
+
+// appendMessageField appends an embedded message written by fn. Since the
+// message is length-prefixed, it's written first and the prefix is inserted
+// afterwards.
+func appendMessageField(b []byte, field int, fn func([]byte) []byte) []byte {
+	b = appendTag(b, field, _wireBytes)
+	start := len(b)
+	b = fn(b)
+	n := len(b) - start
+
+	var prefix [binary.MaxVarintLen64]byte
+	l := binary.PutUvarint(prefix[:], uint64(n))
+	b = append(b, prefix[:l]...)
+	copy(b[start+l:], b[start:start+n])
+	copy(b[start:], prefix[:l])
+	return b
+}
+
+// appendAttributes appends fields as repeated KeyValue messages with the given
+// field number. Keys are sorted so that output is deterministic.
+func appendAttributes(b []byte, field int, fields []zapcore.Field) []byte {
+	if len(fields) == 0 {
+		return b
+	}
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	return appendKeyValues(b, field, enc.Fields)
+}
+
+func appendKeyValues(b []byte, field int, m map[string]interface{}) []byte {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		b = appendKeyValue(b, field, k, m[k])
+	}
+	return b
+}
+
+func appendKeyValue(b []byte, field int, key string, value interface{}) []byte {
+	return appendMessageField(b, field, func(b []byte) []byte {
+		b = appendStringField(b, _keyValueKey, key)
+		return appendMessageField(b, _keyValueValue, func(b []byte) []byte {
+			return appendAnyValue(b, value)
+		})
+	})
+}
+
+// appendAnyValue appends the contents of an AnyValue message holding v, as
+// produced by zapcore.MapObjectEncoder.
+func appendAnyValue(b []byte, v interface{}) []byte {
+	switch v := v.(type) {
+	case nil:
+		// An empty AnyValue represents null.
+		return b
+	case string:
+		return appendStringField(b, _anyValueString, v)
+	case bool:
+		var i uint64
+		if v {
+			i = 1
+		}
+		return appendVarintField(b, _anyValueBool, i)
+	case int:
+		return appendVarintField(b, _anyValueInt, uint64(v))
+	case int64:
+		return appendVarintField(b, _anyValueInt, uint64(v))
+	case int32:
+		return appendVarintField(b, _anyValueInt, uint64(v))
+	case int16:
+		return appendVarintField(b, _anyValueInt, uint64(v))
+	case int8:
+		return appendVarintField(b, _anyValueInt, uint64(v))
+	case uint:
+		return appendUint(b, uint64(v))
+	case uint64:
+		return appendUint(b, v)
+	case uint32:
+		return appendUint(b, uint64(v))
+	case uint16:
+		return appendUint(b, uint64(v))
+	case uint8:
+		return appendUint(b, uint64(v))
+	case uintptr:
+		return appendUint(b, uint64(v))
+	case float64:
+		return appendFixed64Field(b, _anyValueDouble, math.Float64bits(v))
+	case float32:
+		return appendFixed64Field(b, _anyValueDouble, math.Float64bits(float64(v)))
+	case complex128:
+		return appendStringField(b, _anyValueString, strconv.FormatComplex(v, 'f', -1, 128))
+	case complex64:
+		return appendStringField(b, _anyValueString, strconv.FormatComplex(complex128(v), 'f', -1, 64))
+	case []byte:
+		return appendBytesField(b, _anyValueBytes, v)
+	case time.Time:
+		return appendStringField(b, _anyValueString, v.Format(time.RFC3339Nano))
+	case time.Duration:
+		return appendStringField(b, _anyValueString, v.String())
+	case []interface{}:
+		return appendMessageField(b, _anyValueArray, func(b []byte) []byte {
+			for _, elem := range v {
+				b = appendMessageField(b, _listValues, func(b []byte) []byte {
+					return appendAnyValue(b, elem)
+				})
+			}
+			return b
+		})
+	case map[string]interface{}:
+		return appendMessageField(b, _anyValueKVList, func(b []byte) []byte {
+			return appendKeyValues(b, _listValues, v)
+		})
+	default:
+		// Values added with AddReflected.
+		bs, err := json.Marshal(v)
+		if err != nil {
+			return appendStringField(b, _anyValueString, fmt.Sprint(v))
+		}
+		return appendStringField(b, _anyValueString, string(bs))
+	}
+}
+
+// appendUint appends an unsigned integer as an int_value, or as a string if
+// it overflows int64.
+func appendUint(b []byte, v uint64) []byte {
+	if v > math.MaxInt64 {
+		return appendStringField(b, _anyValueString, strconv.FormatUint(v, 10))
+	}
+	return appendVarintField(b, _anyValueInt, v)
+}
+
+// appendLogRecord appends the contents of a LogRecord message. attrs holds
+// pre-encoded attributes bound with With.
+func appendLogRecord(b []byte, ent zapcore.Entry, observed time.Time, attrs []byte, fields []zapcore.Field) []byte {
+	if !ent.Time.IsZero() {
+		b = appendFixed64Field(b, _logRecordTimeUnixNano, uint64(ent.Time.UnixNano()))
+	}
+	b = appendFixed64Field(b, _logRecordObservedTimeUnixNano, uint64(observed.UnixNano()))
+	b = appendVarintField(b, _logRecordSeverityNumber, severityNumber(ent.Level))
+	b = appendStringField(b, _logRecordSeverityText, ent.Level.CapitalString())
+	b = appendMessageField(b, _logRecordBody, func(b []byte) []byte {
+		return appendStringField(b, _anyValueString, ent.Message)
+	})
+	b = append(b, attrs...)
+	b = appendAttributes(b, _logRecordAttributes, fields)
+	if ent.Caller.Defined {
+		b = appendKeyValue(b, _logRecordAttributes, "code.filepath", ent.Caller.File)
+		b = appendKeyValue(b, _logRecordAttributes, "code.lineno", ent.Caller.Line)
+		if ent.Caller.Function != "" {
+			b = appendKeyValue(b, _logRecordAttributes, "code.function", ent.Caller.Function)
+		}
+	}
+	if ent.Stack != "" {
+		b = appendKeyValue(b, _logRecordAttributes, "code.stacktrace", ent.Stack)
+	}
+	return b
+}
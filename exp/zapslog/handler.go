@@ -124,8 +124,10 @@ func convertSlogLevel(l slog.Level) zapcore.Level {
 		return zapcore.WarnLevel
 	case l >= slog.LevelInfo:
 		return zapcore.InfoLevel
-	default:
+	case l >= slog.LevelDebug:
 		return zapcore.DebugLevel
+	default:
+		return zapcore.TraceLevel
 	}
 }
 
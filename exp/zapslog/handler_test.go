@@ -55,6 +55,24 @@ func TestAddCaller(t *testing.T) {
 	)
 }
 
+func TestConvertSlogLevel(t *testing.T) {
+	tests := []struct {
+		give slog.Level
+		want zapcore.Level
+	}{
+		{slog.LevelDebug - 4, zapcore.TraceLevel},
+		{slog.LevelDebug - 1, zapcore.TraceLevel},
+		{slog.LevelDebug, zapcore.DebugLevel},
+		{slog.LevelInfo, zapcore.InfoLevel},
+		{slog.LevelWarn, zapcore.WarnLevel},
+		{slog.LevelError, zapcore.ErrorLevel},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, convertSlogLevel(tt.give), "Unexpected level for %v.", tt.give)
+	}
+}
+
 func TestAddStack(t *testing.T) {
 	fac, logs := observer.New(zapcore.DebugLevel)
 	sl := slog.New(NewHandler(fac, AddStacktraceAt(slog.LevelDebug)))
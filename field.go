@@ -21,10 +21,20 @@
 package zap
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"runtime"
 	"time"
+	"unsafe"
 
+	"go.uber.org/multierr"
+	"go.uber.org/zap/internal/bufferpool"
+	"go.uber.org/zap/internal/monotonic"
 	"go.uber.org/zap/internal/stacktrace"
 	"go.uber.org/zap/zapcore"
 )
@@ -59,6 +69,15 @@ func Binary(key string, val []byte) Field {
 	return Field{Key: key, Type: zapcore.BinaryType, Interface: val}
 }
 
+// Hex constructs a field that carries a binary blob, written as a
+// lower-case hexadecimal string whatever the encoder's configuration. Hex is
+// easier to grep for than base64, as for binary IDs and hashes. To write
+// every binary field as hex, set the EncoderConfig's EncodeBinary to
+// zapcore.HexBinary instead.
+func Hex(key string, val []byte) Field {
+	return Field{Key: key, Type: zapcore.BinaryType, Interface: val, String: zapcore.HexBinary.String()}
+}
+
 // Bool constructs a field that carries a bool.
 func Bool(key string, val bool) Field {
 	var ival int64
@@ -332,16 +351,93 @@ func Reflect(key string, val interface{}) Field {
 //
 // This helps prevent key collisions when injecting loggers into sub-components
 // or third-party libraries.
+//
+// Close the namespace with CloseNamespace, or use Dict to add a fixed set of
+// fields under a key.
 func Namespace(key string) Field {
 	return Field{Key: key, Type: zapcore.NamespaceType}
 }
 
+// CloseNamespace ends the most recently opened namespace, so that subsequent
+// fields are added to its parent again. This lets helpers add namespaced
+// fields without affecting the fields that follow:
+//
+//	logger.Info("request",
+//		zap.Namespace("http"),
+//		zap.String("method", "GET"),
+//		zap.CloseNamespace(),
+//		zap.Int("attempt", 2), // top-level
+//	)
+//
+// It's a no-op if no namespace is open. The built-in encoders support it;
+// custom ObjectEncoders that don't implement a CloseNamespace method keep
+// later fields in the namespace.
+func CloseNamespace() Field {
+	return Field{Type: zapcore.CloseNamespaceType}
+}
+
 // Stringer constructs a field with the given key and the output of the value's
 // String method. The Stringer's String method is called lazily.
 func Stringer(key string, val fmt.Stringer) Field {
 	return Field{Key: key, Type: zapcore.StringerType, Interface: val}
 }
 
+// IPAddr constructs a field that carries a net.IP, formatted lazily as with
+// its String method. A nil IP is logged as nil.
+func IPAddr(key string, val net.IP) Field {
+	if val == nil {
+		return nilField(key)
+	}
+	return Field{Key: key, Type: zapcore.StringerType, Interface: val}
+}
+
+// NetipAddr constructs a field that carries a netip.Addr, formatted lazily
+// as with its String method.
+func NetipAddr(key string, val netip.Addr) Field {
+	return Field{Key: key, Type: zapcore.StringerType, Interface: val}
+}
+
+// URL constructs a field that carries a *url.URL, formatted lazily as with
+// its String method. A nil URL is logged as nil.
+//
+// Note that String includes any password in the URL; use url.URL.Redacted
+// with String to omit it.
+func URL(key string, val *url.URL) Field {
+	if val == nil {
+		return nilField(key)
+	}
+	return Field{Key: key, Type: zapcore.StringerType, Interface: val}
+}
+
+// We need a function with the signature (string, T) for zap.Any.
+func urlValue(key string, val url.URL) Field {
+	return URL(key, &val)
+}
+
+// UUID constructs a field that carries a 16-byte UUID, formatted lazily in
+// its canonical, hyphenated form, as in
+// "123e4567-e89b-12d3-a456-426614174000".
+func UUID(key string, val [16]byte) Field {
+	return Field{Key: key, Type: zapcore.StringerType, Interface: uuid(val)}
+}
+
+type uuid [16]byte
+
+func (u uuid) String() string {
+	const hex = "0123456789abcdef"
+	var buf [36]byte
+	j := 0
+	for i, b := range u {
+		if i == 4 || i == 6 || i == 8 || i == 10 {
+			buf[j] = '-'
+			j++
+		}
+		buf[j], buf[j+1] = hex[b>>4], hex[b&0x0f]
+		j += 2
+	}
+	return string(buf[:])
+}
+
 // Time constructs a Field with the given key and value. The encoder
 // controls how the time is serialized.
 func Time(key string, val time.Time) Field {
@@ -351,6 +447,68 @@ func Time(key string, val time.Time) Field {
 	return Field{Key: key, Type: zapcore.TimeType, Integer: val.UnixNano(), Interface: val.Location()}
 }
 
+// TimeLayout constructs a field that carries a time, written as a string in
+// the given layout, as understood by time.Time.Format, rather than as the
+// encoder's EncodeTime would write it. Like Time, it defers formatting
+// until the field is encoded.
+func TimeLayout(key string, val time.Time, layout string) Field {
+	if val.Before(_minTimeInt64) || val.After(_maxTimeInt64) {
+		return String(key, val.Format(layout))
+	}
+	return Field{Key: key, Type: zapcore.TimeLayoutType, Integer: val.UnixNano(), String: layout, Interface: val.Location()}
+}
+
+// TimeRFC3339 constructs a field that carries a time, written as an RFC 3339
+// string with second precision, such as "2006-01-02T15:04:05Z07:00".
+func TimeRFC3339(key string, val time.Time) Field {
+	return TimeLayout(key, val, time.RFC3339)
+}
+
+// TimeRFC3339Nano is like TimeRFC3339, but writes fractional seconds up to
+// nanosecond precision, dropping trailing zeros.
+func TimeRFC3339Nano(key string, val time.Time) Field {
+	return TimeLayout(key, val, time.RFC3339Nano)
+}
+
+// TimeUnix constructs a field that carries a time as an integer number of
+// seconds since the Unix epoch.
+func TimeUnix(key string, val time.Time) Field {
+	return Int64(key, val.Unix())
+}
+
+// TimeUnixMilli constructs a field that carries a time as an integer number
+// of milliseconds since the Unix epoch.
+func TimeUnixMilli(key string, val time.Time) Field {
+	return Int64(key, val.UnixMilli())
+}
+
+// Since constructs a field that carries the time elapsed since start, as a
+// time.Duration. The elapsed time is measured when the field is encoded, not
+// when it's constructed, so that entries that are never written, such as
+// those dropped by a sampler, don't pay for it:
+//
+//	start := time.Now()
+//	handle(req)
+//	logger.Info("handled request", zap.Since("latency", start))
+//
+// If start has a monotonic clock reading, as times returned by time.Now do,
+// the elapsed time is measured on the monotonic clock, like time.Since.
+func Since(key string, start time.Time) Field {
+	return Field{Key: key, Type: zapcore.SinceType, Integer: monotonic.Offset(start)}
+}
+
+// ElapsedMillis is like Since, but writes the elapsed time as an integer
+// number of milliseconds.
+func ElapsedMillis(key string, start time.Time) Field {
+	return Field{Key: key, Type: zapcore.SinceType, Integer: monotonic.Offset(start), String: zapcore.SinceMillis}
+}
+
+// ElapsedSeconds is like Since, but writes the elapsed time as a
+// floating-point number of seconds.
+func ElapsedSeconds(key string, start time.Time) Field {
+	return Field{Key: key, Type: zapcore.SinceType, Integer: monotonic.Offset(start), String: zapcore.SinceSeconds}
+}
+
 // Timep constructs a field that carries a *time.Time. The returned Field will safely
 // and explicitly represent `nil` when appropriate.
 func Timep(key string, val *time.Time) Field {
@@ -378,6 +536,177 @@ func StackSkip(key string, skip int) Field {
 	return String(key, stacktrace.Take(skip+1)) // skip StackSkip
 }
 
+// A StackOption configures a field built by LazyStack or LazyStackSkip.
+type StackOption interface {
+	applyStack(*lazyStack)
+}
+
+type stackOptionFunc func(*lazyStack)
+
+func (f stackOptionFunc) applyStack(s *lazyStack) {
+	f(s)
+}
+
+// StackMaxDepth limits a stacktrace to its innermost n frames. Values of n
+// below 1 leave the stacktrace unlimited.
+func StackMaxDepth(n int) StackOption {
+	return stackOptionFunc(func(s *lazyStack) {
+		s.maxDepth = n
+	})
+}
+
+// StackFrames encodes a stacktrace as an array of objects with "function",
+// "file", and "line" keys, instead of as a single newline-separated string.
+func StackFrames() StackOption {
+	return stackOptionFunc(func(s *lazyStack) {
+		s.structured = true
+	})
+}
+
+// LazyStack constructs a field that stores a stacktrace of the current
+// goroutine under the provided key. Unlike Stack, it only records program
+// counters when called, and defers resolving them to function names and
+// source positions until the field is encoded, so it's cheap to pass to a
+// log call that turns out to be disabled.
+func LazyStack(key string, opts ...StackOption) Field {
+	return LazyStackSkip(key, 1, opts...) // skip LazyStack
+}
+
+// LazyStackSkip constructs a field similarly to LazyStack, but also skips
+// the given number of frames from the top of the stacktrace.
+func LazyStackSkip(key string, skip int, opts ...StackOption) Field {
+	s := &lazyStack{}
+	for _, opt := range opts {
+		opt.applyStack(s)
+	}
+
+	// Keep one more PC than there are frames to show. Every PC resolves to
+	// at least one frame, so the last one is only used to tell whether the
+	// final frame shown is the runtime frame that ends every stack.
+	max := 0
+	if s.maxDepth > 0 {
+		max = s.maxDepth + 1
+	}
+	s.pcs = stacktrace.PCs(skip+1, max) // skip LazyStackSkip
+
+	if s.structured {
+		return Array(key, s)
+	}
+	return Stringer(key, s)
+}
+
+// lazyStack is a captured stacktrace that is resolved when it's encoded.
+type lazyStack struct {
+	pcs        []uintptr
+	maxDepth   int
+	structured bool
+}
+
+// frames calls fn with each frame in the stacktrace, leaving out the final
+// runtime.main or runtime.goexit frame, as stacktrace.Formatter does.
+func (s *lazyStack) frames(fn func(runtime.Frame)) {
+	frames := runtime.CallersFrames(s.pcs)
+	n := 0
+	for frame, more := frames.Next(); more; frame, more = frames.Next() {
+		if s.maxDepth > 0 && n >= s.maxDepth {
+			return
+		}
+		fn(frame)
+		n++
+	}
+}
+
+func (s *lazyStack) String() string {
+	buf := bufferpool.Get()
+	defer buf.Free()
+
+	stackfmt := stacktrace.NewFormatter(buf)
+	s.frames(stackfmt.FormatFrame)
+	return buf.String()
+}
+
+func (s *lazyStack) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	var err error
+	s.frames(func(frame runtime.Frame) {
+		err = multierr.Append(err, enc.AppendObject(stackFrame(frame)))
+	})
+	return err
+}
+
+type stackFrame runtime.Frame
+
+func (f stackFrame) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("function", f.Function)
+	enc.AddString("file", f.File)
+	enc.AddInt("line", f.Line)
+	return nil
+}
+
+// Primitive is the set of types that Val and Vals can log without
+// reflection: booleans, strings, and numbers, including named types defined
+// on top of them.
+type Primitive interface {
+	~bool | ~string |
+		~int | ~int64 | ~int32 | ~int16 | ~int8 |
+		~uint | ~uint64 | ~uint32 | ~uint16 | ~uint8 | ~uintptr |
+		~float64 | ~float32 | ~complex128 | ~complex64
+}
+
+// Val constructs a field with the given key and value, choosing the field
+// type from the value's underlying type. Unlike Any, it handles named types
+// such as
+//
+//	type UserID int64
+//	type Region string
+//
+// without falling back to reflection.
+func Val[T Primitive](key string, v T) Field {
+	// T's underlying type is one of the types in Primitive, so it's safe to
+	// reinterpret v as that type once we know its kind.
+	p := unsafe.Pointer(&v)
+	switch primitiveKind[T]() {
+	case reflect.Bool:
+		return Bool(key, *(*bool)(p))
+	case reflect.String:
+		return String(key, *(*string)(p))
+	case reflect.Int:
+		return Int(key, *(*int)(p))
+	case reflect.Int64:
+		return Int64(key, *(*int64)(p))
+	case reflect.Int32:
+		return Int32(key, *(*int32)(p))
+	case reflect.Int16:
+		return Int16(key, *(*int16)(p))
+	case reflect.Int8:
+		return Int8(key, *(*int8)(p))
+	case reflect.Uint:
+		return Uint(key, *(*uint)(p))
+	case reflect.Uint64:
+		return Uint64(key, *(*uint64)(p))
+	case reflect.Uint32:
+		return Uint32(key, *(*uint32)(p))
+	case reflect.Uint16:
+		return Uint16(key, *(*uint16)(p))
+	case reflect.Uint8:
+		return Uint8(key, *(*uint8)(p))
+	case reflect.Uintptr:
+		return Uintptr(key, *(*uintptr)(p))
+	case reflect.Float64:
+		return Float64(key, *(*float64)(p))
+	case reflect.Float32:
+		return Float32(key, *(*float32)(p))
+	case reflect.Complex128:
+		return Complex128(key, *(*complex128)(p))
+	default: // reflect.Complex64
+		return Complex64(key, *(*complex64)(p))
+	}
+}
+
+// primitiveKind returns the kind of T's underlying type without allocating.
+func primitiveKind[T Primitive]() reflect.Kind {
+	return reflect.TypeOf((*T)(nil)).Elem().Kind()
+}
+
 // Duration constructs a field with the given key and value. The encoder
 // controls how the duration is serialized.
 func Duration(key string, val time.Duration) Field {
@@ -414,6 +743,67 @@ func Inline(val zapcore.ObjectMarshaler) Field {
 	}
 }
 
+// RawJSON constructs a field that carries pre-serialized JSON, such as a
+// webhook payload or a stored document. The JSON encoder embeds it verbatim
+// instead of decoding and re-encoding it through Reflect; other encoders,
+// including the console encoder, add it as a string.
+//
+// The JSON encoder validates the value first and adds invalid JSON as a
+// string, so a malformed value can't corrupt the rest of the entry.
+func RawJSON(key string, val []byte) Field {
+	return Field{Key: key, Type: zapcore.RawJSONType, Interface: val}
+}
+
+// We need a function with the signature (string, T) for zap.Any. A nil
+// json.RawMessage marshals as null, so it's logged as nil.
+func rawJSONMessage(key string, val json.RawMessage) Field {
+	if val == nil {
+		return nilField(key)
+	}
+	return RawJSON(key, val)
+}
+
+// Lazy constructs a field whose value is computed by calling fn only when the
+// field is encoded; that is, after the entry has passed level checks and
+// sampling. The result is logged as if passed to Any.
+//
+// Use Lazy for values that are expensive to compute, such as summaries in
+// Debug statements that are usually disabled:
+//
+//	logger.Debug("cache state", zap.Lazy("summary", func() any {
+//	  return cache.Summary()
+//	}))
+//
+// Note that Logger.With encodes fields immediately; use Logger.WithLazy to
+// defer evaluation of bound fields too.
+func Lazy(key string, fn func() any) Field {
+	return Field{
+		// The key is unused when encoding, but lets cores that inspect
+		// fields (like samplers with a keyer) see it without calling fn.
+		Key:  key,
+		Type: zapcore.InlineMarshalerType,
+		Interface: lazyField(func() Field {
+			return Any(key, fn())
+		}),
+	}
+}
+
+// LazyField constructs a field that is built by calling fn only when it's
+// encoded. Unlike Lazy, fn chooses both the key and the type of the field.
+func LazyField(fn func() Field) Field {
+	return Field{
+		Type:      zapcore.InlineMarshalerType,
+		Interface: lazyField(fn),
+	}
+}
+
+type lazyField func() Field
+
+func (f lazyField) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	f().AddTo(enc)
+	return nil
+}
+
 // Dict constructs a field containing the provided key-value pairs.
 // It acts similar to [Object], but with the fields specified as arguments.
 func Dict(key string, val ...Field) Field {
@@ -441,6 +831,22 @@ func DictObject(val ...Field) zapcore.ObjectMarshaler {
 	return dictObject(val)
 }
 
+// StaticDict constructs a field like Dict for key-value pairs that never
+// change, such as build or deployment metadata. The JSON and console
+// encoders encode it once per encoder configuration and reuse the result, so
+// a StaticDict built once and logged repeatedly, or bound with With, costs
+// little more than a string per entry. See [zapcore.NewStaticObject] for the
+// restrictions on its fields.
+//
+//	var buildInfo = zap.StaticDict("build",
+//		zap.String("version", version),
+//		zap.String("commit", commit),
+//		zap.Time("built", builtAt),
+//	)
+func StaticDict(key string, val ...Field) Field {
+	return Object(key, zapcore.NewStaticObject(val...))
+}
+
 // We discovered an issue where zap.Any can cause a performance degradation
 // when used in new goroutines.
 //
@@ -611,6 +1017,18 @@ func Any(key string, value interface{}) Field {
 		c = anyFieldC[*time.Duration](Durationp)
 	case []time.Duration:
 		c = anyFieldC[[]time.Duration](Durations)
+	case json.RawMessage:
+		c = anyFieldC[json.RawMessage](rawJSONMessage)
+	case net.IP:
+		c = anyFieldC[net.IP](IPAddr)
+	case netip.Addr:
+		c = anyFieldC[netip.Addr](NetipAddr)
+	case *url.URL:
+		c = anyFieldC[*url.URL](URL)
+	case url.URL:
+		c = anyFieldC[url.URL](urlValue)
+	case [16]byte:
+		c = anyFieldC[[16]byte](UUID)
 	case error:
 		c = anyFieldC[error](NamedError)
 	case []error:
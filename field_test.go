@@ -21,16 +21,23 @@
 package zap
 
 import (
+	"encoding/json"
+	"fmt"
 	"math"
 	"net"
+	"net/netip"
+	"net/url"
 	"regexp"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/internal/stacktrace"
 	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 type username string
@@ -88,6 +95,9 @@ func TestFieldConstructors(t *testing.T) {
 		uint8Val      = uint8(1)
 		uintptrVal    = uintptr(1)
 		nilErr        error
+		netipAddr     = netip.MustParseAddr("::1")
+		urlVal        = &url.URL{Scheme: "https", Host: "example.com", Path: "/a"}
+		uuidVal       = [16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
 	)
 
 	tests := []struct {
@@ -97,6 +107,7 @@ func TestFieldConstructors(t *testing.T) {
 	}{
 		{"Skip", Field{Type: zapcore.SkipType}, Skip()},
 		{"Binary", Field{Key: "k", Type: zapcore.BinaryType, Interface: []byte("ab12")}, Binary("k", []byte("ab12"))},
+		{"Hex", Field{Key: "k", Type: zapcore.BinaryType, Interface: []byte("ab12"), String: "hex"}, Hex("k", []byte("ab12"))},
 		{"Bool", Field{Key: "k", Type: zapcore.BoolType, Integer: 1}, Bool("k", true)},
 		{"Bool", Field{Key: "k", Type: zapcore.BoolType, Integer: 0}, Bool("k", false)},
 		{"ByteString", Field{Key: "k", Type: zapcore.ByteStringType, Interface: []byte("ab12")}, ByteString("k", []byte("ab12"))},
@@ -115,6 +126,12 @@ func TestFieldConstructors(t *testing.T) {
 		{"Time", Field{Key: "k", Type: zapcore.TimeType, Integer: math.MaxInt64, Interface: time.UTC}, Time("k", time.Unix(0, math.MaxInt64).In(time.UTC))},
 		{"Time", Field{Key: "k", Type: zapcore.TimeFullType, Interface: time.Time{}}, Time("k", time.Time{})},
 		{"Time", Field{Key: "k", Type: zapcore.TimeFullType, Interface: time.Unix(math.MaxInt64, 0)}, Time("k", time.Unix(math.MaxInt64, 0))},
+		{"TimeLayout", Field{Key: "k", Type: zapcore.TimeLayoutType, Integer: 1000, String: time.Kitchen, Interface: time.UTC}, TimeLayout("k", time.Unix(0, 1000).In(time.UTC), time.Kitchen)},
+		{"TimeLayout", String("k", "0001-01-01"), TimeLayout("k", time.Time{}, "2006-01-02")},
+		{"TimeRFC3339", Field{Key: "k", Type: zapcore.TimeLayoutType, Integer: 1000, String: time.RFC3339, Interface: time.UTC}, TimeRFC3339("k", time.Unix(0, 1000).In(time.UTC))},
+		{"TimeRFC3339Nano", Field{Key: "k", Type: zapcore.TimeLayoutType, Integer: 1000, String: time.RFC3339Nano, Interface: time.UTC}, TimeRFC3339Nano("k", time.Unix(0, 1000).In(time.UTC))},
+		{"TimeUnix", Field{Key: "k", Type: zapcore.Int64Type, Integer: 1500}, TimeUnix("k", time.Unix(1500, 999))},
+		{"TimeUnixMilli", Field{Key: "k", Type: zapcore.Int64Type, Integer: 1500}, TimeUnixMilli("k", time.Unix(1, 500_000_000))},
 		{"Uint", Field{Key: "k", Type: zapcore.Uint64Type, Integer: 1}, Uint("k", 1)},
 		{"Uint64", Field{Key: "k", Type: zapcore.Uint64Type, Integer: 1}, Uint64("k", 1)},
 		{"Uint32", Field{Key: "k", Type: zapcore.Uint32Type, Integer: 1}, Uint32("k", 1)},
@@ -126,6 +143,8 @@ func TestFieldConstructors(t *testing.T) {
 		{"Stringer", Field{Key: "k", Type: zapcore.StringerType, Interface: addr}, Stringer("k", addr)},
 		{"Object", Field{Key: "k", Type: zapcore.ObjectMarshalerType, Interface: name}, Object("k", name)},
 		{"Inline", Field{Type: zapcore.InlineMarshalerType, Interface: name}, Inline(name)},
+		{"RawJSON", Field{Key: "k", Type: zapcore.RawJSONType, Interface: []byte(`{}`)}, RawJSON("k", []byte(`{}`))},
+		{"CloseNamespace", Field{Type: zapcore.CloseNamespaceType}, CloseNamespace()},
 		{"Any:ObjectMarshaler", Any("k", name), Object("k", name)},
 		{"Any:ArrayMarshaler", Any("k", bools([]bool{true})), Array("k", bools([]bool{true}))},
 		{"Any:Dict", Any("k", []Field{String("k", "v")}), Dict("k", String("k", "v"))},
@@ -174,6 +193,20 @@ func TestFieldConstructors(t *testing.T) {
 		{"Any:Duration", Any("k", time.Second), Duration("k", time.Second)},
 		{"Any:Durations", Any("k", []time.Duration{time.Second}), Durations("k", []time.Duration{time.Second})},
 		{"Any:Fallback", Any("k", struct{}{}), Reflect("k", struct{}{})},
+		{"IPAddr", Field{Key: "k", Type: zapcore.StringerType, Interface: addr}, IPAddr("k", addr)},
+		{"IPAddr:Nil", IPAddr("k", nil), nilField("k")},
+		{"Any:IPAddr", Any("k", addr), IPAddr("k", addr)},
+		{"NetipAddr", Field{Key: "k", Type: zapcore.StringerType, Interface: netipAddr}, NetipAddr("k", netipAddr)},
+		{"Any:NetipAddr", Any("k", netipAddr), NetipAddr("k", netipAddr)},
+		{"URL", Field{Key: "k", Type: zapcore.StringerType, Interface: urlVal}, URL("k", urlVal)},
+		{"URL:Nil", URL("k", nil), nilField("k")},
+		{"Any:URL", Any("k", urlVal), URL("k", urlVal)},
+		{"Any:URLValue", Any("k", *urlVal), URL("k", urlVal)},
+		{"Any:URLNil", Any("k", (*url.URL)(nil)), nilField("k")},
+		{"UUID", Field{Key: "k", Type: zapcore.StringerType, Interface: uuid(uuidVal)}, UUID("k", uuidVal)},
+		{"Any:UUID", Any("k", uuidVal), UUID("k", uuidVal)},
+		{"Any:RawMessage", Any("k", json.RawMessage(`{"a":1}`)), RawJSON("k", []byte(`{"a":1}`))},
+		{"Any:RawMessageNil", Any("k", json.RawMessage(nil)), nilField("k")},
 		{"Ptr:Bool", Boolp("k", nil), nilField("k")},
 		{"Ptr:Bool", Boolp("k", &boolVal), Bool("k", boolVal)},
 		{"Any:PtrBool", Any("k", (*bool)(nil)), nilField("k")},
@@ -266,6 +299,26 @@ func TestFieldConstructors(t *testing.T) {
 	}
 }
 
+func TestCommonTypeFieldsEncoding(t *testing.T) {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range []Field{
+		Any("ip", net.ParseIP("10.0.0.1")),
+		Any("addr", netip.MustParseAddr("fe80::1")),
+		Any("url", url.URL{Scheme: "https", Host: "example.com", Path: "/a b"}),
+		Any("uuid", [16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}),
+		UUID("zero", [16]byte{}),
+	} {
+		f.AddTo(enc)
+	}
+	assert.Equal(t, map[string]interface{}{
+		"ip":   "10.0.0.1",
+		"addr": "fe80::1",
+		"url":  "https://example.com/a%20b",
+		"uuid": "123e4567-e89b-12d3-a456-426614174000",
+		"zero": "00000000-0000-0000-0000-000000000000",
+	}, enc.Fields)
+}
+
 func TestStackField(t *testing.T) {
 	f := Stack("stacktrace")
 	assert.Equal(t, "stacktrace", f.Key, "Unexpected field key.")
@@ -292,6 +345,50 @@ func TestStackSkipFieldWithSkip(t *testing.T) {
 	assertCanBeReused(t, f)
 }
 
+func TestLazyStackField(t *testing.T) {
+	r := regexp.MustCompile(`field_test.go:(\d+)`)
+	f := LazyStack("stacktrace")
+	assert.Equal(t, "stacktrace", f.Key, "Unexpected field key.")
+	assert.Equal(t, zapcore.StringerType, f.Type, "Unexpected field type.")
+	assert.Equal(t,
+		r.ReplaceAllString(stacktrace.Take(0), "field_test.go"),
+		r.ReplaceAllString(f.Interface.(fmt.Stringer).String(), "field_test.go"),
+		"Unexpected stack trace")
+	assertCanBeReused(t, f)
+
+	f = LazyStackSkip("stacktrace", 1)
+	assert.Equal(t, stacktrace.Take(1), f.Interface.(fmt.Stringer).String(), "Unexpected stack trace with skip.")
+}
+
+func TestLazyStackFieldOptions(t *testing.T) {
+	full := strings.Split(LazyStack("s").Interface.(fmt.Stringer).String(), "\n")
+
+	t.Run("max depth", func(t *testing.T) {
+		f := LazyStack("s", StackMaxDepth(1))
+		lines := strings.Split(f.Interface.(fmt.Stringer).String(), "\n")
+		require.Len(t, lines, 2, "Expected a single frame.")
+		assert.Contains(t, lines[0], "TestLazyStackFieldOptions")
+
+		f = LazyStack("s", StackMaxDepth(1000))
+		assert.Len(t, strings.Split(f.Interface.(fmt.Stringer).String(), "\n"), len(full),
+			"Depth larger than the stack should keep every frame except the runtime's.")
+	})
+
+	t.Run("frames", func(t *testing.T) {
+		enc := zapcore.NewMapObjectEncoder()
+		LazyStack("s", StackFrames(), StackMaxDepth(2)).AddTo(enc)
+		frames, ok := enc.Fields["s"].([]interface{})
+		require.True(t, ok, "Expected an array of frames, got %T.", enc.Fields["s"])
+		require.Len(t, frames, 2)
+
+		frame := frames[0].(map[string]interface{})
+		assert.Contains(t, frame["function"], "TestLazyStackFieldOptions")
+		assert.True(t, strings.HasSuffix(frame["file"].(string), "field_test.go"), "Unexpected file %v.", frame["file"])
+		assert.IsType(t, 0, frame["line"])
+		assert.Equal(t, "testing.tRunner", frames[1].(map[string]interface{})["function"])
+	})
+}
+
 func TestDict(t *testing.T) {
 	tests := []struct {
 		desc     string
@@ -301,6 +398,7 @@ func TestDict(t *testing.T) {
 		{"empty", Dict(""), map[string]any{}},
 		{"single", Dict("", String("k", "v")), map[string]any{"k": "v"}},
 		{"multiple", Dict("", String("k", "v"), String("k2", "v2")), map[string]any{"k": "v", "k2": "v2"}},
+		{"static", StaticDict("", String("k", "v"), Int("n", 1)), map[string]any{"k": "v", "n": int64(1)}},
 	}
 
 	for _, tt := range tests {
@@ -357,3 +455,110 @@ func TestDictObject(t *testing.T) {
 		})
 	}
 }
+
+func TestLazy(t *testing.T) {
+	var calls int
+	expensive := func() any {
+		calls++
+		return []int{1, 2}
+	}
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := New(core)
+
+	logger.Debug("disabled", Lazy("k", expensive))
+	assert.Zero(t, calls, "Expected disabled entry not to evaluate the field.")
+
+	f := Lazy("k", expensive)
+	assert.Equal(t, "k", f.Key, "Unexpected key.")
+	logger.Info("enabled", f, LazyField(func() Field { return String("k2", "v") }))
+	assert.Zero(t, calls, "Expected observer not to evaluate the field.")
+
+	entries := logs.TakeAll()
+	require.Len(t, entries, 1, "Expected one entry.")
+	assert.Equal(t, map[string]any{"k": []any{1, 2}, "k2": "v"}, entries[0].ContextMap(), "Unexpected fields.")
+	assert.Equal(t, 1, calls, "Expected encoding to evaluate the field once.")
+}
+
+func TestVal(t *testing.T) {
+	type (
+		myBool    bool
+		myString  string
+		myInt     int
+		myInt8    int8
+		myUint64  uint64
+		myUintptr uintptr
+		myFloat32 float32
+		myComplex complex64
+	)
+
+	tests := []struct {
+		name   string
+		field  Field
+		expect Field
+	}{
+		{"bool", Val("k", true), Bool("k", true)},
+		{"named bool", Val("k", myBool(true)), Bool("k", true)},
+		{"named string", Val("k", myString("v")), String("k", "v")},
+		{"named int", Val("k", myInt(-42)), Int("k", -42)},
+		{"int64", Val("k", int64(42)), Int64("k", 42)},
+		{"int32", Val("k", int32(42)), Int32("k", 42)},
+		{"int16", Val("k", int16(42)), Int16("k", 42)},
+		{"named int8", Val("k", myInt8(42)), Int8("k", 42)},
+		{"uint", Val("k", uint(42)), Uint("k", 42)},
+		{"named uint64", Val("k", myUint64(42)), Uint64("k", 42)},
+		{"uint32", Val("k", uint32(42)), Uint32("k", 42)},
+		{"uint16", Val("k", uint16(42)), Uint16("k", 42)},
+		{"uint8", Val("k", uint8(42)), Uint8("k", 42)},
+		{"named uintptr", Val("k", myUintptr(42)), Uintptr("k", 42)},
+		{"float64", Val("k", 3.14), Float64("k", 3.14)},
+		{"named float32", Val("k", myFloat32(3.14)), Float32("k", 3.14)},
+		{"complex128", Val("k", 1+2i), Complex128("k", 1+2i)},
+		{"named complex64", Val("k", myComplex(1+2i)), Complex64("k", 1+2i)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expect, tt.field, "Unexpected output from Val.")
+		})
+	}
+}
+
+func TestValDoesNotAllocate(t *testing.T) {
+	type userID int64
+	id := userID(1 << 40)
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = Val("user", id)
+	})
+	assert.Zero(t, allocs, "Expected Val not to allocate.")
+}
+
+func TestSince(t *testing.T) {
+	start := time.Now().Add(-time.Hour)
+	fields := []Field{Since("dur", start), ElapsedMillis("ms", start), ElapsedSeconds("s", start)}
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	require.IsType(t, time.Duration(0), enc.Fields["dur"], "Expected Since to add a duration.")
+	require.IsType(t, int64(0), enc.Fields["ms"], "Expected ElapsedMillis to add an int64.")
+	require.IsType(t, float64(0), enc.Fields["s"], "Expected ElapsedSeconds to add a float64.")
+	assert.InDelta(t, time.Hour, enc.Fields["dur"], float64(time.Minute), "Unexpected elapsed duration.")
+	assert.InDelta(t, time.Hour.Milliseconds(), enc.Fields["ms"], 60000, "Unexpected elapsed milliseconds.")
+	assert.InDelta(t, time.Hour.Seconds(), enc.Fields["s"], 60, "Unexpected elapsed seconds.")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = Since("dur", start)
+	})
+	assert.Zero(t, allocs, "Expected Since not to allocate.")
+}
+
+func TestSinceIsMeasuredWhenEncoded(t *testing.T) {
+	f := ElapsedMillis("ms", time.Now())
+	time.Sleep(20 * time.Millisecond)
+
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+	assert.GreaterOrEqual(t, enc.Fields["ms"], int64(20), "Expected the elapsed time to be measured at encode time.")
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"sync"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap/internal/exit"
+	"go.uber.org/zap/zapcore"
+)
+
+// A Syncer flushes buffered data. Loggers, cores, and WriteSyncers are all
+// Syncers.
+type Syncer interface {
+	Sync() error
+}
+
+var _flushRegistry = newFlushRegistry()
+
+// flushRegistry tracks everything FlushAll syncs.
+type flushRegistry struct {
+	mu      sync.Mutex
+	nextID  uint64
+	syncers map[uint64]Syncer
+}
+
+func newFlushRegistry() *flushRegistry {
+	return &flushRegistry{syncers: make(map[uint64]Syncer)}
+}
+
+func (r *flushRegistry) register(s Syncer) (unregister func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := r.nextID
+	r.nextID++
+	r.syncers[id] = s
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			delete(r.syncers, id)
+		})
+	}
+}
+
+func (r *flushRegistry) syncAll() error {
+	r.mu.Lock()
+	syncers := make([]Syncer, 0, len(r.syncers))
+	for _, s := range r.syncers {
+		syncers = append(syncers, s)
+	}
+	r.mu.Unlock()
+
+	// Sync outside the lock, since syncing may be slow.
+	var err error
+	for _, s := range syncers {
+		err = multierr.Append(err, s.Sync())
+	}
+	return err
+}
+
+// RegisterFlush adds s to the set of Syncers flushed by FlushAll, and
+// returns a function that removes it again.
+//
+// Nothing is registered automatically. Register long-lived outputs that
+// buffer writes, such as a zapcore.BufferedWriteSyncer, or use the
+// AddToFlushAll option to register a Logger.
+func RegisterFlush(s Syncer) (unregister func()) {
+	return _flushRegistry.register(s)
+}
+
+// FlushAll syncs the global Logger and every registered Syncer, so that a
+// program can flush all of its logs from a single place:
+//
+//	defer zap.FlushAll()
+//
+// Loggers also call FlushAll before exiting or panicking after writing a
+// Fatal or Panic entry.
+//
+// Some outputs, such as standard error on many platforms, return an error
+// when synced. FlushAll still syncs every other Syncer and returns the
+// combined errors.
+func FlushAll() error {
+	return multierr.Append(L().Sync(), _flushRegistry.syncAll())
+}
+
+// flushAllThen is a CheckWriteHook that flushes all registered Syncers
+// before running another hook.
+type flushAllThen struct {
+	next zapcore.CheckWriteHook
+}
+
+func (h flushAllThen) OnWrite(ce *zapcore.CheckedEntry, fields []Field) {
+	_ = FlushAll()
+	h.next.OnWrite(ce, fields)
+}
+
+// fatalBehaviorHook is a CheckWriteHook that runs the functions registered
+// with WithFatalBehavior and flushes all registered Syncers before running
+// another hook.
+type fatalBehaviorHook struct {
+	fns  []func(zapcore.Entry, []Field)
+	next zapcore.CheckWriteHook
+}
+
+func (h fatalBehaviorHook) OnWrite(ce *zapcore.CheckedEntry, fields []Field) {
+	func() {
+		// Flush even if a function panics, which turns the fatal log into
+		// a panic.
+		defer func() { _ = FlushAll() }()
+		for _, fn := range h.fns {
+			fn(ce.Entry, fields)
+		}
+	}()
+	h.next.OnWrite(ce, fields)
+}
+
+// exitHook is a CheckWriteHook that exits the process with the given status.
+type exitHook int
+
+func (h exitHook) OnWrite(*zapcore.CheckedEntry, []Field) {
+	exit.With(int(h))
+}
+
+// RedirectPanics logs a panic in progress to the given Logger at PanicLevel,
+// with the panic value and the stack of the panicking goroutine, flushes all
+// registered Syncers, and then panics again with the original value. It must
+// be deferred directly:
+//
+//	func main() {
+//		logger, _ := zap.NewProduction()
+//		defer zap.RedirectPanics(logger)
+//		...
+//	}
+//
+// If there is no panic in progress, RedirectPanics does nothing.
+func RedirectPanics(log *Logger) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	// The Panic and Fatal hooks would replace the original panic value, so
+	// write the entry without them and panic ourselves.
+	if ce := log.Check(PanicLevel, "panic"); ce != nil {
+		ent := ce.Entry
+		ce.After(ent, zapcore.WriteThenNoop).Write(
+			Any("panic", r),
+			StackSkip("stack", 1), // skip RedirectPanics
+		)
+	}
+	_ = log.Sync()
+	_ = FlushAll()
+	panic(r)
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/internal/exit"
+	"go.uber.org/zap/internal/ztest"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// useEmptyFlushRegistry swaps in an empty flush registry for the duration
+// of a test, since Syncers registered by other tests stay registered in the
+// global one.
+func useEmptyFlushRegistry(t testing.TB) {
+	old := _flushRegistry
+	_flushRegistry = newFlushRegistry()
+	t.Cleanup(func() { _flushRegistry = old })
+}
+
+func TestFlushAll(t *testing.T) {
+	useEmptyFlushRegistry(t)
+
+	first, second := &ztest.Syncer{}, &ztest.Syncer{}
+	second.SetError(errors.New("sync failed"))
+
+	unregisterFirst := RegisterFlush(first)
+	unregisterSecond := RegisterFlush(second)
+	assert.EqualError(t, FlushAll(), "sync failed")
+	assert.True(t, first.Called(), "Expected first Syncer to be synced.")
+	assert.True(t, second.Called(), "Expected second Syncer to be synced.")
+
+	unregisterSecond()
+	unregisterSecond() // idempotent
+	assert.NoError(t, FlushAll(), "Unregistered Syncers must not be synced.")
+	unregisterFirst()
+}
+
+func TestFlushAllOpenedSinks(t *testing.T) {
+	useEmptyFlushRegistry(t)
+
+	path := filepath.Join(t.TempDir(), "log")
+	ws, closeOut, err := Open(path)
+	require.NoError(t, err)
+	defer closeOut()
+	assert.Empty(t, _flushRegistry.syncers, "Opening sinks shouldn't register them.")
+
+	buffered := &zapcore.BufferedWriteSyncer{WS: ws}
+	defer buffered.Stop()
+	logger := New(zapcore.NewCore(
+		zapcore.NewConsoleEncoder(zapcore.EncoderConfig{MessageKey: "M"}),
+		buffered,
+		DebugLevel,
+	), AddToFlushAll())
+	assert.Len(t, _flushRegistry.syncers, 1, "Expected AddToFlushAll to register the logger.")
+
+	logger.Info("buffered")
+	require.NoError(t, FlushAll())
+
+	bs, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "buffered\n", string(bs))
+}
+
+func TestFatalFlushesAll(t *testing.T) {
+	syncer := &ztest.Syncer{}
+	defer RegisterFlush(syncer)()
+
+	withLogger(t, DebugLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
+		stub := exit.WithStub(func() { logger.Fatal("bye") })
+		assert.True(t, stub.Exited, "Expected Fatal to exit.")
+		assert.True(t, syncer.Called(), "Expected Fatal to flush registered Syncers.")
+	})
+}
+
+func TestRedirectPanics(t *testing.T) {
+	syncer := &ztest.Syncer{}
+	defer RegisterFlush(syncer)()
+
+	withLogger(t, DebugLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
+		assert.PanicsWithValue(t, "boom", func() {
+			defer RedirectPanics(logger)
+			panic("boom")
+		})
+
+		entries := logs.AllUntimed()
+		require.Len(t, entries, 1)
+		assert.Equal(t, PanicLevel, entries[0].Level)
+		assert.Equal(t, "panic", entries[0].Message)
+		fields := entries[0].ContextMap()
+		assert.Equal(t, "boom", fields["panic"])
+		assert.Contains(t, fields["stack"], "TestRedirectPanics", "Expected the panicking stack.")
+		assert.True(t, syncer.Called(), "Expected registered Syncers to be flushed.")
+	})
+}
+
+func TestRedirectPanicsNoPanic(t *testing.T) {
+	withLogger(t, DebugLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
+		assert.NotPanics(t, func() {
+			defer RedirectPanics(logger)
+		})
+		assert.Zero(t, logs.Len())
+	})
+}
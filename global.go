@@ -23,9 +23,15 @@ package zap
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 
 	"go.uber.org/zap/zapcore"
 )
@@ -41,11 +47,25 @@ var (
 	_globalMu sync.RWMutex
 	_globalL  = NewNop()
 	_globalS  = _globalL.Sugar()
+
+	// _scopedGlobals holds the globals installed by WithGlobals, by
+	// goroutine ID. _scopedCount counts its entries, so that L and S can
+	// skip looking up the current goroutine when it's empty.
+	_scopedGlobals map[uint64]scopedGlobals
+	_scopedCount   atomic.Int64
 )
 
-// L returns the global Logger, which can be reconfigured with ReplaceGlobals.
-// It's safe for concurrent use.
+type scopedGlobals struct {
+	l *Logger
+	s *SugaredLogger
+}
+
+// L returns the global Logger, which can be reconfigured with ReplaceGlobals
+// or, for a single goroutine, with WithGlobals. It's safe for concurrent use.
 func L() *Logger {
+	if g, ok := currentScopedGlobals(); ok {
+		return g.l
+	}
 	_globalMu.RLock()
 	l := _globalL
 	_globalMu.RUnlock()
@@ -53,8 +73,12 @@ func L() *Logger {
 }
 
 // S returns the global SugaredLogger, which can be reconfigured with
-// ReplaceGlobals. It's safe for concurrent use.
+// ReplaceGlobals or, for a single goroutine, with WithGlobals. It's safe for
+// concurrent use.
 func S() *SugaredLogger {
+	if g, ok := currentScopedGlobals(); ok {
+		return g.s
+	}
 	_globalMu.RLock()
 	s := _globalS
 	_globalMu.RUnlock()
@@ -72,6 +96,90 @@ func ReplaceGlobals(logger *Logger) func() {
 	return func() { ReplaceGlobals(prev) }
 }
 
+// Globals returns the global Logger along with a function that restores it,
+// read together so that no concurrent ReplaceGlobals can come between them.
+// Unlike L, it ignores loggers installed with WithGlobals.
+//
+//	logger, restore := zap.Globals()
+//	defer restore()
+//	zap.ReplaceGlobals(logger.Named("test"))
+func Globals() (*Logger, func()) {
+	_globalMu.RLock()
+	l := _globalL
+	_globalMu.RUnlock()
+	return l, func() { ReplaceGlobals(l) }
+}
+
+// WithGlobals makes L and S return logger, and logger.Sugar(), on the
+// calling goroutine while f runs, leaving the globals seen by other
+// goroutines untouched. Unlike ReplaceGlobals, it's safe to use in tests
+// that run in parallel:
+//
+//	func TestHandler(t *testing.T) {
+//	  t.Parallel()
+//	  zap.WithGlobals(zaptest.NewLogger(t), func() {
+//	    handle(req) // logs to zap.L()
+//	  })
+//	}
+//
+// Goroutines started by f don't inherit the override and see the
+// process-wide globals. Calls may be nested; the previous override is
+// restored when f returns or panics.
+func WithGlobals(logger *Logger, f func()) {
+	id := goroutineID()
+	_globalMu.Lock()
+	prev, nested := _scopedGlobals[id]
+	if _scopedGlobals == nil {
+		_scopedGlobals = make(map[uint64]scopedGlobals)
+	}
+	_scopedGlobals[id] = scopedGlobals{l: logger, s: logger.Sugar()}
+	if !nested {
+		_scopedCount.Add(1)
+	}
+	_globalMu.Unlock()
+
+	defer func() {
+		_globalMu.Lock()
+		if nested {
+			_scopedGlobals[id] = prev
+		} else {
+			delete(_scopedGlobals, id)
+			_scopedCount.Add(-1)
+		}
+		_globalMu.Unlock()
+	}()
+	f()
+}
+
+// currentScopedGlobals returns the globals installed on the calling goroutine
+// by WithGlobals, if any.
+func currentScopedGlobals() (scopedGlobals, bool) {
+	if _scopedCount.Load() == 0 {
+		return scopedGlobals{}, false
+	}
+	id := goroutineID()
+	_globalMu.RLock()
+	g, ok := _scopedGlobals[id]
+	_globalMu.RUnlock()
+	return g, ok
+}
+
+// goroutineID returns the ID of the calling goroutine, parsed from the
+// "goroutine N [status]:" header of its stack trace.
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, err := strconv.ParseUint(string(b), 10, 64)
+	if err != nil {
+		panic(fmt.Sprintf(_programmerErrorTemplate, err))
+	}
+	return id
+}
+
 // NewStdLog returns a *log.Logger which writes to the supplied zap Logger at
 // InfoLevel. To redirect the standard library's package-global logging
 // functions, use RedirectStdLog instead.
@@ -120,17 +228,81 @@ func RedirectStdLogAt(l *Logger, level zapcore.Level) (func(), error) {
 	return redirectStdLogAt(l, level)
 }
 
+// A StdLogRoute sends lines written to the standard library's
+// package-global logger to a particular level and named logger. Routes let
+// third-party libraries that still use log.Printf log at meaningful levels
+// and under the names of their subsystems.
+//
+// A line matches a route if it starts with Prefix and matches Pattern;
+// empty Prefixes and nil Patterns match every line.
+type StdLogRoute struct {
+	// Prefix is matched against the start of each line, and removed from
+	// matching lines along with any spaces that follow it.
+	Prefix string
+
+	// Pattern is matched against each line, after any Prefix is removed.
+	Pattern *regexp.Regexp
+
+	// Level is the level at which matching lines are logged.
+	Level zapcore.Level
+
+	// Name, if not empty, is added to the logger's name for matching lines,
+	// as if by Logger.Named.
+	Name string
+}
+
+// RedirectStdLogWithRoutes redirects output from the standard library's
+// package-global logger to the supplied logger, like RedirectStdLogAt, but
+// sends lines that match one of the routes to that route's level and named
+// logger. Routes are tried in order, and lines that match none of them are
+// logged at the given level. For example,
+//
+//	restore, err := zap.RedirectStdLogWithRoutes(logger, zap.InfoLevel,
+//		zap.StdLogRoute{Prefix: "[grpc]", Level: zap.DebugLevel, Name: "grpc"},
+//		zap.StdLogRoute{Pattern: regexp.MustCompile(`(?i)\berror\b`), Level: zap.ErrorLevel},
+//	)
+//
+// It returns a function to restore the original prefix and flags and reset
+// the standard library's output to os.Stderr.
+func RedirectStdLogWithRoutes(l *Logger, level zapcore.Level, routes ...StdLogRoute) (func(), error) {
+	return redirectStdLog(l, level, routes)
+}
+
 func redirectStdLogAt(l *Logger, level zapcore.Level) (func(), error) {
-	flags := log.Flags()
-	prefix := log.Prefix()
-	log.SetFlags(0)
-	log.SetPrefix("")
+	return redirectStdLog(l, level, nil)
+}
+
+func redirectStdLog(l *Logger, level zapcore.Level, routes []StdLogRoute) (func(), error) {
 	logger := l.WithOptions(AddCallerSkip(_stdLogDefaultDepth + _loggerWriterDepth))
 	logFunc, err := levelToFunc(logger, level)
 	if err != nil {
 		return nil, err
 	}
-	log.SetOutput(&loggerWriter{logFunc})
+	var w io.Writer = &loggerWriter{logFunc}
+	if len(routes) > 0 {
+		rw := &routedLoggerWriter{
+			logFunc: logFunc,
+			routes:  make([]stdLogRoute, len(routes)),
+		}
+		for i, r := range routes {
+			routeLogger := logger
+			if r.Name != "" {
+				routeLogger = logger.Named(r.Name)
+			}
+			f, err := levelToFunc(routeLogger, r.Level)
+			if err != nil {
+				return nil, fmt.Errorf("invalid route %d: %v", i, err)
+			}
+			rw.routes[i] = stdLogRoute{StdLogRoute: r, logFunc: f}
+		}
+		w = rw
+	}
+
+	flags := log.Flags()
+	prefix := log.Prefix()
+	log.SetFlags(0)
+	log.SetPrefix("")
+	log.SetOutput(w)
 	return func() {
 		log.SetFlags(flags)
 		log.SetPrefix(prefix)
@@ -140,6 +312,8 @@ func redirectStdLogAt(l *Logger, level zapcore.Level) (func(), error) {
 
 func levelToFunc(logger *Logger, lvl zapcore.Level) (func(string, ...Field), error) {
 	switch lvl {
+	case TraceLevel:
+		return logger.Trace, nil
 	case DebugLevel:
 		return logger.Debug, nil
 	case InfoLevel:
@@ -167,3 +341,42 @@ func (l *loggerWriter) Write(p []byte) (int, error) {
 	l.logFunc(string(p))
 	return len(p), nil
 }
+
+type stdLogRoute struct {
+	StdLogRoute
+
+	logFunc func(msg string, fields ...Field)
+}
+
+// match reports whether msg matches the route, returning msg without the
+// route's prefix.
+func (r *stdLogRoute) match(msg string) (string, bool) {
+	if !strings.HasPrefix(msg, r.Prefix) {
+		return "", false
+	}
+	msg = strings.TrimLeft(msg[len(r.Prefix):], " ")
+	if r.Pattern != nil && !r.Pattern.MatchString(msg) {
+		return "", false
+	}
+	return msg, true
+}
+
+// routedLoggerWriter is a loggerWriter that picks the logger for each line
+// from a list of routes.
+type routedLoggerWriter struct {
+	routes  []stdLogRoute
+	logFunc func(msg string, fields ...Field)
+}
+
+func (l *routedLoggerWriter) Write(p []byte) (int, error) {
+	p = bytes.TrimSpace(p)
+	msg, logFunc := string(p), l.logFunc
+	for i := range l.routes {
+		if m, ok := l.routes[i].match(msg); ok {
+			msg, logFunc = m, l.routes[i].logFunc
+			break
+		}
+	}
+	logFunc(msg)
+	return len(p), nil
+}
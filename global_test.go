@@ -22,6 +22,8 @@ package zap
 
 import (
 	"log"
+	"regexp"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -66,6 +68,67 @@ func TestReplaceGlobals(t *testing.T) {
 	assert.Equal(t, initialS, *S(), "Expected func returned from ReplaceGlobals to restore initial S.")
 }
 
+func TestGlobals(t *testing.T) {
+	initial := L()
+	l, restore := Globals()
+	assert.Equal(t, initial, l, "Expected Globals to return the global Logger.")
+
+	ReplaceGlobals(NewNop())
+	restore()
+	assert.Equal(t, initial, L(), "Expected func returned from Globals to restore L.")
+}
+
+func TestWithGlobals(t *testing.T) {
+	initialL, initialS := L(), S()
+
+	withLogger(t, DebugLevel, nil, func(outer *Logger, outerLogs *observer.ObservedLogs) {
+		withLogger(t, DebugLevel, nil, func(inner *Logger, innerLogs *observer.ObservedLogs) {
+			WithGlobals(outer, func() {
+				L().Info("outer")
+				func() {
+					defer func() { recover() }()
+					WithGlobals(inner, func() {
+						S().Info("inner")
+						panic("boom")
+					})
+				}()
+				S().Info("outer again")
+
+				var wg sync.WaitGroup
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					assert.Equal(t, initialL, L(), "Expected other goroutines to see the initial L.")
+					assert.Equal(t, initialS, S(), "Expected other goroutines to see the initial S.")
+				}()
+				wg.Wait()
+			})
+
+			assert.Equal(t, 2, outerLogs.Len(), "Expected entries logged to the outer logger.")
+			assert.Equal(t, 1, innerLogs.Len(), "Expected entries logged to the inner logger.")
+		})
+	})
+
+	assert.Equal(t, initialL, L(), "Expected WithGlobals to restore initial L.")
+	assert.Equal(t, initialS, S(), "Expected WithGlobals to restore initial S.")
+}
+
+func TestWithGlobalsParallel(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			withLogger(t, DebugLevel, nil, func(l *Logger, logs *observer.ObservedLogs) {
+				WithGlobals(l, func() {
+					for j := 0; j < 10; j++ {
+						L().Info("")
+					}
+				})
+				assert.Equal(t, 10, logs.Len(), "Expected entries to go to this test's logger.")
+			})
+		})
+	}
+}
+
 func TestGlobalsConcurrentUse(t *testing.T) {
 	var (
 		stop atomic.Bool
@@ -217,6 +280,55 @@ func TestRedirectStdLogAtCaller(t *testing.T) {
 	}
 }
 
+func TestRedirectStdLogWithRoutes(t *testing.T) {
+	initialFlags := log.Flags()
+	initialPrefix := log.Prefix()
+
+	withLogger(t, DebugLevel, []Option{AddCaller()}, func(l *Logger, logs *observer.ObservedLogs) {
+		restore, err := RedirectStdLogWithRoutes(l.Named("std"), InfoLevel,
+			StdLogRoute{Prefix: "[grpc]", Level: DebugLevel, Name: "grpc"},
+			StdLogRoute{Prefix: "[grpc]", Level: ErrorLevel, Name: "unreachable"},
+			StdLogRoute{Pattern: regexp.MustCompile(`(?i)\berror\b`), Level: ErrorLevel},
+			StdLogRoute{Prefix: "http:", Pattern: regexp.MustCompile("^TLS"), Level: WarnLevel, Name: "http"},
+		)
+		require.NoError(t, err, "Unexpected error.")
+		defer restore()
+
+		log.Print("[grpc] dialing")
+		log.Print("request Error: timeout")
+		log.Print("http: TLS handshake failed")
+		log.Print("http: closing idle connection")
+		log.Print("[grpcx] other")
+
+		entries := logs.All()
+		require.Len(t, entries, 5, "Unexpected number of logs.")
+		for _, e := range entries {
+			assert.Contains(t, e.Caller.File, "global_test.go", "Unexpected caller annotation.")
+		}
+		want := []zapcore.Entry{
+			{Level: DebugLevel, LoggerName: "std.grpc", Message: "dialing"},
+			{Level: ErrorLevel, LoggerName: "std", Message: "request Error: timeout"},
+			{Level: WarnLevel, LoggerName: "std.http", Message: "TLS handshake failed"},
+			{Level: InfoLevel, LoggerName: "std", Message: "http: closing idle connection"},
+			{Level: InfoLevel, LoggerName: "std", Message: "[grpcx] other"},
+		}
+		for i, e := range logs.AllUntimed() {
+			e.Entry.Caller = zapcore.EntryCaller{}
+			assert.Equal(t, want[i], e.Entry, "Unexpected entry %d.", i)
+		}
+	})
+
+	assert.Equal(t, initialFlags, log.Flags(), "Expected to reset initial flags.")
+	assert.Equal(t, initialPrefix, log.Prefix(), "Expected to reset initial prefix.")
+}
+
+func TestRedirectStdLogWithRoutesInvalidLevel(t *testing.T) {
+	withLogger(t, DebugLevel, nil, func(l *Logger, logs *observer.ObservedLogs) {
+		_, err := RedirectStdLogWithRoutes(l, InfoLevel, StdLogRoute{Level: zapcore.Level(99)})
+		assert.ErrorContains(t, err, "invalid route 0", "Expected an error for an invalid route level.")
+	})
+}
+
 func TestRedirectStdLogAtPanics(t *testing.T) {
 	initialFlags := log.Flags()
 	initialPrefix := log.Prefix()
@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	_goroutineLabelsMu sync.RWMutex
+	// _goroutineLabels holds the labels set with SetGoroutineLabel, by
+	// goroutine ID. _goroutineLabelCount counts its entries, so that
+	// loggers can skip the lookup while no labels are set.
+	_goroutineLabels     map[uint64]string
+	_goroutineLabelCount atomic.Int64
+)
+
+// SetGoroutineLabel labels the calling goroutine, such as with the name of
+// the worker it runs, for loggers built with AddGoroutine. It returns a
+// function that restores the goroutine's previous label, or removes the
+// label if it had none; call it before the goroutine exits, since labels
+// are otherwise kept for the life of the process.
+//
+//	go func() {
+//	  defer zap.SetGoroutineLabel("worker-3")()
+//	  ...
+//	}()
+func SetGoroutineLabel(label string) (restore func()) {
+	id := goroutineID()
+	_goroutineLabelsMu.Lock()
+	prev, hadLabel := _goroutineLabels[id]
+	if _goroutineLabels == nil {
+		_goroutineLabels = make(map[uint64]string)
+	}
+	_goroutineLabels[id] = label
+	if !hadLabel {
+		_goroutineLabelCount.Add(1)
+	}
+	_goroutineLabelsMu.Unlock()
+
+	return func() {
+		_goroutineLabelsMu.Lock()
+		defer _goroutineLabelsMu.Unlock()
+		if hadLabel {
+			_goroutineLabels[id] = prev
+		} else if _, ok := _goroutineLabels[id]; ok {
+			delete(_goroutineLabels, id)
+			_goroutineLabelCount.Add(-1)
+		}
+	}
+}
+
+// goroutineLabel returns the label of the goroutine with the given ID, if
+// it has one.
+func goroutineLabel(id uint64) (string, bool) {
+	if _goroutineLabelCount.Load() == 0 {
+		return "", false
+	}
+	_goroutineLabelsMu.RLock()
+	label, ok := _goroutineLabels[id]
+	_goroutineLabelsMu.RUnlock()
+	return label, ok
+}
+
+// AddGoroutine annotates every entry with a string field, under the given
+// key, identifying the goroutine that logged it: its label, if one was set
+// with SetGoroutineLabel, or its numeric ID otherwise. This is meant for
+// debugging concurrency-heavy code, and costs a call to runtime.Stack per
+// entry, so it's best enabled selectively.
+func AddGoroutine(key string) Option {
+	return optionFunc(func(log *Logger) {
+		log.core = zapcore.NewDynamicFieldsCore(log.core, func(zapcore.Entry) []Field {
+			id := goroutineID()
+			if label, ok := goroutineLabel(id); ok {
+				return []Field{String(key, label)}
+			}
+			return []Field{String(key, strconv.FormatUint(id, 10))}
+		})
+	})
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestAddGoroutine(t *testing.T) {
+	withLogger(t, DebugLevel, opts(AddGoroutine("goroutine")), func(logger *Logger, logs *observer.ObservedLogs) {
+		id := strconv.FormatUint(goroutineID(), 10)
+		logger.Info("unlabeled")
+
+		restore := SetGoroutineLabel("main")
+		logger.Info("labeled")
+		restoreInner := SetGoroutineLabel("inner")
+		logger.Info("nested")
+		restoreInner()
+		logger.Info("restored")
+		restore()
+		logger.Info("cleared")
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			defer SetGoroutineLabel("worker")()
+			logger.Info("worker")
+		}()
+		<-done
+
+		var got []string
+		for _, e := range logs.AllUntimed() {
+			require.Len(t, e.Context, 1, "Expected a single goroutine field.")
+			assert.Equal(t, "goroutine", e.Context[0].Key, "Unexpected key.")
+			got = append(got, e.Message+"="+e.Context[0].String)
+		}
+		assert.Equal(t, []string{
+			"unlabeled=" + id,
+			"labeled=main",
+			"nested=inner",
+			"restored=main",
+			"cleared=" + id,
+			"worker=worker",
+		}, got, "Unexpected goroutine fields.")
+		assert.Zero(t, _goroutineLabelCount.Load(), "Expected all labels to be removed.")
+	})
+}
+
+func TestAddGoroutineDisabled(t *testing.T) {
+	withLogger(t, InfoLevel, opts(AddGoroutine("goroutine")), func(logger *Logger, logs *observer.ObservedLogs) {
+		logger.Debug("disabled")
+		logger.With(String("k", "v")).Info("enabled")
+		assert.Equal(t, []observer.LoggedEntry{{
+			Entry:   zapcore.Entry{Level: InfoLevel, Message: "enabled"},
+			Context: []Field{String("k", "v"), String("goroutine", strconv.FormatUint(goroutineID(), 10))},
+		}}, logs.AllUntimed(), "Unexpected entries.")
+	})
+}
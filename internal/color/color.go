@@ -21,7 +21,10 @@
 // Package color adds coloring functionality for TTY output.
 package color
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+)
 
 // Foreground colors.
 const (
@@ -42,3 +45,20 @@ type Color uint8
 func (c Color) Add(s string) string {
 	return fmt.Sprintf("\x1b[%dm%s\x1b[0m", uint8(c), s)
 }
+
+// Supported reports whether colored output should be written to f. It
+// honors the NO_COLOR convention (https://no-color.org) and TERM=dumb, and
+// otherwise reports whether f is a terminal.
+func Supported(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	if f == nil {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
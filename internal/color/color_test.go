@@ -21,9 +21,11 @@
 package color
 
 import (
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestColorFormatting(t *testing.T) {
@@ -34,3 +36,21 @@ func TestColorFormatting(t *testing.T) {
 		"Unexpected colored output.",
 	)
 }
+
+func TestSupported(t *testing.T) {
+	t.Run("NO_COLOR", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		assert.False(t, Supported(os.Stdout), "Expected NO_COLOR to disable color.")
+	})
+
+	t.Run("nil file", func(t *testing.T) {
+		assert.False(t, Supported(nil), "Expected nil file to disable color.")
+	})
+
+	t.Run("regular file", func(t *testing.T) {
+		f, err := os.CreateTemp(t.TempDir(), "color")
+		require.NoError(t, err, "Failed to create temp file.")
+		defer f.Close()
+		assert.False(t, Supported(f), "Expected regular file to disable color.")
+	})
+}
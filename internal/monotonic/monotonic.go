@@ -0,0 +1,40 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package monotonic represents instants as int64 offsets from a reference
+// time taken at startup, so that fields can carry a start time without
+// allocating and still measure elapsed time on the monotonic clock.
+package monotonic
+
+import "time"
+
+var _base = time.Now()
+
+// Offset returns t as a number of nanoseconds since the reference time.
+// If t has a monotonic clock reading, as times returned by time.Now do, so
+// does the offset.
+func Offset(t time.Time) int64 {
+	return int64(t.Sub(_base))
+}
+
+// Since returns the time elapsed since the instant at offset.
+func Since(offset int64) time.Duration {
+	return time.Since(_base) - time.Duration(offset)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package stacktrace
+
+import (
+	"runtime"
+	"sync"
+)
+
+// _callerShards is the number of independently locked shards in the caller
+// cache. It must be a power of two.
+const _callerShards = 64
+
+var _callerCache callerCache
+
+// callerCache maps program counters to resolved frames. The set of call
+// sites in a program is fixed, so the cache is never evicted. It's sharded
+// to keep loggers on many goroutines from contending on a single lock.
+type callerCache struct {
+	shards [_callerShards]callerShard
+}
+
+type callerShard struct {
+	mu     sync.RWMutex
+	frames map[uintptr]runtime.Frame
+}
+
+func (c *callerCache) frame(pc uintptr) runtime.Frame {
+	// Return addresses aren't aligned, but mix in higher bits anyway so
+	// that call sites in the same function spread across shards.
+	s := &c.shards[(pc^pc>>7)&(_callerShards-1)]
+
+	s.mu.RLock()
+	frame, ok := s.frames[pc]
+	s.mu.RUnlock()
+	if ok {
+		return frame
+	}
+
+	frame, _ = runtime.CallersFrames([]uintptr{pc}).Next()
+
+	s.mu.Lock()
+	if s.frames == nil {
+		s.frames = make(map[uintptr]runtime.Frame)
+	}
+	s.frames[pc] = frame
+	s.mu.Unlock()
+	return frame
+}
+
+// Caller returns the frame of a function on the current goroutine's stack,
+// like a stack of depth First captured by Capture, but caches the result of
+// resolving each program counter so that repeated calls from the same call
+// site don't allocate.
+//
+// skip is the number of frames to skip; skip=0 identifies the caller of
+// Caller. It reports false if there is no such frame.
+func Caller(skip int) (runtime.Frame, bool) {
+	var pcs [1]uintptr
+	// +2 to skip Caller and runtime.Callers, as in Capture.
+	if runtime.Callers(skip+2, pcs[:]) == 0 {
+		return runtime.Frame{}, false
+	}
+	return _callerCache.frame(pcs[0]), true
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package stacktrace
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaller(t *testing.T) {
+	frame, ok := Caller(0)
+	require.True(t, ok)
+	assert.Equal(t, "go.uber.org/zap/internal/stacktrace.TestCaller", frame.Function)
+
+	want, got := captureBoth()
+	assert.Equal(t, want.Function, got.Function)
+	assert.Equal(t, want.File, got.File)
+	assert.Equal(t, want.Line, got.Line, "Expected Caller to match Capture(First).")
+
+	frame, ok = Caller(1)
+	require.True(t, ok)
+	assert.Equal(t, "testing.tRunner", frame.Function)
+
+	_, ok = Caller(1000)
+	assert.False(t, ok, "Expected no frame beyond the top of the stack.")
+}
+
+// captureBoth returns its caller's frame as reported by Capture and by
+// Caller.
+func captureBoth() (fromCapture, fromCaller runtime.Frame) {
+	stack := Capture(1, First)
+	defer stack.Free()
+	fromCapture, _ = stack.Next()
+	fromCaller, _ = Caller(1)
+	return fromCapture, fromCaller
+}
+
+func TestCallerCached(t *testing.T) {
+	caller := func() int {
+		frame, _ := Caller(0)
+		return frame.Line
+	}
+	line := caller()
+	allocs := testing.AllocsPerRun(100, func() {
+		assert.Equal(t, line, caller())
+	})
+	assert.Zero(t, allocs, "Expected cached lookups not to allocate.")
+}
+
+func TestCallerConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				frame, ok := Caller(0)
+				assert.True(t, ok)
+				assert.Contains(t, frame.Function, "TestCallerConcurrent")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkCaller(b *testing.B) {
+	b.Run("Capture", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			stack := Capture(0, First)
+			stack.Next()
+			stack.Free()
+		}
+	})
+	b.Run("Caller", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Caller(0)
+		}
+	})
+}
@@ -144,6 +144,24 @@ func Take(skip int) string {
 	return buffer.String()
 }
 
+// PCs returns the program counters of the current goroutine's stack,
+// keeping at most max of them, or all of them if max is zero or less. The
+// stack is captured into pooled storage, so the only allocation is the
+// returned slice, which belongs to the caller.
+//
+// skip is the number of frames to skip before recording the stack trace.
+// skip=0 identifies the caller of PCs.
+func PCs(skip, max int) []uintptr {
+	stack := Capture(skip+1, Full)
+	defer stack.Free()
+
+	pcs := stack.pcs
+	if max > 0 && len(pcs) > max {
+		pcs = pcs[:max]
+	}
+	return append([]uintptr(nil), pcs...)
+}
+
 // Formatter formats a stack trace into a readable string representation.
 type Formatter struct {
 	b        *buffer.Buffer
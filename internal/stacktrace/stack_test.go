@@ -22,6 +22,7 @@ package stacktrace
 
 import (
 	"bytes"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -53,6 +54,18 @@ func TestTakeWithSkip(t *testing.T) {
 	)
 }
 
+func TestPCs(t *testing.T) {
+	all := PCs(0, 0)
+	require.NotEmpty(t, all)
+	frame, _ := runtime.CallersFrames(all).Next()
+	assert.Equal(t, "go.uber.org/zap/internal/stacktrace.TestPCs", frame.Function)
+
+	limited := PCs(1, 2)
+	assert.Len(t, limited, 2)
+	frame, _ = runtime.CallersFrames(limited).Next()
+	assert.Equal(t, "testing.tRunner", frame.Function, "Expected our own frame to be skipped.")
+}
+
 func TestTakeWithSkipInnerFunc(t *testing.T) {
 	var trace string
 	func() {
@@ -0,0 +1,173 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// KubernetesMetadataConfig configures where WithKubernetesMetadata finds
+// the metadata of the pod a process runs in. Kubernetes exposes it through
+// the downward API, as environment variables or files, as configured in the
+// pod spec:
+//
+//	env:
+//	  - name: POD_NAME
+//	    valueFrom: {fieldRef: {fieldPath: metadata.name}}
+//	  - name: POD_NAMESPACE
+//	    valueFrom: {fieldRef: {fieldPath: metadata.namespace}}
+//	  - name: NODE_NAME
+//	    valueFrom: {fieldRef: {fieldPath: spec.nodeName}}
+//	volumeMounts:
+//	  - {name: podinfo, mountPath: /etc/podinfo}
+//	volumes:
+//	  - name: podinfo
+//	    downwardAPI:
+//	      items:
+//	        - {path: labels, fieldRef: {fieldPath: metadata.labels}}
+//
+// Leave a setting empty to skip that source.
+type KubernetesMetadataConfig struct {
+	// Key is the key of the object that holds the metadata. If it's empty,
+	// the metadata is added as top-level fields instead.
+	Key string `json:"key" yaml:"key"`
+	// PodNameEnv is the environment variable holding the pod's name.
+	PodNameEnv string `json:"podNameEnv" yaml:"podNameEnv"`
+	// NamespaceEnv is the environment variable holding the pod's namespace.
+	NamespaceEnv string `json:"namespaceEnv" yaml:"namespaceEnv"`
+	// NamespaceFile is a file holding the pod's namespace, read if the
+	// environment variable isn't set. Every pod with a service account has
+	// one.
+	NamespaceFile string `json:"namespaceFile" yaml:"namespaceFile"`
+	// NodeNameEnv is the environment variable holding the name of the node
+	// the pod is scheduled on.
+	NodeNameEnv string `json:"nodeNameEnv" yaml:"nodeNameEnv"`
+	// LabelsFile is a downward API file holding the pod's labels, one
+	// key="value" pair per line.
+	LabelsFile string `json:"labelsFile" yaml:"labelsFile"`
+}
+
+// NewKubernetesMetadataConfig returns a KubernetesMetadataConfig that reads
+// the environment variables and files in the example above, as well as the
+// namespace of the pod's service account, and nests the metadata under the
+// "k8s" key.
+func NewKubernetesMetadataConfig() KubernetesMetadataConfig {
+	return KubernetesMetadataConfig{
+		Key:           "k8s",
+		PodNameEnv:    "POD_NAME",
+		NamespaceEnv:  "POD_NAMESPACE",
+		NamespaceFile: "/var/run/secrets/kubernetes.io/serviceaccount/namespace",
+		NodeNameEnv:   "NODE_NAME",
+		LabelsFile:    "/etc/podinfo/labels",
+	}
+}
+
+// WithKubernetesMetadata adds the name, namespace, and node of the pod the
+// process runs in, and the pod's labels, to the Logger, reading them as
+// configured by cfg. They're logged under the keys "pod", "namespace",
+// "node", and "labels", nested in an object if cfg.Key is set:
+//
+//	{"k8s":{"labels":{"app":"api"},"namespace":"prod","node":"node-1","pod":"api-7d9f"}}
+//
+// Metadata that isn't available is left out, so the option is safe to use
+// outside of Kubernetes, where it adds nothing.
+func WithKubernetesMetadata(cfg KubernetesMetadataConfig) Option {
+	md := cfg.read()
+	if len(md) == 0 {
+		return Fields()
+	}
+	if cfg.Key == "" {
+		fs := make([]Field, len(md))
+		for i, f := range md {
+			fs[i] = Any(f.key, f.value)
+		}
+		return Fields(fs...)
+	}
+	return Fields(Object(cfg.Key, md))
+}
+
+// read gathers the available metadata, sorted by key.
+func (cfg KubernetesMetadataConfig) read() configObject {
+	var md configObject
+	add := func(key string, value interface{}) {
+		md = append(md, configField{key: key, value: value})
+	}
+
+	if labels := readPodLabels(cfg.LabelsFile); len(labels) > 0 {
+		add("labels", labels)
+	}
+	namespace := lookupEnv(cfg.NamespaceEnv)
+	if namespace == "" && cfg.NamespaceFile != "" {
+		if bs, err := os.ReadFile(cfg.NamespaceFile); err == nil {
+			namespace = string(bytes.TrimSpace(bs))
+		}
+	}
+	if namespace != "" {
+		add("namespace", namespace)
+	}
+	if node := lookupEnv(cfg.NodeNameEnv); node != "" {
+		add("node", node)
+	}
+	if pod := lookupEnv(cfg.PodNameEnv); pod != "" {
+		add("pod", pod)
+	}
+	return md
+}
+
+func lookupEnv(name string) string {
+	if name == "" {
+		return ""
+	}
+	return os.Getenv(name)
+}
+
+// readPodLabels parses a downward API labels file, skipping malformed lines.
+func readPodLabels(path string) configObject {
+	if path == "" {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var labels configObject
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		key, quoted, ok := strings.Cut(s.Text(), "=")
+		if !ok || key == "" {
+			continue
+		}
+		value, err := strconv.Unquote(quoted)
+		if err != nil {
+			continue
+		}
+		labels = append(labels, configField{key: key, value: value})
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].key < labels[j].key })
+	return labels
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithKubernetesMetadata(t *testing.T) {
+	dir := t.TempDir()
+	labels := filepath.Join(dir, "labels")
+	namespace := filepath.Join(dir, "namespace")
+	require.NoError(t, os.WriteFile(labels, []byte(
+		"app=\"api\"\n"+
+			"app.kubernetes.io/version=\"1.2\"\n"+
+			"malformed\n"+
+			"quote=\"say \\\"hi\\\"\"\n",
+	), 0o644))
+	require.NoError(t, os.WriteFile(namespace, []byte("prod\n"), 0o644))
+
+	t.Setenv("ZAP_TEST_POD", "api-7d9f")
+	t.Setenv("ZAP_TEST_NODE", "node-1")
+	t.Setenv("ZAP_TEST_NAMESPACE", "")
+
+	cfg := KubernetesMetadataConfig{
+		Key:           "k8s",
+		PodNameEnv:    "ZAP_TEST_POD",
+		NamespaceEnv:  "ZAP_TEST_NAMESPACE",
+		NamespaceFile: namespace,
+		NodeNameEnv:   "ZAP_TEST_NODE",
+		LabelsFile:    labels,
+	}
+
+	t.Run("nested", func(t *testing.T) {
+		withLogger(t, InfoLevel, opts(WithKubernetesMetadata(cfg)), func(logger *Logger, logs *observer.ObservedLogs) {
+			logger.Info("hello")
+			assert.Equal(t, map[string]interface{}{
+				"k8s": map[string]interface{}{
+					"labels": map[string]interface{}{
+						"app":                       "api",
+						"app.kubernetes.io/version": "1.2",
+						"quote":                     `say "hi"`,
+					},
+					"namespace": "prod",
+					"node":      "node-1",
+					"pod":       "api-7d9f",
+				},
+			}, logs.All()[0].ContextMap(), "Unexpected metadata.")
+		})
+	})
+
+	t.Run("top-level", func(t *testing.T) {
+		cfg := cfg
+		cfg.Key = ""
+		cfg.LabelsFile = ""
+		t.Setenv("ZAP_TEST_NAMESPACE", "staging")
+		withLogger(t, InfoLevel, opts(WithKubernetesMetadata(cfg)), func(logger *Logger, logs *observer.ObservedLogs) {
+			logger.Info("hello")
+			assert.Equal(t, map[string]interface{}{
+				"namespace": "staging",
+				"node":      "node-1",
+				"pod":       "api-7d9f",
+			}, logs.All()[0].ContextMap(), "Expected top-level fields, preferring the environment.")
+		})
+	})
+
+	t.Run("outside Kubernetes", func(t *testing.T) {
+		cfg := KubernetesMetadataConfig{
+			Key:           "k8s",
+			PodNameEnv:    "ZAP_TEST_UNSET",
+			NamespaceFile: filepath.Join(dir, "missing"),
+			LabelsFile:    filepath.Join(dir, "missing"),
+		}
+		withLogger(t, InfoLevel, opts(WithKubernetesMetadata(cfg)), func(logger *Logger, logs *observer.ObservedLogs) {
+			logger.Info("hello")
+			assert.Empty(t, logs.All()[0].Context, "Expected no fields.")
+		})
+	})
+}
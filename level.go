@@ -28,6 +28,9 @@ import (
 )
 
 const (
+	// TraceLevel logs are finer-grained than Debug, such as those tracing
+	// the flow of a program step by step.
+	TraceLevel = zapcore.TraceLevel
 	// DebugLevel logs are typically voluminous, and are usually disabled in
 	// production.
 	DebugLevel = zapcore.DebugLevel
@@ -129,8 +132,9 @@ func (lvl AtomicLevel) String() string {
 }
 
 // UnmarshalText unmarshals the text to an AtomicLevel. It uses the same text
-// representations as the static zapcore.Levels ("debug", "info", "warn",
-// "error", "dpanic", "panic", and "fatal").
+// representations as the static zapcore.Levels ("trace", "debug", "info",
+// "warn", "error", "dpanic", "panic", and "fatal"), along with the names of levels
+// registered with zapcore.RegisterLevel.
 func (lvl *AtomicLevel) UnmarshalText(text []byte) error {
 	if lvl.l == nil {
 		lvl.l = &atomic.Int32{}
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"runtime"
 	"strings"
 
 	"go.uber.org/zap/internal/bufferpool"
@@ -41,19 +42,34 @@ import (
 type Logger struct {
 	core zapcore.Core
 
-	development bool
-	addCaller   bool
-	onPanic     zapcore.CheckWriteHook // default is WriteThenPanic
-	onFatal     zapcore.CheckWriteHook // default is WriteThenFatal
+	development    bool
+	addCaller      bool
+	onPanic        zapcore.CheckWriteHook // default is WriteThenPanic
+	onFatal        zapcore.CheckWriteHook // default is WriteThenFatal
+	fatalBehaviors []func(zapcore.Entry, []Field)
 
 	name        string
 	errorOutput zapcore.WriteSyncer
 
 	addStack zapcore.LevelEnabler
 
-	callerSkip int
+	callerSkip       int
+	callerTrimPrefix string
 
 	clock zapcore.Clock
+
+	eventID     string
+	eventIDHook func(zapcore.Entry) string // nil unless EventIDHook is used
+
+	useArena bool
+
+	sugarValidator *sugarValidator // nil unless StrictSugar is used
+
+	stats      *statsCounter // nil unless stats are collected
+	errorHook  func(error, zapcore.Entry)
+	afterWrite func(zapcore.Entry, error) // see updateAfterWrite
+
+	traceHook func(zapcore.Entry, []zapcore.TraceStep) // nil unless TraceEntries is used
 }
 
 // New constructs a new Logger from the provided zapcore.Core and Options. If
@@ -62,8 +78,8 @@ type Logger struct {
 //
 // This is the most flexible way to construct a Logger, but also the most
 // verbose. For typical use cases, the highly-opinionated presets
-// (NewProduction, NewDevelopment, and NewExample) or the Config struct are
-// more convenient.
+// (NewProduction, NewDevelopment, and NewExample), the NewWith family, or the
+// Config struct are more convenient.
 //
 // For sample code, see the package-level AdvancedConfiguration example.
 func New(core zapcore.Core, options ...Option) *Logger {
@@ -139,6 +155,37 @@ func NewExample(options ...Option) *Logger {
 	return New(core).WithOptions(options...)
 }
 
+// NewWith builds a Logger that encodes entries with enc and writes those that
+// level enables to ws, skipping Config and sink registration entirely. The
+// Logger adds nothing else: use options like AddCaller and AddStacktrace as
+// needed.
+//
+// ws is used as-is. If it isn't safe for concurrent use, wrap it with
+// zapcore.Lock.
+//
+// It's a shortcut for New(zapcore.NewCore(enc, ws, level), ...Option).
+func NewWith(enc zapcore.Encoder, ws zapcore.WriteSyncer, level zapcore.LevelEnabler, options ...Option) *Logger {
+	return New(zapcore.NewCore(enc, ws, level), options...)
+}
+
+// NewStdJSON builds a Logger that writes logs that level enables to w as JSON,
+// using the production encoder configuration. Writes to w are serialized, so
+// it needn't be safe for concurrent use.
+//
+//	logger := zap.NewStdJSON(os.Stdout, zap.InfoLevel)
+func NewStdJSON(w io.Writer, level zapcore.LevelEnabler, options ...Option) *Logger {
+	enc := zapcore.NewJSONEncoder(NewProductionEncoderConfig())
+	return NewWith(enc, zapcore.Lock(zapcore.AddSync(w)), level, options...)
+}
+
+// NewStdConsole builds a Logger that writes logs that level enables to w in a
+// human-friendly format, using the development encoder configuration. Writes
+// to w are serialized, so it needn't be safe for concurrent use.
+func NewStdConsole(w io.Writer, level zapcore.LevelEnabler, options ...Option) *Logger {
+	enc := zapcore.NewConsoleEncoder(NewDevelopmentEncoderConfig())
+	return NewWith(enc, zapcore.Lock(zapcore.AddSync(w)), level, options...)
+}
+
 // Sugar wraps the Logger to provide a more ergonomic, but slightly slower,
 // API. Sugaring a Logger is quite inexpensive, so it's reasonable for a
 // single application to use both Loggers and SugaredLoggers, converting
@@ -189,7 +236,9 @@ func (log *Logger) With(fields ...Field) *Logger {
 // WithLazy creates a child logger and adds structured context to it lazily.
 //
 // The fields are evaluated only if the logger is further chained with [With]
-// or is written to with any of the log level methods.
+// or is written to with any of the log level methods. Entries below the
+// logger's level don't count, so a child that only logs disabled entries
+// never clones its core or encodes its fields.
 // Until that occurs, the logger may retain references to objects inside the fields,
 // and logging will reflect the state of an object at the time of logging,
 // not the time of WithLazy().
@@ -208,6 +257,18 @@ func (log *Logger) WithLazy(fields ...Field) *Logger {
 	}))
 }
 
+// WithEventID creates a child logger that assigns id to every entry it
+// logs, so that statements sharing a stable code, such as an error code,
+// can be emitted and filtered on without a regular field. The ID is set on
+// zapcore.Entry.EventID before the entry is checked and is written under
+// the encoder's EventIDKey. An empty id clears the event ID, letting the
+// EventIDHook assign one instead.
+func (log *Logger) WithEventID(id string) *Logger {
+	l := log.clone()
+	l.eventID = id
+	return l
+}
+
 // Level reports the minimum enabled level for this logger.
 //
 // For NopLoggers, this is [zapcore.InvalidLevel].
@@ -232,6 +293,14 @@ func (log *Logger) Log(lvl zapcore.Level, msg string, fields ...Field) {
 	}
 }
 
+// Trace logs a message at TraceLevel. The message includes any fields passed
+// at the log site, as well as any fields accumulated on the logger.
+func (log *Logger) Trace(msg string, fields ...Field) {
+	if ce := log.check(TraceLevel, msg); ce != nil {
+		ce.Write(fields...)
+	}
+}
+
 // Debug logs a message at DebugLevel. The message includes any fields passed
 // at the log site, as well as any fields accumulated on the logger.
 func (log *Logger) Debug(msg string, fields ...Field) {
@@ -328,7 +397,9 @@ func (log *Logger) check(lvl zapcore.Level, msg string) *zapcore.CheckedEntry {
 
 	// Check the level first to reduce the cost of disabled log calls.
 	// Since Panic and higher may exit, we skip the optimization for those levels.
-	if lvl < zapcore.DPanicLevel && !log.core.Enabled(lvl) {
+	// Traced entries are always checked, so that cores record why they're
+	// rejected.
+	if lvl < zapcore.DPanicLevel && log.traceHook == nil && !log.core.Enabled(lvl) {
 		return nil
 	}
 
@@ -339,16 +410,32 @@ func (log *Logger) check(lvl zapcore.Level, msg string) *zapcore.CheckedEntry {
 		Time:       log.clock.Now(),
 		Level:      lvl,
 		Message:    msg,
+		EventID:    log.eventID,
+	}
+	if ent.EventID == "" && log.eventIDHook != nil {
+		ent.EventID = log.eventIDHook(ent)
+	}
+	if log.traceHook != nil {
+		ent.Trace = new(zapcore.EntryTrace)
 	}
 	ce := log.core.Check(ent, nil)
 	willWrite := ce != nil
+	if !willWrite && log.traceHook != nil {
+		// No core accepted the entry, so it won't reach AfterWrite.
+		log.traceHook(ent, ent.Trace.Steps())
+	}
 
 	// Set up any required terminal behavior.
 	switch ent.Level {
 	case zapcore.PanicLevel:
-		ce = ce.After(ent, terminalHookOverride(zapcore.WriteThenPanic, log.onPanic))
+		ce = ce.After(ent, flushAllThen{terminalHookOverride(zapcore.WriteThenPanic, log.onPanic)})
 	case zapcore.FatalLevel:
-		ce = ce.After(ent, terminalHookOverride(zapcore.WriteThenFatal, log.onFatal))
+		onFatal := terminalHookOverride(zapcore.WriteThenFatal, log.onFatal)
+		if len(log.fatalBehaviors) > 0 {
+			ce = ce.After(ent, fatalBehaviorHook{fns: log.fatalBehaviors, next: onFatal})
+		} else {
+			ce = ce.After(ent, flushAllThen{onFatal})
+		}
 	case zapcore.DPanicLevel:
 		if log.development {
 			ce = ce.After(ent, terminalHookOverride(zapcore.WriteThenPanic, log.onPanic))
@@ -362,31 +449,36 @@ func (log *Logger) check(lvl zapcore.Level, msg string) *zapcore.CheckedEntry {
 		return ce
 	}
 
-	// Thread the error output through to the CheckedEntry.
+	// Thread the error output and write callback through to the CheckedEntry.
 	ce.ErrorOutput = log.errorOutput
+	ce.AfterWrite = log.afterWrite
+	ce.UseArena = log.useArena
 
 	addStack := log.addStack.Enabled(ce.Level)
 	if !log.addCaller && !addStack {
 		return ce
 	}
 
-	// Adding the caller or stack trace requires capturing the callers of
-	// this function. We'll share information between these two.
-	stackDepth := stacktrace.First
-	if addStack {
-		stackDepth = stacktrace.Full
+	if !addStack {
+		// Only the caller is needed. Its frame is cached by program counter,
+		// which is much cheaper than capturing and resolving a stack.
+		frame, ok := stacktrace.Caller(log.callerSkip + callerSkipOffset)
+		if !ok {
+			log.reportCallerError(ent)
+			return ce
+		}
+		ce.Caller = log.entryCaller(frame)
+		return ce
 	}
-	stack := stacktrace.Capture(log.callerSkip+callerSkipOffset, stackDepth)
+
+	// Adding the caller and stack trace requires capturing the callers of
+	// this function. We'll share information between these two.
+	stack := stacktrace.Capture(log.callerSkip+callerSkipOffset, stacktrace.Full)
 	defer stack.Free()
 
 	if stack.Count() == 0 {
 		if log.addCaller {
-			_, _ = fmt.Fprintf(
-				log.errorOutput,
-				"%v Logger.check error: failed to get caller\n",
-				ent.Time.UTC(),
-			)
-			_ = log.errorOutput.Sync()
+			log.reportCallerError(ent)
 		}
 		return ce
 	}
@@ -394,13 +486,7 @@ func (log *Logger) check(lvl zapcore.Level, msg string) *zapcore.CheckedEntry {
 	frame, more := stack.Next()
 
 	if log.addCaller {
-		ce.Caller = zapcore.EntryCaller{
-			Defined:  frame.PC != 0,
-			PC:       frame.PC,
-			File:     frame.File,
-			Line:     frame.Line,
-			Function: frame.Function,
-		}
+		ce.Caller = log.entryCaller(frame)
 	}
 
 	if addStack {
@@ -421,6 +507,26 @@ func (log *Logger) check(lvl zapcore.Level, msg string) *zapcore.CheckedEntry {
 	return ce
 }
 
+// entryCaller builds the caller annotation for an entry from a frame.
+func (log *Logger) entryCaller(frame runtime.Frame) zapcore.EntryCaller {
+	return zapcore.EntryCaller{
+		Defined:  frame.PC != 0,
+		PC:       frame.PC,
+		File:     strings.TrimPrefix(frame.File, log.callerTrimPrefix),
+		Line:     frame.Line,
+		Function: frame.Function,
+	}
+}
+
+func (log *Logger) reportCallerError(ent zapcore.Entry) {
+	_, _ = fmt.Fprintf(
+		log.errorOutput,
+		"%v Logger.check error: failed to get caller\n",
+		ent.Time.UTC(),
+	)
+	_ = log.errorOutput.Sync()
+}
+
 func terminalHookOverride(defaultHook, override zapcore.CheckWriteHook) zapcore.CheckWriteHook {
 	// A nil or WriteThenNoop hook will lead to continued execution after
 	// a Panic or Fatal log entry, which is unexpected. For example,
@@ -21,7 +21,9 @@
 package zap
 
 import (
+	"encoding/json"
 	"errors"
+	"net"
 	"runtime"
 	"strconv"
 	"sync"
@@ -51,6 +53,12 @@ var _jane = &user{
 	CreatedAt: time.Date(1980, 1, 1, 12, 0, 0, 0, time.UTC),
 }
 
+var (
+	_benchIP      = net.ParseIP("192.168.1.1")
+	_benchUUID    = [16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+	_benchRawJSON = []byte(`{"id":42,"tags":["a","b"]}`)
+)
+
 func withBenchedLogger(b *testing.B, f func(*Logger)) {
 	logger := New(
 		zapcore.NewCore(
@@ -242,6 +250,35 @@ func benchmarkWithUsed(b *testing.B, withMethod func(*Logger, ...zapcore.Field)
 	})
 }
 
+// Benchmark5Fields covers the most common shape of log call: a handful of
+// fields, written to a single core. It shouldn't allocate.
+func Benchmark5Fields(b *testing.B) {
+	withBenchedLogger(b, func(log *Logger) {
+		log.Info("Five fields, passed at the log site.",
+			Int("one", 1),
+			String("two", "2"),
+			Bool("three", true),
+			Duration("four", 4*time.Second),
+			Float64("five", 5.5),
+		)
+	})
+}
+
+// Benchmark5FieldsChecked is Benchmark5Fields, using Check and Write.
+func Benchmark5FieldsChecked(b *testing.B) {
+	withBenchedLogger(b, func(log *Logger) {
+		if ce := log.Check(InfoLevel, "Five fields, passed at the log site."); ce != nil {
+			ce.Write(
+				Int("one", 1),
+				String("two", "2"),
+				Bool("three", true),
+				Duration("four", 4*time.Second),
+				Float64("five", 5.5),
+			)
+		}
+	})
+}
+
 func Benchmark10Fields(b *testing.B) {
 	withBenchedLogger(b, func(log *Logger) {
 		log.Info("Ten fields, passed at the log site.",
@@ -302,6 +339,21 @@ func BenchmarkAny(b *testing.B) {
 			typed:  func() Field { return Stringer(key, InfoLevel) },
 			anyArg: InfoLevel,
 		},
+		{
+			name:   "ip",
+			typed:  func() Field { return IPAddr(key, _benchIP) },
+			anyArg: _benchIP,
+		},
+		{
+			name:   "uuid",
+			typed:  func() Field { return UUID(key, _benchUUID) },
+			anyArg: _benchUUID,
+		},
+		{
+			name:   "raw-json",
+			typed:  func() Field { return RawJSON(key, _benchRawJSON) },
+			anyArg: json.RawMessage(_benchRawJSON),
+		},
 	}
 
 	for _, tt := range tests {
@@ -21,12 +21,18 @@
 package zap
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"path/filepath"
+	"runtime"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"go.uber.org/zap/internal/exit"
 	"go.uber.org/zap/internal/ztest"
@@ -112,6 +118,51 @@ func TestLoggerLevel(t *testing.T) {
 	})
 }
 
+func TestLoggerWriteAllocs(t *testing.T) {
+	if ztest.RaceEnabled {
+		t.Skip("sync.Pool drops items under the race detector")
+	}
+
+	newCore := func() zapcore.Core {
+		return zapcore.NewCore(
+			zapcore.NewJSONEncoder(NewProductionEncoderConfig()),
+			&ztest.Discarder{},
+			DebugLevel,
+		)
+	}
+	cores := map[string]zapcore.Core{
+		"core":    newCore(),
+		"sampler": zapcore.NewSamplerWithOptions(newCore(), time.Second, 1<<30, 0),
+	}
+	for name, core := range cores {
+		t.Run(name, func(t *testing.T) {
+			logger := New(core)
+			allocs := testing.AllocsPerRun(100, func() {
+				logger.Info("five fields",
+					Int("one", 1),
+					String("two", "2"),
+					Bool("three", true),
+					Int64("four", 4),
+					Float64("five", 5.5),
+				)
+			})
+			assert.Zero(t, allocs, "Expected logging a few fields to one core not to allocate.")
+		})
+	}
+}
+
+func TestLoggerRetainingCoreFields(t *testing.T) {
+	core, logs := observer.New(DebugLevel)
+	logger := New(core)
+	logger.Info("first", String("a", "1"))
+	logger.Info("second", String("b", "2"))
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2, "Expected two entries.")
+	assert.Equal(t, []Field{String("a", "1")}, entries[0].Context, "Fields kept by a core were overwritten.")
+	assert.Equal(t, []Field{String("b", "2")}, entries[1].Context, "Unexpected fields for the second entry.")
+}
+
 func TestLoggerInitialFields(t *testing.T) {
 	fieldOpts := opts(Fields(Int("foo", 42), String("bar", "baz")))
 	withLogger(t, DebugLevel, fieldOpts, func(logger *Logger, logs *observer.ObservedLogs) {
@@ -430,11 +481,12 @@ func TestLoggerLogFatal(t *testing.T) {
 }
 
 func TestLoggerLeveledMethods(t *testing.T) {
-	withLogger(t, DebugLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
+	withLogger(t, TraceLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
 		tests := []struct {
 			method        func(string, ...Field)
 			expectedLevel zapcore.Level
 		}{
+			{logger.Trace, TraceLevel},
 			{logger.Debug, DebugLevel},
 			{logger.Info, InfoLevel},
 			{logger.Warn, WarnLevel},
@@ -456,8 +508,9 @@ func TestLoggerLeveledMethods(t *testing.T) {
 }
 
 func TestLoggerLogLevels(t *testing.T) {
-	withLogger(t, DebugLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
+	withLogger(t, TraceLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
 		levels := []zapcore.Level{
+			TraceLevel,
 			DebugLevel,
 			InfoLevel,
 			WarnLevel,
@@ -587,6 +640,64 @@ func TestLoggerNames(t *testing.T) {
 	}
 }
 
+func TestLoggerEventID(t *testing.T) {
+	codes := map[string]string{"disk full": "E2001"}
+	hook := EventIDHook(func(ent zapcore.Entry) string { return codes[ent.Message] })
+
+	withLogger(t, DebugLevel, []Option{hook}, func(log *Logger, logs *observer.ObservedLogs) {
+		log.Info("disk full")
+		log.Info("started")
+		log.WithEventID("E1001").Info("disk full")
+		log.WithEventID("E1001").WithEventID("").Info("disk full")
+		log.WithEventID("E1001").Sugar().Infow("sugared")
+
+		var got []string
+		for _, e := range logs.AllUntimed() {
+			got = append(got, e.EventID)
+		}
+		assert.Equal(t, []string{"E2001", "", "E1001", "E2001", "E1001"}, got, "Unexpected event IDs.")
+	})
+
+	t.Run("filtered before writing", func(t *testing.T) {
+		fac, logs := observer.New(DebugLevel)
+		log := New(zapcore.NewFilterCore(fac, zapcore.Not(zapcore.EventIDIs("E1001"))))
+		log.WithEventID("E1001").Info("dropped")
+		log.WithEventID("E1002").Info("kept")
+		require.Equal(t, 1, logs.Len(), "Expected only one entry to be written.")
+		assert.Equal(t, "kept", logs.AllUntimed()[0].Message, "Unexpected entry written.")
+	})
+}
+
+type marshalCounter struct{ n *int }
+
+func (m marshalCounter) MarshalJSON() ([]byte, error) {
+	*m.n++
+	return []byte(`"counted"`), nil
+}
+
+func TestLoggerEntryArena(t *testing.T) {
+	for _, opts := range [][]Option{nil, {EntryArena()}} {
+		var bufs [3]bytes.Buffer
+		var cores []zapcore.Core
+		for i := range bufs {
+			enc := zapcore.NewJSONEncoder(NewProductionEncoderConfig())
+			cores = append(cores, zapcore.NewCore(enc, zapcore.AddSync(&bufs[i]), DebugLevel))
+		}
+		logger := New(zapcore.NewTee(cores...), opts...)
+
+		var n int
+		logger.Info("fan out", Reflect("v", marshalCounter{&n}))
+		want := 3
+		if len(opts) > 0 {
+			want = 1
+		}
+		assert.Equal(t, want, n, "Unexpected number of encodings with options %v.", opts)
+		for i := range bufs {
+			assert.Contains(t, bufs[i].String(), `"v":"counted"`, "Unexpected output from core %d.", i)
+		}
+	}
+}
+
 func TestLoggerWriteFailure(t *testing.T) {
 	errSink := &ztest.Buffer{}
 	logger := New(
@@ -656,6 +767,25 @@ func TestLoggerAddCaller(t *testing.T) {
 	}
 }
 
+func TestLoggerTrimCallerPath(t *testing.T) {
+	_, file, _, ok := runtime.Caller(0)
+	require.True(t, ok)
+	dir := filepath.Dir(file) + "/"
+
+	withLogger(t, DebugLevel, opts(AddCaller(), TrimCallerPath(dir)), func(logger *Logger, logs *observer.ObservedLogs) {
+		logger.Info("caller only")
+		logger.WithOptions(AddStacktrace(DebugLevel)).Info("with stacktrace")
+
+		output := logs.AllUntimed()
+		require.Len(t, output, 2)
+		for _, ent := range output {
+			assert.Equal(t, "logger_test.go", ent.Caller.File, "Expected the prefix to be trimmed.")
+			assert.Equal(t, "go.uber.org/zap.TestLoggerTrimCallerPath.func1", ent.Caller.Function)
+		}
+		assert.Equal(t, output[0].Caller.Line+1, output[1].Caller.Line)
+	})
+}
+
 func TestLoggerAddCallerFunction(t *testing.T) {
 	tests := []struct {
 		options         []Option
@@ -1021,6 +1151,77 @@ func TestLoggerWithFatalHook(t *testing.T) {
 	})
 }
 
+func TestLoggerWithExitCode(t *testing.T) {
+	withLogger(t, InfoLevel, opts(WithExitCode(3)), func(logger *Logger, logs *observer.ObservedLogs) {
+		stub := exit.WithStub(func() { logger.Fatal("great sadness") })
+		assert.True(t, stub.Exited, "Expected Fatal to exit.")
+		assert.Equal(t, 3, stub.Code, "Unexpected exit code.")
+		assert.Equal(t, 1, logs.FilterLevelExact(FatalLevel).Len())
+	})
+}
+
+// syncerFunc is a Syncer that calls itself on Sync.
+type syncerFunc func() error
+
+func (f syncerFunc) Sync() error { return f() }
+
+func TestLoggerWithFatalBehavior(t *testing.T) {
+	useEmptyFlushRegistry(t)
+
+	var calls []string
+	defer RegisterFlush(syncerFunc(func() error {
+		calls = append(calls, "flush")
+		return nil
+	}))()
+	behavior := func(name string) Option {
+		return WithFatalBehavior(func(ent zapcore.Entry, fields []Field) {
+			assert.Equal(t, "great sadness", ent.Message, "Unexpected entry.")
+			assert.Equal(t, []Field{Int("attempts", 3)}, fields, "Unexpected fields.")
+			calls = append(calls, name)
+		})
+	}
+
+	withLogger(t, InfoLevel, opts(behavior("close db"), WithExitCode(2)), func(logger *Logger, logs *observer.ObservedLogs) {
+		child := logger.WithOptions(behavior("flush traces"))
+		sibling := logger.WithOptions(behavior("sibling"))
+
+		stub := exit.WithStub(func() { child.Fatal("great sadness", Int("attempts", 3)) })
+		assert.Equal(t, []string{"close db", "flush traces", "flush"}, calls,
+			"Expected behaviors to run in order before flushing.")
+		assert.True(t, stub.Exited, "Expected Fatal to exit after running behaviors.")
+		assert.Equal(t, 2, stub.Code, "Unexpected exit code.")
+
+		calls = nil
+		stub = exit.WithStub(func() { sibling.Fatal("great sadness", Int("attempts", 3)) })
+		assert.Equal(t, []string{"close db", "sibling", "flush"}, calls,
+			"Expected loggers derived from the same parent not to share behaviors.")
+		assert.True(t, stub.Exited, "Expected Fatal to exit after running behaviors.")
+	})
+}
+
+func TestLoggerWithFatalBehaviorPanic(t *testing.T) {
+	useEmptyFlushRegistry(t)
+
+	var flushed bool
+	defer RegisterFlush(syncerFunc(func() error {
+		flushed = true
+		return nil
+	}))()
+	toPanic := WithFatalBehavior(func(ent zapcore.Entry, _ []Field) {
+		panic(ent.Message)
+	})
+
+	withLogger(t, InfoLevel, opts(toPanic), func(logger *Logger, logs *observer.ObservedLogs) {
+		stub := exit.WithStub(func() {
+			assert.PanicsWithValue(t, "great sadness", func() { logger.Fatal("great sadness") },
+				"Expected the behavior to turn Fatal into a panic.")
+		})
+		assert.False(t, stub.Exited, "Expected no exit after a panic.")
+		assert.True(t, flushed, "Expected registered Syncers to be flushed before panicking.")
+		assert.Equal(t, 1, logs.FilterLevelExact(FatalLevel).Len())
+	})
+}
+
 func TestNopLogger(t *testing.T) {
 	logger := NewNop()
 
@@ -1052,6 +1253,116 @@ func TestMust(t *testing.T) {
 	})
 }
 
+func TestNewWith(t *testing.T) {
+	buf := &ztest.Buffer{}
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"})
+	logger := NewWith(enc, buf, WarnLevel, Fields(String("k", "v")))
+
+	logger.Info("dropped")
+	logger.Warn("kept")
+	assert.Equal(t, []string{`{"msg":"kept","k":"v"}`}, buf.Lines(), "Unexpected output.")
+}
+
+func TestNewStdPresets(t *testing.T) {
+	tests := []struct {
+		desc string
+		new  func(io.Writer, zapcore.LevelEnabler, ...Option) *Logger
+		want string
+	}{
+		{"json", NewStdJSON, `"level":"info"`},
+		{"console", NewStdConsole, "\tINFO\t"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := tt.new(&buf, InfoLevel, WithClock(ztest.NewMockClock()))
+			logger.Debug("dropped")
+			logger.Info("kept")
+			require.NoError(t, logger.Sync(), "Unexpected error syncing.")
+
+			out := buf.String()
+			assert.Equal(t, 1, strings.Count(out, "\n"), "Expected a single line, got %q.", out)
+			assert.Contains(t, out, "kept", "Missing message.")
+			assert.Regexp(t, tt.want, out, "Unexpected level format.")
+		})
+	}
+}
+
+func TestLoggerRecoversFieldErrors(t *testing.T) {
+	buf := &ztest.Buffer{}
+	var hooked []error
+	logger := New(
+		zapcore.NewCore(
+			zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+				MessageKey:  "msg",
+				FieldErrors: zapcore.RecoverFieldErrors,
+			}),
+			buf,
+			DebugLevel,
+		),
+		ErrorOutput(zapcore.AddSync(&ztest.Discarder{})),
+		ErrorHook(func(err error, _ zapcore.Entry) { hooked = append(hooked, err) }),
+	)
+
+	logger.Info("hi", String("a", "b"), Object("obj", zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+		enc.AddString("partial", "x")
+		panic("boom")
+	})), Int("c", 1))
+
+	assert.Equal(t, `{"msg":"hi","a":"b","objError":"PANIC=boom","c":1}`, buf.Stripped(), "Expected the rest of the entry to be written.")
+	require.Len(t, hooked, 1, "Expected the error hook to be called once.")
+	var fe *zapcore.FieldEncodingError
+	require.ErrorAs(t, hooked[0], &fe, "Expected the hook to receive a FieldEncodingError.")
+	assert.Equal(t, "obj", fe.Key, "Unexpected key for the failed field.")
+}
+
+func TestLoggerCheckWith(t *testing.T) {
+	withLogger(t, DebugLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
+		logger = logger.With(String("ctx", "x"))
+		if ce := logger.Check(InfoLevel, "staged"); ce != nil {
+			ce.With(Int("a", 1)).With(Int("b", 2)).Write(Int("c", 3))
+		}
+		assert.Nil(t, logger.Check(DebugLevel-1, "disabled").With(Int("a", 1)), "Expected With on a disabled entry to return nil.")
+
+		require.Equal(t, 1, logs.Len(), "Expected one entry to be logged.")
+		assert.Equal(t, []Field{String("ctx", "x"), Int("a", 1), Int("b", 2), Int("c", 3)}, logs.AllUntimed()[0].Context, "Unexpected context.")
+	})
+}
+
+func TestLoggerTraceEntries(t *testing.T) {
+	type traced struct {
+		msg   string
+		steps []string
+	}
+	var got []traced
+	var errs []error
+	logger := New(
+		zapcore.NewCore(zapcore.NewJSONEncoder(zapcore.EncoderConfig{}), &ztest.FailWriter{}, InfoLevel),
+		ErrorOutput(zapcore.AddSync(&ztest.Discarder{})),
+		ErrorHook(func(err error, _ zapcore.Entry) { errs = append(errs, err) }),
+		TraceEntries(func(ent zapcore.Entry, steps []zapcore.TraceStep) {
+			tr := traced{msg: ent.Message}
+			for _, s := range steps {
+				tr.steps = append(tr.steps, s.String())
+			}
+			got = append(got, tr)
+		}),
+	)
+
+	logger.Debug("disabled")
+	logger.Info("enabled")
+
+	assert.Equal(t, []traced{
+		{msg: "disabled", steps: []string{"*zapcore.jsonCore(*ztest.FailWriter) rejected"}},
+		{msg: "enabled", steps: []string{
+			"*zapcore.jsonCore(*ztest.FailWriter) accepted",
+			"*zapcore.jsonCore(*ztest.FailWriter) write failed: failed",
+		}},
+	}, got, "Unexpected traces.")
+	assert.Len(t, errs, 1, "Expected the error hook to still be called.")
+}
+
 func infoLog(logger *Logger, msg string, fields ...Field) {
 	logger.Info(msg, fields...)
 }
@@ -111,6 +111,16 @@ func AddCallerSkip(skip int) Option {
 	})
 }
 
+// TrimCallerPath configures the Logger to remove the given prefix from the
+// file paths of callers it annotates entries with. Passing the directory a
+// module was built from, such as "/home/build/src/github.com/org/repo/",
+// makes FullPathCallerEncoder report module-relative paths.
+func TrimCallerPath(prefix string) Option {
+	return optionFunc(func(log *Logger) {
+		log.callerTrimPrefix = prefix
+	})
+}
+
 // AddStacktrace configures the Logger to record a stack trace for all messages at
 // or above a given level.
 func AddStacktrace(lvl zapcore.LevelEnabler) Option {
@@ -177,10 +187,159 @@ func WithFatalHook(hook zapcore.CheckWriteHook) Option {
 	})
 }
 
+// WithExitCode makes fatal logs exit the process with the given status
+// instead of 1. It replaces any hook set with WithFatalHook.
+func WithExitCode(code int) Option {
+	return WithFatalHook(exitHook(code))
+}
+
+// WithFatalBehavior registers a function to run after a fatal log has been
+// written, before the process exits. It receives the fatal entry and the
+// fields logged with it, and may be used to release resources that exiting
+// would otherwise leak, such as flushing traces or closing database
+// connections. Functions run in the order they were registered, and the
+// Syncers registered with RegisterFlush are flushed after them, so anything
+// they log isn't lost.
+//
+// A function may also stop the exit altogether: to turn fatal logs into
+// panics in some deployments, for example, panic from it. Registered
+// Syncers are flushed before the panic propagates.
+func WithFatalBehavior(fn func(zapcore.Entry, []Field)) Option {
+	return optionFunc(func(log *Logger) {
+		// Clip the capacity so loggers derived with WithOptions never share
+		// a backing array.
+		log.fatalBehaviors = append(log.fatalBehaviors[:len(log.fatalBehaviors):len(log.fatalBehaviors)], fn)
+	})
+}
+
+// AddToFlushAll registers the logger with RegisterFlush, so that FlushAll
+// syncs its outputs. The registration lasts for the life of the process, so
+// use it for long-lived loggers, such as the one built from a Config when a
+// program starts.
+func AddToFlushAll() Option {
+	return optionFunc(func(log *Logger) {
+		RegisterFlush(log)
+	})
+}
+
 // WithClock specifies the clock used by the logger to determine the current
 // time for logged entries. Defaults to the system clock with time.Now.
+//
+// For very hot loggers, zapcore.NewCoarseClock avoids calling time.Now for
+// every entry. To stamp the entries of a single Core differently, wrap it
+// with zapcore.NewClockCore.
 func WithClock(clock zapcore.Clock) Option {
 	return optionFunc(func(log *Logger) {
 		log.clock = clock
 	})
 }
+
+// StrictSugar makes SugaredLoggers validate the loosely-typed key-value
+// pairs passed to With and the methods ending in "w". Without it, malformed
+// pairs are logged as best they can be, and reported with an entry at
+// ErrorLevel.
+//
+// In strict mode, each malformed pair -- a key without a value, a non-string
+// key, a zap.Field passed as a value, or an error after the first -- is
+// reported to onInvalid as a *SugarArgError. If onInvalid is nil, it's
+// reported with an entry at DPanicLevel instead, which panics in
+// development. The number of problems found is available from
+// SugaredLogger.ValidationStats.
+func StrictSugar(onInvalid func(error)) Option {
+	return optionFunc(func(log *Logger) {
+		log.sugarValidator = &sugarValidator{onInvalid: onInvalid}
+	})
+}
+
+// ErrorHook registers a function that's called whenever the Logger fails to
+// write an entry, with the error returned by its cores and the entry. Errors
+// are still reported to the Logger's error output; see ErrorOutput.
+//
+// The hook runs synchronously on the logging goroutine, so it should be
+// fast and must not log through the same Logger.
+func ErrorHook(hook func(err error, ent zapcore.Entry)) Option {
+	return optionFunc(func(log *Logger) {
+		log.errorHook = hook
+		log.updateAfterWrite()
+	})
+}
+
+// EventIDHook registers a function that assigns event IDs to entries that
+// don't already have one from Logger.WithEventID. It's called with the
+// entry's level, time, logger name, and message before the entry is checked,
+// so cores can filter on the result; return an empty string to leave the
+// entry without an event ID. For example, to look up codes by message:
+//
+//	zap.EventIDHook(func(ent zapcore.Entry) string {
+//	  return codes[ent.Message]
+//	})
+//
+// The hook runs for every entry that passes the level check, so it should be
+// fast.
+func EventIDHook(hook func(ent zapcore.Entry) string) Option {
+	return optionFunc(func(log *Logger) {
+		log.eventIDHook = hook
+	})
+}
+
+// EntryArena makes the Logger share the JSON encodings of reflected fields,
+// such as those built with Reflect or Any, between the cores an entry fans
+// out to, as with zapcore.NewTee. Cores built with JSON encoders then encode
+// each such field once per entry rather than once per core. Only fields
+// passed at the log site are shared, not values nested in objects or arrays;
+// encoder clones and buffers aren't affected, since they're pooled anyway.
+// It pays off for loggers that tee entries with reflected fields to several
+// cores; for others, it's a small overhead.
+//
+// Values must not be modified while they're being logged. See
+// zapcore.CheckedEntry.UseArena.
+func EntryArena() Option {
+	return optionFunc(func(log *Logger) {
+		log.useArena = true
+	})
+}
+
+// TraceEntries makes the Logger trace the path of every entry it checks
+// through its cores, and pass the recorded steps to hook: which cores
+// accepted or rejected the entry, which samplers, filters, and other cores
+// dropped it, and which cores wrote it, or failed to. Use it to find out why
+// entries aren't showing up without bisecting wrapper cores:
+//
+//	logger = logger.WithOptions(zap.TraceEntries(func(ent zapcore.Entry, steps []zapcore.TraceStep) {
+//	  for _, s := range steps {
+//	    fmt.Fprintf(os.Stderr, "%q: %v\n", ent.Message, s)
+//	  }
+//	}))
+//
+// The hook runs once the entry has been written, or right after the check
+// if no core accepted it. Only the built-in cores record steps; see
+// zapcore.EntryTrace. Tracing defeats the Logger's fast path for disabled
+// levels and allocates for every entry, so it's meant for debugging.
+func TraceEntries(hook func(ent zapcore.Entry, steps []zapcore.TraceStep)) Option {
+	return optionFunc(func(log *Logger) {
+		log.traceHook = hook
+		log.updateAfterWrite()
+	})
+}
+
+// CollectStats makes the Logger count the entries it writes and the write
+// errors it encounters. Retrieve the counters with Logger.Stats. Loggers
+// built from a Config can also count the bytes they write and the entries
+// they sample away; see Config.CollectStats.
+func CollectStats() Option {
+	return optionFunc(func(log *Logger) {
+		if log.stats == nil {
+			log.stats = new(statsCounter)
+		}
+		log.updateAfterWrite()
+	})
+}
+
+// withStats makes the Logger count into the given stats counter. It's used
+// by Config.Build to share a counter with the outputs and sampler it builds.
+func withStats(stats *statsCounter) Option {
+	return optionFunc(func(log *Logger) {
+		log.stats = stats
+		log.updateAfterWrite()
+	})
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// RuntimeMetadataConfig selects the process metadata that
+// WithRuntimeMetadata adds to a logger, and the keys it's logged under. Set
+// a key to the empty string to leave its field out.
+type RuntimeMetadataConfig struct {
+	// HostnameKey is the key for the hostname, as reported by the kernel.
+	HostnameKey string `json:"hostnameKey" yaml:"hostnameKey"`
+	// PIDKey is the key for the process ID.
+	PIDKey string `json:"pidKey" yaml:"pidKey"`
+	// VersionKey is the key for the version of the main module, as recorded
+	// in the executable's build information.
+	VersionKey string `json:"versionKey" yaml:"versionKey"`
+	// GoVersionKey is the key for the version of Go that built the
+	// executable.
+	GoVersionKey string `json:"goVersionKey" yaml:"goVersionKey"`
+	// ContainerIDKey is the key for the ID of the container the process runs
+	// in, parsed from its cgroups on Linux.
+	ContainerIDKey string `json:"containerIDKey" yaml:"containerIDKey"`
+}
+
+// NewRuntimeMetadataConfig returns a RuntimeMetadataConfig that adds all of
+// the available metadata, under the keys "hostname", "pid", "version",
+// "goVersion", and "containerID".
+func NewRuntimeMetadataConfig() RuntimeMetadataConfig {
+	return RuntimeMetadataConfig{
+		HostnameKey:    "hostname",
+		PIDKey:         "pid",
+		VersionKey:     "version",
+		GoVersionKey:   "goVersion",
+		ContainerIDKey: "containerID",
+	}
+}
+
+// WithRuntimeMetadata adds fields describing the host and process to the
+// Logger, as selected by cfg. The metadata is gathered once per process and
+// bound to the Logger like fields added with Fields, so it costs nothing
+// per entry. Metadata that isn't available, such as the container ID of a
+// process that isn't running in a container, is left out.
+//
+//	logger := zap.New(core, zap.WithRuntimeMetadata(zap.NewRuntimeMetadataConfig()))
+func WithRuntimeMetadata(cfg RuntimeMetadataConfig) Option {
+	return Fields(cfg.fields(loadRuntimeMetadata())...)
+}
+
+// runtimeMetadata holds the metadata of the current process.
+type runtimeMetadata struct {
+	hostname    string
+	pid         int
+	version     string
+	goVersion   string
+	containerID string
+}
+
+var (
+	_runtimeMetadataOnce sync.Once
+	_runtimeMetadata     runtimeMetadata
+)
+
+func loadRuntimeMetadata() runtimeMetadata {
+	_runtimeMetadataOnce.Do(func() {
+		md := runtimeMetadata{
+			pid:         os.Getpid(),
+			goVersion:   runtime.Version(),
+			containerID: readContainerID([]string{"/proc/self/cgroup", "/proc/self/mountinfo"}),
+		}
+		md.hostname, _ = os.Hostname()
+		if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "(devel)" {
+			md.version = info.Main.Version
+		}
+		_runtimeMetadata = md
+	})
+	return _runtimeMetadata
+}
+
+func (cfg RuntimeMetadataConfig) fields(md runtimeMetadata) []Field {
+	var fs []Field
+	addString := func(key, val string) {
+		if key != "" && val != "" {
+			fs = append(fs, String(key, val))
+		}
+	}
+	addString(cfg.HostnameKey, md.hostname)
+	if cfg.PIDKey != "" {
+		fs = append(fs, Int(cfg.PIDKey, md.pid))
+	}
+	addString(cfg.VersionKey, md.version)
+	addString(cfg.GoVersionKey, md.goVersion)
+	addString(cfg.ContainerIDKey, md.containerID)
+	return fs
+}
+
+// _containerIDRegexp matches the 64-character hexadecimal IDs that Docker,
+// containerd, and CRI-O give containers.
+var _containerIDRegexp = regexp.MustCompile(`\b[0-9a-f]{64}\b`)
+
+// readContainerID returns the first container ID found in the given
+// files. Under cgroups v1, /proc/self/cgroup lists paths that contain the
+// ID; under cgroups v2, it doesn't, but the mounts of the container's files
+// usually do.
+func readContainerID(paths []string) string {
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		id := scanContainerID(bufio.NewScanner(f), strings.HasSuffix(path, "mountinfo"))
+		_ = f.Close()
+		if id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// scanContainerID returns the first container ID in the lines of s. In
+// mountinfo files, only the IDs in container directories are considered,
+// since other mounts may contain unrelated hashes.
+func scanContainerID(s *bufio.Scanner, mountinfo bool) string {
+	for s.Scan() {
+		line := s.Text()
+		if mountinfo && !strings.Contains(line, "/containers/") {
+			continue
+		}
+		if id := _containerIDRegexp.FindString(line); id != "" {
+			return id
+		}
+	}
+	return ""
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithRuntimeMetadata(t *testing.T) {
+	cfg := NewRuntimeMetadataConfig()
+	cfg.VersionKey = "" // not recorded for test binaries
+	withLogger(t, InfoLevel, opts(WithRuntimeMetadata(cfg)), func(logger *Logger, logs *observer.ObservedLogs) {
+		logger.Info("hello")
+		require.Equal(t, 1, logs.Len(), "Expected an entry.")
+		fields := logs.All()[0].ContextMap()
+
+		hostname, err := os.Hostname()
+		require.NoError(t, err)
+		assert.Equal(t, hostname, fields["hostname"], "Unexpected hostname.")
+		assert.Equal(t, int64(os.Getpid()), fields["pid"], "Unexpected PID.")
+		assert.Equal(t, runtime.Version(), fields["goVersion"], "Unexpected Go version.")
+		assert.NotContains(t, fields, "version", "Expected omitted keys to be left out.")
+	})
+}
+
+func TestRuntimeMetadataFields(t *testing.T) {
+	md := runtimeMetadata{
+		hostname:    "host",
+		pid:         42,
+		version:     "v1.2.3",
+		goVersion:   "go1.23",
+		containerID: "",
+	}
+
+	assert.Equal(t, []Field{
+		String("hostname", "host"),
+		Int("pid", 42),
+		String("version", "v1.2.3"),
+		String("goVersion", "go1.23"),
+	}, NewRuntimeMetadataConfig().fields(md), "Expected unavailable metadata to be left out.")
+
+	assert.Equal(t, []Field{
+		String("service.version", "v1.2.3"),
+	}, RuntimeMetadataConfig{VersionKey: "service.version"}.fields(md), "Expected only configured keys.")
+}
+
+func TestReadContainerID(t *testing.T) {
+	const id = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	const otherHash = "fedcba9876543210fedcba9876543210fedcba9876543210fedcba9876543210"
+
+	tests := []struct {
+		desc      string
+		cgroup    string
+		mountinfo string
+		want      string
+	}{
+		{
+			desc:   "cgroups v1",
+			cgroup: "12:memory:/docker/" + id + "\n1:name=systemd:/docker/" + id + "\n",
+			want:   id,
+		},
+		{
+			desc:   "systemd scope",
+			cgroup: "0::/system.slice/docker-" + id + ".scope\n",
+			want:   id,
+		},
+		{
+			desc:   "cgroups v2",
+			cgroup: "0::/\n",
+			mountinfo: strings.Join([]string{
+				"1 2 0:3 / / rw - overlay overlay rw,lowerdir=/var/lib/" + otherHash,
+				"4 5 254:1 /var/lib/docker/containers/" + id + "/hostname /etc/hostname rw - ext4 /dev/vda1 rw",
+			}, "\n"),
+			want: id,
+		},
+		{
+			desc:      "not in a container",
+			cgroup:    "0::/user.slice/user-1000.slice/session-1.scope\n",
+			mountinfo: "1 2 0:3 / / rw - ext4 /dev/vda1 rw\n",
+			want:      "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dir := t.TempDir()
+			cgroup := filepath.Join(dir, "cgroup")
+			mountinfo := filepath.Join(dir, "mountinfo")
+			require.NoError(t, os.WriteFile(cgroup, []byte(tt.cgroup), 0o644))
+			if tt.mountinfo != "" {
+				require.NoError(t, os.WriteFile(mountinfo, []byte(tt.mountinfo), 0o644))
+			}
+			assert.Equal(t, tt.want, readContainerID([]string{cgroup, mountinfo}), "Unexpected container ID.")
+		})
+	}
+}
@@ -21,6 +21,7 @@
 package zap
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -51,19 +52,27 @@ func (e *errSinkNotFound) Error() string {
 	return fmt.Sprintf("no sink found for scheme %q", e.scheme)
 }
 
+// A SinkFactory opens the Sink for a URL. Use ctx to bound any I/O done while
+// opening the sink, such as dialing a remote collector; it isn't meant to be
+// retained afterwards. opts holds the URL's query parameters.
+type SinkFactory func(ctx context.Context, u *url.URL, opts SinkOptions) (Sink, error)
+
 type nopCloserSink struct{ zapcore.WriteSyncer }
 
 func (nopCloserSink) Close() error { return nil }
 
+// Unwrap returns the wrapped WriteSyncer.
+func (s nopCloserSink) Unwrap() zapcore.WriteSyncer { return s.WriteSyncer }
+
 type sinkRegistry struct {
 	mu        sync.Mutex
-	factories map[string]func(*url.URL) (Sink, error)          // keyed by scheme
+	factories map[string]SinkFactory                           // keyed by scheme
 	openFile  func(string, int, os.FileMode) (*os.File, error) // type matches os.OpenFile
 }
 
 func newSinkRegistry() *sinkRegistry {
 	sr := &sinkRegistry{
-		factories: make(map[string]func(*url.URL) (Sink, error)),
+		factories: make(map[string]SinkFactory),
 		openFile:  os.OpenFile,
 	}
 	// Infallible operation: the registry is empty, so we can't have a conflict.
@@ -73,6 +82,14 @@ func newSinkRegistry() *sinkRegistry {
 
 // RegisterSink registers the given factory for the specific scheme.
 func (sr *sinkRegistry) RegisterSink(scheme string, factory func(*url.URL) (Sink, error)) error {
+	return sr.RegisterSinkFactory(scheme, func(_ context.Context, u *url.URL, _ SinkOptions) (Sink, error) {
+		return factory(u)
+	})
+}
+
+// RegisterSinkFactory registers the given context-aware factory for the
+// specific scheme.
+func (sr *sinkRegistry) RegisterSinkFactory(scheme string, factory SinkFactory) error {
 	sr.mu.Lock()
 	defer sr.mu.Unlock()
 
@@ -91,6 +108,10 @@ func (sr *sinkRegistry) RegisterSink(scheme string, factory func(*url.URL) (Sink
 }
 
 func (sr *sinkRegistry) newSink(rawURL string) (Sink, error) {
+	return sr.newSinkContext(context.Background(), rawURL)
+}
+
+func (sr *sinkRegistry) newSinkContext(ctx context.Context, rawURL string) (Sink, error) {
 	// URL parsing doesn't work well for Windows paths such as `c:\log.txt`, as scheme is set to
 	// the drive, and path is unset unless `c:/log.txt` is used.
 	// To avoid Windows-specific URL handling, we instead check IsAbs to open as a file.
@@ -113,20 +134,40 @@ func (sr *sinkRegistry) newSink(rawURL string) (Sink, error) {
 	if !ok {
 		return nil, &errSinkNotFound{u.Scheme}
 	}
-	return factory(u)
+	return factory(ctx, u, SinkOptions{values: u.Query()})
 }
 
 // RegisterSink registers a user-supplied factory for all sinks with a
-// particular scheme.
+// particular scheme. Factories that dial remote services or need their URL's
+// query parameters parsed should use RegisterSinkFactory instead.
 //
 // All schemes must be ASCII, valid under section 0.1 of RFC 3986
 // (https://tools.ietf.org/html/rfc3983#section-3.1), and must not already
 // have a factory registered. Zap automatically registers a factory for the
-// "file" scheme.
+// "file" scheme. Sinks for Graylog and for journald and the Windows Event
+// Log are registered by the zapgelf package and the zapnative module.
 func RegisterSink(scheme string, factory func(*url.URL) (Sink, error)) error {
 	return _sinkRegistry.RegisterSink(scheme, factory)
 }
 
+// RegisterSinkFactory is like RegisterSink, but registers a factory that's
+// also passed the context given to OpenContext, for dial timeouts and
+// cancellation, and the URL's query parameters. Schemes share a single
+// namespace with RegisterSink.
+//
+//	zap.RegisterSinkFactory("tcp", func(ctx context.Context, u *url.URL, opts zap.SinkOptions) (zap.Sink, error) {
+//	  keepAlive, err := opts.Duration("keepAlive", 15*time.Second)
+//	  if err != nil {
+//	    return nil, err
+//	  }
+//	  d := net.Dialer{KeepAlive: keepAlive}
+//	  conn, err := d.DialContext(ctx, "tcp", u.Host)
+//	  ...
+//	})
+func RegisterSinkFactory(scheme string, factory SinkFactory) error {
+	return _sinkRegistry.RegisterSinkFactory(scheme, factory)
+}
+
 func (sr *sinkRegistry) newFileSinkFromURL(u *url.URL) (Sink, error) {
 	if u.User != nil {
 		return nil, fmt.Errorf("user and password not allowed with file URLs: got %v", u)
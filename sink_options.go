@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// SinkOptions holds the query parameters of a sink's URL, passed to factories
+// registered with RegisterSinkFactory. Its accessors return a default when a
+// parameter is absent and a descriptive error when it's malformed.
+type SinkOptions struct {
+	values url.Values
+}
+
+// Has reports whether the URL set the parameter key.
+func (o SinkOptions) Has(key string) bool {
+	_, ok := o.values[key]
+	return ok
+}
+
+// Keys returns the names of all parameters the URL set, sorted. Factories can
+// use it to reject parameters they don't understand.
+func (o SinkOptions) Keys() []string {
+	keys := make([]string, 0, len(o.values))
+	for k := range o.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// String returns the first value of the parameter key, or def if it's unset
+// or empty.
+func (o SinkOptions) String(key, def string) string {
+	if v := o.values.Get(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// Int parses the parameter key as a decimal integer, returning def if it's
+// unset or empty.
+func (o SinkOptions) Int(key string, def int) (int, error) {
+	v := o.values.Get(key)
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("sink option %q must be an integer: got %q", key, v)
+	}
+	return n, nil
+}
+
+// Bool parses the parameter key with strconv.ParseBool, returning def if it's
+// unset or empty.
+func (o SinkOptions) Bool(key string, def bool) (bool, error) {
+	v := o.values.Get(key)
+	if v == "" {
+		return def, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("sink option %q must be a boolean: got %q", key, v)
+	}
+	return b, nil
+}
+
+// Duration parses the parameter key with time.ParseDuration, returning def if
+// it's unset or empty.
+func (o SinkOptions) Duration(key string, def time.Duration) (time.Duration, error) {
+	v := o.values.Get(key)
+	if v == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("sink option %q must be a duration: got %q", key, v)
+	}
+	return d, nil
+}
@@ -22,10 +22,12 @@ package zap
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -83,6 +85,79 @@ func TestRegisterSink(t *testing.T) {
 	assert.Equal(t, "foo", buf.String(), "Unexpected buffer contents.")
 }
 
+func TestRegisterSinkFactory(t *testing.T) {
+	stubSinkRegistry(t)
+
+	type ctxKey struct{}
+	var (
+		gotCtx  context.Context
+		gotOpts SinkOptions
+	)
+	factory := func(ctx context.Context, u *url.URL, opts SinkOptions) (Sink, error) {
+		gotCtx, gotOpts = ctx, opts
+		return nopCloserSink{zapcore.AddSync(io.Discard)}, nil
+	}
+	require.NoError(t, RegisterSinkFactory("ctx", factory), "Failed to register factory.")
+	assert.ErrorContains(t, RegisterSink("CTX", func(*url.URL) (Sink, error) { return nil, nil }),
+		"already registered", "Expected schemes to be shared with RegisterSink.")
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "v")
+	_, closeSink, err := OpenContext(ctx, "ctx://host?retries=3&debug=true")
+	require.NoError(t, err, "Unexpected error opening sink.")
+	defer closeSink()
+
+	assert.Equal(t, "v", gotCtx.Value(ctxKey{}), "Expected the context passed to OpenContext.")
+	assert.Equal(t, []string{"debug", "retries"}, gotOpts.Keys(), "Unexpected options.")
+	retries, err := gotOpts.Int("retries", 0)
+	require.NoError(t, err, "Unexpected error parsing int option.")
+	assert.Equal(t, 3, retries, "Unexpected int option.")
+
+	_, closeSink, err = Open("ctx://host")
+	require.NoError(t, err, "Unexpected error opening sink without a context.")
+	defer closeSink()
+	assert.NotNil(t, gotCtx, "Expected Open to pass a background context.")
+}
+
+func TestSinkOptions(t *testing.T) {
+	opts := SinkOptions{values: url.Values{
+		"name":    {"app"},
+		"n":       {"12"},
+		"bad":     {"x"},
+		"on":      {"true"},
+		"timeout": {"2s"},
+		"empty":   {""},
+	}}
+
+	assert.True(t, opts.Has("empty"), "Expected empty parameters to be present.")
+	assert.False(t, opts.Has("missing"), "Unexpected parameter.")
+	assert.Equal(t, "app", opts.String("name", "def"), "Unexpected string.")
+	assert.Equal(t, "def", opts.String("empty", "def"), "Expected default for empty parameter.")
+
+	n, err := opts.Int("n", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 12, n, "Unexpected int.")
+	n, err = opts.Int("missing", 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, n, "Expected default int.")
+	_, err = opts.Int("bad", 0)
+	assert.ErrorContains(t, err, `sink option "bad" must be an integer: got "x"`)
+
+	on, err := opts.Bool("on", false)
+	assert.NoError(t, err)
+	assert.True(t, on, "Unexpected bool.")
+	_, err = opts.Bool("bad", false)
+	assert.ErrorContains(t, err, "must be a boolean")
+
+	d, err := opts.Duration("timeout", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Second, d, "Unexpected duration.")
+	d, err = opts.Duration("missing", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, d, "Expected default duration.")
+	_, err = opts.Duration("bad", 0)
+	assert.ErrorContains(t, err, "must be a duration")
+}
+
 func TestRegisterSinkErrors(t *testing.T) {
 	nopFactory := func(_ *url.URL) (Sink, error) {
 		return nopCloserSink{zapcore.AddSync(io.Discard)}, nil
@@ -105,3 +180,15 @@ func TestRegisterSinkErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterSinkOptInSchemesFree(t *testing.T) {
+	nopFactory := func(_ *url.URL) (Sink, error) {
+		return nopCloserSink{zapcore.AddSync(io.Discard)}, nil
+	}
+	// These sinks are opt-in through zapgelf and zapnative, so programs that
+	// register their own factories for their schemes keep working.
+	for _, scheme := range []string{"gelf", "journald", "eventlog"} {
+		r := newSinkRegistry()
+		assert.NoError(t, r.RegisterSink(scheme, nopFactory), "Expected scheme %q to be free.", scheme)
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Stats is a snapshot of a Logger's self-monitoring counters. See
+// Logger.Stats.
+type Stats struct {
+	// EntriesWritten counts the entries the Logger handed to its cores,
+	// whether or not they were written successfully.
+	EntriesWritten uint64
+	// WriteErrors counts the entries for which at least one core returned an
+	// error.
+	WriteErrors uint64
+	// BytesWritten counts the bytes written to the Logger's outputs. It's only
+	// maintained for Loggers built from a Config with CollectStats set.
+	BytesWritten uint64
+	// SamplerDropped counts the entries dropped by sampling. It's only
+	// maintained for Loggers built from a Config with CollectStats set and
+	// Sampling enabled.
+	SamplerDropped uint64
+}
+
+// statsCounter holds the counters behind Stats. It's shared by a Logger and
+// all of its children.
+type statsCounter struct {
+	entries    atomic.Uint64
+	errors     atomic.Uint64
+	bytes      atomic.Uint64
+	sampledOut atomic.Uint64
+}
+
+func (s *statsCounter) snapshot() Stats {
+	if s == nil {
+		return Stats{}
+	}
+	return Stats{
+		EntriesWritten: s.entries.Load(),
+		WriteErrors:    s.errors.Load(),
+		BytesWritten:   s.bytes.Load(),
+		SamplerDropped: s.sampledOut.Load(),
+	}
+}
+
+// countBytes wraps ws so that the bytes written through it are counted. It
+// returns ws unchanged if s is nil.
+func (s *statsCounter) countBytes(ws zapcore.WriteSyncer) zapcore.WriteSyncer {
+	if s == nil {
+		return ws
+	}
+	return zapcore.NewCountingWriteSyncer(ws, &s.bytes)
+}
+
+// samplerHook returns a sampling hook that counts dropped entries before
+// calling next, if any.
+func (s *statsCounter) samplerHook(next func(zapcore.Entry, zapcore.SamplingDecision)) func(zapcore.Entry, zapcore.SamplingDecision) {
+	if s == nil {
+		return next
+	}
+	return func(ent zapcore.Entry, dec zapcore.SamplingDecision) {
+		if dec&zapcore.LogDropped > 0 {
+			s.sampledOut.Add(1)
+		}
+		if next != nil {
+			next(ent, dec)
+		}
+	}
+}
+
+// updateAfterWrite rebuilds the callback the Logger attaches to its
+// CheckedEntries from its current stats counter, error hook, and
+// TraceEntries hook.
+func (log *Logger) updateAfterWrite() {
+	stats, hook := log.stats, log.errorHook
+	switch {
+	case stats == nil && hook == nil:
+		log.afterWrite = nil
+	case stats == nil:
+		log.afterWrite = func(ent zapcore.Entry, err error) {
+			if err != nil {
+				hook(err, ent)
+			}
+		}
+	default:
+		log.afterWrite = func(ent zapcore.Entry, err error) {
+			stats.entries.Add(1)
+			if err == nil {
+				return
+			}
+			stats.errors.Add(1)
+			if hook != nil {
+				hook(err, ent)
+			}
+		}
+	}
+	log.addTraceHook()
+}
+
+// addTraceHook makes afterWrite pass the trace of each written entry to the
+// TraceEntries hook, after doing whatever else it did.
+func (log *Logger) addTraceHook() {
+	trace := log.traceHook
+	if trace == nil {
+		return
+	}
+	next := log.afterWrite
+	log.afterWrite = func(ent zapcore.Entry, err error) {
+		if next != nil {
+			next(ent, err)
+		}
+		trace(ent, ent.Trace.Steps())
+	}
+}
+
+// Stats returns a snapshot of the Logger's self-monitoring counters, which
+// let services alert on logging failures. Counters are shared by a Logger
+// and all of its children.
+//
+// Loggers built from a Config collect stats if its CollectStats field is
+// set; other Loggers only do so if they were constructed with the
+// CollectStats option. Otherwise, Stats returns the zero value.
+func (log *Logger) Stats() Stats {
+	return log.stats.snapshot()
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/internal/ztest"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLoggerStats(t *testing.T) {
+	ws := &ztest.Buffer{}
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"})
+	core := zapcore.NewTee(
+		zapcore.NewCore(enc, ws, DebugLevel),
+		zapcore.NewCore(enc, &ztest.FailWriter{}, WarnLevel),
+	)
+
+	type hookCall struct {
+		err error
+		msg string
+	}
+	var calls []hookCall
+	errOut := &ztest.Buffer{}
+	logger := New(core,
+		ErrorOutput(errOut),
+		CollectStats(),
+		ErrorHook(func(err error, ent zapcore.Entry) {
+			calls = append(calls, hookCall{err, ent.Message})
+		}),
+	)
+
+	logger.Info("ok")
+	logger.Named("child").Warn("broken")
+	logger.Debug("ok again")
+
+	assert.Equal(t, Stats{EntriesWritten: 3, WriteErrors: 1}, logger.Stats(), "Unexpected stats.")
+	require.Len(t, calls, 1, "Expected the error hook to be called once.")
+	assert.Error(t, calls[0].err, "Expected the hook to receive the write error.")
+	assert.Equal(t, "broken", calls[0].msg, "Unexpected entry passed to the hook.")
+	assert.Contains(t, errOut.String(), "write error", "Expected errors to still reach the error output.")
+}
+
+func TestLoggerStatsDisabled(t *testing.T) {
+	var hooked []error
+	logger := New(
+		zapcore.NewCore(zapcore.NewJSONEncoder(zapcore.EncoderConfig{}), &ztest.FailWriter{}, DebugLevel),
+		ErrorOutput(zapcore.AddSync(&ztest.Discarder{})),
+		ErrorHook(func(err error, _ zapcore.Entry) { hooked = append(hooked, err) }),
+	)
+	logger.Info("broken")
+
+	assert.Equal(t, Stats{}, logger.Stats(), "Expected no stats without CollectStats.")
+	assert.Len(t, hooked, 1, "Expected the error hook to run without CollectStats.")
+	assert.Equal(t, Stats{}, NewNop().Stats(), "Expected no stats from a no-op Logger.")
+}
+
+func TestConfigStats(t *testing.T) {
+	logOut := filepath.Join(t.TempDir(), "test.log")
+	cfg := NewProductionConfig()
+	cfg.OutputPaths = []string{logOut}
+	cfg.Sampling = &SamplingConfig{Initial: 2, Thereafter: 1000}
+	cfg.CollectStats = true
+
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error constructing logger.")
+	for i := 0; i < 5; i++ {
+		logger.Info("sampled")
+	}
+	logger.Error("failed", Error(errors.New("boom")))
+
+	contents, err := os.ReadFile(logOut)
+	require.NoError(t, err, "Couldn't read log contents from temp file.")
+	assert.Equal(t, Stats{
+		EntriesWritten: 3,
+		BytesWritten:   uint64(len(contents)),
+		SamplerDropped: 3,
+	}, logger.Stats(), "Unexpected stats.")
+}
+
+func TestConfigStatsDisabled(t *testing.T) {
+	cfg := NewProductionConfig()
+	cfg.OutputPaths = []string{filepath.Join(t.TempDir(), "test.log")}
+
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error constructing logger.")
+	logger.Info("not counted")
+	assert.Equal(t, Stats{}, logger.Stats(), "Expected no stats without Config.CollectStats.")
+}
+
+// bufferSink is a Sink that takes ownership of the buffers it's handed.
+type bufferSink struct {
+	ztest.Buffer
+
+	buffers int
+}
+
+func (s *bufferSink) WriteBuffer(buf *buffer.Buffer) error {
+	s.buffers++
+	_, err := s.Buffer.Write(buf.Bytes())
+	buf.Free()
+	return err
+}
+
+func (s *bufferSink) Close() error { return nil }
+
+func TestConfigStatsBufferWriter(t *testing.T) {
+	stubSinkRegistry(t)
+
+	sink := &bufferSink{}
+	require.NoError(t, RegisterSink("buf", func(*url.URL) (Sink, error) {
+		return sink, nil
+	}), "Failed to register sink factory.")
+
+	cfg := NewProductionConfig()
+	cfg.OutputPaths = []string{"buf://"}
+	cfg.CollectStats = true
+
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error constructing logger.")
+	logger.Info("counted")
+
+	assert.Equal(t, 1, sink.buffers, "Expected the entry to be handed to WriteBuffer.")
+	assert.Equal(t, uint64(sink.Len()), logger.Stats().BytesWritten, "Unexpected byte count.")
+}
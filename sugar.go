@@ -22,6 +22,8 @@ package zap
 
 import (
 	"fmt"
+	"sort"
+	"sync/atomic"
 
 	"go.uber.org/zap/zapcore"
 
@@ -32,6 +34,7 @@ const (
 	_oddNumberErrMsg    = "Ignored key without a value."
 	_nonStringKeyErrMsg = "Ignored key-value pairs with non-string keys."
 	_multipleErrMsg     = "Multiple errors without a key."
+	_fieldValueErrMsg   = "Found zap.Field in a loosely-typed key-value pair."
 )
 
 // A SugaredLogger wraps the base Logger functionality in a slower, but less
@@ -39,12 +42,13 @@ const (
 // method.
 //
 // Unlike the Logger, the SugaredLogger doesn't insist on structured logging.
-// For each log level, it exposes four methods:
+// For each log level, it exposes five methods:
 //
 //   - methods named after the log level for log.Print-style logging
 //   - methods ending in "w" for loosely-typed structured logging
 //   - methods ending in "f" for log.Printf-style logging
 //   - methods ending in "ln" for log.Println-style logging
+//   - methods ending in "Lazy" for structured logging with deferred arguments
 //
 // For example, the methods for InfoLevel are:
 //
@@ -52,6 +56,11 @@ const (
 //	Infow(...any)          Structured logging (read as "info with")
 //	Infof(string, ...any)  Printf-style logging
 //	Infoln(...any)         Println-style logging
+//	InfoLazy(LazyArgs)     Structured logging, building arguments only if enabled
+//
+// All of these skip formatting the message when their level is disabled,
+// but Go evaluates their arguments before the call regardless. Use the Lazy
+// methods when building the arguments is itself expensive.
 type SugaredLogger struct {
 	base *Logger
 }
@@ -82,9 +91,11 @@ func (s *SugaredLogger) WithOptions(opts ...Option) *SugaredLogger {
 }
 
 // With adds a variadic number of fields to the logging context. It accepts a
-// mix of strongly-typed Field objects and loosely-typed key-value pairs. When
-// processing pairs, the first element of the pair is used as the field key
-// and the second as the field value.
+// mix of strongly-typed Field objects and loosely-typed key-value pairs, in
+// any order. When processing pairs, the first element of the pair is used as
+// the field key and the second as the field value. Slices of Fields and maps
+// from strings to values may also be interleaved; see ParseSugarArgs for the
+// precise rules.
 //
 // For example,
 //
@@ -115,6 +126,14 @@ func (s *SugaredLogger) With(args ...interface{}) *SugaredLogger {
 	return &SugaredLogger{base: s.base.With(s.sweetenFields(args)...)}
 }
 
+// Withf adds a field to the logging context whose value is formatted with
+// fmt.Sprintf, as in
+//
+//	sugaredLogger.Withf("request", "%s %s", req.Method, req.URL.Path)
+func (s *SugaredLogger) Withf(key, template string, args ...interface{}) *SugaredLogger {
+	return &SugaredLogger{base: s.base.With(String(key, fmt.Sprintf(template, args...)))}
+}
+
 // WithLazy adds a variadic number of fields to the logging context lazily.
 // The fields are evaluated only if the logger is further chained with [With]
 // or is written to with any of the log level methods.
@@ -143,6 +162,12 @@ func (s *SugaredLogger) Log(lvl zapcore.Level, args ...interface{}) {
 	s.log(lvl, "", args, nil)
 }
 
+// Trace logs the provided arguments at [TraceLevel].
+// Spaces are added between arguments when neither is a string.
+func (s *SugaredLogger) Trace(args ...interface{}) {
+	s.log(TraceLevel, "", args, nil)
+}
+
 // Debug logs the provided arguments at [DebugLevel].
 // Spaces are added between arguments when neither is a string.
 func (s *SugaredLogger) Debug(args ...interface{}) {
@@ -192,6 +217,12 @@ func (s *SugaredLogger) Logf(lvl zapcore.Level, template string, args ...interfa
 	s.log(lvl, template, args, nil)
 }
 
+// Tracef formats the message according to the format specifier
+// and logs it at [TraceLevel].
+func (s *SugaredLogger) Tracef(template string, args ...interface{}) {
+	s.log(TraceLevel, template, args, nil)
+}
+
 // Debugf formats the message according to the format specifier
 // and logs it at [DebugLevel].
 func (s *SugaredLogger) Debugf(template string, args ...interface{}) {
@@ -241,6 +272,16 @@ func (s *SugaredLogger) Logw(lvl zapcore.Level, msg string, keysAndValues ...int
 	s.log(lvl, msg, nil, keysAndValues)
 }
 
+// Tracew logs a message with some additional context. The variadic key-value
+// pairs are treated as they are in With.
+//
+// When trace-level logging is disabled, this is much faster than
+//
+//	s.With(keysAndValues).Trace(msg)
+func (s *SugaredLogger) Tracew(msg string, keysAndValues ...interface{}) {
+	s.log(TraceLevel, msg, nil, keysAndValues)
+}
+
 // Debugw logs a message with some additional context. The variadic key-value
 // pairs are treated as they are in With.
 //
@@ -294,6 +335,12 @@ func (s *SugaredLogger) Logln(lvl zapcore.Level, args ...interface{}) {
 	s.logln(lvl, args, nil)
 }
 
+// Traceln logs a message at [TraceLevel].
+// Spaces are always added between arguments.
+func (s *SugaredLogger) Traceln(args ...interface{}) {
+	s.logln(TraceLevel, args, nil)
+}
+
 // Debugln logs a message at [DebugLevel].
 // Spaces are always added between arguments.
 func (s *SugaredLogger) Debugln(args ...interface{}) {
@@ -337,6 +384,69 @@ func (s *SugaredLogger) Fatalln(args ...interface{}) {
 	s.logln(FatalLevel, args, nil)
 }
 
+// LazyArgs builds the message and loosely-typed key-value pairs of an entry
+// logged by one of the SugaredLogger's Lazy methods. The pairs are treated as
+// they are in With.
+type LazyArgs func() (msg string, keysAndValues []interface{})
+
+// LogLazy logs a message with some additional context at the provided level.
+// The message and context are built by calling args, which is skipped
+// entirely if the level is disabled:
+//
+//	s.LogLazy(zap.DebugLevel, func() (string, []any) {
+//		return "cache state", []any{"entries", cache.Dump()}
+//	})
+func (s *SugaredLogger) LogLazy(lvl zapcore.Level, args LazyArgs) {
+	s.logLazy(lvl, args)
+}
+
+// TraceLazy logs a message built by args at [TraceLevel]. args isn't called
+// if trace logging is disabled. See LogLazy for details.
+func (s *SugaredLogger) TraceLazy(args LazyArgs) {
+	s.logLazy(TraceLevel, args)
+}
+
+// DebugLazy logs a message built by args at [DebugLevel]. args isn't called
+// if debug logging is disabled. See LogLazy for details.
+func (s *SugaredLogger) DebugLazy(args LazyArgs) {
+	s.logLazy(DebugLevel, args)
+}
+
+// InfoLazy logs a message built by args at [InfoLevel]. args isn't called
+// if info logging is disabled. See LogLazy for details.
+func (s *SugaredLogger) InfoLazy(args LazyArgs) {
+	s.logLazy(InfoLevel, args)
+}
+
+// WarnLazy logs a message built by args at [WarnLevel]. args isn't called
+// if warn logging is disabled. See LogLazy for details.
+func (s *SugaredLogger) WarnLazy(args LazyArgs) {
+	s.logLazy(WarnLevel, args)
+}
+
+// ErrorLazy logs a message built by args at [ErrorLevel]. args isn't called
+// if error logging is disabled. See LogLazy for details.
+func (s *SugaredLogger) ErrorLazy(args LazyArgs) {
+	s.logLazy(ErrorLevel, args)
+}
+
+// DPanicLazy logs a message built by args at [DPanicLevel]. In development,
+// the logger then panics. (See DPanicLevel for details.)
+func (s *SugaredLogger) DPanicLazy(args LazyArgs) {
+	s.logLazy(DPanicLevel, args)
+}
+
+// PanicLazy logs a message built by args at [PanicLevel], then panics.
+func (s *SugaredLogger) PanicLazy(args LazyArgs) {
+	s.logLazy(PanicLevel, args)
+}
+
+// FatalLazy logs a message built by args at [FatalLevel], then calls
+// os.Exit.
+func (s *SugaredLogger) FatalLazy(args LazyArgs) {
+	s.logLazy(FatalLevel, args)
+}
+
 // Sync flushes any buffered log entries.
 func (s *SugaredLogger) Sync() error {
 	return s.base.Sync()
@@ -356,6 +466,19 @@ func (s *SugaredLogger) log(lvl zapcore.Level, template string, fmtArgs []interf
 	}
 }
 
+// logLazy builds the message and context only once the entry is known to be
+// enabled.
+func (s *SugaredLogger) logLazy(lvl zapcore.Level, args LazyArgs) {
+	if lvl < DPanicLevel && !s.base.Core().Enabled(lvl) {
+		return
+	}
+
+	msg, context := args()
+	if ce := s.base.Check(lvl, msg); ce != nil {
+		ce.Write(s.sweetenFields(context)...)
+	}
+}
+
 // logln message with Sprintln
 func (s *SugaredLogger) logln(lvl zapcore.Level, fmtArgs []interface{}, context []interface{}) {
 	if lvl < DPanicLevel && !s.base.Core().Enabled(lvl) {
@@ -397,29 +520,102 @@ func (s *SugaredLogger) sweetenFields(args []interface{}) []Field {
 		return nil
 	}
 
+	fields, problems := parseSugarArgs(args)
+	var invalid invalidPairs
+	for _, p := range problems {
+		if v := s.base.sugarValidator; v != nil {
+			v.report(s.base, p)
+			continue
+		}
+		switch p.reason {
+		case sugarExtraError:
+			s.base.Error(_multipleErrMsg, Error(p.Key.(error)))
+		case sugarDanglingKey:
+			s.base.Error(_oddNumberErrMsg, Any("ignored", p.Key))
+		case sugarNonStringKey:
+			// Subsequent errors are likely, so allocate once up front.
+			if cap(invalid) == 0 {
+				invalid = make(invalidPairs, 0, len(args)/2)
+			}
+			invalid = append(invalid, invalidPair{p.Position, p.Key, p.Value})
+		}
+	}
+
+	// If we encountered any invalid key-value pairs, log an error.
+	if len(invalid) > 0 {
+		s.base.Error(_nonStringKeyErrMsg, Array("invalid", invalid))
+	}
+	return fields
+}
+
+// ParseSugarArgs converts the loosely-typed arguments accepted by
+// SugaredLogger.With and the SugaredLogger's *w methods into Fields, so that
+// other logging front-ends can accept the same arguments.
+//
+// The arguments are read from left to right. An argument where a key is
+// expected is interpreted as the first of the following that applies:
+//
+//   - a Field is used as is;
+//   - a []Field is expanded in place;
+//   - the first error becomes an Error field;
+//   - a map[string]interface{} or map[string]string is expanded into one
+//     field per entry, in key order;
+//   - anything else is a key, and the argument after it is its value.
+//
+// Keys should be strings, and values shouldn't be Fields. The returned
+// error combines a *SugarArgError for each malformed pair, for each key
+// without a value, and for each error after the first; pairs with
+// non-string keys, dangling keys, and extra errors are left out of the
+// fields.
+func ParseSugarArgs(args ...interface{}) ([]Field, error) {
+	fields, problems := parseSugarArgs(args)
+	var err error
+	for _, p := range problems {
+		err = multierr.Append(err, p)
+	}
+	return fields, err
+}
+
+// parseSugarArgs implements ParseSugarArgs, returning the problems found in
+// the order they were found.
+func parseSugarArgs(args []interface{}) ([]Field, []*SugarArgError) {
 	var (
 		// Allocate enough space for the worst case; if users pass only structured
 		// fields, we shouldn't penalize them with extra allocations.
 		fields    = make([]Field, 0, len(args))
-		invalid   invalidPairs
+		problems  []*SugarArgError
 		seenError bool
 	)
 
 	for i := 0; i < len(args); {
-		// This is a strongly-typed field. Consume it and move on.
-		if f, ok := args[i].(Field); ok {
-			fields = append(fields, f)
+		switch arg := args[i].(type) {
+		case Field:
+			// This is a strongly-typed field. Consume it and move on.
+			fields = append(fields, arg)
 			i++
 			continue
-		}
-
-		// If it is an error, consume it and move on.
-		if err, ok := args[i].(error); ok {
+		case []Field:
+			fields = append(fields, arg...)
+			i++
+			continue
+		case error:
 			if !seenError {
 				seenError = true
-				fields = append(fields, Error(err))
+				fields = append(fields, Error(arg))
 			} else {
-				s.base.Error(_multipleErrMsg, Error(err))
+				problems = append(problems, &SugarArgError{Position: i, Key: arg, reason: sugarExtraError})
+			}
+			i++
+			continue
+		case map[string]interface{}:
+			for _, k := range sortedKeys(arg) {
+				fields = append(fields, Any(k, arg[k]))
+			}
+			i++
+			continue
+		case map[string]string:
+			for _, k := range sortedKeys(arg) {
+				fields = append(fields, String(k, arg[k]))
 			}
 			i++
 			continue
@@ -427,30 +623,146 @@ func (s *SugaredLogger) sweetenFields(args []interface{}) []Field {
 
 		// Make sure this element isn't a dangling key.
 		if i == len(args)-1 {
-			s.base.Error(_oddNumberErrMsg, Any("ignored", args[i]))
+			problems = append(problems, &SugarArgError{Position: i, Key: args[i], reason: sugarDanglingKey})
 			break
 		}
 
 		// Consume this value and the next, treating them as a key-value pair. If the
-		// key isn't a string, add this pair to the slice of invalid pairs.
+		// key isn't a string, leave the pair out.
 		key, val := args[i], args[i+1]
-		if keyStr, ok := key.(string); !ok {
-			// Subsequent errors are likely, so allocate once up front.
-			if cap(invalid) == 0 {
-				invalid = make(invalidPairs, 0, len(args)/2)
-			}
-			invalid = append(invalid, invalidPair{i, key, val})
-		} else {
+		reason, ok := checkSugarPair(key, val)
+		if !ok {
+			problems = append(problems, &SugarArgError{Position: i, Key: key, Value: val, reason: reason})
+		}
+		if keyStr, isString := key.(string); isString {
 			fields = append(fields, Any(keyStr, val))
 		}
 		i += 2
 	}
+	return fields, problems
+}
 
-	// If we encountered any invalid key-value pairs, log an error.
-	if len(invalid) > 0 {
-		s.base.Error(_nonStringKeyErrMsg, Array("invalid", invalid))
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
 	}
-	return fields
+	sort.Strings(keys)
+	return keys
+}
+
+// checkSugarPair reports whether a loosely-typed key-value pair is well
+// formed, and why not if it isn't.
+func checkSugarPair(key, val interface{}) (sugarArgReason, bool) {
+	if _, ok := key.(string); !ok {
+		return sugarNonStringKey, false
+	}
+	switch val.(type) {
+	case Field, []Field:
+		return sugarMisusedField, false
+	}
+	return 0, true
+}
+
+// sugarValidator validates the loosely-typed arguments of SugaredLoggers in
+// strict mode. It's shared by every Logger derived from the one StrictSugar
+// was applied to.
+type sugarValidator struct {
+	onInvalid func(error)
+
+	danglingKeys  atomic.Uint64
+	nonStringKeys atomic.Uint64
+	misusedFields atomic.Uint64
+	extraErrors   atomic.Uint64
+}
+
+func (v *sugarValidator) report(log *Logger, err *SugarArgError) {
+	var msg string
+	switch err.reason {
+	case sugarDanglingKey:
+		v.danglingKeys.Add(1)
+		msg = _oddNumberErrMsg
+	case sugarNonStringKey:
+		v.nonStringKeys.Add(1)
+		msg = _nonStringKeyErrMsg
+	case sugarMisusedField:
+		v.misusedFields.Add(1)
+		msg = _fieldValueErrMsg
+	case sugarExtraError:
+		v.extraErrors.Add(1)
+		msg = _multipleErrMsg
+	}
+
+	if v.onInvalid != nil {
+		v.onInvalid(err)
+		return
+	}
+	log.DPanic(msg, Int("position", err.Position), Any("key", err.Key), Any("value", err.Value))
+}
+
+func (v *sugarValidator) stats() SugarValidationStats {
+	return SugarValidationStats{
+		DanglingKeys:  v.danglingKeys.Load(),
+		NonStringKeys: v.nonStringKeys.Load(),
+		MisusedFields: v.misusedFields.Load(),
+		ExtraErrors:   v.extraErrors.Load(),
+	}
+}
+
+type sugarArgReason int
+
+const (
+	sugarDanglingKey sugarArgReason = iota + 1
+	sugarNonStringKey
+	sugarMisusedField
+	sugarExtraError
+)
+
+func (r sugarArgReason) String() string {
+	switch r {
+	case sugarDanglingKey:
+		return "key without a value"
+	case sugarNonStringKey:
+		return "non-string key"
+	case sugarMisusedField:
+		return "zap.Field used as a value"
+	case sugarExtraError:
+		return "error without a key after the first"
+	}
+	return "unknown problem"
+}
+
+// A SugarArgError describes a malformed loosely-typed key-value pair passed
+// to a SugaredLogger in strict mode or to ParseSugarArgs. See StrictSugar.
+type SugarArgError struct {
+	Position int         // index of the key among the arguments
+	Key      interface{} // the offending key
+	Value    interface{} // the key's value, or nil for a dangling key
+
+	reason sugarArgReason
+}
+
+func (e *SugarArgError) Error() string {
+	return fmt.Sprintf("invalid key-value pair at position %d: %v", e.Position, e.reason)
+}
+
+// SugarValidationStats counts the malformed arguments found by a
+// SugaredLogger in strict mode.
+type SugarValidationStats struct {
+	DanglingKeys  uint64 // keys at the end of the arguments without a value
+	NonStringKeys uint64 // pairs whose key isn't a string
+	MisusedFields uint64 // zap.Fields or []zap.Field used as values
+	ExtraErrors   uint64 // errors without a key after the first
+}
+
+// ValidationStats reports the number of malformed arguments found so far by
+// this SugaredLogger and every other logger sharing its strict mode. It
+// returns zero counts if strict mode isn't enabled; see StrictSugar.
+func (s *SugaredLogger) ValidationStats() SugarValidationStats {
+	if s.base.sugarValidator == nil {
+		return SugarValidationStats{}
+	}
+	return s.base.sugarValidator.stats()
 }
 
 type invalidPair struct {
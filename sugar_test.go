@@ -33,6 +33,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
 )
 
 func TestSugarWith(t *testing.T) {
@@ -134,6 +135,18 @@ func TestSugarWith(t *testing.T) {
 				nonString(invalidPair{2, true, "bar"}, invalidPair{5, 42, "reversed"}),
 			},
 		},
+		{
+			desc:     "interleaved fields, slices of fields, and maps",
+			args:     []interface{}{"foo", 42, []Field{Int("a", 1), Int("b", 2)}, map[string]string{"d": "x", "c": "y"}, Int("e", 3), "bar", map[string]int{"f": 4}},
+			expected: []Field{Int("foo", 42), Int("a", 1), Int("b", 2), String("c", "y"), String("d", "x"), Int("e", 3), Any("bar", map[string]int{"f": 4})},
+			errLogs:  nil,
+		},
+		{
+			desc:     "map of values",
+			args:     []interface{}{map[string]interface{}{"b": true, "a": 1}, "c", "d"},
+			expected: []Field{Int("a", 1), Bool("b", true), String("c", "d")},
+			errLogs:  nil,
+		},
 		{
 			desc:     "multiple errors",
 			args:     []interface{}{errors.New("first"), errors.New("second"), errors.New("third")},
@@ -162,6 +175,32 @@ func TestSugarWith(t *testing.T) {
 	}
 }
 
+func TestParseSugarArgs(t *testing.T) {
+	fields, err := ParseSugarArgs("foo", 42, Int("bar", 1), errors.New("first"), 13, "thirteen", errors.New("second"), "dangling")
+	assert.Equal(t, []Field{Int("foo", 42), Int("bar", 1), Error(errors.New("first"))}, fields, "Unexpected fields.")
+
+	errs := multierr.Errors(err)
+	require.Len(t, errs, 3, "Expected an error for each problem.")
+	for _, err := range errs {
+		var argErr *SugarArgError
+		assert.True(t, errors.As(err, &argErr), "Expected a *SugarArgError, got %T.", err)
+	}
+	assert.EqualError(t, err, "invalid key-value pair at position 4: non-string key; "+
+		"invalid key-value pair at position 6: error without a key after the first; "+
+		"invalid key-value pair at position 7: key without a value", "Unexpected error.")
+
+	fields, err = ParseSugarArgs()
+	assert.NoError(t, err, "Unexpected error parsing no arguments.")
+	assert.Empty(t, fields, "Unexpected fields from no arguments.")
+}
+
+func TestSugarWithf(t *testing.T) {
+	withSugar(t, DebugLevel, nil, func(logger *SugaredLogger, logs *observer.ObservedLogs) {
+		logger.Withf("request", "%s %s", "GET", "/").Info("")
+		assert.Equal(t, []Field{String("request", "GET /")}, logs.AllUntimed()[0].Context, "Unexpected context.")
+	})
+}
+
 func TestSugarWithCaptures(t *testing.T) {
 	type withAny func(*SugaredLogger, ...interface{}) *SugaredLogger
 
@@ -439,6 +478,70 @@ func TestSugarLnLoggingIgnored(t *testing.T) {
 	})
 }
 
+func TestSugarTraceMethods(t *testing.T) {
+	withSugar(t, DebugLevel, nil, func(logger *SugaredLogger, logs *observer.ObservedLogs) {
+		logger.Trace("dropped")
+		assert.Zero(t, logs.Len(), "Expected trace logs to be disabled at debug level.")
+	})
+
+	withSugar(t, TraceLevel, nil, func(logger *SugaredLogger, logs *observer.ObservedLogs) {
+		logger.Trace("foo", 42)
+		logger.Tracef("foo %d", 42)
+		logger.Tracew("foo", "bar", 42)
+		logger.Traceln("foo", 42)
+		logger.TraceLazy(func() (string, []interface{}) {
+			return "foo", []interface{}{"bar", 42}
+		})
+
+		var got []string
+		for _, ent := range logs.AllUntimed() {
+			assert.Equal(t, TraceLevel, ent.Level, "Unexpected level.")
+			got = append(got, ent.Message)
+		}
+		assert.Equal(t, []string{"foo42", "foo 42", "foo", "foo 42", "foo"}, got, "Unexpected messages.")
+	})
+}
+
+func TestSugarLazyLogging(t *testing.T) {
+	err := errors.New("qux")
+	var calls int
+	args := func() (string, []interface{}) {
+		calls++
+		return "foo", []interface{}{"bar", 42, err}
+	}
+
+	withSugar(t, InfoLevel, nil, func(logger *SugaredLogger, logs *observer.ObservedLogs) {
+		logger.DebugLazy(args)
+		logger.LogLazy(DebugLevel, args)
+		assert.Zero(t, calls, "Expected disabled levels to skip building arguments.")
+
+		logger.InfoLazy(args)
+		logger.WarnLazy(args)
+		logger.ErrorLazy(args)
+		logger.DPanicLazy(args)
+		logger.LogLazy(WarnLevel, args)
+		assert.Equal(t, 5, calls, "Expected enabled levels to build arguments once each.")
+
+		var expected []observer.LoggedEntry
+		for _, lvl := range []zapcore.Level{InfoLevel, WarnLevel, ErrorLevel, DPanicLevel, WarnLevel} {
+			expected = append(expected, observer.LoggedEntry{
+				Entry:   zapcore.Entry{Message: "foo", Level: lvl},
+				Context: []Field{Int("bar", 42), Error(err)},
+			})
+		}
+		assert.Equal(t, expected, logs.AllUntimed(), "Unexpected log output.")
+	})
+}
+
+func TestSugarLazyAddCaller(t *testing.T) {
+	withSugar(t, DebugLevel, opts(AddCaller()), func(logger *SugaredLogger, logs *observer.ObservedLogs) {
+		logger.InfoLazy(func() (string, []interface{}) { return "", nil })
+		output := logs.AllUntimed()
+		require.Equal(t, 1, len(output), "Unexpected number of logs written out.")
+		assert.Regexp(t, `.+/sugar_test.go:[\d]+$`, output[0].Caller.String())
+	})
+}
+
 func TestSugarPanicLogging(t *testing.T) {
 	tests := []struct {
 		loggerLevel zapcore.Level
@@ -457,6 +560,8 @@ func TestSugarPanicLogging(t *testing.T) {
 		{FatalLevel, func(s *SugaredLogger) { s.Panicln("foo") }, ""},
 		{PanicLevel, func(s *SugaredLogger) { s.Panicln("foo") }, "foo"},
 		{DebugLevel, func(s *SugaredLogger) { s.Panicln("foo") }, "foo"},
+		{FatalLevel, func(s *SugaredLogger) { s.PanicLazy(func() (string, []interface{}) { return "foo", nil }) }, ""},
+		{PanicLevel, func(s *SugaredLogger) { s.PanicLazy(func() (string, []interface{}) { return "foo", nil }) }, "foo"},
 	}
 
 	for _, tt := range tests {
@@ -492,6 +597,8 @@ func TestSugarFatalLogging(t *testing.T) {
 		{FatalLevel + 1, func(s *SugaredLogger) { s.Fatalln("foo") }, ""},
 		{FatalLevel, func(s *SugaredLogger) { s.Fatalln("foo") }, "foo"},
 		{DebugLevel, func(s *SugaredLogger) { s.Fatalln("foo") }, "foo"},
+		{FatalLevel + 1, func(s *SugaredLogger) { s.FatalLazy(func() (string, []interface{}) { return "foo", nil }) }, ""},
+		{FatalLevel, func(s *SugaredLogger) { s.FatalLazy(func() (string, []interface{}) { return "foo", nil }) }, "foo"},
 	}
 
 	for _, tt := range tests {
@@ -600,3 +707,123 @@ func BenchmarkLnSugarSingleStrArg(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkSugarDisabledLevel(b *testing.B) {
+	payload := make([]int, 64)
+	b.Run("Debugw", func(b *testing.B) {
+		withSugar(b, InfoLevel, nil, func(log *SugaredLogger, logs *observer.ObservedLogs) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				log.Debugw("hello world", "payload", fmt.Sprint(payload))
+			}
+		})
+	})
+	b.Run("DebugLazy", func(b *testing.B) {
+		withSugar(b, InfoLevel, nil, func(log *SugaredLogger, logs *observer.ObservedLogs) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				log.DebugLazy(func() (string, []interface{}) {
+					return "hello world", []interface{}{"payload", fmt.Sprint(payload)}
+				})
+			}
+		})
+	})
+}
+
+func TestSugarStrictHook(t *testing.T) {
+	tests := []struct {
+		desc      string
+		args      []interface{}
+		wantErrs  []string
+		wantStats SugarValidationStats
+		expected  []Field
+	}{
+		{
+			desc:     "well-formed",
+			args:     []interface{}{"foo", 42, Int("bar", 1), errors.New("oops")},
+			expected: []Field{Int("foo", 42), Int("bar", 1), Error(errors.New("oops"))},
+		},
+		{
+			desc:      "dangling key",
+			args:      []interface{}{"foo", 42, "dangling"},
+			wantErrs:  []string{"invalid key-value pair at position 2: key without a value"},
+			wantStats: SugarValidationStats{DanglingKeys: 1},
+			expected:  []Field{Int("foo", 42)},
+		},
+		{
+			desc: "non-string keys",
+			args: []interface{}{1, "one", "foo", 42, 2, "two"},
+			wantErrs: []string{
+				"invalid key-value pair at position 0: non-string key",
+				"invalid key-value pair at position 4: non-string key",
+			},
+			wantStats: SugarValidationStats{NonStringKeys: 2},
+			expected:  []Field{Int("foo", 42)},
+		},
+		{
+			desc: "misused fields",
+			args: []interface{}{"foo", String("bar", "baz"), "quux", []Field{Int("n", 1)}},
+			wantErrs: []string{
+				"invalid key-value pair at position 0: zap.Field used as a value",
+				"invalid key-value pair at position 2: zap.Field used as a value",
+			},
+			wantStats: SugarValidationStats{MisusedFields: 2},
+			expected:  []Field{Any("foo", String("bar", "baz")), Any("quux", []Field{Int("n", 1)})},
+		}, {
+			desc:      "extra errors",
+			args:      []interface{}{errors.New("first"), "foo", 42, errors.New("second")},
+			wantErrs:  []string{"invalid key-value pair at position 3: error without a key after the first"},
+			wantStats: SugarValidationStats{ExtraErrors: 1},
+			expected:  []Field{Error(errors.New("first")), Int("foo", 42)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var errs []string
+			hook := StrictSugar(func(err error) {
+				var argErr *SugarArgError
+				require.True(t, errors.As(err, &argErr), "Expected a *SugarArgError.")
+				errs = append(errs, err.Error())
+			})
+
+			withSugar(t, DebugLevel, []Option{hook}, func(logger *SugaredLogger, logs *observer.ObservedLogs) {
+				logger.Infow("hello", tt.args...)
+
+				assert.Equal(t, tt.wantErrs, errs, "Unexpected validation errors.")
+				assert.Equal(t, tt.wantStats, logger.ValidationStats(), "Unexpected validation stats.")
+				require.Equal(t, 1, logs.Len(), "Strict mode with a hook shouldn't log errors.")
+				assert.Equal(t, tt.expected, logs.AllUntimed()[0].Context)
+			})
+		})
+	}
+}
+
+func TestSugarStrictDPanic(t *testing.T) {
+	withSugar(t, DebugLevel, []Option{StrictSugar(nil), Development()}, func(logger *SugaredLogger, logs *observer.ObservedLogs) {
+		child := logger.With("foo", "bar")
+		assert.Panics(t, func() { child.With("dangling") }, "Expected DPanic in development.")
+
+		entries := logs.AllUntimed()
+		require.Len(t, entries, 1)
+		assert.Equal(t, DPanicLevel, entries[0].Level)
+		assert.Equal(t, _oddNumberErrMsg, entries[0].Message)
+		assert.Equal(t, map[string]interface{}{
+			"foo":      "bar",
+			"position": int64(0),
+			"key":      "dangling",
+			"value":    nil,
+		}, entries[0].ContextMap())
+
+		assert.Equal(t, SugarValidationStats{DanglingKeys: 1}, logger.ValidationStats(),
+			"Stats should be shared with derived loggers.")
+	})
+}
+
+func TestSugarValidationStatsDisabled(t *testing.T) {
+	withSugar(t, DebugLevel, nil, func(logger *SugaredLogger, logs *observer.ObservedLogs) {
+		logger.Infow("hello", "dangling")
+		assert.Equal(t, SugarValidationStats{}, logger.ValidationStats())
+		assert.Equal(t, 2, logs.Len(), "Expected the usual error entry outside strict mode.")
+	})
+}
@@ -21,6 +21,7 @@
 package zap
 
 import (
+	"context"
 	"fmt"
 	"io"
 
@@ -36,7 +37,7 @@ import (
 //
 // Passing no URLs returns a no-op WriteSyncer. Zap handles URLs without a
 // scheme and URLs with the "file" scheme. Third-party code may register
-// factories for other schemes using RegisterSink.
+// factories for other schemes using RegisterSink or RegisterSinkFactory.
 //
 // URLs with the "file" scheme must use absolute paths on the local
 // filesystem. No user, password, port, fragments, or query parameters are
@@ -48,7 +49,14 @@ import (
 // os.Stdout and os.Stderr. When specified without a scheme, relative file
 // paths also work.
 func Open(paths ...string) (zapcore.WriteSyncer, func(), error) {
-	writers, closeAll, err := open(paths)
+	return OpenContext(context.Background(), paths...)
+}
+
+// OpenContext is like Open, but passes ctx to the factories of sinks
+// registered with RegisterSinkFactory. Use it to bound the time spent
+// connecting to remote sinks.
+func OpenContext(ctx context.Context, paths ...string) (zapcore.WriteSyncer, func(), error) {
+	writers, closeAll, err := open(ctx, paths)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -57,7 +65,7 @@ func Open(paths ...string) (zapcore.WriteSyncer, func(), error) {
 	return writer, closeAll, nil
 }
 
-func open(paths []string) ([]zapcore.WriteSyncer, func(), error) {
+func open(ctx context.Context, paths []string) ([]zapcore.WriteSyncer, func(), error) {
 	writers := make([]zapcore.WriteSyncer, 0, len(paths))
 	closers := make([]io.Closer, 0, len(paths))
 	closeAll := func() {
@@ -68,7 +76,7 @@ func open(paths []string) ([]zapcore.WriteSyncer, func(), error) {
 
 	var openErr error
 	for _, path := range paths {
-		sink, err := _sinkRegistry.newSink(path)
+		sink, err := _sinkRegistry.newSinkContext(ctx, path)
 		if err != nil {
 			openErr = multierr.Append(openErr, fmt.Errorf("open sink %q: %w", path, err))
 			continue
@@ -0,0 +1,247 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zapaudit provides a logger for audit trails.
+//
+// Unlike a zap.Logger, which is built to stay out of the application's way,
+// an audit Logger trades throughput for guarantees: it never samples or
+// drops entries, it syncs the output after every entry and reports failures
+// to the caller, and it numbers every entry so that gaps can be detected.
+// Optionally, entries are chained together with an HMAC so that edits,
+// deletions, and reordering are detectable; see Verify.
+package zapaudit // import "go.uber.org/zap/zapaudit"
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// SequenceKey is the key under which every entry's sequence number is
+	// recorded. Sequence numbers start at 1 and increase by one per entry.
+	SequenceKey = "seq"
+
+	// ChainKey is the key under which every entry records the hex-encoded
+	// HMAC of the previous entry, when chaining is enabled with WithHMAC.
+	// The first entry in a chain records an empty string.
+	ChainKey = "chain"
+)
+
+// A Level classifies an audit entry. Audit levels are deliberately distinct
+// from zapcore's severity levels: they describe what kind of event happened,
+// not how urgent it is, and every level is always recorded.
+type Level int8
+
+const (
+	// AccessLevel records reads of protected resources.
+	AccessLevel Level = iota
+	// ChangeLevel records modifications to protected resources or
+	// configuration.
+	ChangeLevel
+	// SecurityLevel records security-relevant events such as
+	// authentication, authorization failures, and permission changes.
+	SecurityLevel
+)
+
+// String returns a lower-case ASCII representation of the audit level.
+func (l Level) String() string {
+	switch l {
+	case AccessLevel:
+		return "access"
+	case ChangeLevel:
+		return "change"
+	case SecurityLevel:
+		return "security"
+	default:
+		return fmt.Sprintf("Level(%d)", l)
+	}
+}
+
+// encodeLevel is installed as the EncodeLevel of every audit Logger's
+// encoder, so that entries carry audit level names instead of severities.
+func encodeLevel(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	enc.AppendString(Level(l).String())
+}
+
+// An Option configures a Logger.
+type Option interface {
+	apply(*Logger)
+}
+
+type optionFunc func(*Logger)
+
+func (f optionFunc) apply(log *Logger) {
+	f(log)
+}
+
+// WithHMAC chains entries together by recording, in each entry, the HMAC-SHA256
+// of the previous entry's encoded bytes under the given key. Entries written
+// with a chain can be checked with Verify.
+func WithHMAC(key []byte) Option {
+	return optionFunc(func(log *Logger) {
+		log.state.key = append([]byte(nil), key...)
+	})
+}
+
+// WithClock configures the Logger to use the supplied clock to timestamp
+// entries. Defaults to the system clock.
+func WithClock(clock zapcore.Clock) Option {
+	return optionFunc(func(log *Logger) {
+		log.clock = clock
+	})
+}
+
+// Logger writes audit entries. Each call to Log encodes, writes, and syncs a
+// single entry before returning, so an entry for which Log returned nil has
+// been acknowledged by the underlying WriteSyncer.
+//
+// A Logger is safe for concurrent use. Loggers derived with With share their
+// parent's sequence and chain.
+type Logger struct {
+	enc   zapcore.Encoder
+	clock zapcore.Clock
+	name  string
+	state *chainState
+}
+
+// chainState is the state shared by a Logger and all of its children.
+type chainState struct {
+	mu   sync.Mutex
+	out  zapcore.WriteSyncer
+	key  []byte // nil unless chaining
+	seq  uint64
+	prev []byte // MAC of the last entry written
+}
+
+// New builds a Logger that writes JSON-encoded entries to out. The level
+// encoder in cfg is always replaced with one that writes audit level names.
+func New(out zapcore.WriteSyncer, cfg zapcore.EncoderConfig, opts ...Option) *Logger {
+	cfg.EncodeLevel = encodeLevel
+	log := &Logger{
+		enc:   zapcore.NewJSONEncoder(cfg),
+		clock: zapcore.DefaultClock,
+		state: &chainState{out: out},
+	}
+	for _, opt := range opts {
+		opt.apply(log)
+	}
+	return log
+}
+
+// NewEncoderConfig returns an opinionated EncoderConfig for audit logs. It
+// is zap's production configuration with ISO8601 timestamps and without
+// stack traces.
+func NewEncoderConfig() zapcore.EncoderConfig {
+	cfg := zap.NewProductionEncoderConfig()
+	cfg.EncodeTime = zapcore.ISO8601TimeEncoder
+	cfg.StacktraceKey = zapcore.OmitKey
+	return cfg
+}
+
+// With creates a child Logger and adds structured context to it. Fields added
+// to the child don't affect the parent, and vice versa.
+func (log *Logger) With(fields ...zap.Field) *Logger {
+	clone := *log
+	clone.enc = log.enc.Clone()
+	for _, f := range fields {
+		f.AddTo(clone.enc)
+	}
+	return &clone
+}
+
+// Named adds a sub-scope to the Logger's name, separated by a period.
+func (log *Logger) Named(s string) *Logger {
+	if s == "" {
+		return log
+	}
+	clone := *log
+	if log.name == "" {
+		clone.name = s
+	} else {
+		clone.name = log.name + "." + s
+	}
+	return &clone
+}
+
+// Log records an audit entry at the given level. It returns only after the
+// entry has been written and the output synced, and reports any error from
+// either step. If Log returns an error, the entry may or may not have been
+// persisted, but the sequence number is consumed either way, so a gap in the
+// trail records the failure.
+func (log *Logger) Log(lvl Level, msg string, fields ...zap.Field) error {
+	ent := zapcore.Entry{
+		Level:      zapcore.Level(lvl),
+		Time:       log.clock.Now(),
+		LoggerName: log.name,
+		Message:    msg,
+	}
+
+	s := log.state
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	// Cap the slice so that appending never writes into the caller's array.
+	fields = append(fields[:len(fields):len(fields)], zap.Uint64(SequenceKey, s.seq))
+	if s.key != nil {
+		fields = append(fields, zap.String(ChainKey, hex.EncodeToString(s.prev)))
+	}
+
+	buf, err := log.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return fmt.Errorf("encode audit entry %d: %w", s.seq, err)
+	}
+	defer buf.Free()
+
+	if s.key != nil {
+		mac := hmac.New(sha256.New, s.key)
+		mac.Write(buf.Bytes())
+		s.prev = mac.Sum(s.prev[:0])
+	}
+
+	if _, err := s.out.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("write audit entry %d: %w", s.seq, err)
+	}
+	if err := s.out.Sync(); err != nil {
+		return fmt.Errorf("sync audit entry %d: %w", s.seq, err)
+	}
+	return nil
+}
+
+// Access records an entry at AccessLevel.
+func (log *Logger) Access(msg string, fields ...zap.Field) error {
+	return log.Log(AccessLevel, msg, fields...)
+}
+
+// Change records an entry at ChangeLevel.
+func (log *Logger) Change(msg string, fields ...zap.Field) error {
+	return log.Log(ChangeLevel, msg, fields...)
+}
+
+// Security records an entry at SecurityLevel.
+func (log *Logger) Security(msg string, fields ...zap.Field) error {
+	return log.Log(SecurityLevel, msg, fields...)
+}
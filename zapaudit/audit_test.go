@@ -0,0 +1,168 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapaudit
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/internal/ztest"
+	"go.uber.org/zap/zapcore"
+)
+
+func testEncoderConfig() zapcore.EncoderConfig {
+	cfg := NewEncoderConfig()
+	cfg.TimeKey = zapcore.OmitKey
+	return cfg
+}
+
+func TestLogger(t *testing.T) {
+	buf := &ztest.Buffer{}
+	log := New(buf, testEncoderConfig()).Named("billing").With(zap.String("tenant", "acme"))
+
+	require.NoError(t, log.Access("read invoice", zap.Int("invoice", 7)))
+	require.NoError(t, log.Change("updated plan"))
+	require.NoError(t, log.Named("auth").Security("login failed"))
+	assert.True(t, buf.Called(), "Expected output to be synced.")
+
+	assert.Equal(t, []string{
+		`{"level":"access","logger":"billing","msg":"read invoice","tenant":"acme","invoice":7,"seq":1}`,
+		`{"level":"change","logger":"billing","msg":"updated plan","tenant":"acme","seq":2}`,
+		`{"level":"security","logger":"billing.auth","msg":"login failed","tenant":"acme","seq":3}`,
+	}, buf.Lines())
+}
+
+func TestLoggerErrors(t *testing.T) {
+	t.Run("write", func(t *testing.T) {
+		log := New(&ztest.FailWriter{}, testEncoderConfig())
+		err := log.Access("foo")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "write audit entry 1")
+	})
+
+	t.Run("sync", func(t *testing.T) {
+		buf := &ztest.Buffer{}
+		buf.SetError(errors.New("disk full"))
+		log := New(buf, testEncoderConfig())
+		assert.EqualError(t, log.Access("foo"), "sync audit entry 1: disk full")
+
+		// The sequence number is consumed, leaving a gap in the trail.
+		buf.SetError(nil)
+		require.NoError(t, log.Access("bar"))
+		assert.Contains(t, buf.Lines()[1], `"seq":2`)
+	})
+}
+
+func TestLoggerDoesNotModifyFields(t *testing.T) {
+	log := New(&ztest.Discarder{}, testEncoderConfig())
+	fields := make([]zap.Field, 1, 4)
+	fields[0] = zap.String("k", "v")
+	require.NoError(t, log.Access("foo", fields...))
+	assert.Equal(t, zap.Field{}, fields[:2][1], "Log must not write into the caller's slice.")
+}
+
+func TestLevelString(t *testing.T) {
+	assert.Equal(t, "access", AccessLevel.String())
+	assert.Equal(t, "change", ChangeLevel.String())
+	assert.Equal(t, "security", SecurityLevel.String())
+	assert.Equal(t, "Level(42)", Level(42).String())
+}
+
+func TestVerify(t *testing.T) {
+	key := []byte("secret")
+	buf := &ztest.Buffer{}
+	clock := ztest.NewMockClock()
+	log := New(buf, NewEncoderConfig(), WithHMAC(key), WithClock(clock))
+	for _, msg := range []string{"one", "two", "three"} {
+		clock.Add(time.Second)
+		require.NoError(t, log.Change(msg))
+	}
+	// A restarted process appends a new chain to the same file.
+	restarted := New(buf, NewEncoderConfig(), WithHMAC(key), WithClock(clock))
+	require.NoError(t, restarted.Change("four"))
+
+	trail := buf.String()
+	require.NoError(t, Verify(key, strings.NewReader(trail)))
+	assert.Contains(t, buf.Lines()[0], `"chain":""`)
+
+	lines := buf.Lines()
+	tests := []struct {
+		desc    string
+		key     []byte
+		lines   []string
+		wantErr string
+	}{
+		{
+			desc:    "wrong key",
+			key:     []byte("guess"),
+			lines:   lines,
+			wantErr: "line 2: chain mismatch at sequence 2",
+		},
+		{
+			desc:    "edited",
+			key:     key,
+			lines:   []string{lines[0], strings.Replace(lines[1], "two", "TWO", 1), lines[2]},
+			wantErr: "line 3: chain mismatch at sequence 3",
+		},
+		{
+			desc:    "deleted",
+			key:     key,
+			lines:   []string{lines[0], lines[2]},
+			wantErr: "line 2: sequence 3 follows 1",
+		},
+		{
+			desc:    "reordered",
+			key:     key,
+			lines:   []string{lines[1], lines[0]},
+			wantErr: "line 1: sequence 2 follows 0",
+		},
+		{
+			desc:    "not JSON",
+			key:     key,
+			lines:   []string{"garbage"},
+			wantErr: "line 1: invalid character",
+		},
+		{
+			desc:    "not chained",
+			key:     key,
+			lines:   []string{`{"seq":1}`},
+			wantErr: `line 1: missing "seq" or "chain"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var in bytes.Buffer
+			for _, l := range tt.lines {
+				in.WriteString(l + "\n")
+			}
+			err := Verify(tt.key, &in)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapaudit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Verify reads an audit trail written by a Logger configured with WithHMAC
+// and checks that it hasn't been tampered with. It returns an error
+// identifying the first entry whose sequence number or chain doesn't match
+// what the previous entry implies.
+//
+// A sequence number of 1 with an empty chain starts a new chain; this is
+// what a restarted process writes to the same file. Verify can't detect the
+// removal of entries from the very end of the trail.
+func Verify(key []byte, r io.Reader) error {
+	var (
+		br   = bufio.NewReader(r)
+		seq  uint64
+		prev []byte
+		mac  = hmac.New(sha256.New, key)
+	)
+	for line := 1; ; line++ {
+		bs, err := br.ReadBytes('\n')
+		if len(bytes.TrimSpace(bs)) > 0 {
+			var ent struct {
+				Seq   *uint64 `json:"seq"`
+				Chain *string `json:"chain"`
+			}
+			if err := json.Unmarshal(bs, &ent); err != nil {
+				return fmt.Errorf("line %d: %w", line, err)
+			}
+			if ent.Seq == nil || ent.Chain == nil {
+				return fmt.Errorf("line %d: missing %q or %q", line, SequenceKey, ChainKey)
+			}
+
+			switch {
+			case *ent.Seq == 1 && *ent.Chain == "":
+				// Start of a new chain.
+			case *ent.Seq != seq+1:
+				return fmt.Errorf("line %d: sequence %d follows %d", line, *ent.Seq, seq)
+			case *ent.Chain != hex.EncodeToString(prev):
+				return fmt.Errorf("line %d: chain mismatch at sequence %d", line, *ent.Seq)
+			}
+
+			seq = *ent.Seq
+			mac.Reset()
+			mac.Write(bs)
+			prev = mac.Sum(prev[:0])
+		}
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// zapbench runs a matrix of zapbench scenarios and prints their throughput,
+// latency, and allocations, optionally writing CPU and heap profiles.
+//
+//	go run go.uber.org/zap/zapbench/cmd/zapbench -encoders json,console -fields 0,10,50 -goroutines 1,8
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"go.uber.org/zap/zapbench"
+)
+
+func main() {
+	log.SetFlags(0)
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("zapbench", flag.ContinueOnError)
+	var (
+		encoders   = flags.String("encoders", "json", "comma-separated encoders: "+strings.Join(zapbench.Encoders, ", "))
+		cores      = flags.String("cores", "io", "comma-separated cores: "+strings.Join(zapbench.Cores, ", "))
+		sinks      = flags.String("sinks", "discard", "comma-separated sinks: "+strings.Join(zapbench.Sinks, ", "))
+		fields     = flags.String("fields", "0,10", "comma-separated numbers of fields per entry")
+		goroutines = flags.String("goroutines", "1,"+strconv.Itoa(runtime.GOMAXPROCS(0)), "comma-separated numbers of concurrent goroutines")
+		entries    = flags.Int("entries", 100000, "entries logged by each scenario")
+		format     = flags.String("format", "text", "output format: text or json")
+		cpuProfile = flags.String("cpuprofile", "", "write a CPU profile of all scenarios to this file")
+		memProfile = flags.String("memprofile", "", "write a heap profile to this file after the last scenario")
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	fieldCounts, err := parseInts(*fields)
+	if err != nil {
+		return fmt.Errorf("invalid -fields: %w", err)
+	}
+	concurrency, err := parseInts(*goroutines)
+	if err != nil {
+		return fmt.Errorf("invalid -goroutines: %w", err)
+	}
+
+	if *cpuProfile != "" {
+		f, err := os.Create(*cpuProfile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if err := pprof.StartCPUProfile(f); err != nil {
+			return err
+		}
+		defer pprof.StopCPUProfile()
+	}
+
+	var results []zapbench.Result
+	for _, enc := range splitList(*encoders) {
+		for _, core := range splitList(*cores) {
+			for _, sink := range splitList(*sinks) {
+				for _, n := range fieldCounts {
+					for _, g := range concurrency {
+						s := zapbench.Scenario{Encoder: enc, Core: core, Sink: sink, Fields: n, Goroutines: g}
+						r, err := zapbench.Run(s, *entries)
+						if err != nil {
+							return fmt.Errorf("%s: %w", s.Name(), err)
+						}
+						results = append(results, r)
+					}
+				}
+			}
+		}
+	}
+
+	if *memProfile != "" {
+		if err := writeHeapProfile(*memProfile); err != nil {
+			return err
+		}
+	}
+	if *format == "json" {
+		return writeJSON(out, results)
+	}
+	return writeText(out, results)
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func parseInts(s string) ([]int, error) {
+	var ns []int
+	for _, item := range splitList(s) {
+		n, err := strconv.Atoi(item)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, errors.New("negative number " + item)
+		}
+		ns = append(ns, n)
+	}
+	return ns, nil
+}
+
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func writeText(out io.Writer, results []zapbench.Result) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "scenario\tentries/s\tp50\tp99\tmax\tallocs/entry\tB/entry\t")
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%.0f\t%v\t%v\t%v\t%.2f\t%.1f\t\n",
+			r.Scenario.Name(), r.EntriesPerSecond(), r.P50, r.P99, r.Max, r.AllocsPerEntry, r.BytesPerEntry)
+	}
+	return w.Flush()
+}
+
+func writeJSON(out io.Writer, results []zapbench.Result) error {
+	type jsonResult struct {
+		Scenario         string  `json:"scenario"`
+		Entries          int     `json:"entries"`
+		EntriesPerSecond float64 `json:"entriesPerSecond"`
+		P50Nanos         int64   `json:"p50Nanos"`
+		P99Nanos         int64   `json:"p99Nanos"`
+		MaxNanos         int64   `json:"maxNanos"`
+		AllocsPerEntry   float64 `json:"allocsPerEntry"`
+		BytesPerEntry    float64 `json:"bytesPerEntry"`
+	}
+	rs := make([]jsonResult, len(results))
+	for i, r := range results {
+		rs[i] = jsonResult{
+			Scenario:         r.Scenario.Name(),
+			Entries:          r.Entries,
+			EntriesPerSecond: r.EntriesPerSecond(),
+			P50Nanos:         r.P50.Nanoseconds(),
+			P99Nanos:         r.P99.Nanoseconds(),
+			MaxNanos:         r.Max.Nanoseconds(),
+			AllocsPerEntry:   r.AllocsPerEntry,
+			BytesPerEntry:    r.BytesPerEntry,
+		}
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rs)
+}
@@ -0,0 +1,360 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zapbench measures the throughput, latency, and allocations of
+// zap's encoders, cores, and sinks under reproducible workloads, so that
+// performance regressions are caught before they ship.
+//
+// Each Scenario logs a fixed number of entries with a fixed set of fields
+// from a fixed number of goroutines. Scenarios can be run directly with Run,
+// or from a testing benchmark with Scenario.Benchmark. The zapbench command
+// in the cmd/zapbench directory runs a matrix of scenarios from the command
+// line.
+package zapbench // import "go.uber.org/zap/zapbench"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Encoders, Cores, and Sinks list the names a Scenario accepts for its
+// encoder, core, and sink.
+var (
+	Encoders = []string{"json", "console", "cbor"}
+	Cores    = []string{"io", "buffered", "tee"}
+	Sinks    = []string{"discard", "file", "buffered"}
+)
+
+// _message is the message of every entry.
+const _message = "Benchmark entry with a message of typical length."
+
+// A Scenario describes one workload.
+type Scenario struct {
+	// Encoder is the name of the encoder: "json", "console", or "cbor".
+	//
+	// Defaults to "json" if unspecified.
+	Encoder string
+	// Core is the name of the core: "io" for zapcore.NewCore, "buffered"
+	// for zapcore.NewBufferedCore, or "tee" for two io cores combined with
+	// zapcore.NewTee.
+	//
+	// Defaults to "io" if unspecified.
+	Core string
+	// Sink is the name of the sink: "discard" for a WriteSyncer that drops
+	// its input, "file" for a temporary file, or "buffered" for a
+	// zapcore.BufferedWriteSyncer that drops its input, which is flushed in
+	// the background.
+	//
+	// Defaults to "discard" if unspecified.
+	Sink string
+	// Fields is the number of fields on each entry. The fields cycle
+	// through strings, integers, floats, booleans, durations, times, and
+	// objects.
+	Fields int
+	// Goroutines is the number of goroutines logging concurrently.
+	//
+	// Defaults to one if unspecified.
+	Goroutines int
+}
+
+func (s Scenario) withDefaults() Scenario {
+	if s.Encoder == "" {
+		s.Encoder = "json"
+	}
+	if s.Core == "" {
+		s.Core = "io"
+	}
+	if s.Sink == "" {
+		s.Sink = "discard"
+	}
+	if s.Goroutines <= 0 {
+		s.Goroutines = 1
+	}
+	return s
+}
+
+// Name identifies the scenario, as in "json/io/discard/fields=10/goroutines=4".
+func (s Scenario) Name() string {
+	s = s.withDefaults()
+	return fmt.Sprintf("%s/%s/%s/fields=%d/goroutines=%d", s.Encoder, s.Core, s.Sink, s.Fields, s.Goroutines)
+}
+
+// Result holds the measurements of a scenario.
+type Result struct {
+	Scenario Scenario
+	Entries  int
+	Elapsed  time.Duration
+
+	// Latencies of individual logging calls.
+	P50, P99, Max time.Duration
+
+	// Heap allocations per entry, including those of background
+	// goroutines such as the buffered core's flusher.
+	AllocsPerEntry float64
+	BytesPerEntry  float64
+}
+
+// EntriesPerSecond reports the scenario's throughput.
+func (r Result) EntriesPerSecond() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Entries) / r.Elapsed.Seconds()
+}
+
+// Run logs entries entries, spread over the scenario's goroutines, and
+// reports the measurements.
+func Run(s Scenario, entries int) (Result, error) {
+	s = s.withDefaults()
+	if entries <= 0 {
+		return Result{}, errors.New("zapbench: the number of entries must be positive")
+	}
+	env, err := s.build()
+	if err != nil {
+		return Result{}, err
+	}
+	fields := makeFields(s.Fields)
+
+	// Spread the entries over the goroutines, and allocate the space for
+	// their latencies before measuring anything.
+	latencies := make([][]time.Duration, s.Goroutines)
+	for i := range latencies {
+		n := entries / s.Goroutines
+		if i < entries%s.Goroutines {
+			n++
+		}
+		latencies[i] = make([]time.Duration, n)
+	}
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for i := range latencies {
+		wg.Add(1)
+		go func(lat []time.Duration) {
+			defer wg.Done()
+			for j := range lat {
+				t := time.Now()
+				env.logger.Info(_message, fields...)
+				lat[j] = time.Since(t)
+			}
+		}(latencies[i])
+	}
+	wg.Wait()
+	err = env.close()
+
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	all := make([]time.Duration, 0, entries)
+	for _, lat := range latencies {
+		all = append(all, lat...)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+
+	return Result{
+		Scenario:       s,
+		Entries:        entries,
+		Elapsed:        elapsed,
+		P50:            percentile(all, 0.50),
+		P99:            percentile(all, 0.99),
+		Max:            all[len(all)-1],
+		AllocsPerEntry: float64(after.Mallocs-before.Mallocs) / float64(entries),
+		BytesPerEntry:  float64(after.TotalAlloc-before.TotalAlloc) / float64(entries),
+	}, err
+}
+
+// Benchmark runs the scenario as a testing benchmark, logging b.N entries
+// spread over the scenario's goroutines.
+func (s Scenario) Benchmark(b *testing.B) {
+	s = s.withDefaults()
+	env, err := s.build()
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer func() {
+		if err := env.close(); err != nil {
+			b.Error(err)
+		}
+	}()
+	fields := makeFields(s.Fields)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	var wg sync.WaitGroup
+	for i := 0; i < s.Goroutines; i++ {
+		n := b.N / s.Goroutines
+		if i < b.N%s.Goroutines {
+			n++
+		}
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			for j := 0; j < n; j++ {
+				env.logger.Info(_message, fields...)
+			}
+		}(n)
+	}
+	wg.Wait()
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	return sorted[int(float64(len(sorted)-1)*p)]
+}
+
+// env is the logger of a scenario and the resources to release after it.
+type env struct {
+	logger  *zap.Logger
+	closers []func() error
+}
+
+func (e *env) close() error {
+	err := e.logger.Sync()
+	for i := len(e.closers) - 1; i >= 0; i-- {
+		err = multierr.Append(err, e.closers[i]())
+	}
+	return err
+}
+
+func (s Scenario) build() (_ *env, retErr error) {
+	e := &env{}
+	defer func() {
+		if retErr != nil {
+			for i := len(e.closers) - 1; i >= 0; i-- {
+				_ = e.closers[i]()
+			}
+		}
+	}()
+
+	enc, err := newEncoder(s.Encoder)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := e.newSink(s.Sink)
+	if err != nil {
+		return nil, err
+	}
+
+	var core zapcore.Core
+	switch s.Core {
+	case "io":
+		core = zapcore.NewCore(enc, ws, zapcore.DebugLevel)
+	case "buffered":
+		bc := zapcore.NewBufferedCore(enc, ws, zapcore.DebugLevel, zapcore.BatchConfig{})
+		e.closers = append(e.closers, bc.Stop)
+		core = bc
+	case "tee":
+		core = zapcore.NewTee(
+			zapcore.NewCore(enc, ws, zapcore.DebugLevel),
+			zapcore.NewCore(enc.Clone(), ws, zapcore.DebugLevel),
+		)
+	default:
+		return nil, fmt.Errorf("zapbench: unknown core %q", s.Core)
+	}
+	e.logger = zap.New(core)
+	return e, nil
+}
+
+func newEncoder(name string) (zapcore.Encoder, error) {
+	cfg := zap.NewProductionEncoderConfig()
+	switch name {
+	case "json":
+		return zapcore.NewJSONEncoder(cfg), nil
+	case "console":
+		return zapcore.NewConsoleEncoder(cfg), nil
+	case "cbor":
+		return zapcore.NewCBOREncoder(cfg), nil
+	}
+	return nil, fmt.Errorf("zapbench: unknown encoder %q", name)
+}
+
+func (e *env) newSink(name string) (zapcore.WriteSyncer, error) {
+	switch name {
+	case "discard":
+		return zapcore.AddSync(io.Discard), nil
+	case "file":
+		f, err := os.CreateTemp("", "zapbench-*.log")
+		if err != nil {
+			return nil, err
+		}
+		e.closers = append(e.closers, func() error {
+			return multierr.Append(f.Close(), os.Remove(f.Name()))
+		})
+		return zapcore.Lock(f), nil
+	case "buffered":
+		ws := &zapcore.BufferedWriteSyncer{WS: zapcore.AddSync(io.Discard)}
+		e.closers = append(e.closers, ws.Stop)
+		return ws, nil
+	}
+	return nil, fmt.Errorf("zapbench: unknown sink %q", name)
+}
+
+// benchObject is the object logged by object fields.
+type benchObject struct {
+	id   int
+	name string
+}
+
+func (o benchObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("id", o.id)
+	enc.AddString("name", o.name)
+	return nil
+}
+
+// makeFields returns n fields, the same every time.
+func makeFields(n int) []zap.Field {
+	epoch := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	fields := make([]zap.Field, n)
+	for i := range fields {
+		key := "field" + strconv.Itoa(i)
+		switch i % 7 {
+		case 0:
+			fields[i] = zap.String(key, "a short string value")
+		case 1:
+			fields[i] = zap.Int(key, i*1000)
+		case 2:
+			fields[i] = zap.Float64(key, float64(i)/3)
+		case 3:
+			fields[i] = zap.Bool(key, i%2 == 0)
+		case 4:
+			fields[i] = zap.Duration(key, time.Duration(i)*time.Millisecond)
+		case 5:
+			fields[i] = zap.Time(key, epoch.Add(time.Duration(i)*time.Second))
+		case 6:
+			fields[i] = zap.Object(key, benchObject{id: i, name: "object"})
+		}
+	}
+	return fields
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapbench
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	for _, enc := range Encoders {
+		for _, core := range Cores {
+			for _, sink := range Sinks {
+				s := Scenario{Encoder: enc, Core: core, Sink: sink, Fields: 8, Goroutines: 3}
+				t.Run(s.Name(), func(t *testing.T) {
+					r, err := Run(s, 100)
+					require.NoError(t, err, "Unexpected error running scenario.")
+					assert.Equal(t, 100, r.Entries, "Unexpected number of entries.")
+					assert.Positive(t, r.EntriesPerSecond(), "Expected a positive throughput.")
+					assert.LessOrEqual(t, r.P50, r.P99, "Expected p50 <= p99.")
+					assert.LessOrEqual(t, r.P99, r.Max, "Expected p99 <= max.")
+				})
+			}
+		}
+	}
+}
+
+func TestRunDefaults(t *testing.T) {
+	r, err := Run(Scenario{}, 1)
+	require.NoError(t, err, "Unexpected error running scenario.")
+	assert.Equal(t, "json/io/discard/fields=0/goroutines=1", r.Scenario.Name(), "Unexpected defaults.")
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		s       Scenario
+		entries int
+		want    string
+	}{
+		{"no entries", Scenario{}, 0, "zapbench: the number of entries must be positive"},
+		{"unknown encoder", Scenario{Encoder: "xml"}, 1, `zapbench: unknown encoder "xml"`},
+		{"unknown core", Scenario{Core: "async"}, 1, `zapbench: unknown core "async"`},
+		{"unknown sink", Scenario{Sink: "kafka"}, 1, `zapbench: unknown sink "kafka"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := Run(tt.s, tt.entries)
+			assert.EqualError(t, err, tt.want, "Unexpected error.")
+		})
+	}
+}
+
+func TestMakeFields(t *testing.T) {
+	assert.Equal(t, makeFields(20), makeFields(20), "Expected the same fields every time.")
+	assert.Len(t, makeFields(3), 3, "Unexpected number of fields.")
+}
+
+func BenchmarkScenarios(b *testing.B) {
+	for _, enc := range Encoders {
+		for _, core := range Cores {
+			for _, fields := range []int{0, 10} {
+				s := Scenario{Encoder: enc, Core: core, Fields: fields}
+				b.Run(s.Name(), s.Benchmark)
+			}
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// BinaryEncoding selects how text encoders write binary fields, such as
+// those built with zap.Binary.
+type BinaryEncoding uint8
+
+const (
+	// Base64Binary writes standard, padded base64, as in RFC 4648. This is
+	// the default.
+	Base64Binary BinaryEncoding = iota
+	// Base64URLBinary writes padded base64 with the URL- and filename-safe
+	// alphabet.
+	Base64URLBinary
+	// HexBinary writes lower-case hexadecimal, which is easier to grep for
+	// than base64, as for IDs and hashes.
+	HexBinary
+	// Base32Binary writes standard, padded base32.
+	Base32Binary
+)
+
+// String returns the encoding's name.
+func (e BinaryEncoding) String() string {
+	switch e {
+	case Base64Binary:
+		return "base64"
+	case Base64URLBinary:
+		return "base64url"
+	case HexBinary:
+		return "hex"
+	case Base32Binary:
+		return "base32"
+	default:
+		return fmt.Sprintf("BinaryEncoding(%d)", uint8(e))
+	}
+}
+
+// UnmarshalText unmarshals text to a BinaryEncoding. "base64" and the empty
+// string unmarshal to Base64Binary, "base64url" to Base64URLBinary, "hex"
+// to HexBinary, and "base32" to Base32Binary.
+func (e *BinaryEncoding) UnmarshalText(text []byte) error {
+	be, err := parseBinaryEncoding(string(text))
+	if err != nil {
+		return err
+	}
+	*e = be
+	return nil
+}
+
+func parseBinaryEncoding(name string) (BinaryEncoding, error) {
+	switch name {
+	case "base64", "":
+		return Base64Binary, nil
+	case "base64url":
+		return Base64URLBinary, nil
+	case "hex":
+		return HexBinary, nil
+	case "base32":
+		return Base32Binary, nil
+	default:
+		return Base64Binary, fmt.Errorf("unrecognized binary encoding: %q", name)
+	}
+}
+
+// EncodeToString returns val in the encoding.
+func (e BinaryEncoding) EncodeToString(val []byte) string {
+	switch e {
+	case Base64URLBinary:
+		return base64.URLEncoding.EncodeToString(val)
+	case HexBinary:
+		return hex.EncodeToString(val)
+	case Base32Binary:
+		return base32.StdEncoding.EncodeToString(val)
+	default:
+		return base64.StdEncoding.EncodeToString(val)
+	}
+}
+
+// binaryEncoding returns the configured BinaryEncoding. Like limitString,
+// it's safe to call on the nil config of an encoder used only for nested
+// values.
+func (cfg *EncoderConfig) binaryEncoding() BinaryEncoding {
+	if cfg == nil {
+		return Base64Binary
+	}
+	return cfg.EncodeBinary
+}
+
+// addBinary adds a BinaryType field. Fields whose String names an encoding,
+// like those built with zap.Hex, are written as strings in that encoding.
+func addBinary(enc ObjectEncoder, key, encoding string, val []byte) error {
+	if encoding == "" {
+		enc.AddBinary(key, val)
+		return nil
+	}
+	be, err := parseBinaryEncoding(encoding)
+	if err != nil {
+		return err
+	}
+	enc.AddString(key, be.EncodeToString(val))
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+)
+
+func TestBinaryEncodingUnmarshalText(t *testing.T) {
+	tests := []struct {
+		text string
+		want BinaryEncoding
+	}{
+		{"", Base64Binary},
+		{"base64", Base64Binary},
+		{"base64url", Base64URLBinary},
+		{"hex", HexBinary},
+		{"base32", Base32Binary},
+	}
+	for _, tt := range tests {
+		var e BinaryEncoding
+		require.NoError(t, e.UnmarshalText([]byte(tt.text)), "Unexpected error unmarshaling %q.", tt.text)
+		assert.Equal(t, tt.want, e, "Unexpected encoding for %q.", tt.text)
+		if tt.text != "" {
+			assert.Equal(t, tt.text, e.String(), "Unexpected string for %v.", e)
+		}
+	}
+
+	var e BinaryEncoding
+	assert.Error(t, e.UnmarshalText([]byte("base58")), "Expected an error for an unknown encoding.")
+	assert.Equal(t, "BinaryEncoding(9)", BinaryEncoding(9).String(), "Unexpected string for an unknown encoding.")
+}
+
+func TestBinaryEncodings(t *testing.T) {
+	val := []byte{0xfb, 0xff, 0x01}
+	tests := []struct {
+		encoding BinaryEncoding
+		want     string
+	}{
+		{Base64Binary, "+/8B"},
+		{Base64URLBinary, "-_8B"},
+		{HexBinary, "fbff01"},
+		{Base32Binary, "7P7QC==="},
+	}
+	for _, tt := range tests {
+		t.Run(tt.encoding.String(), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.encoding.EncodeToString(val), "Unexpected encoding.")
+
+			newGELFEncoder := func(cfg EncoderConfig) Encoder { return NewGELFEncoder(cfg, "host") }
+			for _, newEnc := range []func(EncoderConfig) Encoder{NewJSONEncoder, NewConsoleEncoder, newGELFEncoder} {
+				enc := newEnc(EncoderConfig{EncodeBinary: tt.encoding})
+				enc.AddBinary("k", val)
+				enc.AddArray("arr", ArrayMarshalerFunc(func(arr ArrayEncoder) error {
+					return arr.AppendObject(ObjectMarshalerFunc(func(obj ObjectEncoder) error {
+						obj.AddBinary("nested", val)
+						return nil
+					}))
+				}))
+				buf, err := enc.EncodeEntry(Entry{}, nil)
+				require.NoError(t, err, "Unexpected encoding error.")
+				assert.Equal(t, 2, strings.Count(buf.String(), tt.want), "Expected top-level and nested binary fields in %q.", buf.String())
+				buf.Free()
+			}
+		})
+	}
+}
+
+func TestBinaryFieldEncodingOverride(t *testing.T) {
+	val := []byte{0xfb, 0xff, 0x01}
+	enc := NewMapObjectEncoder()
+	Field{Key: "hex", Type: BinaryType, Interface: val, String: "hex"}.AddTo(enc)
+	Field{Key: "raw", Type: BinaryType, Interface: val}.AddTo(enc)
+	Field{Key: "bad", Type: BinaryType, Interface: val, String: "base58"}.AddTo(enc)
+	assert.Equal(t, map[string]interface{}{
+		"hex":      "fbff01",
+		"raw":      val,
+		"badError": `unrecognized binary encoding: "base58"`,
+	}, enc.Fields, "Unexpected fields.")
+
+	hex := Field{Key: "k", Type: BinaryType, Interface: val, String: "hex"}
+	assert.False(t, hex.Equals(Field{Key: "k", Type: BinaryType, Interface: val}), "Expected fields with different encodings to differ.")
+}
@@ -0,0 +1,275 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/internal/bufferpool"
+)
+
+const (
+	_defaultBatchEntries = 1000
+	_defaultBatchBytes   = 256 * 1024 // 256 kB
+	_defaultBatchLatency = time.Second
+)
+
+// BatchConfig configures how a BufferedCore batches entries.
+type BatchConfig struct {
+	// MaxEntries is the number of entries after which a batch is written.
+	//
+	// Defaults to 1000 if unspecified.
+	MaxEntries int
+
+	// MaxBytes is the size in bytes after which a batch is written. An entry
+	// that would take the batch over this size is written in the next batch.
+	//
+	// Defaults to 256 kB if unspecified.
+	MaxBytes int
+
+	// MaxLatency is the longest an entry waits in a batch before it's
+	// written.
+	//
+	// Defaults to one second if unspecified.
+	MaxLatency time.Duration
+
+	// Clock, if specified, provides the ticker used to enforce MaxLatency.
+	//
+	// Defaults to the system clock.
+	Clock Clock
+}
+
+// A BufferedCore is a Core that encodes entries into a single pooled buffer
+// and writes them to its WriteSyncer in batches, so that file and network
+// sinks see one write per batch rather than one per entry. A batch is written
+// once it holds MaxEntries entries or MaxBytes bytes, or MaxLatency after the
+// previous batch, whichever comes first. Entries above ErrorLevel are written
+// and synced immediately, since they may be followed by a crash.
+//
+// Cores derived from a BufferedCore with With share its batch. Sync writes
+// the pending batch before syncing the WriteSyncer, and Stop does the same
+// after stopping the background goroutine that enforces MaxLatency:
+//
+//	core := zapcore.NewBufferedCore(enc, ws, zapcore.InfoLevel, zapcore.BatchConfig{})
+//	defer core.Stop()
+//	logger := zap.New(core)
+//
+// Unlike BufferedWriteSyncer, which buffers the bytes written to it,
+// BufferedCore counts entries and never splits one across writes.
+type BufferedCore struct {
+	LevelEnabler
+
+	enc    Encoder
+	fields []Field // bound with With
+	batch  *entryBatch
+}
+
+var (
+	_ Core             = (*BufferedCore)(nil)
+	_ leveledEnabler   = (*BufferedCore)(nil)
+	_ fieldAccumulator = (*BufferedCore)(nil)
+)
+
+// NewBufferedCore creates a BufferedCore that writes entries encoded with
+// enc to ws in batches. Writes to ws are serialized, so it needn't be
+// locked. Call Stop when the core is no longer needed.
+func NewBufferedCore(enc Encoder, ws WriteSyncer, enab LevelEnabler, cfg BatchConfig) *BufferedCore {
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = _defaultBatchEntries
+	}
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = _defaultBatchBytes
+	}
+	if cfg.MaxLatency <= 0 {
+		cfg.MaxLatency = _defaultBatchLatency
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = DefaultClock
+	}
+
+	b := &entryBatch{
+		out:        ws,
+		maxEntries: cfg.MaxEntries,
+		maxBytes:   cfg.MaxBytes,
+		buf:        bufferpool.Get(),
+		ticker:     cfg.Clock.NewTicker(cfg.MaxLatency),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	go b.flushLoop()
+
+	return &BufferedCore{
+		LevelEnabler: enab,
+		enc:          enc,
+		batch:        b,
+	}
+}
+
+// Level reports the minimum enabled level for this core.
+func (c *BufferedCore) Level() Level {
+	return LevelOf(c.LevelEnabler)
+}
+
+// With adds structured context to the core. The returned core shares this
+// core's batch.
+func (c *BufferedCore) With(fields []Field) Core {
+	clone := &BufferedCore{
+		LevelEnabler: c.LevelEnabler,
+		enc:          c.enc.Clone(),
+		fields:       append(c.fields[:len(c.fields):len(c.fields)], fields...),
+		batch:        c.batch,
+	}
+	addFields(clone.enc, fields)
+	return clone
+}
+
+// AccumulatedFields returns the fields bound to the core with With.
+func (c *BufferedCore) AccumulatedFields() []Field {
+	return c.fields
+}
+
+// Check adds the core to ce if the entry's level is enabled.
+func (c *BufferedCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	ent.Trace.record(c, TraceRejected, "")
+	return ce
+}
+
+// Write encodes the entry and adds it to the current batch, writing the
+// batch if it's full.
+func (c *BufferedCore) Write(ent Entry, fields []Field) error {
+	buf, fieldErr, err := encodeEntryFieldErrors(c.enc, ent, fields)
+	if err != nil {
+		return err
+	}
+	err = multierr.Append(fieldErr, c.batch.add(buf))
+	buf.Free()
+	if ent.Level > ErrorLevel {
+		// Since we may be crashing the program, write and sync the output.
+		err = multierr.Append(err, c.Sync())
+	}
+	return err
+}
+
+func (c *BufferedCore) retainsFields() bool {
+	return retainsFields(c.enc)
+}
+
+// Sync writes the pending batch and syncs the WriteSyncer.
+func (c *BufferedCore) Sync() error {
+	return c.batch.sync()
+}
+
+// Stop stops the goroutine that writes batches after MaxLatency, then writes
+// the pending batch and syncs the WriteSyncer. Entries written after Stop
+// are only written when batches fill up or Sync is called.
+func (c *BufferedCore) Stop() error {
+	b := c.batch
+	b.mu.Lock()
+	stopped := b.stopped
+	if !stopped {
+		b.stopped = true
+		b.ticker.Stop()
+		close(b.stop)
+	}
+	b.mu.Unlock()
+
+	if !stopped {
+		// Wait outside of the lock, since flushLoop may need it.
+		<-b.done
+	}
+	return c.Sync()
+}
+
+// entryBatch holds the encoded entries shared by a BufferedCore and the
+// cores derived from it.
+type entryBatch struct {
+	out        WriteSyncer
+	maxEntries int
+	maxBytes   int
+
+	mu      sync.Mutex
+	buf     *buffer.Buffer
+	entries int
+	stopped bool
+
+	ticker *time.Ticker
+	stop   chan struct{} // closed when flushLoop should stop
+	done   chan struct{} // closed when flushLoop has stopped
+}
+
+func (b *entryBatch) add(entry *buffer.Buffer) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var err error
+	if b.entries > 0 && b.buf.Len()+entry.Len() > b.maxBytes {
+		err = b.flush()
+	}
+	b.buf.Write(entry.Bytes())
+	b.entries++
+	if b.entries >= b.maxEntries || b.buf.Len() >= b.maxBytes {
+		err = multierr.Append(err, b.flush())
+	}
+	return err
+}
+
+// flush writes the pending entries, if any. The caller must hold mu.
+func (b *entryBatch) flush() error {
+	if b.entries == 0 {
+		return nil
+	}
+	// Hand the buffer off, so WriteSyncers that implement BufferWriter can
+	// take ownership of it without a copy.
+	buf := b.buf
+	b.buf = bufferpool.Get()
+	b.entries = 0
+	return writeBuffer(b.out, buf)
+}
+
+func (b *entryBatch) sync() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return multierr.Append(b.flush(), b.out.Sync())
+}
+
+// flushLoop writes the pending batch at every tick until Stop is called.
+func (b *entryBatch) flushLoop() {
+	defer close(b.done)
+
+	for {
+		select {
+		case <-b.ticker.C:
+			b.mu.Lock()
+			// There's no caller to return write errors to.
+			_ = b.flush()
+			b.mu.Unlock()
+		case <-b.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap/internal/ztest"
+	. "go.uber.org/zap/zapcore"
+)
+
+// batchSink records each write it receives.
+type batchSink struct {
+	ztest.Syncer
+
+	mu     sync.Mutex
+	writes []string
+}
+
+func (s *batchSink) Write(bs []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writes = append(s.writes, string(bs))
+	return len(bs), nil
+}
+
+func (s *batchSink) Writes() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.writes...)
+}
+
+func newBatchTestCore(sink *batchSink, cfg BatchConfig) *BufferedCore {
+	encCfg := testEncoderConfig()
+	encCfg.TimeKey = ""
+	encCfg.LevelKey = ""
+	encCfg.NameKey = ""
+	encCfg.CallerKey = ""
+	encCfg.FunctionKey = ""
+	return NewBufferedCore(NewJSONEncoder(encCfg), sink, DebugLevel, cfg)
+}
+
+func writeEntry(t testing.TB, core Core, lvl Level, msg string) {
+	ce := core.Check(Entry{Level: lvl, Message: msg}, nil)
+	require.NotNil(t, ce, "Expected entry to be enabled.")
+	ce.Write()
+}
+
+func TestBufferedCoreMaxEntries(t *testing.T) {
+	sink := &batchSink{}
+	core := newBatchTestCore(sink, BatchConfig{MaxEntries: 2, MaxLatency: time.Hour})
+	child := core.With([]Field{{Key: "k", Type: StringType, String: "v"}})
+
+	writeEntry(t, core, InfoLevel, "a")
+	assert.Empty(t, sink.Writes(), "Expected the first entry to be buffered.")
+	writeEntry(t, child, InfoLevel, "b")
+	writeEntry(t, core, InfoLevel, "c")
+
+	assert.Equal(t, []string{
+		`{"msg":"a"}` + "\n" + `{"msg":"b","k":"v"}` + "\n",
+	}, sink.Writes(), "Expected a full batch to be written at once.")
+	assert.Equal(t, []Field{{Key: "k", Type: StringType, String: "v"}}, child.(*BufferedCore).AccumulatedFields(), "Unexpected bound fields.")
+
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+	assert.Equal(t, `{"msg":"c"}`+"\n", sink.Writes()[1], "Expected Sync to write the pending batch.")
+	assert.True(t, sink.Called(), "Expected Sync to sync the sink.")
+	require.NoError(t, core.Stop(), "Unexpected error stopping.")
+	assert.Len(t, sink.Writes(), 2, "Expected no empty writes.")
+}
+
+func TestBufferedCoreMaxBytes(t *testing.T) {
+	sink := &batchSink{}
+	core := newBatchTestCore(sink, BatchConfig{MaxBytes: 30, MaxLatency: time.Hour})
+	defer core.Stop()
+
+	writeEntry(t, core, InfoLevel, "first")  // 16 bytes
+	writeEntry(t, core, InfoLevel, "second") // would take the batch to 33 bytes
+	writeEntry(t, core, InfoLevel, strings.Repeat("x", 40))
+
+	assert.Equal(t, []string{
+		`{"msg":"first"}` + "\n",
+		`{"msg":"second"}` + "\n",
+		`{"msg":"` + strings.Repeat("x", 40) + `"}` + "\n",
+	}, sink.Writes(), "Expected batches to be split at MaxBytes.")
+}
+
+func TestBufferedCoreMaxLatency(t *testing.T) {
+	sink := &batchSink{}
+	clock := ztest.NewMockClock()
+	core := newBatchTestCore(sink, BatchConfig{MaxLatency: time.Second, Clock: clock})
+	defer core.Stop()
+
+	writeEntry(t, core, InfoLevel, "a")
+	writeEntry(t, core, InfoLevel, "b")
+	clock.Add(time.Second)
+	assert.Eventually(t, func() bool {
+		return len(sink.Writes()) == 1
+	}, time.Second, time.Millisecond, "Expected the batch to be written after MaxLatency.")
+	assert.Equal(t, `{"msg":"a"}`+"\n"+`{"msg":"b"}`+"\n", sink.Writes()[0], "Unexpected batch.")
+}
+
+func TestBufferedCoreFatalEntries(t *testing.T) {
+	sink := &batchSink{}
+	core := newBatchTestCore(sink, BatchConfig{MaxLatency: time.Hour})
+	defer core.Stop()
+
+	assert.Equal(t, DebugLevel, LevelOf(core), "Unexpected level.")
+	writeEntry(t, core, InfoLevel, "a")
+	ce := core.Check(Entry{Level: PanicLevel, Message: "b"}, nil)
+	require.NotNil(t, ce, "Expected entry to be enabled.")
+	require.NoError(t, core.Write(ce.Entry, nil), "Unexpected error writing.")
+
+	assert.Equal(t, []string{`{"msg":"a"}` + "\n" + `{"msg":"b"}` + "\n"}, sink.Writes(), "Expected entries above ErrorLevel to be written immediately.")
+	assert.True(t, sink.Called(), "Expected entries above ErrorLevel to sync the sink.")
+}
+
+func TestBufferedCoreStopTwice(t *testing.T) {
+	core := newBatchTestCore(&batchSink{}, BatchConfig{})
+	assert.NoError(t, core.Stop(), "Unexpected error stopping.")
+	assert.NoError(t, core.Stop(), "Unexpected error stopping twice.")
+}
@@ -64,7 +64,8 @@ const (
 // By default, a BufferedWriteSyncer will buffer up to 256 kilobytes of logs,
 // waiting at most 30 seconds between flushes.
 // You can customize these parameters by setting the Size or FlushInterval
-// fields.
+// fields, and flush entries at important levels immediately by setting
+// FlushLevel.
 // For example, the following buffers up to 512 kB of logs before flushing them
 // to Stderr, with a maximum of one minute between each flush.
 //
@@ -93,6 +94,16 @@ type BufferedWriteSyncer struct {
 	// Defaults to 30 seconds if unspecified.
 	FlushInterval time.Duration
 
+	// FlushLevel, if specified, makes cores built with NewCore flush the
+	// buffer as soon as they've written an entry at an enabled level, so
+	// that, for example, errors are on disk before a crash rather than
+	// waiting for the next flush. It takes effect only when the
+	// BufferedWriteSyncer is passed to NewCore directly, not wrapped in
+	// another WriteSyncer.
+	//
+	// Entries above ErrorLevel are always flushed.
+	FlushLevel LevelEnabler
+
 	// Clock, if specified, provides control of the source of time for the
 	// writer.
 	//
@@ -167,6 +178,31 @@ func (s *BufferedWriteSyncer) Sync() error {
 	return multierr.Append(err, s.WS.Sync())
 }
 
+func (s *BufferedWriteSyncer) flushesAt(lvl Level) bool {
+	return s.FlushLevel != nil && s.FlushLevel.Enabled(lvl)
+}
+
+// levelFlusher is implemented by WriteSyncers that should be synced right
+// after cores write entries at certain levels to them.
+type levelFlusher interface {
+	flushesAt(Level) bool
+}
+
+// flushesAt reports whether ws should be synced after an entry at lvl is
+// written to it, looking through wrappers that have an Unwrap method.
+func flushesAt(ws WriteSyncer, lvl Level) bool {
+	for {
+		switch w := ws.(type) {
+		case levelFlusher:
+			return w.flushesAt(lvl)
+		case unwrapper:
+			ws = w.Unwrap()
+		default:
+			return false
+		}
+	}
+}
+
 // flushLoop flushes the buffer at the configured interval until Stop is
 // called.
 func (s *BufferedWriteSyncer) flushLoop() {
@@ -22,6 +22,8 @@ package zapcore
 
 import (
 	"bytes"
+	"io"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -146,3 +148,64 @@ func TestBufferWriterWithoutStart(t *testing.T) {
 		assert.NoError(t, ws.Sync(), "Sync must not fail")
 	})
 }
+
+func TestBufferWriterFlushLevel(t *testing.T) {
+	cfg := EncoderConfig{MessageKey: "msg", LevelKey: "level", EncodeLevel: LowercaseLevelEncoder}
+
+	encoders := map[string]Encoder{
+		"json":    NewJSONEncoder(cfg),
+		"console": NewConsoleEncoder(cfg),
+	}
+	for name, enc := range encoders {
+		t.Run(name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			ws := &BufferedWriteSyncer{WS: AddSync(buf), FlushLevel: ErrorLevel}
+			defer func() { assert.NoError(t, ws.Stop()) }()
+
+			core := NewCore(enc, ws, DebugLevel)
+			require.NoError(t, core.Write(Entry{Level: WarnLevel, Message: "buffered"}, nil))
+			assert.Zero(t, buf.Len(), "Entries below FlushLevel should stay buffered.")
+
+			require.NoError(t, core.Write(Entry{Level: ErrorLevel, Message: "flushed"}, nil))
+			assert.Contains(t, buf.String(), "buffered", "Flush should include earlier entries.")
+			assert.Contains(t, buf.String(), "flushed")
+		})
+	}
+
+	wrappers := map[string]func(WriteSyncer) WriteSyncer{
+		"locked": Lock,
+		"multi": func(ws WriteSyncer) WriteSyncer {
+			return NewMultiWriteSyncer(ws, AddSync(io.Discard))
+		},
+		"unwrapper": func(ws WriteSyncer) WriteSyncer {
+			return Lock(unwrappingWriteSyncer{ws})
+		},
+		"counting": func(ws WriteSyncer) WriteSyncer {
+			return NewCountingWriteSyncer(ws, new(atomic.Uint64))
+		},
+	}
+	for name, wrap := range wrappers {
+		t.Run(name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			ws := &BufferedWriteSyncer{WS: AddSync(buf), FlushLevel: ErrorLevel}
+			defer func() { assert.NoError(t, ws.Stop()) }()
+
+			core := NewCore(NewJSONEncoder(cfg), wrap(ws), DebugLevel)
+			require.NoError(t, core.Write(Entry{Level: WarnLevel, Message: "buffered"}, nil))
+			assert.Zero(t, buf.Len(), "Entries below FlushLevel should stay buffered.")
+
+			require.NoError(t, core.Write(Entry{Level: ErrorLevel, Message: "flushed"}, nil))
+			assert.Contains(t, buf.String(), "flushed", "Expected FlushLevel to apply through %v.", name)
+		})
+	}
+
+	t.Run("unset", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		ws := &BufferedWriteSyncer{WS: AddSync(buf)}
+		defer func() { assert.NoError(t, ws.Stop()) }()
+
+		core := NewCore(NewJSONEncoder(cfg), ws, DebugLevel)
+		require.NoError(t, core.Write(Entry{Level: ErrorLevel, Message: "buffered"}, nil))
+		assert.Zero(t, buf.Len(), "Expected no flush without FlushLevel.")
+	})
+}
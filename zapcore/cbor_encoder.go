@@ -0,0 +1,667 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"sort"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/internal/bufferpool"
+	"go.uber.org/zap/internal/pool"
+)
+
+// CBOR major types, pre-shifted into the high three bits of the initial byte.
+const (
+	_cborUint   byte = 0 << 5
+	_cborNegInt byte = 1 << 5
+	_cborBytes  byte = 2 << 5
+	_cborText   byte = 3 << 5
+	_cborArray  byte = 4 << 5
+	_cborMap    byte = 5 << 5
+	_cborSimple byte = 7 << 5
+
+	_cborFalse   = _cborSimple | 20
+	_cborTrue    = _cborSimple | 21
+	_cborNull    = _cborSimple | 22
+	_cborFloat16 = _cborSimple | 25
+	_cborFloat32 = _cborSimple | 26
+	_cborFloat64 = _cborSimple | 27
+)
+
+var _cborPool = pool.New(func() *cborEncoder {
+	return &cborEncoder{}
+})
+
+func putCBOREncoder(enc *cborEncoder) {
+	if enc.reflectBuf != nil {
+		enc.reflectBuf.Free()
+	}
+	enc.EncoderConfig = nil
+	enc.bs = enc.bs[:0]
+	enc.frames = enc.frames[:0]
+	enc.openNamespaces = 0
+	enc.reflectBuf = nil
+	enc.reflectEnc = nil
+	_cborPool.Put(enc)
+}
+
+// cborFrame is a map or array whose header hasn't been written yet. CBOR
+// prefixes containers with their length, which isn't known until they're
+// closed.
+type cborFrame struct {
+	start   int  // offset of the container's first element
+	isArray bool // otherwise, it's a map
+	n       int  // number of elements in an array
+
+	// offsets of each key in a map. Entries span from their key's offset to
+	// the next entry's.
+	keys []int
+}
+
+type cborEncoder struct {
+	*EncoderConfig
+	bs             []byte
+	frames         []cborFrame
+	openNamespaces int
+
+	// for encoding generic values by reflection
+	reflectBuf *buffer.Buffer
+	reflectEnc ReflectedEncoder
+
+	// scratch space for closing frames
+	scratch []byte
+	spans   [][2]int
+}
+
+// NewCBOREncoder creates an encoder that writes each entry as a CBOR
+// (RFC 8949) map. Consecutive entries form a CBOR sequence (RFC 8742), so
+// LineEnding is ignored.
+//
+// Maps and arrays use definite lengths, map keys are sorted, and numbers use
+// their shortest form, so output follows CBOR's core deterministic encoding
+// as long as keys aren't duplicated. Like the JSON encoder, the CBOR encoder
+// doesn't deduplicate keys.
+//
+// Binary fields are encoded as CBOR byte strings, and complex numbers as
+// two-element arrays of their real and imaginary parts. Values without a
+// natural CBOR representation, such as those added with AddReflected, are
+// encoded by the configured ReflectedEncoder (JSON by default) and embedded
+// as text strings.
+func NewCBOREncoder(cfg EncoderConfig) Encoder {
+	// If no EncoderConfig.NewReflectedEncoder is provided by the user, then use default
+	if cfg.NewReflectedEncoder == nil {
+		cfg.NewReflectedEncoder = defaultReflectedEncoder
+	}
+
+	cfg.EncodeKey = cacheKeyEncoder(cfg.EncodeKey)
+
+	enc := &cborEncoder{EncoderConfig: &cfg}
+	enc.pushFrame(false /* isArray */)
+	return enc
+}
+
+func (enc *cborEncoder) AddArray(key string, arr ArrayMarshaler) error {
+	enc.addKey(key)
+	return enc.AppendArray(arr)
+}
+
+func (enc *cborEncoder) AddObject(key string, obj ObjectMarshaler) error {
+	enc.addKey(key)
+	return enc.AppendObject(obj)
+}
+
+func (enc *cborEncoder) AddBinary(key string, val []byte) {
+	enc.addKey(key)
+	enc.bs = appendCBORHead(enc.bs, _cborBytes, uint64(len(val)))
+	enc.bs = append(enc.bs, val...)
+}
+
+func (enc *cborEncoder) AddByteString(key string, val []byte) {
+	enc.addKey(key)
+	if enc.limitsBytes(val) {
+		enc.AppendString(enc.limitString(string(val)))
+		return
+	}
+	enc.AppendByteString(val)
+}
+
+func (enc *cborEncoder) AddBool(key string, val bool) {
+	enc.addKey(key)
+	enc.AppendBool(val)
+}
+
+func (enc *cborEncoder) AddComplex128(key string, val complex128) {
+	enc.addKey(key)
+	enc.AppendComplex128(val)
+}
+
+func (enc *cborEncoder) AddComplex64(key string, val complex64) {
+	enc.addKey(key)
+	enc.AppendComplex64(val)
+}
+
+func (enc *cborEncoder) AddDuration(key string, val time.Duration) {
+	enc.addKey(key)
+	enc.AppendDuration(val)
+}
+
+func (enc *cborEncoder) AddFloat64(key string, val float64) {
+	enc.addKey(key)
+	enc.AppendFloat64(val)
+}
+
+func (enc *cborEncoder) AddFloat32(key string, val float32) {
+	enc.addKey(key)
+	enc.AppendFloat32(val)
+}
+
+func (enc *cborEncoder) AddInt64(key string, val int64) {
+	enc.addKey(key)
+	enc.AppendInt64(val)
+}
+
+func (enc *cborEncoder) AddReflected(key string, obj interface{}) error {
+	valueBytes, err := enc.encodeReflected(obj)
+	if err != nil {
+		return err
+	}
+	enc.addKey(key)
+	enc.appendTextBytes(valueBytes)
+	return nil
+}
+
+// EncodingName implements PreEncodedEncoder.
+func (enc *cborEncoder) EncodingName() string {
+	return "cbor"
+}
+
+// AddPreEncoded adds a pre-encoded CBOR data item. It isn't validated, so
+// it must be a single, well-formed item.
+func (enc *cborEncoder) AddPreEncoded(key string, val []byte) {
+	enc.addKey(key)
+	enc.bs = append(enc.bs, val...)
+}
+
+func (enc *cborEncoder) OpenNamespace(key string) {
+	enc.addKey(key)
+	enc.pushFrame(false /* isArray */)
+	enc.openNamespaces++
+}
+
+func (enc *cborEncoder) CloseNamespace() {
+	if enc.openNamespaces > 0 {
+		enc.closeFrame()
+		enc.openNamespaces--
+	}
+}
+
+func (enc *cborEncoder) AddString(key, val string) {
+	enc.addKey(key)
+	enc.AppendString(enc.limitString(val))
+}
+
+func (enc *cborEncoder) AddTime(key string, val time.Time) {
+	enc.addKey(key)
+	enc.AppendTime(val)
+}
+
+func (enc *cborEncoder) AddUint64(key string, val uint64) {
+	enc.addKey(key)
+	enc.AppendUint64(val)
+}
+
+func (enc *cborEncoder) AppendArray(arr ArrayMarshaler) error {
+	enc.addElement()
+	enc.pushFrame(true /* isArray */)
+	err := enc.limitArray(arr).MarshalLogArray(enc)
+	enc.closeFrame()
+	return err
+}
+
+func (enc *cborEncoder) AppendObject(obj ObjectMarshaler) error {
+	// Close ONLY new openNamespaces that are created during
+	// AppendObject().
+	old := enc.openNamespaces
+	enc.openNamespaces = 0
+	enc.addElement()
+	enc.pushFrame(false /* isArray */)
+	err := obj.MarshalLogObject(enc)
+	enc.closeOpenNamespaces()
+	enc.closeFrame()
+	enc.openNamespaces = old
+	return err
+}
+
+func (enc *cborEncoder) AppendBool(val bool) {
+	enc.addElement()
+	if val {
+		enc.bs = append(enc.bs, _cborTrue)
+	} else {
+		enc.bs = append(enc.bs, _cborFalse)
+	}
+}
+
+func (enc *cborEncoder) AppendByteString(val []byte) {
+	enc.addElement()
+	enc.appendTextBytes(val)
+}
+
+// appendComplex appends a complex number as a two-element array.
+func (enc *cborEncoder) appendComplex(val complex128, bitSize int) {
+	enc.addElement()
+	enc.bs = append(enc.bs, _cborArray|2)
+	enc.appendRawFloat(real(val), bitSize)
+	enc.appendRawFloat(imag(val), bitSize)
+}
+
+func (enc *cborEncoder) AppendDuration(val time.Duration) {
+	cur := len(enc.bs)
+	if e := enc.EncodeDuration; e != nil {
+		e(val, enc)
+	}
+	if cur == len(enc.bs) {
+		// User-supplied EncodeDuration is a no-op. Fall back to nanoseconds to
+		// keep the map well-formed.
+		enc.AppendInt64(int64(val))
+	}
+}
+
+func (enc *cborEncoder) AppendInt64(val int64) {
+	enc.addElement()
+	if val < 0 {
+		enc.bs = appendCBORHead(enc.bs, _cborNegInt, uint64(-1-val))
+	} else {
+		enc.bs = appendCBORHead(enc.bs, _cborUint, uint64(val))
+	}
+}
+
+func (enc *cborEncoder) AppendReflected(val interface{}) error {
+	valueBytes, err := enc.encodeReflected(val)
+	if err != nil {
+		return err
+	}
+	enc.addElement()
+	enc.appendTextBytes(valueBytes)
+	return nil
+}
+
+func (enc *cborEncoder) AppendString(val string) {
+	enc.addElement()
+	enc.appendText(val)
+}
+
+func (enc *cborEncoder) AppendTimeLayout(time time.Time, layout string) {
+	enc.addElement()
+	enc.appendText(time.Format(layout))
+}
+
+func (enc *cborEncoder) AppendTime(val time.Time) {
+	cur := len(enc.bs)
+	if e := enc.EncodeTime; e != nil {
+		e(val, enc)
+	}
+	if cur == len(enc.bs) {
+		// User-supplied EncodeTime is a no-op. Fall back to nanos since epoch
+		// to keep the map well-formed.
+		enc.AppendInt64(val.UnixNano())
+	}
+}
+
+func (enc *cborEncoder) AppendUint64(val uint64) {
+	enc.addElement()
+	enc.bs = appendCBORHead(enc.bs, _cborUint, val)
+}
+
+func (enc *cborEncoder) AddInt(k string, v int)         { enc.AddInt64(k, int64(v)) }
+func (enc *cborEncoder) AddInt32(k string, v int32)     { enc.AddInt64(k, int64(v)) }
+func (enc *cborEncoder) AddInt16(k string, v int16)     { enc.AddInt64(k, int64(v)) }
+func (enc *cborEncoder) AddInt8(k string, v int8)       { enc.AddInt64(k, int64(v)) }
+func (enc *cborEncoder) AddUint(k string, v uint)       { enc.AddUint64(k, uint64(v)) }
+func (enc *cborEncoder) AddUint32(k string, v uint32)   { enc.AddUint64(k, uint64(v)) }
+func (enc *cborEncoder) AddUint16(k string, v uint16)   { enc.AddUint64(k, uint64(v)) }
+func (enc *cborEncoder) AddUint8(k string, v uint8)     { enc.AddUint64(k, uint64(v)) }
+func (enc *cborEncoder) AddUintptr(k string, v uintptr) { enc.AddUint64(k, uint64(v)) }
+func (enc *cborEncoder) AppendComplex64(v complex64)    { enc.appendComplex(complex128(v), 32) }
+func (enc *cborEncoder) AppendComplex128(v complex128)  { enc.appendComplex(v, 64) }
+func (enc *cborEncoder) AppendFloat64(v float64)        { enc.appendFloat(v, 64) }
+func (enc *cborEncoder) AppendFloat32(v float32)        { enc.appendFloat(float64(v), 32) }
+func (enc *cborEncoder) AppendInt(v int)                { enc.AppendInt64(int64(v)) }
+func (enc *cborEncoder) AppendInt32(v int32)            { enc.AppendInt64(int64(v)) }
+func (enc *cborEncoder) AppendInt16(v int16)            { enc.AppendInt64(int64(v)) }
+func (enc *cborEncoder) AppendInt8(v int8)              { enc.AppendInt64(int64(v)) }
+func (enc *cborEncoder) AppendUint(v uint)              { enc.AppendUint64(uint64(v)) }
+func (enc *cborEncoder) AppendUint32(v uint32)          { enc.AppendUint64(uint64(v)) }
+func (enc *cborEncoder) AppendUint16(v uint16)          { enc.AppendUint64(uint64(v)) }
+func (enc *cborEncoder) AppendUint8(v uint8)            { enc.AppendUint64(uint64(v)) }
+func (enc *cborEncoder) AppendUintptr(v uintptr)        { enc.AppendUint64(uint64(v)) }
+
+func (enc *cborEncoder) Clone() Encoder {
+	clone := enc.clone()
+	clone.appendContext(enc)
+	return clone
+}
+
+// clone returns an empty encoder with the same configuration.
+func (enc *cborEncoder) clone() *cborEncoder {
+	clone := _cborPool.Get()
+	clone.EncoderConfig = enc.EncoderConfig
+	clone.pushFrame(false /* isArray */)
+	return clone
+}
+
+// appendContext copies the fields added to src into enc, including any
+// namespaces that src left open.
+func (enc *cborEncoder) appendContext(src *cborEncoder) {
+	offset := len(enc.bs)
+	enc.bs = append(enc.bs, src.bs...)
+	for i, f := range src.frames {
+		if i > 0 {
+			enc.pushFrame(f.isArray)
+		}
+		top := &enc.frames[len(enc.frames)-1]
+		if i > 0 {
+			top.start = f.start + offset
+		}
+		top.n += f.n
+		for _, k := range f.keys {
+			top.keys = append(top.keys, k+offset)
+		}
+	}
+	enc.openNamespaces += src.openNamespaces
+}
+
+func (enc *cborEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer, error) {
+	final := enc.clone()
+
+	if final.LevelKey != "" && final.EncodeLevel != nil {
+		final.addEntryKey(final.LevelKey)
+		cur := len(final.bs)
+		final.EncodeLevel(ent.Level, final)
+		if cur == len(final.bs) {
+			// User-supplied EncodeLevel was a no-op. Fall back to strings to
+			// keep the map well-formed.
+			final.AppendString(ent.Level.String())
+		}
+	}
+	if final.TimeKey != "" && !ent.Time.IsZero() {
+		final.addEntryKey(final.TimeKey)
+		final.AppendTime(ent.Time)
+	}
+	if ent.LoggerName != "" && final.NameKey != "" {
+		final.addEntryKey(final.NameKey)
+		cur := len(final.bs)
+		nameEncoder := final.EncodeName
+
+		// if no name encoder provided, fall back to FullNameEncoder for backwards
+		// compatibility
+		if nameEncoder == nil {
+			nameEncoder = FullNameEncoder
+		}
+
+		nameEncoder(ent.LoggerName, final)
+		if cur == len(final.bs) {
+			// User-supplied EncodeName was a no-op. Fall back to strings to
+			// keep the map well-formed.
+			final.AppendString(ent.LoggerName)
+		}
+	}
+	if ent.Caller.Defined {
+		if final.CallerKey != "" {
+			final.addEntryKey(final.CallerKey)
+			cur := len(final.bs)
+			final.EncodeCaller(ent.Caller, final)
+			if cur == len(final.bs) {
+				// User-supplied EncodeCaller was a no-op. Fall back to strings
+				// to keep the map well-formed.
+				final.AppendString(ent.Caller.String())
+			}
+		}
+		if final.FunctionKey != "" {
+			final.addEntryKey(final.FunctionKey)
+			final.AppendString(ent.Caller.Function)
+		}
+	}
+	if ent.EventID != "" && final.EventIDKey != "" {
+		final.addEntryKey(final.EventIDKey)
+		final.AppendString(ent.EventID)
+	}
+	if final.MessageKey != "" {
+		final.addEntryKey(final.MessageKey)
+		final.AppendString(ent.Message)
+	}
+	final.appendContext(enc)
+	addFields(final, fields)
+	final.closeOpenNamespaces()
+	if ent.Stack != "" && final.StacktraceKey != "" {
+		// Stack traces aren't subject to MaxStringLength.
+		final.addEntryKey(final.StacktraceKey)
+		final.AppendString(ent.Stack)
+	}
+	final.closeFrame()
+
+	ret := bufferpool.Get()
+	ret.AppendBytes(final.bs)
+	putCBOREncoder(final)
+	return ret, nil
+}
+
+func (enc *cborEncoder) closeOpenNamespaces() {
+	for i := 0; i < enc.openNamespaces; i++ {
+		enc.closeFrame()
+	}
+	enc.openNamespaces = 0
+}
+
+func (enc *cborEncoder) resetReflectBuf() {
+	if enc.reflectBuf == nil {
+		enc.reflectBuf = bufferpool.Get()
+		enc.reflectEnc = enc.NewReflectedEncoder(enc.reflectBuf)
+	} else {
+		enc.reflectBuf.Reset()
+	}
+}
+
+func (enc *cborEncoder) encodeReflected(obj interface{}) ([]byte, error) {
+	if obj == nil {
+		return nullLiteralBytes, nil
+	}
+	enc.resetReflectBuf()
+	if err := enc.reflectEnc.Encode(obj); err != nil {
+		return nil, err
+	}
+	enc.reflectBuf.TrimNewline()
+	return enc.reflectBuf.Bytes(), nil
+}
+
+// addKey starts a new entry in the innermost map.
+func (enc *cborEncoder) addKey(key string) {
+	enc.addEntryKey(enc.encodeKey(key))
+}
+
+// addEntryKey adds a key configured in the EncoderConfig, which isn't
+// canonicalized.
+func (enc *cborEncoder) addEntryKey(key string) {
+	top := &enc.frames[len(enc.frames)-1]
+	top.keys = append(top.keys, len(enc.bs))
+	enc.appendText(key)
+}
+
+// addElement accounts for a new value in the innermost array, if any. Values
+// in maps are accounted for by their keys.
+func (enc *cborEncoder) addElement() {
+	if top := &enc.frames[len(enc.frames)-1]; top.isArray {
+		top.n++
+	}
+}
+
+func (enc *cborEncoder) pushFrame(isArray bool) {
+	var keys []int
+	if n := len(enc.frames); n < cap(enc.frames) {
+		// Reuse the keys slice left behind by an earlier frame.
+		keys = enc.frames[:n+1][n].keys[:0]
+	}
+	enc.frames = append(enc.frames, cborFrame{
+		start:   len(enc.bs),
+		isArray: isArray,
+		keys:    keys,
+	})
+}
+
+// closeFrame writes the innermost container's header. Map entries are
+// sorted by their encoded keys, as required for deterministic encoding.
+func (enc *cborEncoder) closeFrame() {
+	f := &enc.frames[len(enc.frames)-1]
+	enc.frames = enc.frames[:len(enc.frames)-1]
+
+	out := enc.scratch[:0]
+	if f.isArray {
+		out = appendCBORHead(out, _cborArray, uint64(f.n))
+		out = append(out, enc.bs[f.start:]...)
+	} else {
+		out = appendCBORHead(out, _cborMap, uint64(len(f.keys)))
+		spans := enc.spans[:0]
+		for i, k := range f.keys {
+			end := len(enc.bs)
+			if i+1 < len(f.keys) {
+				end = f.keys[i+1]
+			}
+			spans = append(spans, [2]int{k, end})
+		}
+		sort.SliceStable(spans, func(i, j int) bool {
+			return bytes.Compare(cborItem(enc.bs[spans[i][0]:]), cborItem(enc.bs[spans[j][0]:])) < 0
+		})
+		for _, s := range spans {
+			out = append(out, enc.bs[s[0]:s[1]]...)
+		}
+		enc.spans = spans
+	}
+	enc.bs = append(enc.bs[:f.start], out...)
+	enc.scratch = out
+}
+
+// appendText appends a CBOR text string, replacing or escaping invalid
+// UTF-8 as configured.
+func (enc *cborEncoder) appendText(s string) {
+	s = enc.invalidUTF8().validText(s)
+	enc.bs = appendCBORHead(enc.bs, _cborText, uint64(len(s)))
+	enc.bs = append(enc.bs, s...)
+}
+
+// appendTextBytes is the []byte equivalent of appendText.
+func (enc *cborEncoder) appendTextBytes(s []byte) {
+	s = enc.invalidUTF8().validBytes(s)
+	enc.bs = appendCBORHead(enc.bs, _cborText, uint64(len(s)))
+	enc.bs = append(enc.bs, s...)
+}
+
+func (enc *cborEncoder) appendFloat(val float64, bitSize int) {
+	enc.addElement()
+	enc.appendRawFloat(val, bitSize)
+}
+
+// appendRawFloat appends a float in the shortest form that preserves its
+// value.
+func (enc *cborEncoder) appendRawFloat(val float64, bitSize int) {
+	switch {
+	case math.IsNaN(val):
+		enc.bs = append(enc.bs, _cborFloat16, 0x7e, 0x00)
+	case bitSize == 32 || float64(float32(val)) == val:
+		if h, ok := float16Bits(float32(val)); ok {
+			enc.bs = binary.BigEndian.AppendUint16(append(enc.bs, _cborFloat16), h)
+		} else {
+			enc.bs = binary.BigEndian.AppendUint32(append(enc.bs, _cborFloat32), math.Float32bits(float32(val)))
+		}
+	default:
+		enc.bs = binary.BigEndian.AppendUint64(append(enc.bs, _cborFloat64), math.Float64bits(val))
+	}
+}
+
+// appendCBORHead appends the initial bytes of a data item with the given
+// major type and argument.
+func appendCBORHead(bs []byte, major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return append(bs, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(bs, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(bs, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(bs, major|26), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(bs, major|27), n)
+	}
+}
+
+// cborItem returns the string data item (a map key) at the start of bs.
+func cborItem(bs []byte) []byte {
+	var head, n int
+	switch info := bs[0] & 0x1f; {
+	case info < 24:
+		head, n = 1, int(info)
+	case info == 24:
+		head, n = 2, int(bs[1])
+	case info == 25:
+		head, n = 3, int(binary.BigEndian.Uint16(bs[1:]))
+	case info == 26:
+		head, n = 5, int(binary.BigEndian.Uint32(bs[1:]))
+	default:
+		head, n = 9, int(binary.BigEndian.Uint64(bs[1:]))
+	}
+	return bs[:head+n]
+}
+
+// float16Bits returns the IEEE 754 half-precision representation of f, if f
+// can be represented exactly.
+func float16Bits(f float32) (uint16, bool) {
+	bits := math.Float32bits(f)
+	sign := uint16(bits>>16) & 0x8000
+	exp := int(bits>>23) & 0xff
+	mant := bits & 0x7fffff
+
+	switch {
+	case exp == 0xff: // infinities; NaNs are handled by the caller
+		return sign | 0x7c00, mant == 0
+	case exp == 0: // zero, or a float32 subnormal too small for float16
+		return sign, mant == 0
+	}
+
+	switch e := exp - 127; {
+	case e >= -14 && e <= 15: // float16 normal
+		if mant&0x1fff != 0 {
+			return 0, false
+		}
+		return sign | uint16(e+15)<<10 | uint16(mant>>13), true
+	case e >= -24 && e < -14: // float16 subnormal
+		full := mant | 0x800000
+		shift := uint(-e - 1)
+		if full&(1<<shift-1) != 0 {
+			return 0, false
+		}
+		return sign | uint16(full>>shift), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+)
+
+func TestCBOREncodeEntry(t *testing.T) {
+	enc := NewCBOREncoder(testEncoderConfig())
+	enc.AddString("ctx", "bound")
+	enc.OpenNamespace("ns")
+
+	buf, err := enc.EncodeEntry(_testEntry, []Field{
+		{Key: "int", Type: Int64Type, Integer: -42},
+		{Key: "bin", Type: BinaryType, Interface: []byte{1, 2}},
+	})
+	require.NoError(t, err, "Unexpected encoding error.")
+	defer buf.Free()
+
+	got := decodeCBOR(t, buf.Bytes())
+	assert.Equal(t, map[string]interface{}{
+		"level":      "info",
+		"ts":         float64(0),
+		"name":       "main",
+		"caller":     "foo.go:42",
+		"func":       "foo.Foo",
+		"msg":        "hello",
+		"ctx":        "bound",
+		"ns":         map[string]interface{}{"int": int64(-42), "bin": []byte{1, 2}},
+		"stacktrace": "fake-stack",
+	}, got, "Unexpected decoded entry.")
+}
+
+func TestCBOREncodeEntryEventID(t *testing.T) {
+	cfg := testEncoderConfig()
+	cfg.EventIDKey = "event"
+	ent := _testEntry
+	ent.EventID = "E1001"
+
+	buf, err := NewCBOREncoder(cfg).EncodeEntry(ent, nil)
+	require.NoError(t, err, "Unexpected encoding error.")
+	defer buf.Free()
+
+	got := decodeCBOR(t, buf.Bytes()).(map[string]interface{})
+	assert.Equal(t, "E1001", got["event"], "Unexpected event ID.")
+}
+
+func TestCBOREncoderTypes(t *testing.T) {
+	tests := []struct {
+		desc string
+		f    func(ObjectEncoder)
+		want interface{}
+	}{
+		{"uint", func(e ObjectEncoder) { e.AddUint64("k", 1<<40) }, uint64(1 << 40)},
+		{"negative int", func(e ObjectEncoder) { e.AddInt8("k", -1) }, int64(-1)},
+		{"bool", func(e ObjectEncoder) { e.AddBool("k", true) }, true},
+		{"float16", func(e ObjectEncoder) { e.AddFloat64("k", 1.5) }, float64(1.5)},
+		{"float32", func(e ObjectEncoder) { e.AddFloat32("k", 3.14) }, float64(float32(3.14))},
+		{"float64", func(e ObjectEncoder) { e.AddFloat64("k", 3.14) }, 3.14},
+		{"subnormal float16", func(e ObjectEncoder) { e.AddFloat64("k", math.Ldexp(1, -24)) }, math.Ldexp(1, -24)},
+		{"inf", func(e ObjectEncoder) { e.AddFloat64("k", math.Inf(-1)) }, math.Inf(-1)},
+		{"complex", func(e ObjectEncoder) { e.AddComplex128("k", 1+2i) }, []interface{}{float64(1), float64(2)}},
+		{"duration", func(e ObjectEncoder) { e.AddDuration("k", time.Second) }, float64(1)},
+		{"invalid utf8", func(e ObjectEncoder) { e.AddString("k", "a\xffb") }, "a�b"},
+		{"byte string", func(e ObjectEncoder) { e.AddByteString("k", []byte("foo")) }, "foo"},
+		{"reflected", func(e ObjectEncoder) { _ = e.AddReflected("k", []int{1}) }, "[1]"},
+		{
+			"array",
+			func(e ObjectEncoder) {
+				_ = e.AddArray("k", ArrayMarshalerFunc(func(arr ArrayEncoder) error {
+					arr.AppendString("a")
+					arr.AppendInt(1)
+					return arr.AppendObject(ObjectMarshalerFunc(func(o ObjectEncoder) error {
+						o.OpenNamespace("inner")
+						o.AddBool("b", false)
+						return nil
+					}))
+				}))
+			},
+			[]interface{}{"a", uint64(1), map[string]interface{}{"inner": map[string]interface{}{"b": false}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			enc := NewCBOREncoder(EncoderConfig{EncodeDuration: SecondsDurationEncoder})
+			tt.f(enc.(ObjectEncoder))
+			buf, err := enc.EncodeEntry(Entry{}, nil)
+			require.NoError(t, err, "Unexpected encoding error.")
+			defer buf.Free()
+			assert.Equal(t, map[string]interface{}{"k": tt.want}, decodeCBOR(t, buf.Bytes()))
+		})
+	}
+}
+
+func TestCBOREncoderCanonical(t *testing.T) {
+	enc := NewCBOREncoder(EncoderConfig{})
+	fields := []Field{
+		{Key: "bb", Type: BoolType, Integer: 1},
+		{Key: "a", Type: BoolType, Integer: 1},
+		{Key: "c", Type: BoolType, Integer: 0},
+	}
+	buf, err := enc.EncodeEntry(Entry{}, fields)
+	require.NoError(t, err, "Unexpected encoding error.")
+	defer buf.Free()
+
+	// Keys must be sorted by their encoded form, which sorts shorter keys
+	// first.
+	want := []byte{0xa3, 0x61, 'a', 0xf5, 0x61, 'c', 0xf4, 0x62, 'b', 'b', 0xf5}
+	assert.Equal(t, want, buf.Bytes(), "Unexpected CBOR bytes.")
+}
+
+func TestCBOREncoderClone(t *testing.T) {
+	parent := NewCBOREncoder(EncoderConfig{MessageKey: "msg"})
+	parent.AddString("parent", "p")
+	child := parent.Clone()
+	child.AddString("child", "c")
+
+	buf, err := parent.EncodeEntry(Entry{Message: "m"}, nil)
+	require.NoError(t, err, "Unexpected encoding error.")
+	assert.Equal(t, map[string]interface{}{"msg": "m", "parent": "p"}, decodeCBOR(t, buf.Bytes()))
+	buf.Free()
+
+	buf, err = child.EncodeEntry(Entry{Message: "m"}, nil)
+	require.NoError(t, err, "Unexpected encoding error.")
+	assert.Equal(t, map[string]interface{}{"msg": "m", "parent": "p", "child": "c"}, decodeCBOR(t, buf.Bytes()))
+	buf.Free()
+}
+
+// decodeCBOR decodes a single data item, verifying that it uses the subset of
+// CBOR emitted by the encoder: definite lengths and sorted map keys.
+func decodeCBOR(t testing.TB, bs []byte) interface{} {
+	v, rest, err := decodeCBORItem(bs)
+	require.NoError(t, err, "Failed to decode CBOR.")
+	require.Empty(t, rest, "Unexpected trailing bytes.")
+	return v
+}
+
+func decodeCBORItem(bs []byte) (interface{}, []byte, error) {
+	if len(bs) == 0 {
+		return nil, nil, errors.New("unexpected end of input")
+	}
+	major, info := bs[0]>>5, bs[0]&0x1f
+	bs = bs[1:]
+	var n uint64
+	switch {
+	case info < 24:
+		n = uint64(info)
+	case info == 24:
+		n, bs = uint64(bs[0]), bs[1:]
+	case info == 25:
+		n, bs = uint64(binary.BigEndian.Uint16(bs)), bs[2:]
+	case info == 26:
+		n, bs = uint64(binary.BigEndian.Uint32(bs)), bs[4:]
+	case info == 27:
+		n, bs = binary.BigEndian.Uint64(bs), bs[8:]
+	default:
+		return nil, nil, errors.New("indefinite lengths aren't expected")
+	}
+
+	switch major {
+	case 0:
+		return n, bs, nil
+	case 1:
+		return -1 - int64(n), bs, nil
+	case 2:
+		return append([]byte{}, bs[:n]...), bs[n:], nil
+	case 3:
+		return string(bs[:n]), bs[n:], nil
+	case 4:
+		arr := []interface{}{}
+		for i := uint64(0); i < n; i++ {
+			v, rest, err := decodeCBORItem(bs)
+			if err != nil {
+				return nil, nil, err
+			}
+			arr = append(arr, v)
+			bs = rest
+		}
+		return arr, bs, nil
+	case 5:
+		m := map[string]interface{}{}
+		var lastKey []byte
+		for i := uint64(0); i < n; i++ {
+			k, rest, err := decodeCBORItem(bs)
+			if err != nil {
+				return nil, nil, err
+			}
+			encodedKey := bs[:len(bs)-len(rest)]
+			if lastKey != nil && bytes.Compare(lastKey, encodedKey) >= 0 {
+				return nil, nil, errors.New("map keys aren't sorted")
+			}
+			lastKey = encodedKey
+			v, rest, err := decodeCBORItem(rest)
+			if err != nil {
+				return nil, nil, err
+			}
+			m[k.(string)] = v
+			bs = rest
+		}
+		return m, bs, nil
+	case 7:
+		switch info {
+		case 20:
+			return false, bs, nil
+		case 21:
+			return true, bs, nil
+		case 22:
+			return nil, bs, nil
+		case 25:
+			return float16ToFloat64(uint16(n)), bs, nil
+		case 26:
+			return float64(math.Float32frombits(uint32(n))), bs, nil
+		case 27:
+			return math.Float64frombits(n), bs, nil
+		}
+	}
+	return nil, nil, errors.New("unsupported data item")
+}
+
+func float16ToFloat64(h uint16) float64 {
+	exp, mant := int(h>>10)&0x1f, float64(h&0x3ff)
+	var v float64
+	switch exp {
+	case 0:
+		v = math.Ldexp(mant, -24)
+	case 0x1f:
+		v = math.Inf(1)
+		if mant != 0 {
+			v = math.NaN()
+		}
+	default:
+		v = math.Ldexp(mant+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		v = -v
+	}
+	return v
+}
@@ -20,7 +20,11 @@
 
 package zapcore
 
-import "time"
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
 // DefaultClock is the default clock used by Zap in operations that require
 // time. This clock uses the system clock for all operations.
@@ -46,3 +50,129 @@ func (systemClock) Now() time.Time {
 func (systemClock) NewTicker(duration time.Duration) *time.Ticker {
 	return time.NewTicker(duration)
 }
+
+// A CoarseClock is a Clock that reads the system time on a background ticker
+// rather than on every call to Now, trading timestamp resolution for speed.
+// It suits very hot loggers, for which time.Now can dominate the cost of
+// logging.
+//
+// Times it reports never go backwards and carry monotonic clock readings, but
+// lag the system clock by up to the clock's resolution. Stop the clock when
+// it's no longer needed to release its goroutine.
+type CoarseClock struct {
+	now  atomic.Pointer[time.Time]
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+var _ Clock = (*CoarseClock)(nil)
+
+// NewCoarseClock starts a CoarseClock that updates its time once per
+// resolution. Resolutions of zero or less default to a millisecond.
+func NewCoarseClock(resolution time.Duration) *CoarseClock {
+	if resolution <= 0 {
+		resolution = time.Millisecond
+	}
+	c := &CoarseClock{
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	now := time.Now()
+	c.now.Store(&now)
+	go c.run(resolution)
+	return c
+}
+
+func (c *CoarseClock) run(resolution time.Duration) {
+	defer close(c.done)
+
+	ticker := time.NewTicker(resolution)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			now := time.Now()
+			c.now.Store(&now)
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// Now returns the time as of the clock's last update. Once the clock is
+// stopped, it returns time.Now.
+func (c *CoarseClock) Now() time.Time {
+	if now := c.now.Load(); now != nil {
+		return *now
+	}
+	return time.Now()
+}
+
+// NewTicker returns a ticker using the system clock.
+func (c *CoarseClock) NewTicker(d time.Duration) *time.Ticker {
+	return time.NewTicker(d)
+}
+
+// Stop stops updating the clock and waits for its goroutine to exit. It's
+// safe to call more than once.
+func (c *CoarseClock) Stop() {
+	c.once.Do(func() {
+		close(c.stop)
+		<-c.done
+		c.now.Store(nil)
+	})
+}
+
+type clockCore struct {
+	Core
+
+	clock Clock
+}
+
+var (
+	_ Core           = (*clockCore)(nil)
+	_ leveledEnabler = (*clockCore)(nil)
+	_ arenaWriter    = (*clockCore)(nil)
+)
+
+// NewClockCore wraps a Core so that entries written to it are stamped with
+// the time read from clock, regardless of the time the Logger assigned them.
+// Give each core of a tee its own clock to make tests of multi-core setups
+// deterministic.
+//
+// The clock is read when each entry is written. Whether an entry is written
+// depends on the wrapped Core's Enabled method only: its Check method isn't
+// called, so Cores that decide in Check, like samplers, should be wrapped
+// around the clock core instead.
+func NewClockCore(core Core, clock Clock) Core {
+	return &clockCore{Core: core, clock: clock}
+}
+
+func (c *clockCore) Level() Level {
+	return LevelOf(c.Core)
+}
+
+func (c *clockCore) With(fields []Field) Core {
+	return &clockCore{Core: c.Core.With(fields), clock: c.clock}
+}
+
+func (c *clockCore) AccumulatedFields() []Field {
+	return AccumulatedFields(c.Core)
+}
+
+func (c *clockCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *clockCore) Write(ent Entry, fields []Field) error {
+	return c.writeArena(ent, fields, nil)
+}
+
+func (c *clockCore) writeArena(ent Entry, fields []Field, arena *entryArena) error {
+	ent.Time = c.clock.Now()
+	return writeArena(c.Core, ent, fields, arena)
+}
@@ -24,6 +24,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/internal/ztest"
 )
 
@@ -42,3 +44,55 @@ func TestSystemClock_NewTicker(t *testing.T) {
 		}
 	}
 }
+
+func TestCoarseClock(t *testing.T) {
+	clock := NewCoarseClock(time.Millisecond)
+	defer clock.Stop()
+
+	first := clock.Now()
+	assert.False(t, first.IsZero(), "Expected the clock to start with the current time.")
+	assert.WithinDuration(t, time.Now(), first, time.Second, "Unexpected initial time.")
+	assert.Eventually(t, func() bool {
+		return clock.Now().After(first)
+	}, time.Second, time.Millisecond, "Expected the clock to advance.")
+
+	prev := clock.Now()
+	for i := 0; i < 100; i++ {
+		now := clock.Now()
+		assert.False(t, now.Before(prev), "Clock went backwards.")
+		prev = now
+	}
+
+	clock.Stop()
+	clock.Stop() // idempotent
+	before := time.Now()
+	assert.False(t, clock.Now().Before(before), "Expected stopped clocks to fall back to time.Now.")
+}
+
+func TestCoarseClockDefaultResolution(t *testing.T) {
+	clock := NewCoarseClock(0)
+	defer clock.Stop()
+
+	ticker := clock.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+	<-ticker.C
+}
+
+func TestClockCore(t *testing.T) {
+	clock := ztest.NewMockClock()
+	clock.Add(time.Hour)
+	buf := &ztest.Buffer{}
+	enc := NewJSONEncoder(EncoderConfig{MessageKey: "msg", TimeKey: "ts", EncodeTime: RFC3339TimeEncoder})
+	core := NewClockCore(NewFieldTrackingCore(NewCore(enc, buf, InfoLevel)), clock).With([]Field{{Key: "k", Type: StringType, String: "v"}})
+
+	assert.Equal(t, InfoLevel, LevelOf(core), "Unexpected level.")
+	assert.Equal(t, []Field{{Key: "k", Type: StringType, String: "v"}}, AccumulatedFields(core), "Unexpected accumulated fields.")
+	assert.Nil(t, core.Check(Entry{Level: DebugLevel}, nil), "Expected disabled entries to be dropped.")
+
+	ce := core.Check(Entry{Level: InfoLevel, Time: time.Unix(42, 0), Message: "hello"}, nil)
+	require.NotNil(t, ce, "Expected the entry to be enabled.")
+	ce.Write()
+
+	want := `{"ts":"` + clock.Now().Format(time.RFC3339) + `","msg":"hello","k":"v"}`
+	assert.Equal(t, []string{want}, buf.Lines(), "Expected the core's clock to stamp the entry.")
+}
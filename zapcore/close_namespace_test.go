@@ -0,0 +1,160 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func closeNamespaceFields() []Field {
+	return []Field{
+		{Key: "outer", Type: NamespaceType},
+		{Key: "a", Type: Int64Type, Integer: 1},
+		{Key: "inner", Type: NamespaceType},
+		{Key: "b", Type: Int64Type, Integer: 2},
+		{Type: CloseNamespaceType},
+		{Key: "c", Type: Int64Type, Integer: 3},
+		{Type: CloseNamespaceType},
+		{Key: "d", Type: Int64Type, Integer: 4},
+		// Closing without an open namespace is a no-op.
+		{Type: CloseNamespaceType},
+		{Key: "e", Type: Int64Type, Integer: 5},
+	}
+}
+
+func TestCloseNamespaceJSON(t *testing.T) {
+	cfg := testEncoderConfig()
+	cfg.TimeKey = ""
+	cfg.LevelKey = ""
+	cfg.SkipLineEnding = true
+	enc := NewJSONEncoder(cfg)
+
+	buf, err := enc.EncodeEntry(Entry{Message: "m"}, closeNamespaceFields())
+	require.NoError(t, err)
+	defer buf.Free()
+	assert.Equal(t, `{"msg":"m","outer":{"a":1,"inner":{"b":2},"c":3},"d":4,"e":5}`, buf.String())
+}
+
+func TestCloseNamespaceInsideObject(t *testing.T) {
+	cfg := testEncoderConfig()
+	cfg.SkipLineEnding = true
+	enc := NewJSONEncoder(cfg)
+	enc.OpenNamespace("ns")
+	require.NoError(t, enc.AddObject("obj", ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+		// Objects can only close namespaces they opened themselves.
+		Field{Type: CloseNamespaceType}.AddTo(enc)
+		enc.AddInt("a", 1)
+		return nil
+	})))
+	enc.AddInt("b", 2)
+
+	buf, err := enc.EncodeEntry(Entry{}, nil)
+	require.NoError(t, err)
+	defer buf.Free()
+	assert.Equal(t, `{"level":"info","msg":"","ns":{"obj":{"a":1},"b":2}}`, buf.String())
+}
+
+func TestCloseNamespaceMapObjectEncoder(t *testing.T) {
+	enc := NewMapObjectEncoder()
+	for _, f := range closeNamespaceFields() {
+		f.AddTo(enc)
+	}
+	assert.Equal(t, map[string]interface{}{
+		"outer": map[string]interface{}{
+			"a":     int64(1),
+			"inner": map[string]interface{}{"b": int64(2)},
+			"c":     int64(3),
+		},
+		"d": int64(4),
+		"e": int64(5),
+	}, enc.Fields)
+}
+
+func TestCloseNamespaceCBOR(t *testing.T) {
+	enc := NewCBOREncoder(EncoderConfig{MessageKey: "msg"})
+	buf, err := enc.EncodeEntry(Entry{Message: "m"}, closeNamespaceFields())
+	require.NoError(t, err)
+	defer buf.Free()
+	assert.Equal(t, map[string]interface{}{
+		"msg": "m",
+		"outer": map[string]interface{}{
+			"a":     uint64(1),
+			"inner": map[string]interface{}{"b": uint64(2)},
+			"c":     uint64(3),
+		},
+		"d": uint64(4),
+		"e": uint64(5),
+	}, decodeCBOR(t, buf.Bytes()))
+}
+
+func TestCloseNamespaceGELF(t *testing.T) {
+	enc := NewGELFEncoder(testEncoderConfig(), "host")
+	enc.OpenNamespace("ctx")
+	clone := enc.Clone()
+	clone.OpenNamespace("req")
+
+	got := decodeGELF(t, clone, Entry{}, closeNamespaceFields())
+	for _, key := range []string{"_ctx.req.outer.a", "_ctx.req.outer.inner.b", "_ctx.req.outer.c", "_ctx.req.d", "_ctx.e"} {
+		assert.Contains(t, got, key)
+	}
+
+	got = decodeGELF(t, enc, Entry{}, []Field{{Key: "a", Type: StringType, String: "b"}})
+	assert.Equal(t, "b", got["_ctx.a"], "Clone modified the original encoder.")
+}
+
+func TestCloseNamespaceECS(t *testing.T) {
+	enc := NewECSEncoder(ecsTestEncoderConfig())
+	got := decodeECS(t, enc, Entry{}, closeNamespaceFields()...)
+	for _, key := range []string{"outer.a", "outer.inner.b", "outer.c", "d", "e"} {
+		assert.Contains(t, got, key)
+	}
+}
+
+func TestDuplicateKeyCoreCloseNamespace(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+	core := NewDuplicateKeyCore(obs, DuplicateKeysLastWins)
+
+	fields := []Field{
+		{Key: "req", Type: NamespaceType},
+		{Key: "id", Type: Int64Type, Integer: 1},
+		{Type: CloseNamespaceType},
+		{Key: "id", Type: Int64Type, Integer: 2},
+		{Key: "req", Type: StringType, String: "dup"},
+	}
+	require.NoError(t, core.Write(Entry{}, fields))
+
+	// Keys after the namespace closes are back in the parent scope, and a
+	// key colliding with a namespace is dropped rather than the namespace.
+	assert.Equal(t, []Field{
+		{Key: "req", Type: NamespaceType},
+		{Key: "id", Type: Int64Type, Integer: 1},
+		{Type: CloseNamespaceType},
+		{Key: "id", Type: Int64Type, Integer: 2},
+		{Type: SkipType},
+	}, logs.AllUntimed()[0].Context)
+}
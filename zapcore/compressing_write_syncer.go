@@ -0,0 +1,247 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+const (
+	_defaultCompressionFlushSize     = 64 * 1024 // 64 kB
+	_defaultCompressionFlushInterval = time.Second
+)
+
+var errCompressionStopped = errors.New("compressing WriteSyncer is stopped")
+
+// A CompressedWriter compresses the data written to it. Flush writes out
+// everything written so far such that a reader can decompress it, and Close
+// ends the compressed stream. *gzip.Writer and the encoders of most zstd
+// packages are CompressedWriters.
+type CompressedWriter interface {
+	io.WriteCloser
+	Flush() error
+}
+
+// A CompressionCodec creates the CompressedWriters used by
+// NewCompressingWriteSyncer.
+type CompressionCodec interface {
+	NewWriter(io.Writer) (CompressedWriter, error)
+}
+
+// CompressionCodecFunc is a function that implements CompressionCodec. For
+// example, with github.com/klauspost/compress/zstd:
+//
+//	codec := zapcore.CompressionCodecFunc(func(w io.Writer) (zapcore.CompressedWriter, error) {
+//	  return zstd.NewWriter(w)
+//	})
+type CompressionCodecFunc func(io.Writer) (CompressedWriter, error)
+
+// NewWriter calls f.
+func (f CompressionCodecFunc) NewWriter(w io.Writer) (CompressedWriter, error) {
+	return f(w)
+}
+
+// GzipCodec returns a CompressionCodec that writes a gzip stream compressed
+// at the given level, such as gzip.DefaultCompression or gzip.BestSpeed.
+func GzipCodec(level int) CompressionCodec {
+	return CompressionCodecFunc(func(w io.Writer) (CompressedWriter, error) {
+		return gzip.NewWriterLevel(w, level)
+	})
+}
+
+// CompressionOptions configures a WriteSyncer built with
+// NewCompressingWriteSyncer.
+type CompressionOptions struct {
+	// FlushSize is the amount of uncompressed data after which the
+	// compressed stream is flushed, making everything written so far
+	// readable. Smaller values let readers follow the stream more closely,
+	// at the cost of compressing less well.
+	//
+	// Defaults to 64 kB if unspecified.
+	FlushSize int
+
+	// FlushInterval is how often the compressed stream is flushed if data
+	// has been written since the last flush, so that tools like tail -f
+	// keep up with quiet logs.
+	//
+	// Defaults to 1 second if unspecified.
+	FlushInterval time.Duration
+
+	// Clock, if specified, provides control of the source of time for the
+	// flushes.
+	//
+	// Defaults to the system clock.
+	Clock Clock
+}
+
+// A CompressingWriteSyncer is a WriteSyncer that compresses the data written
+// to it before writing it to a wrapped WriteSyncer. It periodically flushes
+// the compressed stream, so that the data written so far can be read, for
+// example with tail -f and zcat, before the stream ends.
+//
+// CompressingWriteSyncer is safe for concurrent use; writes are serialized,
+// so there's no need to wrap the WriteSyncer with Lock. Call Stop when it's
+// no longer needed to end the compressed stream.
+type CompressingWriteSyncer struct {
+	ws        WriteSyncer
+	flushSize int
+	ticker    *time.Ticker
+
+	mu      sync.Mutex
+	w       CompressedWriter
+	pending int  // bytes written since the last flush
+	stopped bool // whether Stop has run
+
+	stop chan struct{} // closed when flushLoop should stop
+	done chan struct{} // closed when flushLoop has stopped
+}
+
+var _ WriteSyncer = (*CompressingWriteSyncer)(nil)
+
+// NewCompressingWriteSyncer returns a WriteSyncer that compresses the data
+// written to it with codec before writing it to ws. For example, this
+// writes gzip-compressed logs to a file:
+//
+//	f, err := os.Create("app.log.gz")
+//	// ...
+//	ws, err := zapcore.NewCompressingWriteSyncer(f, zapcore.GzipCodec(gzip.BestSpeed), zapcore.CompressionOptions{})
+//	// ...
+//	defer ws.Stop()
+//
+// The compressed stream is flushed between writes, never in the middle of
+// one, so each flush leaves a readable stream of whole log entries.
+func NewCompressingWriteSyncer(ws WriteSyncer, codec CompressionCodec, opts CompressionOptions) (*CompressingWriteSyncer, error) {
+	w, err := codec.NewWriter(ws)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &CompressingWriteSyncer{
+		ws:        ws,
+		flushSize: opts.FlushSize,
+		w:         w,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	if s.flushSize <= 0 {
+		s.flushSize = _defaultCompressionFlushSize
+	}
+	flushInterval := opts.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = _defaultCompressionFlushInterval
+	}
+	clock := opts.Clock
+	if clock == nil {
+		clock = DefaultClock
+	}
+
+	s.ticker = clock.NewTicker(flushInterval)
+	go s.flushLoop()
+	return s, nil
+}
+
+// Write compresses bs, flushing the compressed stream if enough data has
+// been written since the last flush.
+func (s *CompressingWriteSyncer) Write(bs []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		return 0, errCompressionStopped
+	}
+	n, err := s.w.Write(bs)
+	s.pending += n
+	if err == nil && s.pending >= s.flushSize {
+		err = s.flush()
+	}
+	return n, err
+}
+
+// Sync flushes the compressed stream and syncs the wrapped WriteSyncer.
+func (s *CompressingWriteSyncer) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var err error
+	if !s.stopped {
+		err = s.flush()
+	}
+	return multierr.Append(err, s.ws.Sync())
+}
+
+// flush flushes the compressed stream if anything has been written since
+// the last flush. It must be called with s.mu held.
+func (s *CompressingWriteSyncer) flush() error {
+	if s.pending == 0 {
+		return nil
+	}
+	s.pending = 0
+	return s.w.Flush()
+}
+
+// flushLoop flushes the compressed stream at the configured interval until
+// Stop is called.
+func (s *CompressingWriteSyncer) flushLoop() {
+	defer close(s.done)
+
+	for {
+		select {
+		case <-s.ticker.C:
+			s.mu.Lock()
+			if !s.stopped {
+				// Errors are reported by the next Write or Sync,
+				// which fail the same way.
+				_ = s.flush()
+			}
+			s.mu.Unlock()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// Stop ends the compressed stream, cleans up the background goroutine, and
+// syncs the wrapped WriteSyncer. Later writes fail. Stop is safe to call
+// more than once.
+func (s *CompressingWriteSyncer) Stop() error {
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return nil
+	}
+	s.stopped = true
+	s.ticker.Stop()
+	close(s.stop)
+	err := s.w.Close()
+	s.mu.Unlock()
+
+	// Wait for flushLoop to end outside of the lock, as it may be waiting
+	// for the lock.
+	<-s.done
+
+	return multierr.Append(err, s.ws.Sync())
+}
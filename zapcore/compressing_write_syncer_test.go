@@ -0,0 +1,126 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap/internal/ztest"
+	. "go.uber.org/zap/zapcore"
+)
+
+// gunzip decompresses the data written to sink so far, which may not yet
+// hold a complete gzip stream. It reports whether the stream was complete.
+func gunzip(t testing.TB, sink *batchSink) (string, bool) {
+	data := strings.Join(sink.Writes(), "")
+	if data == "" {
+		return "", false
+	}
+	r, err := gzip.NewReader(strings.NewReader(data))
+	require.NoError(t, err, "Invalid gzip header.")
+	out, err := io.ReadAll(r)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return string(out), false
+	}
+	require.NoError(t, err, "Invalid gzip stream.")
+	return string(out), true
+}
+
+func TestCompressingWriteSyncer(t *testing.T) {
+	sink := &batchSink{}
+	ws, err := NewCompressingWriteSyncer(sink, GzipCodec(gzip.BestSpeed), CompressionOptions{
+		FlushInterval: time.Hour,
+	})
+	require.NoError(t, err, "Unexpected error constructing WriteSyncer.")
+
+	n, err := ws.Write([]byte("foo\n"))
+	require.NoError(t, err, "Unexpected write error.")
+	assert.Equal(t, 4, n, "Unexpected number of bytes written.")
+	out, _ := gunzip(t, sink)
+	assert.Empty(t, out, "Expected writes to be held until a flush.")
+
+	require.NoError(t, ws.Sync(), "Unexpected sync error.")
+	out, complete := gunzip(t, sink)
+	assert.Equal(t, "foo\n", out, "Expected Sync to flush the stream.")
+	assert.False(t, complete, "Expected the stream to stay open.")
+
+	_, err = ws.Write([]byte("bar\n"))
+	require.NoError(t, err, "Unexpected write error.")
+	require.NoError(t, ws.Stop(), "Unexpected error stopping WriteSyncer.")
+	out, complete = gunzip(t, sink)
+	assert.Equal(t, "foo\nbar\n", out, "Unexpected output after Stop.")
+	assert.True(t, complete, "Expected Stop to end the stream.")
+
+	_, err = ws.Write([]byte("baz\n"))
+	assert.Error(t, err, "Expected writes after Stop to fail.")
+	assert.NoError(t, ws.Sync(), "Expected Sync after Stop to succeed.")
+	assert.NoError(t, ws.Stop(), "Expected Stop to be idempotent.")
+}
+
+func TestCompressingWriteSyncerFlushSize(t *testing.T) {
+	sink := &batchSink{}
+	ws, err := NewCompressingWriteSyncer(sink, GzipCodec(gzip.DefaultCompression), CompressionOptions{
+		FlushSize:     8,
+		FlushInterval: time.Hour,
+	})
+	require.NoError(t, err, "Unexpected error constructing WriteSyncer.")
+	defer func() { assert.NoError(t, ws.Stop(), "Unexpected error stopping WriteSyncer.") }()
+
+	for _, line := range []string{"one\n", "two\n", "three\n"} {
+		_, err := ws.Write([]byte(line))
+		require.NoError(t, err, "Unexpected write error.")
+	}
+	out, _ := gunzip(t, sink)
+	assert.Equal(t, "one\ntwo\n", out, "Expected a flush once FlushSize bytes were written.")
+}
+
+func TestCompressingWriteSyncerFlushInterval(t *testing.T) {
+	sink := &batchSink{}
+	clock := ztest.NewMockClock()
+	ws, err := NewCompressingWriteSyncer(sink, GzipCodec(gzip.BestSpeed), CompressionOptions{
+		FlushInterval: time.Second,
+		Clock:         clock,
+	})
+	require.NoError(t, err, "Unexpected error constructing WriteSyncer.")
+	defer func() { assert.NoError(t, ws.Stop(), "Unexpected error stopping WriteSyncer.") }()
+
+	_, err = ws.Write([]byte("foo\n"))
+	require.NoError(t, err, "Unexpected write error.")
+	clock.Add(time.Second)
+	assert.Eventually(t, func() bool {
+		out, _ := gunzip(t, sink)
+		return out == "foo\n"
+	}, time.Second, time.Millisecond, "Expected a periodic flush.")
+}
+
+func TestCompressingWriteSyncerCodecError(t *testing.T) {
+	_, err := NewCompressingWriteSyncer(AddSync(&bytes.Buffer{}), GzipCodec(42), CompressionOptions{})
+	assert.Error(t, err, "Expected an invalid compression level to fail.")
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/internal/color"
+)
+
+// ColorMode controls whether the console encoder colors its output with ANSI
+// escape sequences.
+type ColorMode uint8
+
+const (
+	// ColorNever disables colored output. This is the default.
+	ColorNever ColorMode = iota
+	// ColorAlways colors output unconditionally.
+	ColorAlways
+	// ColorAuto colors output if the encoder's core writes to a terminal and
+	// the NO_COLOR environment variable is unset or empty. The sink is found
+	// by NewCore, looking through WriteSyncers that have an
+	// Unwrap() WriteSyncer method; an encoder used without a core, or with
+	// a sink that isn't a single *os.File, doesn't color.
+	ColorAuto
+)
+
+// String returns the mode's name, as accepted by UnmarshalText.
+func (m ColorMode) String() string {
+	switch m {
+	case ColorNever:
+		return "never"
+	case ColorAlways:
+		return "always"
+	case ColorAuto:
+		return "auto"
+	default:
+		return fmt.Sprintf("ColorMode(%d)", m)
+	}
+}
+
+// MarshalText marshals the ColorMode to text.
+func (m ColorMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText unmarshals text to a ColorMode. "always" is unmarshaled to
+// ColorAlways, "auto" to ColorAuto, and "never" or the empty string to
+// ColorNever.
+func (m *ColorMode) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "", "never":
+		*m = ColorNever
+	case "always":
+		*m = ColorAlways
+	case "auto":
+		*m = ColorAuto
+	default:
+		return fmt.Errorf("unrecognized color mode: %q", text)
+	}
+	return nil
+}
+
+// sinkAwareEncoder is implemented by encoders whose output depends on the
+// WriteSyncer they write to. NewCore calls forSink with its sink.
+type sinkAwareEncoder interface {
+	forSink(WriteSyncer) Encoder
+}
+
+// forSink colors the output under ColorAuto if ws is a terminal.
+func (c consoleEncoder) forSink(ws WriteSyncer) Encoder {
+	if c.autoTheme == nil || !color.Supported(sinkFile(ws)) {
+		return c
+	}
+	clone := c.Clone().(consoleEncoder)
+	clone.theme = c.autoTheme
+	return clone
+}
+
+// sinkFile returns the *os.File that ws writes to, or nil if it doesn't
+// write to exactly one file.
+func sinkFile(ws WriteSyncer) *os.File {
+	for {
+		switch w := ws.(type) {
+		case *os.File:
+			return w
+		case writerWrapper:
+			f, _ := w.Writer.(*os.File)
+			return f
+		case unwrapper:
+			ws = w.Unwrap()
+		default:
+			return nil
+		}
+	}
+}
+
+// ConsoleTheme configures the colors used by the console encoder when
+// EncoderConfig.ConsoleColor enables them. Each color is an ANSI SGR
+// parameter string, such as "31" for red or "1;34" for bold blue; an empty
+// string leaves that part of the output uncolored.
+type ConsoleTheme struct {
+	// Levels colors the level element of each entry.
+	Levels map[Level]string
+	// Key colors the keys of structured context.
+	Key string
+	// Value colors the scalar values of structured context.
+	Value string
+}
+
+// DefaultConsoleTheme returns the theme used when ConsoleColor is set but
+// ConsoleTheme is nil: levels are colored as by CapitalColorLevelEncoder,
+// including registered levels with a LevelColor, keys are dimmed, and values
+// are bright.
+func DefaultConsoleTheme() *ConsoleTheme {
+	levels := make(map[Level]string, len(_levelToColor))
+	for lvl, c := range _levelToColor {
+		levels[lvl] = strconv.Itoa(int(c))
+	}
+	for lvl, sgr := range customLevelColors() {
+		levels[lvl] = sgr
+	}
+	return &ConsoleTheme{
+		Levels: levels,
+		Key:    "2",
+		Value:  "1",
+	}
+}
+
+const _colorReset = "\x1b[0m"
+
+func appendColorStart(buf *buffer.Buffer, sgr string) {
+	buf.AppendString("\x1b[")
+	buf.AppendString(sgr)
+	buf.AppendByte('m')
+}
+
+// appendColoredJSON copies the JSON in src to buf, coloring object keys with
+// the theme's Key color and scalar values with its Value color. Structural
+// characters are left uncolored so that nested values stay readable.
+//
+// src must be valid JSON (or a comma-separated list of JSON object members,
+// as produced by the JSON encoder).
+func (t *ConsoleTheme) appendColoredJSON(buf *buffer.Buffer, src []byte) {
+	for i := 0; i < len(src); {
+		switch c := src[i]; c {
+		case '{', '}', '[', ']', ',', ':', ' ', '\t', '\n', '\r':
+			buf.AppendByte(c)
+			i++
+		case '"':
+			end := i + 1
+			for end < len(src) && src[end] != '"' {
+				if src[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end < len(src) {
+				end++ // closing quote
+			}
+			sgr := t.Value
+			if end < len(src) && src[end] == ':' {
+				sgr = t.Key
+			}
+			t.appendSpan(buf, sgr, src[i:end])
+			i = end
+		default:
+			// Numbers, booleans, and null.
+			end := i + 1
+			for end < len(src) && !isJSONDelimiter(src[end]) {
+				end++
+			}
+			t.appendSpan(buf, t.Value, src[i:end])
+			i = end
+		}
+	}
+}
+
+func (t *ConsoleTheme) appendSpan(buf *buffer.Buffer, sgr string, span []byte) {
+	if sgr == "" {
+		buf.AppendBytes(span)
+		return
+	}
+	appendColorStart(buf, sgr)
+	buf.AppendBytes(span)
+	buf.AppendString(_colorReset)
+}
+
+func isJSONDelimiter(c byte) bool {
+	switch c {
+	case '{', '}', '[', ']', ',', ':', ' ', '\t', '\n', '\r', '"':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap/internal/color"
+)
+
+type unwrappingWriteSyncer struct{ WriteSyncer }
+
+func (w unwrappingWriteSyncer) Unwrap() WriteSyncer { return w.WriteSyncer }
+
+func TestColorAutoFollowsSink(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm")
+
+	tty, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	require.NoError(t, err, "Failed to open %v.", os.DevNull)
+	defer tty.Close()
+	if !color.Supported(tty) {
+		t.Skipf("%v isn't a character device on this platform", os.DevNull)
+	}
+	file, err := os.CreateTemp(t.TempDir(), "color")
+	require.NoError(t, err, "Failed to create temp file.")
+	defer file.Close()
+
+	enc := NewConsoleEncoder(EncoderConfig{ConsoleColor: ColorAuto})
+	assert.Nil(t, enc.(consoleEncoder).theme, "Expected no color without a sink.")
+
+	tests := []struct {
+		desc string
+		ws   WriteSyncer
+		want bool
+	}{
+		{"terminal", tty, true},
+		{"locked terminal", Lock(tty), true},
+		{"wrapped terminal", AddSync(struct{ *os.File }{tty}), false},
+		{"unwrapped terminal", Lock(unwrappingWriteSyncer{tty}), true},
+		{"regular file", Lock(file), false},
+		{"several terminals", NewMultiWriteSyncer(tty, tty), false},
+		{"buffer", AddSync(&bytes.Buffer{}), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			core := NewCore(enc, tt.ws, DebugLevel).(*ioCore)
+			colored := core.enc.(consoleEncoder).theme != nil
+			assert.Equal(t, tt.want, colored, "Unexpected coloring.")
+		})
+	}
+	assert.Nil(t, enc.(consoleEncoder).theme, "Expected NewCore not to modify the encoder.")
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// _columnEllipsis marks the start of a logger name or caller that was cut
+// short to fit its column.
+const _columnEllipsis = "…"
+
+// fitColumns fits each element the console encoder added for one part of an
+// entry to a column width columns wide. If the width isn't positive, the
+// elements are left alone.
+//
+// Shorter values are padded with spaces, on the left if alignRight is set
+// and on the right otherwise. Longer values are cut short: if keepTail is
+// set, their start is replaced by an ellipsis, and otherwise their end is
+// dropped. Values holding ANSI escape sequences, such as those from
+// CapitalColorLevelEncoder, are padded but never cut short.
+func fitColumns(elems []interface{}, width int, alignRight, keepTail bool) {
+	if width <= 0 {
+		return
+	}
+	for i := range elems {
+		s, ok := elems[i].(string)
+		if !ok {
+			s = fmt.Sprint(elems[i])
+		}
+		elems[i] = fitColumn(s, width, alignRight, keepTail)
+	}
+}
+
+func fitColumn(s string, width int, alignRight, keepTail bool) string {
+	colored := strings.IndexByte(s, '\x1b') >= 0
+	n := visibleWidth(s)
+	if n > width && !colored {
+		if !keepTail {
+			return truncateRunes(s, width)
+		}
+		// Keep the last width-1 runes, after the ellipsis.
+		tail := s
+		for k := n - width + 1; k > 0; k-- {
+			_, size := utf8.DecodeRuneInString(tail)
+			tail = tail[size:]
+		}
+		return _columnEllipsis + tail
+	}
+	if n >= width {
+		return s
+	}
+	pad := strings.Repeat(" ", width-n)
+	if alignRight {
+		return pad + s
+	}
+	return s + pad
+}
+
+// truncateRunes returns the first n runes of s.
+func truncateRunes(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
+
+// visibleWidth returns the number of runes in s, not counting ANSI escape
+// sequences.
+func visibleWidth(s string) int {
+	n := 0
+	for i := 0; i < len(s); {
+		if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '[' {
+			// Skip to the sequence's final byte, in the range '@' to '~'.
+			i += 2
+			for i < len(s) && (s[i] < '@' || s[i] > '~') {
+				i++
+			}
+			i++
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		n++
+	}
+	return n
+}
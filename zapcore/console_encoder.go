@@ -45,6 +45,15 @@ func putSliceEncoder(e *sliceArrayEncoder) {
 
 type consoleEncoder struct {
 	*jsonEncoder
+
+	// theme is nil if output isn't colored.
+	theme *ConsoleTheme
+	// autoTheme is the theme to use under ColorAuto if the sink is a
+	// terminal.
+	autoTheme *ConsoleTheme
+
+	// order in which EncodeEntry writes the parts of each entry
+	order []EntryPart
 }
 
 // NewConsoleEncoder creates an encoder whose output is designed for human -
@@ -55,19 +64,52 @@ type consoleEncoder struct {
 // Note that although the console encoder doesn't use the keys specified in the
 // encoder configuration, it will omit any element whose key is set to the empty
 // string.
+//
+// Output can be colored with ANSI escape sequences by setting the
+// configuration's ConsoleColor and, optionally, ConsoleTheme.
 func NewConsoleEncoder(cfg EncoderConfig) Encoder {
 	if cfg.ConsoleSeparator == "" {
 		// Use a default delimiter of '\t' for backwards compatibility
 		cfg.ConsoleSeparator = "\t"
 	}
-	return consoleEncoder{newJSONEncoder(cfg, true)}
+	enc := consoleEncoder{
+		jsonEncoder: newJSONEncoder(cfg, true),
+		order:       resolveEncodeOrder(cfg.EncodeOrder, _consoleDefaultOrder),
+	}
+	enc.quoteRawJSON = true
+	if cfg.ConsoleColor == ColorAlways || cfg.ConsoleColor == ColorAuto {
+		theme := cfg.ConsoleTheme
+		if theme == nil {
+			theme = DefaultConsoleTheme()
+		}
+		if cfg.ConsoleColor == ColorAlways {
+			enc.theme = theme
+		} else {
+			enc.autoTheme = theme
+		}
+	}
+	return enc
 }
 
 func (c consoleEncoder) Clone() Encoder {
-	return consoleEncoder{c.jsonEncoder.Clone().(*jsonEncoder)}
+	return consoleEncoder{
+		jsonEncoder: c.jsonEncoder.Clone().(*jsonEncoder),
+		theme:       c.theme,
+		autoTheme:   c.autoTheme,
+		order:       c.order,
+	}
 }
 
 func (c consoleEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer, error) {
+	line, _, err := c.encodeEntryFieldErrors(ent, fields)
+	return line, err
+}
+
+func (c consoleEncoder) retainsFields() bool {
+	return false
+}
+
+func (c consoleEncoder) encodeEntryFieldErrors(ent Entry, fields []Field) (*buffer.Buffer, error, error) {
 	line := bufferpool.Get()
 
 	// We don't want the entry's metadata to be quoted and escaped (if it's
@@ -77,59 +119,95 @@ func (c consoleEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer,
 	// If this ever becomes a performance bottleneck, we can implement
 	// ArrayEncoder for our plain-text format.
 	arr := getSliceEncoder()
-	if c.TimeKey != "" && c.EncodeTime != nil && !ent.Time.IsZero() {
-		c.EncodeTime(ent.Time, arr)
-	}
-	if c.LevelKey != "" && c.EncodeLevel != nil {
-		c.EncodeLevel(ent.Level, arr)
+	var fieldErr error
+	for _, part := range c.order {
+		if perr := c.encodePart(line, arr, part, ent, fields); perr != nil {
+			fieldErr = perr
+		}
 	}
-	if ent.LoggerName != "" && c.NameKey != "" {
-		nameEncoder := c.EncodeName
+	putSliceEncoder(arr)
 
-		if nameEncoder == nil {
-			// Fall back to FullNameEncoder for backward compatibility.
-			nameEncoder = FullNameEncoder
-		}
+	line.AppendString(c.LineEnding)
+	return line, fieldErr, nil
+}
 
-		nameEncoder(ent.LoggerName, arr)
-	}
-	if ent.Caller.Defined {
-		if c.CallerKey != "" && c.EncodeCaller != nil {
+// encodePart writes one part of an entry to line. Metadata is encoded to arr
+// first and then printed. It returns the fields replaced by placeholders
+// under RecoverFieldErrors.
+func (c consoleEncoder) encodePart(line *buffer.Buffer, arr *sliceArrayEncoder, part EntryPart, ent Entry, fields []Field) error {
+	var err error
+	switch part {
+	case TimePart:
+		if c.TimeKey != "" && c.EncodeTime != nil && !ent.Time.IsZero() {
+			c.EncodeTime(ent.Time, arr)
+		}
+	case LevelPart:
+		if c.LevelKey != "" && c.EncodeLevel != nil {
+			c.EncodeLevel(ent.Level, arr)
+			fitColumns(arr.elems, c.ConsoleLevelWidth, false, false)
+			if c.theme != nil && len(arr.elems) > 0 {
+				if sgr := c.theme.Levels[ent.Level]; sgr != "" {
+					last := len(arr.elems) - 1
+					arr.elems[last] = "\x1b[" + sgr + "m" + fmt.Sprint(arr.elems[last]) + _colorReset
+				}
+			}
+		}
+	case NamePart:
+		if ent.LoggerName != "" && c.NameKey != "" {
+			nameEncoder := c.EncodeName
+
+			if nameEncoder == nil {
+				// Fall back to FullNameEncoder for backward compatibility.
+				nameEncoder = FullNameEncoder
+			}
+
+			nameEncoder(ent.LoggerName, arr)
+			c.ConsoleControlChars.sanitizeElems(arr.elems)
+			fitColumns(arr.elems, c.ConsoleNameWidth, false, true)
+		}
+	case CallerPart:
+		if ent.Caller.Defined && c.CallerKey != "" && c.EncodeCaller != nil {
 			c.EncodeCaller(ent.Caller, arr)
+			fitColumns(arr.elems, c.ConsoleCallerWidth, true, true)
 		}
-		if c.FunctionKey != "" {
+	case FunctionPart:
+		if ent.Caller.Defined && c.FunctionKey != "" {
 			arr.AppendString(ent.Caller.Function)
 		}
-	}
-	for i := range arr.elems {
-		if i > 0 {
-			line.AppendString(c.ConsoleSeparator)
+	case EventIDPart:
+		if ent.EventID != "" && c.EventIDKey != "" {
+			arr.AppendString(c.ConsoleControlChars.sanitize(ent.EventID))
+		}
+	case MessagePart:
+		if c.MessageKey != "" {
+			c.addSeparatorIfNecessary(line)
+			line.AppendString(c.ConsoleControlChars.sanitize(c.invalidUTF8().validText(ent.Message)))
+		}
+	case FieldsPart:
+		// Add any structured context.
+		err = c.writeContext(line, fields)
+	case StacktracePart:
+		// If there's no stacktrace key, honor that; this allows users to force
+		// single-line output.
+		if ent.Stack != "" && c.StacktraceKey != "" {
+			line.AppendByte('\n')
+			line.AppendString(c.invalidUTF8().validText(ent.Stack))
 		}
-		_, _ = fmt.Fprint(line, arr.elems[i])
 	}
-	putSliceEncoder(arr)
 
-	// Add the message itself.
-	if c.MessageKey != "" {
+	for i := range arr.elems {
 		c.addSeparatorIfNecessary(line)
-		line.AppendString(ent.Message)
-	}
-
-	// Add any structured context.
-	c.writeContext(line, fields)
-
-	// If there's no stacktrace key, honor that; this allows users to force
-	// single-line output.
-	if ent.Stack != "" && c.StacktraceKey != "" {
-		line.AppendByte('\n')
-		line.AppendString(ent.Stack)
+		if str, ok := arr.elems[i].(string); ok {
+			line.AppendString(c.invalidUTF8().validText(str))
+		} else {
+			_, _ = fmt.Fprint(line, arr.elems[i])
+		}
 	}
-
-	line.AppendString(c.LineEnding)
-	return line, nil
+	arr.elems = arr.elems[:0]
+	return err
 }
 
-func (c consoleEncoder) writeContext(line *buffer.Buffer, extra []Field) {
+func (c consoleEncoder) writeContext(line *buffer.Buffer, extra []Field) error {
 	context := c.jsonEncoder.Clone().(*jsonEncoder)
 	defer func() {
 		// putJSONEncoder assumes the buffer is still used, but we write out the buffer so
@@ -138,16 +216,25 @@ func (c consoleEncoder) writeContext(line *buffer.Buffer, extra []Field) {
 		putJSONEncoder(context)
 	}()
 
-	addFields(context, extra)
+	err := addFields(context, extra)
 	context.closeOpenNamespaces()
 	if context.buf.Len() == 0 {
-		return
+		return err
 	}
 
 	c.addSeparatorIfNecessary(line)
+	if c.ConsoleMultilineWidth > 0 {
+		c.writeMultilineContext(line, context.buf.Bytes())
+		return err
+	}
 	line.AppendByte('{')
-	line.Write(context.buf.Bytes())
+	if c.theme != nil {
+		c.theme.appendColoredJSON(line, context.buf.Bytes())
+	} else {
+		line.Write(context.buf.Bytes())
+	}
 	line.AppendByte('}')
+	return err
 }
 
 func (c consoleEncoder) addSeparatorIfNecessary(line *buffer.Buffer) {
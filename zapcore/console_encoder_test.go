@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	//revive:disable:dot-imports
 	. "go.uber.org/zap/zapcore"
 )
@@ -63,6 +64,17 @@ func TestConsoleEncodeEntry(t *testing.T) {
 				LoggerName: "name",
 				Message:    "message",
 			},
+		}, {
+			desc:     "raw JSON written as a string",
+			expected: "info\tname\tmessage\t{\"payload\": \"{\\\"a\\\": 1,\\n \\\"b\\\": [true]}\"}\n",
+			ent: Entry{
+				Level:      InfoLevel,
+				LoggerName: "name",
+				Message:    "message",
+			},
+			fields: []Field{
+				{Key: "payload", Type: RawJSONType, Interface: []byte("{\"a\": 1,\n \"b\": [true]}")},
+			},
 		},
 	}
 
@@ -133,3 +145,301 @@ func encoderTestEncoderConfig(separator string) EncoderConfig {
 	testEncoder.ConsoleSeparator = separator
 	return testEncoder
 }
+
+func TestConsoleColumns(t *testing.T) {
+	cfg := EncoderConfig{
+		LevelKey:           "L",
+		NameKey:            "N",
+		CallerKey:          "C",
+		MessageKey:         "M",
+		EncodeLevel:        CapitalLevelEncoder,
+		EncodeCaller:       ShortCallerEncoder,
+		ConsoleLevelWidth:  5,
+		ConsoleNameWidth:   6,
+		ConsoleCallerWidth: 12,
+	}
+	caller := func(file string, line int) EntryCaller {
+		return EntryCaller{Defined: true, File: file, Line: line}
+	}
+
+	tests := []struct {
+		desc string
+		ent  Entry
+		want string
+	}{
+		{
+			desc: "padded",
+			ent:  Entry{Level: InfoLevel, LoggerName: "db", Caller: caller("/src/a/b.go", 7), Message: "hi"},
+			want: "INFO \tdb    \t    a/b.go:7\thi\n",
+		},
+		{
+			desc: "exact",
+			ent:  Entry{Level: ErrorLevel, LoggerName: "server", Caller: caller("/src/ab/cd.go:1", 1), Message: "hi"},
+			want: "ERROR\tserver\tab/cd.go:1:1\thi\n",
+		},
+		{
+			desc: "truncated",
+			ent:  Entry{Level: DPanicLevel, LoggerName: "server.http", Caller: caller("/src/pkg/handler.go", 123), Message: "hi"},
+			want: "DPANI\t….http\t…dler.go:123\thi\n",
+		},
+	}
+	enc := NewConsoleEncoder(cfg)
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			buf, err := enc.EncodeEntry(tt.ent, nil)
+			require.NoError(t, err, "Unexpected console encoding error.")
+			assert.Equal(t, tt.want, buf.String(), "Unexpected output.")
+			buf.Free()
+		})
+	}
+
+	t.Run("colored levels", func(t *testing.T) {
+		cfg := cfg
+		cfg.EncodeLevel = CapitalColorLevelEncoder
+		cfg.ConsoleLevelWidth = 3
+		buf, err := NewConsoleEncoder(cfg).EncodeEntry(Entry{Level: InfoLevel, Message: "hi"}, nil)
+		require.NoError(t, err, "Unexpected console encoding error.")
+		assert.Equal(t, "\x1b[34mINFO\x1b[0m\thi\n", buf.String(), "Expected colored levels not to be cut short.")
+
+		cfg.ConsoleLevelWidth = 6
+		buf, err = NewConsoleEncoder(cfg).EncodeEntry(Entry{Level: InfoLevel, Message: "hi"}, nil)
+		require.NoError(t, err, "Unexpected console encoding error.")
+		assert.Equal(t, "\x1b[34mINFO\x1b[0m  \thi\n", buf.String(), "Expected colored levels to be padded by their visible width.")
+	})
+}
+
+func TestConsoleColor(t *testing.T) {
+	fields := []Field{
+		{Key: "str", Type: StringType, String: `a "b"`},
+		{Key: "num", Type: Int64Type, Integer: 42},
+		{Key: "ok", Type: BoolType, Integer: 1},
+	}
+	tests := []struct {
+		desc  string
+		mode  ColorMode
+		theme *ConsoleTheme
+		want  string
+	}{
+		{
+			desc: "never",
+			mode: ColorNever,
+			want: `info	hello	{"str": "a \"b\"", "num": 42, "ok": true}` + "\n",
+		},
+		{
+			desc: "default theme",
+			mode: ColorAlways,
+			want: "\x1b[34minfo\x1b[0m\thello\t{" +
+				"\x1b[2m\"str\"\x1b[0m: \x1b[1m\"a \\\"b\\\"\"\x1b[0m, " +
+				"\x1b[2m\"num\"\x1b[0m: \x1b[1m42\x1b[0m, " +
+				"\x1b[2m\"ok\"\x1b[0m: \x1b[1mtrue\x1b[0m}\n",
+		},
+		{
+			desc:  "custom theme",
+			mode:  ColorAlways,
+			theme: &ConsoleTheme{Key: "36"},
+			want: "info\thello\t{" +
+				"\x1b[36m\"str\"\x1b[0m: \"a \\\"b\\\"\", " +
+				"\x1b[36m\"num\"\x1b[0m: 42, " +
+				"\x1b[36m\"ok\"\x1b[0m: true}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			enc := NewConsoleEncoder(EncoderConfig{
+				LevelKey:     "L",
+				MessageKey:   "M",
+				EncodeLevel:  LowercaseLevelEncoder,
+				LineEnding:   DefaultLineEnding,
+				ConsoleColor: tt.mode,
+				ConsoleTheme: tt.theme,
+			})
+			buf, err := enc.EncodeEntry(Entry{Level: InfoLevel, Message: "hello"}, fields)
+			require.NoError(t, err, "Unexpected console encoding error.")
+			assert.Equal(t, tt.want, buf.String(), "Incorrect encoded entry.")
+			buf.Free()
+		})
+	}
+}
+
+func TestColorModeUnmarshalText(t *testing.T) {
+	tests := []struct {
+		text string
+		want ColorMode
+	}{
+		{"", ColorNever},
+		{"never", ColorNever},
+		{"always", ColorAlways},
+		{"auto", ColorAuto},
+	}
+	for _, tt := range tests {
+		var m ColorMode
+		require.NoError(t, m.UnmarshalText([]byte(tt.text)), "Unexpected error unmarshaling %q.", tt.text)
+		assert.Equal(t, tt.want, m, "Unexpected mode for %q.", tt.text)
+		if tt.text != "" {
+			assert.Equal(t, tt.text, m.String(), "Unexpected string for %v.", m)
+		}
+	}
+
+	var m ColorMode
+	assert.Error(t, m.UnmarshalText([]byte("rainbow")), "Expected error for unknown mode.")
+}
+
+func TestConsoleMultiline(t *testing.T) {
+	reflected := Field{Key: "user", Type: ReflectType, Interface: map[string]interface{}{
+		"name":  "jane",
+		"roles": []string{"admin", "dev"},
+	}}
+	tests := []struct {
+		desc   string
+		width  int
+		fields []Field
+		want   string
+	}{
+		{
+			desc:   "fits",
+			width:  80,
+			fields: []Field{{Key: "k", Type: Int64Type, Integer: 1}, reflected},
+			want:   `hello	{"k": 1, "user": {"name":"jane","roles":["admin","dev"]}}`,
+		},
+		{
+			desc:   "nested fits",
+			width:  60,
+			fields: []Field{{Key: "k", Type: Int64Type, Integer: 1}, reflected},
+			want: `hello	{
+  "k": 1,
+  "user": {"name":"jane","roles":["admin","dev"]}
+}`,
+		},
+		{
+			desc:   "nested expands",
+			width:  20,
+			fields: []Field{reflected, {Key: "empty", Type: ReflectType, Interface: []int{}}},
+			want: `hello	{
+  "user": {
+    "name": "jane",
+    "roles": [
+      "admin",
+      "dev"
+    ]
+  },
+  "empty": []
+}`,
+		},
+		{
+			desc:   "long string",
+			width:  20,
+			fields: []Field{{Key: "out", Type: StringType, String: "line one\nline two\n"}},
+			want: `hello	{
+  "out": "line one\n"
+         "line two\n"
+}`,
+		},
+		{
+			desc:   "escaped backslash",
+			width:  20,
+			fields: []Field{{Key: "path", Type: StringType, String: `C:\new\dir\file.txt`}},
+			want: `hello	{
+  "path": "C:\\new\\dir\\file.txt"
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			enc := NewConsoleEncoder(EncoderConfig{
+				MessageKey:            "msg",
+				ConsoleMultilineWidth: tt.width,
+			})
+			buf, err := enc.EncodeEntry(Entry{Message: "hello"}, tt.fields)
+			require.NoError(t, err, "Unexpected console encoding error.")
+			assert.Equal(t, tt.want+"\n", buf.String(), "Unexpected multi-line output.")
+			buf.Free()
+		})
+	}
+}
+
+func TestConsoleMultilineColor(t *testing.T) {
+	enc := NewConsoleEncoder(EncoderConfig{
+		MessageKey:            "msg",
+		ConsoleColor:          ColorAlways,
+		ConsoleTheme:          &ConsoleTheme{Key: "1"},
+		ConsoleMultilineWidth: 10,
+	})
+	buf, err := enc.EncodeEntry(Entry{Message: "hello"}, []Field{{Key: "k", Type: StringType, String: "value"}})
+	require.NoError(t, err, "Unexpected console encoding error.")
+	assert.Equal(t, "hello\t{\n  \x1b[1m\"k\"\x1b[0m: \"value\"\n}\n", buf.String(), "Unexpected colored output.")
+	buf.Free()
+}
+
+func TestControlCharPolicyUnmarshalText(t *testing.T) {
+	tests := []struct {
+		text string
+		want ControlCharPolicy
+	}{
+		{"", KeepControlChars},
+		{"keep", KeepControlChars},
+		{"escape", EscapeControlChars},
+		{"strip", StripControlChars},
+	}
+	for _, tt := range tests {
+		var p ControlCharPolicy
+		require.NoError(t, p.UnmarshalText([]byte(tt.text)), "Unexpected error unmarshaling %q.", tt.text)
+		assert.Equal(t, tt.want, p, "Unexpected policy for %q.", tt.text)
+		if tt.text != "" {
+			assert.Equal(t, tt.text, p.String(), "Unexpected string for %v.", p)
+		}
+	}
+
+	var p ControlCharPolicy
+	assert.Error(t, p.UnmarshalText([]byte("drop")), "Expected an error for an unknown policy.")
+	assert.Equal(t, "ControlCharPolicy(9)", ControlCharPolicy(9).String(), "Unexpected string for an unknown policy.")
+}
+
+func TestConsoleControlChars(t *testing.T) {
+	ent := Entry{
+		Level:      InfoLevel,
+		LoggerName: "a\nb",
+		EventID:    "id\r",
+		Message:    "login failed\n2024-01-01\tINFO\tlogin ok \x1b[31mred\x1b[0m \x1b]0;title\a\u009bdone\x7f",
+	}
+	fields := []Field{{Key: "user", Type: StringType, String: "x\ny"}}
+
+	tests := []struct {
+		policy ControlCharPolicy
+		want   string
+	}{
+		{
+			policy: KeepControlChars,
+			want:   "\x1b[34mINFO\x1b[0m\ta\nb\tid\r\t" + ent.Message + "\t{\n  \"user\": \"x\\n\"\n          \"y\"\n}\n",
+		},
+		{
+			policy: EscapeControlChars,
+			want: "\x1b[34mINFO\x1b[0m\ta\\nb\tid\\r\t" +
+				`login failed\n2024-01-01\tINFO\tlogin ok \x1b[31mred\x1b[0m \x1b]0;title\x07\u009bdone\x7f` +
+				"\t{\n  \"user\": \"x\\ny\"\n}\n",
+		},
+		{
+			policy: StripControlChars,
+			want:   "\x1b[34mINFO\x1b[0m\tab\tid\tlogin failed2024-01-01INFOlogin ok red done\t{\n  \"user\": \"x\\ny\"\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			enc := NewConsoleEncoder(EncoderConfig{
+				LevelKey:              "L",
+				NameKey:               "N",
+				EventIDKey:            "E",
+				MessageKey:            "M",
+				EncodeLevel:           CapitalColorLevelEncoder,
+				ConsoleMultilineWidth: 10,
+				ConsoleControlChars:   tt.policy,
+			})
+			buf, err := enc.EncodeEntry(ent, fields)
+			require.NoError(t, err, "Unexpected console encoding error.")
+			assert.Equal(t, tt.want, buf.String(), "Unexpected output.")
+			buf.Free()
+		})
+	}
+}
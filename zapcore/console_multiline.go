@@ -0,0 +1,228 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"bytes"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/internal/bufferpool"
+)
+
+// _multilineIndent is the indentation added for each level of nesting in
+// multi-line console context.
+const _multilineIndent = "  "
+
+// writeMultilineContext appends the encoded context members in fields,
+// wrapped in braces, to line. If they don't fit in the configured width,
+// they're spread over multiple lines.
+func (c consoleEncoder) writeMultilineContext(line *buffer.Buffer, fields []byte) {
+	obj := bufferpool.Get()
+	defer obj.Free()
+	obj.AppendByte('{')
+	obj.AppendBytes(fields)
+	obj.AppendByte('}')
+
+	col := line.Len()
+	if i := bytes.LastIndexByte(line.Bytes(), '\n'); i >= 0 {
+		col -= i + 1
+	}
+
+	out := line
+	if c.theme != nil {
+		out = bufferpool.Get()
+		defer out.Free()
+	}
+	mw := multilineWriter{
+		buf:          out,
+		width:        c.ConsoleMultilineWidth,
+		breakStrings: c.ConsoleControlChars == KeepControlChars,
+	}
+	mw.value(obj.Bytes(), 0, col)
+	if c.theme != nil {
+		c.theme.appendColoredJSON(line, out.Bytes())
+	}
+}
+
+// multilineWriter lays out JSON over multiple lines, keeping values that fit
+// in width columns on a single line.
+type multilineWriter struct {
+	buf   *buffer.Buffer
+	width int
+	// breakStrings breaks long strings after each escaped newline. It's
+	// off when control characters are escaped or stripped, so that every
+	// line of output starts an entry or a member.
+	breakStrings bool
+}
+
+// value appends the JSON value src. It starts at column col of a line
+// indented by indent levels.
+func (w multilineWriter) value(src []byte, indent, col int) {
+	if col+len(src) <= w.width {
+		w.buf.AppendBytes(src)
+		return
+	}
+	switch src[0] {
+	case '{', '[':
+		w.container(src, indent)
+	case '"':
+		if !w.breakStrings {
+			w.buf.AppendBytes(src)
+			return
+		}
+		w.string(src, col)
+	default:
+		w.buf.AppendBytes(src)
+	}
+}
+
+// container appends the object or array src with one member per line.
+func (w multilineWriter) container(src []byte, indent int) {
+	end := len(src) - 1
+	w.buf.AppendByte(src[0])
+	inner := indent + 1
+	empty := true
+	for i := skipJSONSpace(src, 1); i < end; {
+		if !empty {
+			w.buf.AppendByte(',')
+		}
+		empty = false
+		w.newline(inner)
+		col := inner * len(_multilineIndent)
+
+		if src[0] == '{' {
+			keyEnd := scanJSONValue(src[:end], i)
+			w.buf.AppendBytes(src[i:keyEnd])
+			w.buf.AppendString(": ")
+			col += keyEnd - i + 2
+			i = skipJSONSpace(src, keyEnd)
+			if i < end && src[i] == ':' {
+				i = skipJSONSpace(src, i+1)
+			}
+		}
+
+		valEnd := scanJSONValue(src[:end], i)
+		if valEnd > i {
+			w.value(src[i:valEnd], inner, col)
+		}
+		i = skipJSONSpace(src, valEnd)
+		if i < end && src[i] == ',' {
+			i = skipJSONSpace(src, i+1)
+		}
+	}
+	if !empty {
+		w.newline(indent)
+	}
+	w.buf.AppendByte(src[end])
+}
+
+// string appends the JSON string src, breaking it into adjacent quoted
+// strings after each escaped newline. Continuation lines are aligned with
+// col.
+func (w multilineWriter) string(src []byte, col int) {
+	start := 0
+	for i := 1; i < len(src)-2; i++ {
+		if src[i] != '\\' {
+			continue
+		}
+		i++
+		if src[i] == 'n' && i < len(src)-2 {
+			w.buf.AppendBytes(src[start : i+1])
+			w.buf.AppendByte('"')
+			w.buf.AppendByte('\n')
+			for n := 0; n < col; n++ {
+				w.buf.AppendByte(' ')
+			}
+			w.buf.AppendByte('"')
+			start = i + 1
+		}
+	}
+	w.buf.AppendBytes(src[start:])
+}
+
+func (w multilineWriter) newline(indent int) {
+	w.buf.AppendByte('\n')
+	for n := 0; n < indent; n++ {
+		w.buf.AppendString(_multilineIndent)
+	}
+}
+
+// scanJSONValue returns the index just past the JSON value starting at
+// src[i], or len(src) if the value is unterminated.
+func scanJSONValue(src []byte, i int) int {
+	if i >= len(src) {
+		return len(src)
+	}
+	switch src[i] {
+	case '"':
+		return scanJSONString(src, i)
+	case '{', '[':
+		depth := 0
+		for i < len(src) {
+			switch src[i] {
+			case '"':
+				i = scanJSONString(src, i)
+				continue
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return i + 1
+				}
+			}
+			i++
+		}
+		return len(src)
+	default:
+		end := i + 1
+		for end < len(src) && !isJSONDelimiter(src[end]) {
+			end++
+		}
+		return end
+	}
+}
+
+// scanJSONString returns the index just past the JSON string starting at
+// src[i], or len(src) if the string is unterminated.
+func scanJSONString(src []byte, i int) int {
+	for i++; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(src)
+}
+
+func skipJSONSpace(src []byte, i int) int {
+	for i < len(src) {
+		switch src[i] {
+		case ' ', '\t', '\n', '\r':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
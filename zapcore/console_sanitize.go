@@ -0,0 +1,190 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ControlCharPolicy controls how the console encoder writes control
+// characters, such as newlines and ANSI escape sequences, that appear in
+// messages, logger names, and event IDs. Escaping or stripping them keeps
+// user-controlled strings from forging extra log lines or rewriting the
+// terminal that displays the logs.
+type ControlCharPolicy uint8
+
+const (
+	// KeepControlChars writes control characters as they are. This is the
+	// default.
+	KeepControlChars ControlCharPolicy = iota
+	// EscapeControlChars writes newlines, carriage returns, and tabs as the
+	// text \n, \r, and \t, other ASCII control characters, including the ESC
+	// that starts ANSI escape sequences, as \xHH, and C1 control characters
+	// as \u00HH.
+	EscapeControlChars
+	// StripControlChars removes ANSI escape sequences and control
+	// characters, including newlines.
+	StripControlChars
+)
+
+// String returns the policy's name.
+func (p ControlCharPolicy) String() string {
+	switch p {
+	case KeepControlChars:
+		return "keep"
+	case EscapeControlChars:
+		return "escape"
+	case StripControlChars:
+		return "strip"
+	default:
+		return fmt.Sprintf("ControlCharPolicy(%d)", uint8(p))
+	}
+}
+
+// UnmarshalText unmarshals text to a ControlCharPolicy. "keep" and the
+// empty string unmarshal to KeepControlChars, "escape" to
+// EscapeControlChars, and "strip" to StripControlChars.
+func (p *ControlCharPolicy) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "keep", "":
+		*p = KeepControlChars
+	case "escape":
+		*p = EscapeControlChars
+	case "strip":
+		*p = StripControlChars
+	default:
+		return fmt.Errorf("unrecognized control character policy: %q", text)
+	}
+	return nil
+}
+
+// sanitize returns s with its control characters escaped or stripped.
+// Strings without any, by far the most common, are returned as they are.
+func (p ControlCharPolicy) sanitize(s string) string {
+	if p == KeepControlChars || !hasControlChars(s) {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s) + 8)
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !isControlRune(r, size) {
+			b.WriteString(s[i : i+size])
+			i += size
+			continue
+		}
+		if p == StripControlChars {
+			i += escapeSequenceLen(s[i:])
+			continue
+		}
+		switch r {
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < utf8.RuneSelf {
+				fmt.Fprintf(&b, `\x%02x`, r)
+			} else {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			}
+		}
+		i += size
+	}
+	return b.String()
+}
+
+// hasControlChars reports whether s holds any ASCII or C1 control
+// characters. C1 characters, U+0080 to U+009F, are encoded as 0xC2 followed
+// by a byte from 0x80 to 0x9F.
+func hasControlChars(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < 0x20 || c == 0x7f {
+			return true
+		}
+		if c == 0xc2 && i+1 < len(s) && s[i+1] < 0xa0 && s[i+1] >= 0x80 {
+			return true
+		}
+	}
+	return false
+}
+
+func isControlRune(r rune, size int) bool {
+	if r == utf8.RuneError && size == 1 {
+		return false
+	}
+	return r < 0x20 || (r >= 0x7f && r < 0xa0)
+}
+
+// escapeSequenceLen returns the length of the control character at the
+// start of s, together with the rest of the ANSI escape sequence it starts,
+// if any. CSI sequences, like those that set colors, run up to a final byte
+// from '@' to '~', and OSC sequences, like those that set window titles or
+// write hyperlinks, up to a BEL or ST.
+func escapeSequenceLen(s string) int {
+	_, size := utf8.DecodeRuneInString(s)
+	if s[0] != '\x1b' || len(s) < 2 {
+		return size
+	}
+	switch s[1] {
+	case '[':
+		for i := 2; i < len(s); i++ {
+			if s[i] >= '@' && s[i] <= '~' {
+				return i + 1
+			}
+		}
+		return len(s)
+	case ']':
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return len(s)
+	default:
+		return size
+	}
+}
+
+// sanitizeElems applies the policy to elems, which hold whatever the
+// configured name encoder appended. Time, level, and caller encoders aren't
+// fed user input, and may add colors deliberately, so they're trusted.
+func (p ControlCharPolicy) sanitizeElems(elems []interface{}) {
+	if p == KeepControlChars {
+		return
+	}
+	for i, elem := range elems {
+		s, ok := elem.(string)
+		if !ok {
+			s = fmt.Sprint(elem)
+		}
+		elems[i] = p.sanitize(s)
+	}
+}
@@ -44,6 +44,33 @@ type Core interface {
 	Sync() error
 }
 
+// AccumulatedFields returns the fields bound to core with With, or nil if
+// core doesn't report them.
+//
+// Wrapper cores, such as samplers and filters, can use this to make decisions
+// based on a logger's context (a tenant or endpoint, say) even if the fields
+// were bound before the wrapper was applied. Cores report their fields by
+// implementing:
+//
+//	AccumulatedFields() []Field
+//
+// Tracking fields costs an allocation per With, so the cores returned by
+// NewCore don't. Wrap a core with NewFieldTrackingCore to opt in; Zap's
+// wrapper cores report the fields of the cores they wrap. Callers must not
+// modify the returned slice.
+func AccumulatedFields(core Core) []Field {
+	if fa, ok := core.(fieldAccumulator); ok {
+		return fa.AccumulatedFields()
+	}
+	return nil
+}
+
+// fieldAccumulator is implemented by cores that report the fields bound to
+// them with With.
+type fieldAccumulator interface {
+	AccumulatedFields() []Field
+}
+
 type nopCore struct{}
 
 // NewNopCore returns a no-op Core.
@@ -59,6 +86,9 @@ NewCore creates a Core specialized to the Encoder type for maximum performance,
 removing interface dispatch from the critical Write/With path.
 */
 func NewCore(enc Encoder, ws WriteSyncer, enab LevelEnabler) Core {
+	if e, ok := enc.(sinkAwareEncoder); ok {
+		enc = e.forSink(ws)
+	}
 	switch e := enc.(type) {
 	case *jsonEncoder:
 		return &jsonCore{
@@ -91,6 +121,9 @@ type jsonCore struct {
 var (
 	_ Core           = (*ioCore)(nil)
 	_ leveledEnabler = (*ioCore)(nil)
+	_ fieldRetainer  = (*ioCore)(nil)
+	_ fieldRetainer  = (*jsonCore)(nil)
+	_ arenaWriter    = (*jsonCore)(nil)
 )
 
 func (c *ioCore) Level() Level {
@@ -114,6 +147,7 @@ func (c *ioCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
 	if c.Enabled(ent.Level) {
 		return ce.AddCore(ent, c)
 	}
+	ent.Trace.record(c, TraceRejected, "")
 	return ce
 }
 
@@ -122,43 +156,53 @@ func (c *jsonCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
 	if c.Enabled(ent.Level) {
 		return ce.AddCore(ent, c)
 	}
+	ent.Trace.record(c, TraceRejected, "")
 	return ce
 }
 
 func (c *ioCore) Write(ent Entry, fields []Field) error {
-	buf, err := c.enc.EncodeEntry(ent, fields)
+	buf, fieldErr, err := encodeEntryFieldErrors(c.enc, ent, fields)
 	if err != nil {
 		return err
 	}
-	_, err = c.out.Write(buf.Bytes())
-	buf.Free()
-	if err != nil {
+	if err := writeEntryBuffer(c.out, ent.Level, buf); err != nil {
 		return err
 	}
-	if ent.Level > ErrorLevel {
+	if ent.Level > ErrorLevel || flushesAt(c.out, ent.Level) {
 		// Since we may be crashing the program, sync the output.
 		// Ignore Sync errors, pending a clean solution to issue #370.
 		_ = c.Sync()
 	}
-	return nil
+	// Fields replaced by placeholders don't stop the entry from being
+	// written, but are still reported.
+	return fieldErr
 }
 
 // Specialized Write for jsonCore removes encoder interface dispatch.
 func (c *jsonCore) Write(ent Entry, fields []Field) error {
-	buf, err := c.enc.EncodeEntry(ent, fields)
-	if err != nil {
-		return err
-	}
-	_, err = c.out.Write(buf.Bytes())
-	buf.Free()
-	if err != nil {
+	return c.writeArena(ent, fields, nil)
+}
+
+// writeArena writes an entry, sharing the encodings of reflected values
+// with the other cores the entry fans out to through arena.
+func (c *jsonCore) writeArena(ent Entry, fields []Field, arena *entryArena) error {
+	buf, fieldErr := c.enc.encodeEntry(ent, fields, arena)
+	if err := writeEntryBuffer(c.out, ent.Level, buf); err != nil {
 		return err
 	}
-	if ent.Level > ErrorLevel {
+	if ent.Level > ErrorLevel || flushesAt(c.out, ent.Level) {
 		// Since we may be crashing the program, sync the output.
 		_ = c.Sync()
 	}
-	return nil
+	return fieldErr
+}
+
+func (c *ioCore) retainsFields() bool {
+	return retainsFields(c.enc)
+}
+
+func (c *jsonCore) retainsFields() bool {
+	return false
 }
 
 func (c *ioCore) Sync() error {
@@ -21,6 +21,7 @@
 package zapcore_test
 
 import (
+	"bytes"
 	"errors"
 	"os"
 	"testing"
@@ -164,3 +165,81 @@ func TestIOCoreWriteFailure(t *testing.T) {
 	// Should log the error.
 	assert.Error(t, err, "Expected writing Entry to fail.")
 }
+
+func TestAccumulatedFields(t *testing.T) {
+	a, b := makeInt64Field("a", 1), makeInt64Field("b", 2)
+	jsonCore := NewFieldTrackingCore(NewCore(NewJSONEncoder(testEncoderConfig()), &ztest.Discarder{}, DebugLevel))
+	ioCore := NewFieldTrackingCore(NewCore(NewConsoleEncoder(testEncoderConfig()), &ztest.Discarder{}, DebugLevel))
+	increased, err := NewIncreaseLevelCore(jsonCore, WarnLevel)
+	require.NoError(t, err, "Unexpected error increasing level.")
+
+	tests := []struct {
+		desc string
+		core Core
+	}{
+		{"json", jsonCore},
+		{"io", ioCore},
+		{"tee", NewTee(jsonCore, ioCore)},
+		{"hooked", RegisterHooks(jsonCore)},
+		{"increased level", increased},
+		{"lazy", NewLazyWith(jsonCore, nil)},
+		{"sampler", NewSamplerWithOptions(jsonCore, time.Second, 1, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Empty(t, AccumulatedFields(tt.core), "Expected no fields on a new core.")
+
+			parent := tt.core.With([]Field{a})
+			child := parent.With([]Field{b})
+			sibling := parent.With([]Field{makeInt64Field("c", 3)})
+			assert.Equal(t, []Field{a}, AccumulatedFields(parent), "Unexpected parent fields.")
+			assert.Equal(t, []Field{a, b}, AccumulatedFields(child), "Unexpected child fields.")
+			assert.Len(t, AccumulatedFields(sibling), 2, "Unexpected sibling fields.")
+		})
+	}
+
+	assert.Nil(t, AccumulatedFields(NewNopCore()), "Expected nil for cores that don't report fields.")
+	untracked := NewCore(NewJSONEncoder(testEncoderConfig()), &ztest.Discarder{}, DebugLevel).With([]Field{a})
+	assert.Nil(t, AccumulatedFields(untracked), "Expected NewCore not to track fields.")
+}
+
+// countingObject counts how often it's marshaled.
+type countingObject struct{ calls int }
+
+func (o *countingObject) MarshalLogObject(enc ObjectEncoder) error {
+	o.calls++
+	enc.AddString("k", "v")
+	return nil
+}
+
+func TestIOCoreEncodesContextOnce(t *testing.T) {
+	// Fields bound with With are encoded into the cloned encoder once and
+	// spliced into every entry, rather than being encoded per entry.
+	cfg := testEncoderConfig()
+	tests := []struct {
+		desc string
+		enc  Encoder
+	}{
+		{"json", NewJSONEncoder(cfg)},
+		{"console", NewConsoleEncoder(cfg)},
+		{"cbor", NewCBOREncoder(cfg)},
+		{"ecs", NewECSEncoder(cfg)},
+		{"gelf", NewGELFEncoder(cfg, "host")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			obj := &countingObject{}
+			sink := &ztest.Buffer{}
+			core := NewCore(tt.enc, sink, DebugLevel).With([]Field{
+				{Key: "obj", Type: ObjectMarshalerType, Interface: obj},
+			})
+			for i := 0; i < 3; i++ {
+				require.NoError(t, core.Write(Entry{Level: InfoLevel, Message: "hello"}, nil))
+			}
+			assert.Equal(t, 1, obj.calls, "Expected bound fields to be encoded once.")
+			assert.Equal(t, 3, bytes.Count(sink.Bytes(), []byte("hello")), "Expected three entries.")
+		})
+	}
+}
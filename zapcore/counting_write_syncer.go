@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"sync/atomic"
+
+	"go.uber.org/zap/buffer"
+)
+
+type countingWriteSyncer struct {
+	ws WriteSyncer
+	n  *atomic.Uint64
+}
+
+// NewCountingWriteSyncer wraps ws so that the number of bytes written
+// through it is added to n. Unlike a wrapper defined outside this package,
+// it keeps the optional behavior of ws visible to cores: buffers are still
+// handed to a BufferWriter, entries are still routed by
+// NewLevelFilterWriteSyncer, and BufferedWriteSyncer's FlushLevel still
+// applies.
+func NewCountingWriteSyncer(ws WriteSyncer, n *atomic.Uint64) WriteSyncer {
+	return &countingWriteSyncer{ws: ws, n: n}
+}
+
+func (s *countingWriteSyncer) Write(bs []byte) (int, error) {
+	n, err := s.ws.Write(bs)
+	s.n.Add(uint64(n))
+	return n, err
+}
+
+// WriteBuffer counts buf as written if the wrapped WriteSyncer accepts it.
+func (s *countingWriteSyncer) WriteBuffer(buf *buffer.Buffer) error {
+	n := buf.Len()
+	err := writeBuffer(s.ws, buf)
+	if err == nil {
+		s.n.Add(uint64(n))
+	}
+	return err
+}
+
+func (s *countingWriteSyncer) writeLevelBuffer(lvl Level, buf *buffer.Buffer) error {
+	n := buf.Len()
+	err := writeEntryBuffer(s.ws, lvl, buf)
+	if err == nil {
+		s.n.Add(uint64(n))
+	}
+	return err
+}
+
+func (s *countingWriteSyncer) flushesAt(lvl Level) bool {
+	return flushesAt(s.ws, lvl)
+}
+
+// Unwrap returns the wrapped WriteSyncer.
+func (s *countingWriteSyncer) Unwrap() WriteSyncer {
+	return s.ws
+}
+
+func (s *countingWriteSyncer) Sync() error {
+	return s.ws.Sync()
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/internal/ztest"
+)
+
+func TestCountingWriteSyncer(t *testing.T) {
+	var n atomic.Uint64
+	buf := &ztest.Buffer{}
+	ws := NewCountingWriteSyncer(buf, &n)
+
+	_, err := ws.Write([]byte("foo\n"))
+	require.NoError(t, err, "Unexpected error writing bytes.")
+	core := NewCore(NewJSONEncoder(EncoderConfig{MessageKey: "msg"}), ws, DebugLevel)
+	require.NoError(t, core.Write(Entry{Message: "bar"}, nil), "Unexpected error writing entry.")
+
+	assert.Equal(t, uint64(buf.Len()), n.Load(), "Unexpected byte count.")
+	require.NoError(t, ws.Sync(), "Unexpected error syncing.")
+	assert.True(t, buf.Called(), "Expected Sync to be forwarded.")
+	assert.Equal(t, WriteSyncer(buf), ws.(unwrapper).Unwrap(), "Unexpected wrapped WriteSyncer.")
+
+	n.Store(0)
+	failing := NewCountingWriteSyncer(&ztest.FailWriter{}, &n)
+	assert.Error(t, writeBuffer(failing, buffer.NewPool().Get()), "Expected write errors to propagate.")
+	assert.Zero(t, n.Load(), "Expected failed writes not to be counted.")
+}
+
+func TestCountingWriteSyncerWriteBuffer(t *testing.T) {
+	var n atomic.Uint64
+	owner := &bufferOwner{}
+	core := NewCore(NewJSONEncoder(EncoderConfig{MessageKey: "msg"}), NewCountingWriteSyncer(owner, &n), DebugLevel)
+	require.NoError(t, core.Write(Entry{Message: "foo"}, nil), "Unexpected error writing entry.")
+
+	require.Len(t, owner.bufs, 1, "Expected the buffer to be handed to the sink.")
+	assert.Zero(t, owner.writes, "Expected no copies through Write.")
+	assert.Equal(t, uint64(owner.bufs[0].Len()), n.Load(), "Unexpected byte count.")
+	owner.bufs[0].Free()
+}
+
+func TestCountingWriteSyncerLevelFilter(t *testing.T) {
+	var n atomic.Uint64
+	stderr, stdout := &ztest.Buffer{}, &ztest.Buffer{}
+	ws := NewCountingWriteSyncer(NewLevelFilterWriteSyncer(ErrorLevel, stderr, stdout), &n)
+	core := NewCore(NewJSONEncoder(EncoderConfig{MessageKey: "msg"}), ws, DebugLevel)
+
+	require.NoError(t, core.Write(Entry{Level: InfoLevel, Message: "info"}, nil), "Unexpected error writing entry.")
+	require.NoError(t, core.Write(Entry{Level: ErrorLevel, Message: "error"}, nil), "Unexpected error writing entry.")
+
+	assert.Equal(t, []string{`{"msg":"error"}`}, stderr.Lines(), "Unexpected entries routed to match.")
+	assert.Equal(t, []string{`{"msg":"info"}`}, stdout.Lines(), "Unexpected entries routed to other.")
+	assert.Equal(t, uint64(stderr.Len()+stdout.Len()), n.Load(), "Unexpected byte count.")
+}
@@ -0,0 +1,582 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/internal/bufferpool"
+	"go.uber.org/zap/internal/pool"
+)
+
+// _csvOverflowColumn names the final column, which holds the fields that
+// don't have columns of their own, in CSVHeader.
+const _csvOverflowColumn = "fields"
+
+var _csvPool = pool.New(func() *csvEncoder {
+	return &csvEncoder{}
+})
+
+func putCSVEncoder(enc *csvEncoder) {
+	for _, je := range [2]*jsonEncoder{enc.overflow, enc.scratch} {
+		if je != nil {
+			je.buf.Free()
+			putJSONEncoder(je)
+		}
+	}
+	for i := range enc.cells {
+		enc.cells[i] = enc.cells[i][:0]
+	}
+	enc.EncoderConfig = nil
+	enc.layout = nil
+	enc.cells = enc.cells[:0]
+	enc.overflow = nil
+	enc.scratch = nil
+	_csvPool.Put(enc)
+}
+
+// csvColumn is one of the columns written by a CSV encoder.
+type csvColumn struct {
+	name string
+	part EntryPart // FieldsPart for columns that hold a field
+}
+
+// csvLayout describes the columns of a CSV encoder. It's shared by clones.
+type csvLayout struct {
+	columns []csvColumn
+	fields  map[string]int // indexes of the columns that hold fields
+}
+
+func newCSVLayout(cfg *EncoderConfig) *csvLayout {
+	entryKeys := make(map[string]EntryPart)
+	var defaults []string
+	for _, k := range []struct {
+		key  string
+		part EntryPart
+	}{
+		{cfg.TimeKey, TimePart},
+		{cfg.LevelKey, LevelPart},
+		{cfg.NameKey, NamePart},
+		{cfg.CallerKey, CallerPart},
+		{cfg.FunctionKey, FunctionPart},
+		{cfg.EventIDKey, EventIDPart},
+		{cfg.MessageKey, MessagePart},
+		{cfg.StacktraceKey, StacktracePart},
+	} {
+		if k.key == "" {
+			continue
+		}
+		if _, ok := entryKeys[k.key]; !ok {
+			entryKeys[k.key] = k.part
+			defaults = append(defaults, k.key)
+		}
+	}
+
+	names := cfg.CSVColumns
+	if len(names) == 0 {
+		names = defaults
+	}
+	layout := &csvLayout{fields: make(map[string]int)}
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+
+		part, ok := entryKeys[name]
+		if !ok {
+			part = FieldsPart
+			layout.fields[name] = len(layout.columns)
+		}
+		layout.columns = append(layout.columns, csvColumn{name: name, part: part})
+	}
+	return layout
+}
+
+type csvEncoder struct {
+	*EncoderConfig
+	layout *csvLayout
+	cells  [][]byte // unquoted values, one per column
+
+	// overflow holds the fields without columns, as the body of a JSON
+	// object.
+	overflow *jsonEncoder
+	// scratch encodes values that aren't strings for cells.
+	scratch *jsonEncoder
+}
+
+// NewCSVEncoder creates an encoder that writes each entry as a row of
+// comma-separated values, for loading logs straight into databases and
+// spreadsheets. Set EncoderConfig.CSVSeparator to a tab to write
+// tab-separated values instead.
+//
+// EncoderConfig.CSVColumns lists the columns in order. Columns named by one
+// of the EncoderConfig's keys, such as TimeKey or MessageKey, hold that part
+// of the entry, and other columns hold the top-level field with the same
+// key. If CSVColumns is empty, the entry's parts are written in the order
+// time, level, name, caller, function, event ID, message, and stack trace.
+// A final column holds all other fields, including those in namespaces, as
+// a JSON object, and is empty if there are none.
+//
+// Strings are written as they are, and arrays, objects, and reflected values
+// as JSON. Values that contain the separator, quotes, or line breaks are
+// quoted as described in RFC 4180. Use CSVHeader for a matching header row.
+func NewCSVEncoder(cfg EncoderConfig) Encoder {
+	return newCSVEncoder(cfg)
+}
+
+func newCSVEncoder(cfg EncoderConfig) *csvEncoder {
+	applyCSVDefaults(&cfg)
+	cfg.EncodeKey = cacheKeyEncoder(cfg.EncodeKey)
+	if cfg.NewReflectedEncoder == nil {
+		cfg.NewReflectedEncoder = defaultReflectedEncoder
+	}
+
+	enc := &csvEncoder{
+		EncoderConfig: &cfg,
+		layout:        newCSVLayout(&cfg),
+	}
+	enc.cells = make([][]byte, len(enc.layout.columns))
+	enc.overflow = enc.newJSONEncoder()
+	return enc
+}
+
+func applyCSVDefaults(cfg *EncoderConfig) {
+	if cfg.SkipLineEnding {
+		cfg.LineEnding = ""
+	} else if cfg.LineEnding == "" {
+		cfg.LineEnding = DefaultLineEnding
+	}
+	if cfg.CSVSeparator == "" {
+		cfg.CSVSeparator = ","
+	}
+}
+
+// CSVHeader returns the header row for the encoder that NewCSVEncoder builds
+// from cfg: the names of its columns, followed by "fields" for the column
+// that holds the remaining fields.
+func CSVHeader(cfg EncoderConfig) string {
+	applyCSVDefaults(&cfg)
+	line := bufferpool.Get()
+	defer line.Free()
+
+	for _, col := range newCSVLayout(&cfg).columns {
+		appendCSVCell(line, []byte(col.name), cfg.CSVSeparator)
+		line.AppendString(cfg.CSVSeparator)
+	}
+	appendCSVCell(line, []byte(_csvOverflowColumn), cfg.CSVSeparator)
+	line.AppendString(cfg.LineEnding)
+	return line.String()
+}
+
+func (enc *csvEncoder) newJSONEncoder() *jsonEncoder {
+	je := _jsonPool.Get()
+	je.EncoderConfig = enc.EncoderConfig
+	je.buf = bufferpool.Get()
+	return je
+}
+
+// column returns the index of the column that holds the field with the
+// given key, or -1 if it belongs in the overflow column.
+func (enc *csvEncoder) column(key string) int {
+	if enc.overflow.openNamespaces > 0 {
+		return -1
+	}
+	if i, ok := enc.layout.fields[enc.encodeKey(key)]; ok {
+		return i
+	}
+	return -1
+}
+
+// startCell returns the scratch encoder, emptied to encode a cell's value.
+func (enc *csvEncoder) startCell() *jsonEncoder {
+	if enc.scratch == nil {
+		enc.scratch = enc.newJSONEncoder()
+	}
+	enc.scratch.buf.Reset()
+	enc.scratch.openNamespaces = 0
+	return enc.scratch
+}
+
+// endCell sets column i to the value encoded by the scratch encoder,
+// unquoting it if it's a JSON string.
+func (enc *csvEncoder) endCell(i int) {
+	enc.cells[i] = appendUnquotedJSON(enc.cells[i][:0], enc.scratch.buf.Bytes())
+}
+
+func (enc *csvEncoder) setCell(i int, val string) {
+	enc.cells[i] = append(enc.cells[i][:0], val...)
+}
+
+func (enc *csvEncoder) AddArray(key string, arr ArrayMarshaler) error {
+	i := enc.column(key)
+	if i < 0 {
+		return enc.overflow.AddArray(key, arr)
+	}
+	err := enc.startCell().AppendArray(arr)
+	enc.endCell(i)
+	return err
+}
+
+func (enc *csvEncoder) AddObject(key string, obj ObjectMarshaler) error {
+	i := enc.column(key)
+	if i < 0 {
+		return enc.overflow.AddObject(key, obj)
+	}
+	err := enc.startCell().AppendObject(obj)
+	enc.endCell(i)
+	return err
+}
+
+func (enc *csvEncoder) AddBinary(key string, val []byte) {
+	enc.AddString(key, enc.binaryEncoding().EncodeToString(val))
+}
+
+func (enc *csvEncoder) AddByteString(key string, val []byte) {
+	i := enc.column(key)
+	if i < 0 {
+		enc.overflow.AddByteString(key, val)
+		return
+	}
+	enc.cells[i] = append(enc.cells[i][:0], val...)
+}
+
+func (enc *csvEncoder) AddBool(key string, val bool) {
+	i := enc.column(key)
+	if i < 0 {
+		enc.overflow.AddBool(key, val)
+		return
+	}
+	enc.cells[i] = strconv.AppendBool(enc.cells[i][:0], val)
+}
+
+func (enc *csvEncoder) AddComplex128(key string, val complex128) {
+	i := enc.column(key)
+	if i < 0 {
+		enc.overflow.AddComplex128(key, val)
+		return
+	}
+	enc.startCell().AppendComplex128(val)
+	enc.endCell(i)
+}
+
+func (enc *csvEncoder) AddComplex64(key string, val complex64) {
+	enc.AddComplex128(key, complex128(val))
+}
+
+func (enc *csvEncoder) AddDuration(key string, val time.Duration) {
+	i := enc.column(key)
+	if i < 0 {
+		enc.overflow.AddDuration(key, val)
+		return
+	}
+	enc.startCell().AppendDuration(val)
+	enc.endCell(i)
+}
+
+func (enc *csvEncoder) AddFloat64(key string, val float64) {
+	i := enc.column(key)
+	if i < 0 {
+		enc.overflow.AddFloat64(key, val)
+		return
+	}
+	enc.startCell().AppendFloat64(val)
+	enc.endCell(i)
+}
+
+func (enc *csvEncoder) AddFloat32(key string, val float32) {
+	i := enc.column(key)
+	if i < 0 {
+		enc.overflow.AddFloat32(key, val)
+		return
+	}
+	enc.startCell().AppendFloat32(val)
+	enc.endCell(i)
+}
+
+func (enc *csvEncoder) AddInt64(key string, val int64) {
+	i := enc.column(key)
+	if i < 0 {
+		enc.overflow.AddInt64(key, val)
+		return
+	}
+	enc.cells[i] = strconv.AppendInt(enc.cells[i][:0], val, 10)
+}
+
+func (enc *csvEncoder) AddReflected(key string, obj interface{}) error {
+	i := enc.column(key)
+	if i < 0 {
+		return enc.overflow.AddReflected(key, obj)
+	}
+	err := enc.startCell().AppendReflected(obj)
+	enc.endCell(i)
+	return err
+}
+
+func (enc *csvEncoder) AddRawJSON(key string, val []byte) {
+	i := enc.column(key)
+	if i < 0 {
+		enc.overflow.AddRawJSON(key, val)
+		return
+	}
+	enc.cells[i] = append(enc.cells[i][:0], val...)
+}
+
+// EncodingName implements PreEncodedEncoder. Values are JSON-encoded, both
+// in their own columns and in the overflow column.
+func (enc *csvEncoder) EncodingName() string {
+	return "json"
+}
+
+func (enc *csvEncoder) AddPreEncoded(key string, val []byte) {
+	enc.AddRawJSON(key, val)
+}
+
+func (enc *csvEncoder) OpenNamespace(key string) {
+	enc.overflow.OpenNamespace(key)
+}
+
+func (enc *csvEncoder) CloseNamespace() {
+	enc.overflow.CloseNamespace()
+}
+
+func (enc *csvEncoder) AddString(key, val string) {
+	i := enc.column(key)
+	if i < 0 {
+		enc.overflow.AddString(key, val)
+		return
+	}
+	enc.setCell(i, val)
+}
+
+func (enc *csvEncoder) AddTime(key string, val time.Time) {
+	i := enc.column(key)
+	if i < 0 {
+		enc.overflow.AddTime(key, val)
+		return
+	}
+	enc.startCell().AppendTime(val)
+	enc.endCell(i)
+}
+
+func (enc *csvEncoder) AddUint64(key string, val uint64) {
+	i := enc.column(key)
+	if i < 0 {
+		enc.overflow.AddUint64(key, val)
+		return
+	}
+	enc.cells[i] = strconv.AppendUint(enc.cells[i][:0], val, 10)
+}
+
+func (enc *csvEncoder) AddInt(k string, v int)         { enc.AddInt64(k, int64(v)) }
+func (enc *csvEncoder) AddInt32(k string, v int32)     { enc.AddInt64(k, int64(v)) }
+func (enc *csvEncoder) AddInt16(k string, v int16)     { enc.AddInt64(k, int64(v)) }
+func (enc *csvEncoder) AddInt8(k string, v int8)       { enc.AddInt64(k, int64(v)) }
+func (enc *csvEncoder) AddUint(k string, v uint)       { enc.AddUint64(k, uint64(v)) }
+func (enc *csvEncoder) AddUint32(k string, v uint32)   { enc.AddUint64(k, uint64(v)) }
+func (enc *csvEncoder) AddUint16(k string, v uint16)   { enc.AddUint64(k, uint64(v)) }
+func (enc *csvEncoder) AddUint8(k string, v uint8)     { enc.AddUint64(k, uint64(v)) }
+func (enc *csvEncoder) AddUintptr(k string, v uintptr) { enc.AddUint64(k, uint64(v)) }
+
+func (enc *csvEncoder) Clone() Encoder {
+	return enc.clone()
+}
+
+func (enc *csvEncoder) clone() *csvEncoder {
+	clone := _csvPool.Get()
+	clone.EncoderConfig = enc.EncoderConfig
+	clone.layout = enc.layout
+	if cap(clone.cells) < len(enc.cells) {
+		clone.cells = make([][]byte, len(enc.cells))
+	}
+	clone.cells = clone.cells[:len(enc.cells)]
+	for i, cell := range enc.cells {
+		clone.cells[i] = append(clone.cells[i][:0], cell...)
+	}
+	clone.overflow = enc.overflow.Clone().(*jsonEncoder)
+	return clone
+}
+
+func (enc *csvEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer, error) {
+	final := enc.clone()
+	for i, col := range final.layout.columns {
+		final.encodePart(i, col.part, ent)
+	}
+	addFields(final, fields)
+
+	line := bufferpool.Get()
+	for _, cell := range final.cells {
+		appendCSVCell(line, final.invalidUTF8().validBytes(cell), final.CSVSeparator)
+		line.AppendString(final.CSVSeparator)
+	}
+	if final.overflow.buf.Len() > 0 {
+		final.overflow.closeOpenNamespaces()
+		obj := final.startCell().buf
+		obj.AppendByte('{')
+		obj.Write(final.overflow.buf.Bytes())
+		obj.AppendByte('}')
+		appendCSVCell(line, obj.Bytes(), final.CSVSeparator)
+	}
+	line.AppendString(final.LineEnding)
+
+	putCSVEncoder(final)
+	return line, nil
+}
+
+// encodePart sets column i to the given part of the entry. Columns that
+// hold fields are left alone.
+func (enc *csvEncoder) encodePart(i int, part EntryPart, ent Entry) {
+	switch part {
+	case LevelPart:
+		s := enc.startCell()
+		if enc.EncodeLevel != nil {
+			enc.EncodeLevel(ent.Level, s)
+		}
+		if s.buf.Len() == 0 {
+			s.AppendString(ent.Level.String())
+		}
+		enc.endCell(i)
+	case TimePart:
+		if !ent.Time.IsZero() {
+			enc.startCell().AppendTime(ent.Time)
+			enc.endCell(i)
+		}
+	case NamePart:
+		if ent.LoggerName != "" {
+			s := enc.startCell()
+			nameEncoder := enc.EncodeName
+			if nameEncoder == nil {
+				nameEncoder = FullNameEncoder
+			}
+			nameEncoder(ent.LoggerName, s)
+			if s.buf.Len() == 0 {
+				s.AppendString(ent.LoggerName)
+			}
+			enc.endCell(i)
+		}
+	case CallerPart:
+		if ent.Caller.Defined {
+			s := enc.startCell()
+			if enc.EncodeCaller != nil {
+				enc.EncodeCaller(ent.Caller, s)
+			}
+			if s.buf.Len() == 0 {
+				s.AppendString(ent.Caller.String())
+			}
+			enc.endCell(i)
+		}
+	case FunctionPart:
+		if ent.Caller.Defined {
+			enc.setCell(i, ent.Caller.Function)
+		}
+	case EventIDPart:
+		enc.setCell(i, ent.EventID)
+	case MessagePart:
+		enc.setCell(i, ent.Message)
+	case StacktracePart:
+		enc.setCell(i, ent.Stack)
+	}
+}
+
+// appendCSVCell appends a value to line, quoting it if it contains the
+// separator, quotes, or line breaks.
+func appendCSVCell(line *buffer.Buffer, cell []byte, sep string) {
+	if !bytes.ContainsAny(cell, "\"\r\n") && !strings.Contains(string(cell), sep) {
+		line.AppendBytes(cell)
+		return
+	}
+	line.AppendByte('"')
+	for _, c := range cell {
+		if c == '"' {
+			line.AppendByte('"')
+		}
+		line.AppendByte(c)
+	}
+	line.AppendByte('"')
+}
+
+// appendUnquotedJSON appends val to dst, unquoting it first if it's a JSON
+// string. It understands the escapes written by the JSON encoder.
+func appendUnquotedJSON(dst, val []byte) []byte {
+	if len(val) < 2 || val[0] != '"' || val[len(val)-1] != '"' {
+		return append(dst, val...)
+	}
+
+	val = val[1 : len(val)-1]
+	for i := 0; i < len(val); i++ {
+		c := val[i]
+		if c != '\\' || i+1 == len(val) {
+			dst = append(dst, c)
+			continue
+		}
+		i++
+		switch c = val[i]; c {
+		case 'b':
+			dst = append(dst, '\b')
+		case 'f':
+			dst = append(dst, '\f')
+		case 'n':
+			dst = append(dst, '\n')
+		case 'r':
+			dst = append(dst, '\r')
+		case 't':
+			dst = append(dst, '\t')
+		case 'u':
+			if r, ok := parseHex4(val[i+1:]); ok {
+				dst = utf8.AppendRune(dst, r)
+				i += 4
+				continue
+			}
+			dst = append(dst, '\\', c)
+		default:
+			dst = append(dst, c)
+		}
+	}
+	return dst
+}
+
+// parseHex4 parses the four hexadecimal digits at the start of bs.
+func parseHex4(bs []byte) (rune, bool) {
+	if len(bs) < 4 {
+		return 0, false
+	}
+	var r rune
+	for _, c := range bs[:4] {
+		switch {
+		case '0' <= c && c <= '9':
+			c -= '0'
+		case 'a' <= c && c <= 'f':
+			c -= 'a' - 10
+		case 'A' <= c && c <= 'F':
+			c -= 'A' - 10
+		default:
+			return 0, false
+		}
+		r = r<<4 | rune(c)
+	}
+	return r, true
+}
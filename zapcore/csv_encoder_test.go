@@ -0,0 +1,141 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "go.uber.org/zap/zapcore"
+)
+
+func TestCSVEncoder(t *testing.T) {
+	cfg := testEncoderConfig()
+	cfg.EncodeTime = ISO8601TimeEncoder
+	cfg.CSVColumns = []string{"ts", "level", "msg", "user", "count", "tags", "missing"}
+
+	enc := NewCSVEncoder(cfg)
+	enc.AddString("user", `alice "al", smith`)
+	ent := Entry{
+		Level:   WarnLevel,
+		Time:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Message: "line one\nline two",
+	}
+	buf, err := enc.EncodeEntry(ent, []Field{
+		{Key: "count", Type: Int64Type, Integer: 3},
+		{Key: "tags", Type: ArrayMarshalerType, Interface: StringSlice{"a", "b"}},
+		{Key: "extra", Type: BoolType, Integer: 1},
+		{Key: "req", Type: NamespaceType},
+		{Key: "user", Type: StringType, String: "nested"},
+	})
+	require.NoError(t, err, "Unexpected encoding error.")
+
+	want := "2026-01-02T03:04:05.000Z,warn,\"line one\nline two\",\"alice \"\"al\"\", smith\",3," +
+		`"[""a"",""b""]",,"{""extra"":true,""req"":{""user"":""nested""}}"` + "\n"
+	assert.Equal(t, want, buf.String(), "Unexpected CSV output.")
+
+	records, err := csv.NewReader(strings.NewReader(CSVHeader(cfg) + buf.String())).ReadAll()
+	require.NoError(t, err, "Output isn't valid CSV.")
+	assert.Equal(t, [][]string{
+		{"ts", "level", "msg", "user", "count", "tags", "missing", "fields"},
+		{
+			"2026-01-02T03:04:05.000Z", "warn", "line one\nline two", `alice "al", smith`, "3",
+			`["a","b"]`, "", `{"extra":true,"req":{"user":"nested"}}`,
+		},
+	}, records, "Unexpected records.")
+}
+
+func TestCSVEncoderDefaultColumns(t *testing.T) {
+	cfg := testEncoderConfig()
+	cfg.CSVSeparator = "\t"
+
+	assert.Equal(t, "ts\tlevel\tname\tcaller\tfunc\tmsg\tstacktrace\tfields\n", CSVHeader(cfg), "Unexpected header.")
+
+	enc := NewCSVEncoder(cfg)
+	buf, err := enc.EncodeEntry(Entry{
+		Level:      InfoLevel,
+		Time:       time.Unix(1, 0),
+		LoggerName: "main",
+		Message:    "tab\there",
+		Caller:     EntryCaller{Defined: true, File: "a/b/c.go", Line: 7, Function: "pkg.f"},
+	}, []Field{{Key: "err", Type: ErrorType, Interface: errors.New("boom")}})
+	require.NoError(t, err, "Unexpected encoding error.")
+	assert.Equal(t, "1\tinfo\tmain\tb/c.go:7\tpkg.f\t\"tab\there\"\t\t\"{\"\"err\"\":\"\"boom\"\"}\"\n", buf.String(), "Unexpected TSV output.")
+}
+
+func TestCSVEncoderClone(t *testing.T) {
+	cfg := testEncoderConfig()
+	cfg.CSVColumns = []string{"msg", "a"}
+	parent := NewCSVEncoder(cfg)
+	parent.AddString("a", "parent")
+
+	child := parent.Clone()
+	child.AddString("a", "child")
+	child.AddInt64("b", 1)
+
+	for _, tt := range []struct {
+		enc  Encoder
+		want string
+	}{
+		{parent, "m,parent,\n"},
+		{child, "m,child,\"{\"\"b\"\":1}\"\n"},
+	} {
+		buf, err := tt.enc.EncodeEntry(Entry{Message: "m"}, nil)
+		require.NoError(t, err, "Unexpected encoding error.")
+		assert.Equal(t, tt.want, buf.String(), "Unexpected output.")
+	}
+}
+
+func TestCSVEncoderValues(t *testing.T) {
+	cfg := testEncoderConfig()
+	cfg.CSVColumns = []string{"v"}
+
+	tests := []struct {
+		desc  string
+		field Field
+		want  string
+	}{
+		{"bool", Field{Type: BoolType, Integer: 1}, "true"},
+		{"uint", Field{Type: Uint32Type, Integer: 7}, "7"},
+		{"float", Field{Type: Float64Type, Integer: int64(0x3ff8000000000000)}, "1.5"},
+		{"duration", Field{Type: DurationType, Integer: int64(time.Second)}, "1"},
+		{"bytes", Field{Type: ByteStringType, Interface: []byte("b")}, "b"},
+		{"complex", Field{Type: Complex128Type, Interface: complex(1, 2)}, "1+2i"},
+		{"escaped", Field{Type: ReflectType, Interface: "a\u0001\"\tb"}, "\"a\u0001\"\"\tb\""},
+		{"reflected", Field{Type: ReflectType, Interface: map[string]int{"x": 1}}, `"{""x"":1}"`},
+		{"raw", Field{Type: RawJSONType, Interface: []byte(`[1,2]`)}, `"[1,2]"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			tt.field.Key = "v"
+			buf, err := NewCSVEncoder(cfg).EncodeEntry(Entry{}, []Field{tt.field})
+			require.NoError(t, err, "Unexpected encoding error.")
+			assert.Equal(t, tt.want+",\n", buf.String(), "Unexpected output.")
+		})
+	}
+}
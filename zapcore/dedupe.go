@@ -0,0 +1,280 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+// DedupeFields returns a keyer for NewDedupeCore that treats entries as
+// identical only if they share a message and the values of the given
+// fields, whether those were added with With or when logging.
+func DedupeFields(keys ...string) func(Entry, []Field) string {
+	return func(ent Entry, fields []Field) string {
+		var sb strings.Builder
+		sb.WriteString(ent.Message)
+		for _, key := range keys {
+			sb.WriteByte(0)
+			// The last field with the key wins, as it would in most
+			// decoders of the encoded entry.
+			for i := len(fields) - 1; i >= 0; i-- {
+				if fields[i].Key == key {
+					sb.WriteString(fieldValueString(fields[i]))
+					break
+				}
+			}
+		}
+		return sb.String()
+	}
+}
+
+func fieldValueString(f Field) string {
+	if f.Type == StringType {
+		return f.String
+	}
+	enc := NewMapObjectEncoder()
+	f.AddTo(enc)
+	return fmt.Sprint(enc.Fields[f.Key])
+}
+
+// NewDedupeCore wraps a core so that identical entries logged within window
+// of each other are written only once. Entries are identical if they have
+// the same level and the same key, as returned by keyer; a nil keyer
+// compares messages alone, and DedupeFields builds keyers that also compare
+// selected fields.
+//
+// The first entry with a given key is written immediately and opens a
+// window. Repeats within the window are suppressed and counted. Once the
+// window has closed, the last repeat is written with an additional
+// "repeat_count" field holding the number of suppressed entries. Windows are
+// closed as entries are written, and by Sync, rather than by a timer: a
+// summary is written by the next Write after its window ends, or by the next
+// Sync, whichever comes first. Windows are timed by the entries' own
+// timestamps.
+//
+// Entries above ErrorLevel are never suppressed, since the process may be
+// about to exit.
+//
+// The wrapped core's Check method is bypassed, so it should be wrapped
+// around the core that does the encoding, and any sampling applied outside
+// of it.
+func NewDedupeCore(core Core, window time.Duration, keyer func(Entry, []Field) string) Core {
+	c := &dedupeCore{
+		Core:   core,
+		window: window,
+		keyer:  keyer,
+		state:  &dedupeState{windows: make(map[string]*dedupeWindow)},
+	}
+	if keyer != nil {
+		// Include fields bound before the core was wrapped.
+		c.fields = AccumulatedFields(core)
+	}
+	return c
+}
+
+type dedupeCore struct {
+	Core
+
+	window time.Duration
+	state  *dedupeState // shared with cores derived by With
+
+	// keyer and the fields it's called with, in addition to the fields of
+	// each entry. fields is only tracked if keyer is set.
+	keyer  func(Entry, []Field) string
+	fields []Field
+}
+
+var (
+	_ Core             = (*dedupeCore)(nil)
+	_ leveledEnabler   = (*dedupeCore)(nil)
+	_ fieldAccumulator = (*dedupeCore)(nil)
+	_ arenaWriter      = (*dedupeCore)(nil)
+)
+
+// dedupeState tracks the open windows of a dedupeCore and its clones.
+type dedupeState struct {
+	mu        sync.Mutex
+	windows   map[string]*dedupeWindow
+	nextSweep time.Time
+}
+
+// dedupeWindow tracks the entries suppressed for one key. Once there's been
+// at least one repeat, ent and fields hold the latest one, and core the
+// core it was logged to.
+type dedupeWindow struct {
+	end    time.Time
+	count  int
+	core   Core
+	ent    Entry
+	fields []Field
+}
+
+func (c *dedupeCore) Level() Level {
+	return LevelOf(c.Core)
+}
+
+func (c *dedupeCore) AccumulatedFields() []Field {
+	if c.keyer != nil {
+		return c.fields
+	}
+	return AccumulatedFields(c.Core)
+}
+
+func (c *dedupeCore) With(fields []Field) Core {
+	clone := *c
+	clone.Core = c.Core.With(fields)
+	if c.keyer != nil {
+		// Clip the capacity so siblings never share a backing array.
+		clone.fields = append(c.fields[:len(c.fields):len(c.fields)], fields...)
+	}
+	return &clone
+}
+
+func (c *dedupeCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	ent.Trace.record(c, TraceRejected, "")
+	return ce
+}
+
+func (c *dedupeCore) Write(ent Entry, fields []Field) error {
+	return c.writeArena(ent, fields, nil)
+}
+
+// writeArena writes an entry that isn't a duplicate through arena. Suppressed
+// entries are summarized later, without it.
+func (c *dedupeCore) writeArena(ent Entry, fields []Field, arena *entryArena) error {
+	if ent.Level > ErrorLevel {
+		return writeArena(c.Core, ent, fields, arena)
+	}
+
+	key := c.key(ent, fields)
+	s := c.state
+	s.mu.Lock()
+	closed := s.closeWindows(ent.Time, key)
+	if w, ok := s.windows[key]; ok {
+		w.count++
+		w.core = c.Core
+		w.ent = ent
+		w.fields = append(w.fields[:0], fields...)
+		s.mu.Unlock()
+		ent.Trace.record(c, TraceDropped, "duplicate")
+		return writeDedupeSummaries(closed)
+	}
+	s.windows[key] = &dedupeWindow{end: ent.Time.Add(c.window)}
+	s.mu.Unlock()
+
+	err := writeDedupeSummaries(closed)
+	return multierr.Append(err, writeArena(c.Core, ent, fields, arena))
+}
+
+// Sync closes every open window, writing summaries for those with
+// suppressed entries, then syncs the wrapped core.
+func (c *dedupeCore) Sync() error {
+	s := c.state
+	s.mu.Lock()
+	var closed []*dedupeWindow
+	for key, w := range s.windows {
+		if w.count > 0 {
+			closed = append(closed, w)
+		}
+		delete(s.windows, key)
+	}
+	s.mu.Unlock()
+
+	err := writeDedupeSummaries(closed)
+	return multierr.Append(err, c.Core.Sync())
+}
+
+func (c *dedupeCore) key(ent Entry, fields []Field) string {
+	key := ent.Message
+	if c.keyer != nil {
+		all := fields
+		if len(c.fields) > 0 {
+			all = make([]Field, 0, len(c.fields)+len(fields))
+			all = append(all, c.fields...)
+			all = append(all, fields...)
+		}
+		key = c.keyer(ent, all)
+	}
+	return ent.Level.String() + "\x00" + key
+}
+
+// closeWindows removes the windows that have ended by now and returns those
+// with suppressed entries. The window for key is always checked, and all
+// other windows at most once per window duration. The caller must hold
+// s.mu.
+func (s *dedupeState) closeWindows(now time.Time, key string) []*dedupeWindow {
+	var closed []*dedupeWindow
+	if now.Before(s.nextSweep) {
+		if w, ok := s.windows[key]; ok && !now.Before(w.end) {
+			delete(s.windows, key)
+			if w.count > 0 {
+				closed = append(closed, w)
+			}
+		}
+		return closed
+	}
+
+	for k, w := range s.windows {
+		if now.Before(w.end) {
+			continue
+		}
+		delete(s.windows, k)
+		if w.count > 0 {
+			closed = append(closed, w)
+		}
+	}
+	// Windows opened later end later still, so none can end before then.
+	s.nextSweep = s.earliestEnd()
+	return closed
+}
+
+// earliestEnd returns the time at which the first open window ends.
+func (s *dedupeState) earliestEnd() time.Time {
+	var earliest time.Time
+	for _, w := range s.windows {
+		if earliest.IsZero() || w.end.Before(earliest) {
+			earliest = w.end
+		}
+	}
+	return earliest
+}
+
+func writeDedupeSummaries(closed []*dedupeWindow) error {
+	sort.Slice(closed, func(i, j int) bool {
+		return closed[i].end.Before(closed[j].end)
+	})
+
+	var err error
+	for _, w := range closed {
+		fields := append(w.fields, Field{Key: "repeat_count", Type: Int64Type, Integer: int64(w.count)})
+		err = multierr.Append(err, w.core.Write(w.ent, fields))
+	}
+	return err
+}
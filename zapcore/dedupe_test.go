@@ -0,0 +1,162 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestDedupeCore(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+	core := NewDedupeCore(obs, 10*time.Second, nil)
+
+	start := time.Unix(0, 0)
+	write := func(lvl Level, msg string, offset time.Duration, fields ...Field) {
+		ent := Entry{Level: lvl, Message: msg, Time: start.Add(offset)}
+		if ce := core.Check(ent, nil); ce != nil {
+			ce.Write(fields...)
+		}
+	}
+
+	write(InfoLevel, "retrying", 0, makeInt64Field("attempt", 1))
+	write(InfoLevel, "retrying", time.Second, makeInt64Field("attempt", 2))
+	write(WarnLevel, "retrying", time.Second) // different level
+	write(InfoLevel, "retrying", 2*time.Second, makeInt64Field("attempt", 3))
+	write(InfoLevel, "other", 3*time.Second)
+	write(DPanicLevel, "fatal-ish", 4*time.Second)
+	write(DPanicLevel, "fatal-ish", 4*time.Second)
+	write(InfoLevel, "retrying", 11*time.Second, makeInt64Field("attempt", 4))
+
+	assert.Equal(t, []observer.LoggedEntry{
+		{
+			Entry:   Entry{Level: InfoLevel, Message: "retrying", Time: start},
+			Context: []Field{makeInt64Field("attempt", 1)},
+		},
+		{
+			Entry:   Entry{Level: WarnLevel, Message: "retrying", Time: start.Add(time.Second)},
+			Context: []Field{},
+		},
+		{
+			Entry:   Entry{Level: InfoLevel, Message: "other", Time: start.Add(3 * time.Second)},
+			Context: []Field{},
+		},
+		{
+			Entry:   Entry{Level: DPanicLevel, Message: "fatal-ish", Time: start.Add(4 * time.Second)},
+			Context: []Field{},
+		},
+		{
+			Entry:   Entry{Level: DPanicLevel, Message: "fatal-ish", Time: start.Add(4 * time.Second)},
+			Context: []Field{},
+		},
+		{
+			Entry:   Entry{Level: InfoLevel, Message: "retrying", Time: start.Add(2 * time.Second)},
+			Context: []Field{makeInt64Field("attempt", 3), makeInt64Field("repeat_count", 2)},
+		},
+		{
+			Entry:   Entry{Level: InfoLevel, Message: "retrying", Time: start.Add(11 * time.Second)},
+			Context: []Field{makeInt64Field("attempt", 4)},
+		},
+	}, logs.All(), "Unexpected entries.")
+}
+
+func TestDedupeCoreClosesOtherWindows(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+	core := NewDedupeCore(obs, time.Second, nil)
+
+	start := time.Unix(0, 0)
+	for _, tt := range []struct {
+		msg    string
+		offset time.Duration
+	}{
+		{"noisy", 0},
+		{"noisy", 100 * time.Millisecond},
+		{"quiet", 2 * time.Second}, // closes the window for "noisy"
+	} {
+		require.NoError(t, core.Write(Entry{Message: tt.msg, Time: start.Add(tt.offset)}, nil))
+	}
+
+	var msgs []string
+	for _, e := range logs.AllUntimed() {
+		msgs = append(msgs, e.Message)
+	}
+	assert.Equal(t, []string{"noisy", "noisy", "quiet"}, msgs)
+	assert.Equal(t, map[string]interface{}{"repeat_count": int64(1)}, logs.AllUntimed()[1].ContextMap())
+}
+
+func TestDedupeCoreSync(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+	core := NewDedupeCore(obs, time.Hour, nil)
+
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		require.NoError(t, core.Write(Entry{Message: "flood", Time: now}, nil))
+	}
+	require.NoError(t, core.Write(Entry{Message: "once", Time: now}, nil))
+	assert.Equal(t, 2, logs.Len(), "Expected repeats to be suppressed.")
+
+	require.NoError(t, core.Sync())
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 3, "Expected Sync to write a summary.")
+	assert.Equal(t, "flood", entries[2].Message)
+	assert.Equal(t, map[string]interface{}{"repeat_count": int64(4)}, entries[2].ContextMap())
+
+	require.NoError(t, core.Sync())
+	assert.Equal(t, 3, logs.Len(), "Expected summaries to be written once.")
+}
+
+func TestDedupeFields(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+	core := NewDedupeCore(obs.With([]Field{makeInt64Field("shard", 1)}), time.Hour, DedupeFields("shard", "host"))
+	assert.Equal(t, []Field{makeInt64Field("shard", 1)}, AccumulatedFields(core))
+
+	host := func(h string) Field { return Field{Key: "host", Type: StringType, String: h} }
+	now := time.Now()
+	write := func(c Core, fields ...Field) {
+		require.NoError(t, c.Write(Entry{Message: "unreachable", Time: now}, fields))
+	}
+
+	write(core, host("a"))
+	write(core, host("a"))
+	write(core, host("b"))
+	write(core.With([]Field{makeInt64Field("shard", 2)}), host("a"))
+	write(core.With([]Field{host("a")}), host("c"), host("a"))
+	assert.Equal(t, 3, logs.Len(), "Expected entries to be deduplicated by shard and host.")
+
+	require.NoError(t, core.Sync())
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 4)
+	assert.Equal(t, int64(2), entries[3].ContextMap()["repeat_count"])
+}
+
+func TestLevelOfDedupeCore(t *testing.T) {
+	obs, _ := observer.New(WarnLevel)
+	core := NewDedupeCore(obs, time.Second, nil)
+	assert.Equal(t, WarnLevel, LevelOf(core))
+	assert.Nil(t, core.Check(Entry{Level: InfoLevel}, nil), "Expected disabled levels to be skipped.")
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/multierr"
+)
+
+// DuplicateKeyMode selects what a core built by NewDuplicateKeyCore does
+// with fields whose keys repeat within a single entry.
+type DuplicateKeyMode uint8
+
+const (
+	// DuplicateKeysLastWins drops all but the last field with a given key,
+	// mirroring how most JSON decoders resolve duplicates.
+	DuplicateKeysLastWins DuplicateKeyMode = iota
+	// DuplicateKeysRename keeps every field, appending "_2", "_3", and so on
+	// to the keys of repeated occurrences.
+	DuplicateKeysRename
+	// DuplicateKeysReport writes the entry unchanged and reports the
+	// duplicate keys as an error from Write, which loggers send to their
+	// ErrorOutput.
+	DuplicateKeysReport
+)
+
+// String returns a lower-case name for the mode.
+func (m DuplicateKeyMode) String() string {
+	switch m {
+	case DuplicateKeysLastWins:
+		return "last-wins"
+	case DuplicateKeysRename:
+		return "rename"
+	case DuplicateKeysReport:
+		return "report"
+	default:
+		return fmt.Sprintf("DuplicateKeyMode(%d)", m)
+	}
+}
+
+// DuplicateKeyOption configures a core built by NewDuplicateKeyCore.
+type DuplicateKeyOption interface {
+	apply(*duplicateKeyCore)
+}
+
+type duplicateKeyOptionFunc func(*duplicateKeyCore)
+
+func (f duplicateKeyOptionFunc) apply(c *duplicateKeyCore) {
+	f(c)
+}
+
+// DuplicateKeyHook registers a function called with the keys that were
+// repeated in an entry, in any mode. Keys inside namespaces are reported
+// with the namespace path, as in "ns.key".
+func DuplicateKeyHook(hook func(ent Entry, keys []string)) DuplicateKeyOption {
+	return duplicateKeyOptionFunc(func(c *duplicateKeyCore) {
+		c.hook = hook
+	})
+}
+
+// NewDuplicateKeyCore wraps a core so that duplicate field keys in an entry
+// are detected and resolved according to mode. Fields added with With are
+// considered together with the fields passed when logging, and keys are
+// compared within the namespace they were added to.
+//
+// To see fields from With, the returned core holds them itself instead of
+// passing them to the wrapped core, so the wrapped core re-encodes them for
+// every entry. The wrapped core's Check method is also bypassed, so it
+// should be wrapped around the core that does the encoding, and any sampling
+// applied outside of it.
+func NewDuplicateKeyCore(core Core, mode DuplicateKeyMode, opts ...DuplicateKeyOption) Core {
+	c := &duplicateKeyCore{
+		Core: core,
+		mode: mode,
+	}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+type duplicateKeyCore struct {
+	Core
+
+	mode   DuplicateKeyMode
+	hook   func(Entry, []string)
+	fields []Field
+}
+
+var (
+	_ Core             = (*duplicateKeyCore)(nil)
+	_ leveledEnabler   = (*duplicateKeyCore)(nil)
+	_ fieldAccumulator = (*duplicateKeyCore)(nil)
+)
+
+func (c *duplicateKeyCore) Level() Level {
+	return LevelOf(c.Core)
+}
+
+func (c *duplicateKeyCore) AccumulatedFields() []Field {
+	base := AccumulatedFields(c.Core)
+	if len(c.fields) == 0 {
+		return base
+	}
+	return append(base[:len(base):len(base)], c.fields...)
+}
+
+func (c *duplicateKeyCore) With(fields []Field) Core {
+	if len(fields) == 0 {
+		return c
+	}
+	clone := *c
+	clone.fields = make([]Field, 0, len(c.fields)+len(fields))
+	clone.fields = append(clone.fields, c.fields...)
+	clone.fields = append(clone.fields, fields...)
+	return &clone
+}
+
+func (c *duplicateKeyCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *duplicateKeyCore) Write(ent Entry, fields []Field) error {
+	all := fields
+	if len(c.fields) > 0 {
+		all = make([]Field, 0, len(c.fields)+len(fields))
+		all = append(all, c.fields...)
+		all = append(all, fields...)
+	}
+
+	all, dups := c.resolve(all)
+	if len(dups) > 0 && c.hook != nil {
+		c.hook(ent, dups)
+	}
+
+	err := c.Core.Write(ent, all)
+	if c.mode == DuplicateKeysReport && len(dups) > 0 {
+		err = multierr.Append(err, fmt.Errorf("duplicate keys in entry %q: %s", ent.Message, strings.Join(dups, ", ")))
+	}
+	return err
+}
+
+// resolve finds duplicate keys in fields and resolves them according to
+// c.mode. It returns the fields to write and the duplicated keys, and
+// copies fields before modifying them.
+func (c *duplicateKeyCore) resolve(fields []Field) ([]Field, []string) {
+	var (
+		scope  string         // enclosing namespaces, each followed by "."
+		scopes []string       // scopes enclosing the current one, innermost last
+		seen   map[string]int // scoped key -> index of its last occurrence
+		dups   []string
+		copied bool
+	)
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		if f.Type == SkipType {
+			continue
+		}
+		if f.Type == CloseNamespaceType {
+			if n := len(scopes); n > 0 {
+				scope, scopes = scopes[n-1], scopes[:n-1]
+			}
+			continue
+		}
+		if seen == nil {
+			seen = make(map[string]int, len(fields))
+		}
+
+		key := scope + f.Key
+		prev, ok := seen[key]
+		if ok {
+			dups = append(dups, key)
+			if c.mode != DuplicateKeysReport && !copied {
+				fields = append([]Field(nil), fields...)
+				copied = true
+			}
+
+			switch c.mode {
+			case DuplicateKeysLastWins:
+				if fields[prev].Type == NamespaceType {
+					// Dropping a namespace would spill its contents into
+					// the parent, so drop the later field instead.
+					fields[i] = Field{Type: SkipType}
+					continue
+				}
+				fields[prev] = Field{Type: SkipType}
+			case DuplicateKeysRename:
+				for n := 2; ok; n++ {
+					f.Key = fields[i].Key + "_" + strconv.Itoa(n)
+					key = scope + f.Key
+					_, ok = seen[key]
+				}
+				fields[i] = f
+			}
+		}
+
+		seen[key] = i
+		if f.Type == NamespaceType {
+			scopes = append(scopes, scope)
+			scope = key + "."
+		}
+	}
+	return fields, dups
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestDuplicateKeyCore(t *testing.T) {
+	str := func(k, v string) Field { return Field{Key: k, Type: StringType, String: v} }
+	ns := func(k string) Field { return Field{Key: k, Type: NamespaceType} }
+
+	context := []Field{str("a", "ctx"), ns("req"), str("id", "ctx")}
+	fields := []Field{str("id", "1"), str("b", "x"), str("id", "2")}
+
+	tests := []struct {
+		mode    DuplicateKeyMode
+		want    []Field
+		wantErr string
+	}{
+		{
+			mode: DuplicateKeysLastWins,
+			want: []Field{
+				str("a", "ctx"), ns("req"), {Type: SkipType},
+				{Type: SkipType}, str("b", "x"), str("id", "2"),
+			},
+		},
+		{
+			mode: DuplicateKeysRename,
+			want: []Field{
+				str("a", "ctx"), ns("req"), str("id", "ctx"),
+				str("id_2", "1"), str("b", "x"), str("id_3", "2"),
+			},
+		},
+		{
+			mode:    DuplicateKeysReport,
+			want:    append(append([]Field(nil), context...), fields...),
+			wantErr: `duplicate keys in entry "msg": req.id, req.id`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			obs, logs := observer.New(DebugLevel)
+			var hooked []string
+			core := NewDuplicateKeyCore(obs, tt.mode, DuplicateKeyHook(func(_ Entry, keys []string) {
+				hooked = append(hooked, keys...)
+			})).With(context)
+
+			ent := Entry{Level: InfoLevel, Message: "msg"}
+			ce := core.Check(ent, nil)
+			require.NotNil(t, ce)
+
+			given := append([]Field(nil), fields...)
+			err := core.Write(ent, given)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, fields, given, "Caller's fields must not be modified.")
+			assert.Equal(t, []string{"req.id", "req.id"}, hooked)
+
+			entries := logs.AllUntimed()
+			require.Len(t, entries, 1)
+			assert.Equal(t, tt.want, entries[0].Context)
+		})
+	}
+}
+
+func TestDuplicateKeyCoreScopes(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+	core := NewDuplicateKeyCore(obs, DuplicateKeysRename)
+
+	fields := []Field{
+		{Key: "a", Type: Int64Type, Integer: 1},
+		{Key: "a_2", Type: Int64Type, Integer: 2},
+		{Key: "a", Type: NamespaceType},
+		{Key: "a", Type: Int64Type, Integer: 3},
+	}
+	require.NoError(t, core.Write(Entry{}, fields))
+
+	// The second "a" would be renamed to "a_2", which is taken. Keys inside
+	// the renamed namespace are scoped to it and don't clash.
+	assert.Equal(t, []Field{
+		{Key: "a", Type: Int64Type, Integer: 1},
+		{Key: "a_2", Type: Int64Type, Integer: 2},
+		{Key: "a_3", Type: NamespaceType},
+		{Key: "a", Type: Int64Type, Integer: 3},
+	}, logs.AllUntimed()[0].Context)
+}
+
+func TestDuplicateKeyCoreNoDuplicates(t *testing.T) {
+	obs, logs := observer.New(InfoLevel)
+	var called bool
+	core := NewDuplicateKeyCore(obs, DuplicateKeysReport, DuplicateKeyHook(func(Entry, []string) {
+		called = true
+	}))
+
+	assert.Nil(t, core.Check(Entry{Level: DebugLevel}, nil), "Expected disabled level to be filtered.")
+	assert.Equal(t, InfoLevel, LevelOf(core))
+
+	with := core.With([]Field{{Key: "a", Type: StringType, String: "1"}})
+	assert.Equal(t, []Field{{Key: "a", Type: StringType, String: "1"}}, AccumulatedFields(with))
+	assert.Equal(t, core, core.With(nil))
+
+	require.NoError(t, with.Write(Entry{Level: InfoLevel}, []Field{{Key: "b", Type: SkipType}, {Key: "b", Type: SkipType}}))
+	assert.False(t, called, "Hook must not be called without duplicates.")
+	assert.Equal(t, 1, logs.Len())
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import "go.uber.org/multierr"
+
+// NewDynamicFieldsCore wraps a core so that every entry is written with the
+// fields returned by fn, ahead of the entry's own fields. Unlike fields
+// bound with With, they're computed anew for each entry, on the goroutine
+// that writes it, so they can capture state such as goroutine-local labels.
+// fn may return nil to add no fields.
+func NewDynamicFieldsCore(core Core, fn func(Entry) []Field) Core {
+	return &dynamicFieldsCore{Core: core, fn: fn}
+}
+
+type dynamicFieldsCore struct {
+	Core
+
+	fn func(Entry) []Field
+}
+
+var (
+	_ Core             = (*dynamicFieldsCore)(nil)
+	_ leveledEnabler   = (*dynamicFieldsCore)(nil)
+	_ fieldAccumulator = (*dynamicFieldsCore)(nil)
+)
+
+func (c *dynamicFieldsCore) Level() Level {
+	return LevelOf(c.Core)
+}
+
+func (c *dynamicFieldsCore) AccumulatedFields() []Field {
+	return AccumulatedFields(c.Core)
+}
+
+func (c *dynamicFieldsCore) With(fields []Field) Core {
+	return &dynamicFieldsCore{Core: c.Core.With(fields), fn: c.fn}
+}
+
+func (c *dynamicFieldsCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *dynamicFieldsCore) Write(ent Entry, fields []Field) error {
+	ce := c.Core.Check(ent, nil)
+	if ce == nil {
+		return nil
+	}
+
+	if dynamic := c.fn(ent); len(dynamic) > 0 {
+		// Put the dynamic fields first, outside any namespace the entry's
+		// fields open.
+		all := make([]Field, 0, len(dynamic)+len(fields))
+		all = append(all, dynamic...)
+		fields = append(all, fields...)
+	}
+
+	var err error
+	for i := range ce.cores {
+		err = multierr.Append(err, ce.cores[i].Write(ent, fields))
+	}
+	putCheckedEntry(ce)
+	return err
+}
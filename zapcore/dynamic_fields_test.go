@@ -0,0 +1,85 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestDynamicFieldsCore(t *testing.T) {
+	obs, logs := observer.New(InfoLevel)
+	var calls int
+	core := NewDynamicFieldsCore(obs, func(ent Entry) []Field {
+		calls++
+		if ent.Message == "none" {
+			return nil
+		}
+		return []Field{makeInt64Field("call", calls)}
+	})
+	core = core.With([]Field{makeInt64Field("bound", 1)})
+
+	assert.Equal(t, InfoLevel, LevelOf(core), "Unexpected level.")
+	assert.Equal(t, []Field{makeInt64Field("bound", 1)}, AccumulatedFields(core), "Unexpected accumulated fields.")
+	assert.Nil(t, core.Check(Entry{Level: DebugLevel}, nil), "Expected disabled entries to be skipped.")
+	assert.Zero(t, calls, "Expected no fields to be computed for disabled entries.")
+
+	for _, msg := range []string{"first", "none", "second"} {
+		if ce := core.Check(Entry{Level: InfoLevel, Message: msg}, nil); ce != nil {
+			ce.Write(makeInt64Field("own", 0))
+		}
+	}
+	assert.Equal(t, []observer.LoggedEntry{
+		{
+			Entry:   Entry{Level: InfoLevel, Message: "first"},
+			Context: []Field{makeInt64Field("bound", 1), makeInt64Field("call", 1), makeInt64Field("own", 0)},
+		},
+		{
+			Entry:   Entry{Level: InfoLevel, Message: "none"},
+			Context: []Field{makeInt64Field("bound", 1), makeInt64Field("own", 0)},
+		},
+		{
+			Entry:   Entry{Level: InfoLevel, Message: "second"},
+			Context: []Field{makeInt64Field("bound", 1), makeInt64Field("call", 3), makeInt64Field("own", 0)},
+		},
+	}, logs.AllUntimed(), "Unexpected entries.")
+}
+
+func TestDynamicFieldsCoreRespectsWrappedCheck(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+	filtered := NewFilterCore(obs, MessageMatches(regexp.MustCompile("keep")))
+	core := NewDynamicFieldsCore(filtered, func(Entry) []Field {
+		return []Field{makeInt64Field("dynamic", 1)}
+	})
+
+	for _, msg := range []string{"keep", "drop"} {
+		if ce := core.Check(Entry{Level: InfoLevel, Message: msg}, nil); ce != nil {
+			ce.Write()
+		}
+	}
+	assert.Equal(t, 1, logs.Len(), "Expected the wrapped core's Check to apply.")
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/buffer"
+)
+
+// _ecsVersion is the version of the Elastic Common Schema that the ECS
+// encoder follows.
+const _ecsVersion = "1.6.0"
+
+// ECS field names for the caller, which the ECS encoder splits into
+// separate fields.
+const (
+	_ecsFileNameKey = "log.origin.file.name"
+	_ecsFileLineKey = "log.origin.file.line"
+	_ecsFunctionKey = "log.origin.function"
+)
+
+// _ecsFieldKeys maps commonly used field keys, including those logged by
+// zaphttp, onto their ECS equivalents.
+var _ecsFieldKeys = map[string]string{
+	"error":            "error.message",
+	"errorVerbose":     "error.stack_trace",
+	"trace_id":         "trace.id",
+	"traceID":          "trace.id",
+	"traceId":          "trace.id",
+	"span_id":          "span.id",
+	"spanID":           "span.id",
+	"spanId":           "span.id",
+	"transaction_id":   "transaction.id",
+	"service":          "service.name",
+	"hostname":         "host.hostname",
+	"user_id":          "user.id",
+	"duration":         "event.duration",
+	"http.method":      "http.request.method",
+	"http.path":        "url.path",
+	"http.status":      "http.response.status_code",
+	"http.bytes":       "http.response.body.bytes",
+	"http.duration":    "event.duration",
+	"http.remote_addr": "client.address",
+}
+
+type ecsEncoder struct {
+	Encoder
+
+	caller   bool
+	function bool
+
+	// prefix holds the names of open namespaces, each followed by a period.
+	prefix string
+	// namespaces holds the length of prefix before each open namespace,
+	// innermost last.
+	namespaces []int
+}
+
+// NewECSEncoder creates a JSON encoder that emits Elastic Common Schema
+// (ECS) documents, so that Elasticsearch and Kibana can use entries without
+// a translation layer. Pair it with an EncoderConfig that uses ECS keys,
+// such as the one returned by zap.NewECSEncoderConfig.
+//
+// On top of the JSON encoder, it
+//   - adds an ecs.version field to every entry;
+//   - splits the caller into log.origin.file.name and log.origin.file.line,
+//     and writes the function as log.origin.function, when CallerKey and
+//     FunctionKey are set;
+//   - writes errors logged under the "error" key as error.message,
+//     error.type, and, for errors with verbose forms, error.stack_trace;
+//   - renames well-known field keys, such as trace_id and span_id, to
+//     their ECS equivalents (trace.id and span.id); and
+//   - writes namespaces as dotted key prefixes, which Elasticsearch treats
+//     as nested objects.
+func NewECSEncoder(cfg EncoderConfig) Encoder {
+	e := &ecsEncoder{
+		caller:   cfg.CallerKey != OmitKey,
+		function: cfg.FunctionKey != OmitKey,
+	}
+	cfg.CallerKey = OmitKey
+	cfg.FunctionKey = OmitKey
+	e.Encoder = NewJSONEncoder(cfg)
+	e.Encoder.AddString("ecs.version", _ecsVersion)
+	return e
+}
+
+// key returns the ECS key for a field.
+func (e *ecsEncoder) key(k string) string {
+	if e.prefix != "" {
+		return e.prefix + k
+	}
+	if ecs, ok := _ecsFieldKeys[k]; ok {
+		return ecs
+	}
+	return k
+}
+
+func (e *ecsEncoder) AddArray(k string, v ArrayMarshaler) error {
+	return e.Encoder.AddArray(e.key(k), v)
+}
+
+func (e *ecsEncoder) AddObject(k string, v ObjectMarshaler) error {
+	return e.Encoder.AddObject(e.key(k), v)
+}
+
+func (e *ecsEncoder) AddReflected(k string, v interface{}) error {
+	return e.Encoder.AddReflected(e.key(k), v)
+}
+
+// AddError writes errors logged under the "error" key with their ECS
+// error.type, in addition to the fields written for all errors.
+func (e *ecsEncoder) AddError(k string, err error) error {
+	if e.prefix == "" && k == "error" {
+		e.Encoder.AddString("error.type", fmt.Sprintf("%T", err))
+	}
+	return encodeError(k, err, e)
+}
+
+func (e *ecsEncoder) AddRawJSON(k string, v []byte) {
+	addRawJSON(e.Encoder, e.key(k), v)
+}
+
+// EncodingName implements PreEncodedEncoder, accepting the values of the
+// wrapped encoder, if it accepts any.
+func (e *ecsEncoder) EncodingName() string {
+	if pe, ok := e.Encoder.(PreEncodedEncoder); ok {
+		return pe.EncodingName()
+	}
+	return ""
+}
+
+func (e *ecsEncoder) AddPreEncoded(k string, v []byte) {
+	if pe, ok := e.Encoder.(PreEncodedEncoder); ok {
+		pe.AddPreEncoded(e.key(k), v)
+		return
+	}
+	e.Encoder.AddByteString(e.key(k), v)
+}
+
+func (e *ecsEncoder) OpenNamespace(k string) {
+	e.namespaces = append(e.namespaces, len(e.prefix))
+	e.prefix = e.key(k) + "."
+}
+
+func (e *ecsEncoder) CloseNamespace() {
+	if n := len(e.namespaces); n > 0 {
+		e.prefix = e.prefix[:e.namespaces[n-1]]
+		e.namespaces = e.namespaces[:n-1]
+	}
+}
+
+func (e *ecsEncoder) AddBinary(k string, v []byte)          { e.Encoder.AddBinary(e.key(k), v) }
+func (e *ecsEncoder) AddByteString(k string, v []byte)      { e.Encoder.AddByteString(e.key(k), v) }
+func (e *ecsEncoder) AddBool(k string, v bool)              { e.Encoder.AddBool(e.key(k), v) }
+func (e *ecsEncoder) AddComplex128(k string, v complex128)  { e.Encoder.AddComplex128(e.key(k), v) }
+func (e *ecsEncoder) AddComplex64(k string, v complex64)    { e.Encoder.AddComplex64(e.key(k), v) }
+func (e *ecsEncoder) AddDuration(k string, v time.Duration) { e.Encoder.AddDuration(e.key(k), v) }
+func (e *ecsEncoder) AddFloat64(k string, v float64)        { e.Encoder.AddFloat64(e.key(k), v) }
+func (e *ecsEncoder) AddFloat32(k string, v float32)        { e.Encoder.AddFloat32(e.key(k), v) }
+func (e *ecsEncoder) AddInt(k string, v int)                { e.Encoder.AddInt(e.key(k), v) }
+func (e *ecsEncoder) AddInt64(k string, v int64)            { e.Encoder.AddInt64(e.key(k), v) }
+func (e *ecsEncoder) AddInt32(k string, v int32)            { e.Encoder.AddInt32(e.key(k), v) }
+func (e *ecsEncoder) AddInt16(k string, v int16)            { e.Encoder.AddInt16(e.key(k), v) }
+func (e *ecsEncoder) AddInt8(k string, v int8)              { e.Encoder.AddInt8(e.key(k), v) }
+func (e *ecsEncoder) AddString(k, v string)                 { e.Encoder.AddString(e.key(k), v) }
+func (e *ecsEncoder) AddTime(k string, v time.Time)         { e.Encoder.AddTime(e.key(k), v) }
+func (e *ecsEncoder) AddUint(k string, v uint)              { e.Encoder.AddUint(e.key(k), v) }
+func (e *ecsEncoder) AddUint64(k string, v uint64)          { e.Encoder.AddUint64(e.key(k), v) }
+func (e *ecsEncoder) AddUint32(k string, v uint32)          { e.Encoder.AddUint32(e.key(k), v) }
+func (e *ecsEncoder) AddUint16(k string, v uint16)          { e.Encoder.AddUint16(e.key(k), v) }
+func (e *ecsEncoder) AddUint8(k string, v uint8)            { e.Encoder.AddUint8(e.key(k), v) }
+func (e *ecsEncoder) AddUintptr(k string, v uintptr)        { e.Encoder.AddUintptr(e.key(k), v) }
+
+func (e *ecsEncoder) Clone() Encoder {
+	return e.clone()
+}
+
+func (e *ecsEncoder) clone() *ecsEncoder {
+	return &ecsEncoder{
+		Encoder:  e.Encoder.Clone(),
+		caller:   e.caller,
+		function: e.function,
+		prefix:   e.prefix,
+		// Cap the slice so that appending to the clone's namespaces never
+		// overwrites the original's.
+		namespaces: e.namespaces[:len(e.namespaces):len(e.namespaces)],
+	}
+}
+
+func (e *ecsEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer, error) {
+	final := e.clone()
+	if ent.Caller.Defined {
+		// Namespaces are only prefixes, so these always land at the top level.
+		if e.caller {
+			file := strings.TrimSuffix(ent.Caller.TrimmedPath(), ":"+strconv.Itoa(ent.Caller.Line))
+			final.Encoder.AddString(_ecsFileNameKey, file)
+			final.Encoder.AddInt(_ecsFileLineKey, ent.Caller.Line)
+		}
+		if e.function {
+			final.Encoder.AddString(_ecsFunctionKey, ent.Caller.Function)
+		}
+	}
+	addFields(final, fields)
+	return final.Encoder.EncodeEntry(ent, nil)
+}
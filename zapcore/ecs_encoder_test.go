@@ -0,0 +1,132 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+)
+
+func ecsTestEncoderConfig() EncoderConfig {
+	return EncoderConfig{
+		TimeKey:        "@timestamp",
+		LevelKey:       "log.level",
+		NameKey:        "log.logger",
+		CallerKey:      "log.origin.file.name",
+		FunctionKey:    "log.origin.function",
+		MessageKey:     "message",
+		StacktraceKey:  "log.origin.stack_trace",
+		EncodeLevel:    LowercaseLevelEncoder,
+		EncodeTime:     RFC3339TimeEncoder,
+		EncodeDuration: NanosDurationEncoder,
+		EncodeCaller:   ShortCallerEncoder,
+	}
+}
+
+func decodeECS(t *testing.T, enc Encoder, ent Entry, fields ...Field) map[string]interface{} {
+	buf, err := enc.EncodeEntry(ent, fields)
+	require.NoError(t, err, "Unexpected encoding error.")
+	defer buf.Free()
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got), "Invalid JSON: %s", buf.String())
+	return got
+}
+
+func TestECSEncoder(t *testing.T) {
+	enc := NewECSEncoder(ecsTestEncoderConfig())
+	enc.AddString("trace_id", "abc")
+	Field{Key: "error", Type: ErrorType, Interface: errTooFewUsers(2)}.AddTo(enc)
+
+	ent := Entry{
+		Level:      ErrorLevel,
+		Time:       time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		LoggerName: "main",
+		Message:    "failed",
+		Caller:     EntryCaller{Defined: true, File: "/src/app/main.go", Line: 42, Function: "main.run"},
+		Stack:      "goroutine 1",
+	}
+	got := decodeECS(t, enc, ent,
+		Field{Key: "spanID", Type: StringType, String: "def"},
+		Field{Key: "http.status", Type: Int64Type, Integer: 503},
+		Field{Key: "http.duration", Type: DurationType, Integer: int64(time.Millisecond)},
+		Field{Key: "custom", Type: BoolType, Integer: 1},
+	)
+
+	assert.Equal(t, map[string]interface{}{
+		"ecs.version":               "1.6.0",
+		"@timestamp":                "2020-01-02T03:04:05Z",
+		"log.level":                 "error",
+		"log.logger":                "main",
+		"log.origin.file.name":      "app/main.go",
+		"log.origin.file.line":      42.0,
+		"log.origin.function":       "main.run",
+		"log.origin.stack_trace":    "goroutine 1",
+		"message":                   "failed",
+		"error.message":             "2 too few users",
+		"error.type":                "zapcore_test.errTooFewUsers",
+		"error.stack_trace":         "verbose: 2 too few users",
+		"trace.id":                  "abc",
+		"span.id":                   "def",
+		"http.response.status_code": 503.0,
+		"event.duration":            1e6,
+		"custom":                    true,
+	}, got, "Unexpected ECS document.")
+}
+
+func TestECSEncoderCallerKeys(t *testing.T) {
+	cfg := ecsTestEncoderConfig()
+	cfg.CallerKey = OmitKey
+	cfg.FunctionKey = OmitKey
+	ent := Entry{Caller: EntryCaller{Defined: true, File: "main.go", Line: 1, Function: "main.main"}}
+
+	got := decodeECS(t, NewECSEncoder(cfg), ent)
+	assert.NotContains(t, got, "log.origin.file.name")
+	assert.NotContains(t, got, "log.origin.file.line")
+	assert.NotContains(t, got, "log.origin.function")
+}
+
+func TestECSEncoderNamespaces(t *testing.T) {
+	enc := NewECSEncoder(ecsTestEncoderConfig())
+	enc.OpenNamespace("outer")
+	clone := enc.Clone()
+	clone.OpenNamespace("inner")
+
+	ent := Entry{Caller: EntryCaller{Defined: true, File: "main.go", Line: 7}}
+	got := decodeECS(t, clone, ent,
+		Field{Key: "trace_id", Type: StringType, String: "abc"},
+		Field{Key: "error", Type: ErrorType, Interface: errTooFewUsers(1)},
+	)
+	assert.Equal(t, "abc", got["outer.inner.trace_id"], "Keys in namespaces shouldn't be renamed.")
+	assert.Equal(t, "1 too few users", got["outer.inner.error"])
+	assert.NotContains(t, got, "error.type", "Errors in namespaces shouldn't get ECS error fields.")
+	assert.Equal(t, 7.0, got["log.origin.file.line"], "Caller should stay at the top level.")
+
+	got = decodeECS(t, enc, Entry{}, Field{Key: "a", Type: StringType, String: "b"})
+	assert.Equal(t, "b", got["outer.a"], "Clone modified the original encoder.")
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import "fmt"
+
+// An EntryPart names one part of an encoded log entry, for use in
+// EncoderConfig.EncodeOrder.
+type EntryPart string
+
+// Parts of a log entry that can be ordered with EncoderConfig.EncodeOrder.
+const (
+	TimePart       EntryPart = "time"
+	LevelPart      EntryPart = "level"
+	NamePart       EntryPart = "name"
+	CallerPart     EntryPart = "caller"
+	FunctionPart   EntryPart = "function"
+	EventIDPart    EntryPart = "eventID"
+	MessagePart    EntryPart = "message"
+	FieldsPart     EntryPart = "fields"
+	StacktracePart EntryPart = "stacktrace"
+)
+
+var (
+	_jsonDefaultOrder = []EntryPart{
+		LevelPart, TimePart, NamePart, CallerPart, FunctionPart,
+		EventIDPart, MessagePart, FieldsPart, StacktracePart,
+	}
+	_consoleDefaultOrder = []EntryPart{
+		TimePart, LevelPart, NamePart, CallerPart, FunctionPart,
+		EventIDPart, MessagePart, FieldsPart, StacktracePart,
+	}
+)
+
+// UnmarshalText unmarshals text to an EntryPart, rejecting unknown names.
+func (p *EntryPart) UnmarshalText(text []byte) error {
+	switch part := EntryPart(text); part {
+	case TimePart, LevelPart, NamePart, CallerPart, FunctionPart,
+		EventIDPart, MessagePart, FieldsPart, StacktracePart:
+		*p = part
+		return nil
+	default:
+		return fmt.Errorf("unknown entry part: %q", text)
+	}
+}
+
+// resolveEncodeOrder returns the order in which an encoder with the given
+// default order should write the parts of each entry: the parts listed in
+// order first, followed by the rest in their default order. Unknown and
+// repeated parts are ignored.
+func resolveEncodeOrder(order, defaults []EntryPart) []EntryPart {
+	if len(order) == 0 {
+		return defaults
+	}
+
+	resolved := make([]EntryPart, 0, len(defaults))
+	has := func(p EntryPart) bool {
+		for _, q := range resolved {
+			if p == q {
+				return true
+			}
+		}
+		return false
+	}
+	for _, p := range order {
+		for _, d := range defaults {
+			if p == d && !has(p) {
+				resolved = append(resolved, p)
+			}
+		}
+	}
+	for _, d := range defaults {
+		if !has(d) {
+			resolved = append(resolved, d)
+		}
+	}
+	return resolved
+}
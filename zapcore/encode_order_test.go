@@ -0,0 +1,115 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+)
+
+func TestEncodeOrder(t *testing.T) {
+	ent := Entry{
+		Level:   InfoLevel,
+		Message: "hello",
+		Time:    _epoch,
+		Stack:   "fake-stack",
+		Caller:  EntryCaller{Defined: true, File: "foo.go", Line: 42, Function: "foo.Foo"},
+	}
+	fields := []Field{{Key: "k", Type: StringType, String: "v"}}
+
+	tests := []struct {
+		desc        string
+		order       []EntryPart
+		wantJSON    string
+		wantConsole string
+	}{
+		{
+			desc:        "default",
+			wantJSON:    `{"level":"info","ts":0,"caller":"foo.go:42","func":"foo.Foo","msg":"hello","k":"v","stacktrace":"fake-stack"}`,
+			wantConsole: "0\tinfo\tfoo.go:42\tfoo.Foo\thello\t{\"k\": \"v\"}\nfake-stack",
+		},
+		{
+			desc:        "time first, message last",
+			order:       []EntryPart{TimePart, LevelPart, FieldsPart, StacktracePart, MessagePart},
+			wantJSON:    `{"ts":0,"level":"info","k":"v","stacktrace":"fake-stack","msg":"hello","caller":"foo.go:42","func":"foo.Foo"}`,
+			wantConsole: "0\tinfo\t{\"k\": \"v\"}\nfake-stack\thello\tfoo.go:42\tfoo.Foo",
+		},
+		{
+			desc:        "unknown and repeated parts",
+			order:       []EntryPart{MessagePart, "bogus", MessagePart},
+			wantJSON:    `{"msg":"hello","level":"info","ts":0,"caller":"foo.go:42","func":"foo.Foo","k":"v","stacktrace":"fake-stack"}`,
+			wantConsole: "hello\t0\tinfo\tfoo.go:42\tfoo.Foo\t{\"k\": \"v\"}\nfake-stack",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cfg := testEncoderConfig()
+			cfg.SkipLineEnding = true
+			cfg.EncodeOrder = tt.order
+
+			encode := func(enc Encoder) string {
+				buf, err := enc.EncodeEntry(ent, fields)
+				require.NoError(t, err)
+				defer buf.Free()
+				return buf.String()
+			}
+			assert.Equal(t, tt.wantJSON, encode(NewJSONEncoder(cfg)), "Unexpected JSON output.")
+			assert.Equal(t, tt.wantConsole, encode(NewConsoleEncoder(cfg)), "Unexpected console output.")
+		})
+	}
+}
+
+func TestEncodeOrderWithNamespace(t *testing.T) {
+	cfg := testEncoderConfig()
+	cfg.SkipLineEnding = true
+	cfg.TimeKey = ""
+	cfg.EncodeOrder = []EntryPart{FieldsPart}
+
+	enc := NewJSONEncoder(cfg)
+	enc.OpenNamespace("ns")
+	enc.AddString("k", "v")
+
+	buf, err := enc.EncodeEntry(Entry{Level: InfoLevel, Message: "hello"}, nil)
+	require.NoError(t, err)
+	defer buf.Free()
+	assert.Equal(t, `{"ns":{"k":"v"},"level":"info","msg":"hello"}`, buf.String(),
+		"Expected namespaces to close before the remaining parts.")
+}
+
+func TestEntryPartUnmarshal(t *testing.T) {
+	var cfg EncoderConfig
+	require.NoError(t, json.Unmarshal([]byte(`{"encodeOrder": ["time", "message"]}`), &cfg))
+	assert.Equal(t, []EntryPart{TimePart, MessagePart}, cfg.EncodeOrder)
+
+	require.NoError(t, yaml.Unmarshal([]byte("encodeOrder: [stacktrace]"), &cfg))
+	assert.Equal(t, []EntryPart{StacktracePart}, cfg.EncodeOrder)
+
+	err := json.Unmarshal([]byte(`{"encodeOrder": ["timestamp"]}`), &cfg)
+	assert.ErrorContains(t, err, `unknown entry part: "timestamp"`)
+}
@@ -22,7 +22,10 @@ package zapcore
 
 import (
 	"encoding/json"
-	"io"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.uber.org/zap/buffer"
@@ -53,7 +56,11 @@ func LowercaseLevelEncoder(l Level, enc PrimitiveArrayEncoder) {
 func LowercaseColorLevelEncoder(l Level, enc PrimitiveArrayEncoder) {
 	s, ok := _levelToLowercaseColorString[l]
 	if !ok {
-		s = _unknownLevelColor.Add(l.String())
+		if cl := lookupCustomLevel(l); cl != nil {
+			s = cl.lowercaseColored
+		} else {
+			s = _unknownLevelColor.Add(l.String())
+		}
 	}
 	enc.AppendString(s)
 }
@@ -69,7 +76,11 @@ func CapitalLevelEncoder(l Level, enc PrimitiveArrayEncoder) {
 func CapitalColorLevelEncoder(l Level, enc PrimitiveArrayEncoder) {
 	s, ok := _levelToCapitalColorString[l]
 	if !ok {
-		s = _unknownLevelColor.Add(l.CapitalString())
+		if cl := lookupCustomLevel(l); cl != nil {
+			s = cl.capitalColored
+		} else {
+			s = _unknownLevelColor.Add(l.CapitalString())
+		}
 	}
 	enc.AppendString(s)
 }
@@ -98,9 +109,23 @@ func (e *LevelEncoder) UnmarshalText(text []byte) error {
 // to a PrimitiveArrayEncoder's Append* method.
 type TimeEncoder func(time.Time, PrimitiveArrayEncoder)
 
+// appendTimeUnixEncoder is implemented by encoders that can write a time as
+// an exact decimal number of units since the Unix epoch without going
+// through a float64.
+type appendTimeUnixEncoder interface {
+	AppendTimeUnix(time.Time, time.Duration)
+}
+
 // EpochTimeEncoder serializes a time.Time to a floating-point number of seconds
 // since the Unix epoch.
+//
+// If enc supports AppendTimeUnix(t time.Time, unit time.Duration), it's used
+// instead of appending a float64.
 func EpochTimeEncoder(t time.Time, enc PrimitiveArrayEncoder) {
+	if enc, ok := enc.(appendTimeUnixEncoder); ok {
+		enc.AppendTimeUnix(t, time.Second)
+		return
+	}
 	nanos := t.UnixNano()
 	sec := float64(nanos) / float64(time.Second)
 	enc.AppendFloat64(sec)
@@ -108,12 +133,25 @@ func EpochTimeEncoder(t time.Time, enc PrimitiveArrayEncoder) {
 
 // EpochMillisTimeEncoder serializes a time.Time to a floating-point number of
 // milliseconds since the Unix epoch.
+//
+// If enc supports AppendTimeUnix(t time.Time, unit time.Duration), it's used
+// instead of appending a float64.
 func EpochMillisTimeEncoder(t time.Time, enc PrimitiveArrayEncoder) {
+	if enc, ok := enc.(appendTimeUnixEncoder); ok {
+		enc.AppendTimeUnix(t, time.Millisecond)
+		return
+	}
 	nanos := t.UnixNano()
 	millis := float64(nanos) / float64(time.Millisecond)
 	enc.AppendFloat64(millis)
 }
 
+// EpochMicrosTimeEncoder serializes a time.Time to an integer number of
+// microseconds since the Unix epoch.
+func EpochMicrosTimeEncoder(t time.Time, enc PrimitiveArrayEncoder) {
+	enc.AppendInt64(t.UnixNano() / int64(time.Microsecond))
+}
+
 // EpochNanosTimeEncoder serializes a time.Time to an integer number of
 // nanoseconds since the Unix epoch.
 func EpochNanosTimeEncoder(t time.Time, enc PrimitiveArrayEncoder) {
@@ -167,14 +205,44 @@ func TimeEncoderOfLayout(layout string) TimeEncoder {
 	}
 }
 
+// _timeLayouts maps the lower-cased names of the standard library's layout
+// constants to their layouts, for use in configuration.
+var _timeLayouts = map[string]string{
+	"ansic":      time.ANSIC,
+	"unixdate":   time.UnixDate,
+	"rubydate":   time.RubyDate,
+	"rfc822":     time.RFC822,
+	"rfc822z":    time.RFC822Z,
+	"rfc850":     time.RFC850,
+	"rfc1123":    time.RFC1123,
+	"rfc1123z":   time.RFC1123Z,
+	"kitchen":    time.Kitchen,
+	"stamp":      time.Stamp,
+	"stampmilli": time.StampMilli,
+	"stampmicro": time.StampMicro,
+	"stampnano":  time.StampNano,
+	"datetime":   "2006-01-02 15:04:05",
+	"dateonly":   "2006-01-02",
+	"timeonly":   "15:04:05",
+}
+
 // UnmarshalText unmarshals text to a TimeEncoder.
 // "rfc3339nano" and "RFC3339Nano" are unmarshaled to RFC3339NanoTimeEncoder.
 // "rfc3339" and "RFC3339" are unmarshaled to RFC3339TimeEncoder.
 // "iso8601" and "ISO8601" are unmarshaled to ISO8601TimeEncoder.
 // "millis" is unmarshaled to EpochMillisTimeEncoder.
+// "micros" is unmarshaled to EpochMicrosTimeEncoder.
 // "nanos" is unmarshaled to EpochNanosEncoder.
+// The names of the time package's layout constants, such as "RFC1123",
+// "Kitchen", or "DateTime", are matched case-insensitively and unmarshaled
+// to a TimeEncoder using that layout.
 // Anything else is unmarshaled to EpochTimeEncoder.
 func (e *TimeEncoder) UnmarshalText(text []byte) error {
+	if layout, ok := _timeLayouts[strings.ToLower(string(text))]; ok {
+		*e = TimeEncoderOfLayout(layout)
+		return nil
+	}
+
 	switch string(text) {
 	case "rfc3339nano", "RFC3339Nano":
 		*e = RFC3339NanoTimeEncoder
@@ -184,6 +252,8 @@ func (e *TimeEncoder) UnmarshalText(text []byte) error {
 		*e = ISO8601TimeEncoder
 	case "millis":
 		*e = EpochMillisTimeEncoder
+	case "micros":
+		*e = EpochMicrosTimeEncoder
 	case "nanos":
 		*e = EpochNanosTimeEncoder
 	default:
@@ -194,6 +264,8 @@ func (e *TimeEncoder) UnmarshalText(text []byte) error {
 
 // UnmarshalYAML unmarshals YAML to a TimeEncoder.
 // If value is an object with a "layout" field, it will be unmarshaled to  TimeEncoder with given layout.
+// The layout uses the reference time of the time package and must not be
+// empty.
 //
 //	timeEncoder:
 //	  layout: 06/01/02 03:04pm
@@ -206,6 +278,9 @@ func (e *TimeEncoder) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		Layout string `json:"layout" yaml:"layout"`
 	}
 	if err := unmarshal(&o); err == nil {
+		if o.Layout == "" {
+			return errors.New("time encoder layout must not be empty")
+		}
 		*e = TimeEncoderOfLayout(o.Layout)
 		return nil
 	}
@@ -231,7 +306,18 @@ func (e *TimeEncoder) UnmarshalJSON(data []byte) error {
 type DurationEncoder func(time.Duration, PrimitiveArrayEncoder)
 
 // SecondsDurationEncoder serializes a time.Duration to a floating-point number of seconds elapsed.
+//
+// If enc supports AppendDurationUnit(d, unit time.Duration), it's used
+// instead of appending a float64.
 func SecondsDurationEncoder(d time.Duration, enc PrimitiveArrayEncoder) {
+	type appendDurationEncoder interface {
+		AppendDurationUnit(time.Duration, time.Duration)
+	}
+
+	if enc, ok := enc.(appendDurationEncoder); ok {
+		enc.AppendDurationUnit(d, time.Second)
+		return
+	}
 	enc.AppendFloat64(float64(d) / float64(time.Second))
 }
 
@@ -253,9 +339,81 @@ func StringDurationEncoder(d time.Duration, enc PrimitiveArrayEncoder) {
 	enc.AppendString(d.String())
 }
 
+// RoundMillisDurationEncoder serializes a time.Duration to an integer number
+// of milliseconds, rounded to the nearest millisecond rather than truncated
+// like MillisDurationEncoder.
+func RoundMillisDurationEncoder(d time.Duration, enc PrimitiveArrayEncoder) {
+	enc.AppendInt64(int64(d.Round(time.Millisecond) / time.Millisecond))
+}
+
+// RoundMicrosDurationEncoder serializes a time.Duration to an integer number
+// of microseconds, rounded to the nearest microsecond.
+func RoundMicrosDurationEncoder(d time.Duration, enc PrimitiveArrayEncoder) {
+	enc.AppendInt64(int64(d.Round(time.Microsecond) / time.Microsecond))
+}
+
+// HumanDurationEncoder serializes a time.Duration to a short, readable
+// string with three significant digits in the largest fitting unit, such as
+// "1.5s", "230ms", or "4µs". Durations of a minute or more are rounded to
+// the second and written like time.Duration's String method, as in "1m23s".
+func HumanDurationEncoder(d time.Duration, enc PrimitiveArrayEncoder) {
+	enc.AppendString(humanDuration(d))
+}
+
+func humanDuration(d time.Duration) string {
+	units := [...]struct {
+		size time.Duration
+		name string
+	}{
+		{time.Nanosecond, "ns"},
+		{time.Microsecond, "µs"},
+		{time.Millisecond, "ms"},
+		{time.Second, "s"},
+	}
+
+	abs := d
+	if abs < 0 {
+		abs = -abs
+	}
+	// abs is only negative for math.MinInt64, which has no positive
+	// counterpart.
+	if abs <= 0 || abs >= time.Minute {
+		return d.Round(time.Second).String()
+	}
+
+	i := len(units) - 1
+	for abs < units[i].size {
+		i--
+	}
+	v := roundSignificant(float64(abs)/float64(units[i].size), 3)
+	if v >= 1000 && i < len(units)-1 {
+		// Rounding carried over into the next unit, as in 999.9ms.
+		i++
+		v /= 1000
+	}
+	if i == len(units)-1 && v >= 60 {
+		return d.Round(time.Second).String()
+	}
+
+	s := strconv.FormatFloat(v, 'f', -1, 64) + units[i].name
+	if d < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// roundSignificant rounds v, which must be at least 1, to n significant
+// digits.
+func roundSignificant(v float64, n int) float64 {
+	scale := math.Pow(10, float64(n-1)-math.Floor(math.Log10(v)))
+	return math.Round(v*scale) / scale
+}
+
 // UnmarshalText unmarshals text to a DurationEncoder. "string" is unmarshaled
-// to StringDurationEncoder, and anything else is unmarshaled to
-// NanosDurationEncoder.
+// to StringDurationEncoder, "nanos" to NanosDurationEncoder, "ms" to
+// MillisDurationEncoder, "ms-int" to RoundMillisDurationEncoder, "us-int"
+// to RoundMicrosDurationEncoder, "human" to HumanDurationEncoder, and
+// anything else to SecondsDurationEncoder.
 func (e *DurationEncoder) UnmarshalText(text []byte) error {
 	switch string(text) {
 	case "string":
@@ -264,6 +422,12 @@ func (e *DurationEncoder) UnmarshalText(text []byte) error {
 		*e = NanosDurationEncoder
 	case "ms":
 		*e = MillisDurationEncoder
+	case "ms-int":
+		*e = RoundMillisDurationEncoder
+	case "us-int":
+		*e = RoundMicrosDurationEncoder
+	case "human":
+		*e = HumanDurationEncoder
 	default:
 		*e = SecondsDurationEncoder
 	}
@@ -337,6 +501,7 @@ type EncoderConfig struct {
 	NameKey        string `json:"nameKey" yaml:"nameKey"`
 	CallerKey      string `json:"callerKey" yaml:"callerKey"`
 	FunctionKey    string `json:"functionKey" yaml:"functionKey"`
+	EventIDKey     string `json:"eventIDKey" yaml:"eventIDKey"`
 	StacktraceKey  string `json:"stacktraceKey" yaml:"stacktraceKey"`
 	SkipLineEnding bool   `json:"skipLineEnding" yaml:"skipLineEnding"`
 	LineEnding     string `json:"lineEnding" yaml:"lineEnding"`
@@ -350,12 +515,136 @@ type EncoderConfig struct {
 	// Unlike the other primitive type encoders, EncodeName is optional. The
 	// zero value falls back to FullNameEncoder.
 	EncodeName NameEncoder `json:"nameEncoder" yaml:"nameEncoder"`
+	// EncodeKey, if set, canonicalizes the keys of fields, including those
+	// in namespaces and nested objects, for example with
+	// SnakeCaseKeyEncoder. The keys above are used as they are. The JSON,
+	// console, CBOR, GELF, and CSV encoders support this.
+	EncodeKey KeyEncoder `json:"keyEncoder" yaml:"keyEncoder"`
 	// Configure the encoder for interface{} type objects.
-	// If not provided, objects are encoded using json.Encoder
-	NewReflectedEncoder func(io.Writer) ReflectedEncoder `json:"-" yaml:"-"`
+	// If not provided, objects are encoded using json.Encoder. In
+	// configuration files, it's set by the name of a factory registered
+	// with RegisterReflectedEncoder, such as "strict".
+	NewReflectedEncoder ReflectedEncoderFactory `json:"reflectedEncoder" yaml:"reflectedEncoder"`
 	// Configures the field separator used by the console encoder. Defaults
 	// to tab.
 	ConsoleSeparator string `json:"consoleSeparator" yaml:"consoleSeparator"`
+	// Configures ANSI coloring for the console encoder. Output is uncolored
+	// by default. If ConsoleTheme is nil, DefaultConsoleTheme is used.
+	ConsoleColor ColorMode     `json:"consoleColor" yaml:"consoleColor"`
+	ConsoleTheme *ConsoleTheme `json:"-" yaml:"-"`
+	// ConsoleMultilineWidth, if positive, makes the console encoder spread
+	// structured context that doesn't fit in this many columns over multiple
+	// indented lines. Nested objects and arrays that fit stay on one line, and
+	// long strings are broken after each newline. Reflected values are laid
+	// out the same way.
+	ConsoleMultilineWidth int `json:"consoleMultilineWidth" yaml:"consoleMultilineWidth"`
+	// ConsoleLevelWidth, ConsoleNameWidth, and ConsoleCallerWidth, if
+	// positive, make the console encoder write the level, logger name, and
+	// caller in columns of fixed width, so that entries line up. Shorter
+	// values are padded with spaces; levels and names are left-aligned and
+	// callers are right-aligned. Longer levels are cut short, and longer
+	// names and callers keep their end, after an ellipsis.
+	ConsoleLevelWidth  int `json:"consoleLevelWidth" yaml:"consoleLevelWidth"`
+	ConsoleNameWidth   int `json:"consoleNameWidth" yaml:"consoleNameWidth"`
+	ConsoleCallerWidth int `json:"consoleCallerWidth" yaml:"consoleCallerWidth"`
+	// ConsoleControlChars controls how the console encoder writes control
+	// characters, such as newlines and ANSI escape sequences, in messages,
+	// logger names, and event IDs, so that user-controlled strings can't
+	// forge log lines. Structured context is always escaped as JSON, but with
+	// EscapeControlChars or StripControlChars, ConsoleMultilineWidth no
+	// longer breaks strings at newlines. Stack traces are written as they
+	// are. Defaults to KeepControlChars.
+	ConsoleControlChars ControlCharPolicy `json:"consoleControlChars" yaml:"consoleControlChars"`
+	// CSVColumns lists, in order, the columns written by the CSV encoder.
+	// Columns named by one of the keys above hold that part of the entry,
+	// and other columns hold the top-level field with the same key, as
+	// canonicalized by EncodeKey. See NewCSVEncoder.
+	CSVColumns []string `json:"csvColumns" yaml:"csvColumns"`
+	// CSVSeparator separates the columns written by the CSV encoder.
+	// Defaults to a comma; use a tab for tab-separated values.
+	CSVSeparator string `json:"csvSeparator" yaml:"csvSeparator"`
+	// MaxStringLength, if positive, caps the length in bytes of string and
+	// byte string field values, including those in arrays and objects.
+	// Longer values are cut short and annotated with their original length,
+	// as in "abc...(5000 bytes)". Messages and stack traces aren't capped.
+	// The JSON, console, and CBOR encoders support this.
+	MaxStringLength int `json:"maxStringLength" yaml:"maxStringLength"`
+	// MaxArrayElements, if positive, caps the number of elements encoded for
+	// each array. The remaining elements are replaced by a single string
+	// holding the array's original length, as in "...(5000 elements)". The
+	// JSON, console, and CBOR encoders support this.
+	MaxArrayElements int `json:"maxArrayElements" yaml:"maxArrayElements"`
+	// InternStringValues, if positive, makes the encoder cache the escaped
+	// encodings of up to this many distinct short string values that it sees
+	// repeatedly, such as environment, region, or service names, and reuse
+	// them rather than escaping those values again. The cache is shared by an
+	// encoder and all its clones. The JSON and console encoders support this.
+	InternStringValues int `json:"internStringValues" yaml:"internStringValues"`
+	// EncodeOrder, if set, changes the order in which the parts of each
+	// entry are written, for ingestion systems that expect, say, the
+	// timestamp first or the message last. Listed parts come first, in the
+	// given order, followed by the others in the encoder's usual order. The
+	// JSON and console encoders support this.
+	EncodeOrder []EntryPart `json:"encodeOrder" yaml:"encodeOrder"`
+	// ErrorStacks adds the stack trace at which a logged error originated,
+	// under ${key}Stack, for errors that carry one: those with a
+	// StackTrace() []uintptr method, a StackTrace method returning a slice
+	// of program counters like github.com/pkg/errors' StackTrace, or a
+	// StackTrace() string method. Wrapped errors are searched, and the
+	// innermost stack is used. The JSON, console, CBOR, and GELF encoders
+	// support this.
+	ErrorStacks bool `json:"errorStacks" yaml:"errorStacks"`
+	// FieldErrors controls what happens when a field fails to encode, for
+	// example because its MarshalLogObject returns an error or panics. It
+	// defaults to InlineFieldErrors; with RecoverFieldErrors, a failing
+	// field is replaced by a "<key>Error" placeholder and reported through
+	// the logger's ErrorHook. The JSON and console encoders support this.
+	FieldErrors FieldErrorPolicy `json:"fieldErrors" yaml:"fieldErrors"`
+	// TrustStringers skips the recovery of panics from the String methods
+	// of Stringer fields, saving a deferred recover per field in code paths
+	// whose Stringers are known not to panic. A panicking String method,
+	// including one called on a nil pointer, then propagates to the caller.
+	// The JSON, console, CBOR, GELF, and CSV encoders support this.
+	TrustStringers bool `json:"trustStringers" yaml:"trustStringers"`
+	// ClassifyError, if set, classifies every logged error, centralizing a
+	// codebase's error taxonomy: errors it assigns a category get
+	// ${key}.kind and ${key}.retryable fields, such as "error.kind" and
+	// "error.retryable". The JSON, console, CBOR, GELF, and CSV encoders
+	// support this.
+	ClassifyError ErrorClassifier `json:"-" yaml:"-"`
+	// InvalidUTF8 controls how strings that aren't valid UTF-8, including
+	// messages, logger names, keys, and field values, are written. It
+	// defaults to ReplaceInvalidUTF8. The JSON, console, CBOR, GELF, and
+	// CSV encoders support this.
+	InvalidUTF8 InvalidUTF8Policy `json:"invalidUTF8" yaml:"invalidUTF8"`
+	// EncodeBinary selects how binary fields are written. It defaults to
+	// Base64Binary; HexBinary is easier to grep for binary IDs and hashes.
+	// The JSON, console, GELF, and CSV encoders support this; the CBOR
+	// encoder writes binary fields as byte strings.
+	EncodeBinary BinaryEncoding `json:"binaryEncoding" yaml:"binaryEncoding"`
+}
+
+// ErrorClassifier assigns an error to a category, such as "timeout" or
+// "validation", and reports whether the operation that failed may be
+// retried. An empty category leaves the error unclassified.
+type ErrorClassifier func(err error) (category string, retryable bool)
+
+// errorStacksEnabled is promoted to the encoders that embed an EncoderConfig,
+// so encodeError can tell whether to add stack traces.
+func (cfg *EncoderConfig) errorStacksEnabled() bool {
+	return cfg.ErrorStacks
+}
+
+// errorClassifier is promoted like errorStacksEnabled, so encodeError can
+// classify errors.
+func (cfg *EncoderConfig) errorClassifier() ErrorClassifier {
+	return cfg.ClassifyError
+}
+
+// stringersTrusted is promoted like errorStacksEnabled, so encodeStringer
+// can tell whether to recover panics.
+func (cfg *EncoderConfig) stringersTrusted() bool {
+	return cfg.TrustStringers
 }
 
 // ObjectEncoder is a strongly-typed, encoding-agnostic interface for adding a
@@ -398,6 +687,51 @@ type ObjectEncoder interface {
 	OpenNamespace(key string)
 }
 
+// RawJSONEncoder is an optional interface for ObjectEncoders that can embed
+// pre-serialized JSON, such as the values of RawJSON fields, without
+// decoding it. Use a type assertion to check for it:
+//
+//	if re, ok := enc.(zapcore.RawJSONEncoder); ok {
+//	  re.AddRawJSON("payload", payload)
+//	} else {
+//	  enc.AddByteString("payload", payload)
+//	}
+//
+// The JSON, console, CSV, ECS, and GELF encoders implement it.
+type RawJSONEncoder interface {
+	// AddRawJSON adds value, which should be valid JSON, under key.
+	// Encoders that can't embed invalid JSON safely add it as a string
+	// instead.
+	AddRawJSON(key string, value []byte)
+}
+
+// PreEncodedEncoder is an optional interface for ObjectEncoders that can
+// embed values encoded ahead of time in their own format. It lets caching
+// layers encode an expensive value once per encoding and splice the result
+// into every entry that logs it:
+//
+//	if pe, ok := enc.(zapcore.PreEncodedEncoder); ok {
+//	  if bs, ok := cache.Load(pe.EncodingName()); ok {
+//	    pe.AddPreEncoded(key, bs)
+//	    return nil
+//	  }
+//	}
+//	return enc.AddObject(key, value) // encode it the slow way
+//
+// The JSON, console, CSV, ECS, and CBOR encoders implement it.
+type PreEncodedEncoder interface {
+	// EncodingName names the format of the values that AddPreEncoded
+	// accepts, such as "json" or "cbor". It's empty if the encoder can't
+	// currently accept pre-encoded values.
+	EncodingName() string
+
+	// AddPreEncoded adds value, a single complete value in the format named
+	// by EncodingName, under key. The value is embedded as is, so it must
+	// have been encoded for the same EncoderConfig, and is only validated if
+	// that's cheap.
+	AddPreEncoded(key string, value []byte)
+}
+
 // ArrayEncoder is a strongly-typed, encoding-agnostic interface for adding
 // array-like objects to the logging context. Of note, it supports mixed-type
 // arrays even though they aren't typical in Go. Like slices, ArrayEncoders
@@ -452,6 +786,11 @@ type PrimitiveArrayEncoder interface {
 // Implementations of the ObjectEncoder interface's methods can, of course,
 // freely modify the receiver. However, the Clone and EncodeEntry methods will
 // be called concurrently and shouldn't modify the receiver.
+//
+// Cores add the fields bound with With to a clone of their encoder once, so
+// implementations should encode fields as they're added and have EncodeEntry
+// copy that pre-encoded context into each entry, as the built-in encoders
+// do, rather than holding on to the fields and encoding them every time.
 type Encoder interface {
 	ObjectEncoder
 
@@ -0,0 +1,228 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// limitString applies MaxStringLength to a field value.
+func (cfg *EncoderConfig) limitString(s string) string {
+	if cfg == nil || cfg.MaxStringLength <= 0 || len(s) <= cfg.MaxStringLength {
+		return s
+	}
+	cut := cfg.MaxStringLength
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "...(" + strconv.Itoa(len(s)) + " bytes)"
+}
+
+// limitsBytes reports whether MaxStringLength is set and val exceeds it.
+func (cfg *EncoderConfig) limitsBytes(val []byte) bool {
+	return cfg != nil && cfg.MaxStringLength > 0 && len(val) > cfg.MaxStringLength
+}
+
+// limitArray applies MaxArrayElements and MaxStringLength to the elements
+// of an array, returning arr unchanged if neither is set.
+func (cfg *EncoderConfig) limitArray(arr ArrayMarshaler) ArrayMarshaler {
+	if cfg == nil || (cfg.MaxArrayElements <= 0 && cfg.MaxStringLength <= 0) {
+		return arr
+	}
+	return limitedArray{arr: arr, cfg: cfg}
+}
+
+type limitedArray struct {
+	arr ArrayMarshaler
+	cfg *EncoderConfig
+}
+
+func (a limitedArray) MarshalLogArray(enc ArrayEncoder) error {
+	lenc := &limitedArrayEncoder{ArrayEncoder: enc, cfg: a.cfg}
+	err := a.arr.MarshalLogArray(lenc)
+	if max := a.cfg.MaxArrayElements; max > 0 && lenc.n > max {
+		enc.AppendString("...(" + strconv.Itoa(lenc.n) + " elements)")
+	}
+	return err
+}
+
+// limitedArrayEncoder drops the elements of an array beyond
+// MaxArrayElements, counting them, and caps string elements.
+type limitedArrayEncoder struct {
+	ArrayEncoder
+
+	cfg *EncoderConfig
+	n   int // elements appended so far, including dropped ones
+}
+
+// keep counts an element and reports whether it should be encoded.
+func (e *limitedArrayEncoder) keep() bool {
+	e.n++
+	return e.cfg.MaxArrayElements <= 0 || e.n <= e.cfg.MaxArrayElements
+}
+
+func (e *limitedArrayEncoder) AppendString(v string) {
+	if e.keep() {
+		e.ArrayEncoder.AppendString(e.cfg.limitString(v))
+	}
+}
+
+func (e *limitedArrayEncoder) AppendByteString(v []byte) {
+	if !e.keep() {
+		return
+	}
+	if e.cfg.limitsBytes(v) {
+		e.ArrayEncoder.AppendString(e.cfg.limitString(string(v)))
+		return
+	}
+	e.ArrayEncoder.AppendByteString(v)
+}
+
+func (e *limitedArrayEncoder) AppendArray(v ArrayMarshaler) error {
+	if e.keep() {
+		return e.ArrayEncoder.AppendArray(v)
+	}
+	return nil
+}
+
+func (e *limitedArrayEncoder) AppendObject(v ObjectMarshaler) error {
+	if e.keep() {
+		return e.ArrayEncoder.AppendObject(v)
+	}
+	return nil
+}
+
+func (e *limitedArrayEncoder) AppendReflected(v interface{}) error {
+	if e.keep() {
+		return e.ArrayEncoder.AppendReflected(v)
+	}
+	return nil
+}
+
+func (e *limitedArrayEncoder) AppendDuration(v time.Duration) {
+	if e.keep() {
+		e.ArrayEncoder.AppendDuration(v)
+	}
+}
+
+func (e *limitedArrayEncoder) AppendTime(v time.Time) {
+	if e.keep() {
+		e.ArrayEncoder.AppendTime(v)
+	}
+}
+
+func (e *limitedArrayEncoder) AppendBool(v bool) {
+	if e.keep() {
+		e.ArrayEncoder.AppendBool(v)
+	}
+}
+
+func (e *limitedArrayEncoder) AppendComplex128(v complex128) {
+	if e.keep() {
+		e.ArrayEncoder.AppendComplex128(v)
+	}
+}
+
+func (e *limitedArrayEncoder) AppendComplex64(v complex64) {
+	if e.keep() {
+		e.ArrayEncoder.AppendComplex64(v)
+	}
+}
+
+func (e *limitedArrayEncoder) AppendFloat64(v float64) {
+	if e.keep() {
+		e.ArrayEncoder.AppendFloat64(v)
+	}
+}
+
+func (e *limitedArrayEncoder) AppendFloat32(v float32) {
+	if e.keep() {
+		e.ArrayEncoder.AppendFloat32(v)
+	}
+}
+
+func (e *limitedArrayEncoder) AppendInt(v int) {
+	if e.keep() {
+		e.ArrayEncoder.AppendInt(v)
+	}
+}
+
+func (e *limitedArrayEncoder) AppendInt64(v int64) {
+	if e.keep() {
+		e.ArrayEncoder.AppendInt64(v)
+	}
+}
+
+func (e *limitedArrayEncoder) AppendInt32(v int32) {
+	if e.keep() {
+		e.ArrayEncoder.AppendInt32(v)
+	}
+}
+
+func (e *limitedArrayEncoder) AppendInt16(v int16) {
+	if e.keep() {
+		e.ArrayEncoder.AppendInt16(v)
+	}
+}
+
+func (e *limitedArrayEncoder) AppendInt8(v int8) {
+	if e.keep() {
+		e.ArrayEncoder.AppendInt8(v)
+	}
+}
+
+func (e *limitedArrayEncoder) AppendUint(v uint) {
+	if e.keep() {
+		e.ArrayEncoder.AppendUint(v)
+	}
+}
+
+func (e *limitedArrayEncoder) AppendUint64(v uint64) {
+	if e.keep() {
+		e.ArrayEncoder.AppendUint64(v)
+	}
+}
+
+func (e *limitedArrayEncoder) AppendUint32(v uint32) {
+	if e.keep() {
+		e.ArrayEncoder.AppendUint32(v)
+	}
+}
+
+func (e *limitedArrayEncoder) AppendUint16(v uint16) {
+	if e.keep() {
+		e.ArrayEncoder.AppendUint16(v)
+	}
+}
+
+func (e *limitedArrayEncoder) AppendUint8(v uint8) {
+	if e.keep() {
+		e.ArrayEncoder.AppendUint8(v)
+	}
+}
+
+func (e *limitedArrayEncoder) AppendUintptr(v uintptr) {
+	if e.keep() {
+		e.ArrayEncoder.AppendUintptr(v)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+)
+
+func limitedEncoderConfig() EncoderConfig {
+	cfg := testEncoderConfig()
+	cfg.TimeKey = ""
+	cfg.CallerKey = ""
+	cfg.FunctionKey = ""
+	cfg.NameKey = ""
+	cfg.MaxStringLength = 5
+	cfg.MaxArrayElements = 3
+	return cfg
+}
+
+func limitedFields() []Field {
+	ints := ArrayMarshalerFunc(func(arr ArrayEncoder) error {
+		for i := 0; i < 10; i++ {
+			arr.AppendInt(i)
+		}
+		return nil
+	})
+	strs := ArrayMarshalerFunc(func(arr ArrayEncoder) error {
+		arr.AppendString("short")
+		arr.AppendString("much too long")
+		arr.AppendByteString([]byte("bytes too long"))
+		return nil
+	})
+	nested := ArrayMarshalerFunc(func(arr ArrayEncoder) error {
+		for i := 0; i < 4; i++ {
+			if err := arr.AppendArray(ints); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	obj := ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+		enc.AddString("inner", "also too long")
+		return nil
+	})
+	return []Field{
+		{Key: "s", Type: StringType, String: "hello world"},
+		{Key: "ok", Type: StringType, String: "tiny"},
+		{Key: "bs", Type: ByteStringType, Interface: []byte("byte string")},
+		{Key: "snow", Type: StringType, String: "abcd☃"},
+		{Key: "ints", Type: ArrayMarshalerType, Interface: ints},
+		{Key: "strs", Type: ArrayMarshalerType, Interface: strs},
+		{Key: "nested", Type: ArrayMarshalerType, Interface: nested},
+		{Key: "obj", Type: ObjectMarshalerType, Interface: obj},
+	}
+}
+
+func TestEncoderLimitsJSON(t *testing.T) {
+	enc := NewJSONEncoder(limitedEncoderConfig())
+	ent := Entry{Level: InfoLevel, Message: "a message longer than the limit", Stack: "a long stack trace"}
+
+	buf, err := enc.EncodeEntry(ent, limitedFields())
+	require.NoError(t, err, "Unexpected encoding error.")
+	defer buf.Free()
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got), "Invalid JSON: %s", buf.String())
+	firstInts := []interface{}{0.0, 1.0, 2.0, "...(10 elements)"}
+	assert.Equal(t, map[string]interface{}{
+		"level":      "info",
+		"msg":        "a message longer than the limit",
+		"stacktrace": "a long stack trace",
+		"s":          "hello...(11 bytes)",
+		"ok":         "tiny",
+		"bs":         "byte ...(11 bytes)",
+		"snow":       "abcd...(7 bytes)",
+		"ints":       firstInts,
+		"strs":       []interface{}{"short", "much ...(13 bytes)", "bytes...(14 bytes)"},
+		"nested":     []interface{}{firstInts, firstInts, firstInts, "...(4 elements)"},
+		"obj":        map[string]interface{}{"inner": "also ...(13 bytes)"},
+	}, got, "Unexpected encoded entry.")
+}
+
+func TestEncoderLimitsConsole(t *testing.T) {
+	enc := NewConsoleEncoder(limitedEncoderConfig())
+	buf, err := enc.EncodeEntry(Entry{Level: InfoLevel, Message: "msg"}, limitedFields()[:1])
+	require.NoError(t, err, "Unexpected encoding error.")
+	defer buf.Free()
+	assert.Equal(t, "info\tmsg\t{\"s\": \"hello...(11 bytes)\"}\n", buf.String())
+}
+
+func TestEncoderLimitsCBOR(t *testing.T) {
+	enc := NewCBOREncoder(limitedEncoderConfig())
+	buf, err := enc.EncodeEntry(Entry{Level: InfoLevel, Message: "msg"}, limitedFields()[:5])
+	require.NoError(t, err, "Unexpected encoding error.")
+	defer buf.Free()
+
+	got := decodeCBOR(t, buf.Bytes()).(map[string]interface{})
+	assert.Equal(t, "hello...(11 bytes)", got["s"])
+	assert.Equal(t, "byte ...(11 bytes)", got["bs"])
+	assert.Equal(t, []interface{}{uint64(0), uint64(1), uint64(2), "...(10 elements)"}, got["ints"])
+}
+
+func TestEncoderLimitsUnset(t *testing.T) {
+	cfg := limitedEncoderConfig()
+	cfg.MaxStringLength = 0
+	cfg.MaxArrayElements = 0
+	long := strings.Repeat("x", 1000)
+
+	buf, err := NewJSONEncoder(cfg).EncodeEntry(Entry{}, []Field{{Key: "s", Type: StringType, String: long}})
+	require.NoError(t, err, "Unexpected encoding error.")
+	defer buf.Free()
+	assert.Contains(t, buf.String(), long, "Expected no truncation without limits.")
+}
@@ -22,6 +22,7 @@ package zapcore_test
 
 import (
 	"encoding/json"
+	"math"
 	"strings"
 	"testing"
 	"time"
@@ -249,6 +250,40 @@ func TestEncoderConfiguration(t *testing.T) {
 			expectedJSON:    `{"L":"info","T":0,"N":"main","C":"foo.go:42","M":"hello","S":"fake-stack"}` + "\n",
 			expectedConsole: "0\tinfo\tmain\tfoo.go:42\thello\nfake-stack\n",
 		},
+		{
+			desc: "write the event ID if EventIDKey is set",
+			cfg: EncoderConfig{
+				LevelKey:       "L",
+				TimeKey:        "T",
+				MessageKey:     "M",
+				NameKey:        "N",
+				CallerKey:      "C",
+				FunctionKey:    "F",
+				EventIDKey:     "E",
+				StacktraceKey:  "S",
+				LineEnding:     base.LineEnding,
+				EncodeTime:     base.EncodeTime,
+				EncodeDuration: base.EncodeDuration,
+				EncodeLevel:    base.EncodeLevel,
+				EncodeCaller:   base.EncodeCaller,
+			},
+			amendEntry: func(ent Entry) Entry {
+				ent.EventID = "E1001"
+				return ent
+			},
+			expectedJSON:    `{"L":"info","T":0,"N":"main","C":"foo.go:42","F":"foo.Foo","E":"E1001","M":"hello","S":"fake-stack"}` + "\n",
+			expectedConsole: "0\tinfo\tmain\tfoo.go:42\tfoo.Foo\tE1001\thello\nfake-stack\n",
+		},
+		{
+			desc: "skip event ID if EventIDKey is omitted",
+			cfg:  base,
+			amendEntry: func(ent Entry) Entry {
+				ent.EventID = "E1001"
+				return ent
+			},
+			expectedJSON:    `{"level":"info","ts":0,"name":"main","caller":"foo.go:42","func":"foo.Foo","msg":"hello","stacktrace":"fake-stack"}` + "\n",
+			expectedConsole: "0\tinfo\tmain\tfoo.go:42\tfoo.Foo\thello\nfake-stack\n",
+		},
 		{
 			desc: "skip stacktrace if StacktraceKey is omitted",
 			cfg: EncoderConfig{
@@ -593,7 +628,12 @@ func TestTimeEncoders(t *testing.T) {
 		{"timeEncoder: iso8601", "1970-01-01T00:01:40.050Z"},
 		{"timeEncoder: ISO8601", "1970-01-01T00:01:40.050Z"},
 		{"timeEncoder: millis", 100050.005},
+		{"timeEncoder: micros", int64(100050005)},
 		{"timeEncoder: nanos", int64(100050005000)},
+		{"timeEncoder: RFC1123", "Thu, 01 Jan 1970 00:01:40 UTC"},
+		{"timeEncoder: kitchen", "12:01AM"},
+		{"timeEncoder: DateTime", "1970-01-01 00:01:40"},
+		{"timeEncoder: stampmilli", "Jan  1 00:01:40.050"},
 		{"timeEncoder: {layout: 06/01/02 03:04pm}", "70/01/01 12:01am"},
 		{"timeEncoder: ''", 100.050005},
 		{"timeEncoder: something-random", 100.050005},
@@ -618,8 +658,9 @@ func TestTimeEncoders(t *testing.T) {
 
 func TestTimeEncodersWrongYAML(t *testing.T) {
 	tests := []string{
-		"timeEncoder: [1, 2, 3]", // wrong type
-		"timeEncoder: {foo:bar",  // broken yaml
+		"timeEncoder: [1, 2, 3]",  // wrong type
+		"timeEncoder: {foo:bar",   // broken yaml
+		"timeEncoder: {foo: bar}", // missing layout
 	}
 	for _, tt := range tests {
 		cfg := EncoderConfig{}
@@ -635,6 +676,9 @@ func TestTimeEncodersParseFromJSON(t *testing.T) {
 	}{
 		{`{"timeEncoder": "iso8601"}`, "1970-01-01T00:01:40.050Z"},
 		{`{"timeEncoder": {"layout": "06/01/02 03:04pm"}}`, "70/01/01 12:01am"},
+		{`{"timeEncoder": {"layout": "2006-01-02 15:04:05.000"}}`, "1970-01-01 00:01:40.050"},
+		{`{"timeEncoder": "micros"}`, int64(100050005)},
+		{`{"timeEncoder": "dateOnly"}`, "1970-01-01"},
 	}
 
 	for _, tt := range tests {
@@ -659,6 +703,9 @@ func TestDurationEncoders(t *testing.T) {
 		{"string", "1.0000005s"},
 		{"nanos", int64(1000000500)},
 		{"ms", int64(1000)},
+		{"ms-int", int64(1000)},
+		{"us-int", int64(1000001)},
+		{"human", "1s"},
 		{"", 1.0000005},
 		{"something-random", 1.0000005},
 	}
@@ -675,6 +722,46 @@ func TestDurationEncoders(t *testing.T) {
 	}
 }
 
+func TestRoundDurationEncoders(t *testing.T) {
+	tests := []struct {
+		d      time.Duration
+		millis int64
+		micros int64
+	}{
+		{1499 * time.Microsecond, 1, 1499},
+		{1500 * time.Microsecond, 2, 1500},
+		{1500499 * time.Nanosecond, 2, 1500},
+		{-1500 * time.Microsecond, -2, -1500},
+	}
+	for _, tt := range tests {
+		assertAppended(t, tt.millis, func(arr ArrayEncoder) { RoundMillisDurationEncoder(tt.d, arr) }, "Unexpected milliseconds for %v.", tt.d)
+		assertAppended(t, tt.micros, func(arr ArrayEncoder) { RoundMicrosDurationEncoder(tt.d, arr) }, "Unexpected microseconds for %v.", tt.d)
+	}
+}
+
+func TestHumanDurationEncoder(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{4 * time.Nanosecond, "4ns"},
+		{4*time.Microsecond + 4*time.Nanosecond, "4µs"},
+		{12345 * time.Microsecond, "12.3ms"},
+		{230 * time.Millisecond, "230ms"},
+		{1500 * time.Millisecond, "1.5s"},
+		{999999 * time.Nanosecond, "1ms"},
+		{-1500 * time.Millisecond, "-1.5s"},
+		{59999 * time.Millisecond, "1m0s"},
+		{83456 * time.Millisecond, "1m23s"},
+		{2*time.Hour + 5*time.Second, "2h0m5s"},
+		{math.MinInt64, time.Duration(math.MinInt64).Round(time.Second).String()},
+	}
+	for _, tt := range tests {
+		assertAppended(t, tt.want, func(arr ArrayEncoder) { HumanDurationEncoder(tt.d, arr) }, "Unexpected output for %v.", int64(tt.d))
+	}
+}
+
 func TestCallerEncoders(t *testing.T) {
 	caller := EntryCaller{Defined: true, File: "/home/jack/src/github.com/foo/foo.go", Line: 42}
 	tests := []struct {
@@ -0,0 +1,173 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package enctest helps authors of zapcore.Encoders check that their
+// encoders behave as well as the built-in ones on arbitrary input.
+//
+// Invariants, such as ValidJSON and ValidUTF8, check the output of an
+// encoder for one entry. Check applies them to a single entry, and Fuzz
+// applies them to entries produced by Generate from a fuzzer's input:
+//
+//	func FuzzLogfmtEncoder(f *testing.F) {
+//		enctest.Fuzz(f, func() zapcore.Encoder {
+//			return NewLogfmtEncoder(zap.NewProductionEncoderConfig())
+//		}, enctest.ValidUTF8)
+//	}
+package enctest // import "go.uber.org/zap/zapcore/enctest"
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"unicode/utf8"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap/zapcore"
+)
+
+// An Invariant checks the output of an encoder for one entry, returning an
+// error describing any violation.
+type Invariant func(out []byte) error
+
+// ValidJSON checks that the output is a single JSON object, followed by
+// any line ending.
+func ValidJSON(out []byte) error {
+	obj := trimLineEnding(out)
+	if !json.Valid(obj) {
+		return errors.New("output isn't valid JSON")
+	}
+	if len(obj) == 0 || obj[0] != '{' {
+		return errors.New("output isn't a JSON object")
+	}
+	return nil
+}
+
+// UniqueKeys checks that no JSON object in the output, at any depth, has
+// the same key twice. It's meant for encoders configured to remove
+// duplicate keys, or cores such as those built by
+// zapcore.NewDuplicateKeyCore.
+func UniqueKeys(out []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(trimLineEnding(out)))
+	return uniqueKeys(dec, "")
+}
+
+func uniqueKeys(dec *json.Decoder, path string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("output isn't valid JSON: %v", err)
+	}
+	switch tok {
+	case json.Delim('{'):
+		seen := make(map[string]struct{})
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return fmt.Errorf("output isn't valid JSON: %v", err)
+			}
+			key := tok.(string)
+			keyPath := key
+			if path != "" {
+				keyPath = path + "." + key
+			}
+			if _, ok := seen[key]; ok {
+				return fmt.Errorf("duplicate key %q", keyPath)
+			}
+			seen[key] = struct{}{}
+			if err := uniqueKeys(dec, keyPath); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+	case json.Delim('['):
+		for i := 0; dec.More(); i++ {
+			if err := uniqueKeys(dec, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+	}
+	if err != nil {
+		return fmt.Errorf("output isn't valid JSON: %v", err)
+	}
+	return nil
+}
+
+// ValidUTF8 checks that the output is valid UTF-8, whatever the strings
+// that were logged.
+func ValidUTF8(out []byte) error {
+	if !utf8.Valid(out) {
+		return errors.New("output isn't valid UTF-8")
+	}
+	return nil
+}
+
+// SingleLine checks that the output holds no line breaks other than a
+// final line ending, so that each entry stays on its own line.
+func SingleLine(out []byte) error {
+	if bytes.ContainsAny(trimLineEnding(out), "\r\n") {
+		return errors.New("output spans multiple lines")
+	}
+	return nil
+}
+
+func trimLineEnding(out []byte) []byte {
+	return bytes.TrimRight(out, "\r\n")
+}
+
+// Check encodes an entry with enc and checks the output against each of
+// invariants, returning the violations. Errors and panics from the
+// encoder are violations too.
+func Check(enc zapcore.Encoder, ent zapcore.Entry, fields []zapcore.Field, invariants ...Invariant) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("encoder panicked: %v", r)
+		}
+	}()
+
+	buf, err := enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return fmt.Errorf("encoder failed: %v", err)
+	}
+	defer buf.Free()
+
+	for _, inv := range invariants {
+		if verr := inv(buf.Bytes()); verr != nil {
+			err = multierr.Append(err, fmt.Errorf("%v in %q", verr, buf.Bytes()))
+		}
+	}
+	return err
+}
+
+// Fuzz adds a corpus of seeds to f and fuzzes the encoders returned by
+// newEncoder with the entries Generate produces from the fuzzer's input,
+// failing if Check reports a violation of any of invariants.
+func Fuzz(f *testing.F, newEncoder func() zapcore.Encoder, invariants ...Invariant) {
+	for _, seed := range Seeds() {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ent, fields := Generate(data)
+		if err := Check(newEncoder(), ent, fields, invariants...); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
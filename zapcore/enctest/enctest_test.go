@@ -0,0 +1,146 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package enctest_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zapcore/enctest"
+)
+
+func TestInvariants(t *testing.T) {
+	tests := []struct {
+		desc string
+		inv  enctest.Invariant
+		out  string
+		want string // empty if the output is valid
+	}{
+		{"valid JSON", enctest.ValidJSON, `{"a":[1,{"b":2}]}` + "\n", ""},
+		{"valid JSON with CRLF", enctest.ValidJSON, `{"a":1}` + "\r\n", ""},
+		{"invalid JSON", enctest.ValidJSON, `{"a":}` + "\n", "output isn't valid JSON"},
+		{"JSON array", enctest.ValidJSON, `[1]` + "\n", "output isn't a JSON object"},
+		{"unique keys", enctest.UniqueKeys, `{"a":{"a":1},"b":[{"a":1},{"a":2}]}`, ""},
+		{"duplicate keys", enctest.UniqueKeys, `{"a":1,"a":2}`, `duplicate key "a"`},
+		{"nested duplicate keys", enctest.UniqueKeys, `{"a":[{"b":1,"b":2}]}`, `duplicate key "a[0].b"`},
+		{"unique keys in invalid JSON", enctest.UniqueKeys, `{"a":`, "output isn't valid JSON: EOF"},
+		{"valid UTF-8", enctest.ValidUTF8, "héllo", ""},
+		{"invalid UTF-8", enctest.ValidUTF8, "h\xffllo", "output isn't valid UTF-8"},
+		{"single line", enctest.SingleLine, "one line\n", ""},
+		{"multiple lines", enctest.SingleLine, "one\ntwo\n", "output spans multiple lines"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.inv([]byte(tt.out))
+			if tt.want == "" {
+				assert.NoError(t, err, "Unexpected violation.")
+			} else {
+				assert.EqualError(t, err, tt.want, "Unexpected violation.")
+			}
+		})
+	}
+}
+
+// brokenEncoder fails or panics when encoding entries.
+type brokenEncoder struct {
+	zapcore.Encoder
+
+	panics bool
+}
+
+func (e brokenEncoder) EncodeEntry(zapcore.Entry, []zapcore.Field) (*buffer.Buffer, error) {
+	if e.panics {
+		panic("boom")
+	}
+	return nil, errors.New("fail")
+}
+
+func TestCheck(t *testing.T) {
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"})
+	fields := []zapcore.Field{
+		{Key: "a", Type: zapcore.StringType, String: "x"},
+		{Key: "a", Type: zapcore.StringType, String: "y"},
+	}
+	assert.NoError(t, enctest.Check(enc, zapcore.Entry{Message: "hi"}, fields, enctest.ValidJSON), "Unexpected violation.")
+	assert.EqualError(t,
+		enctest.Check(enc, zapcore.Entry{Message: "hi"}, fields, enctest.ValidJSON, enctest.UniqueKeys),
+		`duplicate key "a" in "{\"msg\":\"hi\",\"a\":\"x\",\"a\":\"y\"}\n"`,
+		"Expected a violation.")
+
+	assert.EqualError(t, enctest.Check(brokenEncoder{}, zapcore.Entry{}, nil), "encoder failed: fail", "Expected encoding errors to be violations.")
+	assert.EqualError(t, enctest.Check(brokenEncoder{panics: true}, zapcore.Entry{}, nil), "encoder panicked: boom", "Expected panics to be violations.")
+}
+
+func TestGenerate(t *testing.T) {
+	for _, seed := range enctest.Seeds() {
+		ent, fields := enctest.Generate(seed)
+		ent2, fields2 := enctest.Generate(seed)
+		assert.Equal(t, ent, ent2, "Expected the same entry from the same input.")
+		assert.Equal(t, len(fields), len(fields2), "Expected the same fields from the same input.")
+	}
+
+	ent, fields := enctest.Generate(nil)
+	assert.Equal(t, zapcore.TraceLevel, ent.Level, "Unexpected level from empty input.")
+	assert.Empty(t, fields, "Expected no fields from empty input.")
+
+	ent, fields = enctest.Generate(enctest.Seeds()[1])
+	assert.Equal(t, "app", ent.LoggerName, "Unexpected logger name.")
+	assert.Equal(t, "hello", ent.Message, "Unexpected message.")
+	assert.Equal(t, []zapcore.Field{{Key: "key", Type: zapcore.StringType, String: "value"}}, fields, "Unexpected fields.")
+
+	_, fields = enctest.Generate(enctest.Seeds()[3])
+	require.Len(t, fields, 4, "Unexpected number of fields.")
+	for i, typ := range []zapcore.FieldType{zapcore.NamespaceType, zapcore.ObjectMarshalerType, zapcore.ArrayMarshalerType, zapcore.InlineMarshalerType} {
+		assert.Equal(t, typ, fields[i].Type, "Unexpected type of field %d.", i)
+	}
+}
+
+func encoderConfig() zapcore.EncoderConfig {
+	return zapcore.EncoderConfig{
+		MessageKey:     "msg",
+		LevelKey:       "level",
+		TimeKey:        "ts",
+		NameKey:        "logger",
+		CallerKey:      "caller",
+		FunctionKey:    "func",
+		StacktraceKey:  "stacktrace",
+		EncodeLevel:    zapcore.LowercaseLevelEncoder,
+		EncodeTime:     zapcore.ISO8601TimeEncoder,
+		EncodeDuration: zapcore.StringDurationEncoder,
+		EncodeCaller:   zapcore.ShortCallerEncoder,
+	}
+}
+
+func FuzzJSONEncoder(f *testing.F) {
+	enctest.Fuzz(f, func() zapcore.Encoder {
+		return zapcore.NewJSONEncoder(encoderConfig())
+	}, enctest.ValidJSON, enctest.ValidUTF8, enctest.SingleLine)
+}
+
+func FuzzGELFEncoder(f *testing.F) {
+	enctest.Fuzz(f, func() zapcore.Encoder {
+		return zapcore.NewGELFEncoder(encoderConfig(), "host")
+	}, enctest.ValidJSON, enctest.ValidUTF8, enctest.SingleLine)
+}
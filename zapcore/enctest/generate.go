@@ -0,0 +1,220 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package enctest
+
+import (
+	"errors"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// _maxDepth limits the nesting of generated objects.
+const _maxDepth = 3
+
+var _levels = []zapcore.Level{
+	zapcore.TraceLevel,
+	zapcore.DebugLevel,
+	zapcore.InfoLevel,
+	zapcore.WarnLevel,
+	zapcore.ErrorLevel,
+	zapcore.DPanicLevel,
+	zapcore.PanicLevel,
+	zapcore.FatalLevel,
+}
+
+// Generate derives an entry and its fields from arbitrary bytes, such as a
+// fuzzer's input. The same bytes always produce the same entry. Strings,
+// including keys, messages, and logger names, are taken from the input as
+// they are, so they may be empty, hold control characters, or be invalid
+// UTF-8. Fields cover every kind of value an encoder is asked to encode,
+// including namespaces and nested objects and arrays.
+func Generate(data []byte) (zapcore.Entry, []zapcore.Field) {
+	s := &source{data: data}
+	ent := zapcore.Entry{
+		Level:      _levels[int(s.next())%len(_levels)],
+		Time:       time.Unix(0, s.int64()).UTC(),
+		LoggerName: s.string(),
+		Message:    s.string(),
+	}
+	if s.next()%2 == 1 {
+		ent.Caller = zapcore.EntryCaller{
+			Defined:  true,
+			File:     s.string(),
+			Line:     int(s.next()),
+			Function: s.string(),
+		}
+	}
+	if s.next()%4 == 0 {
+		ent.Stack = s.string()
+	}
+	return ent, s.fields(0)
+}
+
+// Seeds returns inputs for Generate that cover common edge cases, for use
+// as a fuzzing corpus.
+func Seeds() [][]byte {
+	return [][]byte{
+		{},
+		// A plain entry with a single string field.
+		[]byte("\x02\x00\x00\x00\x00\x00\x00\x00\x01\x03app\x05hello\x00\x01\x01\x03key\x00\x05value"),
+		// Characters that need escaping, invalid UTF-8, a caller, a stack,
+		// a NaN, and a complex number.
+		[]byte("\x04\x7f\xff\xff\xff\xff\xff\xff\xff\x02\xff\xfe\x03\"\\\n\x01\x02\xc3\x28\x07\x00\x04\x02a\n" +
+			"\x03\x01k\x04\x7f\xf8\x00\x00\x00\x00\x00\x00\x00\x01\x02\xff\x00\x02\xe2\x82\x0f\x01\x02"),
+		// A namespace, a nested object, an array, and an inline object.
+		[]byte("\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x01\x04\x02ns\x09\x03obj\x0a\x02\x01a\x05\x01" +
+			"\x01b\x02\xff\xff\xff\xff\xff\xff\xff\xff\x03arr\x0b\x02\x01x\x00\x01i\x10\x01\x01k\x00\x01v"),
+		// An error, a Stringer with control characters, a reflected value,
+		// and a duration.
+		[]byte("\x05\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x01\x04\x03err\x0c\x04oops\x01s\x0d\x02\t\x1b" +
+			"\x01r\x0e\x01k\x01v\x01d\x06\x00\x00\x00\x00\x3b\x9a\xca\x00"),
+	}
+}
+
+// source hands out the bytes of a fuzzer's input, and zeros once they run
+// out.
+type source struct {
+	data []byte
+}
+
+func (s *source) next() byte {
+	if len(s.data) == 0 {
+		return 0
+	}
+	b := s.data[0]
+	s.data = s.data[1:]
+	return b
+}
+
+func (s *source) int64() int64 {
+	var v uint64
+	for i := 0; i < 8; i++ {
+		v = v<<8 | uint64(s.next())
+	}
+	return int64(v)
+}
+
+func (s *source) bytes() []byte {
+	n := int(s.next() % 32)
+	if n > len(s.data) {
+		n = len(s.data)
+	}
+	bs := s.data[:n:n]
+	s.data = s.data[n:]
+	return bs
+}
+
+func (s *source) string() string {
+	return string(s.bytes())
+}
+
+func (s *source) fields(depth int) []zapcore.Field {
+	n := int(s.next() % 8)
+	fields := make([]zapcore.Field, 0, n)
+	for i := 0; i < n && len(s.data) > 0; i++ {
+		fields = append(fields, s.field(depth))
+	}
+	return fields
+}
+
+func (s *source) field(depth int) zapcore.Field {
+	f := zapcore.Field{Key: s.string()}
+	switch s.next() % 17 {
+	case 0:
+		f.Type, f.String = zapcore.StringType, s.string()
+	case 1:
+		f.Type, f.Interface = zapcore.ByteStringType, s.bytes()
+	case 2:
+		f.Type, f.Integer = zapcore.Int64Type, s.int64()
+	case 3:
+		f.Type, f.Integer = zapcore.Uint64Type, s.int64()
+	case 4:
+		// Any bits, including those of NaNs and infinities.
+		f.Type, f.Integer = zapcore.Float64Type, s.int64()
+	case 5:
+		f.Type, f.Integer = zapcore.BoolType, int64(s.next()%2)
+	case 6:
+		f.Type, f.Integer = zapcore.DurationType, s.int64()
+	case 7:
+		f.Type, f.Integer, f.Interface = zapcore.TimeType, s.int64(), time.UTC
+	case 8:
+		f.Type, f.Interface = zapcore.BinaryType, s.bytes()
+	case 9:
+		f.Type = zapcore.NamespaceType
+	case 10:
+		if depth >= _maxDepth {
+			f.Type = zapcore.SkipType
+			break
+		}
+		f.Type, f.Interface = zapcore.ObjectMarshalerType, fieldsObject(s.fields(depth+1))
+	case 11:
+		f.Type, f.Interface = zapcore.ArrayMarshalerType, s.stringArray()
+	case 12:
+		f.Type, f.Interface = zapcore.ErrorType, errors.New(s.string())
+	case 13:
+		f.Type, f.Interface = zapcore.StringerType, stringer(s.string())
+	case 14:
+		f.Type, f.Interface = zapcore.ReflectType, map[string]interface{}{s.string(): s.string()}
+	case 15:
+		f.Type, f.Interface = zapcore.Complex128Type, complex(float64(s.next()), -float64(s.next()))
+	case 16:
+		if depth >= _maxDepth {
+			f.Type = zapcore.SkipType
+			break
+		}
+		f.Type, f.Interface = zapcore.InlineMarshalerType, fieldsObject(s.fields(depth+1))
+	}
+	return f
+}
+
+func (s *source) stringArray() stringArray {
+	n := int(s.next() % 8)
+	arr := make(stringArray, 0, n)
+	for i := 0; i < n && len(s.data) > 0; i++ {
+		arr = append(arr, s.string())
+	}
+	return arr
+}
+
+type fieldsObject []zapcore.Field
+
+func (fs fieldsObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for i := range fs {
+		fs[i].AddTo(enc)
+	}
+	return nil
+}
+
+type stringArray []string
+
+func (ss stringArray) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, s := range ss {
+		enc.AppendString(s)
+	}
+	return nil
+}
+
+type stringer string
+
+func (s stringer) String() string {
+	return string(s)
+}
@@ -32,11 +32,24 @@ import (
 	"go.uber.org/zap/internal/pool"
 )
 
+const (
+	// _inlineFields is the number of fields that a CheckedEntry can hold
+	// without allocating.
+	_inlineFields = 8
+	// _maxPooledFields bounds the field capacity that pooled CheckedEntries
+	// retain, so that a few very large entries don't pin memory.
+	_maxPooledFields = 128
+)
+
+// _cePool caches CheckedEntries. sync.Pool keeps a cache per P, so checking
+// and writing entries doesn't contend across goroutines.
 var _cePool = pool.New(func() *CheckedEntry {
 	// Pre-allocate some space for cores.
-	return &CheckedEntry{
+	ce := &CheckedEntry{
 		cores: make([]Core, 4),
 	}
+	ce.fields = ce.fieldArr[:0]
+	return ce
 })
 
 func getCheckedEntry() *CheckedEntry {
@@ -147,6 +160,13 @@ type Entry struct {
 	Message    string
 	Caller     EntryCaller
 	Stack      string
+	// EventID optionally identifies the log statement with a stable code,
+	// such as an error code, that can be emitted and filtered on without
+	// adding a field. Encoders write it under EncoderConfig.EventIDKey.
+	EventID string
+	// Trace, if set, records the path the entry takes through the cores
+	// that check and write it. See EntryTrace.
+	Trace *EntryTrace
 }
 
 // CheckWriteHook is a custom action that may be executed after an entry is
@@ -201,6 +221,25 @@ func (a CheckWriteAction) OnWrite(ce *CheckedEntry, _ []Field) {
 
 var _ CheckWriteHook = CheckWriteAction(0)
 
+func (a CheckWriteAction) retainsFields() bool { return false }
+
+// fieldRetainer is implemented by Cores, Encoders, and CheckWriteHooks that
+// know whether they keep the slice of fields they're given after the call
+// that passed it returns. CheckedEntry can reuse its memory for the fields
+// of entries written only to those that don't.
+type fieldRetainer interface {
+	retainsFields() bool
+}
+
+// retainsFields reports whether v may keep the fields it's given. Values
+// that don't implement fieldRetainer are assumed to.
+func retainsFields(v interface{}) bool {
+	if r, ok := v.(fieldRetainer); ok {
+		return r.retainsFields()
+	}
+	return true
+}
+
 // CheckedEntry is an Entry together with a collection of Cores that have
 // already agreed to log it.
 //
@@ -210,14 +249,37 @@ var _ CheckWriteHook = CheckWriteAction(0)
 type CheckedEntry struct {
 	Entry
 	ErrorOutput WriteSyncer
-	dirty       bool // best-effort detection of pool misuse
-	after       CheckWriteHook
-	cores       []Core
+
+	// AfterWrite, if set, is called once the entry has been written to all
+	// of its Cores with the combined error they returned, if any. It runs
+	// before the entry's CheckWriteHook.
+	AfterWrite func(ent Entry, err error)
+
+	// UseArena, if set, makes Write share the JSON encodings of the entry's
+	// reflected fields between the cores it fans out to, such as the cores
+	// of a Tee, for the duration of the write. JSON cores then encode each
+	// ReflectType field passed to Write once, rather than once per core,
+	// unless their encoders use a custom NewReflectedEncoder. Reflected
+	// values nested in other fields are encoded by each core. Values must
+	// not change while the entry is written.
+	UseArena bool
+
+	dirty bool // best-effort detection of pool misuse
+	after CheckWriteHook
+	cores []Core
+
+	// fields holds the fields staged with With, followed by those passed to
+	// Write if none of the Cores keep them. It's backed by fieldArr until an
+	// entry has more than _inlineFields fields.
+	fields   []Field
+	fieldArr [_inlineFields]Field
 }
 
 func (ce *CheckedEntry) reset() {
 	ce.Entry = Entry{}
 	ce.ErrorOutput = nil
+	ce.AfterWrite = nil
+	ce.UseArena = false
 	ce.dirty = false
 	ce.after = nil
 	for i := range ce.cores {
@@ -225,6 +287,37 @@ func (ce *CheckedEntry) reset() {
 		ce.cores[i] = nil
 	}
 	ce.cores = ce.cores[:0]
+	for i := range ce.fields {
+		// don't keep references to field values
+		ce.fields[i] = Field{}
+	}
+	if cap(ce.fields) > _inlineFields {
+		// fieldArr still holds the fields from before ce.fields outgrew it.
+		ce.fieldArr = [_inlineFields]Field{}
+	}
+	if cap(ce.fields) > _maxPooledFields {
+		ce.fields = ce.fieldArr[:0]
+	}
+	ce.fields = ce.fields[:0]
+}
+
+// With adds fields to the entry, to be written ahead of those passed to
+// Write. It lets the layers of a program stage fields that are expensive to
+// build once the level check has passed, without collecting them into a
+// slice of their own:
+//
+//	if ce := logger.Check(zap.DebugLevel, "cache miss"); ce != nil {
+//		ce.With(zap.Stringer("key", key)).Write(zap.Duration("took", took))
+//	}
+//
+// It returns the CheckedEntry, and like Write, it's safe to call on a nil
+// CheckedEntry reference.
+func (ce *CheckedEntry) With(fields ...Field) *CheckedEntry {
+	if ce == nil || ce.dirty {
+		return ce
+	}
+	ce.fields = append(ce.fields, fields...)
+	return ce
 }
 
 // Write writes the entry to the stored Cores, returns any errors, and returns
@@ -253,9 +346,33 @@ func (ce *CheckedEntry) Write(fields ...Field) {
 	}
 	ce.dirty = true
 
+	// Never hand the caller's fields to the Cores: they're interfaces, so
+	// doing so would move every caller's variadic fields to the heap.
+	// Instead, pass the CheckedEntry's own fields, which are reused once
+	// it's written, if nothing keeps them past this call, and a copy
+	// otherwise.
+	var fs []Field
+	if ce.retainsFields() {
+		fs = make([]Field, 0, len(ce.fields)+len(fields))
+		fs = append(fs, ce.fields...)
+		fs = append(fs, fields...)
+	} else {
+		ce.fields = append(ce.fields, fields...)
+		fs = ce.fields
+	}
+
+	var arena *entryArena
+	if ce.UseArena && len(ce.cores) > 1 {
+		arena = getEntryArena()
+	}
 	var err error
 	for i := range ce.cores {
-		err = multierr.Append(err, ce.cores[i].Write(ce.Entry, fields))
+		werr := writeArena(ce.cores[i], ce.Entry, fs, arena)
+		ce.Trace.recordWrite(ce.cores[i], werr)
+		err = multierr.Append(err, werr)
+	}
+	if arena != nil {
+		putEntryArena(arena)
 	}
 	if err != nil && ce.ErrorOutput != nil {
 		_, _ = fmt.Fprintf(
@@ -266,14 +383,31 @@ func (ce *CheckedEntry) Write(fields ...Field) {
 		)
 		_ = ce.ErrorOutput.Sync() // ignore error
 	}
+	if ce.AfterWrite != nil {
+		ce.AfterWrite(ce.Entry, err)
+	}
 
 	hook := ce.after
 	if hook != nil {
-		hook.OnWrite(ce, fields)
+		hook.OnWrite(ce, fs)
 	}
 	putCheckedEntry(ce)
 }
 
+// retainsFields reports whether any of the entry's Cores, or its
+// CheckWriteHook, may keep the fields they're given after Write returns.
+func (ce *CheckedEntry) retainsFields() bool {
+	if ce.after != nil && retainsFields(ce.after) {
+		return true
+	}
+	for _, c := range ce.cores {
+		if retainsFields(c) {
+			return true
+		}
+	}
+	return false
+}
+
 // AddCore adds a Core that has agreed to log this CheckedEntry. It's intended to be
 // used by Core.Check implementations, and is safe to call on nil CheckedEntry
 // references.
@@ -283,6 +417,7 @@ func (ce *CheckedEntry) AddCore(ent Entry, core Core) *CheckedEntry {
 		ce.Entry = ent
 	}
 	ce.cores = append(ce.cores, core)
+	ent.Trace.record(core, TraceAccepted, "")
 	return ce
 }
 
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/internal/bufferpool"
+	"go.uber.org/zap/internal/pool"
+)
+
+// _maxPooledArenaBytes caps the scratch buffer kept by pooled arenas, so
+// that an occasional huge entry doesn't pin its memory.
+const _maxPooledArenaBytes = 64 * 1024
+
+var _arenaPool = pool.New(func() *entryArena {
+	return &entryArena{
+		buf:   bufferpool.Get(),
+		spans: make(map[int]arenaSpan),
+	}
+})
+
+// An entryArena holds scratch memory shared by the cores that write a single
+// entry, and is reset once the entry is written. It lets cores that fan out
+// an entry, as a Tee does, encode each of its reflected values once rather
+// than once per core. See CheckedEntry.UseArena.
+type entryArena struct {
+	// buf holds the encodings of reflected fields, located by spans, which
+	// are keyed by the index of the field in the entry.
+	buf   *buffer.Buffer
+	spans map[int]arenaSpan
+}
+
+type arenaSpan struct{ start, end int }
+
+func getEntryArena() *entryArena {
+	return _arenaPool.Get()
+}
+
+func putEntryArena(a *entryArena) {
+	for k := range a.spans {
+		delete(a.spans, k)
+	}
+	if a.buf.Cap() > _maxPooledArenaBytes {
+		a.buf.Free()
+		a.buf = bufferpool.Get()
+	}
+	a.buf.Reset()
+	_arenaPool.Put(a)
+}
+
+// reflected returns the encoding of obj, the value of the top-level field at
+// index i, encoding it with enc only if no other core has encoded that field
+// for this entry. Every core receives the same fields, so the index
+// identifies the value. Values aren't identified by their addresses, since
+// marshalers may reuse a variable for several values.
+func (a *entryArena) reflected(i int, obj interface{}, enc *jsonEncoder) ([]byte, error) {
+	if s, ok := a.spans[i]; ok {
+		return a.buf.Bytes()[s.start:s.end], nil
+	}
+
+	bs, err := enc.reflect(obj)
+	if err != nil {
+		return nil, err
+	}
+	start := a.buf.Len()
+	a.buf.Write(bs)
+	a.spans[i] = arenaSpan{start: start, end: a.buf.Len()}
+	return bs, nil
+}
+
+// arenaWriter is implemented by cores that can use an entryArena when
+// writing an entry. Since the arena identifies values by their index in the
+// entry's fields, wrappers may only forward it to the cores they wrap along
+// with the fields they were given, unchanged.
+type arenaWriter interface {
+	writeArena(Entry, []Field, *entryArena) error
+}
+
+// writeArena writes an entry to core, sharing arena with the other cores the
+// entry fans out to if it's not nil and core supports it.
+func writeArena(core Core, ent Entry, fields []Field, arena *entryArena) error {
+	if arena != nil {
+		if aw, ok := core.(arenaWriter); ok {
+			return aw.writeArena(ent, fields, arena)
+		}
+	}
+	return core.Write(ent, fields)
+}
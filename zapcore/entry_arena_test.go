@@ -0,0 +1,182 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "go.uber.org/zap/zapcore"
+)
+
+// countingMarshaler counts the times it's marshaled to JSON.
+type countingMarshaler struct {
+	calls *atomic.Int32
+	err   error
+}
+
+func (m countingMarshaler) MarshalJSON() ([]byte, error) {
+	m.calls.Add(1)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []byte(`{"n":1}`), nil
+}
+
+type noHTMLEscapeEncoder struct{ *json.Encoder }
+
+func newCustomReflectedEncoder(w io.Writer) ReflectedEncoder {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return noHTMLEscapeEncoder{enc}
+}
+
+func TestCheckedEntryUseArena(t *testing.T) {
+	newCore := func(buf *bytes.Buffer, custom bool) Core {
+		cfg := testEncoderConfig()
+		if custom {
+			cfg.NewReflectedEncoder = newCustomReflectedEncoder
+		}
+		return NewCore(NewJSONEncoder(cfg), AddSync(buf), DebugLevel)
+	}
+
+	tests := []struct {
+		desc      string
+		useArena  bool
+		custom    bool
+		err       error
+		wantCalls int32
+	}{
+		{desc: "without arena", wantCalls: 3},
+		{desc: "with arena", useArena: true, wantCalls: 1},
+		{desc: "custom reflected encoder", useArena: true, custom: true, wantCalls: 2},
+		{desc: "encoding errors", useArena: true, err: errors.New("fail"), wantCalls: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var bufs [3]bytes.Buffer
+			core := NewTee(newCore(&bufs[0], false), newCore(&bufs[1], false), newCore(&bufs[2], tt.custom))
+
+			var calls atomic.Int32
+			m := countingMarshaler{calls: &calls, err: tt.err}
+			ce := core.Check(Entry{Level: InfoLevel, Message: "fan out"}, nil)
+			require.NotNil(t, ce, "Expected entry to be enabled.")
+			ce.UseArena = tt.useArena
+			ce.Write(
+				Field{Key: "a", Type: ReflectType, Interface: m},
+				Field{Key: "b", Type: ReflectType, Interface: []int{1, 2}},
+			)
+
+			assert.Equal(t, tt.wantCalls, calls.Load(), "Unexpected number of encodings.")
+			if tt.err != nil {
+				return
+			}
+			for i := range bufs {
+				assert.Contains(t, bufs[i].String(), `"a":{"n":1},"b":[1,2]`, "Unexpected output from core %d.", i)
+			}
+		})
+	}
+}
+
+type reusedValue struct{ N int }
+
+// reusingMarshaler reflects a single variable for every element.
+type reusingMarshaler int
+
+func (m reusingMarshaler) MarshalLogArray(arr ArrayEncoder) error {
+	var tmp reusedValue
+	for i := 1; i <= int(m); i++ {
+		tmp.N = i
+		if err := arr.AppendReflected(&tmp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestCheckedEntryUseArenaReusedValues(t *testing.T) {
+	var bufs [2]bytes.Buffer
+	core := NewTee(
+		NewCore(NewJSONEncoder(testEncoderConfig()), AddSync(&bufs[0]), DebugLevel),
+		NewCore(NewJSONEncoder(testEncoderConfig()), AddSync(&bufs[1]), DebugLevel),
+	)
+
+	tmp := reusedValue{N: 4}
+	ce := core.Check(Entry{Level: InfoLevel, Message: "fan out"}, nil)
+	require.NotNil(t, ce, "Expected entry to be enabled.")
+	ce.UseArena = true
+	ce.Write(
+		Field{Key: "a", Type: ArrayMarshalerType, Interface: reusingMarshaler(3)},
+		Field{Key: "b", Type: ReflectType, Interface: &tmp},
+		Field{Key: "c", Type: ReflectType, Interface: &tmp},
+	)
+
+	for i := range bufs {
+		assert.Contains(t, bufs[i].String(),
+			`"a":[{"N":1},{"N":2},{"N":3}],"b":{"N":4},"c":{"N":4}`,
+			"Unexpected output from core %d.", i)
+	}
+}
+
+func TestCheckedEntryUseArenaWrappers(t *testing.T) {
+	wrappers := map[string]func(Core) Core{
+		"sampler": func(c Core) Core {
+			return NewSamplerWithOptions(c, time.Minute, 10, 0)
+		},
+		"field tracking": NewFieldTrackingCore,
+		"clock": func(c Core) Core {
+			return NewClockCore(c, DefaultClock)
+		},
+		"dedupe": func(c Core) Core {
+			return NewDedupeCore(c, time.Minute, nil)
+		},
+	}
+
+	for name, wrap := range wrappers {
+		t.Run(name, func(t *testing.T) {
+			var bufs [2]bytes.Buffer
+			core := NewTee(
+				wrap(NewCore(NewJSONEncoder(testEncoderConfig()), AddSync(&bufs[0]), DebugLevel)),
+				wrap(NewCore(NewJSONEncoder(testEncoderConfig()), AddSync(&bufs[1]), DebugLevel)),
+			)
+
+			var calls atomic.Int32
+			ce := core.Check(Entry{Level: InfoLevel, Message: "fan out"}, nil)
+			require.NotNil(t, ce, "Expected entry to be enabled.")
+			ce.UseArena = true
+			ce.Write(Field{Key: "a", Type: ReflectType, Interface: countingMarshaler{calls: &calls}})
+
+			assert.Equal(t, int32(1), calls.Load(), "Expected the encoding to be shared through %v.", name)
+			for i := range bufs {
+				assert.Contains(t, bufs[i].String(), `"a":{"n":1}`, "Unexpected output from core %d.", i)
+			}
+		})
+	}
+}
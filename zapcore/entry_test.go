@@ -25,8 +25,10 @@ import (
 	"testing"
 
 	"go.uber.org/zap/internal/exit"
+	"go.uber.org/zap/internal/ztest"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func assertGoexit(t *testing.T, f func()) {
@@ -138,6 +140,31 @@ func TestCheckedEntryWrite(t *testing.T) {
 		ce.Write()
 		assert.True(t, hook.called, "Expected to call custom action after Write.")
 	})
+
+	t.Run("AfterWrite", func(t *testing.T) {
+		enc := NewJSONEncoder(EncoderConfig{MessageKey: "msg"})
+		ent := Entry{Message: "hello"}
+		for _, ws := range []WriteSyncer{&ztest.Discarder{}, &ztest.FailWriter{}} {
+			var (
+				gotEnt Entry
+				gotErr error
+				calls  int
+			)
+			ce := (*CheckedEntry)(nil).AddCore(ent, NewCore(enc, ws, DebugLevel))
+			ce.AfterWrite = func(ent Entry, err error) {
+				gotEnt, gotErr = ent, err
+				calls++
+			}
+			ce.Write()
+			assert.Equal(t, 1, calls, "Expected AfterWrite to be called once.")
+			assert.Equal(t, ent, gotEnt, "Unexpected entry passed to AfterWrite.")
+			if _, ok := ws.(*ztest.FailWriter); ok {
+				assert.Error(t, gotErr, "Expected AfterWrite to receive the write error.")
+			} else {
+				assert.NoError(t, gotErr, "Unexpected error passed to AfterWrite.")
+			}
+		}
+	})
 }
 
 type customHook struct {
@@ -147,3 +174,80 @@ type customHook struct {
 func (c *customHook) OnWrite(_ *CheckedEntry, _ []Field) {
 	c.called = true
 }
+
+func TestCheckedEntryFieldBuffer(t *testing.T) {
+	makeFields := func(n int) []Field {
+		fs := make([]Field, n)
+		for i := range fs {
+			fs[i] = Field{Key: "k", Type: StringType, String: "v"}
+		}
+		return fs
+	}
+
+	for _, retains := range []bool{false, true} {
+		for _, n := range []int{0, 3, _inlineFields, _maxPooledFields + 1} {
+			var got []Field
+			core := &fieldRecordingCore{retains: retains, write: func(fs []Field) {
+				if retains {
+					got = fs
+				} else {
+					got = append([]Field(nil), fs...)
+				}
+			}}
+			ce := core.Check(Entry{}, nil)
+			staged := makeFields(n)
+			ce.With(staged...).Write(Field{Key: "last", Type: StringType, String: "v"})
+			require.Len(t, got, n+1, "Unexpected number of fields with %d staged fields.", n)
+			assert.Equal(t, "last", got[n].Key, "Expected fields passed to Write last.")
+			if !retains && n+1 <= _inlineFields {
+				assert.Same(t, &ce.fieldArr[0], &ce.fields[:1][0], "Expected fields to be kept inline.")
+			}
+
+			ce.reset()
+			assert.Empty(t, ce.fields, "Expected fields to be reset.")
+			assert.LessOrEqual(t, cap(ce.fields), _maxPooledFields, "Expected field capacity to be bounded.")
+			for _, f := range ce.fields[:cap(ce.fields)] {
+				assert.Equal(t, Field{}, f, "Expected field references to be dropped.")
+			}
+			assert.Equal(t, staged, got[:n], "Cores that keep fields must not be handed pooled ones.")
+		}
+	}
+}
+
+func TestCheckedEntryWith(t *testing.T) {
+	field := func(key string) Field {
+		return Field{Key: key, Type: StringType, String: "v"}
+	}
+
+	var nilEntry *CheckedEntry
+	assert.Nil(t, nilEntry.With(field("a")), "Expected With on a nil CheckedEntry to return nil.")
+
+	var got []Field
+	core := &fieldRecordingCore{write: func(fs []Field) {
+		got = append([]Field(nil), fs...)
+	}}
+	ce := core.Check(Entry{}, nil)
+	assert.Same(t, ce, ce.With(field("a")), "Expected With to return its receiver.")
+	ce.With().With(field("b"), field("c")).Write(field("d"))
+	assert.Equal(t, []Field{field("a"), field("b"), field("c"), field("d")}, got, "Unexpected fields written.")
+}
+
+// fieldRecordingCore passes the fields of every entry it writes to a
+// function.
+type fieldRecordingCore struct {
+	nopCore
+
+	retains bool
+	write   func([]Field)
+}
+
+func (c *fieldRecordingCore) retainsFields() bool { return c.retains }
+
+func (c *fieldRecordingCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	return ce.AddCore(ent, c)
+}
+
+func (c *fieldRecordingCore) Write(_ Entry, fs []Field) error {
+	c.write(fs)
+	return nil
+}
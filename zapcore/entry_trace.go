@@ -0,0 +1,168 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// TraceEvent is something that happened to an entry on its way through a
+// tree of cores.
+type TraceEvent uint8
+
+const (
+	// TraceAccepted means that a core agreed to write the entry.
+	TraceAccepted TraceEvent = iota
+	// TraceRejected means that a core's level enabler rejected the entry.
+	TraceRejected
+	// TraceDropped means that a core, such as a sampler, filter, or
+	// deduplicating core, dropped the entry.
+	TraceDropped
+	// TraceWritten means that a core wrote the entry.
+	TraceWritten
+	// TraceWriteFailed means that a core failed to write the entry.
+	TraceWriteFailed
+)
+
+// String returns a lower-case description of the event.
+func (e TraceEvent) String() string {
+	switch e {
+	case TraceAccepted:
+		return "accepted"
+	case TraceRejected:
+		return "rejected"
+	case TraceDropped:
+		return "dropped"
+	case TraceWritten:
+		return "written"
+	case TraceWriteFailed:
+		return "write failed"
+	default:
+		return fmt.Sprintf("TraceEvent(%d)", uint8(e))
+	}
+}
+
+// TraceStep is one step in an EntryTrace.
+type TraceStep struct {
+	// Core describes the core that recorded the step by its type, such as
+	// "*zapcore.sampler". Cores that write to a WriteSyncer add the type of
+	// the WriteSyncer, as in "*zapcore.ioCore(*os.File)".
+	Core  string
+	Event TraceEvent
+	// Reason, if set, explains why the entry was rejected or dropped, as in
+	// "sampled out".
+	Reason string
+	// Err is the error returned by a failed write.
+	Err error
+}
+
+// String describes the step on a single line.
+func (s TraceStep) String() string {
+	var b strings.Builder
+	b.WriteString(s.Core)
+	b.WriteByte(' ')
+	b.WriteString(s.Event.String())
+	if s.Reason != "" {
+		b.WriteString(" (")
+		b.WriteString(s.Reason)
+		b.WriteByte(')')
+	}
+	if s.Err != nil {
+		b.WriteString(": ")
+		b.WriteString(s.Err.Error())
+	}
+	return b.String()
+}
+
+// EntryTrace records the path of an entry through a tree of cores: the
+// cores that accepted or rejected it, those that dropped it, such as
+// samplers, and those that wrote it. It answers the question of why an entry
+// didn't show up without bisecting wrapper cores.
+//
+// Tracing is off unless an entry's Trace is set, as zap's TraceEntries
+// option does for every entry a Logger checks. The built-in cores record
+// their steps; other cores may call Record themselves. An EntryTrace is safe
+// for concurrent use.
+type EntryTrace struct {
+	mu    sync.Mutex
+	steps []TraceStep
+}
+
+// Record adds a step to the trace. It's safe to call on a nil *EntryTrace,
+// so that cores can record steps whether or not the entry is traced.
+func (t *EntryTrace) Record(step TraceStep) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.steps = append(t.steps, step)
+	t.mu.Unlock()
+}
+
+// Steps returns a copy of the steps recorded so far, in order.
+func (t *EntryTrace) Steps() []TraceStep {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]TraceStep(nil), t.steps...)
+}
+
+// record adds a step for core. It's kept small enough to inline, so that
+// untraced entries only pay for a nil check.
+func (t *EntryTrace) record(core interface{}, event TraceEvent, reason string) {
+	if t != nil {
+		t.Record(TraceStep{Core: describeTracedCore(core), Event: event, Reason: reason})
+	}
+}
+
+// recordWrite adds a TraceWritten or TraceWriteFailed step for core.
+func (t *EntryTrace) recordWrite(core Core, err error) {
+	if t == nil {
+		return
+	}
+	step := TraceStep{Core: describeTracedCore(core), Event: TraceWritten}
+	if err != nil {
+		step.Event = TraceWriteFailed
+		step.Err = err
+	}
+	t.Record(step)
+}
+
+// describeTracedCore describes core by its type and, for cores that write to
+// a WriteSyncer, that of the WriteSyncer.
+func describeTracedCore(core interface{}) string {
+	var out WriteSyncer
+	switch c := core.(type) {
+	case *ioCore:
+		out = c.out
+	case *jsonCore:
+		out = c.out
+	case *BufferedCore:
+		out = c.batch.out
+	default:
+		return fmt.Sprintf("%T", core)
+	}
+	return fmt.Sprintf("%T(%T)", core, out)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/internal/ztest"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+)
+
+func TestEntryTrace(t *testing.T) {
+	enc := NewJSONEncoder(EncoderConfig{MessageKey: "msg"})
+	core := NewTee(
+		NewSamplerWithOptions(NewCore(enc, &ztest.Discarder{}, InfoLevel), time.Minute, 1, 0),
+		NewCore(enc, &ztest.FailWriter{}, ErrorLevel),
+	)
+	trace := func(lvl Level) []string {
+		ent := Entry{Level: lvl, Message: "hello", Trace: new(EntryTrace)}
+		if ce := core.Check(ent, nil); ce != nil {
+			ce.Write()
+		}
+		var steps []string
+		for _, s := range ent.Trace.Steps() {
+			steps = append(steps, s.String())
+		}
+		return steps
+	}
+
+	assert.Equal(t, []string{
+		"*zapcore.jsonCore(*ztest.Discarder) accepted",
+		"*zapcore.jsonCore(*ztest.FailWriter) accepted",
+		"*zapcore.jsonCore(*ztest.Discarder) written",
+		"*zapcore.jsonCore(*ztest.FailWriter) write failed: failed",
+	}, trace(ErrorLevel), "Unexpected trace for an entry written by both cores.")
+	assert.Equal(t, []string{
+		"*zapcore.sampler dropped (sampled out)",
+		"*zapcore.jsonCore(*ztest.FailWriter) accepted",
+		"*zapcore.jsonCore(*ztest.FailWriter) write failed: failed",
+	}, trace(ErrorLevel), "Unexpected trace for a sampled entry.")
+	assert.Equal(t, []string{
+		"*zapcore.sampler rejected",
+		"*zapcore.jsonCore(*ztest.FailWriter) rejected",
+	}, trace(DebugLevel), "Unexpected trace for a disabled entry.")
+}
+
+func TestEntryTraceNil(t *testing.T) {
+	var trace *EntryTrace
+	assert.NotPanics(t, func() { trace.Record(TraceStep{}) }, "Unexpected panic recording to a nil trace.")
+	assert.Nil(t, trace.Steps(), "Expected no steps in a nil trace.")
+}
+
+func TestTraceStepString(t *testing.T) {
+	tests := []struct {
+		step TraceStep
+		want string
+	}{
+		{TraceStep{Core: "c", Event: TraceAccepted}, "c accepted"},
+		{TraceStep{Core: "c", Event: TraceDropped, Reason: "duplicate"}, "c dropped (duplicate)"},
+		{TraceStep{Core: "c", Event: TraceWriteFailed, Err: errors.New("boom")}, "c write failed: boom"},
+		{TraceStep{Core: "c", Event: TraceEvent(42)}, "c TraceEvent(42)"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.step.String(), "Unexpected string for %#v.", tt.step)
+	}
+}
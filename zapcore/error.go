@@ -21,10 +21,14 @@
 package zapcore
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"runtime"
 
+	"go.uber.org/zap/internal/bufferpool"
 	"go.uber.org/zap/internal/pool"
+	"go.uber.org/zap/internal/stacktrace"
 )
 
 // Encodes the given error into fields of an object. A field with the given
@@ -33,12 +37,22 @@ import (
 // If the error implements fmt.Formatter, a field with the name ${key}Verbose
 // is also added with the full verbose error message.
 //
+// If the encoder's EncoderConfig sets ClassifyError and it assigns the error
+// a category, ${key}.kind and ${key}.retryable fields are added.
+//
+// If the encoder's EncoderConfig enables ErrorStacks and the error, or one it
+// wraps, carries a stack trace, a ${key}Stack field is added with the
+// innermost stack.
+//
 // Finally, if the error implements errorGroup (from go.uber.org/multierr) or
 // causer (from github.com/pkg/errors), a ${key}Causes field is added with an
 // array of objects containing the errors this error was comprised of.
 //
 //	{
 //	  "error": err.Error(),
+//	  "error.kind": "timeout",
+//	  "error.retryable": true,
+//	  "errorStack": "main.main\n\t/src/main.go:12",
 //	  "errorVerbose": fmt.Sprintf("%+v", err),
 //	  "errorCauses": [
 //	    ...
@@ -63,6 +77,17 @@ func encodeError(key string, err error, enc ObjectEncoder) (retErr error) {
 
 	basic := err.Error()
 	enc.AddString(key, basic)
+	if classify := errorClassifier(enc); classify != nil {
+		if kind, retryable := classify(err); kind != "" {
+			enc.AddString(key+".kind", kind)
+			enc.AddBool(key+".retryable", retryable)
+		}
+	}
+	if errorStacksEnabled(enc) {
+		if stack := errorStack(err); stack != "" {
+			enc.AddString(key+"Stack", stack)
+		}
+	}
 
 	switch e := err.(type) {
 	case errorGroup:
@@ -78,6 +103,104 @@ func encodeError(key string, err error, enc ObjectEncoder) (retErr error) {
 	return nil
 }
 
+// errorStacksEnabled reports whether enc is configured to add the stack
+// traces of errors.
+func errorStacksEnabled(enc ObjectEncoder) bool {
+	c, ok := enc.(interface{ errorStacksEnabled() bool })
+	return ok && c.errorStacksEnabled()
+}
+
+// errorClassifier returns the ErrorClassifier enc is configured with, if
+// any.
+func errorClassifier(enc ObjectEncoder) ErrorClassifier {
+	if c, ok := enc.(interface{ errorClassifier() ErrorClassifier }); ok {
+		return c.errorClassifier()
+	}
+	return nil
+}
+
+// errorStack returns the innermost stack trace carried by err or the errors
+// it wraps, or an empty string if there's none.
+func errorStack(err error) string {
+	var (
+		stack string
+		pcs   []uintptr
+	)
+	for ; err != nil; err = unwrapCause(err) {
+		switch e := err.(type) {
+		case interface{ StackTrace() string }:
+			stack, pcs = e.StackTrace(), nil
+		case interface{ StackTrace() []uintptr }:
+			stack, pcs = "", e.StackTrace()
+		default:
+			if p, ok := reflectedStackTrace(err); ok {
+				stack, pcs = "", p
+			}
+		}
+	}
+	if len(pcs) > 0 {
+		return formatPCs(pcs)
+	}
+	return stack
+}
+
+// reflectedStackTrace calls err's StackTrace method if it returns a slice of
+// program counters of a named type, such as github.com/pkg/errors'
+// StackTrace, which can't be matched with an interface without importing
+// that package.
+func reflectedStackTrace(err error) ([]uintptr, bool) {
+	m := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !m.IsValid() {
+		return nil, false
+	}
+	t := m.Type()
+	if t.NumIn() != 0 || t.NumOut() != 1 || t.Out(0).Kind() != reflect.Slice || t.Out(0).Elem().Kind() != reflect.Uintptr {
+		return nil, false
+	}
+
+	frames := m.Call(nil)[0]
+	pcs := make([]uintptr, frames.Len())
+	for i := range pcs {
+		pcs[i] = uintptr(frames.Index(i).Uint())
+	}
+	return pcs, true
+}
+
+// unwrapCause returns the error wrapped by err, using either Unwrap or the
+// Cause method of github.com/pkg/errors.
+func unwrapCause(err error) error {
+	if u := errors.Unwrap(err); u != nil {
+		return u
+	}
+	if c, ok := err.(interface{ Cause() error }); ok {
+		return c.Cause()
+	}
+	return nil
+}
+
+// formatPCs formats a stack of return program counters, as returned by
+// runtime.Callers, like the stack traces of log entries.
+func formatPCs(pcs []uintptr) string {
+	buf := bufferpool.Get()
+	defer buf.Free()
+
+	stackfmt := stacktrace.NewFormatter(buf)
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		// Like stack traces of entries, leave out the final runtime.main or
+		// runtime.goexit frame.
+		if !more && (frame.Function == "runtime.main" || frame.Function == "runtime.goexit") {
+			break
+		}
+		stackfmt.FormatFrame(frame)
+		if !more {
+			break
+		}
+	}
+	return buf.String()
+}
+
 type errorGroup interface {
 	// Provides read-only access to the underlying list of errors, preferably
 	// without causing any allocs.
@@ -21,12 +21,15 @@
 package zapcore_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.uber.org/multierr"
 	//revive:disable:dot-imports
@@ -224,3 +227,135 @@ func (enc brokenArrayObjectEncoder) AddArray(key string, marshaler ArrayMarshale
 func (enc brokenArrayObjectEncoder) AppendObject(ObjectMarshaler) error {
 	return enc.Err
 }
+
+// pcsError carries a stack of program counters, like the errors of
+// github.com/pkg/errors. Its StackTrace method returns a named slice type,
+// as pkg/errors' does.
+type pcsError struct {
+	msg string
+	pcs []uintptr
+}
+
+type fakeFrame uintptr
+
+type fakeStackTrace []fakeFrame
+
+func (e *pcsError) Error() string { return e.msg }
+
+func (e *pcsError) StackTrace() fakeStackTrace {
+	st := make(fakeStackTrace, len(e.pcs))
+	for i, pc := range e.pcs {
+		st[i] = fakeFrame(pc)
+	}
+	return st
+}
+
+type rawPCsError struct{ pcs []uintptr }
+
+func (e rawPCsError) Error() string         { return "raw" }
+func (e rawPCsError) StackTrace() []uintptr { return e.pcs }
+
+type stringStackError struct{}
+
+func (stringStackError) Error() string      { return "string" }
+func (stringStackError) StackTrace() string { return "main.main\n\tmain.go:1" }
+
+type stackWrapper struct{ err error }
+
+func (e stackWrapper) Error() string      { return "wrapper: " + e.err.Error() }
+func (e stackWrapper) Unwrap() error      { return e.err }
+func (e stackWrapper) StackTrace() string { return "outer" }
+
+func callers() []uintptr {
+	pcs := make([]uintptr, 32)
+	return pcs[:runtime.Callers(1, pcs)]
+}
+
+func TestErrorStacks(t *testing.T) {
+	pcs := callers()
+	tests := []struct {
+		desc string
+		err  error
+		want string // regexp; empty if no stack is expected
+	}{
+		{desc: "no stack", err: errors.New("plain")},
+		{desc: "pkg/errors style", err: &pcsError{"boom", pcs}, want: `^go.uber.org/zap/zapcore_test.callers\n\t.*error_test.go:\d+\ngo.uber.org/zap/zapcore_test.TestErrorStacks\n`},
+		{desc: "raw program counters", err: rawPCsError{pcs}, want: `zapcore_test.TestErrorStacks\n`},
+		{desc: "string", err: stringStackError{}, want: `^main.main\n\tmain.go:1$`},
+		{desc: "wrapped", err: fmt.Errorf("outer: %w", stringStackError{}), want: `^main.main\n\tmain.go:1$`},
+		{desc: "innermost wins", err: stackWrapper{rawPCsError{pcs}}, want: `zapcore_test.TestErrorStacks\n`},
+		{desc: "outer only", err: stackWrapper{errors.New("inner")}, want: `^outer$`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			for _, enabled := range []bool{false, true} {
+				cfg := testEncoderConfig()
+				cfg.ErrorStacks = enabled
+				enc := NewJSONEncoder(cfg)
+				enc.AddObject("o", ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+					Field{Key: "k", Type: ErrorType, Interface: tt.err}.AddTo(enc)
+					return nil
+				}))
+				buf, err := enc.EncodeEntry(Entry{}, nil)
+				require.NoError(t, err, "Unexpected encoding error.")
+
+				var out struct {
+					O map[string]interface{} `json:"o"`
+				}
+				require.NoError(t, json.Unmarshal(buf.Bytes(), &out), "Invalid JSON.")
+				stack, ok := out.O["kStack"].(string)
+				if !enabled || tt.want == "" {
+					assert.False(t, ok, "Unexpected stack.")
+					continue
+				}
+				assert.Regexp(t, tt.want, stack, "Unexpected stack.")
+				assert.NotContains(t, stack, "runtime.goexit", "Expected the final runtime frame to be left out.")
+			}
+		})
+	}
+}
+
+func TestErrorClassifier(t *testing.T) {
+	errTimeout := errors.New("timeout")
+	classify := func(err error) (string, bool) {
+		switch {
+		case errors.Is(err, errTimeout):
+			return "timeout", true
+		case errors.Is(err, io.EOF):
+			return "eof", false
+		default:
+			return "", false
+		}
+	}
+	enc := NewJSONEncoder(EncoderConfig{ClassifyError: classify})
+
+	tests := []struct {
+		desc string
+		err  error
+		want string
+	}{
+		{
+			desc: "classified",
+			err:  fmt.Errorf("dial: %w", errTimeout),
+			want: `{"k":"dial: timeout","k.kind":"timeout","k.retryable":true}`,
+		},
+		{
+			desc: "unclassified",
+			err:  errors.New("plain"),
+			want: `{"k":"plain"}`,
+		},
+		{
+			desc: "causes",
+			err:  multierr.Combine(io.EOF, errors.New("plain")),
+			want: `{"k":"EOF; plain","k.kind":"eof","k.retryable":false,"kCauses":[{"error":"EOF","error.kind":"eof","error.retryable":false},{"error":"plain"}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			buf, err := enc.EncodeEntry(Entry{}, []Field{{Key: "k", Type: ErrorType, Interface: tt.err}})
+			require.NoError(t, err, "Unexpected encoding error.")
+			assert.Equal(t, tt.want+"\n", buf.String(), "Unexpected output.")
+		})
+	}
+}
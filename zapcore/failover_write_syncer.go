@@ -0,0 +1,188 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+const (
+	_defaultFailoverThreshold     = 3
+	_defaultFailoverProbeInterval = 5 * time.Second
+)
+
+// FailoverPolicy configures when a WriteSyncer built with
+// NewFailoverWriteSyncer fails over from its primary to its fallback, and
+// when it tries the primary again.
+type FailoverPolicy struct {
+	// FailureThreshold is the number of consecutive failed writes to the
+	// primary after which all writes go to the fallback.
+	//
+	// Defaults to 3 if unspecified.
+	FailureThreshold int
+
+	// ProbeInterval is how long to wait after failing over, or after a
+	// failed probe, before the next write is tried against the primary
+	// again. If that write succeeds, the primary is restored.
+	//
+	// Defaults to 5 seconds if unspecified.
+	ProbeInterval time.Duration
+
+	// OnTransition, if specified, is called every time the WriteSyncer
+	// fails over to the fallback or recovers to the primary. It's called
+	// synchronously, after the write that caused the transition, so it may
+	// safely log through the same WriteSyncer.
+	OnTransition func(FailoverEvent)
+
+	// Clock, if specified, provides the source of time used to schedule
+	// probes.
+	//
+	// Defaults to the system clock.
+	Clock Clock
+}
+
+// FailoverEvent describes a transition of a failover WriteSyncer between its
+// primary and fallback.
+type FailoverEvent struct {
+	// Time is when the transition happened.
+	Time time.Time
+
+	// FailedOver is true if writes now go to the fallback, and false if the
+	// primary has recovered.
+	FailedOver bool
+
+	// Err is the write error that caused a failover. It's nil for
+	// recoveries.
+	Err error
+}
+
+type failoverWriteSyncer struct {
+	primary  WriteSyncer
+	fallback WriteSyncer
+
+	threshold     int
+	probeInterval time.Duration
+	onTransition  func(FailoverEvent)
+	clock         Clock
+
+	mu        sync.Mutex
+	failures  int       // consecutive failed writes to the primary
+	failed    bool      // whether writes go to the fallback
+	nextProbe time.Time // when failed, when to try the primary again
+}
+
+// NewFailoverWriteSyncer returns a WriteSyncer that writes to primary until
+// the writes fail persistently, as is common with network sinks and pipes,
+// and then to fallback, typically a local file. While failed over, it
+// periodically tries the primary again and switches back as soon as a write
+// to it succeeds.
+//
+// Writes that fail on the primary are repeated on the fallback, so entries
+// aren't lost while failures accumulate towards the policy's threshold. A
+// write that fails partway may therefore be duplicated across the two.
+//
+// The returned WriteSyncer is safe for concurrent use; writes are
+// serialized, so there's no need to wrap either sink with Lock.
+func NewFailoverWriteSyncer(primary, fallback WriteSyncer, policy FailoverPolicy) WriteSyncer {
+	ws := &failoverWriteSyncer{
+		primary:       primary,
+		fallback:      fallback,
+		threshold:     policy.FailureThreshold,
+		probeInterval: policy.ProbeInterval,
+		onTransition:  policy.OnTransition,
+		clock:         policy.Clock,
+	}
+	if ws.threshold <= 0 {
+		ws.threshold = _defaultFailoverThreshold
+	}
+	if ws.probeInterval <= 0 {
+		ws.probeInterval = _defaultFailoverProbeInterval
+	}
+	if ws.clock == nil {
+		ws.clock = DefaultClock
+	}
+	return ws
+}
+
+func (s *failoverWriteSyncer) Write(bs []byte) (int, error) {
+	s.mu.Lock()
+	n, ev, err := s.write(bs)
+	s.mu.Unlock()
+
+	if ev != nil && s.onTransition != nil {
+		s.onTransition(*ev)
+	}
+	return n, err
+}
+
+// write writes bs to the active sink and reports any resulting transition.
+// It must be called with s.mu held.
+func (s *failoverWriteSyncer) write(bs []byte) (int, *FailoverEvent, error) {
+	if s.failed {
+		now := s.clock.Now()
+		if now.Before(s.nextProbe) {
+			n, err := s.fallback.Write(bs)
+			return n, nil, err
+		}
+		if n, err := s.primary.Write(bs); err == nil {
+			s.failed = false
+			s.failures = 0
+			return n, &FailoverEvent{Time: now}, nil
+		}
+		s.nextProbe = now.Add(s.probeInterval)
+		n, err := s.fallback.Write(bs)
+		return n, nil, err
+	}
+
+	n, err := s.primary.Write(bs)
+	if err == nil {
+		s.failures = 0
+		return n, nil, nil
+	}
+
+	s.failures++
+	var ev *FailoverEvent
+	if s.failures >= s.threshold {
+		now := s.clock.Now()
+		s.failed = true
+		s.nextProbe = now.Add(s.probeInterval)
+		ev = &FailoverEvent{Time: now, FailedOver: true, Err: err}
+	}
+	n, ferr := s.fallback.Write(bs)
+	if ferr != nil {
+		return n, ev, multierr.Append(err, ferr)
+	}
+	return n, ev, nil
+}
+
+// Sync flushes whichever sink is currently receiving writes.
+func (s *failoverWriteSyncer) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failed {
+		return s.fallback.Sync()
+	}
+	return s.primary.Sync()
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/internal/ztest"
+)
+
+// toggleWriter fails every write while its err is set.
+type toggleWriter struct {
+	bytes.Buffer
+	ztest.Syncer
+
+	err error
+}
+
+func (w *toggleWriter) Write(bs []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	return w.Buffer.Write(bs)
+}
+
+func TestFailoverWriteSyncer(t *testing.T) {
+	clock := ztest.NewMockClock()
+	primary := &toggleWriter{}
+	fallback := &toggleWriter{}
+	var events []FailoverEvent
+	ws := NewFailoverWriteSyncer(primary, fallback, FailoverPolicy{
+		FailureThreshold: 2,
+		ProbeInterval:    time.Minute,
+		OnTransition:     func(ev FailoverEvent) { events = append(events, ev) },
+		Clock:            clock,
+	})
+
+	write := func(s string) {
+		n, err := ws.Write([]byte(s))
+		require.NoError(t, err, "Unexpected error writing %q.", s)
+		require.Equal(t, len(s), n, "Unexpected byte count writing %q.", s)
+	}
+
+	write("a")
+	assert.Equal(t, "a", primary.String(), "Expected healthy writes to go to the primary.")
+
+	down := errors.New("primary down")
+	primary.err = down
+	write("b")
+	assert.Empty(t, events, "Failed over before reaching the threshold.")
+	write("c")
+	require.Len(t, events, 1, "Expected to fail over at the threshold.")
+	assert.Equal(t, FailoverEvent{Time: clock.Now(), FailedOver: true, Err: down}, events[0])
+	assert.Equal(t, "bc", fallback.String(), "Expected failed writes to be repeated on the fallback.")
+
+	require.NoError(t, ws.Sync(), "Unexpected error syncing.")
+	assert.True(t, fallback.Called(), "Expected to sync the fallback while failed over.")
+	assert.False(t, primary.Called(), "Unexpected sync of the primary while failed over.")
+
+	primary.err = nil
+	write("d")
+	assert.Equal(t, "bcd", fallback.String(), "Expected writes to stay on the fallback until the next probe.")
+
+	clock.Add(time.Minute)
+	write("e")
+	require.Len(t, events, 2, "Expected to recover once a probe succeeds.")
+	assert.Equal(t, FailoverEvent{Time: clock.Now()}, events[1])
+	assert.Equal(t, "ae", primary.String(), "Expected the probe write to go to the primary.")
+}
+
+func TestFailoverWriteSyncerFailedProbe(t *testing.T) {
+	clock := ztest.NewMockClock()
+	primary := &toggleWriter{err: errors.New("down")}
+	fallback := &toggleWriter{}
+	ws := NewFailoverWriteSyncer(primary, fallback, FailoverPolicy{
+		FailureThreshold: 1,
+		ProbeInterval:    time.Minute,
+		Clock:            clock,
+	})
+
+	_, err := ws.Write([]byte("a"))
+	require.NoError(t, err)
+
+	clock.Add(time.Minute)
+	_, err = ws.Write([]byte("b"))
+	require.NoError(t, err, "Expected a failed probe to fall back.")
+
+	primary.err = nil
+	clock.Add(30 * time.Second)
+	_, err = ws.Write([]byte("c"))
+	require.NoError(t, err)
+	assert.Equal(t, "abc", fallback.String(), "Expected a failed probe to delay the next one.")
+	assert.Empty(t, primary.String(), "Unexpected write to the primary.")
+}
+
+func TestFailoverWriteSyncerBothFail(t *testing.T) {
+	ws := NewFailoverWriteSyncer(
+		&toggleWriter{err: errors.New("primary")},
+		&toggleWriter{err: errors.New("fallback")},
+		FailoverPolicy{},
+	)
+	_, err := ws.Write([]byte("a"))
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "primary")
+	assert.ErrorContains(t, err, "fallback")
+}
@@ -26,6 +26,8 @@ import (
 	"math"
 	"reflect"
 	"time"
+
+	"go.uber.org/zap/internal/monotonic"
 )
 
 // A FieldType indicates which member of the Field union struct should be used
@@ -39,7 +41,10 @@ const (
 	ArrayMarshalerType
 	// ObjectMarshalerType indicates that the field carries an ObjectMarshaler.
 	ObjectMarshalerType
-	// BinaryType indicates that the field carries an opaque binary blob.
+	// BinaryType indicates that the field carries an opaque binary blob. If
+	// the field's String names a BinaryEncoding, such as "hex", the blob is
+	// written as a string in that encoding, whatever the encoder's
+	// configuration.
 	BinaryType
 	// BoolType indicates that the field carries a bool.
 	BoolType
@@ -96,6 +101,28 @@ const (
 	// InlineMarshalerType indicates that the field carries an ObjectMarshaler
 	// that should be inlined.
 	InlineMarshalerType
+	// RawJSONType indicates that the field carries pre-serialized JSON.
+	RawJSONType
+	// CloseNamespaceType signals the end of the most recently opened
+	// namespace. All subsequent fields should be added to its parent.
+	CloseNamespaceType
+	// TimeLayoutType indicates that the field carries a time.Time, stored
+	// like those of TimeType, that's written as a string in the layout held
+	// in the field's String rather than by the encoder's EncodeTime.
+	TimeLayoutType
+	// SinceType indicates that the field carries a start time, stored in
+	// Integer as an offset from a reference time, and is written as the
+	// time elapsed since then when it's encoded. The field's String picks
+	// the unit: empty for a time.Duration, SinceMillis for an integer
+	// number of milliseconds, or SinceSeconds for a float64 number of
+	// seconds.
+	SinceType
+)
+
+// Units for the String of SinceType fields.
+const (
+	SinceMillis  = "ms"
+	SinceSeconds = "s"
 )
 
 // A Field is a marshaling operation used to add a key-value pair to a logger's
@@ -112,6 +139,13 @@ type Field struct {
 // AddTo exports a field through the ObjectEncoder interface. It's primarily
 // useful to library authors, and shouldn't be necessary in most applications.
 func (f Field) AddTo(enc ObjectEncoder) {
+	if err := f.addTo(enc); err != nil {
+		enc.AddString(fmt.Sprintf("%sError", f.Key), err.Error())
+	}
+}
+
+// addTo exports a field, returning any error from its marshaler.
+func (f Field) addTo(enc ObjectEncoder) error {
 	var err error
 
 	switch f.Type {
@@ -122,7 +156,7 @@ func (f Field) AddTo(enc ObjectEncoder) {
 	case InlineMarshalerType:
 		err = f.Interface.(ObjectMarshaler).MarshalLogObject(enc)
 	case BinaryType:
-		enc.AddBinary(f.Key, f.Interface.([]byte))
+		err = addBinary(enc, f.Key, f.String, f.Interface.([]byte))
 	case BoolType:
 		enc.AddBool(f.Key, f.Integer == 1)
 	case ByteStringType:
@@ -148,14 +182,13 @@ func (f Field) AddTo(enc ObjectEncoder) {
 	case StringType:
 		enc.AddString(f.Key, f.String)
 	case TimeType:
-		if f.Interface != nil {
-			enc.AddTime(f.Key, time.Unix(0, f.Integer).In(f.Interface.(*time.Location)))
-		} else {
-			// Fall back to UTC if location is nil.
-			enc.AddTime(f.Key, time.Unix(0, f.Integer))
-		}
+		enc.AddTime(f.Key, f.unixNanoTime())
+	case TimeLayoutType:
+		enc.AddString(f.Key, f.unixNanoTime().Format(f.String))
 	case TimeFullType:
 		enc.AddTime(f.Key, f.Interface.(time.Time))
+	case SinceType:
+		addSince(enc, f.Key, f.String, monotonic.Since(f.Integer))
 	case Uint64Type:
 		enc.AddUint64(f.Key, uint64(f.Integer))
 	case Uint32Type:
@@ -166,23 +199,46 @@ func (f Field) AddTo(enc ObjectEncoder) {
 		enc.AddUint8(f.Key, uint8(f.Integer))
 	case UintptrType:
 		enc.AddUintptr(f.Key, uintptr(f.Integer))
+	case RawJSONType:
+		addRawJSON(enc, f.Key, f.Interface.([]byte))
 	case ReflectType:
 		err = enc.AddReflected(f.Key, f.Interface)
 	case NamespaceType:
 		enc.OpenNamespace(f.Key)
+	case CloseNamespaceType:
+		closeNamespace(enc)
 	case StringerType:
 		err = encodeStringer(f.Key, f.Interface, enc)
 	case ErrorType:
-		err = encodeError(f.Key, f.Interface.(error), enc)
+		err = addError(enc, f.Key, f.Interface.(error))
 	case SkipType:
 		break
 	default:
 		panic(fmt.Sprintf("unknown field type: %v", f))
 	}
+	return err
+}
 
-	if err != nil {
-		enc.AddString(fmt.Sprintf("%sError", f.Key), err.Error())
+// addSince adds an elapsed time in the given SinceType unit.
+func addSince(enc ObjectEncoder, key, unit string, elapsed time.Duration) {
+	switch unit {
+	case SinceMillis:
+		enc.AddInt64(key, elapsed.Milliseconds())
+	case SinceSeconds:
+		enc.AddFloat64(key, elapsed.Seconds())
+	default:
+		enc.AddDuration(key, elapsed)
+	}
+}
+
+// unixNanoTime returns the time carried by a TimeType or TimeLayoutType
+// field.
+func (f Field) unixNanoTime() time.Time {
+	if f.Interface != nil {
+		return time.Unix(0, f.Integer).In(f.Interface.(*time.Location))
 	}
+	// Fall back to UTC if location is nil.
+	return time.Unix(0, f.Integer)
 }
 
 // Equals returns whether two fields are equal. For non-primitive types such as
@@ -196,22 +252,78 @@ func (f Field) Equals(other Field) bool {
 	}
 
 	switch f.Type {
-	case BinaryType, ByteStringType:
-		return bytes.Equal(f.Interface.([]byte), other.Interface.([]byte))
-	case ArrayMarshalerType, ObjectMarshalerType, ErrorType, ReflectType:
+	case BinaryType, ByteStringType, RawJSONType:
+		return f.String == other.String && bytes.Equal(f.Interface.([]byte), other.Interface.([]byte))
+	case ArrayMarshalerType, ObjectMarshalerType, InlineMarshalerType, ErrorType, ReflectType:
+		// Inline marshalers include Lazy fields, which hold functions. Those
+		// aren't comparable with ==, and DeepEqual treats them as unequal.
 		return reflect.DeepEqual(f.Interface, other.Interface)
 	default:
 		return f == other
 	}
 }
 
-func addFields(enc ObjectEncoder, fields []Field) {
+// addRawJSON embeds JSON verbatim if the encoder supports it, and adds it as
+// a string otherwise.
+func addRawJSON(enc ObjectEncoder, key string, val []byte) {
+	if re, ok := enc.(RawJSONEncoder); ok {
+		re.AddRawJSON(key, val)
+		return
+	}
+	enc.AddByteString(key, val)
+}
+
+// namespaceCloser is implemented by ObjectEncoders that can close the most
+// recently opened namespace.
+type namespaceCloser interface {
+	CloseNamespace()
+}
+
+// closeNamespace closes the encoder's most recently opened namespace, if the
+// encoder supports it. Encoders that don't keep all subsequent fields in the
+// namespace.
+func closeNamespace(enc ObjectEncoder) {
+	if nc, ok := enc.(namespaceCloser); ok {
+		nc.CloseNamespace()
+	}
+}
+
+// errorEncoder is implemented by ObjectEncoders that lay out errors
+// themselves.
+type errorEncoder interface {
+	AddError(key string, err error) error
+}
+
+// addError adds an error using the encoder's own layout if it has one, and
+// the fields described by encodeError otherwise.
+func addError(enc ObjectEncoder, key string, err error) error {
+	if ee, ok := enc.(errorEncoder); ok {
+		return ee.AddError(key, err)
+	}
+	return encodeError(key, err, enc)
+}
+
+// addFields adds fields to enc. If enc recovers field errors, it returns
+// the fields that failed.
+func addFields(enc ObjectEncoder, fields []Field) error {
+	if re, ok := enc.(recoveringEncoder); ok && re.fieldErrorPolicy() == RecoverFieldErrors {
+		return addFieldsRecovering(re, fields)
+	}
 	for i := range fields {
 		fields[i].AddTo(enc)
 	}
+	return nil
+}
+
+func encodeStringer(key string, stringer interface{}, enc ObjectEncoder) error {
+	if c, ok := enc.(interface{ stringersTrusted() bool }); ok && c.stringersTrusted() {
+		enc.AddString(key, stringer.(fmt.Stringer).String())
+		return nil
+	}
+	return encodeStringerSafely(key, stringer, enc)
 }
 
-func encodeStringer(key string, stringer interface{}, enc ObjectEncoder) (retErr error) {
+func encodeStringerSafely(key string, stringer interface{}, enc ObjectEncoder) (retErr error) {
 	// Try to capture panics (from nil references or otherwise) when calling
 	// the String() method, similar to https://golang.org/src/fmt/print.go#L540
 	defer func() {
@@ -224,7 +336,9 @@ func encodeStringer(key string, stringer interface{}, enc ObjectEncoder) (retErr
 				return
 			}
 
-			retErr = fmt.Errorf("PANIC=%v", err)
+			// Name the Stringer's type, since the panic value alone rarely
+			// says where it came from.
+			retErr = fmt.Errorf("PANIC=%v (in String method of %T)", err, stringer)
 		}
 	}()
 
@@ -0,0 +1,156 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"fmt"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap/buffer"
+)
+
+// FieldErrorPolicy controls how an encoder handles fields that fail to
+// encode, such as an ObjectMarshaler that returns an error or a Stringer
+// that panics.
+type FieldErrorPolicy uint8
+
+const (
+	// InlineFieldErrors adds a "<key>Error" field after whatever output the
+	// failing field produced, and lets panics from marshalers propagate. This
+	// is the default.
+	InlineFieldErrors FieldErrorPolicy = iota
+	// RecoverFieldErrors recovers panics field by field, discards the partial
+	// output of a failing field, and substitutes a "<key>Error" placeholder.
+	// The rest of the entry is preserved. EncodeEntry still returns the
+	// encoded entry without an error, but cores built with NewCore or
+	// NewBufferedCore write the entry and then return a *FieldEncodingError
+	// for each failed field, so that the failures reach the logger's
+	// ErrorOutput and ErrorHook.
+	RecoverFieldErrors
+)
+
+// String returns the policy's name.
+func (p FieldErrorPolicy) String() string {
+	switch p {
+	case InlineFieldErrors:
+		return "inline"
+	case RecoverFieldErrors:
+		return "recover"
+	default:
+		return fmt.Sprintf("FieldErrorPolicy(%d)", uint8(p))
+	}
+}
+
+// UnmarshalText unmarshals text to a FieldErrorPolicy. "inline" and the
+// empty string unmarshal to InlineFieldErrors, and "recover" unmarshals to
+// RecoverFieldErrors.
+func (p *FieldErrorPolicy) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "inline", "":
+		*p = InlineFieldErrors
+	case "recover":
+		*p = RecoverFieldErrors
+	default:
+		return fmt.Errorf("unrecognized field error policy: %q", text)
+	}
+	return nil
+}
+
+// FieldEncodingError describes a field that an encoder replaced with a
+// placeholder under RecoverFieldErrors.
+type FieldEncodingError struct {
+	Key string
+	Err error
+}
+
+func (e *FieldEncodingError) Error() string {
+	return fmt.Sprintf("failed to encode field %q: %v", e.Key, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *FieldEncodingError) Unwrap() error {
+	return e.Err
+}
+
+// fieldErrorEncoder is implemented by encoders that support
+// RecoverFieldErrors. Like any Encoder's, their EncodeEntry returns either
+// an entry or an error; encodeEntryFieldErrors also returns the fields
+// replaced by placeholders in an entry it encoded, so that cores can write
+// the entry and still report them.
+type fieldErrorEncoder interface {
+	encodeEntryFieldErrors(Entry, []Field) (buf *buffer.Buffer, fieldErr error, err error)
+}
+
+// encodeEntryFieldErrors encodes an entry with enc. If enc doesn't support
+// RecoverFieldErrors, fieldErr is always nil.
+func encodeEntryFieldErrors(enc Encoder, ent Entry, fields []Field) (buf *buffer.Buffer, fieldErr error, err error) {
+	if fe, ok := enc.(fieldErrorEncoder); ok {
+		return fe.encodeEntryFieldErrors(ent, fields)
+	}
+	buf, err = enc.EncodeEntry(ent, fields)
+	return buf, nil, err
+}
+
+// fieldMark is a position in an encoder's output that a failing field can
+// be rolled back to.
+type fieldMark struct {
+	len            int
+	openNamespaces int
+}
+
+// recoveringEncoder is implemented by encoders that support
+// RecoverFieldErrors.
+type recoveringEncoder interface {
+	ObjectEncoder
+
+	fieldErrorPolicy() FieldErrorPolicy
+	mark() fieldMark
+	rollback(fieldMark)
+}
+
+// addFieldsRecovering adds fields to enc, replacing any that fail with a
+// placeholder. It returns the failures.
+func addFieldsRecovering(enc recoveringEncoder, fields []Field) error {
+	var errs error
+	for i := range fields {
+		errs = multierr.Append(errs, addFieldRecovering(enc, fields[i]))
+	}
+	return errs
+}
+
+func addFieldRecovering(enc recoveringEncoder, f Field) (retErr error) {
+	m := enc.mark()
+	fail := func(err error) error {
+		enc.rollback(m)
+		enc.AddString(f.Key+"Error", err.Error())
+		return &FieldEncodingError{Key: f.Key, Err: err}
+	}
+	defer func() {
+		if v := recover(); v != nil {
+			retErr = fail(fmt.Errorf("PANIC=%v", v))
+		}
+	}()
+
+	if err := f.addTo(enc); err != nil {
+		return fail(err)
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/internal/ztest"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+)
+
+// halfMarshaler adds a field, then fails with err or panics with
+// panicValue.
+type halfMarshaler struct {
+	namespace  bool
+	err        error
+	panicValue interface{}
+}
+
+func (m halfMarshaler) MarshalLogObject(enc ObjectEncoder) error {
+	if m.namespace {
+		enc.OpenNamespace("ns")
+	}
+	enc.AddInt("a", 1)
+	if m.panicValue != nil {
+		panic(m.panicValue)
+	}
+	return m.err
+}
+
+func TestFieldErrorPolicyUnmarshalText(t *testing.T) {
+	tests := []struct {
+		text string
+		want FieldErrorPolicy
+	}{
+		{"", InlineFieldErrors},
+		{"inline", InlineFieldErrors},
+		{"recover", RecoverFieldErrors},
+	}
+	for _, tt := range tests {
+		var p FieldErrorPolicy
+		require.NoError(t, p.UnmarshalText([]byte(tt.text)), "Unexpected error unmarshaling %q.", tt.text)
+		assert.Equal(t, tt.want, p, "Unexpected policy for %q.", tt.text)
+		if tt.text != "" {
+			assert.Equal(t, tt.text, p.String(), "Unexpected string for %v.", p)
+		}
+	}
+
+	var p FieldErrorPolicy
+	assert.Error(t, p.UnmarshalText([]byte("ignore")), "Expected an error for an unknown policy.")
+	assert.Equal(t, "FieldErrorPolicy(9)", FieldErrorPolicy(9).String(), "Unexpected string for an unknown policy.")
+}
+
+func TestFieldErrorsInline(t *testing.T) {
+	enc := NewJSONEncoder(EncoderConfig{MessageKey: "msg"})
+
+	buf, err := enc.EncodeEntry(Entry{Message: "hi"}, []Field{
+		{Key: "obj", Type: ObjectMarshalerType, Interface: halfMarshaler{err: errors.New("fail")}},
+	})
+	require.NoError(t, err, "Unexpected error encoding entry.")
+	assert.Equal(t, `{"msg":"hi","obj":{"a":1},"objError":"fail"}`+"\n", buf.String(), "Unexpected output.")
+	buf.Free()
+
+	assert.Panics(t, func() {
+		_, _ = enc.EncodeEntry(Entry{}, []Field{
+			{Key: "obj", Type: ObjectMarshalerType, Interface: halfMarshaler{panicValue: "boom"}},
+		})
+	}, "Expected panics to propagate by default.")
+}
+
+func TestFieldErrorsRecover(t *testing.T) {
+	cfg := EncoderConfig{MessageKey: "msg", FieldErrors: RecoverFieldErrors}
+	fields := []Field{
+		{Key: "before", Type: StringType, String: "x"},
+		{Key: "panics", Type: ObjectMarshalerType, Interface: halfMarshaler{namespace: true, panicValue: "boom"}},
+		{Key: "fails", Type: ObjectMarshalerType, Interface: halfMarshaler{err: errors.New("fail")}},
+		{Key: "inline", Type: InlineMarshalerType, Interface: halfMarshaler{namespace: true, panicValue: "oops"}},
+		{Key: "after", Type: StringType, String: "y"},
+	}
+
+	tests := []struct {
+		desc string
+		enc  Encoder
+		want string
+	}{
+		{
+			desc: "json",
+			enc:  NewJSONEncoder(cfg),
+			want: `{"msg":"hi","before":"x","panicsError":"PANIC=boom","failsError":"fail","inlineError":"PANIC=oops","after":"y"}` + "\n",
+		},
+		{
+			desc: "console",
+			enc:  NewConsoleEncoder(cfg),
+			want: `hi	{"before": "x", "panicsError": "PANIC=boom", "failsError": "fail", "inlineError": "PANIC=oops", "after": "y"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			buf, err := tt.enc.EncodeEntry(Entry{Message: "hi"}, fields)
+			require.NoError(t, err, "Expected EncodeEntry to return the entry without an error.")
+			assert.Equal(t, tt.want, buf.String(), "Unexpected output.")
+			buf.Free()
+
+			out := &ztest.Buffer{}
+			err = NewCore(tt.enc, out, DebugLevel).Write(Entry{Message: "hi"}, fields)
+			assert.Equal(t, tt.want, out.String(), "Expected the entry to be written.")
+			var fe *FieldEncodingError
+			require.ErrorAs(t, err, &fe, "Expected a FieldEncodingError.")
+			assert.Equal(t, "panics", fe.Key, "Unexpected key for the first failed field.")
+			assert.EqualError(t, err, `failed to encode field "panics": PANIC=boom; `+
+				`failed to encode field "fails": fail; `+
+				`failed to encode field "inline": PANIC=oops`, "Unexpected error.")
+		})
+	}
+}
+
+func TestFieldErrorsRecoverWith(t *testing.T) {
+	buf := &ztest.Buffer{}
+	core := NewCore(
+		NewJSONEncoder(EncoderConfig{MessageKey: "msg", FieldErrors: RecoverFieldErrors}),
+		buf,
+		DebugLevel,
+	).With([]Field{
+		{Key: "ctx", Type: ObjectMarshalerType, Interface: halfMarshaler{panicValue: "boom"}},
+	})
+
+	err := core.Write(Entry{Message: "hi"}, []Field{
+		{Key: "obj", Type: ObjectMarshalerType, Interface: halfMarshaler{err: errors.New("fail")}},
+	})
+	assert.EqualError(t, err, `failed to encode field "obj": fail`, "Expected Write to report the failed field.")
+	assert.Equal(t, `{"msg":"hi","ctxError":"PANIC=boom","objError":"fail"}`, buf.Stripped(), "Expected the entry to be written.")
+}
+
+func TestFieldErrorsRecoverWrappers(t *testing.T) {
+	enc := NewJSONEncoder(EncoderConfig{MessageKey: "msg", FieldErrors: RecoverFieldErrors})
+	fields := []Field{
+		{Key: "obj", Type: ObjectMarshalerType, Interface: halfMarshaler{err: errors.New("fail")}},
+		{Key: "big", Type: StringType, String: strings.Repeat("x", 40)},
+	}
+
+	tests := []struct {
+		desc string
+		core func(*testing.T, WriteSyncer) Core
+		want string
+	}{
+		{
+			desc: "MaxEntryBytes",
+			core: func(_ *testing.T, ws WriteSyncer) Core {
+				return NewCore(MaxEntryBytes(enc, 64, nil), ws, DebugLevel)
+			},
+			want: `{"msg":"hi","objError":"fail","big":"xxxx...","truncated":true}`,
+		},
+		{
+			desc: "BufferedCore",
+			core: func(t *testing.T, ws WriteSyncer) Core {
+				core := NewBufferedCore(enc, ws, DebugLevel, BatchConfig{})
+				t.Cleanup(func() { assert.NoError(t, core.Stop(), "Unexpected error stopping.") })
+				return core
+			},
+			want: `{"msg":"hi","objError":"fail","big":"` + strings.Repeat("x", 40) + `"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			out := &ztest.Buffer{}
+			core := tt.core(t, out)
+			err := core.Write(Entry{Message: "hi"}, fields)
+			assert.EqualError(t, err, `failed to encode field "obj": fail`, "Expected Write to report the failed field.")
+			require.NoError(t, core.Sync(), "Unexpected error syncing.")
+			assert.Equal(t, tt.want, out.Stripped(), "Expected the entry to be written.")
+		})
+	}
+}
@@ -113,10 +113,10 @@ func TestFieldAddingError(t *testing.T) {
 		{t: ArrayMarshalerType, iface: users(-1), want: []interface{}{}, err: "too few users"},
 		{t: ObjectMarshalerType, iface: users(-1), want: map[string]interface{}{}, err: "too few users"},
 		{t: InlineMarshalerType, iface: users(-1), want: nil, err: "too few users"},
-		{t: StringerType, iface: obj{}, want: empty, err: "PANIC=interface conversion: zapcore_test.obj is not fmt.Stringer: missing method String"},
-		{t: StringerType, iface: &obj{1}, want: empty, err: "PANIC=panic with string"},
-		{t: StringerType, iface: &obj{2}, want: empty, err: "PANIC=panic with error"},
-		{t: StringerType, iface: &obj{3}, want: empty, err: "PANIC=<nil>"},
+		{t: StringerType, iface: obj{}, want: empty, err: "PANIC=interface conversion: zapcore_test.obj is not fmt.Stringer: missing method String (in String method of zapcore_test.obj)"},
+		{t: StringerType, iface: &obj{1}, want: empty, err: "PANIC=panic with string (in String method of *zapcore_test.obj)"},
+		{t: StringerType, iface: &obj{2}, want: empty, err: "PANIC=panic with error (in String method of *zapcore_test.obj)"},
+		{t: StringerType, iface: &obj{3}, want: empty, err: "PANIC=<nil> (in String method of *zapcore_test.obj)"},
 		{t: ErrorType, iface: &errObj{kind: 1}, want: empty, err: "PANIC=panic in Error() method"},
 	}
 	for _, tt := range tests {
@@ -128,6 +128,28 @@ func TestFieldAddingError(t *testing.T) {
 	}
 }
 
+func TestFieldAddingTrustedStringer(t *testing.T) {
+	for _, trusted := range []bool{false, true} {
+		enc := NewJSONEncoder(EncoderConfig{TrustStringers: trusted})
+
+		Field{Key: "k", Type: StringerType, Interface: &obj{}}.AddTo(enc)
+		panics := func() {
+			Field{Key: "p", Type: StringerType, Interface: &obj{1}}.AddTo(enc)
+		}
+		if trusted {
+			assert.Panics(t, panics, "Expected panics to propagate from trusted Stringers.")
+			continue
+		}
+		assert.NotPanics(t, panics, "Unexpected panic from an untrusted Stringer.")
+
+		buf, err := enc.EncodeEntry(Entry{}, nil)
+		require.NoError(t, err, "Unexpected error encoding entry.")
+		assert.Equal(t,
+			`{"k":"obj","pError":"PANIC=panic with string (in String method of *zapcore_test.obj)"}`+"\n",
+			buf.String(), "Unexpected output.")
+	}
+}
+
 func TestFields(t *testing.T) {
 	tests := []struct {
 		t     FieldType
@@ -140,6 +162,7 @@ func TestFields(t *testing.T) {
 		{t: ObjectMarshalerType, iface: users(2), want: map[string]interface{}{"users": 2}},
 		{t: BoolType, i: 0, want: false},
 		{t: ByteStringType, iface: []byte("foo"), want: "foo"},
+		{t: RawJSONType, iface: []byte(`{"a":1}`), want: `{"a":1}`},
 		{t: Complex128Type, iface: 1 + 2i, want: 1 + 2i},
 		{t: Complex64Type, iface: complex64(1 + 2i), want: complex64(1 + 2i)},
 		{t: DurationType, i: 1000, want: time.Microsecond},
@@ -152,6 +175,8 @@ func TestFields(t *testing.T) {
 		{t: StringType, s: "foo", want: "foo"},
 		{t: TimeType, i: 1000, iface: time.UTC, want: time.Unix(0, 1000).In(time.UTC)},
 		{t: TimeType, i: 1000, want: time.Unix(0, 1000)},
+		{t: TimeLayoutType, i: 1500000000, s: time.Kitchen, iface: time.UTC, want: "12:00AM"},
+		{t: TimeLayoutType, i: 1500000000, s: "05.000", want: "01.500"},
 		{t: Uint64Type, i: 42, want: uint64(42)},
 		{t: Uint32Type, i: 42, want: uint32(42)},
 		{t: Uint16Type, i: 42, want: uint16(42)},
@@ -341,6 +366,26 @@ func TestEquals(t *testing.T) {
 			b:    zap.Object("k", nil),
 			want: false,
 		},
+		{
+			a:    zap.Inline(users(10)),
+			b:    zap.Inline(users(10)),
+			want: true,
+		},
+		{
+			a:    zap.Inline(users(10)),
+			b:    zap.Inline(users(11)),
+			want: false,
+		},
+		{
+			a:    zap.Lazy("k", func() any { return 1 }),
+			b:    zap.Lazy("k", func() any { return 1 }),
+			want: false,
+		},
+		{
+			a:    zap.Lazy("k", func() any { return 1 }),
+			b:    zap.Inline(users(10)),
+			want: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,60 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+// NewFieldTrackingCore wraps a core so that it reports the fields bound to
+// it with With through AccumulatedFields. Wrap the cores built by NewCore
+// with it when wrappers applied later, such as a sampler with a keyer or a
+// filter, must see the context bound beforehand.
+func NewFieldTrackingCore(core Core) Core {
+	return &fieldTrackingCore{Core: core}
+}
+
+type fieldTrackingCore struct {
+	Core
+
+	fields []Field // bound with With
+}
+
+var (
+	_ Core             = (*fieldTrackingCore)(nil)
+	_ leveledEnabler   = (*fieldTrackingCore)(nil)
+	_ fieldAccumulator = (*fieldTrackingCore)(nil)
+)
+
+func (c *fieldTrackingCore) Level() Level {
+	return LevelOf(c.Core)
+}
+
+func (c *fieldTrackingCore) AccumulatedFields() []Field {
+	return c.fields
+}
+
+func (c *fieldTrackingCore) With(fields []Field) Core {
+	return &fieldTrackingCore{
+		Core:   c.Core.With(fields),
+		fields: append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	}
+}
+
+func (c *fieldTrackingCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	return c.Core.Check(ent, ce)
+}
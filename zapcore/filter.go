@@ -0,0 +1,246 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"path"
+	"regexp"
+
+	"go.uber.org/multierr"
+)
+
+// A FilterPredicate selects the entries kept by NewFilterCore. Build
+// predicates with MessageMatches, LoggerNameMatches, EventIDIs, HasField and
+// FieldMatches, and combine them with AllOf, AnyOf, and Not. The zero
+// FilterPredicate matches every entry.
+type FilterPredicate struct {
+	// match reports whether the predicate matches an entry, given the fields
+	// bound to the logger and those passed at the log site. It's nil for the
+	// zero value.
+	match func(ent Entry, context, fields []Field) bool
+
+	// needsFields is set if match depends on fields. Predicates that don't
+	// are evaluated when the entry is checked; the rest, when it's written.
+	needsFields bool
+}
+
+func (p FilterPredicate) matches(ent Entry, context, fields []Field) bool {
+	return p.match == nil || p.match(ent, context, fields)
+}
+
+// MessageMatches matches entries whose message matches re.
+func MessageMatches(re *regexp.Regexp) FilterPredicate {
+	return FilterPredicate{match: func(ent Entry, _, _ []Field) bool {
+		return re.MatchString(ent.Message)
+	}}
+}
+
+// LoggerNameMatches matches entries from loggers whose names match pattern,
+// using the syntax of path.Match. For example, "http.*" matches
+// "http.server" and "http.client". Malformed patterns match nothing.
+func LoggerNameMatches(pattern string) FilterPredicate {
+	return FilterPredicate{match: func(ent Entry, _, _ []Field) bool {
+		ok, err := path.Match(pattern, ent.LoggerName)
+		return err == nil && ok
+	}}
+}
+
+// EventIDIs matches entries whose EventID is one of ids.
+func EventIDIs(ids ...string) FilterPredicate {
+	return FilterPredicate{match: func(ent Entry, _, _ []Field) bool {
+		for _, id := range ids {
+			if ent.EventID == id {
+				return true
+			}
+		}
+		return false
+	}}
+}
+
+// HasField matches entries with a field named key, either bound to the
+// logger with With or passed at the log site.
+func HasField(key string) FilterPredicate {
+	return FieldMatches(key, func(Field) bool { return true })
+}
+
+// FieldMatches matches entries with at least one field named key, either
+// bound to the logger with With or passed at the log site, for which match
+// returns true.
+//
+//	zapcore.FieldMatches("path", func(f zapcore.Field) bool {
+//	  return f.Type == zapcore.StringType && f.String == "/health"
+//	})
+func FieldMatches(key string, match func(Field) bool) FilterPredicate {
+	return FilterPredicate{
+		match: func(_ Entry, context, fields []Field) bool {
+			for _, fs := range [2][]Field{context, fields} {
+				for _, f := range fs {
+					if f.Key == key && match(f) {
+						return true
+					}
+				}
+			}
+			return false
+		},
+		needsFields: true,
+	}
+}
+
+// AllOf matches entries matched by every one of preds. With no predicates,
+// it matches every entry.
+func AllOf(preds ...FilterPredicate) FilterPredicate {
+	return FilterPredicate{
+		match: func(ent Entry, context, fields []Field) bool {
+			for _, p := range preds {
+				if !p.matches(ent, context, fields) {
+					return false
+				}
+			}
+			return true
+		},
+		needsFields: anyNeedsFields(preds),
+	}
+}
+
+// AnyOf matches entries matched by at least one of preds. With no
+// predicates, it matches nothing.
+func AnyOf(preds ...FilterPredicate) FilterPredicate {
+	return FilterPredicate{
+		match: func(ent Entry, context, fields []Field) bool {
+			for _, p := range preds {
+				if p.matches(ent, context, fields) {
+					return true
+				}
+			}
+			return false
+		},
+		needsFields: anyNeedsFields(preds),
+	}
+}
+
+// Not matches the entries that pred doesn't.
+func Not(pred FilterPredicate) FilterPredicate {
+	return FilterPredicate{
+		match: func(ent Entry, context, fields []Field) bool {
+			return !pred.matches(ent, context, fields)
+		},
+		needsFields: pred.needsFields,
+	}
+}
+
+func anyNeedsFields(preds []FilterPredicate) bool {
+	for _, p := range preds {
+		if p.needsFields {
+			return true
+		}
+	}
+	return false
+}
+
+type filterCore struct {
+	Core
+
+	pred    FilterPredicate
+	context []Field // bound with With, including those bound before wrapping
+}
+
+var (
+	_ Core             = (*filterCore)(nil)
+	_ leveledEnabler   = (*filterCore)(nil)
+	_ fieldAccumulator = (*filterCore)(nil)
+)
+
+// NewFilterCore wraps a Core so that only entries matched by pred are
+// logged. To drop entries instead, negate the predicate with Not. For
+// example, this drops successful health checks from access logs:
+//
+//	core = zapcore.NewFilterCore(core, zapcore.Not(zapcore.AllOf(
+//	  zapcore.LoggerNameMatches("http"),
+//	  zapcore.MessageMatches(regexp.MustCompile(`^handled request$`)),
+//	  zapcore.FieldMatches("http.path", func(f zapcore.Field) bool {
+//	    return f.String == "/health"
+//	  }),
+//	)))
+//
+// Predicates that only inspect the entry are evaluated when it's checked,
+// so filtered entries cost no more than disabled ones. Predicates on fields
+// can only be evaluated once the log site's fields are known, so entries
+// are filtered by them when written.
+func NewFilterCore(core Core, pred FilterPredicate) Core {
+	return &filterCore{
+		Core:    core,
+		pred:    pred,
+		context: AccumulatedFields(core),
+	}
+}
+
+func (c *filterCore) Level() Level {
+	return LevelOf(c.Core)
+}
+
+func (c *filterCore) AccumulatedFields() []Field {
+	return c.context
+}
+
+func (c *filterCore) With(fields []Field) Core {
+	return &filterCore{
+		Core: c.Core.With(fields),
+		pred: c.pred,
+		// Clip the capacity so siblings never share a backing array.
+		context: append(c.context[:len(c.context):len(c.context)], fields...),
+	}
+}
+
+func (c *filterCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if !c.pred.needsFields {
+		if c.pred.matches(ent, nil, nil) {
+			return c.Core.Check(ent, ce)
+		}
+		ent.Trace.record(c, TraceDropped, "filtered out")
+		return ce
+	}
+	if c.Enabled(ent.Level) {
+		// The decision depends on the log site's fields.
+		return ce.AddCore(ent, c)
+	}
+	ent.Trace.record(c, TraceRejected, "")
+	return ce
+}
+
+func (c *filterCore) Write(ent Entry, fields []Field) error {
+	if !c.pred.matches(ent, c.context, fields) {
+		ent.Trace.record(c, TraceDropped, "filtered out")
+		return nil
+	}
+	ce := c.Core.Check(ent, nil)
+	if ce == nil {
+		return nil
+	}
+
+	var err error
+	for i := range ce.cores {
+		werr := ce.cores[i].Write(ent, fields)
+		ent.Trace.recordWrite(ce.cores[i], werr)
+		err = multierr.Append(err, werr)
+	}
+	putCheckedEntry(ce)
+	return err
+}
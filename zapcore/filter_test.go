@@ -0,0 +1,173 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestFilterCore(t *testing.T) {
+	str := func(key, val string) Field { return Field{Key: key, Type: StringType, String: val} }
+	health := FieldMatches("path", func(f Field) bool { return f.String == "/health" })
+
+	tests := []struct {
+		desc   string
+		pred   FilterPredicate
+		bound  []Field
+		ent    Entry
+		fields []Field
+		want   bool
+	}{
+		{desc: "zero value", ent: Entry{Message: "hello"}, want: true},
+		{
+			desc: "message match",
+			pred: MessageMatches(regexp.MustCompile(`^hel+o$`)),
+			ent:  Entry{Message: "hello"},
+			want: true,
+		},
+		{
+			desc: "message mismatch",
+			pred: MessageMatches(regexp.MustCompile(`^bye$`)),
+			ent:  Entry{Message: "hello"},
+		},
+		{
+			desc: "logger name glob",
+			pred: LoggerNameMatches("http.*"),
+			ent:  Entry{LoggerName: "http.server"},
+			want: true,
+		},
+		{
+			desc: "malformed glob",
+			pred: LoggerNameMatches("http.["),
+			ent:  Entry{LoggerName: "http.["},
+		},
+		{
+			desc: "event ID match",
+			pred: EventIDIs("E1", "E2"),
+			ent:  Entry{EventID: "E2"},
+			want: true,
+		},
+		{
+			desc: "event ID mismatch",
+			pred: EventIDIs("E1"),
+			ent:  Entry{EventID: "E3"},
+		},
+		{
+			desc:   "field presence at log site",
+			pred:   HasField("user"),
+			fields: []Field{str("user", "alice")},
+			want:   true,
+		},
+		{
+			desc:  "bound field presence",
+			pred:  HasField("user"),
+			bound: []Field{str("user", "alice")},
+			want:  true,
+		},
+		{
+			desc:   "missing field",
+			pred:   HasField("user"),
+			fields: []Field{str("path", "/")},
+		},
+		{
+			desc:   "field value",
+			pred:   health,
+			fields: []Field{str("path", "/health")},
+			want:   true,
+		},
+		{
+			desc:   "drop health checks from access logs",
+			pred:   Not(AllOf(LoggerNameMatches("http"), health)),
+			ent:    Entry{LoggerName: "http"},
+			fields: []Field{str("path", "/health")},
+		},
+		{
+			desc:   "keep other access logs",
+			pred:   Not(AllOf(LoggerNameMatches("http"), health)),
+			ent:    Entry{LoggerName: "http"},
+			fields: []Field{str("path", "/users")},
+			want:   true,
+		},
+		{
+			desc: "any of",
+			pred: AnyOf(MessageMatches(regexp.MustCompile("x")), LoggerNameMatches("db")),
+			ent:  Entry{LoggerName: "db", Message: "query"},
+			want: true,
+		},
+		{desc: "empty any of", pred: AnyOf()},
+		{desc: "empty all of", pred: AllOf(), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			obs, logs := observer.New(DebugLevel)
+			core := NewFilterCore(obs, tt.pred)
+			if len(tt.bound) > 0 {
+				core = core.With(tt.bound)
+			}
+			if ce := core.Check(tt.ent, nil); ce != nil {
+				ce.Write(tt.fields...)
+			}
+			if tt.want {
+				assert.Equal(t, 1, logs.Len(), "Expected the entry to be logged.")
+			} else {
+				assert.Zero(t, logs.Len(), "Expected the entry to be filtered.")
+			}
+		})
+	}
+}
+
+func TestFilterCoreEntryPredicatesSkipWrite(t *testing.T) {
+	obs, logs := observer.New(InfoLevel)
+	core := NewFilterCore(obs, Not(LoggerNameMatches("noisy")))
+
+	assert.Nil(t, core.Check(Entry{LoggerName: "noisy", Level: ErrorLevel}, nil),
+		"Expected entry-only predicates to filter entries when checked.")
+	assert.Nil(t, core.Check(Entry{Level: DebugLevel}, nil), "Expected the wrapped core's level to apply.")
+	assert.Equal(t, InfoLevel, LevelOf(core), "Unexpected level.")
+
+	fieldCore := NewFilterCore(obs, HasField("k"))
+	assert.Nil(t, fieldCore.Check(Entry{Level: DebugLevel}, nil), "Expected the wrapped core's level to apply.")
+	assert.NoError(t, fieldCore.Write(Entry{Level: DebugLevel}, []Field{makeInt64Field("k", 1)}),
+		"Unexpected error writing directly.")
+	assert.Zero(t, logs.Len(), "Expected direct writes to respect the wrapped core's level.")
+	assert.NoError(t, fieldCore.Write(Entry{Level: InfoLevel}, []Field{makeInt64Field("k", 1)}))
+	assert.Equal(t, 1, logs.Len(), "Expected direct writes to be filtered and logged.")
+}
+
+func TestFilterCoreAccumulatedFields(t *testing.T) {
+	bound := makeInt64Field("k", 1)
+	obs, _ := observer.New(DebugLevel)
+	core := NewFilterCore(obs.With([]Field{bound}), HasField("k"))
+	assert.Equal(t, []Field{bound}, AccumulatedFields(core), "Expected fields bound before wrapping.")
+
+	other := makeInt64Field("j", 2)
+	child := core.With([]Field{other})
+	assert.Equal(t, []Field{bound, other}, AccumulatedFields(child), "Unexpected fields after With.")
+	assert.Equal(t, []Field{bound}, AccumulatedFields(core), "With must not modify the parent.")
+}
@@ -0,0 +1,427 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"os"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/internal/bufferpool"
+	"go.uber.org/zap/internal/pool"
+)
+
+const _gelfVersion = "1.1"
+
+var _gelfPool = pool.New(func() *gelfEncoder {
+	return &gelfEncoder{}
+})
+
+func putGELFEncoder(enc *gelfEncoder) {
+	if enc.scratch != nil {
+		putJSONEncoder(enc.scratch)
+	}
+	enc.EncoderConfig = nil
+	enc.host = ""
+	enc.buf = nil
+	enc.prefix = ""
+	enc.namespaces = nil
+	enc.scratch = nil
+	_gelfPool.Put(enc)
+}
+
+type gelfEncoder struct {
+	*EncoderConfig
+	host string
+	buf  *buffer.Buffer // additional fields, each preceded by a comma
+
+	// prefix holds the names of open namespaces and objects, each followed by
+	// a period.
+	prefix string
+	// namespaces holds the length of prefix before each open namespace
+	// within the current object, innermost last.
+	namespaces []int
+
+	// scratch encodes values that GELF can't represent natively.
+	scratch *jsonEncoder
+}
+
+// NewGELFEncoder creates an encoder that produces Graylog Extended Log
+// Format (GELF) 1.1 messages, suitable for the sink in the zapgelf package
+// or any other GELF input. If host is empty, the encoder uses the machine's
+// hostname.
+//
+// GELF fixes the names of its standard fields, so the encoder ignores the
+// level, time, and message settings of the EncoderConfig: the message is
+// always written as short_message, the time as a fractional Unix timestamp,
+// and the level as the matching syslog severity (DebugLevel is 7, InfoLevel
+// is 6, and so on down to FatalLevel, which is 0). Stack traces become the
+// full_message. The logger name, caller, and function are written as
+// additional fields when their keys are set.
+//
+// Every other field becomes an additional field: its key is prefixed with
+// an underscore and characters other than letters, digits, underscores,
+// periods, and dashes are replaced with underscores. Objects and namespaces
+// are flattened, joining keys with periods, so that
+//
+//	logger.Info("done", zap.Object("req", req))
+//
+// produces fields such as _req.method. GELF only allows strings and numbers
+// as values, so booleans, arrays, and other values are written as strings
+// holding their JSON encoding.
+func NewGELFEncoder(cfg EncoderConfig, host string) Encoder {
+	return newGELFEncoder(cfg, host)
+}
+
+func newGELFEncoder(cfg EncoderConfig, host string) *gelfEncoder {
+	if cfg.SkipLineEnding {
+		cfg.LineEnding = ""
+	} else if cfg.LineEnding == "" {
+		cfg.LineEnding = DefaultLineEnding
+	}
+	if cfg.NewReflectedEncoder == nil {
+		cfg.NewReflectedEncoder = defaultReflectedEncoder
+	}
+	cfg.EncodeKey = cacheKeyEncoder(cfg.EncodeKey)
+	if host == "" {
+		host, _ = os.Hostname()
+	}
+	return &gelfEncoder{
+		EncoderConfig: &cfg,
+		host:          host,
+		buf:           bufferpool.Get(),
+	}
+}
+
+func (enc *gelfEncoder) AddArray(key string, arr ArrayMarshaler) error {
+	err := enc.resetScratch().AppendArray(arr)
+	enc.addScratch(key)
+	return err
+}
+
+func (enc *gelfEncoder) AddObject(key string, obj ObjectMarshaler) error {
+	old, oldNamespaces := enc.prefix, enc.namespaces
+	enc.prefix += gelfFieldName(enc.encodeKey(key)) + "."
+	enc.namespaces = nil
+	err := obj.MarshalLogObject(enc)
+	enc.prefix, enc.namespaces = old, oldNamespaces
+	return err
+}
+
+func (enc *gelfEncoder) AddBinary(key string, val []byte) {
+	enc.AddString(key, enc.binaryEncoding().EncodeToString(val))
+}
+
+func (enc *gelfEncoder) AddByteString(key string, val []byte) {
+	enc.AddString(key, string(val))
+}
+
+func (enc *gelfEncoder) AddBool(key string, val bool) {
+	enc.addKey(key)
+	enc.buf.AppendByte('"')
+	enc.buf.AppendBool(val)
+	enc.buf.AppendByte('"')
+}
+
+func (enc *gelfEncoder) AddComplex128(key string, val complex128) {
+	enc.resetScratch().AppendComplex128(val)
+	enc.addScratch(key)
+}
+
+func (enc *gelfEncoder) AddComplex64(key string, val complex64) {
+	enc.resetScratch().AppendComplex64(val)
+	enc.addScratch(key)
+}
+
+func (enc *gelfEncoder) AddDuration(key string, val time.Duration) {
+	enc.resetScratch().AppendDuration(val)
+	enc.addScratch(key)
+}
+
+func (enc *gelfEncoder) AddFloat64(key string, val float64) {
+	enc.resetScratch().AppendFloat64(val)
+	enc.addScratch(key)
+}
+
+func (enc *gelfEncoder) AddFloat32(key string, val float32) {
+	enc.resetScratch().AppendFloat32(val)
+	enc.addScratch(key)
+}
+
+func (enc *gelfEncoder) AddInt64(key string, val int64) {
+	enc.addKey(key)
+	enc.buf.AppendInt(val)
+}
+
+func (enc *gelfEncoder) AddReflected(key string, obj interface{}) error {
+	err := enc.resetScratch().AppendReflected(obj)
+	enc.addScratch(key)
+	return err
+}
+
+// AddRawJSON adds pre-serialized JSON. Strings and numbers are embedded
+// verbatim; everything else is added as a string.
+func (enc *gelfEncoder) AddRawJSON(key string, val []byte) {
+	enc.resetScratch().AddRawJSON("", val)
+	// Strip the empty key added by the scratch encoder.
+	raw := enc.scratch.buf.Bytes()[len(`"":`):]
+	enc.addRaw(key, raw)
+}
+
+func (enc *gelfEncoder) OpenNamespace(key string) {
+	enc.namespaces = append(enc.namespaces, len(enc.prefix))
+	enc.prefix += gelfFieldName(enc.encodeKey(key)) + "."
+}
+
+func (enc *gelfEncoder) CloseNamespace() {
+	if n := len(enc.namespaces); n > 0 {
+		enc.prefix = enc.prefix[:enc.namespaces[n-1]]
+		enc.namespaces = enc.namespaces[:n-1]
+	}
+}
+
+func (enc *gelfEncoder) AddString(key, val string) {
+	enc.addKey(key)
+	enc.buf.AppendByte('"')
+	enc.invalidUTF8().appendJSONString(enc.buf, enc.limitString(val))
+	enc.buf.AppendByte('"')
+}
+
+func (enc *gelfEncoder) AddTime(key string, val time.Time) {
+	enc.resetScratch().AppendTime(val)
+	enc.addScratch(key)
+}
+
+func (enc *gelfEncoder) AddUint64(key string, val uint64) {
+	enc.addKey(key)
+	enc.buf.AppendUint(val)
+}
+
+func (enc *gelfEncoder) AddInt(k string, v int)         { enc.AddInt64(k, int64(v)) }
+func (enc *gelfEncoder) AddInt32(k string, v int32)     { enc.AddInt64(k, int64(v)) }
+func (enc *gelfEncoder) AddInt16(k string, v int16)     { enc.AddInt64(k, int64(v)) }
+func (enc *gelfEncoder) AddInt8(k string, v int8)       { enc.AddInt64(k, int64(v)) }
+func (enc *gelfEncoder) AddUint(k string, v uint)       { enc.AddUint64(k, uint64(v)) }
+func (enc *gelfEncoder) AddUint32(k string, v uint32)   { enc.AddUint64(k, uint64(v)) }
+func (enc *gelfEncoder) AddUint16(k string, v uint16)   { enc.AddUint64(k, uint64(v)) }
+func (enc *gelfEncoder) AddUint8(k string, v uint8)     { enc.AddUint64(k, uint64(v)) }
+func (enc *gelfEncoder) AddUintptr(k string, v uintptr) { enc.AddUint64(k, uint64(v)) }
+
+func (enc *gelfEncoder) Clone() Encoder {
+	clone := enc.clone()
+	clone.buf.Write(enc.buf.Bytes())
+	return clone
+}
+
+func (enc *gelfEncoder) clone() *gelfEncoder {
+	clone := _gelfPool.Get()
+	clone.EncoderConfig = enc.EncoderConfig
+	clone.host = enc.host
+	clone.prefix = enc.prefix
+	// Cap the slice so that appending to the clone's namespaces never
+	// overwrites the original's.
+	clone.namespaces = enc.namespaces[:len(enc.namespaces):len(enc.namespaces)]
+	clone.buf = bufferpool.Get()
+	return clone
+}
+
+func (enc *gelfEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer, error) {
+	final := enc.clone()
+	addFields(final, fields)
+
+	line := bufferpool.Get()
+	line.AppendString(`{"version":"` + _gelfVersion + `","host":"`)
+	enc.invalidUTF8().appendJSONString(line, enc.host)
+	line.AppendString(`","short_message":"`)
+	enc.invalidUTF8().appendJSONString(line, ent.Message)
+	line.AppendByte('"')
+	if ent.Stack != "" && enc.StacktraceKey != "" {
+		line.AppendString(`,"full_message":"`)
+		enc.invalidUTF8().appendJSONString(line, ent.Stack)
+		line.AppendByte('"')
+	}
+	if !ent.Time.IsZero() {
+		line.AppendString(`,"timestamp":`)
+		appendGELFTimestamp(line, ent.Time)
+	}
+	line.AppendString(`,"level":`)
+	line.AppendInt(int64(gelfLevel(ent.Level)))
+
+	// The logger name, caller, function, and event ID are written as additional fields,
+	// ahead of the context, without any namespace prefix.
+	meta := enc.clone()
+	meta.prefix = ""
+	if ent.LoggerName != "" && meta.NameKey != "" {
+		nameEncoder := meta.EncodeName
+		if nameEncoder == nil {
+			nameEncoder = FullNameEncoder
+		}
+		nameEncoder(ent.LoggerName, meta.resetScratch())
+		meta.addScratchOr(meta.NameKey, ent.LoggerName)
+	}
+	if ent.Caller.Defined {
+		if meta.CallerKey != "" && meta.EncodeCaller != nil {
+			meta.EncodeCaller(ent.Caller, meta.resetScratch())
+			meta.addScratchOr(meta.CallerKey, ent.Caller.String())
+		}
+		if meta.FunctionKey != "" {
+			meta.AddString(meta.FunctionKey, ent.Caller.Function)
+		}
+	}
+	if ent.EventID != "" && meta.EventIDKey != "" {
+		meta.AddString(meta.EventIDKey, ent.EventID)
+	}
+	line.Write(meta.buf.Bytes())
+	line.Write(enc.buf.Bytes())
+	line.Write(final.buf.Bytes())
+	line.AppendByte('}')
+	line.AppendString(enc.LineEnding)
+
+	final.buf.Free()
+	putGELFEncoder(final)
+	meta.buf.Free()
+	putGELFEncoder(meta)
+	return line, nil
+}
+
+// gelfLevel maps a level onto a syslog severity, where lower numbers are
+// more severe.
+func gelfLevel(lvl Level) int {
+	switch {
+	case lvl <= DebugLevel:
+		return 7 // LOG_DEBUG
+	case lvl == InfoLevel:
+		return 6 // LOG_INFO
+	case lvl == WarnLevel:
+		return 4 // LOG_WARNING
+	case lvl == ErrorLevel:
+		return 3 // LOG_ERR
+	case lvl == DPanicLevel:
+		return 2 // LOG_CRIT
+	case lvl == PanicLevel:
+		return 1 // LOG_ALERT
+	default:
+		return 0 // LOG_EMERG
+	}
+}
+
+// appendGELFTimestamp appends t as seconds since the Unix epoch with
+// millisecond precision.
+func appendGELFTimestamp(buf *buffer.Buffer, t time.Time) {
+	ms := t.UnixMilli()
+	sec, frac := ms/1000, ms%1000
+	if frac < 0 {
+		sec, frac = sec-1, frac+1000
+	}
+	buf.AppendInt(sec)
+	buf.AppendByte('.')
+	if frac < 100 {
+		buf.AppendByte('0')
+	}
+	if frac < 10 {
+		buf.AppendByte('0')
+	}
+	buf.AppendInt(frac)
+}
+
+// gelfFieldName replaces the characters that GELF doesn't allow in field
+// names with underscores.
+func gelfFieldName(key string) string {
+	for i := 0; i < len(key); i++ {
+		if !gelfFieldNameChar(key[i]) {
+			return sanitizeGELFFieldName(key)
+		}
+	}
+	return key
+}
+
+func sanitizeGELFFieldName(key string) string {
+	bs := []byte(key)
+	for i, c := range bs {
+		if !gelfFieldNameChar(c) {
+			bs[i] = '_'
+		}
+	}
+	return string(bs)
+}
+
+func gelfFieldNameChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '_' || c == '.' || c == '-'
+}
+
+func (enc *gelfEncoder) addKey(key string) {
+	name := enc.prefix + gelfFieldName(enc.encodeKey(key))
+	if name == "id" {
+		// GELF reserves _id, so rename rather than lose the field.
+		name = "_id"
+	}
+	enc.buf.AppendString(`,"_`)
+	enc.buf.AppendString(name)
+	enc.buf.AppendString(`":`)
+}
+
+// resetScratch returns an empty JSON encoder with the same configuration,
+// used to encode values that GELF doesn't support natively.
+func (enc *gelfEncoder) resetScratch() *jsonEncoder {
+	if enc.scratch == nil {
+		enc.scratch = _jsonPool.Get()
+		enc.scratch.EncoderConfig = enc.EncoderConfig
+		enc.scratch.buf = bufferpool.Get()
+	}
+	enc.scratch.buf.Reset()
+	enc.scratch.openNamespaces = 0
+	return enc.scratch
+}
+
+// addScratch adds the value encoded by the scratch encoder.
+func (enc *gelfEncoder) addScratch(key string) {
+	enc.scratch.closeOpenNamespaces()
+	enc.addRaw(key, enc.scratch.buf.Bytes())
+}
+
+// addScratchOr adds the value encoded by the scratch encoder, or fallback if
+// the scratch encoder is empty.
+func (enc *gelfEncoder) addScratchOr(key, fallback string) {
+	if enc.scratch.buf.Len() == 0 {
+		enc.AddString(key, fallback)
+		return
+	}
+	enc.addScratch(key)
+}
+
+// addRaw adds a JSON value, keeping strings and numbers as they are and
+// converting everything else to a string.
+func (enc *gelfEncoder) addRaw(key string, raw []byte) {
+	enc.addKey(key)
+	if len(raw) > 0 {
+		switch c := raw[0]; {
+		case c == '"', c == '-', c >= '0' && c <= '9':
+			enc.buf.Write(raw)
+			return
+		}
+	}
+	enc.buf.AppendByte('"')
+	enc.invalidUTF8().appendJSONBytes(enc.buf, raw)
+	enc.buf.AppendByte('"')
+}
+
+var _ RawJSONEncoder = (*gelfEncoder)(nil)
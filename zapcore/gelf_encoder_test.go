@@ -0,0 +1,147 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+)
+
+func decodeGELF(t *testing.T, enc Encoder, ent Entry, fields []Field) map[string]interface{} {
+	buf, err := enc.EncodeEntry(ent, fields)
+	require.NoError(t, err, "Unexpected encoding error.")
+	defer buf.Free()
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got), "Invalid JSON: %s", buf.String())
+	return got
+}
+
+func TestGELFEncoder(t *testing.T) {
+	enc := NewGELFEncoder(testEncoderConfig(), "myhost")
+	enc.AddString("service", "api")
+	enc.OpenNamespace("ctx")
+	enc.AddInt("attempt", 2)
+
+	ent := Entry{
+		Level:      WarnLevel,
+		Time:       time.Unix(1500000000, 123456789),
+		LoggerName: "main",
+		Message:    "careful",
+		Caller:     EntryCaller{Defined: true, File: "foo.go", Line: 42, Function: "foo.Bar"},
+		Stack:      "goroutine 1",
+	}
+	req := ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+		enc.AddString("method", "GET")
+		enc.AddBool("tls", true)
+		return nil
+	})
+	got := decodeGELF(t, enc, ent, []Field{
+		{Key: "id", Type: StringType, String: "abc"},
+		{Key: "user name", Type: StringType, String: "jane"},
+		{Key: "latency", Type: DurationType, Integer: int64(1500 * time.Millisecond)},
+		{Key: "ratio", Type: Float64Type, Integer: 4608308318706860032}, // 1.25
+		{Key: "req", Type: ObjectMarshalerType, Interface: req},
+		{Key: "tags", Type: ArrayMarshalerType, Interface: ArrayMarshalerFunc(func(arr ArrayEncoder) error {
+			arr.AppendString("a")
+			arr.AppendInt(1)
+			return nil
+		})},
+		{Key: "err", Type: ErrorType, Interface: errors.New("boom")},
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"version":         "1.1",
+		"host":            "myhost",
+		"short_message":   "careful",
+		"full_message":    "goroutine 1",
+		"timestamp":       1500000000.123,
+		"level":           4.0,
+		"_name":           "main",
+		"_func":           "foo.Bar",
+		"_caller":         "foo.go:42",
+		"_service":        "api",
+		"_ctx.id":         "abc",
+		"_ctx.user_name":  "jane",
+		"_ctx.latency":    1.5,
+		"_ctx.ratio":      1.25,
+		"_ctx.req.method": "GET",
+		"_ctx.req.tls":    "true",
+		"_ctx.tags":       `["a",1]`,
+		"_ctx.attempt":    2.0,
+		"_ctx.err":        "boom",
+	}, got, "Unexpected GELF message.")
+}
+
+func TestGELFEncoderID(t *testing.T) {
+	got := decodeGELF(t, NewGELFEncoder(testEncoderConfig(), "h"), Entry{}, []Field{
+		{Key: "id", Type: StringType, String: "abc"},
+	})
+	assert.Equal(t, "abc", got["__id"], "Expected the id field to be renamed.")
+	assert.NotContains(t, got, "_id", "GELF reserves the _id field.")
+}
+
+func TestGELFEncoderLevels(t *testing.T) {
+	tests := []struct {
+		lvl  Level
+		want float64
+	}{
+		{DebugLevel - 1, 7},
+		{DebugLevel, 7},
+		{InfoLevel, 6},
+		{WarnLevel, 4},
+		{ErrorLevel, 3},
+		{DPanicLevel, 2},
+		{PanicLevel, 1},
+		{FatalLevel, 0},
+	}
+
+	enc := NewGELFEncoder(testEncoderConfig(), "h")
+	for _, tt := range tests {
+		got := decodeGELF(t, enc, Entry{Level: tt.lvl}, nil)
+		assert.Equal(t, tt.want, got["level"], "Unexpected severity for %v.", tt.lvl)
+	}
+}
+
+func TestGELFEncoderClone(t *testing.T) {
+	enc := NewGELFEncoder(testEncoderConfig(), "h")
+	enc.AddString("a", "1")
+	clone := enc.Clone()
+	clone.OpenNamespace("ns")
+	clone.AddString("b", "2")
+
+	got := decodeGELF(t, enc, Entry{}, []Field{{Key: "c", Type: StringType, String: "3"}})
+	assert.Equal(t, "1", got["_a"])
+	assert.Equal(t, "3", got["_c"])
+	assert.NotContains(t, got, "_ns.b", "Clone modified the original encoder.")
+
+	got = decodeGELF(t, clone, Entry{}, []Field{{Key: "c", Type: StringType, String: "3"}})
+	assert.Equal(t, "2", got["_ns.b"])
+	assert.Equal(t, "3", got["_ns.c"])
+}
@@ -66,6 +66,10 @@ func (h *hooked) With(fields []Field) Core {
 	}
 }
 
+func (h *hooked) AccumulatedFields() []Field {
+	return AccumulatedFields(h.Core)
+}
+
 func (h *hooked) Write(ent Entry, _ []Field) error {
 	// Since our downstream had a chance to register itself directly with the
 	// CheckedMessage, we don't need to call it here.
@@ -58,8 +58,13 @@ func (c *levelFilterCore) With(fields []Field) Core {
 	return &levelFilterCore{c.core.With(fields), c.level}
 }
 
+func (c *levelFilterCore) AccumulatedFields() []Field {
+	return AccumulatedFields(c.core)
+}
+
 func (c *levelFilterCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
 	if !c.Enabled(ent.Level) {
+		ent.Trace.record(c, TraceRejected, "")
 		return ce
 	}
 
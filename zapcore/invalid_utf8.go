@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"go.uber.org/zap/buffer"
+)
+
+// InvalidUTF8Policy controls how encoders write strings that aren't valid
+// UTF-8, such as messages and field values built from untrusted bytes.
+type InvalidUTF8Policy uint8
+
+const (
+	// ReplaceInvalidUTF8 replaces invalid UTF-8 with the Unicode replacement
+	// character, U+FFFD. This is the default.
+	ReplaceInvalidUTF8 InvalidUTF8Policy = iota
+	// EscapeInvalidUTF8 writes each byte of invalid UTF-8 as the text \xHH,
+	// so that the original bytes can be recovered from the output.
+	EscapeInvalidUTF8
+)
+
+// String returns the policy's name.
+func (p InvalidUTF8Policy) String() string {
+	switch p {
+	case ReplaceInvalidUTF8:
+		return "replace"
+	case EscapeInvalidUTF8:
+		return "escape"
+	default:
+		return fmt.Sprintf("InvalidUTF8Policy(%d)", uint8(p))
+	}
+}
+
+// UnmarshalText unmarshals text to an InvalidUTF8Policy. "replace" and the
+// empty string unmarshal to ReplaceInvalidUTF8, and "escape" unmarshals to
+// EscapeInvalidUTF8.
+func (p *InvalidUTF8Policy) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "replace", "":
+		*p = ReplaceInvalidUTF8
+	case "escape":
+		*p = EscapeInvalidUTF8
+	default:
+		return fmt.Errorf("unrecognized invalid UTF-8 policy: %q", text)
+	}
+	return nil
+}
+
+// invalidUTF8 returns the configured InvalidUTF8Policy. Like limitString,
+// it's safe to call on the nil config of an encoder used only for nested
+// values.
+func (cfg *EncoderConfig) invalidUTF8() InvalidUTF8Policy {
+	if cfg == nil {
+		return ReplaceInvalidUTF8
+	}
+	return cfg.InvalidUTF8
+}
+
+// appendJSONString appends s to buf, JSON-escaped but without quotes.
+func (p InvalidUTF8Policy) appendJSONString(buf *buffer.Buffer, s string) {
+	if p == EscapeInvalidUTF8 {
+		buf.AppendJSONStringEscapingInvalid(s)
+		return
+	}
+	buf.AppendJSONString(s)
+}
+
+// appendJSONBytes is the []byte equivalent of appendJSONString.
+func (p InvalidUTF8Policy) appendJSONBytes(buf *buffer.Buffer, s []byte) {
+	if p == EscapeInvalidUTF8 {
+		buf.AppendJSONBytesEscapingInvalid(s)
+		return
+	}
+	buf.AppendJSONBytes(s)
+}
+
+// validText returns s, with any invalid UTF-8 replaced or escaped. Valid
+// strings, by far the most common, are returned as they are after a scan
+// that checks ASCII text a word at a time.
+func (p InvalidUTF8Policy) validText(s string) string {
+	if utf8.ValidString(s) {
+		return s
+	}
+	if p != EscapeInvalidUTF8 {
+		return strings.ToValidUTF8(s, string(utf8.RuneError))
+	}
+	var b strings.Builder
+	b.Grow(len(s) + 8)
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			fmt.Fprintf(&b, `\x%02x`, s[i])
+		} else {
+			b.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	return b.String()
+}
+
+// validBytes is the []byte equivalent of validText.
+func (p InvalidUTF8Policy) validBytes(s []byte) []byte {
+	if utf8.Valid(s) {
+		return s
+	}
+	if p != EscapeInvalidUTF8 {
+		return bytes.ToValidUTF8(s, []byte(string(utf8.RuneError)))
+	}
+	return []byte(p.validText(string(s)))
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+)
+
+func TestInvalidUTF8PolicyUnmarshalText(t *testing.T) {
+	tests := []struct {
+		text string
+		want InvalidUTF8Policy
+	}{
+		{"", ReplaceInvalidUTF8},
+		{"replace", ReplaceInvalidUTF8},
+		{"escape", EscapeInvalidUTF8},
+	}
+	for _, tt := range tests {
+		var p InvalidUTF8Policy
+		require.NoError(t, p.UnmarshalText([]byte(tt.text)), "Unexpected error unmarshaling %q.", tt.text)
+		assert.Equal(t, tt.want, p, "Unexpected policy for %q.", tt.text)
+		if tt.text != "" {
+			assert.Equal(t, tt.text, p.String(), "Unexpected string for %v.", p)
+		}
+	}
+
+	var p InvalidUTF8Policy
+	assert.Error(t, p.UnmarshalText([]byte("drop")), "Expected an error for an unknown policy.")
+	assert.Equal(t, "InvalidUTF8Policy(9)", InvalidUTF8Policy(9).String(), "Unexpected string for an unknown policy.")
+}
+
+func TestInvalidUTF8(t *testing.T) {
+	ent := Entry{Message: "caf\xe9"}
+	fields := []Field{
+		{Key: "s", Type: StringType, String: "a\xffb"},
+		{Key: "b", Type: ByteStringType, Interface: []byte("\xfe")},
+	}
+
+	tests := []struct {
+		desc   string
+		newEnc func(EncoderConfig) Encoder
+		policy InvalidUTF8Policy
+		want   string
+	}{
+		{
+			desc:   "JSON replace",
+			newEnc: NewJSONEncoder,
+			want:   `{"msg":"caf\ufffd","s":"a\ufffdb","b":"\ufffd"}` + "\n",
+		},
+		{
+			desc:   "JSON escape",
+			newEnc: NewJSONEncoder,
+			policy: EscapeInvalidUTF8,
+			want:   `{"msg":"caf\\xe9","s":"a\\xffb","b":"\\xfe"}` + "\n",
+		},
+		{
+			desc:   "console replace",
+			newEnc: NewConsoleEncoder,
+			want:   "caf�\t" + `{"s": "a\ufffdb", "b": "\ufffd"}` + "\n",
+		},
+		{
+			desc:   "console escape",
+			newEnc: NewConsoleEncoder,
+			policy: EscapeInvalidUTF8,
+			want:   `caf\xe9` + "\t" + `{"s": "a\\xffb", "b": "\\xfe"}` + "\n",
+		},
+		{
+			desc:   "CSV replace",
+			newEnc: NewCSVEncoder,
+			want:   "caf�,a�b," + `"{""b"":""\ufffd""}"` + "\n",
+		},
+		{
+			desc:   "CSV escape",
+			newEnc: NewCSVEncoder,
+			policy: EscapeInvalidUTF8,
+			want:   `caf\xe9,a\xffb,"{""b"":""\\xfe""}"` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			enc := tt.newEnc(EncoderConfig{
+				MessageKey:  "msg",
+				CSVColumns:  []string{"msg", "s"},
+				InvalidUTF8: tt.policy,
+			})
+			buf, err := enc.EncodeEntry(ent, fields)
+			require.NoError(t, err, "Unexpected error encoding entry.")
+			assert.Equal(t, tt.want, buf.String(), "Unexpected output.")
+			buf.Free()
+		})
+	}
+}
+
+func TestInvalidUTF8CBOR(t *testing.T) {
+	for _, policy := range []InvalidUTF8Policy{ReplaceInvalidUTF8, EscapeInvalidUTF8} {
+		enc := NewCBOREncoder(EncoderConfig{MessageKey: "msg", InvalidUTF8: policy})
+		buf, err := enc.EncodeEntry(Entry{Message: "caf\xe9"}, nil)
+		require.NoError(t, err, "Unexpected error encoding entry.")
+
+		want := "caf�"
+		if policy == EscapeInvalidUTF8 {
+			want = `caf\xe9`
+		}
+		assert.True(t, bytes.Contains(buf.Bytes(), []byte(want)), "Expected %q in output with policy %v.", want, policy)
+		assert.False(t, bytes.Contains(buf.Bytes(), []byte("caf\xe9")), "Unexpected invalid UTF-8 with policy %v.", policy)
+		buf.Free()
+	}
+}
@@ -21,19 +21,17 @@
 package zapcore
 
 import (
-	"encoding/base64"
+	"bytes"
+	"encoding/json"
 	"math"
 	"time"
-	"unicode/utf8"
 
+	"go.uber.org/multierr"
 	"go.uber.org/zap/buffer"
 	"go.uber.org/zap/internal/bufferpool"
 	"go.uber.org/zap/internal/pool"
 )
 
-// For JSON-escaping; see jsonEncoder.safeAddString below.
-const _hex = "0123456789abcdef"
-
 var _jsonPool = pool.New(func() *jsonEncoder {
 	return &jsonEncoder{}
 })
@@ -45,9 +43,16 @@ func putJSONEncoder(enc *jsonEncoder) {
 	enc.EncoderConfig = nil
 	enc.buf = nil
 	enc.spaced = false
+	enc.quoteRawJSON = false
 	enc.openNamespaces = 0
 	enc.reflectBuf = nil
 	enc.reflectEnc = nil
+	enc.sharedReflect = false
+	enc.arena = nil
+	enc.arenaField = 0
+	enc.interner = nil
+	enc.order = nil
+	enc.fieldErr = nil
 	_jsonPool.Put(enc)
 }
 
@@ -55,11 +60,32 @@ type jsonEncoder struct {
 	*EncoderConfig
 	buf            *buffer.Buffer
 	spaced         bool // include spaces after colons and commas
+	quoteRawJSON   bool // add RawJSON values as strings, for console output
 	openNamespaces int
 
 	// for encoding generic values by reflection
 	reflectBuf *buffer.Buffer
 	reflectEnc ReflectedEncoder
+	// sharedReflect is set if reflectEnc is the default reflected encoder,
+	// so that other encoders produce the same encodings.
+	sharedReflect bool
+	// arena is set while EncodeEntry writes an entry that fans out to
+	// several cores; see CheckedEntry.UseArena.
+	arena *entryArena
+	// arenaField is one more than the index of the top-level reflected field
+	// being encoded through arena, or zero. Reflected values nested in
+	// arrays and objects aren't shared.
+	arenaField int
+
+	// shared with clones; nil unless InternStringValues is set
+	interner *stringInterner
+
+	// order in which EncodeEntry writes the parts of each entry
+	order []EntryPart
+
+	// fields replaced by placeholders while encoding an entry; see
+	// RecoverFieldErrors
+	fieldErr error
 }
 
 // NewJSONEncoder creates a fast, low-allocation JSON encoder. The encoder
@@ -85,16 +111,25 @@ func newJSONEncoder(cfg EncoderConfig, spaced bool) *jsonEncoder {
 		cfg.LineEnding = DefaultLineEnding
 	}
 
+	cfg.EncodeKey = cacheKeyEncoder(cfg.EncodeKey)
+
 	// If no EncoderConfig.NewReflectedEncoder is provided by the user, then use default
-	if cfg.NewReflectedEncoder == nil {
+	sharedReflect := cfg.NewReflectedEncoder == nil
+	if sharedReflect {
 		cfg.NewReflectedEncoder = defaultReflectedEncoder
 	}
 
-	return &jsonEncoder{
+	enc := &jsonEncoder{
 		EncoderConfig: &cfg,
 		buf:           bufferpool.Get(),
 		spaced:        spaced,
+		order:         resolveEncodeOrder(cfg.EncodeOrder, _jsonDefaultOrder),
+		sharedReflect: sharedReflect,
+	}
+	if cfg.InternStringValues > 0 {
+		enc.interner = newStringInterner(cfg.InternStringValues, cfg.InvalidUTF8)
 	}
+	return enc
 }
 
 func (enc *jsonEncoder) AddArray(key string, arr ArrayMarshaler) error {
@@ -108,11 +143,15 @@ func (enc *jsonEncoder) AddObject(key string, obj ObjectMarshaler) error {
 }
 
 func (enc *jsonEncoder) AddBinary(key string, val []byte) {
-	enc.AddString(key, base64.StdEncoding.EncodeToString(val))
+	enc.AddString(key, enc.binaryEncoding().EncodeToString(val))
 }
 
 func (enc *jsonEncoder) AddByteString(key string, val []byte) {
 	enc.addKey(key)
+	if enc.limitsBytes(val) {
+		enc.AppendString(enc.limitString(string(val)))
+		return
+	}
 	enc.AppendByteString(val)
 }
 
@@ -168,6 +207,17 @@ func (enc *jsonEncoder) encodeReflected(obj interface{}) ([]byte, error) {
 	if obj == nil {
 		return nullLiteralBytes, nil
 	}
+	if enc.arenaField > 0 {
+		i := enc.arenaField - 1
+		enc.arenaField = 0
+		return enc.arena.reflected(i, obj, enc)
+	}
+	return enc.reflect(obj)
+}
+
+// reflect encodes obj with the encoder's ReflectedEncoder. The result is
+// only valid until the next call.
+func (enc *jsonEncoder) reflect(obj interface{}) ([]byte, error) {
 	enc.resetReflectBuf()
 	if err := enc.reflectEnc.Encode(obj); err != nil {
 		return nil, err
@@ -186,15 +236,62 @@ func (enc *jsonEncoder) AddReflected(key string, obj interface{}) error {
 	return err
 }
 
+// AddRawJSON adds pre-serialized JSON without re-encoding it. Values that
+// aren't valid JSON are added as strings instead, so that they can't corrupt
+// the rest of the entry. Whitespace is removed from values that span
+// multiple lines to keep each entry on one line. The console encoder adds
+// all values as strings.
+func (enc *jsonEncoder) AddRawJSON(key string, val []byte) {
+	if enc.quoteRawJSON {
+		enc.AddByteString(key, val)
+		return
+	}
+	enc.addJSON(key, val)
+}
+
+// addJSON embeds val, adding it as a string if it isn't valid JSON.
+func (enc *jsonEncoder) addJSON(key string, val []byte) {
+	if !json.Valid(val) {
+		enc.AddByteString(key, val)
+		return
+	}
+	enc.addKey(key)
+	if bytes.ContainsAny(val, "\r\n") {
+		var compacted bytes.Buffer
+		// val is valid, so Compact can't fail.
+		_ = json.Compact(&compacted, val)
+		val = compacted.Bytes()
+	}
+	enc.buf.AppendBytes(val)
+}
+
+// EncodingName implements PreEncodedEncoder.
+func (enc *jsonEncoder) EncodingName() string {
+	return "json"
+}
+
+// AddPreEncoded adds a pre-encoded JSON value. Like AddRawJSON, it adds
+// invalid JSON as a string, but it embeds valid JSON in console output too.
+func (enc *jsonEncoder) AddPreEncoded(key string, val []byte) {
+	enc.addJSON(key, val)
+}
+
 func (enc *jsonEncoder) OpenNamespace(key string) {
 	enc.addKey(key)
 	enc.buf.AppendByte('{')
 	enc.openNamespaces++
 }
 
+func (enc *jsonEncoder) CloseNamespace() {
+	if enc.openNamespaces > 0 {
+		enc.buf.AppendByte('}')
+		enc.openNamespaces--
+	}
+}
+
 func (enc *jsonEncoder) AddString(key, val string) {
 	enc.addKey(key)
-	enc.AppendString(val)
+	enc.AppendString(enc.limitString(val))
 }
 
 func (enc *jsonEncoder) AddTime(key string, val time.Time) {
@@ -210,7 +307,7 @@ func (enc *jsonEncoder) AddUint64(key string, val uint64) {
 func (enc *jsonEncoder) AppendArray(arr ArrayMarshaler) error {
 	enc.addElementSeparator()
 	enc.buf.AppendByte('[')
-	err := arr.MarshalLogArray(enc)
+	err := enc.limitArray(arr).MarshalLogArray(enc)
 	enc.buf.AppendByte(']')
 	return err
 }
@@ -222,7 +319,12 @@ func (enc *jsonEncoder) AppendObject(obj ObjectMarshaler) error {
 	enc.openNamespaces = 0
 	enc.addElementSeparator()
 	enc.buf.AppendByte('{')
-	err := obj.MarshalLogObject(enc)
+	var err error
+	if so, ok := obj.(*staticObject); ok {
+		so.appendJSON(enc)
+	} else {
+		err = obj.MarshalLogObject(enc)
+	}
 	enc.buf.AppendByte('}')
 	enc.closeOpenNamespaces()
 	enc.openNamespaces = old
@@ -293,7 +395,11 @@ func (enc *jsonEncoder) AppendReflected(val interface{}) error {
 func (enc *jsonEncoder) AppendString(val string) {
 	enc.addElementSeparator()
 	enc.buf.AppendByte('"')
-	enc.safeAddString(val)
+	if enc.interner != nil {
+		enc.interner.appendString(enc.buf, val)
+	} else {
+		enc.safeAddString(val)
+	}
 	enc.buf.AppendByte('"')
 }
 
@@ -304,6 +410,16 @@ func (enc *jsonEncoder) AppendTimeLayout(time time.Time, layout string) {
 	enc.buf.AppendByte('"')
 }
 
+func (enc *jsonEncoder) AppendTimeUnix(t time.Time, unit time.Duration) {
+	enc.addElementSeparator()
+	enc.buf.AppendTimeUnix(t, unit)
+}
+
+func (enc *jsonEncoder) AppendDurationUnit(d, unit time.Duration) {
+	enc.addElementSeparator()
+	enc.buf.AppendDuration(d, unit)
+}
+
 func (enc *jsonEncoder) AppendTime(val time.Time) {
 	cur := enc.buf.Len()
 	if e := enc.EncodeTime; e != nil {
@@ -355,87 +471,163 @@ func (enc *jsonEncoder) clone() *jsonEncoder {
 	clone := _jsonPool.Get()
 	clone.EncoderConfig = enc.EncoderConfig
 	clone.spaced = enc.spaced
+	clone.quoteRawJSON = enc.quoteRawJSON
 	clone.openNamespaces = enc.openNamespaces
+	clone.interner = enc.interner
+	clone.order = enc.order
+	clone.sharedReflect = enc.sharedReflect
 	clone.buf = bufferpool.Get()
 	return clone
 }
 
 func (enc *jsonEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer, error) {
+	buf, _ := enc.encodeEntry(ent, fields, nil)
+	return buf, nil
+}
+
+func (enc *jsonEncoder) retainsFields() bool {
+	return false
+}
+
+func (enc *jsonEncoder) encodeEntryFieldErrors(ent Entry, fields []Field) (*buffer.Buffer, error, error) {
+	buf, fieldErr := enc.encodeEntry(ent, fields, nil)
+	return buf, fieldErr, nil
+}
+
+// encodeEntry encodes an entry, sharing the encodings of reflected values
+// through arena if it's not nil. It returns the fields replaced by
+// placeholders under RecoverFieldErrors along with the encoded entry.
+func (enc *jsonEncoder) encodeEntry(ent Entry, fields []Field, arena *entryArena) (*buffer.Buffer, error) {
 	final := enc.clone()
+	final.arena = arena
 	final.buf.AppendByte('{')
-
-	if final.LevelKey != "" && final.EncodeLevel != nil {
-		final.addKey(final.LevelKey)
-		cur := final.buf.Len()
-		final.EncodeLevel(ent.Level, final)
-		if cur == final.buf.Len() {
-			// User-supplied EncodeLevel was a no-op. Fall back to strings to keep
-			// output JSON valid.
-			final.AppendString(ent.Level.String())
-		}
+	for _, part := range final.order {
+		final.encodePart(part, ent, enc.buf, fields)
 	}
-	if final.TimeKey != "" && !ent.Time.IsZero() {
-		final.AddTime(final.TimeKey, ent.Time)
-	}
-	if ent.LoggerName != "" && final.NameKey != "" {
-		final.addKey(final.NameKey)
-		cur := final.buf.Len()
-		nameEncoder := final.EncodeName
+	final.buf.AppendByte('}')
+	final.buf.AppendString(final.LineEnding)
+
+	ret, err := final.buf, final.fieldErr
+	putJSONEncoder(final)
+	return ret, err
+}
 
-		// if no name encoder provided, fall back to FullNameEncoder for backwards
-		// compatibility
-		if nameEncoder == nil {
-			nameEncoder = FullNameEncoder
+// addArenaFields adds fields like addFields, sharing the encodings of
+// top-level reflected fields through enc.arena.
+func (enc *jsonEncoder) addArenaFields(fields []Field) error {
+	var errs error
+	for i := range fields {
+		if fields[i].Type == ReflectType {
+			enc.arenaField = i + 1
 		}
+		errs = multierr.Append(errs, addFields(enc, fields[i:i+1]))
+		enc.arenaField = 0
+	}
+	return errs
+}
+
+// encodePart writes one part of an entry. context holds the encoder's
+// accumulated fields.
+func (enc *jsonEncoder) encodePart(part EntryPart, ent Entry, context *buffer.Buffer, fields []Field) {
+	switch part {
+	case LevelPart:
+		if enc.LevelKey != "" && enc.EncodeLevel != nil {
+			enc.addEntryKey(enc.LevelKey)
+			cur := enc.buf.Len()
+			enc.EncodeLevel(ent.Level, enc)
+			if cur == enc.buf.Len() {
+				// User-supplied EncodeLevel was a no-op. Fall back to strings to keep
+				// output JSON valid.
+				enc.AppendString(ent.Level.String())
+			}
+		}
+	case TimePart:
+		if enc.TimeKey != "" && !ent.Time.IsZero() {
+			enc.addEntryKey(enc.TimeKey)
+			enc.AppendTime(ent.Time)
+		}
+	case NamePart:
+		if ent.LoggerName != "" && enc.NameKey != "" {
+			enc.addEntryKey(enc.NameKey)
+			cur := enc.buf.Len()
+			nameEncoder := enc.EncodeName
+
+			// if no name encoder provided, fall back to FullNameEncoder for backwards
+			// compatibility
+			if nameEncoder == nil {
+				nameEncoder = FullNameEncoder
+			}
 
-		nameEncoder(ent.LoggerName, final)
-		if cur == final.buf.Len() {
-			// User-supplied EncodeName was a no-op. Fall back to strings to
-			// keep output JSON valid.
-			final.AppendString(ent.LoggerName)
+			nameEncoder(ent.LoggerName, enc)
+			if cur == enc.buf.Len() {
+				// User-supplied EncodeName was a no-op. Fall back to strings to
+				// keep output JSON valid.
+				enc.AppendString(ent.LoggerName)
+			}
 		}
-	}
-	if ent.Caller.Defined {
-		if final.CallerKey != "" {
-			final.addKey(final.CallerKey)
-			cur := final.buf.Len()
-			final.EncodeCaller(ent.Caller, final)
-			if cur == final.buf.Len() {
+	case CallerPart:
+		if ent.Caller.Defined && enc.CallerKey != "" {
+			enc.addEntryKey(enc.CallerKey)
+			cur := enc.buf.Len()
+			enc.EncodeCaller(ent.Caller, enc)
+			if cur == enc.buf.Len() {
 				// User-supplied EncodeCaller was a no-op. Fall back to strings to
 				// keep output JSON valid.
-				final.AppendString(ent.Caller.String())
+				enc.AppendString(ent.Caller.String())
 			}
 		}
-		if final.FunctionKey != "" {
-			final.addKey(final.FunctionKey)
-			final.AppendString(ent.Caller.Function)
+	case FunctionPart:
+		if ent.Caller.Defined && enc.FunctionKey != "" {
+			enc.addEntryKey(enc.FunctionKey)
+			enc.AppendString(ent.Caller.Function)
+		}
+	case EventIDPart:
+		if ent.EventID != "" && enc.EventIDKey != "" {
+			enc.addEntryKey(enc.EventIDKey)
+			enc.AppendString(ent.EventID)
+		}
+	case MessagePart:
+		if enc.MessageKey != "" {
+			enc.addEntryKey(enc.MessageKey)
+			enc.AppendString(ent.Message)
+		}
+	case FieldsPart:
+		if context.Len() > 0 {
+			enc.addElementSeparator()
+			enc.buf.Write(context.Bytes())
+		}
+		if enc.arena != nil && enc.sharedReflect {
+			enc.fieldErr = enc.addArenaFields(fields)
+		} else {
+			enc.fieldErr = addFields(enc, fields)
+		}
+		enc.closeOpenNamespaces()
+	case StacktracePart:
+		if ent.Stack != "" && enc.StacktraceKey != "" {
+			// Stack traces aren't subject to MaxStringLength.
+			enc.addEntryKey(enc.StacktraceKey)
+			enc.AppendString(ent.Stack)
 		}
 	}
-	if final.MessageKey != "" {
-		final.addKey(enc.MessageKey)
-		final.AppendString(ent.Message)
-	}
-	if enc.buf.Len() > 0 {
-		final.addElementSeparator()
-		final.buf.Write(enc.buf.Bytes())
-	}
-	addFields(final, fields)
-	final.closeOpenNamespaces()
-	if ent.Stack != "" && final.StacktraceKey != "" {
-		final.AddString(final.StacktraceKey, ent.Stack)
-	}
-	final.buf.AppendByte('}')
-	final.buf.AppendString(final.LineEnding)
-
-	ret := final.buf
-	putJSONEncoder(final)
-	return ret, nil
 }
 
 func (enc *jsonEncoder) truncate() {
 	enc.buf.Reset()
 }
 
+func (enc *jsonEncoder) fieldErrorPolicy() FieldErrorPolicy {
+	return enc.FieldErrors
+}
+
+func (enc *jsonEncoder) mark() fieldMark {
+	return fieldMark{len: enc.buf.Len(), openNamespaces: enc.openNamespaces}
+}
+
+func (enc *jsonEncoder) rollback(m fieldMark) {
+	enc.buf.Truncate(m.len)
+	enc.openNamespaces = m.openNamespaces
+}
+
 func (enc *jsonEncoder) closeOpenNamespaces() {
 	for i := 0; i < enc.openNamespaces; i++ {
 		enc.buf.AppendByte('}')
@@ -444,6 +636,12 @@ func (enc *jsonEncoder) closeOpenNamespaces() {
 }
 
 func (enc *jsonEncoder) addKey(key string) {
+	enc.addEntryKey(enc.encodeKey(key))
+}
+
+// addEntryKey adds a key configured in the EncoderConfig, which isn't
+// canonicalized.
+func (enc *jsonEncoder) addEntryKey(key string) {
 	enc.addElementSeparator()
 	enc.buf.AppendByte('"')
 	enc.safeAddString(key)
@@ -488,22 +686,12 @@ func (enc *jsonEncoder) appendFloat(val float64, bitSize int) {
 // Unlike the standard library's encoder, it doesn't attempt to protect the
 // user from browser vulnerabilities or JSONP-related problems.
 func (enc *jsonEncoder) safeAddString(s string) {
-	safeAppendStringLike(
-		(*buffer.Buffer).AppendString,
-		utf8.DecodeRuneInString,
-		enc.buf,
-		s,
-	)
+	enc.invalidUTF8().appendJSONString(enc.buf, s)
 }
 
 // safeAddByteString is no-alloc equivalent of safeAddString(string(s)) for s []byte.
 func (enc *jsonEncoder) safeAddByteString(s []byte) {
-	safeAppendStringLike(
-		(*buffer.Buffer).AppendBytes,
-		utf8.DecodeRune,
-		enc.buf,
-		s,
-	)
+	enc.invalidUTF8().appendJSONBytes(enc.buf, s)
 }
 
 //
@@ -551,81 +739,3 @@ func utoa64(u uint64) []byte {
 	}
 	return buf[pos:]
 }
-
-// safeAppendStringLike is a generic implementation of safeAddString and safeAddByteString.
-// It appends a string or byte slice to the buffer, escaping all special characters.
-func safeAppendStringLike[S []byte | string](
-	// appendTo appends this string-like object to the buffer.
-	appendTo func(*buffer.Buffer, S),
-	// decodeRune decodes the next rune from the string-like object
-	// and returns its value and width in bytes.
-	decodeRune func(S) (rune, int),
-	buf *buffer.Buffer,
-	s S,
-) {
-	// The encoding logic below works by skipping over characters
-	// that can be safely copied as-is,
-	// until a character is found that needs special handling.
-	// At that point, we copy everything we've seen so far,
-	// and then handle that special character.
-	//
-	// last is the index of the last byte that was copied to the buffer.
-	last := 0
-	for i := 0; i < len(s); {
-		if s[i] >= utf8.RuneSelf {
-			// Character >= RuneSelf may be part of a multi-byte rune.
-			// They need to be decoded before we can decide how to handle them.
-			r, size := decodeRune(s[i:])
-			if r != utf8.RuneError || size != 1 {
-				// No special handling required.
-				// Skip over this rune and continue.
-				i += size
-				continue
-			}
-
-			// Invalid UTF-8 sequence.
-			// Replace it with the Unicode replacement character.
-			appendTo(buf, s[last:i])
-			buf.AppendString(`\ufffd`)
-
-			i++
-			last = i
-		} else {
-			// Character < RuneSelf is a single-byte UTF-8 rune.
-			if s[i] >= 0x20 && s[i] != '\\' && s[i] != '"' {
-				// No escaping necessary.
-				// Skip over this character and continue.
-				i++
-				continue
-			}
-
-			// This character needs to be escaped.
-			appendTo(buf, s[last:i])
-			switch s[i] {
-			case '\\', '"':
-				buf.AppendByte('\\')
-				buf.AppendByte(s[i])
-			case '\n':
-				buf.AppendByte('\\')
-				buf.AppendByte('n')
-			case '\r':
-				buf.AppendByte('\\')
-				buf.AppendByte('r')
-			case '\t':
-				buf.AppendByte('\\')
-				buf.AppendByte('t')
-			default:
-				// Encode bytes < 0x20, except for the escape sequences above.
-				buf.AppendString(`\u00`)
-				buf.AppendByte(_hex[s[i]>>4])
-				buf.AppendByte(_hex[s[i]&0xF])
-			}
-
-			i++
-			last = i
-		}
-	}
-
-	// add remaining
-	appendTo(buf, s[last:])
-}
\ No newline at end of file
@@ -130,3 +130,63 @@ func BenchmarkStandardJSON(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkJSONInternStringValues(b *testing.B) {
+	fields := []Field{
+		{Key: "env", Type: StringType, String: "production"},
+		{Key: "region", Type: StringType, String: "us-east-1"},
+		{Key: "service", Type: StringType, String: "billing-api"},
+		{Key: "team", Type: StringType, String: "Zahlungsabwicklung für Kunden"},
+		{Key: "query", Type: StringType, String: `SELECT "id" FROM "users"`},
+	}
+	ent := Entry{Level: InfoLevel, Message: "handled request", LoggerName: "http"}
+
+	for _, intern := range []int{0, 64} {
+		b.Run(fmt.Sprintf("intern=%d", intern), func(b *testing.B) {
+			cfg := testEncoderConfig()
+			cfg.InternStringValues = intern
+			enc := NewJSONEncoder(cfg)
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					buf, err := enc.EncodeEntry(ent, fields)
+					if err != nil {
+						b.Fatal(err)
+					}
+					buf.Free()
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkJSONStaticObject(b *testing.B) {
+	fields := make([]Field, 20)
+	for i := range fields {
+		fields[i] = Field{Key: fmt.Sprintf("key%d", i), Type: StringType, String: "some static value"}
+	}
+	objects := map[string]ObjectMarshaler{
+		"dynamic": ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+			for _, f := range fields {
+				f.AddTo(enc)
+			}
+			return nil
+		}),
+		"static": NewStaticObject(fields...),
+	}
+	for _, name := range []string{"dynamic", "static"} {
+		field := Field{Key: "meta", Type: ObjectMarshalerType, Interface: objects[name]}
+		b.Run(name, func(b *testing.B) {
+			enc := NewJSONEncoder(testEncoderConfig())
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					buf, _ := enc.EncodeEntry(Entry{Message: "static", Time: time.Unix(0, 0)}, []Field{field})
+					buf.Free()
+				}
+			})
+		})
+	}
+}
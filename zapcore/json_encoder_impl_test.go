@@ -123,6 +123,10 @@ func TestJSONEncoderObjectFields(t *testing.T) {
 		{"byteString", `"k":"v"`, func(e Encoder) { e.AddByteString("k", []byte("v")) }},
 		{"byteString", `"k":""`, func(e Encoder) { e.AddByteString("k", []byte{}) }},
 		{"byteString", `"k":""`, func(e Encoder) { e.AddByteString("k", nil) }},
+		{"rawJSON", `"k":{"a":[1,true]}`, func(e Encoder) { e.(*jsonEncoder).AddRawJSON("k", []byte(`{"a":[1,true]}`)) }},
+		{"rawJSON/multiline", `"k":{"a":"b\nc"}`, func(e Encoder) { e.(*jsonEncoder).AddRawJSON("k", []byte("{\n  \"a\": \"b\\nc\"\n}")) }},
+		{"rawJSON/invalid", `"k":"{\"a\""`, func(e Encoder) { e.(*jsonEncoder).AddRawJSON("k", []byte(`{"a"`)) }},
+		{"rawJSON/field", `"k":[1,2]`, func(e Encoder) { Field{Key: "k", Type: RawJSONType, Interface: []byte(`[1,2]`)}.AddTo(e) }},
 		{"complex128", `"k":"1+2i"`, func(e Encoder) { e.AddComplex128("k", 1+2i) }},
 		{"complex128/negative_i", `"k":"1-2i"`, func(e Encoder) { e.AddComplex128("k", 1-2i) }},
 		{"complex64", `"k":"1+2i"`, func(e Encoder) { e.AddComplex64("k", 1+2i) }},
@@ -676,7 +680,7 @@ var _stringLikeCorpus = []string{
 	`a"b`,
 }
 
-func FuzzSafeAppendStringLike_bytes(f *testing.F) {
+func FuzzAppendJSONBytes(f *testing.F) {
 	for _, s := range _stringLikeCorpus {
 		f.Add([]byte(s))
 	}
@@ -685,18 +689,13 @@ func FuzzSafeAppendStringLike_bytes(f *testing.F) {
 			t.Skip()
 		}
 
-		fuzzSafeAppendStringLike(t, string(b), func(buf *buffer.Buffer) {
-			safeAppendStringLike(
-				(*buffer.Buffer).AppendBytes,
-				utf8.DecodeRune,
-				buf,
-				b,
-			)
+		fuzzAppendJSONString(t, string(b), func(buf *buffer.Buffer) {
+			buf.AppendJSONBytes(b)
 		})
 	})
 }
 
-func FuzzSafeAppendStringLike_string(f *testing.F) {
+func FuzzAppendJSONString(f *testing.F) {
 	for _, s := range _stringLikeCorpus {
 		f.Add(s)
 	}
@@ -705,18 +704,13 @@ func FuzzSafeAppendStringLike_string(f *testing.F) {
 			t.Skip()
 		}
 
-		fuzzSafeAppendStringLike(t, s, func(buf *buffer.Buffer) {
-			safeAppendStringLike(
-				(*buffer.Buffer).AppendString,
-				utf8.DecodeRuneInString,
-				buf,
-				s,
-			)
+		fuzzAppendJSONString(t, s, func(buf *buffer.Buffer) {
+			buf.AppendJSONString(s)
 		})
 	})
 }
 
-func fuzzSafeAppendStringLike(
+func fuzzAppendJSONString(
 	t *testing.T,
 	want string,
 	writeString func(*buffer.Buffer),
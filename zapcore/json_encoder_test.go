@@ -216,6 +216,24 @@ func TestJSONEmptyConfig(t *testing.T) {
 	}
 }
 
+func TestJSONEpochEncodersAreExact(t *testing.T) {
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+		EncodeTime:     zapcore.EpochTimeEncoder,
+		EncodeDuration: zapcore.SecondsDurationEncoder,
+	})
+
+	buf, err := enc.EncodeEntry(zapcore.Entry{}, []zapcore.Field{
+		zap.Time("ts", time.Unix(1700000000, 123456789)),
+		zap.Duration("elapsed", 1500*time.Millisecond),
+	})
+	if assert.NoError(t, err, "Unexpected JSON encoding error.") {
+		// A float64 can't represent the timestamp down to the nanosecond.
+		assert.Equal(t, `{"ts":1700000000.123456789,"elapsed":1.5}`+"\n", buf.String(),
+			"Expected epoch times and durations to be encoded exactly.")
+	}
+	buf.Free()
+}
+
 // Encodes any object into empty json '{}'
 type emptyReflectedEncoder struct {
 	writer io.Writer
@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/buffer"
+)
+
+// _maxInternedLength is the length in bytes of the longest string value a
+// stringInterner caches. Longer values are rarely repeated verbatim.
+const _maxInternedLength = 64
+
+// stringInterner caches the JSON-escaped encodings of string values that are
+// encoded repeatedly.
+//
+// Lookups are lock-free: the cache is an immutable map, replaced wholesale
+// when a value is added. Values are only cached once they've been seen
+// twice, so that one-off values like request IDs don't fill the cache.
+type stringInterner struct {
+	max     int
+	invalid InvalidUTF8Policy
+	cache   atomic.Pointer[map[string][]byte]
+
+	mu   sync.Mutex // guards seen and replacing cache
+	seen map[string]struct{}
+}
+
+func newStringInterner(max int, invalid InvalidUTF8Policy) *stringInterner {
+	si := &stringInterner{
+		max:     max,
+		invalid: invalid,
+		seen:    make(map[string]struct{}),
+	}
+	cache := make(map[string][]byte)
+	si.cache.Store(&cache)
+	return si
+}
+
+// appendString appends s to buf, JSON-escaped but without quotes.
+func (si *stringInterner) appendString(buf *buffer.Buffer, s string) {
+	if len(s) > _maxInternedLength {
+		si.invalid.appendJSONString(buf, s)
+		return
+	}
+	if escaped, ok := (*si.cache.Load())[s]; ok {
+		buf.AppendBytes(escaped)
+		return
+	}
+
+	start := buf.Len()
+	si.invalid.appendJSONString(buf, s)
+	si.observe(s, buf.Bytes()[start:])
+}
+
+// observe records a cache miss for s, caching its escaped encoding if s has
+// been seen before.
+func (si *stringInterner) observe(s string, escaped []byte) {
+	// Never make logging wait on the interner: if another goroutine is
+	// updating it, skip this value.
+	if !si.mu.TryLock() {
+		return
+	}
+	defer si.mu.Unlock()
+
+	cache := *si.cache.Load()
+	if len(cache) >= si.max {
+		return
+	}
+	if _, ok := cache[s]; ok {
+		return
+	}
+	if _, ok := si.seen[s]; !ok {
+		if len(si.seen) >= si.max {
+			// Mostly one-off values; start over.
+			si.seen = make(map[string]struct{})
+		}
+		// Copy s so that the interner doesn't retain the memory it's
+		// part of.
+		si.seen[string([]byte(s))] = struct{}{}
+		return
+	}
+
+	delete(si.seen, s)
+	next := make(map[string][]byte, len(cache)+1)
+	for k, v := range cache {
+		next[k] = v
+	}
+	next[string([]byte(s))] = append([]byte(nil), escaped...)
+	si.cache.Store(&next)
+}
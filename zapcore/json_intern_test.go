@@ -0,0 +1,83 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/internal/bufferpool"
+)
+
+func TestStringInterner(t *testing.T) {
+	si := newStringInterner(2, ReplaceInvalidUTF8)
+	encode := func(s string) string {
+		buf := bufferpool.Get()
+		defer buf.Free()
+		si.appendString(buf, s)
+		return buf.String()
+	}
+	cached := func() map[string][]byte { return *si.cache.Load() }
+
+	assert.Equal(t, `say \"hi\"`, encode(`say "hi"`), "Unexpected encoding on first sight.")
+	assert.Empty(t, cached(), "Expected values to be cached only once repeated.")
+	assert.Equal(t, `say \"hi\"`, encode(`say "hi"`), "Unexpected encoding on second sight.")
+	assert.Equal(t, map[string][]byte{`say "hi"`: []byte(`say \"hi\"`)}, cached(), "Expected repeated value to be cached.")
+	assert.Equal(t, `say \"hi\"`, encode(`say "hi"`), "Unexpected encoding from the cache.")
+
+	long := strings.Repeat("x", _maxInternedLength+1)
+	encode(long)
+	assert.Equal(t, long, encode(long), "Unexpected encoding of long value.")
+	assert.Len(t, cached(), 1, "Expected long values not to be cached.")
+
+	for _, s := range []string{"a", "a", "b", "b", "c", "c"} {
+		assert.Equal(t, s, encode(s), "Unexpected encoding.")
+	}
+	assert.Len(t, cached(), 2, "Expected the cache to be bounded.")
+	assert.Contains(t, cached(), "a", "Expected earlier values to stay cached.")
+}
+
+func TestJSONEncoderInternStringValues(t *testing.T) {
+	cfg := EncoderConfig{MessageKey: "msg", NameKey: "logger", InternStringValues: 16}
+	enc := NewJSONEncoder(cfg).(*jsonEncoder)
+	clone := enc.Clone().(*jsonEncoder)
+	require.NotNil(t, enc.interner, "Expected an interner.")
+	assert.Same(t, enc.interner, clone.interner, "Expected clones to share the interner.")
+
+	plain := NewJSONEncoder(EncoderConfig{MessageKey: "msg", NameKey: "logger"})
+	fields := []Field{
+		{Key: "env", Type: StringType, String: "prod"},
+		{Key: "team", Type: StringType, String: "Zahlungsabwicklung für \"Kunden\""},
+	}
+	ent := Entry{Message: "hello", LoggerName: "http"}
+	for i := 0; i < 3; i++ {
+		want, err := plain.EncodeEntry(ent, fields)
+		require.NoError(t, err)
+		got, err := clone.EncodeEntry(ent, fields)
+		require.NoError(t, err)
+		assert.Equal(t, want.String(), got.String(), "Interning changed the output.")
+		want.Free()
+		got.Free()
+	}
+	assert.Contains(t, *enc.interner.cache.Load(), "prod", "Expected repeated values to be interned.")
+}
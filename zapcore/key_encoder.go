@@ -0,0 +1,159 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode"
+)
+
+// _maxCachedKeys caps the number of keys whose canonical forms an encoder
+// caches. Once the cache is full, further keys are canonicalized each time
+// they're encoded.
+const _maxCachedKeys = 4096
+
+// A KeyEncoder canonicalizes field keys, for example to make a codebase that
+// mixes naming conventions emit uniformly named fields. Encoders call it at
+// most once per distinct key and cache the result, so it needn't be fast,
+// but it must be safe for concurrent use and return the same result for the
+// same key.
+type KeyEncoder func(string) string
+
+// SnakeCaseKeyEncoder converts keys to snake_case: "userID", "UserId", and
+// "user-id" all become "user_id". Periods are kept, so each part of a dotted
+// key is converted separately.
+func SnakeCaseKeyEncoder(key string) string {
+	return joinKeyWords(key, false /* camel */)
+}
+
+// CamelCaseKeyEncoder converts keys to camelCase: "user_id", "UserID", and
+// "user-id" all become "userId". Periods are kept, so each part of a dotted
+// key is converted separately.
+func CamelCaseKeyEncoder(key string) string {
+	return joinKeyWords(key, true /* camel */)
+}
+
+// UnmarshalText unmarshals text to a KeyEncoder. "snake" is unmarshaled to
+// SnakeCaseKeyEncoder and "camel" to CamelCaseKeyEncoder. Anything else is
+// unmarshaled to nil, which leaves keys as they are.
+func (e *KeyEncoder) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "snake":
+		*e = SnakeCaseKeyEncoder
+	case "camel":
+		*e = CamelCaseKeyEncoder
+	default:
+		*e = nil
+	}
+	return nil
+}
+
+// encodeKey returns the canonical form of a field key. Keys configured for
+// the entry itself, like MessageKey, aren't passed through it. Encoders
+// built without a config leave keys as they are.
+func (cfg *EncoderConfig) encodeKey(key string) string {
+	if cfg == nil || cfg.EncodeKey == nil {
+		return key
+	}
+	return cfg.EncodeKey(key)
+}
+
+// cacheKeyEncoder wraps a KeyEncoder so that each key is canonicalized only
+// once. Encoders wrap the KeyEncoder in their copy of the EncoderConfig, so
+// the cache is shared with their clones.
+func cacheKeyEncoder(encode KeyEncoder) KeyEncoder {
+	if encode == nil {
+		return nil
+	}
+	var (
+		cache sync.Map // map[string]string
+		size  atomic.Int64
+	)
+	return func(key string) string {
+		if canonical, ok := cache.Load(key); ok {
+			return canonical.(string)
+		}
+		canonical := encode(key)
+		if size.Load() < _maxCachedKeys {
+			// Copy key so that the cache doesn't retain the memory it's
+			// part of.
+			if _, loaded := cache.LoadOrStore(string([]byte(key)), canonical); !loaded {
+				size.Add(1)
+			}
+		}
+		return canonical
+	}
+}
+
+// joinKeyWords splits each period-separated part of key into words and joins
+// them in snake_case or camelCase. Words are separated by underscores,
+// hyphens, and spaces, and start at each upper-case letter that follows a
+// lower-case letter or digit, or that ends a run of upper-case letters, as
+// in "HTTPServer".
+func joinKeyWords(key string, camel bool) string {
+	runes := []rune(key)
+	var sb strings.Builder
+	sb.Grow(len(key) + 2)
+
+	var (
+		partStart = true  // no letters written yet in this part
+		wordStart = false // the next letter starts a new word
+	)
+	for i, r := range runes {
+		switch r {
+		case '.':
+			sb.WriteRune(r)
+			partStart, wordStart = true, false
+			continue
+		case '_', '-', ' ':
+			wordStart = true
+			continue
+		}
+
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				(unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				wordStart = true
+			}
+		}
+
+		switch {
+		case partStart:
+			sb.WriteRune(unicode.ToLower(r))
+		case wordStart && camel:
+			sb.WriteRune(unicode.ToUpper(r))
+		case wordStart:
+			sb.WriteByte('_')
+			sb.WriteRune(unicode.ToLower(r))
+		default:
+			sb.WriteRune(unicode.ToLower(r))
+		}
+		partStart, wordStart = false, false
+	}
+	if sb.Len() == 0 {
+		// Don't turn keys like "_" into empty keys.
+		return key
+	}
+	return sb.String()
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "go.uber.org/zap/zapcore"
+)
+
+func TestCaseKeyEncoders(t *testing.T) {
+	tests := []struct {
+		key   string
+		snake string
+		camel string
+	}{
+		{"", "", ""},
+		{"id", "id", "id"},
+		{"userID", "user_id", "userId"},
+		{"UserId", "user_id", "userId"},
+		{"user_id", "user_id", "userId"},
+		{"user-id", "user_id", "userId"},
+		{"User Name", "user_name", "userName"},
+		{"HTTPStatus", "http_status", "httpStatus"},
+		{"ipv4Addr", "ipv4_addr", "ipv4Addr"},
+		{"http.RequestID", "http.request_id", "http.requestId"},
+		{"_private", "private", "private"},
+		{"_", "_", "_"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.snake, SnakeCaseKeyEncoder(tt.key), "Unexpected snake_case form of %q.", tt.key)
+		assert.Equal(t, tt.camel, CamelCaseKeyEncoder(tt.key), "Unexpected camelCase form of %q.", tt.key)
+	}
+}
+
+func TestKeyEncoderUnmarshalText(t *testing.T) {
+	tests := []struct {
+		text string
+		want string // encoding of "userID"
+	}{
+		{"snake", "user_id"},
+		{"camel", "userId"},
+		{"", "userID"},
+		{"something-random", "userID"},
+	}
+
+	for _, tt := range tests {
+		var ke KeyEncoder
+		require.NoError(t, ke.UnmarshalText([]byte(tt.text)), "Unexpected error unmarshaling %q.", tt.text)
+		got := "userID"
+		if ke != nil {
+			got = ke(got)
+		}
+		assert.Equal(t, tt.want, got, "Unexpected key encoder for %q.", tt.text)
+	}
+}
+
+func TestEncodeKey(t *testing.T) {
+	cfg := testEncoderConfig()
+	cfg.MessageKey = "logMessage"
+	cfg.EncodeKey = SnakeCaseKeyEncoder
+
+	fields := []Field{
+		{Key: "requestID", Type: StringType, String: "r1"},
+		{Key: "httpReq", Type: NamespaceType},
+		{Key: "statusCode", Type: Int64Type, Integer: 200},
+	}
+	ent := Entry{Level: InfoLevel, Message: "hi", Time: time.Unix(0, 0)}
+
+	t.Run("json", func(t *testing.T) {
+		enc := NewJSONEncoder(cfg)
+		enc.AddString("userID", "u1")
+		buf, err := enc.EncodeEntry(ent, fields)
+		require.NoError(t, err, "Unexpected encoding error.")
+		assert.Equal(t,
+			`{"level":"info","ts":0,"logMessage":"hi","user_id":"u1","request_id":"r1","http_req":{"status_code":200}}`+"\n",
+			buf.String(), "Expected field keys, but not entry keys, to be canonicalized.")
+	})
+
+	t.Run("cbor", func(t *testing.T) {
+		buf, err := NewCBOREncoder(cfg).EncodeEntry(ent, fields)
+		require.NoError(t, err, "Unexpected encoding error.")
+		got := decodeCBOR(t, buf.Bytes()).(map[string]interface{})
+		assert.Equal(t, "hi", got["logMessage"], "Expected entry keys to be left as they are.")
+		assert.Equal(t, "r1", got["request_id"], "Expected field keys to be canonicalized.")
+		assert.Contains(t, got, "http_req", "Expected namespace keys to be canonicalized.")
+	})
+
+	t.Run("gelf", func(t *testing.T) {
+		got := decodeGELF(t, NewGELFEncoder(cfg, "h"), ent, fields)
+		assert.Equal(t, "r1", got["_request_id"], "Expected field keys to be canonicalized.")
+		assert.Equal(t, float64(200), got["_http_req.status_code"], "Expected namespace keys to be canonicalized.")
+	})
+}
+
+func TestEncodeKeyCached(t *testing.T) {
+	var calls atomic.Int64
+	cfg := testEncoderConfig()
+	cfg.EncodeKey = func(key string) string {
+		calls.Add(1)
+		return "k_" + key
+	}
+
+	enc := NewJSONEncoder(cfg)
+	for i := 0; i < 3; i++ {
+		clone := enc.Clone()
+		clone.AddString("a", "x")
+		clone.AddString("b", "y")
+		buf, err := clone.EncodeEntry(Entry{}, nil)
+		require.NoError(t, err, "Unexpected encoding error.")
+		assert.Equal(t, `{"level":"info","msg":"","k_a":"x","k_b":"y"}`+"\n", buf.String(), "Unexpected output.")
+	}
+	assert.Equal(t, int64(2), calls.Load(), "Expected each key to be canonicalized once.")
+}
@@ -23,32 +23,66 @@ package zapcore
 import "sync"
 
 type lazyWithCore struct {
-	Core
-	sync.Once
+	core   Core // the wrapped Core, without fields; never changes
 	fields []Field
+
+	once     sync.Once
+	withCore Core // core.With(fields), set by initOnce
 }
 
+var (
+	_ Core           = (*lazyWithCore)(nil)
+	_ leveledEnabler = (*lazyWithCore)(nil)
+)
+
 // NewLazyWith wraps a Core with a "lazy" Core that will only encode fields if
 // the logger is written to (or is further chained in a lon-lazy manner).
+// Entries below the wrapped Core's level don't count as writes, so a lazy
+// Core that only ever sees disabled entries never clones its Core or encodes
+// its fields.
 func NewLazyWith(core Core, fields []Field) Core {
 	return &lazyWithCore{
-		Core:   core,
+		core:   core,
 		fields: fields,
 	}
 }
 
-func (d *lazyWithCore) initOnce() {
-	d.Once.Do(func() {
-		d.Core = d.Core.With(d.fields)
+func (d *lazyWithCore) initOnce() Core {
+	d.once.Do(func() {
+		d.withCore = d.core.With(d.fields)
 	})
+	return d.withCore
+}
+
+func (d *lazyWithCore) Enabled(lvl Level) bool {
+	return d.core.Enabled(lvl)
+}
+
+func (d *lazyWithCore) Level() Level {
+	return LevelOf(d.core)
 }
 
 func (d *lazyWithCore) With(fields []Field) Core {
-	d.initOnce()
-	return d.Core.With(fields)
+	return d.initOnce().With(fields)
+}
+
+func (d *lazyWithCore) AccumulatedFields() []Field {
+	return AccumulatedFields(d.initOnce())
 }
 
 func (d *lazyWithCore) Check(e Entry, ce *CheckedEntry) *CheckedEntry {
-	d.initOnce()
-	return d.Core.Check(e, ce)
+	// Adding fields doesn't change a Core's level, so there's no need to
+	// clone the Core only to find out that an entry is disabled.
+	if !d.core.Enabled(e.Level) {
+		return ce
+	}
+	return d.initOnce().Check(e, ce)
+}
+
+func (d *lazyWithCore) Write(e Entry, fields []Field) error {
+	return d.initOnce().Write(e, fields)
+}
+
+func (d *lazyWithCore) Sync() error {
+	return d.core.Sync()
 }
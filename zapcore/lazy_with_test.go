@@ -21,6 +21,7 @@
 package zapcore_test
 
 import (
+	"sync"
 	"sync/atomic"
 	"testing"
 
@@ -152,3 +153,33 @@ func TestLazyCore(t *testing.T) {
 		})
 	}
 }
+
+func TestLazyCoreDisabledEntries(t *testing.T) {
+	withLazyCore(func(lazy zapcore.Core, proxy *proxyCore, logs *observer.ObservedLogs) {
+		assert.Nil(t, lazy.Check(zapcore.Entry{Level: zapcore.DebugLevel}, nil), "Expected disabled entry to be dropped.")
+		assert.False(t, lazy.Enabled(zapcore.DebugLevel), "Expected lazy core to report the inner core's level.")
+		assert.Equal(t, zapcore.InfoLevel, zapcore.LevelOf(lazy), "Unexpected level.")
+		assert.Zero(t, proxy.withCount.Load(), "Expected disabled entries not to clone the inner core.")
+		assert.Zero(t, logs.Len(), "Unexpected log output.")
+	}, makeInt64Field("a", 1))
+}
+
+func TestLazyCoreConcurrentUse(t *testing.T) {
+	withLazyCore(func(lazy zapcore.Core, proxy *proxyCore, logs *observer.ObservedLogs) {
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				lazy.Enabled(zapcore.InfoLevel)
+				if ce := lazy.Check(zapcore.Entry{Level: zapcore.InfoLevel}, nil); ce != nil {
+					ce.Write()
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int64(1), proxy.withCount.Load(), "Expected the inner core to be cloned exactly once.")
+		assert.Equal(t, 4, logs.Len(), "Unexpected number of entries.")
+	}, makeInt64Field("a", 1))
+}
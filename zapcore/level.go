@@ -32,9 +32,13 @@ var errUnmarshalNilLevel = errors.New("can't unmarshal a nil *Level")
 type Level int8
 
 const (
+	// TraceLevel logs are finer-grained than Debug, such as those tracing
+	// the flow of a program step by step. They're for systems with a trace
+	// severity, like logrus, slog, and syslog, to map onto.
+	TraceLevel Level = iota - 2
 	// DebugLevel logs are typically voluminous, and are usually disabled in
 	// production.
-	DebugLevel Level = iota - 1
+	DebugLevel
 	// InfoLevel is the default logging priority.
 	InfoLevel
 	// WarnLevel logs are more important than Info, but don't need individual
@@ -51,7 +55,7 @@ const (
 	// FatalLevel logs a message, then calls os.Exit(1).
 	FatalLevel
 
-	_minLevel = DebugLevel
+	_minLevel = TraceLevel
 	_maxLevel = FatalLevel
 
 	// InvalidLevel is an invalid value for Level.
@@ -79,8 +83,8 @@ type leveledEnabler interface {
 }
 
 // LevelOf reports the minimum enabled log level for the given LevelEnabler
-// from Zap's supported log levels, or [InvalidLevel] if none of them are
-// enabled.
+// from Zap's supported log levels and those registered with RegisterLevel,
+// or [InvalidLevel] if none of them are enabled.
 //
 // A LevelEnabler may implement a 'Level() Level' method to override the
 // behavior of this function.
@@ -100,18 +104,27 @@ func LevelOf(enab LevelEnabler) Level {
 		return lvler.Level()
 	}
 
+	if lvl, ok := customLevelOf(enab, true /* below */); ok {
+		return lvl
+	}
 	for lvl := _minLevel; lvl <= _maxLevel; lvl++ {
 		if enab.Enabled(lvl) {
 			return lvl
 		}
 	}
+	if lvl, ok := customLevelOf(enab, false /* below */); ok {
+		return lvl
+	}
 
 	return InvalidLevel
 }
 
-// String returns a lower-case ASCII representation of the log level.
+// String returns a lower-case ASCII representation of the log level. Levels
+// registered with RegisterLevel are represented by their names.
 func (l Level) String() string {
 	switch l {
+	case TraceLevel:
+		return "trace"
 	case DebugLevel:
 		return "debug"
 	case InfoLevel:
@@ -127,6 +140,9 @@ func (l Level) String() string {
 	case FatalLevel:
 		return "fatal"
 	default:
+		if cl := lookupCustomLevel(l); cl != nil {
+			return cl.name
+		}
 		return fmt.Sprintf("Level(%d)", l)
 	}
 }
@@ -136,6 +152,8 @@ func (l Level) CapitalString() string {
 	// Printing levels in all-caps is common enough that we should export this
 	// functionality.
 	switch l {
+	case TraceLevel:
+		return "TRACE"
 	case DebugLevel:
 		return "DEBUG"
 	case InfoLevel:
@@ -151,6 +169,9 @@ func (l Level) CapitalString() string {
 	case FatalLevel:
 		return "FATAL"
 	default:
+		if cl := lookupCustomLevel(l); cl != nil {
+			return cl.capital
+		}
 		return fmt.Sprintf("LEVEL(%d)", l)
 	}
 }
@@ -178,7 +199,20 @@ func (l *Level) UnmarshalText(text []byte) error {
 }
 
 func (l *Level) unmarshalText(text []byte) bool {
+	if l.unmarshalBuiltinText(text) {
+		return true
+	}
+	lvl, ok := parseCustomLevel(string(text))
+	if ok {
+		*l = lvl
+	}
+	return ok
+}
+
+func (l *Level) unmarshalBuiltinText(text []byte) bool {
 	switch string(text) {
+	case "trace":
+		*l = TraceLevel
 	case "debug":
 		*l = DebugLevel
 	case "info", "": // make the zero value useful
@@ -0,0 +1,298 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// LevelRange returns a LevelEnabler that enables the levels from min to max,
+// inclusive.
+func LevelRange(min, max Level) LevelEnabler {
+	return levelRange{min: min, max: max}
+}
+
+type levelRange struct{ min, max Level }
+
+func (r levelRange) Enabled(lvl Level) bool {
+	return lvl >= r.min && lvl <= r.max
+}
+
+// EnableOnly returns a LevelEnabler that enables exactly the given levels.
+func EnableOnly(levels ...Level) LevelEnabler {
+	var s levelSet
+	for _, lvl := range levels {
+		s[levelIndex(lvl)/64] |= 1 << (levelIndex(lvl) % 64)
+	}
+	return s
+}
+
+// levelSet is a bitmap with one bit for every possible Level.
+type levelSet [4]uint64
+
+func levelIndex(lvl Level) int {
+	return int(lvl) - math.MinInt8
+}
+
+func (s levelSet) Enabled(lvl Level) bool {
+	i := levelIndex(lvl)
+	return s[i/64]&(1<<(i%64)) != 0
+}
+
+// AndEnablers returns a LevelEnabler that enables the levels enabled by all
+// of the given enablers. With no enablers, it enables every level.
+func AndEnablers(enabs ...LevelEnabler) LevelEnabler {
+	return andEnabler(enabs)
+}
+
+type andEnabler []LevelEnabler
+
+func (a andEnabler) Enabled(lvl Level) bool {
+	for _, enab := range a {
+		if !enab.Enabled(lvl) {
+			return false
+		}
+	}
+	return true
+}
+
+// OrEnablers returns a LevelEnabler that enables the levels enabled by any of
+// the given enablers. With no enablers, it enables no levels.
+func OrEnablers(enabs ...LevelEnabler) LevelEnabler {
+	return orEnabler(enabs)
+}
+
+type orEnabler []LevelEnabler
+
+func (o orEnabler) Enabled(lvl Level) bool {
+	for _, enab := range o {
+		if enab.Enabled(lvl) {
+			return true
+		}
+	}
+	return false
+}
+
+// NotEnabler returns a LevelEnabler that enables the levels that enab
+// doesn't.
+func NotEnabler(enab LevelEnabler) LevelEnabler {
+	return notEnabler{enab}
+}
+
+type notEnabler struct{ enab LevelEnabler }
+
+func (n notEnabler) Enabled(lvl Level) bool {
+	return !n.enab.Enabled(lvl)
+}
+
+// ParseLevelEnabler parses a LevelEnabler from a text expression, so that
+// level filters can be written in configuration files. Expressions are built
+// from the following, where the levels are those understood by ParseLevel:
+//
+//	warn            only the given level
+//	info..error     the levels from info to error, inclusive
+//	warn..          warn and above; ">=warn" is equivalent
+//	..info          info and below; "<=info" is equivalent
+//	>info, <error   the levels strictly above or below the given one
+//	!expr           the levels that expr doesn't enable
+//	a & b           the levels enabled by both a and b
+//	a | b           the levels enabled by either a or b; "a, b" is equivalent
+//	(expr)          grouping
+//
+// "!" binds tightest and "|" loosest. For example, "debug..error & !info"
+// enables debug, warn, and error entries, and "warn | fatal" enables only
+// warnings and fatal errors.
+func ParseLevelEnabler(expr string) (LevelEnabler, error) {
+	p := levelExprParser{tokens: tokenizeLevelExpr(expr)}
+	enab, err := p.parseOr()
+	if err == nil && p.pos < len(p.tokens) {
+		err = fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid level expression %q: %v", expr, err)
+	}
+	return enab, nil
+}
+
+// tokenizeLevelExpr splits a level expression into operators and level
+// names, dropping whitespace.
+func tokenizeLevelExpr(expr string) []string {
+	var tokens []string
+	for i := 0; i < len(expr); {
+		switch c := expr[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(expr[i:], ".."), strings.HasPrefix(expr[i:], ">="), strings.HasPrefix(expr[i:], "<="):
+			tokens = append(tokens, expr[i:i+2])
+			i += 2
+		case strings.IndexByte("()!&|,<>.", c) >= 0:
+			tokens = append(tokens, expr[i:i+1])
+			i++
+		default:
+			j := i + 1
+			for j < len(expr) && strings.IndexByte(" \t\n\r()!&|,<>.=", expr[j]) < 0 {
+				j++
+			}
+			tokens = append(tokens, expr[i:j])
+			i = j
+		}
+	}
+	return tokens
+}
+
+type levelExprParser struct {
+	tokens []string
+	pos    int
+}
+
+var errLevelExprEnd = errors.New("unexpected end of expression")
+
+func (p *levelExprParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *levelExprParser) next() string {
+	tok := p.peek()
+	if tok != "" {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *levelExprParser) parseOr() (LevelEnabler, error) {
+	enabs, err := p.parseList(p.parseAnd, "|", ",")
+	if err != nil || len(enabs) == 1 {
+		return firstEnabler(enabs), err
+	}
+	return OrEnablers(enabs...), nil
+}
+
+func (p *levelExprParser) parseAnd() (LevelEnabler, error) {
+	enabs, err := p.parseList(p.parseUnary, "&")
+	if err != nil || len(enabs) == 1 {
+		return firstEnabler(enabs), err
+	}
+	return AndEnablers(enabs...), nil
+}
+
+// parseList parses one or more operands separated by any of the given
+// operators.
+func (p *levelExprParser) parseList(operand func() (LevelEnabler, error), ops ...string) ([]LevelEnabler, error) {
+	var enabs []LevelEnabler
+	for {
+		enab, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		enabs = append(enabs, enab)
+
+		var more bool
+		for _, op := range ops {
+			if p.peek() == op {
+				more = true
+			}
+		}
+		if !more {
+			return enabs, nil
+		}
+		p.next()
+	}
+}
+
+func firstEnabler(enabs []LevelEnabler) LevelEnabler {
+	if len(enabs) == 0 {
+		return nil
+	}
+	return enabs[0]
+}
+
+func (p *levelExprParser) parseUnary() (LevelEnabler, error) {
+	switch tok := p.next(); tok {
+	case "":
+		return nil, errLevelExprEnd
+	case "!":
+		enab, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return NotEnabler(enab), nil
+	case "(":
+		enab, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, errors.New(`missing ")"`)
+		}
+		return enab, nil
+	case ">=", "<=", ">", "<", "..":
+		lvl, err := p.parseLevel()
+		if err != nil {
+			return nil, err
+		}
+		switch tok {
+		case ">=":
+			return LevelRange(lvl, math.MaxInt8), nil
+		case ">":
+			return NotEnabler(LevelRange(math.MinInt8, lvl)), nil
+		case "<":
+			return NotEnabler(LevelRange(lvl, math.MaxInt8)), nil
+		default: // "<=" and ".."
+			return LevelRange(math.MinInt8, lvl), nil
+		}
+	default:
+		p.pos--
+		lvl, err := p.parseLevel()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ".." {
+			return EnableOnly(lvl), nil
+		}
+		p.next()
+		switch p.peek() {
+		case "", ")", "&", "|", ",":
+			return LevelRange(lvl, math.MaxInt8), nil
+		}
+		max, err := p.parseLevel()
+		if err != nil {
+			return nil, err
+		}
+		return LevelRange(lvl, max), nil
+	}
+}
+
+func (p *levelExprParser) parseLevel() (Level, error) {
+	tok := p.next()
+	if tok == "" {
+		return InvalidLevel, errLevelExprEnd
+	}
+	if strings.IndexByte("()!&|,<>.", tok[0]) >= 0 {
+		return InvalidLevel, fmt.Errorf("expected a level, got %q", tok)
+	}
+	return ParseLevel(tok)
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// enabledLevels lists the built-in levels that enab enables.
+func enabledLevels(enab LevelEnabler) []Level {
+	var levels []Level
+	for lvl := _minLevel; lvl <= _maxLevel; lvl++ {
+		if enab.Enabled(lvl) {
+			levels = append(levels, lvl)
+		}
+	}
+	return levels
+}
+
+func TestLevelEnablerCombinators(t *testing.T) {
+	tests := []struct {
+		desc string
+		enab LevelEnabler
+		want []Level
+	}{
+		{"range", LevelRange(InfoLevel, ErrorLevel), []Level{InfoLevel, WarnLevel, ErrorLevel}},
+		{"empty range", LevelRange(ErrorLevel, InfoLevel), nil},
+		{"only", EnableOnly(DebugLevel, FatalLevel), []Level{DebugLevel, FatalLevel}},
+		{"only nothing", EnableOnly(), nil},
+		{"and", AndEnablers(InfoLevel, LevelRange(TraceLevel, WarnLevel)), []Level{InfoLevel, WarnLevel}},
+		{"empty and", AndEnablers(), []Level{TraceLevel, DebugLevel, InfoLevel, WarnLevel, ErrorLevel, DPanicLevel, PanicLevel, FatalLevel}},
+		{"or", OrEnablers(EnableOnly(DebugLevel), PanicLevel), []Level{DebugLevel, PanicLevel, FatalLevel}},
+		{"empty or", OrEnablers(), nil},
+		{"not", NotEnabler(LevelRange(DebugLevel, DPanicLevel)), []Level{TraceLevel, PanicLevel, FatalLevel}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, enabledLevels(tt.enab), "Unexpected enabled levels.")
+		})
+	}
+}
+
+func TestEnableOnlyExtremeLevels(t *testing.T) {
+	enab := EnableOnly(math.MinInt8, math.MaxInt8)
+	assert.True(t, enab.Enabled(math.MinInt8), "Expected the lowest level to be enabled.")
+	assert.True(t, enab.Enabled(math.MaxInt8), "Expected the highest level to be enabled.")
+	assert.Empty(t, enabledLevels(enab), "Expected no built-in levels to be enabled.")
+}
+
+func TestLevelEnablerLevelOf(t *testing.T) {
+	assert.Equal(t, WarnLevel, LevelOf(LevelRange(WarnLevel, ErrorLevel)), "Unexpected level of a range.")
+	assert.Equal(t, InvalidLevel, LevelOf(EnableOnly()), "Unexpected level of an empty set.")
+}
+
+func TestParseLevelEnabler(t *testing.T) {
+	tests := []struct {
+		expr string
+		want []Level
+	}{
+		{"warn", []Level{WarnLevel}},
+		{"WARN", []Level{WarnLevel}},
+		{"info..error", []Level{InfoLevel, WarnLevel, ErrorLevel}},
+		{"dpanic..", []Level{DPanicLevel, PanicLevel, FatalLevel}},
+		{"..debug", []Level{TraceLevel, DebugLevel}},
+		{">=panic", []Level{PanicLevel, FatalLevel}},
+		{"<=trace", []Level{TraceLevel}},
+		{">panic", []Level{FatalLevel}},
+		{"<debug", []Level{TraceLevel}},
+		{"!info", []Level{TraceLevel, DebugLevel, WarnLevel, ErrorLevel, DPanicLevel, PanicLevel, FatalLevel}},
+		{"debug..error & !info", []Level{DebugLevel, WarnLevel, ErrorLevel}},
+		{"warn | fatal", []Level{WarnLevel, FatalLevel}},
+		{"warn, fatal", []Level{WarnLevel, FatalLevel}},
+		{"debug | warn & error", []Level{DebugLevel}},
+		{"(debug | warn) & !debug", []Level{WarnLevel}},
+		{"!(trace..info | fatal)", []Level{WarnLevel, ErrorLevel, DPanicLevel, PanicLevel}},
+		{" !!info ", []Level{InfoLevel}},
+		{"(info..)&(..warn)", []Level{InfoLevel, WarnLevel}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			enab, err := ParseLevelEnabler(tt.expr)
+			require.NoError(t, err, "Unexpected error parsing expression.")
+			assert.Equal(t, tt.want, enabledLevels(enab), "Unexpected enabled levels.")
+		})
+	}
+}
+
+func TestParseLevelEnablerErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		err  string
+	}{
+		{"", "unexpected end of expression"},
+		{"loud", `unrecognized level: "loud"`},
+		{"info &", "unexpected end of expression"},
+		{"(info", `missing ")"`},
+		{"info)", `unexpected ")"`},
+		{"info warn", `unexpected "warn"`},
+		{">=", "unexpected end of expression"},
+		{"info..&", "unexpected end of expression"},
+		{"..|", `expected a level, got "|"`},
+		{"info.error", `unexpected "."`},
+		{"=info", `unrecognized level: "=info"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := ParseLevelEnabler(tt.expr)
+			require.Error(t, err, "Expected an error.")
+			assert.Contains(t, err.Error(), tt.err, "Unexpected error message.")
+			assert.Contains(t, err.Error(), "invalid level expression", "Expected the error to quote the expression.")
+		})
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// customLevel describes a level registered with RegisterLevel.
+type customLevel struct {
+	name    string // lower-case
+	capital string
+	sgr     string // ANSI SGR parameters, or "" if uncolored
+
+	lowercaseColored string
+	capitalColored   string
+}
+
+// _customLevels holds the levels registered with RegisterLevel.
+var _customLevels = struct {
+	sync.RWMutex
+
+	byLevel map[Level]*customLevel
+	byName  map[string]Level // keyed by lower-case name
+	below   []Level          // registered levels below _minLevel, ascending
+	above   []Level          // registered levels above InvalidLevel, ascending
+}{
+	byLevel: make(map[Level]*customLevel),
+	byName:  make(map[string]Level),
+}
+
+// A LevelOption configures a level registered with RegisterLevel.
+type LevelOption interface {
+	apply(*customLevel)
+}
+
+type levelOptionFunc func(*customLevel)
+
+func (f levelOptionFunc) apply(cl *customLevel) {
+	f(cl)
+}
+
+// LevelColor colors a registered level in the output of the color level
+// encoders and the default console theme. sgr is an ANSI SGR parameter
+// string, such as "36" for cyan; see ConsoleTheme.
+func LevelColor(sgr string) LevelOption {
+	return levelOptionFunc(func(cl *customLevel) {
+		cl.sgr = sgr
+	})
+}
+
+// RegisterLevel registers a custom level, such as a Finest level below
+// TraceLevel or an Audit level above FatalLevel, under the given name. Levels
+// are ordered by value, like the built-in ones, so lvl must lie outside the
+// range of the built-in levels and InvalidLevel.
+//
+// Once registered, the level's name is used by Level.String and
+// Level.CapitalString, and therefore by the level encoders and text
+// marshaling, and it's accepted by ParseLevel and Level.UnmarshalText, and
+// therefore by zap's AtomicLevel and Config. Names are case-insensitive.
+//
+// Register levels during initialization, before any logging. Registering the
+// same level under the same name again only updates its options; any other
+// conflict with a built-in or registered level is an error.
+//
+//	const AuditLevel = zapcore.InvalidLevel + 1
+//
+//	func init() {
+//	  zapcore.RegisterLevel(AuditLevel, "audit", zapcore.LevelColor("36"))
+//	}
+func RegisterLevel(lvl Level, name string, opts ...LevelOption) error {
+	if lvl >= _minLevel && lvl <= InvalidLevel {
+		return fmt.Errorf("can't register level %d: it's reserved for built-in levels", lvl)
+	}
+	lower := strings.ToLower(name)
+	if lower == "" || strings.ContainsAny(lower, " \t\r\n") {
+		return fmt.Errorf("can't register level %d: name %q must be non-empty and contain no whitespace", lvl, name)
+	}
+	var builtin Level
+	if builtin.unmarshalBuiltinText([]byte(lower)) {
+		return fmt.Errorf("can't register level %d: name %q is used by %v", lvl, name, builtin)
+	}
+
+	cl := &customLevel{name: lower, capital: strings.ToUpper(lower)}
+	for _, opt := range opts {
+		opt.apply(cl)
+	}
+	cl.lowercaseColored = colorLevelName(cl.sgr, cl.name)
+	cl.capitalColored = colorLevelName(cl.sgr, cl.capital)
+
+	_customLevels.Lock()
+	defer _customLevels.Unlock()
+
+	if other, ok := _customLevels.byName[lower]; ok && other != lvl {
+		return fmt.Errorf("can't register level %d: name %q is used by level %d", lvl, name, other)
+	}
+	if prev, ok := _customLevels.byLevel[lvl]; ok {
+		if prev.name != lower {
+			return fmt.Errorf("can't register level %d as %q: already registered as %q", lvl, name, prev.name)
+		}
+		_customLevels.byLevel[lvl] = cl
+		return nil
+	}
+
+	_customLevels.byLevel[lvl] = cl
+	_customLevels.byName[lower] = lvl
+	if lvl < _minLevel {
+		_customLevels.below = insertLevel(_customLevels.below, lvl)
+	} else {
+		_customLevels.above = insertLevel(_customLevels.above, lvl)
+	}
+	return nil
+}
+
+// insertLevel returns a sorted copy of levels with lvl added. Readers hold on
+// to the old slice without the lock, so it mustn't be modified in place.
+func insertLevel(levels []Level, lvl Level) []Level {
+	out := make([]Level, 0, len(levels)+1)
+	out = append(out, levels...)
+	out = append(out, lvl)
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+func colorLevelName(sgr, name string) string {
+	if sgr == "" {
+		return _unknownLevelColor.Add(name)
+	}
+	return "\x1b[" + sgr + "m" + name + _colorReset
+}
+
+// lookupCustomLevel returns the registered level lvl, or nil.
+func lookupCustomLevel(lvl Level) *customLevel {
+	_customLevels.RLock()
+	cl := _customLevels.byLevel[lvl]
+	_customLevels.RUnlock()
+	return cl
+}
+
+// parseCustomLevel returns the registered level with the given lower-case
+// name.
+func parseCustomLevel(name string) (Level, bool) {
+	_customLevels.RLock()
+	lvl, ok := _customLevels.byName[name]
+	_customLevels.RUnlock()
+	return lvl, ok
+}
+
+// customLevelOf reports the lowest registered level below or above the
+// built-in ones that enab enables.
+func customLevelOf(enab LevelEnabler, below bool) (Level, bool) {
+	_customLevels.RLock()
+	levels := _customLevels.above
+	if below {
+		levels = _customLevels.below
+	}
+	_customLevels.RUnlock()
+
+	for _, lvl := range levels {
+		if enab.Enabled(lvl) {
+			return lvl, true
+		}
+	}
+	return InvalidLevel, false
+}
+
+// customLevelColors returns the SGR parameters of the registered levels that
+// have colors.
+func customLevelColors() map[Level]string {
+	_customLevels.RLock()
+	defer _customLevels.RUnlock()
+
+	colors := make(map[Level]string)
+	for lvl, cl := range _customLevels.byLevel {
+		if cl.sgr != "" {
+			colors[lvl] = cl.sgr
+		}
+	}
+	return colors
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	_testFinestLevel = TraceLevel - 1
+	_testAuditLevel  = InvalidLevel + 1
+)
+
+// withCustomLevels runs f with an empty level registry, restoring the
+// original afterwards.
+func withCustomLevels(t testing.TB, f func()) {
+	_customLevels.Lock()
+	byLevel, byName := _customLevels.byLevel, _customLevels.byName
+	below, above := _customLevels.below, _customLevels.above
+	_customLevels.byLevel = make(map[Level]*customLevel)
+	_customLevels.byName = make(map[string]Level)
+	_customLevels.below, _customLevels.above = nil, nil
+	_customLevels.Unlock()
+
+	defer func() {
+		_customLevels.Lock()
+		_customLevels.byLevel, _customLevels.byName = byLevel, byName
+		_customLevels.below, _customLevels.above = below, above
+		_customLevels.Unlock()
+	}()
+	f()
+}
+
+func TestRegisterLevel(t *testing.T) {
+	withCustomLevels(t, func() {
+		require.NoError(t, RegisterLevel(_testFinestLevel, "Finest", LevelColor("36")))
+		require.NoError(t, RegisterLevel(_testAuditLevel, "audit"))
+
+		assert.Equal(t, "finest", _testFinestLevel.String(), "Unexpected lower-case name.")
+		assert.Equal(t, "FINEST", _testFinestLevel.CapitalString(), "Unexpected capital name.")
+		assert.Equal(t, "audit", _testAuditLevel.String(), "Unexpected lower-case name.")
+		assert.Equal(t, "Level(-42)", Level(-42).String(), "Unregistered levels shouldn't change.")
+
+		for _, text := range []string{"finest", "FINEST", "Finest"} {
+			lvl, err := ParseLevel(text)
+			require.NoError(t, err, "Unexpected error parsing %q.", text)
+			assert.Equal(t, _testFinestLevel, lvl, "Unexpected level parsed from %q.", text)
+		}
+		text, err := _testAuditLevel.MarshalText()
+		require.NoError(t, err)
+		assert.Equal(t, "audit", string(text), "Unexpected marshaled text.")
+
+		assert.Equal(t, _testFinestLevel, LevelOf(_testFinestLevel), "Expected registered levels below trace to be reported.")
+		assert.Equal(t, TraceLevel, LevelOf(TraceLevel), "Unexpected level.")
+		assert.Equal(t, _testAuditLevel, LevelOf(_testAuditLevel), "Expected registered levels above fatal to be reported.")
+
+		// Registering the same level and name again updates its options.
+		require.NoError(t, RegisterLevel(_testAuditLevel, "AUDIT", LevelColor("35")))
+		assert.Equal(t, map[Level]string{_testFinestLevel: "36", _testAuditLevel: "35"}, customLevelColors())
+		assert.Equal(t, "36", DefaultConsoleTheme().Levels[_testFinestLevel], "Expected the default theme to color registered levels.")
+	})
+}
+
+func TestRegisterLevelErrors(t *testing.T) {
+	withCustomLevels(t, func() {
+		require.NoError(t, RegisterLevel(_testFinestLevel, "finest"))
+
+		tests := []struct {
+			desc string
+			lvl  Level
+			name string
+			want string
+		}{
+			{"built-in value", InfoLevel, "notice", "reserved for built-in levels"},
+			{"invalid value", InvalidLevel, "notice", "reserved for built-in levels"},
+			{"empty name", _testAuditLevel, "", "must be non-empty"},
+			{"whitespace", _testAuditLevel, "my level", "contain no whitespace"},
+			{"built-in name", _testAuditLevel, "Warning", `"Warning" is used by warn`},
+			{"trace", _testAuditLevel, "trace", `"trace" is used by trace`},
+			{"registered name", _testAuditLevel, "FINEST", `"FINEST" is used by level -3`},
+			{"renamed", _testFinestLevel, "lowest", `already registered as "finest"`},
+		}
+		for _, tt := range tests {
+			t.Run(tt.desc, func(t *testing.T) {
+				assert.ErrorContains(t, RegisterLevel(tt.lvl, tt.name), tt.want)
+			})
+		}
+	})
+}
+
+func TestCustomLevelEncoders(t *testing.T) {
+	withCustomLevels(t, func() {
+		require.NoError(t, RegisterLevel(_testFinestLevel, "finest", LevelColor("36")))
+		require.NoError(t, RegisterLevel(_testAuditLevel, "audit"))
+
+		tests := []struct {
+			enc  LevelEncoder
+			lvl  Level
+			want string
+		}{
+			{LowercaseLevelEncoder, _testFinestLevel, "finest"},
+			{CapitalLevelEncoder, _testFinestLevel, "FINEST"},
+			{LowercaseColorLevelEncoder, _testFinestLevel, "\x1b[36mfinest\x1b[0m"},
+			{CapitalColorLevelEncoder, _testFinestLevel, "\x1b[36mFINEST\x1b[0m"},
+			{CapitalColorLevelEncoder, _testAuditLevel, "\x1b[31mAUDIT\x1b[0m"},
+		}
+		for _, tt := range tests {
+			arr := &sliceArrayEncoder{}
+			tt.enc(tt.lvl, arr)
+			assert.Equal(t, []interface{}{tt.want}, arr.elems, "Unexpected encoded level.")
+		}
+
+		enc := NewJSONEncoder(EncoderConfig{MessageKey: "msg", LevelKey: "level", EncodeLevel: LowercaseLevelEncoder})
+		buf, err := enc.EncodeEntry(Entry{Level: _testAuditLevel, Message: "hi"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, `{"level":"audit","msg":"hi"}`+"\n", buf.String(), "Unexpected JSON output.")
+		buf.Free()
+	})
+}
@@ -31,6 +31,7 @@ import (
 
 func TestLevelString(t *testing.T) {
 	tests := map[Level]string{
+		TraceLevel:   "trace",
 		DebugLevel:   "debug",
 		InfoLevel:    "info",
 		WarnLevel:    "warn",
@@ -53,6 +54,7 @@ func TestLevelText(t *testing.T) {
 		text  string
 		level Level
 	}{
+		{"trace", TraceLevel},
 		{"debug", DebugLevel},
 		{"info", InfoLevel},
 		{"", InfoLevel}, // make the zero value useful
@@ -114,6 +116,7 @@ func TestCapitalLevelsParse(t *testing.T) {
 		text  string
 		level Level
 	}{
+		{"TRACE", TraceLevel},
 		{"DEBUG", DebugLevel},
 		{"INFO", InfoLevel},
 		{"WARN", WarnLevel},
@@ -229,6 +232,7 @@ func TestLevelOf(t *testing.T) {
 		give LevelEnabler
 		want Level
 	}{
+		{desc: "trace", give: TraceLevel, want: TraceLevel},
 		{desc: "debug", give: DebugLevel, want: DebugLevel},
 		{desc: "info", give: InfoLevel, want: InfoLevel},
 		{desc: "warn", give: WarnLevel, want: WarnLevel},
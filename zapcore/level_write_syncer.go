@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"go.uber.org/multierr"
+	"go.uber.org/zap/buffer"
+)
+
+// levelWriter is implemented by WriteSyncers that route encoded entries by
+// level. Cores that know an entry's level write through writeEntryBuffer.
+type levelWriter interface {
+	writeLevelBuffer(lvl Level, buf *buffer.Buffer) error
+}
+
+// writeEntryBuffer writes an encoded entry at level lvl to ws and frees it,
+// like writeBuffer, letting ws route it by level if it can.
+func writeEntryBuffer(ws WriteSyncer, lvl Level, buf *buffer.Buffer) error {
+	if lw, ok := ws.(levelWriter); ok {
+		return lw.writeLevelBuffer(lvl, buf)
+	}
+	return writeBuffer(ws, buf)
+}
+
+type levelFilterWriteSyncer struct {
+	enab         LevelEnabler
+	match, other WriteSyncer
+}
+
+// NewLevelFilterWriteSyncer creates a WriteSyncer that routes the entries
+// written by a core to one of two WriteSyncers by level: entries at levels
+// enab enables go to match, and the others go to other. This lets a single
+// core and encoder send, say, errors to stderr and everything else to
+// stdout, without encoding each entry twice:
+//
+//	ws := NewLevelFilterWriteSyncer(ErrorLevel, Lock(os.Stderr), Lock(os.Stdout))
+//	core := NewCore(enc, ws, DebugLevel)
+//
+// Writes that don't come from a core carry no level and go to other. Sync
+// syncs both WriteSyncers.
+//
+// Wrapping the result, for example with NewMultiWriteSyncer or
+// BufferedWriteSyncer, hides the levels of entries; wrap match and other
+// instead. Lock is the exception, since it passes levels through.
+func NewLevelFilterWriteSyncer(enab LevelEnabler, match, other WriteSyncer) WriteSyncer {
+	return &levelFilterWriteSyncer{enab: enab, match: match, other: other}
+}
+
+func (s *levelFilterWriteSyncer) route(lvl Level) WriteSyncer {
+	if s.enab.Enabled(lvl) {
+		return s.match
+	}
+	return s.other
+}
+
+func (s *levelFilterWriteSyncer) Write(p []byte) (int, error) {
+	return s.other.Write(p)
+}
+
+func (s *levelFilterWriteSyncer) writeLevelBuffer(lvl Level, buf *buffer.Buffer) error {
+	return writeEntryBuffer(s.route(lvl), lvl, buf)
+}
+
+func (s *levelFilterWriteSyncer) flushesAt(lvl Level) bool {
+	return flushesAt(s.route(lvl), lvl)
+}
+
+func (s *levelFilterWriteSyncer) Sync() error {
+	return multierr.Append(s.match.Sync(), s.other.Sync())
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/internal/ztest"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+)
+
+func TestLevelFilterWriteSyncer(t *testing.T) {
+	for _, locked := range []bool{false, true} {
+		stderr, stdout := &ztest.Buffer{}, &ztest.Buffer{}
+		ws := NewLevelFilterWriteSyncer(ErrorLevel, stderr, stdout)
+		if locked {
+			ws = Lock(ws)
+		}
+		core := NewCore(NewJSONEncoder(EncoderConfig{MessageKey: "msg"}), ws, DebugLevel)
+
+		for _, ent := range []Entry{
+			{Level: DebugLevel, Message: "debug"},
+			{Level: ErrorLevel, Message: "error"},
+			{Level: InfoLevel, Message: "info"},
+			{Level: DPanicLevel, Message: "dpanic"},
+		} {
+			require.NoError(t, core.Write(ent, nil), "Unexpected error writing entry.")
+		}
+		_, err := ws.Write([]byte("raw\n"))
+		require.NoError(t, err, "Unexpected error writing bytes.")
+
+		assert.Equal(t, []string{`{"msg":"error"}`, `{"msg":"dpanic"}`}, stderr.Lines(), "Unexpected entries routed to match.")
+		assert.Equal(t, []string{`{"msg":"debug"}`, `{"msg":"info"}`, "raw"}, stdout.Lines(), "Unexpected entries routed to other.")
+
+		require.NoError(t, ws.Sync(), "Unexpected error syncing.")
+		assert.True(t, stderr.Called(), "Expected match to be synced.")
+		assert.True(t, stdout.Called(), "Expected other to be synced.")
+	}
+}
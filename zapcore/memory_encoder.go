@@ -30,6 +30,8 @@ type MapObjectEncoder struct {
 	Fields map[string]interface{}
 	// cur is a pointer to the namespace we're currently writing to.
 	cur map[string]interface{}
+	// parents holds the namespaces enclosing cur, innermost last.
+	parents []map[string]interface{}
 }
 
 // NewMapObjectEncoder creates a new map-backed ObjectEncoder.
@@ -129,9 +131,20 @@ func (m *MapObjectEncoder) AddReflected(k string, v interface{}) error {
 func (m *MapObjectEncoder) OpenNamespace(k string) {
 	ns := make(map[string]interface{})
 	m.cur[k] = ns
+	m.parents = append(m.parents, m.cur)
 	m.cur = ns
 }
 
+// CloseNamespace closes the most recently opened namespace, so that
+// subsequent fields are added to its parent. It's a no-op if no namespace
+// is open.
+func (m *MapObjectEncoder) CloseNamespace() {
+	if n := len(m.parents); n > 0 {
+		m.cur = m.parents[n-1]
+		m.parents = m.parents[:n-1]
+	}
+}
+
 // sliceArrayEncoder is an ArrayEncoder backed by a simple []interface{}. Like
 // the MapObjectEncoder, it's not designed for production use.
 type sliceArrayEncoder struct {
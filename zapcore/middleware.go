@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import "time"
+
+// A Middleware wraps a Core to add cross-cutting behavior, such as
+// filtering, rate limiting, or redaction, and returns the wrapped Core.
+// Compose middlewares with Chain.
+type Middleware func(Core) Core
+
+// Chain wraps core with each of the given middlewares, so that the first
+// middleware is outermost and sees each entry first. For example,
+//
+//	core = zapcore.Chain(core,
+//	  zapcore.FilterMiddleware(zapcore.Not(zapcore.LoggerNameMatches("noisy"))),
+//	  zapcore.RateLimitMiddleware(time.Second, 1000),
+//	  zapcore.RedactMiddleware("password", "token"),
+//	)
+//
+// filters entries before they count towards the rate limit, and redacts the
+// fields of the entries that remain. Nil middlewares are skipped.
+func Chain(core Core, middlewares ...Middleware) Core {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if m := middlewares[i]; m != nil {
+			core = m(core)
+		}
+	}
+	return core
+}
+
+// FilterMiddleware returns a Middleware that wraps a Core with
+// NewFilterCore, so that only entries matched by pred are logged.
+func FilterMiddleware(pred FilterPredicate) Middleware {
+	return func(core Core) Core {
+		return NewFilterCore(core, pred)
+	}
+}
+
+// SamplerMiddleware returns a Middleware that wraps a Core with
+// NewSamplerWithOptions.
+func SamplerMiddleware(tick time.Duration, first, thereafter int, opts ...SamplerOption) Middleware {
+	return func(core Core) Core {
+		return NewSamplerWithOptions(core, tick, first, thereafter, opts...)
+	}
+}
+
+// RateLimitMiddleware returns a Middleware that logs at most limit entries
+// of each level per tick, whatever their message, and drops the rest. It's
+// a Sampler that counts every entry of a level under the same key, so
+// further SamplerOptions, such as SamplerHook, may be passed to observe
+// dropped entries.
+func RateLimitMiddleware(tick time.Duration, limit int, opts ...SamplerOption) Middleware {
+	opts = append([]SamplerOption{SamplerWithKeyer(rateLimitKey)}, opts...)
+	return SamplerMiddleware(tick, limit, 0, opts...)
+}
+
+func rateLimitKey(Entry, []Field) string { return "" }
+
+// RedactMiddleware returns a Middleware that wraps a Core with
+// NewRedactCore, hiding the values of fields with the given keys.
+func RedactMiddleware(keys ...string) Middleware {
+	return func(core Core) Core {
+		return NewRedactCore(core, keys...)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestChainOrder(t *testing.T) {
+	var calls []string
+	named := func(name string) Middleware {
+		return func(core Core) Core {
+			calls = append(calls, name)
+			return core
+		}
+	}
+
+	core, _ := observer.New(DebugLevel)
+	assert.Equal(t, core, Chain(core), "Expected no middlewares to leave the core as-is.")
+	Chain(core, named("outer"), nil, named("inner"))
+	assert.Equal(t, []string{"inner", "outer"}, calls, "Expected the first middleware to be outermost.")
+}
+
+func TestChainMiddlewares(t *testing.T) {
+	core, logs := observer.New(DebugLevel)
+	core = Chain(core,
+		FilterMiddleware(Not(LoggerNameMatches("noisy"))),
+		RateLimitMiddleware(time.Minute, 2),
+		RedactMiddleware("password"),
+	)
+
+	now := time.Now()
+	write := func(ent Entry, fields ...Field) {
+		ent.Time = now
+		if ce := core.Check(ent, nil); ce != nil {
+			ce.Write(fields...)
+		}
+	}
+	password := Field{Key: "password", Type: StringType, String: "hunter2"}
+	write(Entry{Level: InfoLevel, LoggerName: "noisy", Message: "filtered"})
+	write(Entry{Level: InfoLevel, Message: "first"}, password)
+	write(Entry{Level: InfoLevel, Message: "second"})
+	write(Entry{Level: InfoLevel, Message: "limited"})
+	write(Entry{Level: WarnLevel, Message: "other level"})
+
+	var msgs []string
+	for _, e := range logs.AllUntimed() {
+		msgs = append(msgs, e.Message)
+	}
+	assert.Equal(t, []string{"first", "second", "other level"}, msgs, "Unexpected entries.")
+	assert.Equal(t, map[string]interface{}{"password": RedactedValue}, logs.AllUntimed()[0].ContextMap(), "Expected password to be redacted.")
+}
+
+func TestSamplerMiddleware(t *testing.T) {
+	core, logs := observer.New(DebugLevel)
+	core = Chain(core, SamplerMiddleware(time.Minute, 1, 0))
+	for i := 0; i < 3; i++ {
+		if ce := core.Check(Entry{Level: InfoLevel, Message: "msg", Time: time.Now()}, nil); ce != nil {
+			ce.Write()
+		}
+	}
+	assert.Equal(t, 1, logs.Len(), "Expected repeated entries to be sampled.")
+}
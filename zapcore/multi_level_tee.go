@@ -0,0 +1,209 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"reflect"
+
+	"go.uber.org/multierr"
+)
+
+// A TeeBranch is a destination for NewMultiLevelTee: a Core, along with the
+// LevelEnabler that decides which entries it's sent.
+type TeeBranch struct {
+	// Level restricts the branch to entries at levels it enables. A nil Level
+	// enables every level. Branches are grouped by Level, so share a single
+	// enabler between branches rather than constructing one per branch.
+	Level LevelEnabler
+
+	// Core receives entries enabled by Level. It still applies its own level
+	// checks.
+	Core Core
+}
+
+// _staticLevels is a mask of the levels in [_minLevel, _maxLevel].
+const _staticLevels = 1<<(_maxLevel-_minLevel+1) - 1
+
+// teeGroup is a set of cores sharing a LevelEnabler.
+type teeGroup struct {
+	enab  LevelEnabler // nil if static
+	mask  uint8        // levels enabled by a static enabler, offset by _minLevel
+	cores multiCore
+}
+
+// enabled reports whether the group's enabler accepts lvl.
+func (g *teeGroup) enabled(lvl Level) bool {
+	if g.enab == nil {
+		return lvl >= _minLevel && lvl <= _maxLevel && g.mask&(1<<(lvl-_minLevel)) != 0
+	}
+	return g.enab.Enabled(lvl)
+}
+
+type multiLevelCore []teeGroup
+
+var (
+	_ leveledEnabler = multiLevelCore(nil)
+	_ Core           = multiLevelCore(nil)
+)
+
+// NewMultiLevelTee creates a Core that duplicates log entries into the given
+// branches, sending each entry only to branches whose Level enables it.
+//
+// Branches that share a LevelEnabler are grouped, so each enabler is
+// consulted once per entry no matter how many cores it guards, and the cores
+// in disabled groups are skipped without calling their Check methods. Static
+// enablers, like Level constants, are resolved when the tee is built. When
+// fan-out is high, this makes Check considerably cheaper than combining
+// NewTee with level-filtering cores.
+//
+//	core := zapcore.NewMultiLevelTee(
+//	  zapcore.TeeBranch{Level: zapcore.ErrorLevel, Core: alertCore},
+//	  zapcore.TeeBranch{Level: atom, Core: stdoutCore},
+//	  zapcore.TeeBranch{Level: atom, Core: fileCore},
+//	)
+//
+// Calling it with no branches returns a no-op Core.
+func NewMultiLevelTee(branches ...TeeBranch) Core {
+	if len(branches) == 0 {
+		return NewNopCore()
+	}
+
+	var groups multiLevelCore
+	byEnabler := make(map[LevelEnabler]int)
+	for _, b := range branches {
+		key, static := b.Level, true
+		switch enab := b.Level.(type) {
+		case nil:
+			key = nil
+		case Level:
+		default:
+			static = false
+			if !reflect.TypeOf(enab).Comparable() {
+				// Funcs and the like can't be map keys. They get groups of
+				// their own.
+				groups = append(groups, teeGroup{enab: enab, cores: multiCore{b.Core}})
+				continue
+			}
+		}
+
+		if i, ok := byEnabler[key]; ok {
+			groups[i].cores = append(groups[i].cores, b.Core)
+			continue
+		}
+		g := teeGroup{cores: multiCore{b.Core}}
+		if static {
+			g.mask = staticLevelMask(b.Level)
+		} else {
+			g.enab = b.Level
+		}
+		byEnabler[key] = len(groups)
+		groups = append(groups, g)
+	}
+	return groups
+}
+
+// staticLevelMask returns the mask of levels that enab, which must be nil or
+// a Level, enables.
+func staticLevelMask(enab LevelEnabler) uint8 {
+	if enab == nil {
+		return _staticLevels
+	}
+	var mask uint8
+	for lvl := _minLevel; lvl <= _maxLevel; lvl++ {
+		if enab.Enabled(lvl) {
+			mask |= 1 << (lvl - _minLevel)
+		}
+	}
+	return mask
+}
+
+func (mc multiLevelCore) With(fields []Field) Core {
+	clone := make(multiLevelCore, len(mc))
+	for i := range mc {
+		clone[i] = mc[i]
+		clone[i].cores = mc[i].cores.With(fields).(multiCore)
+	}
+	return clone
+}
+
+// AccumulatedFields reports the fields of the first core. Fields bound with
+// With are bound to every core, so they only differ if the cores were
+// constructed with different context.
+func (mc multiLevelCore) AccumulatedFields() []Field {
+	return AccumulatedFields(mc[0].cores[0])
+}
+
+func (mc multiLevelCore) Level() Level {
+	minLvl := _maxLevel + 1 // mc is never empty
+	for i := range mc {
+		lvl := mc[i].cores.Level()
+		if mc[i].enab != nil {
+			if elvl := LevelOf(mc[i].enab); elvl > lvl {
+				lvl = elvl
+			}
+		} else {
+			for lvl <= _maxLevel && !mc[i].enabled(lvl) {
+				lvl++
+			}
+		}
+		if lvl < minLvl {
+			minLvl = lvl
+		}
+	}
+	return minLvl
+}
+
+func (mc multiLevelCore) Enabled(lvl Level) bool {
+	for i := range mc {
+		if mc[i].enabled(lvl) && mc[i].cores.Enabled(lvl) {
+			return true
+		}
+	}
+	return false
+}
+
+func (mc multiLevelCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	for i := range mc {
+		if mc[i].enabled(ent.Level) {
+			ce = mc[i].cores.Check(ent, ce)
+		}
+	}
+	return ce
+}
+
+// Write writes the entry to the cores of every branch enabled at its level.
+func (mc multiLevelCore) Write(ent Entry, fields []Field) error {
+	var err error
+	for i := range mc {
+		if mc[i].enabled(ent.Level) {
+			err = multierr.Append(err, mc[i].cores.Write(ent, fields))
+		}
+	}
+	return err
+}
+
+func (mc multiLevelCore) Sync() error {
+	var err error
+	for i := range mc {
+		err = multierr.Append(err, mc[i].cores.Sync())
+	}
+	return err
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"errors"
+	"testing"
+
+	"go.uber.org/zap/internal/ztest"
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingEnabler enables levels at or above lvl and counts how often it's
+// consulted.
+type countingEnabler struct {
+	lvl   Level
+	calls int
+}
+
+func (e *countingEnabler) Enabled(lvl Level) bool {
+	e.calls++
+	return lvl >= e.lvl
+}
+
+// enablerFunc is a func that implements LevelEnabler. Funcs aren't
+// comparable, so each gets a group of its own.
+type enablerFunc func(Level) bool
+
+func (f enablerFunc) Enabled(lvl Level) bool { return f(lvl) }
+
+// checkCountingCore counts calls to Check.
+type checkCountingCore struct {
+	Core
+
+	checks *int
+}
+
+func (c checkCountingCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	*c.checks++
+	return c.Core.Check(ent, ce)
+}
+
+func TestMultiLevelTeeUnusualInput(t *testing.T) {
+	assert.Equal(t, NewNopCore(), NewMultiLevelTee(), "Expected to return NopCore.")
+}
+
+func TestMultiLevelTeeCheck(t *testing.T) {
+	shared := &countingEnabler{lvl: WarnLevel}
+	var checks int
+	debugCore, debugLogs := observer.New(DebugLevel)
+	warnCore1, warnLogs1 := observer.New(DebugLevel)
+	warnCore2, warnLogs2 := observer.New(DebugLevel)
+	errorCore, errorLogs := observer.New(DebugLevel)
+	funcCore, funcLogs := observer.New(DebugLevel)
+
+	tee := NewMultiLevelTee(
+		TeeBranch{Core: debugCore},
+		TeeBranch{Level: shared, Core: checkCountingCore{warnCore1, &checks}},
+		TeeBranch{Level: shared, Core: checkCountingCore{warnCore2, &checks}},
+		TeeBranch{Level: ErrorLevel, Core: errorCore},
+		TeeBranch{Level: enablerFunc(func(lvl Level) bool { return lvl == InfoLevel }), Core: funcCore},
+	)
+
+	var ents []Entry
+	for _, lvl := range []Level{DebugLevel, InfoLevel, WarnLevel, ErrorLevel} {
+		ent := Entry{Level: lvl, Message: "log-at-" + lvl.String()}
+		ents = append(ents, ent)
+		if ce := tee.Check(ent, nil); ce != nil {
+			ce.Write()
+		}
+	}
+
+	assert.Equal(t, 4, shared.calls, "Expected the shared enabler to be consulted once per entry.")
+	assert.Equal(t, 4, checks, "Expected disabled branches to be skipped without calling Check.")
+
+	messages := func(logs *observer.ObservedLogs) []string {
+		var msgs []string
+		for _, e := range logs.AllUntimed() {
+			msgs = append(msgs, e.Message)
+		}
+		return msgs
+	}
+	assert.Equal(t, []string{"log-at-debug", "log-at-info", "log-at-warn", "log-at-error"}, messages(debugLogs))
+	assert.Equal(t, []string{"log-at-warn", "log-at-error"}, messages(warnLogs1))
+	assert.Equal(t, []string{"log-at-warn", "log-at-error"}, messages(warnLogs2))
+	assert.Equal(t, []string{"log-at-error"}, messages(errorLogs))
+	assert.Equal(t, []string{"log-at-info"}, messages(funcLogs))
+
+	t.Run("Write", func(t *testing.T) {
+		assert.NoError(t, tee.Write(ents[1], nil), "Unexpected error writing directly.")
+		assert.Equal(t, 5, debugLogs.Len(), "Expected direct writes to reach enabled branches.")
+		assert.Equal(t, 2, funcLogs.Len(), "Expected direct writes to reach enabled branches.")
+		assert.Equal(t, 2, warnLogs1.Len(), "Expected direct writes to skip disabled branches.")
+	})
+}
+
+func TestMultiLevelTeeWith(t *testing.T) {
+	debugCore, debugLogs := observer.New(DebugLevel)
+	warnCore, warnLogs := observer.New(DebugLevel)
+	tee := NewMultiLevelTee(
+		TeeBranch{Core: debugCore},
+		TeeBranch{Level: WarnLevel, Core: warnCore},
+	)
+
+	f := makeInt64Field("k", 42)
+	tee = tee.With([]Field{f})
+	assert.Equal(t, []Field{f}, AccumulatedFields(tee), "Unexpected accumulated fields.")
+
+	ent := Entry{Level: WarnLevel, Message: "log-at-warn"}
+	if ce := tee.Check(ent, nil); ce != nil {
+		ce.Write()
+	}
+	for _, logs := range []*observer.ObservedLogs{debugLogs, warnLogs} {
+		assert.Equal(t, []observer.LoggedEntry{
+			{Entry: ent, Context: []Field{f}},
+		}, logs.All())
+	}
+}
+
+func TestMultiLevelTeeLevels(t *testing.T) {
+	debugCore, _ := observer.New(DebugLevel)
+	infoCore, _ := observer.New(InfoLevel)
+
+	tests := []struct {
+		desc        string
+		give        []TeeBranch
+		wantLevel   Level
+		wantEnabled []Level
+	}{
+		{
+			desc:        "branch level above core",
+			give:        []TeeBranch{{Level: WarnLevel, Core: debugCore}},
+			wantLevel:   WarnLevel,
+			wantEnabled: []Level{WarnLevel, ErrorLevel, FatalLevel},
+		},
+		{
+			desc:        "core level above branch",
+			give:        []TeeBranch{{Level: DebugLevel, Core: infoCore}},
+			wantLevel:   InfoLevel,
+			wantEnabled: []Level{InfoLevel, WarnLevel, FatalLevel},
+		},
+		{
+			desc: "lowest branch wins",
+			give: []TeeBranch{
+				{Level: ErrorLevel, Core: debugCore},
+				{Level: &countingEnabler{lvl: InfoLevel}, Core: debugCore},
+			},
+			wantLevel:   InfoLevel,
+			wantEnabled: []Level{InfoLevel, ErrorLevel},
+		},
+		{
+			desc:      "nothing enabled",
+			give:      []TeeBranch{{Level: enablerFunc(func(Level) bool { return false }), Core: debugCore}},
+			wantLevel: InvalidLevel,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			tee := NewMultiLevelTee(tt.give...)
+			assert.Equal(t, tt.wantLevel, LevelOf(tee), "Unexpected level.")
+			for _, lvl := range tt.wantEnabled {
+				assert.True(t, tee.Enabled(lvl), "Expected %v to be enabled.", lvl)
+			}
+			assert.False(t, tee.Enabled(DebugLevel), "Expected debug to be disabled.")
+		})
+	}
+}
+
+func TestMultiLevelTeeSync(t *testing.T) {
+	sink := &ztest.Discarder{}
+	err := errors.New("failed")
+	sink.SetError(err)
+
+	infoCore, _ := observer.New(InfoLevel)
+	tee := NewMultiLevelTee(
+		TeeBranch{Level: ErrorLevel, Core: infoCore},
+		TeeBranch{Level: ErrorLevel, Core: NewCore(NewJSONEncoder(testEncoderConfig()), sink, DebugLevel)},
+	)
+	assert.Equal(t, err, tee.Sync(), "Expected an error when part of the tee can't Sync.")
+}
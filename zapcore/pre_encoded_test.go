@@ -0,0 +1,103 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+)
+
+// cachedPayload adds a pre-encoded value under "payload" if the encoder
+// accepts one of its encodings, and a string otherwise.
+type cachedPayload map[string][]byte
+
+func (p cachedPayload) MarshalLogObject(enc ObjectEncoder) error {
+	if pe, ok := enc.(PreEncodedEncoder); ok {
+		if bs, ok := p[pe.EncodingName()]; ok {
+			pe.AddPreEncoded("payload", bs)
+			return nil
+		}
+	}
+	enc.AddString("payload", "slow")
+	return nil
+}
+
+func TestPreEncodedEncoders(t *testing.T) {
+	payload := cachedPayload{
+		"json": []byte(`{"a":[1,2]}`),
+		"cbor": {0xa1, 0x61, 'a', 0x82, 0x01, 0x02}, // {"a": [1, 2]}
+	}
+	cfg := EncoderConfig{MessageKey: "msg", LineEnding: "\n"}
+	fields := []Field{
+		{Type: InlineMarshalerType, Interface: payload},
+		{Key: "after", Type: BoolType, Integer: 1},
+	}
+
+	tests := []struct {
+		desc string
+		enc  Encoder
+		want string
+	}{
+		{"json", NewJSONEncoder(cfg), `{"msg":"hello","payload":{"a":[1,2]},"after":true}` + "\n"},
+		{"console", NewConsoleEncoder(cfg), `hello	{"payload": {"a":[1,2]}, "after": true}` + "\n"},
+		{"csv", NewCSVEncoder(cfg), `hello,"{""payload"":{""a"":[1,2]},""after"":true}"` + "\n"},
+		{"ecs", NewECSEncoder(cfg), `{"msg":"hello","ecs.version":"1.6.0","payload":{"a":[1,2]},"after":true}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			buf, err := tt.enc.EncodeEntry(Entry{Message: "hello"}, fields)
+			require.NoError(t, err, "Unexpected error encoding entry.")
+			assert.Equal(t, tt.want, buf.String(), "Unexpected output.")
+			buf.Free()
+		})
+	}
+
+	t.Run("cbor", func(t *testing.T) {
+		buf, err := NewCBOREncoder(cfg).EncodeEntry(Entry{Message: "hello"}, fields)
+		require.NoError(t, err, "Unexpected error encoding entry.")
+		assert.Equal(t, map[string]interface{}{
+			"msg":     "hello",
+			"payload": map[string]interface{}{"a": []interface{}{uint64(1), uint64(2)}},
+			"after":   true,
+		}, decodeCBOR(t, buf.Bytes()), "Unexpected output.")
+		buf.Free()
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		enc := NewMapObjectEncoder()
+		require.NoError(t, payload.MarshalLogObject(enc))
+		assert.Equal(t, map[string]interface{}{"payload": "slow"}, enc.Fields, "Expected encoders without support to fall back.")
+	})
+}
+
+func TestPreEncodedJSONInvalid(t *testing.T) {
+	enc := NewJSONEncoder(EncoderConfig{MessageKey: "msg"})
+	enc.(PreEncodedEncoder).AddPreEncoded("bad", []byte(`{"unterminated`))
+	buf, err := enc.EncodeEntry(Entry{Message: "hello"}, nil)
+	require.NoError(t, err, "Unexpected error encoding entry.")
+	assert.Equal(t, `{"msg":"hello","bad":"{\"unterminated"}`+"\n", buf.String(), "Expected invalid JSON to be added as a string.")
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import "go.uber.org/multierr"
+
+// RedactedValue replaces the values of fields hidden by a redacting Core.
+const RedactedValue = "[REDACTED]"
+
+type redactCore struct {
+	Core
+
+	keys map[string]struct{}
+}
+
+var (
+	_ Core           = (*redactCore)(nil)
+	_ leveledEnabler = (*redactCore)(nil)
+)
+
+// NewRedactCore wraps a Core so that the values of fields with any of the
+// given keys are replaced with RedactedValue, whether the fields are bound
+// to the Core with With or passed at the log site. Only the keys of
+// top-level fields are compared; fields nested in objects, and fields bound
+// to core before it was wrapped, are logged as-is.
+func NewRedactCore(core Core, keys ...string) Core {
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[k] = struct{}{}
+	}
+	return &redactCore{Core: core, keys: set}
+}
+
+func (c *redactCore) Level() Level {
+	return LevelOf(c.Core)
+}
+
+func (c *redactCore) AccumulatedFields() []Field {
+	return AccumulatedFields(c.Core)
+}
+
+func (c *redactCore) With(fields []Field) Core {
+	return &redactCore{Core: c.Core.With(c.redact(fields)), keys: c.keys}
+}
+
+func (c *redactCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		// Fields are only known when the entry is written.
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *redactCore) Write(ent Entry, fields []Field) error {
+	ce := c.Core.Check(ent, nil)
+	if ce == nil {
+		return nil
+	}
+
+	fields = c.redact(fields)
+	var err error
+	for i := range ce.cores {
+		err = multierr.Append(err, ce.cores[i].Write(ent, fields))
+	}
+	putCheckedEntry(ce)
+	return err
+}
+
+// redact returns fields with the values of redacted keys replaced. The
+// slice is only copied if a field needs redacting.
+func (c *redactCore) redact(fields []Field) []Field {
+	copied := false
+	for i, f := range fields {
+		if _, ok := c.keys[f.Key]; !ok || f.Type == NamespaceType || f.Type == CloseNamespaceType {
+			continue
+		}
+		if !copied {
+			fields = append([]Field(nil), fields...)
+			copied = true
+		}
+		fields[i] = Field{Key: f.Key, Type: StringType, String: RedactedValue}
+	}
+	return fields
+}
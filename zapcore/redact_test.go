@@ -0,0 +1,61 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRedactCore(t *testing.T) {
+	str := func(key, val string) Field { return Field{Key: key, Type: StringType, String: val} }
+	obs, logs := observer.New(InfoLevel)
+	core := NewRedactCore(obs, "password", "token", "ns")
+
+	assert.Equal(t, InfoLevel, LevelOf(core), "Unexpected level.")
+	assert.Nil(t, core.Check(Entry{Level: DebugLevel}, nil), "Expected disabled entries to be skipped.")
+
+	bound := []Field{str("token", "abc"), str("user", "alice")}
+	child := core.With(bound)
+	fields := []Field{
+		str("password", "hunter2"),
+		{Key: "ns", Type: NamespaceType},
+		{Key: "n", Type: Int64Type, Integer: 1},
+	}
+	ce := child.Check(Entry{Level: InfoLevel, Message: "login"}, nil)
+	require.NotNil(t, ce, "Expected enabled entry to be checked.")
+	ce.Write(fields...)
+
+	require.Equal(t, 1, logs.Len(), "Expected one entry.")
+	assert.Equal(t, map[string]interface{}{
+		"token":    RedactedValue,
+		"user":     "alice",
+		"password": RedactedValue,
+		"ns":       map[string]interface{}{"n": int64(1)},
+	}, logs.AllUntimed()[0].ContextMap(), "Unexpected fields.")
+	assert.Equal(t, "abc", bound[0].String, "Expected bound fields to be left unmodified.")
+	assert.Equal(t, "hunter2", fields[0].String, "Expected log site fields to be left unmodified.")
+}
@@ -22,7 +22,12 @@ package zapcore
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"sort"
+	"strings"
+	"sync"
 )
 
 // ReflectedEncoder serializes log fields that can't be serialized with Zap's
@@ -33,9 +38,98 @@ type ReflectedEncoder interface {
 	Encode(interface{}) error
 }
 
+// ReflectedEncoderFactory builds a ReflectedEncoder that writes to w.
+//
+// In configuration files, factories are referred to by the names they were
+// registered under with RegisterReflectedEncoder.
+type ReflectedEncoderFactory func(w io.Writer) ReflectedEncoder
+
+// ErrReflectionDisabled is returned when StrictReflectedEncoder is asked to
+// encode a value.
+var ErrReflectionDisabled = errors.New("reflected values are disabled")
+
+var _reflectedEncoders = struct {
+	sync.RWMutex
+	byName map[string]ReflectedEncoderFactory
+}{
+	byName: map[string]ReflectedEncoderFactory{
+		"json":   defaultReflectedEncoder,
+		"strict": StrictReflectedEncoder,
+	},
+}
+
+// RegisterReflectedEncoder makes a ReflectedEncoderFactory available to
+// configuration files under the given name, for example to select a faster
+// JSON library:
+//
+//	zapcore.RegisterReflectedEncoder("jsoniter", func(w io.Writer) zapcore.ReflectedEncoder {
+//	  return jsoniter.ConfigFastest.NewEncoder(w)
+//	})
+//
+// The names "json", for encoding/json (the default), and "strict", for
+// StrictReflectedEncoder, are registered by default. It's an error to
+// register a name twice.
+func RegisterReflectedEncoder(name string, factory ReflectedEncoderFactory) error {
+	if name == "" {
+		return errors.New("no reflected encoder name specified")
+	}
+	_reflectedEncoders.Lock()
+	defer _reflectedEncoders.Unlock()
+	if _, ok := _reflectedEncoders.byName[name]; ok {
+		return fmt.Errorf("reflected encoder already registered for name %q", name)
+	}
+	_reflectedEncoders.byName[name] = factory
+	return nil
+}
+
+// UnmarshalText unmarshals the name of a registered ReflectedEncoderFactory.
+// The empty string is unmarshaled to nil, which selects the default.
+func (f *ReflectedEncoderFactory) UnmarshalText(text []byte) error {
+	name := string(text)
+	if name == "" {
+		*f = nil
+		return nil
+	}
+	_reflectedEncoders.RLock()
+	factory, ok := _reflectedEncoders.byName[name]
+	_reflectedEncoders.RUnlock()
+	if !ok {
+		return fmt.Errorf("no reflected encoder registered for name %q; known names: %v", name, reflectedEncoderNames())
+	}
+	*f = factory
+	return nil
+}
+
+func reflectedEncoderNames() string {
+	_reflectedEncoders.RLock()
+	names := make([]string, 0, len(_reflectedEncoders.byName))
+	for name := range _reflectedEncoders.byName {
+		names = append(names, name)
+	}
+	_reflectedEncoders.RUnlock()
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
 func defaultReflectedEncoder(w io.Writer) ReflectedEncoder {
 	enc := json.NewEncoder(w)
 	// For consistency with our custom JSON encoder.
 	enc.SetEscapeHTML(false)
 	return enc
 }
+
+// StrictReflectedEncoder builds a ReflectedEncoder that refuses to encode
+// anything, returning ErrReflectionDisabled instead. Use it in
+// allocation-sensitive services to keep reflection-based encoding out of
+// the logging path: fields that would be reflected, such as those built
+// with zap.Reflect or by zap.Any for unsupported types, are replaced with
+// an error noting that reflection is disabled.
+func StrictReflectedEncoder(io.Writer) ReflectedEncoder {
+	return strictReflectedEncoder{}
+}
+
+type strictReflectedEncoder struct{}
+
+func (strictReflectedEncoder) Encode(interface{}) error {
+	return ErrReflectionDisabled
+}
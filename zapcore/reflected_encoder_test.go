@@ -0,0 +1,116 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+)
+
+// upperReflectedEncoder encodes every value as the string "UPPER".
+type upperReflectedEncoder struct{ w io.Writer }
+
+func (e upperReflectedEncoder) Encode(interface{}) error {
+	_, err := io.WriteString(e.w, `"UPPER"`)
+	return err
+}
+
+func TestRegisterReflectedEncoder(t *testing.T) {
+	require.NoError(t, RegisterReflectedEncoder("upper-test", func(w io.Writer) ReflectedEncoder {
+		return upperReflectedEncoder{w}
+	}))
+	assert.EqualError(t,
+		RegisterReflectedEncoder("upper-test", StrictReflectedEncoder),
+		`reflected encoder already registered for name "upper-test"`,
+		"Expected duplicate names to be rejected.")
+	assert.EqualError(t,
+		RegisterReflectedEncoder("", StrictReflectedEncoder),
+		"no reflected encoder name specified",
+		"Expected empty names to be rejected.")
+
+	var cfg EncoderConfig
+	require.NoError(t, json.Unmarshal([]byte(`{"messageKey":"msg","reflectedEncoder":"upper-test"}`), &cfg))
+	buf, err := NewJSONEncoder(cfg).EncodeEntry(Entry{Message: "hello"}, []Field{
+		{Key: "obj", Type: ReflectType, Interface: struct{ A int }{1}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `{"msg":"hello","obj":"UPPER"}`+"\n", buf.String(), "Expected the registered encoder to be used.")
+	buf.Free()
+}
+
+func TestReflectedEncoderFactoryUnmarshalText(t *testing.T) {
+	var f ReflectedEncoderFactory
+	require.NoError(t, f.UnmarshalText([]byte("strict")))
+	assert.NotNil(t, f, "Expected the strict encoder to be registered.")
+
+	require.NoError(t, f.UnmarshalText([]byte("json")))
+	assert.NotNil(t, f, "Expected the JSON encoder to be registered.")
+
+	require.NoError(t, f.UnmarshalText(nil))
+	assert.Nil(t, f, "Expected the empty name to select the default.")
+
+	err := f.UnmarshalText([]byte("missing"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `no reflected encoder registered for name "missing"`, "Unexpected error.")
+	assert.Contains(t, err.Error(), "json, strict", "Expected the error to list known names.")
+}
+
+func TestStrictReflectedEncoder(t *testing.T) {
+	fields := []Field{
+		{Key: "obj", Type: ReflectType, Interface: struct{ A int }{1}},
+		{Key: "after", Type: StringType, String: "ok"},
+	}
+
+	tests := []struct {
+		desc string
+		enc  Encoder
+		want string
+	}{
+		{
+			desc: "json",
+			enc:  NewJSONEncoder(EncoderConfig{MessageKey: "msg", NewReflectedEncoder: StrictReflectedEncoder}),
+			want: `{"msg":"hello","objError":"reflected values are disabled","after":"ok"}` + "\n",
+		},
+		{
+			desc: "console",
+			enc:  NewConsoleEncoder(EncoderConfig{MessageKey: "msg", NewReflectedEncoder: StrictReflectedEncoder}),
+			want: `hello	{"objError": "reflected values are disabled", "after": "ok"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			buf, err := tt.enc.EncodeEntry(Entry{Message: "hello"}, fields)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String(), "Expected reflected fields to be rejected.")
+			buf.Free()
+		})
+	}
+
+	assert.Equal(t, ErrReflectionDisabled, StrictReflectedEncoder(io.Discard).Encode(1), "Unexpected error.")
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+const (
+	_defaultRetroWindow     = time.Minute
+	_defaultRetroMaxEntries = 100
+)
+
+// RetroPolicy configures a core built with NewRetroCore.
+type RetroPolicy struct {
+	// Trigger decides which levels flush the buffered entries of their
+	// scope. Entries at other levels are buffered rather than written.
+	//
+	// Defaults to ErrorLevel and above if unspecified.
+	Trigger LevelEnabler
+
+	// ScopeKey is the key of the field that identifies an entry's scope,
+	// such as a request ID. The field may be added with With or when
+	// logging. Entries without it share a single scope.
+	//
+	// Defaults to a single scope for all entries if unspecified.
+	ScopeKey string
+
+	// Window is how far back from a trigger entry buffered entries are
+	// still written. Older entries are discarded.
+	//
+	// Defaults to one minute if unspecified.
+	Window time.Duration
+
+	// MaxEntries is the number of entries buffered per scope. Once a scope
+	// is full, its oldest entries are discarded.
+	//
+	// Defaults to 100 if unspecified.
+	MaxEntries int
+}
+
+// NewRetroCore wraps a core so that entries at levels that don't trigger a
+// flush are held back, and written only if a trigger entry follows in the
+// same scope. When that happens, the scope's buffered entries from within
+// the policy's window are written in order, followed by the trigger entry;
+// otherwise, they're discarded as they age out. This gives full context for
+// failures at a fraction of the steady-state volume: wrap a Debug-level
+// core, and only the requests that end in errors are logged in detail.
+//
+// Windows are timed by the entries' own timestamps, and buffered entries
+// are discarded as later entries are written rather than by a timer. Sync
+// doesn't flush buffered entries.
+//
+// The wrapped core's Check method is bypassed, so it should be wrapped
+// around the core that does the encoding, and any sampling applied outside
+// of it.
+func NewRetroCore(core Core, policy RetroPolicy) Core {
+	if policy.Trigger == nil {
+		policy.Trigger = ErrorLevel
+	}
+	if policy.Window <= 0 {
+		policy.Window = _defaultRetroWindow
+	}
+	if policy.MaxEntries <= 0 {
+		policy.MaxEntries = _defaultRetroMaxEntries
+	}
+	c := &retroCore{
+		Core:   core,
+		policy: policy,
+		state:  &retroState{scopes: make(map[string]*retroScope)},
+	}
+	if policy.ScopeKey != "" {
+		c.scope, _ = retroScopeOf(AccumulatedFields(core), policy.ScopeKey)
+	}
+	return c
+}
+
+type retroCore struct {
+	Core
+
+	policy RetroPolicy
+	state  *retroState // shared with cores derived by With
+	scope  string      // scope set by With, if any
+}
+
+var (
+	_ Core           = (*retroCore)(nil)
+	_ leveledEnabler = (*retroCore)(nil)
+)
+
+// retroState holds the buffered entries of a retroCore and its clones.
+type retroState struct {
+	mu        sync.Mutex
+	scopes    map[string]*retroScope
+	nextSweep time.Time
+}
+
+// retroScope holds the buffered entries of one scope, oldest first.
+type retroScope struct {
+	entries []retroEntry
+}
+
+// retroEntry is a buffered entry and the core it was logged to.
+type retroEntry struct {
+	core   Core
+	ent    Entry
+	fields []Field
+}
+
+func (c *retroCore) Level() Level {
+	return LevelOf(c.Core)
+}
+
+func (c *retroCore) With(fields []Field) Core {
+	clone := *c
+	clone.Core = c.Core.With(fields)
+	if c.policy.ScopeKey != "" {
+		if scope, ok := retroScopeOf(fields, c.policy.ScopeKey); ok {
+			clone.scope = scope
+		}
+	}
+	return &clone
+}
+
+func (c *retroCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *retroCore) Write(ent Entry, fields []Field) error {
+	scope := c.scope
+	if c.policy.ScopeKey != "" {
+		if s, ok := retroScopeOf(fields, c.policy.ScopeKey); ok {
+			scope = s
+		}
+	}
+
+	s := c.state
+	s.mu.Lock()
+	s.sweep(ent.Time, c.policy.Window)
+	if !c.policy.Trigger.Enabled(ent.Level) {
+		s.buffer(scope, retroEntry{
+			core:   c.Core,
+			ent:    ent,
+			fields: append([]Field(nil), fields...),
+		}, c.policy.MaxEntries)
+		s.mu.Unlock()
+		return nil
+	}
+	var buffered []retroEntry
+	if sc, ok := s.scopes[scope]; ok {
+		buffered = sc.entries
+		delete(s.scopes, scope)
+	}
+	s.mu.Unlock()
+
+	var err error
+	cutoff := ent.Time.Add(-c.policy.Window)
+	for _, e := range buffered {
+		if e.ent.Time.Before(cutoff) {
+			continue
+		}
+		err = multierr.Append(err, e.core.Write(e.ent, e.fields))
+	}
+	return multierr.Append(err, c.Core.Write(ent, fields))
+}
+
+// buffer appends e to the given scope, discarding the scope's oldest entry
+// if it's full. The caller must hold s.mu.
+func (s *retroState) buffer(scope string, e retroEntry, max int) {
+	sc, ok := s.scopes[scope]
+	if !ok {
+		sc = &retroScope{}
+		s.scopes[scope] = sc
+	}
+	if len(sc.entries) >= max {
+		copy(sc.entries, sc.entries[1:])
+		sc.entries[len(sc.entries)-1] = e
+		return
+	}
+	sc.entries = append(sc.entries, e)
+}
+
+// sweep discards the scopes whose newest entry is older than window as of
+// now. It runs at most once per window. The caller must hold s.mu.
+func (s *retroState) sweep(now time.Time, window time.Duration) {
+	if now.Before(s.nextSweep) {
+		return
+	}
+	cutoff := now.Add(-window)
+	for key, sc := range s.scopes {
+		if sc.entries[len(sc.entries)-1].ent.Time.Before(cutoff) {
+			delete(s.scopes, key)
+		}
+	}
+	s.nextSweep = now.Add(window)
+}
+
+// retroScopeOf returns the value of the last field with the given key.
+func retroScopeOf(fields []Field, key string) (string, bool) {
+	for i := len(fields) - 1; i >= 0; i-- {
+		if fields[i].Key == key {
+			return fieldValueString(fields[i]), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func retroMessages(logs *observer.ObservedLogs) []string {
+	entries := logs.TakeAll()
+	msgs := make([]string, len(entries))
+	for i, e := range entries {
+		msgs[i] = e.Message
+	}
+	return msgs
+}
+
+func TestRetroCore(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+	core := NewRetroCore(obs, RetroPolicy{ScopeKey: "req"})
+
+	start := time.Unix(0, 0)
+	write := func(c Core, lvl Level, msg string, offset time.Duration, fields ...Field) {
+		ent := Entry{Level: lvl, Message: msg, Time: start.Add(offset)}
+		if ce := c.Check(ent, nil); ce != nil {
+			ce.Write(fields...)
+		}
+	}
+
+	req1 := core.With([]Field{makeInt64Field("req", 1)})
+	req2 := core.With([]Field{makeInt64Field("req", 2)})
+	write(req1, DebugLevel, "1: parsing", 0)
+	write(req2, DebugLevel, "2: parsing", 0)
+	write(req1, InfoLevel, "1: querying", time.Second)
+	write(core, WarnLevel, "2: slow", time.Second, makeInt64Field("req", 2))
+	write(core, InfoLevel, "unscoped", time.Second)
+	assert.Empty(t, logs.All(), "Expected entries below ErrorLevel to be buffered.")
+
+	write(req1, ErrorLevel, "1: failed", 2*time.Second)
+	assert.Equal(t, []string{"1: parsing", "1: querying", "1: failed"}, retroMessages(logs),
+		"Expected the failed request's history to be written.")
+
+	write(req1, ErrorLevel, "1: failed again", 3*time.Second)
+	assert.Equal(t, []string{"1: failed again"}, retroMessages(logs),
+		"Expected flushed entries to be written only once.")
+
+	write(req2, ErrorLevel, "2: failed", 3*time.Second)
+	entries := logs.TakeAll()
+	if assert.Len(t, entries, 3, "Expected the second request's history to be written.") {
+		assert.Equal(t, "2: slow", entries[1].Message, "Unexpected buffered entry.")
+		assert.Equal(t, []Field{makeInt64Field("req", 2)}, entries[1].Context,
+			"Expected buffered entries to keep their fields.")
+	}
+
+	write(core, DPanicLevel, "unscoped failure", 4*time.Second)
+	assert.Equal(t, []string{"unscoped", "unscoped failure"}, retroMessages(logs),
+		"Expected entries without a scope to share one.")
+}
+
+func TestRetroCoreWindow(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+	core := NewRetroCore(obs, RetroPolicy{Window: 10 * time.Second})
+
+	start := time.Unix(0, 0)
+	write := func(lvl Level, msg string, offset time.Duration) {
+		ent := Entry{Level: lvl, Message: msg, Time: start.Add(offset)}
+		if ce := core.Check(ent, nil); ce != nil {
+			ce.Write()
+		}
+	}
+
+	write(DebugLevel, "stale", 0)
+	write(DebugLevel, "recent", 5*time.Second)
+	write(ErrorLevel, "failed", 12*time.Second)
+	assert.Equal(t, []string{"recent", "failed"}, retroMessages(logs),
+		"Expected entries older than the window to be discarded.")
+
+	write(DebugLevel, "forgotten", 20*time.Second)
+	write(DebugLevel, "unrelated", 40*time.Second) // sweeps "forgotten"
+	write(ErrorLevel, "failed", 41*time.Second)
+	assert.Equal(t, []string{"unrelated", "failed"}, retroMessages(logs),
+		"Expected expired scopes to be swept.")
+}
+
+func TestRetroCoreMaxEntries(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+	core := NewRetroCore(obs, RetroPolicy{MaxEntries: 2, Trigger: WarnLevel})
+
+	for _, ent := range []Entry{
+		{Level: DebugLevel, Message: "a"},
+		{Level: DebugLevel, Message: "b"},
+		{Level: InfoLevel, Message: "c"},
+		{Level: WarnLevel, Message: "d"},
+	} {
+		if ce := core.Check(ent, nil); ce != nil {
+			ce.Write()
+		}
+	}
+	assert.Equal(t, []string{"b", "c", "d"}, retroMessages(logs),
+		"Expected only the newest entries to be buffered.")
+}
+
+func TestRetroCoreLevels(t *testing.T) {
+	obs, logs := observer.New(InfoLevel)
+	core := NewRetroCore(obs, RetroPolicy{})
+
+	assert.Equal(t, InfoLevel, LevelOf(core), "Unexpected level.")
+	assert.Nil(t, core.Check(Entry{Level: DebugLevel}, nil), "Expected disabled levels to be skipped.")
+	if ce := core.Check(Entry{Level: ErrorLevel, Message: "failed"}, nil); assert.NotNil(t, ce, "Expected enabled levels to be checked.") {
+		ce.Write()
+	}
+	assert.Equal(t, []string{"failed"}, retroMessages(logs), "Unexpected entries.")
+	assert.NoError(t, core.Sync(), "Unexpected error syncing.")
+}
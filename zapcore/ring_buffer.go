@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"io"
+	"sync/atomic"
+)
+
+// RingBuffer is a WriteSyncer that keeps only the most recently written
+// entries in memory, overwriting the oldest ones as new entries arrive. It's
+// meant to be used as a "flight recorder": tee a Debug-level core writing to
+// a RingBuffer alongside the Info-level core that's shipped normally, and
+// call Dump from a crash handler or debug endpoint to recover the detailed
+// history leading up to a failure.
+//
+// Each call to Write is treated as one entry, which matches how the cores in
+// this package write to their WriteSyncers. Writes and dumps never block
+// each other; concurrent writers claim slots in the ring with a single
+// atomic increment.
+type RingBuffer struct {
+	slots    []atomic.Pointer[ringEntry]
+	maxBytes int
+	next     atomic.Uint64 // sequence number of the next write
+}
+
+type ringEntry struct {
+	seq uint64
+	b   []byte
+}
+
+var _ WriteSyncer = (*RingBuffer)(nil)
+
+// NewRingBuffer builds a RingBuffer that retains the last maxEntries entries.
+//
+// If maxBytes is positive, Dump emits at most that many bytes of history:
+// it stops at the newest entries that fit, and entries larger than maxBytes
+// aren't retained at all. maxEntries must be positive.
+func NewRingBuffer(maxEntries, maxBytes int) *RingBuffer {
+	if maxEntries <= 0 {
+		panic("zapcore: NewRingBuffer requires a positive number of entries")
+	}
+	return &RingBuffer{
+		slots:    make([]atomic.Pointer[ringEntry], maxEntries),
+		maxBytes: maxBytes,
+	}
+}
+
+// Write records a copy of p as a single entry, evicting the oldest entry if
+// the ring is full. It never fails.
+func (r *RingBuffer) Write(p []byte) (int, error) {
+	if r.maxBytes > 0 && len(p) > r.maxBytes {
+		return len(p), nil
+	}
+	e := &ringEntry{b: append([]byte(nil), p...)}
+	e.seq = r.next.Add(1) - 1
+	r.slots[e.seq%uint64(len(r.slots))].Store(e)
+	return len(p), nil
+}
+
+// Sync is a no-op: a RingBuffer holds its entries in memory until they're
+// dumped.
+func (r *RingBuffer) Sync() error {
+	return nil
+}
+
+// Len reports the number of entries currently retained.
+func (r *RingBuffer) Len() int {
+	n := r.next.Load()
+	if n > uint64(len(r.slots)) {
+		return len(r.slots)
+	}
+	return int(n)
+}
+
+// Dump writes the retained entries to w, oldest first. It doesn't remove
+// them from the ring, so it may be called repeatedly.
+//
+// Dump is safe to call while other goroutines are writing, but entries that
+// are overwritten while it runs are skipped rather than reported out of
+// order.
+func (r *RingBuffer) Dump(w io.Writer) error {
+	entries := r.snapshot()
+	for i := len(entries) - 1; i >= 0; i-- {
+		if _, err := w.Write(entries[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshot collects the retained entries, newest first, stopping once the
+// byte budget is exhausted.
+func (r *RingBuffer) snapshot() [][]byte {
+	end := r.next.Load()
+	var start uint64
+	if size := uint64(len(r.slots)); end > size {
+		start = end - size
+	}
+
+	entries := make([][]byte, 0, end-start)
+	var total int
+	for seq := end; seq > start; seq-- {
+		e := r.slots[(seq-1)%uint64(len(r.slots))].Load()
+		if e == nil || e.seq != seq-1 {
+			// The slot hasn't been filled yet, or a newer write has already
+			// replaced the entry.
+			continue
+		}
+		if r.maxBytes > 0 && total+len(e.b) > r.maxBytes {
+			break
+		}
+		total += len(e.b)
+		entries = append(entries, e.b)
+	}
+	return entries
+}
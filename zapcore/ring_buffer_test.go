@@ -0,0 +1,145 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dumpString(t *testing.T, r *RingBuffer) string {
+	var buf bytes.Buffer
+	require.NoError(t, r.Dump(&buf), "Unexpected error dumping ring buffer.")
+	return buf.String()
+}
+
+func TestRingBufferKeepsLastEntries(t *testing.T) {
+	r := NewRingBuffer(3, 0)
+	assert.Equal(t, 0, r.Len(), "Expected an empty ring.")
+	assert.Empty(t, dumpString(t, r), "Expected an empty dump.")
+
+	for i := 0; i < 5; i++ {
+		n, err := r.Write([]byte(fmt.Sprintf("%d\n", i)))
+		require.NoError(t, err, "Unexpected error writing to ring buffer.")
+		assert.Equal(t, 2, n, "Unexpected number of bytes written.")
+	}
+	assert.NoError(t, r.Sync(), "Unexpected error syncing ring buffer.")
+	assert.Equal(t, 3, r.Len(), "Expected a full ring.")
+	assert.Equal(t, "2\n3\n4\n", dumpString(t, r), "Unexpected dump.")
+	assert.Equal(t, "2\n3\n4\n", dumpString(t, r), "Expected dumps to be repeatable.")
+}
+
+func TestRingBufferCopiesWrites(t *testing.T) {
+	r := NewRingBuffer(2, 0)
+	p := []byte("foo\n")
+	_, err := r.Write(p)
+	require.NoError(t, err, "Unexpected error writing to ring buffer.")
+	copy(p, "bar\n")
+	assert.Equal(t, "foo\n", dumpString(t, r), "Expected the ring to keep a copy of each write.")
+}
+
+func TestRingBufferMaxBytes(t *testing.T) {
+	r := NewRingBuffer(10, 8)
+	for _, s := range []string{"aaa\n", "bbb\n", "ccc\n", "this is too long\n"} {
+		_, err := r.Write([]byte(s))
+		require.NoError(t, err, "Unexpected error writing to ring buffer.")
+	}
+	assert.Equal(t, 3, r.Len(), "Expected oversized entries to be dropped.")
+	assert.Equal(t, "bbb\nccc\n", dumpString(t, r), "Expected the dump to keep the newest entries within the byte budget.")
+}
+
+func TestRingBufferDumpError(t *testing.T) {
+	r := NewRingBuffer(2, 0)
+	_, err := r.Write([]byte("foo\n"))
+	require.NoError(t, err, "Unexpected error writing to ring buffer.")
+	failErr := errors.New("fail")
+	assert.Equal(t, failErr, r.Dump(&toggleWriter{err: failErr}), "Expected Dump to return the writer's error.")
+}
+
+func TestRingBufferPanicsWithoutEntries(t *testing.T) {
+	assert.Panics(t, func() { NewRingBuffer(0, 0) }, "Expected a panic with no room for entries.")
+}
+
+func TestRingBufferConcurrency(t *testing.T) {
+	const (
+		writers = 8
+		writes  = 1000
+	)
+	r := NewRingBuffer(64, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < writes; j++ {
+				_, _ = r.Write([]byte(fmt.Sprintf("%d-%d\n", i, j)))
+			}
+		}(i)
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_ = r.Dump(&bytes.Buffer{})
+		}
+	}()
+	wg.Wait()
+	<-done
+
+	assert.Equal(t, 64, r.Len(), "Expected a full ring.")
+	assert.Equal(t, 64, bytes.Count([]byte(dumpString(t, r)), []byte("\n")), "Expected every slot in the dump.")
+}
+
+func TestRingBufferFlightRecorder(t *testing.T) {
+	var shipped bytes.Buffer
+	ring := NewRingBuffer(10, 0)
+	enc := NewJSONEncoder(EncoderConfig{MessageKey: "msg", LevelKey: "level", EncodeLevel: LowercaseLevelEncoder})
+	core := NewTee(
+		NewCore(enc, AddSync(&shipped), InfoLevel),
+		NewCore(enc.Clone(), ring, DebugLevel),
+	)
+
+	for _, ent := range []Entry{
+		{Level: DebugLevel, Message: "connecting"},
+		{Level: InfoLevel, Message: "connected"},
+		{Level: DebugLevel, Message: "sent request"},
+	} {
+		if ce := core.Check(ent, nil); ce != nil {
+			ce.Write()
+		}
+	}
+
+	assert.Equal(t, `{"level":"info","msg":"connected"}`+"\n", shipped.String(), "Unexpected shipped output.")
+	assert.Equal(t,
+		`{"level":"debug","msg":"connecting"}`+"\n"+
+			`{"level":"info","msg":"connected"}`+"\n"+
+			`{"level":"debug","msg":"sent request"}`+"\n",
+		dumpString(t, ring),
+		"Expected the ring to keep the Debug-level history.",
+	)
+}
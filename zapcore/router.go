@@ -0,0 +1,253 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"path"
+
+	"go.uber.org/multierr"
+)
+
+// Route is a destination for NewRouterCore, along with the predicates that
+// select which entries are sent to it. A route matches an entry if all of its
+// predicates do; unset predicates match everything.
+type Route struct {
+	// Core receives entries matching this route. It still applies its own
+	// level checks.
+	Core Core
+
+	// Level restricts the route to entries at levels it enables, e.g. a
+	// LevelEnablerFunc accepting a range of levels.
+	Level LevelEnabler
+
+	// LoggerName restricts the route to entries from loggers whose names
+	// match this pattern, using the syntax of path.Match. For example,
+	// "http.*" matches "http.server" and "http.client". Malformed patterns
+	// match nothing.
+	LoggerName string
+
+	// Field restricts the route to entries with at least one field, either
+	// bound to the logger with With or passed at the log site, for which it
+	// returns true.
+	//
+	// Field predicates can only be evaluated once the log site's fields are
+	// known, so routes using them are resolved when the entry is written.
+	Field func(Field) bool
+
+	// Continue makes entries that match this route fall through to the
+	// following routes, as if it hadn't matched. By default, an entry is sent
+	// to the first matching route only.
+	Continue bool
+}
+
+// matchesEntry reports whether the route's entry-level predicates match.
+func (r *Route) matchesEntry(ent Entry) bool {
+	if r.Level != nil && !r.Level.Enabled(ent.Level) {
+		return false
+	}
+	if r.LoggerName != "" {
+		if ok, err := path.Match(r.LoggerName, ent.LoggerName); err != nil || !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesFields reports whether the route's field predicate, if any, matches
+// the bound context or the log site's fields.
+func (r *Route) matchesFields(context, fields []Field) bool {
+	if r.Field == nil {
+		return true
+	}
+	for _, f := range context {
+		if r.Field(f) {
+			return true
+		}
+	}
+	for _, f := range fields {
+		if r.Field(f) {
+			return true
+		}
+	}
+	return false
+}
+
+type routerCore struct {
+	routes []Route
+
+	// Fields bound with With, for Field predicates. Only tracked if a route
+	// has one.
+	context     []Field
+	fieldRoutes bool
+}
+
+var (
+	_ Core           = (*routerCore)(nil)
+	_ leveledEnabler = (*routerCore)(nil)
+)
+
+// NewRouterCore creates a Core that sends each entry to the first of the
+// given routes that matches it. Entries that match no route are dropped; add
+// a final route with no predicates to catch everything else.
+//
+// Unlike combining NewTee with level-filtering cores, the router evaluates
+// each entry's destination once, and entries are only encoded by the cores
+// they're routed to.
+//
+//	core := zapcore.NewRouterCore(
+//	  zapcore.Route{LoggerName: "audit.*", Core: auditCore},
+//	  zapcore.Route{Level: zapcore.ErrorLevel, Core: alertCore, Continue: true},
+//	  zapcore.Route{Core: defaultCore},
+//	)
+func NewRouterCore(routes ...Route) Core {
+	rc := &routerCore{routes: routes}
+	for i := range routes {
+		if routes[i].Field != nil {
+			rc.fieldRoutes = true
+		}
+	}
+	return rc
+}
+
+func (rc *routerCore) Level() Level {
+	minLvl := InvalidLevel
+	for i := range rc.routes {
+		lvl := LevelOf(rc.routes[i].Core)
+		if r := rc.routes[i].Level; r != nil {
+			if rlvl := LevelOf(r); rlvl > lvl {
+				lvl = rlvl
+			}
+		}
+		if minLvl == InvalidLevel || lvl < minLvl {
+			minLvl = lvl
+		}
+	}
+	return minLvl
+}
+
+func (rc *routerCore) Enabled(lvl Level) bool {
+	for i := range rc.routes {
+		r := &rc.routes[i]
+		if (r.Level == nil || r.Level.Enabled(lvl)) && r.Core.Enabled(lvl) {
+			return true
+		}
+	}
+	return false
+}
+
+func (rc *routerCore) With(fields []Field) Core {
+	clone := &routerCore{
+		routes:      make([]Route, len(rc.routes)),
+		fieldRoutes: rc.fieldRoutes,
+	}
+	copy(clone.routes, rc.routes)
+	for i := range clone.routes {
+		clone.routes[i].Core = clone.routes[i].Core.With(fields)
+	}
+	if rc.fieldRoutes {
+		clone.context = append(rc.context[:len(rc.context):len(rc.context)], fields...)
+	}
+	return clone
+}
+
+func (rc *routerCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	routed := false
+	for i := range rc.routes {
+		r := &rc.routes[i]
+		if !r.matchesEntry(ent) {
+			continue
+		}
+		if r.Field != nil {
+			// The rest of the decision depends on the log site's fields.
+			return ce.AddCore(ent, &deferredRouterCore{router: rc, from: i})
+		}
+		routed = true
+		ce = r.Core.Check(ent, ce)
+		if !r.Continue {
+			break
+		}
+	}
+	if !routed {
+		ent.Trace.record(rc, TraceDropped, "no matching route")
+	}
+	return ce
+}
+
+func (rc *routerCore) Write(ent Entry, fields []Field) error {
+	// Check never adds the router itself to a CheckedEntry, but Write must
+	// still route correctly if called directly.
+	return (&deferredRouterCore{router: rc}).Write(ent, fields)
+}
+
+func (rc *routerCore) Sync() error {
+	var err error
+	for i := range rc.routes {
+		err = multierr.Append(err, rc.routes[i].Core.Sync())
+	}
+	return err
+}
+
+// deferredRouterCore finishes routing an entry at write time, starting from
+// the first route with a Field predicate.
+type deferredRouterCore struct {
+	router *routerCore
+	from   int
+}
+
+func (d *deferredRouterCore) Enabled(Level) bool       { return true }
+func (d *deferredRouterCore) With(fields []Field) Core { return d.router.With(fields) }
+func (d *deferredRouterCore) Sync() error              { return d.router.Sync() }
+
+func (d *deferredRouterCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	return ce.AddCore(ent, d)
+}
+
+func (d *deferredRouterCore) Write(ent Entry, fields []Field) error {
+	var ce *CheckedEntry
+	routed := false
+	routes := d.router.routes
+	for i := d.from; i < len(routes); i++ {
+		r := &routes[i]
+		if !r.matchesEntry(ent) || !r.matchesFields(d.router.context, fields) {
+			continue
+		}
+		routed = true
+		ce = r.Core.Check(ent, ce)
+		if !r.Continue {
+			break
+		}
+	}
+	if !routed {
+		ent.Trace.record(d.router, TraceDropped, "no matching route")
+	}
+	if ce == nil {
+		return nil
+	}
+
+	var err error
+	for i := range ce.cores {
+		werr := ce.cores[i].Write(ent, fields)
+		ent.Trace.recordWrite(ce.cores[i], werr)
+		err = multierr.Append(err, werr)
+	}
+	putCheckedEntry(ce)
+	return err
+}
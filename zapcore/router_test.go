@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRouterCore(t *testing.T) {
+	audit, auditLogs := observer.New(DebugLevel)
+	alerts, alertLogs := observer.New(DebugLevel)
+	tenant, tenantLogs := observer.New(DebugLevel)
+	fallback, fallbackLogs := observer.New(InfoLevel)
+
+	core := NewRouterCore(
+		Route{LoggerName: "audit.*", Core: audit},
+		Route{Level: ErrorLevel, Core: alerts, Continue: true},
+		Route{
+			Field: func(f Field) bool { return f.Key == "tenant" && f.String == "acme" },
+			Core:  tenant,
+		},
+		Route{Core: fallback},
+	)
+
+	write := func(c Core, ent Entry, fields ...Field) {
+		if ce := c.Check(ent, nil); ce != nil {
+			ce.Write(fields...)
+		}
+	}
+	acme := Field{Key: "tenant", Type: StringType, String: "acme"}
+
+	write(core, Entry{LoggerName: "audit.login", Level: ErrorLevel, Message: "audit"})
+	write(core, Entry{Level: ErrorLevel, Message: "error"})
+	write(core, Entry{Level: InfoLevel, Message: "site field"}, acme)
+	write(core.With([]Field{acme}), Entry{Level: InfoLevel, Message: "bound field"})
+	write(core, Entry{Level: InfoLevel, Message: "plain"})
+	write(core, Entry{Level: DebugLevel, Message: "debug"})
+
+	assert.Equal(t, []string{"audit"}, messages(auditLogs), "Unexpected audit entries.")
+	assert.Equal(t, []string{"error"}, messages(alertLogs), "Unexpected alert entries.")
+	assert.Equal(t, []string{"site field", "bound field"}, messages(tenantLogs), "Unexpected tenant entries.")
+	assert.Equal(t, []string{"error", "plain"}, messages(fallbackLogs), "Unexpected fallback entries.")
+
+	assert.True(t, core.Enabled(DebugLevel), "Expected debug to be enabled by the tenant route.")
+	assert.Equal(t, DebugLevel, LevelOf(core), "Unexpected router level.")
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+}
+
+func TestRouterCoreNoRoutes(t *testing.T) {
+	core := NewRouterCore()
+	assert.False(t, core.Enabled(FatalLevel), "Expected no levels to be enabled.")
+	assert.Equal(t, InvalidLevel, LevelOf(core), "Unexpected router level.")
+	assert.Nil(t, core.Check(Entry{Level: FatalLevel}, nil), "Expected entries to be dropped.")
+}
+
+func TestRouterCoreMalformedPattern(t *testing.T) {
+	dest, logs := observer.New(DebugLevel)
+	core := NewRouterCore(Route{LoggerName: "[", Core: dest})
+	assert.Nil(t, core.Check(Entry{LoggerName: "["}, nil), "Expected malformed pattern to match nothing.")
+	assert.Zero(t, logs.Len(), "Unexpected entries.")
+}
+
+func messages(logs *observer.ObservedLogs) []string {
+	var msgs []string
+	for _, entry := range logs.AllUntimed() {
+		msgs = append(msgs, entry.Message)
+	}
+	return msgs
+}
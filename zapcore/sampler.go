@@ -124,6 +124,79 @@ func SamplerHook(hook func(entry Entry, dec SamplingDecision)) SamplerOption {
 	})
 }
 
+// SamplingInfo describes a decision made by a Sampler. It's passed to hooks
+// registered with SamplerDecisionHook. More details may be added to it over
+// time, so hooks shouldn't construct or compare it as a whole.
+type SamplingInfo struct {
+	// Entry is the entry that was sampled or dropped.
+	Entry Entry
+	// Decision is the decision the Sampler made.
+	Decision SamplingDecision
+	// Key is the bucket the entry was counted under: its message, unless a
+	// keyer was set with SamplerWithKeyer.
+	Key string
+	// Count is the number of entries counted under Key at the entry's level
+	// in the current tick, including this one.
+	Count uint64
+	// Fields holds the fields bound to the Sampler with With, including
+	// those bound to the wrapped core beforehand if it reports them (see
+	// AccumulatedFields). Fields passed at the log site aren't known when
+	// sampling decisions are made. The slice is shared and must not be
+	// modified or retained.
+	Fields []Field
+}
+
+// SamplerDecisionHook registers a function which will be called when Sampler
+// makes a decision, with the entry's bucket key, counter value, and bound
+// fields in addition to the entry and decision.
+//
+// Use it to attribute dropped volume to the bucket that was suppressed, or to
+// summarize dropped logs by the context that was most suppressed, such as
+// tenant or endpoint:
+//
+//	zapcore.SamplerDecisionHook(func(info zapcore.SamplingInfo) {
+//	  if info.Decision&zapcore.LogDropped == 0 {
+//	    return
+//	  }
+//	  for _, f := range info.Fields {
+//	    if f.Key == "tenant_id" {
+//	      suppressed.Add(f.String, 1)
+//	    }
+//	  }
+//	})
+//
+// The hook runs on every sampling decision, so it should be fast.
+func SamplerDecisionHook(hook func(SamplingInfo)) SamplerOption {
+	return optionFunc(func(s *sampler) {
+		s.decisionHook = hook
+	})
+}
+
+// SamplerWithKeyer changes how the Sampler groups entries. By default,
+// entries are counted per level and message; with a keyer, they're counted
+// per level and whatever string the keyer returns.
+//
+// The keyer receives the entry and the fields bound to the Sampler with With,
+// including those bound to the wrapped core beforehand if it reports them
+// (see AccumulatedFields), so entries can be sampled per tenant, endpoint, or
+// any other piece of context:
+//
+//	zapcore.SamplerWithKeyer(func(ent zapcore.Entry, fields []zapcore.Field) string {
+//	  for _, f := range fields {
+//	    if f.Key == "tenant_id" {
+//	      return f.String
+//	    }
+//	  }
+//	  return ent.Message
+//	})
+//
+// The keyer runs on every sampled level, so it should be cheap.
+func SamplerWithKeyer(keyer func(Entry, []Field) string) SamplerOption {
+	return optionFunc(func(s *sampler) {
+		s.keyer = keyer
+	})
+}
+
 // NewSamplerWithOptions creates a Core that samples incoming entries, which
 // caps the CPU and I/O load of logging while attempting to preserve a
 // representative subset of your logs.
@@ -144,7 +217,8 @@ func SamplerHook(hook func(entry Entry, dec SamplingDecision)) SamplerOption {
 // in that interval.
 //
 // Sampler can be configured to report sampling decisions with the SamplerHook
-// option.
+// and SamplerDecisionHook options, and to group entries by something other
+// than their message with the SamplerWithKeyer option.
 //
 // Keep in mind that Zap's sampling implementation is optimized for speed over
 // absolute precision; under load, each tick may be slightly over- or
@@ -161,6 +235,10 @@ func NewSamplerWithOptions(core Core, tick time.Duration, first, thereafter int,
 	for _, opt := range opts {
 		opt.apply(s)
 	}
+	if s.tracksFields() {
+		// Include fields bound before the sampler was applied.
+		s.fields = AccumulatedFields(core)
+	}
 
 	return s
 }
@@ -172,6 +250,12 @@ type sampler struct {
 	tick              time.Duration
 	first, thereafter uint64
 	hook              func(Entry, SamplingDecision)
+	decisionHook      func(SamplingInfo)
+
+	// keyer and the fields it's called with. fields is only tracked if keyer
+	// or decisionHook is set.
+	keyer  func(Entry, []Field) string
+	fields []Field
 }
 
 var (
@@ -201,29 +285,67 @@ func (s *sampler) Level() Level {
 }
 
 func (s *sampler) With(fields []Field) Core {
-	return &sampler{
-		Core:       s.Core.With(fields),
-		tick:       s.tick,
-		counts:     s.counts,
-		first:      s.first,
-		thereafter: s.thereafter,
-		hook:       s.hook,
+	clone := &sampler{
+		Core:         s.Core.With(fields),
+		tick:         s.tick,
+		counts:       s.counts,
+		first:        s.first,
+		thereafter:   s.thereafter,
+		hook:         s.hook,
+		keyer:        s.keyer,
+		decisionHook: s.decisionHook,
+	}
+	if s.tracksFields() {
+		// Clip the capacity so siblings never share a backing array.
+		clone.fields = append(s.fields[:len(s.fields):len(s.fields)], fields...)
+	}
+	return clone
+}
+
+// tracksFields reports whether the sampler keeps the fields bound to it.
+func (s *sampler) tracksFields() bool {
+	return s.keyer != nil || s.decisionHook != nil
+}
+
+func (s *sampler) AccumulatedFields() []Field {
+	if s.tracksFields() {
+		return s.fields
 	}
+	return AccumulatedFields(s.Core)
 }
 
 func (s *sampler) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
 	if !s.Enabled(ent.Level) {
+		ent.Trace.record(s, TraceRejected, "")
 		return ce
 	}
 
 	if ent.Level >= _minLevel && ent.Level <= _maxLevel {
-		counter := s.counts.get(ent.Level, ent.Message)
+		key := ent.Message
+		if s.keyer != nil {
+			key = s.keyer(ent, s.fields)
+		}
+		counter := s.counts.get(ent.Level, key)
 		n := counter.IncCheckReset(ent.Time, s.tick)
 		if n > s.first && (s.thereafter == 0 || (n-s.first)%s.thereafter != 0) {
-			s.hook(ent, LogDropped)
+			s.report(ent, key, n, LogDropped)
+			ent.Trace.record(s, TraceDropped, "sampled out")
 			return ce
 		}
-		s.hook(ent, LogSampled)
+		s.report(ent, key, n, LogSampled)
 	}
 	return s.Core.Check(ent, ce)
 }
+
+func (s *sampler) report(ent Entry, key string, n uint64, dec SamplingDecision) {
+	s.hook(ent, dec)
+	if s.decisionHook != nil {
+		s.decisionHook(SamplingInfo{
+			Entry:    ent,
+			Decision: dec,
+			Key:      key,
+			Count:    n,
+			Fields:   s.fields,
+		})
+	}
+}
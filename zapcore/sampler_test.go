@@ -272,7 +272,7 @@ func TestSamplerRaces(t *testing.T) {
 func TestSamplerUnknownLevels(t *testing.T) {
 	// Prove that out-of-bounds levels don't panic.
 	unknownLevels := []Level{
-		DebugLevel - 1,
+		TraceLevel - 1,
 		FatalLevel + 1,
 	}
 
@@ -327,3 +327,106 @@ func TestSamplerWithZeroThereafter(t *testing.T) {
 	assert.Equal(t, 4, int(counter.logs.Load()),
 		"Unexpected number of logs")
 }
+
+func TestSamplerWithKeyer(t *testing.T) {
+	core, logs := observer.New(DebugLevel)
+	keyer := func(ent Entry, fields []Field) string {
+		for _, f := range fields {
+			if f.Key == "tenant" {
+				return f.String
+			}
+		}
+		return ent.Message
+	}
+
+	dropped := make(map[string]int)
+	sampler := NewSamplerWithOptions(core, time.Minute, 1, 0,
+		SamplerWithKeyer(keyer),
+		SamplerDecisionHook(func(info SamplingInfo) {
+			if info.Decision&LogDropped > 0 {
+				dropped[info.Key]++
+			}
+		}),
+	)
+
+	// Messages are identical, so without the keyer only one entry would get
+	// through.
+	now := time.Now()
+	for _, tenant := range []string{"a", "b", "a", "c", "b", "a"} {
+		child := sampler.With([]Field{makeInt64Field("iter", 1)}).
+			With([]Field{{Key: "tenant", Type: StringType, String: tenant}})
+		if ce := child.Check(Entry{Level: InfoLevel, Message: "msg", Time: now}, nil); ce != nil {
+			ce.Write()
+		}
+	}
+
+	var tenants []string
+	for _, entry := range logs.TakeAll() {
+		tenants = append(tenants, entry.ContextMap()["tenant"].(string))
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, tenants, "Expected one entry per tenant.")
+	assert.Equal(t, map[string]int{"a": 2, "b": 1}, dropped, "Unexpected dropped counts per key.")
+}
+
+func TestSamplerDecisionHookKeyDefaultsToMessage(t *testing.T) {
+	var keys []string
+	sampler := NewSamplerWithOptions(&countingCore{}, time.Minute, 1, 0,
+		SamplerDecisionHook(func(info SamplingInfo) {
+			keys = append(keys, info.Key)
+		}),
+	)
+	for _, msg := range []string{"foo", "bar"} {
+		sampler.Check(Entry{Level: InfoLevel, Message: msg, Time: time.Now()}, nil)
+	}
+	assert.Equal(t, []string{"foo", "bar"}, keys, "Expected keys to default to messages.")
+}
+
+func TestSamplerWithKeyerSeesFieldsBoundBeforeWrapping(t *testing.T) {
+	core, logs := observer.New(DebugLevel)
+	core = core.With([]Field{{Key: "tenant", Type: StringType, String: "a"}})
+
+	var keys []string
+	sampler := NewSamplerWithOptions(core, time.Minute, 1, 0,
+		SamplerWithKeyer(func(_ Entry, fields []Field) string {
+			var key string
+			for _, f := range fields {
+				key += f.Key + "=" + f.String + ";"
+			}
+			keys = append(keys, key)
+			return key
+		}),
+	)
+	child := sampler.With([]Field{{Key: "endpoint", Type: StringType, String: "/"}})
+	if ce := child.Check(Entry{Level: InfoLevel, Time: time.Now()}, nil); ce != nil {
+		ce.Write()
+	}
+
+	assert.Equal(t, []string{"tenant=a;endpoint=/;"}, keys, "Expected keyer to see all bound fields.")
+	assert.Equal(t, 1, logs.Len(), "Expected entry to be logged.")
+}
+
+func TestSamplerDecisionHook(t *testing.T) {
+	var infos []SamplingInfo
+	sampler := NewSamplerWithOptions(&countingCore{}, time.Minute, 1, 2,
+		SamplerDecisionHook(func(info SamplingInfo) {
+			infos = append(infos, info)
+		}),
+	)
+	tenant := Field{Key: "tenant", Type: StringType, String: "a"}
+	child := sampler.With([]Field{tenant})
+
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		child.Check(Entry{Level: InfoLevel, Message: "msg", Time: now}, nil)
+	}
+
+	require.Len(t, infos, 3, "Expected a decision per entry.")
+	for i, dec := range []SamplingDecision{LogSampled, LogDropped, LogSampled} {
+		info := infos[i]
+		assert.Equal(t, dec, info.Decision, "Unexpected decision for entry %d.", i)
+		assert.Equal(t, "msg", info.Key, "Expected key to default to the message.")
+		assert.Equal(t, uint64(i+1), info.Count, "Unexpected counter value for entry %d.", i)
+		assert.Equal(t, []Field{tenant}, info.Fields, "Expected bound fields.")
+		assert.Equal(t, "msg", info.Entry.Message, "Unexpected entry.")
+	}
+}
@@ -0,0 +1,288 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/multierr"
+)
+
+// A Schema declares the fields that entries must, and must not, carry. Keys
+// are compared with the fields bound to a logger with With as well as those
+// passed at the log site. Keys of fields inside namespaces are prefixed with
+// the namespace path, as in "http.status".
+type Schema struct {
+	// Required lists the keys that every entry must have, such as service,
+	// env, or trace_id.
+	Required []string
+
+	// Forbidden lists the keys that entries must not have, such as password.
+	Forbidden []string
+
+	// Types constrains the types of fields with the given keys. A field
+	// with one of the keys must have one of the listed types; for example,
+	// {"user_id": {StringType}}. Keys that aren't present aren't checked.
+	Types map[string][]FieldType
+}
+
+// SchemaAction selects what a core built by NewSchemaCore does with entries
+// that violate its schema.
+type SchemaAction uint8
+
+const (
+	// SchemaAnnotate writes entries that violate the schema with an added
+	// schemaViolations field listing the violations.
+	SchemaAnnotate SchemaAction = iota
+	// SchemaDrop drops entries that violate the schema.
+	SchemaDrop
+	// SchemaPanic writes entries that violate the schema, annotated as with
+	// SchemaAnnotate, and then panics with a *SchemaError. It's intended
+	// for development and tests, like DPanicLevel.
+	SchemaPanic
+)
+
+// String returns a lower-case name for the action.
+func (a SchemaAction) String() string {
+	switch a {
+	case SchemaAnnotate:
+		return "annotate"
+	case SchemaDrop:
+		return "drop"
+	case SchemaPanic:
+		return "panic"
+	default:
+		return fmt.Sprintf("SchemaAction(%d)", a)
+	}
+}
+
+// A SchemaError lists the ways an entry violates a Schema.
+type SchemaError struct {
+	Entry      Entry
+	Violations []string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("entry %q violates schema: %s", e.Entry.Message, strings.Join(e.Violations, "; "))
+}
+
+// SchemaOption configures a core built by NewSchemaCore.
+type SchemaOption interface {
+	apply(*schemaCore)
+}
+
+type schemaOptionFunc func(*schemaCore)
+
+func (f schemaOptionFunc) apply(c *schemaCore) {
+	f(c)
+}
+
+// SchemaHook registers a function called with every entry that violates the
+// schema, whatever the action, so that violations can be counted or
+// reported.
+func SchemaHook(hook func(*SchemaError)) SchemaOption {
+	return schemaOptionFunc(func(c *schemaCore) {
+		c.hook = hook
+	})
+}
+
+type schemaCore struct {
+	Core
+
+	schema    Schema
+	forbidden map[string]struct{}
+	action    SchemaAction
+	hook      func(*SchemaError)
+	context   []Field // bound with With, including those bound before wrapping
+}
+
+var (
+	_ Core             = (*schemaCore)(nil)
+	_ leveledEnabler   = (*schemaCore)(nil)
+	_ fieldAccumulator = (*schemaCore)(nil)
+)
+
+// NewSchemaCore wraps a Core so that entries are validated against schema,
+// and those that violate it are handled according to action. For example,
+// this requires every entry to identify its service and environment:
+//
+//	core = zapcore.NewSchemaCore(core, zapcore.Schema{
+//	  Required:  []string{"service", "env"},
+//	  Forbidden: []string{"password"},
+//	  Types:     map[string][]zapcore.FieldType{"env": {zapcore.StringType}},
+//	}, zapcore.SchemaAnnotate)
+//
+// Entries are validated when they're written, once the log site's fields
+// are known.
+func NewSchemaCore(core Core, schema Schema, action SchemaAction, opts ...SchemaOption) Core {
+	c := &schemaCore{
+		Core:      core,
+		schema:    schema,
+		forbidden: make(map[string]struct{}, len(schema.Forbidden)),
+		action:    action,
+		context:   AccumulatedFields(core),
+	}
+	for _, k := range schema.Forbidden {
+		c.forbidden[k] = struct{}{}
+	}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+func (c *schemaCore) Level() Level {
+	return LevelOf(c.Core)
+}
+
+func (c *schemaCore) AccumulatedFields() []Field {
+	return c.context
+}
+
+func (c *schemaCore) With(fields []Field) Core {
+	clone := *c
+	clone.Core = c.Core.With(fields)
+	// Clip the capacity so siblings never share a backing array.
+	clone.context = append(c.context[:len(c.context):len(c.context)], fields...)
+	return &clone
+}
+
+func (c *schemaCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		// The entry can only be validated once its fields are known.
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *schemaCore) Write(ent Entry, fields []Field) error {
+	violations := c.validate(fields)
+	if len(violations) == 0 {
+		return c.write(ent, fields)
+	}
+
+	serr := &SchemaError{Entry: ent, Violations: violations}
+	if c.hook != nil {
+		c.hook(serr)
+	}
+	if c.action == SchemaDrop {
+		return nil
+	}
+
+	annotated := make([]Field, 0, len(fields)+1)
+	annotated = append(annotated, Field{
+		Key:       "schemaViolations",
+		Type:      ArrayMarshalerType,
+		Interface: stringArray(violations),
+	})
+	// Put the annotation first, outside any namespace the fields open.
+	annotated = append(annotated, fields...)
+	err := c.write(ent, annotated)
+	if c.action == SchemaPanic {
+		panic(serr)
+	}
+	return err
+}
+
+// write writes the entry to the wrapped core, which is checked first.
+func (c *schemaCore) write(ent Entry, fields []Field) error {
+	ce := c.Core.Check(ent, nil)
+	if ce == nil {
+		return nil
+	}
+
+	var err error
+	for i := range ce.cores {
+		err = multierr.Append(err, ce.cores[i].Write(ent, fields))
+	}
+	putCheckedEntry(ce)
+	return err
+}
+
+// validate returns the ways the bound context and fields violate the
+// schema.
+func (c *schemaCore) validate(fields []Field) []string {
+	var (
+		violations []string
+		seen       map[string]struct{}
+	)
+	if len(c.schema.Required) > 0 {
+		seen = make(map[string]struct{}, len(c.context)+len(fields))
+	}
+	for _, fs := range [2][]Field{c.context, fields} {
+		var (
+			scope  string   // enclosing namespaces, each followed by "."
+			scopes []string // scopes enclosing the current one, innermost last
+		)
+		for _, f := range fs {
+			switch f.Type {
+			case SkipType:
+				continue
+			case CloseNamespaceType:
+				if n := len(scopes); n > 0 {
+					scope, scopes = scopes[n-1], scopes[:n-1]
+				}
+				continue
+			}
+
+			key := scope + f.Key
+			if seen != nil {
+				seen[key] = struct{}{}
+			}
+			if _, ok := c.forbidden[key]; ok {
+				violations = append(violations, fmt.Sprintf("forbidden key %q", key))
+			}
+			if types, ok := c.schema.Types[key]; ok && !hasFieldType(types, f.Type) {
+				violations = append(violations, fmt.Sprintf("key %q has the wrong type", key))
+			}
+			if f.Type == NamespaceType {
+				scopes = append(scopes, scope)
+				scope = key + "."
+			}
+		}
+	}
+	for _, k := range c.schema.Required {
+		if _, ok := seen[k]; !ok {
+			violations = append(violations, fmt.Sprintf("missing required key %q", k))
+		}
+	}
+	return violations
+}
+
+func hasFieldType(types []FieldType, t FieldType) bool {
+	for _, want := range types {
+		if t == want {
+			return true
+		}
+	}
+	return false
+}
+
+// stringArray is an ArrayMarshaler for a slice of strings.
+type stringArray []string
+
+func (ss stringArray) MarshalLogArray(arr ArrayEncoder) error {
+	for _, s := range ss {
+		arr.AppendString(s)
+	}
+	return nil
+}
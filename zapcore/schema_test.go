@@ -0,0 +1,129 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSchemaCore(t *testing.T) {
+	str := func(key, val string) Field { return Field{Key: key, Type: StringType, String: val} }
+	schema := Schema{
+		Required:  []string{"service", "req.id"},
+		Forbidden: []string{"password"},
+		Types:     map[string][]FieldType{"env": {StringType}},
+	}
+
+	tests := []struct {
+		desc       string
+		fields     []Field
+		violations []string
+	}{
+		{
+			desc:   "valid",
+			fields: []Field{str("env", "prod"), {Key: "req", Type: NamespaceType}, str("id", "1")},
+		},
+		{
+			desc:   "missing and forbidden",
+			fields: []Field{str("password", "hunter2"), str("id", "1")},
+			violations: []string{
+				`forbidden key "password"`,
+				`missing required key "req.id"`,
+			},
+		},
+		{
+			desc: "wrong type after closed namespace",
+			fields: []Field{
+				{Key: "req", Type: NamespaceType},
+				str("id", "1"),
+				{Type: CloseNamespaceType},
+				{Key: "env", Type: Int64Type, Integer: 1},
+			},
+			violations: []string{`key "env" has the wrong type`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			for _, action := range []SchemaAction{SchemaAnnotate, SchemaDrop, SchemaPanic} {
+				obs, logs := observer.New(InfoLevel)
+				var reported []*SchemaError
+				core := NewSchemaCore(obs, schema, action, SchemaHook(func(err *SchemaError) {
+					reported = append(reported, err)
+				})).With([]Field{str("service", "api")})
+
+				assert.Nil(t, core.Check(Entry{Level: DebugLevel}, nil), "Expected disabled entries to be skipped.")
+				ce := core.Check(Entry{Level: InfoLevel, Message: "msg"}, nil)
+				require.NotNil(t, ce, "Expected enabled entry to be checked.")
+
+				if len(tt.violations) > 0 && action == SchemaPanic {
+					assert.Panics(t, func() { ce.Write(tt.fields...) }, "Expected %v to panic.", action)
+				} else {
+					ce.Write(tt.fields...)
+				}
+
+				if len(tt.violations) == 0 {
+					assert.Empty(t, reported, "Unexpected violations reported.")
+					require.Equal(t, 1, logs.Len(), "Expected entry to be written.")
+					assert.NotContains(t, logs.All()[0].ContextMap(), "schemaViolations", "Unexpected annotation.")
+					continue
+				}
+
+				require.Len(t, reported, 1, "Expected violations to be reported.")
+				assert.Equal(t, tt.violations, reported[0].Violations, "Unexpected violations.")
+				if action == SchemaDrop {
+					assert.Equal(t, 0, logs.Len(), "Expected entry to be dropped.")
+					continue
+				}
+				require.Equal(t, 1, logs.Len(), "Expected entry to be written.")
+				want := make([]interface{}, len(tt.violations))
+				for i, v := range tt.violations {
+					want[i] = v
+				}
+				assert.Equal(t, want, logs.All()[0].ContextMap()["schemaViolations"], "Unexpected annotation for %v.", action)
+			}
+		})
+	}
+}
+
+func TestSchemaCoreKeepsWrappedContext(t *testing.T) {
+	obs, logs := observer.New(InfoLevel)
+	core := NewSchemaCore(
+		obs.With([]Field{{Key: "service", Type: StringType, String: "api"}}),
+		Schema{Required: []string{"service"}},
+		SchemaDrop,
+	)
+	require.NoError(t, core.Write(Entry{Level: InfoLevel}, nil), "Unexpected write error.")
+	assert.Equal(t, 1, logs.Len(), "Expected fields bound before wrapping to satisfy the schema.")
+}
+
+func TestSchemaActionString(t *testing.T) {
+	assert.Equal(t, "annotate", SchemaAnnotate.String())
+	assert.Equal(t, "drop", SchemaDrop.String())
+	assert.Equal(t, "panic", SchemaPanic.String())
+	assert.Equal(t, "SchemaAction(9)", SchemaAction(9).String())
+}
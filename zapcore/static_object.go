@@ -0,0 +1,79 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import "sync"
+
+// staticObjectKey identifies the JSON encoders that encode a static object
+// the same way: those sharing an EncoderConfig and spacing.
+type staticObjectKey struct {
+	cfg    *EncoderConfig
+	spaced bool
+}
+
+type staticObject struct {
+	fields []Field
+
+	// encoded caches the object's JSON encoding, without braces, by
+	// staticObjectKey.
+	encoded sync.Map
+}
+
+// NewStaticObject returns an ObjectMarshaler for a fixed set of fields whose
+// values never change, such as build information or deployment metadata.
+// The JSON and console encoders encode it once per encoder configuration
+// and copy the cached encoding into every later entry, so that logging a
+// large static object, or binding it with With, costs little more than
+// logging a string. Other encoders marshal the fields every time.
+//
+// The fields must not be modified after they're passed to NewStaticObject,
+// and their values must encode the same way every time: reflected values,
+// Stringers, and ObjectMarshalers must be immutable, and fields built with
+// zap.Lazy shouldn't be used.
+func NewStaticObject(fields ...Field) ObjectMarshaler {
+	return &staticObject{fields: fields}
+}
+
+func (o *staticObject) MarshalLogObject(enc ObjectEncoder) error {
+	addFields(enc, o.fields)
+	return nil
+}
+
+// appendJSON appends the object's fields to enc, which must be positioned
+// just after the object's opening brace.
+func (o *staticObject) appendJSON(enc *jsonEncoder) {
+	key := staticObjectKey{cfg: enc.EncoderConfig, spaced: enc.spaced}
+	if bs, ok := o.encoded.Load(key); ok {
+		enc.buf.Write(bs.([]byte))
+		return
+	}
+
+	tmp := enc.clone()
+	tmp.openNamespaces = 0
+	addFields(tmp, o.fields)
+	tmp.closeOpenNamespaces()
+	bs := append([]byte(nil), tmp.buf.Bytes()...)
+	tmp.buf.Free()
+	putJSONEncoder(tmp)
+
+	o.encoded.Store(key, bs)
+	enc.buf.Write(bs)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "go.uber.org/zap/zapcore"
+)
+
+func TestStaticObject(t *testing.T) {
+	var calls int
+	counted := ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+		calls++
+		enc.AddString("inner", "x")
+		return nil
+	})
+	static := NewStaticObject(
+		Field{Key: "s", Type: StringType, String: "v"},
+		Field{Key: "o", Type: ObjectMarshalerType, Interface: counted},
+		Field{Key: "ns", Type: NamespaceType},
+		Field{Key: "n", Type: Int64Type, Integer: 1},
+	)
+	field := Field{Key: "static", Type: ObjectMarshalerType, Interface: static}
+
+	cfg := testEncoderConfig()
+	cfg.TimeKey, cfg.LevelKey, cfg.NameKey, cfg.CallerKey, cfg.FunctionKey = "", "", "", "", ""
+	cfg.SkipLineEnding = true
+	tests := []struct {
+		desc string
+		enc  Encoder
+		want string
+	}{
+		{
+			desc: "json",
+			enc:  NewJSONEncoder(cfg),
+			want: `{"msg":"m","static":{"s":"v","o":{"inner":"x"},"ns":{"n":1}},"after":true}`,
+		},
+		{
+			desc: "console",
+			enc:  NewConsoleEncoder(cfg),
+			want: `m	{"static": {"s": "v", "o": {"inner": "x"}, "ns": {"n": 1}}, "after": true}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			calls = 0
+			enc := tt.enc.Clone()
+			for i := 0; i < 3; i++ {
+				buf, err := enc.EncodeEntry(Entry{Message: "m"}, []Field{
+					field,
+					{Key: "after", Type: BoolType, Integer: 1},
+				})
+				require.NoError(t, err, "Unexpected encoding error.")
+				assert.Equal(t, tt.want, buf.String(), "Unexpected output.")
+				buf.Free()
+			}
+			assert.Equal(t, 1, calls, "Expected the object to be encoded once per encoder configuration.")
+		})
+	}
+
+	t.Run("other encoders", func(t *testing.T) {
+		enc := NewMapObjectEncoder()
+		field.AddTo(enc)
+		assert.Equal(t, map[string]interface{}{
+			"s":  "v",
+			"o":  map[string]interface{}{"inner": "x"},
+			"ns": map[string]interface{}{"n": int64(1)},
+		}, enc.Fields["static"], "Unexpected fields.")
+	})
+}
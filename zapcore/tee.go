@@ -53,6 +53,13 @@ func (mc multiCore) With(fields []Field) Core {
 	return clone
 }
 
+// AccumulatedFields reports the fields of the first core. Fields bound with
+// With are bound to every core, so they only differ if the cores were
+// constructed with different context.
+func (mc multiCore) AccumulatedFields() []Field {
+	return AccumulatedFields(mc[0])
+}
+
 func (mc multiCore) Level() Level {
 	minLvl := _maxLevel // mc is never empty
 	for i := range mc {
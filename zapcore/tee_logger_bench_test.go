@@ -61,3 +61,78 @@ func BenchmarkTeeCheck(b *testing.B) {
 		})
 	})
 }
+
+func BenchmarkMultiLevelTeeCheck(b *testing.B) {
+	lvl := InfoLevel
+	newCore := func() Core {
+		return NewCore(NewJSONEncoder(testEncoderConfig()), &ztest.Discarder{}, DebugLevel)
+	}
+	var (
+		branches []TeeBranch
+		cores    []Core
+	)
+	for i := 0; i < 8; i++ {
+		core := newCore()
+		branches = append(branches, TeeBranch{Level: lvl, Core: core})
+		filtered, err := NewIncreaseLevelCore(core, lvl)
+		if err != nil {
+			b.Fatal(err)
+		}
+		cores = append(cores, filtered)
+	}
+
+	for _, bb := range []struct {
+		name string
+		core Core
+	}{
+		{"NewTee", NewTee(cores...)},
+		{"NewMultiLevelTee", NewMultiLevelTee(branches...)},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			ent := Entry{Level: DebugLevel, Message: "foo"}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if ce := bb.core.Check(ent, nil); ce != nil {
+					b.Fatal("Expected debug entries to be disabled.")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkTeeReflected(b *testing.B) {
+	type user struct {
+		Name  string   `json:"name"`
+		Email string   `json:"email"`
+		Roles []string `json:"roles"`
+	}
+	field := Field{Key: "user", Type: ReflectType, Interface: user{
+		Name:  "jane",
+		Email: "jane@example.com",
+		Roles: []string{"admin", "dev"},
+	}}
+
+	for _, useArena := range []bool{false, true} {
+		name := "default"
+		if useArena {
+			name = "arena"
+		}
+		b.Run(name, func(b *testing.B) {
+			core := NewTee(
+				NewCore(NewJSONEncoder(testEncoderConfig()), &ztest.Discarder{}, DebugLevel),
+				NewCore(NewJSONEncoder(testEncoderConfig()), &ztest.Discarder{}, DebugLevel),
+				NewCore(NewJSONEncoder(testEncoderConfig()), &ztest.Discarder{}, DebugLevel),
+			)
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if ce := core.Check(Entry{Level: InfoLevel, Message: "fan out"}, nil); ce != nil {
+						ce.UseArena = useArena
+						ce.Write(field)
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"sort"
+	"unicode/utf8"
+
+	"go.uber.org/zap/buffer"
+)
+
+const (
+	// _truncatedKey is the key of the field added to truncated entries.
+	_truncatedKey = "truncated"
+	// _truncatedSuffix marks strings that were cut short.
+	_truncatedSuffix = "..."
+)
+
+type truncatingEncoder struct {
+	Encoder
+
+	max        int
+	onTruncate func(Entry, int)
+}
+
+// MaxEntryBytes wraps an Encoder so that the entries it encodes don't
+// exceed max bytes, since many log backends reject larger entries outright.
+//
+// Oversized entries are shrunk by trimming the fields passed at the log
+// site, largest first: string and byte string values are cut short and end
+// with "...", while other values are dropped. If that isn't enough, the
+// message is cut short too. Truncated entries carry an additional
+// "truncated": true field, and onTruncate, if not nil, is called with the
+// entry and its original encoded size.
+//
+// Fields bound with With are already encoded, so they can't be trimmed; an
+// entry whose context alone exceeds max is written as small as it can be
+// made, but still exceeds it.
+func MaxEntryBytes(enc Encoder, max int, onTruncate func(ent Entry, size int)) Encoder {
+	return &truncatingEncoder{
+		Encoder:    enc,
+		max:        max,
+		onTruncate: onTruncate,
+	}
+}
+
+func (e *truncatingEncoder) Clone() Encoder {
+	return &truncatingEncoder{
+		Encoder:    e.Encoder.Clone(),
+		max:        e.max,
+		onTruncate: e.onTruncate,
+	}
+}
+
+func (e *truncatingEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer, error) {
+	buf, _, err := e.encodeEntryFieldErrors(ent, fields)
+	return buf, err
+}
+
+func (e *truncatingEncoder) retainsFields() bool {
+	return retainsFields(e.Encoder)
+}
+
+func (e *truncatingEncoder) encodeEntryFieldErrors(ent Entry, fields []Field) (*buffer.Buffer, error, error) {
+	buf, fieldErr, err := encodeEntryFieldErrors(e.Encoder, ent, fields)
+	if err != nil || buf.Len() <= e.max {
+		return buf, fieldErr, err
+	}
+
+	size := buf.Len()
+	buf.Free()
+	buf, fieldErr, err = e.truncate(ent, fields)
+	if e.onTruncate != nil {
+		e.onTruncate(ent, size)
+	}
+	return buf, fieldErr, err
+}
+
+// truncate encodes a shrunk copy of an oversized entry. Like
+// encodeEntryFieldErrors, it returns the fields replaced by placeholders in
+// the entry it settles on.
+func (e *truncatingEncoder) truncate(ent Entry, fields []Field) (*buffer.Buffer, error, error) {
+	// Work on a copy of the fields, with the marker at the end.
+	fs := make([]Field, len(fields), len(fields)+1)
+	copy(fs, fields)
+	fs = append(fs, Field{Key: _truncatedKey, Type: BoolType, Integer: 1})
+	fields = fs[:len(fields)]
+
+	// Measure each field's share of the entry, to trim the largest first.
+	sizes := make([]int, len(fields))
+	base, err := e.encodedLen(ent, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range fields {
+		n, err := e.encodedLen(ent, fields[i:i+1])
+		if err != nil {
+			return nil, nil, err
+		}
+		sizes[i] = n - base
+	}
+	order := make([]int, len(fields))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return sizes[order[a]] > sizes[order[b]]
+	})
+
+	for _, i := range order {
+		buf, fieldErr, err := encodeEntryFieldErrors(e.Encoder, ent, fs)
+		if err != nil || buf.Len() <= e.max {
+			return buf, fieldErr, err
+		}
+		excess := buf.Len() - e.max
+		buf.Free()
+		fields[i] = shrinkField(fields[i], excess)
+	}
+
+	buf, fieldErr, err := encodeEntryFieldErrors(e.Encoder, ent, fs)
+	if err != nil || buf.Len() <= e.max {
+		return buf, fieldErr, err
+	}
+	excess := buf.Len() - e.max
+	buf.Free()
+	ent.Message = truncateString(ent.Message, excess)
+	return encodeEntryFieldErrors(e.Encoder, ent, fs)
+}
+
+func (e *truncatingEncoder) encodedLen(ent Entry, fields []Field) (int, error) {
+	buf, err := e.Encoder.EncodeEntry(ent, fields)
+	if err != nil {
+		return 0, err
+	}
+	n := buf.Len()
+	buf.Free()
+	return n, nil
+}
+
+// shrinkField returns a copy of f whose encoded value is at least excess
+// bytes shorter, if possible.
+func shrinkField(f Field, excess int) Field {
+	switch f.Type {
+	case NamespaceType, CloseNamespaceType:
+		// Dropping only one side of a namespace would misplace the fields
+		// after it.
+		return f
+	case StringType:
+		f.String = truncateString(f.String, excess)
+		return f
+	case ByteStringType:
+		if bs, ok := f.Interface.([]byte); ok {
+			f.Interface = []byte(truncateString(string(bs), excess))
+			return f
+		}
+	}
+	return Field{Key: f.Key, Type: SkipType}
+}
+
+// truncateString shortens s by at least excess bytes, including the
+// truncation suffix, without splitting a multi-byte rune. Since escaping
+// never makes a string shorter, its encoded form shrinks at least as much.
+func truncateString(s string, excess int) string {
+	cut := len(s) - excess - len(_truncatedSuffix)
+	if cut <= 0 {
+		if len(s) > len(_truncatedSuffix) {
+			return _truncatedSuffix
+		}
+		return ""
+	}
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + _truncatedSuffix
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+)
+
+func TestMaxEntryBytes(t *testing.T) {
+	str := func(key, val string) Field { return Field{Key: key, Type: StringType, String: val} }
+	ent := Entry{Level: InfoLevel, Message: "hello"}
+	big := strings.Repeat("x", 200)
+
+	tests := []struct {
+		desc      string
+		max       int
+		ent       Entry
+		context   []Field
+		fields    []Field
+		want      map[string]interface{}
+		truncated bool
+	}{
+		{
+			desc:   "fits",
+			max:    100,
+			ent:    ent,
+			fields: []Field{str("k", "v")},
+			want:   map[string]interface{}{"level": "info", "msg": "hello", "k": "v"},
+		},
+		{
+			desc:   "largest field trimmed first",
+			max:    120,
+			ent:    ent,
+			fields: []Field{str("small", "keep me"), str("big", big), makeInt64Field("n", 42)},
+			want: map[string]interface{}{
+				"level":     "info",
+				"msg":       "hello",
+				"small":     "keep me",
+				"big":       strings.Repeat("x", 35) + "...",
+				"n":         float64(42),
+				"truncated": true,
+			},
+			truncated: true,
+		},
+		{
+			desc:   "non-string fields dropped",
+			max:    60,
+			ent:    ent,
+			fields: []Field{{Key: "list", Type: StringerType, Interface: stringer(big)}, makeInt64Field("n", 42)},
+			want: map[string]interface{}{
+				"level":     "info",
+				"msg":       "hello",
+				"n":         float64(42),
+				"truncated": true,
+			},
+			truncated: true,
+		},
+		{
+			desc: "message trimmed last",
+			max:  70,
+			ent:  Entry{Level: InfoLevel, Message: "a long message " + big},
+			want: map[string]interface{}{
+				"level":     "info",
+				"msg":       "a long message xxxxxxxxx...",
+				"truncated": true,
+			},
+			truncated: true,
+		},
+		{
+			desc:    "context can't be trimmed",
+			max:     50,
+			ent:     ent,
+			context: []Field{str("ctx", big)},
+			want: map[string]interface{}{
+				"level":     "info",
+				"msg":       "...",
+				"ctx":       big,
+				"truncated": true,
+			},
+			truncated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var (
+				calls   int
+				gotSize int
+			)
+			enc := MaxEntryBytes(NewJSONEncoder(EncoderConfig{
+				MessageKey:  "msg",
+				LevelKey:    "level",
+				EncodeLevel: LowercaseLevelEncoder,
+			}), tt.max, func(e Entry, size int) {
+				calls++
+				gotSize = size
+			})
+			for _, f := range tt.context {
+				f.AddTo(enc)
+			}
+			enc = enc.Clone()
+
+			buf, err := enc.EncodeEntry(tt.ent, tt.fields)
+			require.NoError(t, err, "Unexpected error encoding.")
+			defer buf.Free()
+
+			var got map[string]interface{}
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &got), "Invalid JSON: %s", buf.String())
+			assert.Equal(t, tt.want, got, "Unexpected entry.")
+			if len(tt.context) == 0 {
+				assert.LessOrEqual(t, buf.Len(), tt.max, "Entry exceeds the limit.")
+			}
+			if tt.truncated {
+				assert.Equal(t, 1, calls, "Expected the hook to be called once.")
+				assert.Greater(t, gotSize, tt.max, "Expected the hook to receive the original size.")
+			} else {
+				assert.Zero(t, calls, "Unexpected hook call.")
+			}
+		})
+	}
+}
+
+func TestMaxEntryBytesMultiByte(t *testing.T) {
+	enc := MaxEntryBytes(NewJSONEncoder(EncoderConfig{MessageKey: "msg"}), 50, nil)
+	fields := []Field{{Key: "s", Type: StringType, String: strings.Repeat("☃", 30)}}
+
+	buf, err := enc.EncodeEntry(Entry{Message: "m"}, fields)
+	require.NoError(t, err, "Unexpected error encoding.")
+	defer buf.Free()
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got), "Invalid JSON: %s", buf.String())
+	assert.True(t, strings.HasSuffix(got["s"].(string), "☃..."), "Expected to cut between runes, got %q.", got["s"])
+	assert.Equal(t, []Field{{Key: "s", Type: StringType, String: strings.Repeat("☃", 30)}}, fields,
+		"Caller's fields must not be modified.")
+}
+
+type stringer string
+
+func (s stringer) String() string { return string(s) }
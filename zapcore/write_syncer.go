@@ -25,6 +25,7 @@ import (
 	"sync"
 
 	"go.uber.org/multierr"
+	"go.uber.org/zap/buffer"
 )
 
 // A WriteSyncer is an io.Writer that can also flush any buffered data. Note
@@ -34,6 +35,31 @@ type WriteSyncer interface {
 	Sync() error
 }
 
+// A BufferWriter is a WriteSyncer that can take ownership of the buffers
+// that Encoders produce. Cores hand encoded entries to BufferWriters through
+// WriteBuffer rather than Write, which saves sinks that would otherwise copy
+// each entry, such as network batchers, from doing so.
+//
+// WriteBuffer owns buf once it's called: it must call buf.Free exactly once,
+// even if it fails, and must not use buf afterwards. It may hold on to buf
+// past the call, for example until a batch is sent.
+type BufferWriter interface {
+	WriteSyncer
+
+	WriteBuffer(buf *buffer.Buffer) error
+}
+
+// writeBuffer writes an encoded entry to ws and frees it, handing buf over
+// if ws is a BufferWriter.
+func writeBuffer(ws WriteSyncer, buf *buffer.Buffer) error {
+	if bw, ok := ws.(BufferWriter); ok {
+		return bw.WriteBuffer(buf)
+	}
+	_, err := ws.Write(buf.Bytes())
+	buf.Free()
+	return err
+}
+
 // AddSync converts an io.Writer to a WriteSyncer. It attempts to be
 // intelligent: if the concrete type of the io.Writer implements WriteSyncer,
 // we'll use the existing Sync method. If it doesn't, we'll add a no-op Sync.
@@ -46,6 +72,13 @@ func AddSync(w io.Writer) WriteSyncer {
 	}
 }
 
+// unwrapper is implemented by WriteSyncers that wrap a single other
+// WriteSyncer. Optional behavior that doesn't involve writing, such as
+// FlushLevel or ColorAuto's terminal detection, is found through it.
+type unwrapper interface {
+	Unwrap() WriteSyncer
+}
+
 type lockedWriteSyncer struct {
 	sync.Mutex
 	ws WriteSyncer
@@ -68,6 +101,33 @@ func (s *lockedWriteSyncer) Write(bs []byte) (int, error) {
 	return n, err
 }
 
+// WriteBuffer hands buf to the wrapped WriteSyncer if it's a BufferWriter,
+// and writes and frees it otherwise.
+func (s *lockedWriteSyncer) WriteBuffer(buf *buffer.Buffer) error {
+	s.Lock()
+	err := writeBuffer(s.ws, buf)
+	s.Unlock()
+	return err
+}
+
+// writeLevelBuffer passes the entry's level on to the wrapped WriteSyncer,
+// so that Lock can wrap the result of NewLevelFilterWriteSyncer.
+func (s *lockedWriteSyncer) writeLevelBuffer(lvl Level, buf *buffer.Buffer) error {
+	s.Lock()
+	err := writeEntryBuffer(s.ws, lvl, buf)
+	s.Unlock()
+	return err
+}
+
+// Unwrap returns the wrapped WriteSyncer.
+func (s *lockedWriteSyncer) Unwrap() WriteSyncer {
+	return s.ws
+}
+
+func (s *lockedWriteSyncer) flushesAt(lvl Level) bool {
+	return flushesAt(s.ws, lvl)
+}
+
 func (s *lockedWriteSyncer) Sync() error {
 	s.Lock()
 	err := s.ws.Sync()
@@ -120,3 +180,14 @@ func (ws multiWriteSyncer) Sync() error {
 	}
 	return err
 }
+
+// flushesAt reports whether any of the WriteSyncers should be synced. Sync
+// syncs them all, which is harmless for the others.
+func (ws multiWriteSyncer) flushesAt(lvl Level) bool {
+	for _, w := range ws {
+		if flushesAt(w, lvl) {
+			return true
+		}
+	}
+	return false
+}
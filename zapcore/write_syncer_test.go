@@ -28,6 +28,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/buffer"
 	"go.uber.org/zap/internal/ztest"
 )
 
@@ -134,3 +135,54 @@ func TestMultiWriteSyncerSync_AllCalled(t *testing.T) {
 	assert.True(t, failed.Called(), "Expected first sink to have Sync method called.")
 	assert.True(t, second.Called(), "Expected call to Sync even with first failure.")
 }
+
+// bufferOwner is a BufferWriter that keeps the buffers it's handed.
+type bufferOwner struct {
+	ztest.Syncer
+
+	bufs   []*buffer.Buffer
+	writes int
+}
+
+func (o *bufferOwner) Write(bs []byte) (int, error) {
+	o.writes++
+	return len(bs), nil
+}
+
+func (o *bufferOwner) WriteBuffer(buf *buffer.Buffer) error {
+	o.bufs = append(o.bufs, buf)
+	return nil
+}
+
+func TestWriteBufferHandsOff(t *testing.T) {
+	pool := buffer.NewPool()
+	owner := &bufferOwner{}
+
+	for _, ws := range []WriteSyncer{owner, Lock(owner)} {
+		buf := pool.Get()
+		buf.AppendString("foo")
+		require.NoError(t, writeBuffer(ws, buf), "Unexpected error writing buffer.")
+	}
+
+	require.Len(t, owner.bufs, 2, "Expected both buffers to be handed to the sink.")
+	assert.Zero(t, owner.writes, "Expected no copies through Write.")
+	for _, buf := range owner.bufs {
+		assert.Equal(t, "foo", buf.String(), "Buffer freed before the sink was done with it.")
+		buf.Free()
+	}
+}
+
+func TestWriteBufferFallback(t *testing.T) {
+	pool := buffer.NewPool()
+	plain, locked := &ztest.Buffer{}, &ztest.Buffer{}
+
+	for _, ws := range []WriteSyncer{plain, Lock(locked)} {
+		buf := pool.Get()
+		buf.AppendString("foo")
+		require.NoError(t, writeBuffer(ws, buf), "Unexpected error writing buffer.")
+	}
+	assert.Equal(t, "foo", plain.String(), "Unexpected output.")
+	assert.Equal(t, "foo", locked.String(), "Unexpected output through Lock.")
+
+	assert.Error(t, writeBuffer(&ztest.FailWriter{}, pool.Get()), "Expected write errors to propagate.")
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zapgelf provides a zap sink that sends entries to a Graylog GELF
+// UDP input. It's meant to be used with zap's "gelf" encoding:
+//
+//	if err := zapgelf.Register(); err != nil {
+//		return err
+//	}
+//	cfg := zap.NewProductionConfig()
+//	cfg.Encoding = "gelf"
+//	cfg.OutputPaths = []string{"gelf://graylog.internal:12201?compress=gzip"}
+package zapgelf // import "go.uber.org/zap/zapgelf"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+const (
+	_gelfDefaultPort      = "12201"
+	_gelfDefaultChunkSize = 1420 // fits a typical WAN MTU
+	_gelfChunkHeaderLen   = 12
+	_gelfMaxChunks        = 128
+)
+
+var _gelfChunkMagic = [2]byte{0x1e, 0x0f}
+
+// Register registers the sink for URLs with the "gelf" scheme. See
+// RegisterScheme for the URL format.
+func Register() error {
+	return RegisterScheme("gelf")
+}
+
+// RegisterScheme registers the sink for URLs with the given scheme. Each
+// write is sent to the input as a single message, split into GELF chunks if
+// it doesn't fit in one datagram. URLs have the form
+//
+//	gelf://host[:port][?compress=gzip&chunkSize=1420]
+//
+// The port defaults to 12201. Messages are sent uncompressed unless compress
+// is set to gzip. chunkSize is the largest datagram the sink sends,
+// including the 12-byte header of each chunk; it defaults to 1420 bytes.
+func RegisterScheme(scheme string) error {
+	return zap.RegisterSinkFactory(scheme, newSink)
+}
+
+// gelfSink sends each write to a Graylog GELF UDP input as a single
+// message, splitting it into GELF chunks if it doesn't fit in one datagram.
+type gelfSink struct {
+	conn      net.Conn
+	compress  bool
+	chunkSize int
+
+	baseID uint64
+	nextID atomic.Uint64
+	gzips  sync.Pool // of *gzip.Writer
+}
+
+func newSink(ctx context.Context, u *url.URL, opts zap.SinkOptions) (zap.Sink, error) {
+	if u.User != nil || u.Fragment != "" || (u.Path != "" && u.Path != "/") {
+		return nil, fmt.Errorf("%s URLs may only set a host, port, and query parameters: got %v", u.Scheme, u)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("%s URLs must set a host: got %v", u.Scheme, u)
+	}
+	port := u.Port()
+	if port == "" {
+		port = _gelfDefaultPort
+	}
+
+	s := &gelfSink{chunkSize: _gelfDefaultChunkSize}
+	switch c := opts.String("compress", ""); c {
+	case "", "none":
+	case "gzip":
+		s.compress = true
+	default:
+		return nil, fmt.Errorf("unsupported gelf compression %q: use gzip or none", c)
+	}
+	if cs := opts.String("chunkSize", ""); cs != "" {
+		n, err := strconv.Atoi(cs)
+		if err != nil || n <= _gelfChunkHeaderLen {
+			return nil, fmt.Errorf("gelf chunkSize must be an integer greater than %d: got %q", _gelfChunkHeaderLen, cs)
+		}
+		s.chunkSize = n
+	}
+
+	var id [8]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, fmt.Errorf("can't generate gelf message IDs: %v", err)
+	}
+	s.baseID = binary.BigEndian.Uint64(id[:])
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", net.JoinHostPort(u.Hostname(), port))
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to gelf input: %v", err)
+	}
+	s.conn = conn
+	return s, nil
+}
+
+func (s *gelfSink) Write(bs []byte) (int, error) {
+	msg := bytes.TrimRight(bs, "\r\n\x00")
+	if s.compress {
+		var err error
+		if msg, err = s.gzip(msg); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(msg) <= s.chunkSize {
+		if _, err := s.conn.Write(msg); err != nil {
+			return 0, err
+		}
+		return len(bs), nil
+	}
+	if err := s.writeChunks(msg); err != nil {
+		return 0, err
+	}
+	return len(bs), nil
+}
+
+// writeChunks sends a message that doesn't fit in a single datagram as a
+// series of GELF chunks.
+func (s *gelfSink) writeChunks(msg []byte) error {
+	dataLen := s.chunkSize - _gelfChunkHeaderLen
+	count := (len(msg) + dataLen - 1) / dataLen
+	if count > _gelfMaxChunks {
+		return fmt.Errorf("gelf message of %d bytes needs %d chunks, more than the maximum of %d", len(msg), count, _gelfMaxChunks)
+	}
+
+	chunk := make([]byte, _gelfChunkHeaderLen, s.chunkSize)
+	copy(chunk, _gelfChunkMagic[:])
+	binary.BigEndian.PutUint64(chunk[2:10], s.baseID+s.nextID.Add(1))
+	chunk[11] = byte(count)
+	for seq := 0; seq < count; seq++ {
+		data := msg[seq*dataLen:]
+		if len(data) > dataLen {
+			data = data[:dataLen]
+		}
+		chunk[10] = byte(seq)
+		if _, err := s.conn.Write(append(chunk[:_gelfChunkHeaderLen], data...)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *gelfSink) gzip(msg []byte) ([]byte, error) {
+	var out bytes.Buffer
+	zw, ok := s.gzips.Get().(*gzip.Writer)
+	if ok {
+		zw.Reset(&out)
+	} else {
+		zw = gzip.NewWriter(&out)
+	}
+	defer s.gzips.Put(zw)
+
+	if _, err := zw.Write(msg); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// Sync is a no-op: every message is sent as soon as it's written.
+func (s *gelfSink) Sync() error {
+	return nil
+}
+
+func (s *gelfSink) Close() error {
+	return s.conn.Close()
+}
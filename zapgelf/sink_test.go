@@ -0,0 +1,186 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapgelf
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// _testSchemes counts the schemes registered by openGELF, since each can
+// only be registered once per process.
+var _testSchemes int
+
+// openGELF registers the sink under a fresh scheme and opens rawURL, which
+// is given without a scheme.
+func openGELF(t *testing.T, rawURL string) (zapcore.WriteSyncer, error) {
+	_testSchemes++
+	scheme := "gelf-test" + strconv.Itoa(_testSchemes)
+	require.NoError(t, RegisterScheme(scheme), "Failed to register GELF sink.")
+
+	ws, closeAll, err := zap.Open(scheme + "://" + rawURL)
+	if err == nil {
+		t.Cleanup(closeAll)
+	}
+	return ws, err
+}
+
+// listenGELF starts a fake GELF UDP input and returns its address along with
+// a function that receives the next datagram sent to it.
+func listenGELF(t *testing.T) (addr string, recv func() []byte) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err, "Failed to listen on fake GELF input.")
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn.LocalAddr().String(), func() []byte {
+		buf := make([]byte, 65536)
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err, "Failed to read from fake GELF input.")
+		return buf[:n]
+	}
+}
+
+func TestGELFSink(t *testing.T) {
+	addr, recv := listenGELF(t)
+
+	sink, err := openGELF(t, addr)
+	require.NoError(t, err, "Failed to open GELF sink.")
+
+	logger := zap.New(zapcore.NewCore(zapcore.NewGELFEncoder(zap.NewProductionEncoderConfig(), "myhost"), sink, zap.DebugLevel))
+	logger.Warn("careful", zap.String("request-id", "abc"))
+
+	var msg map[string]interface{}
+	require.NoError(t, json.Unmarshal(recv(), &msg), "Expected an unchunked JSON message.")
+	assert.Equal(t, "myhost", msg["host"])
+	assert.Equal(t, "careful", msg["short_message"])
+	assert.Equal(t, 4.0, msg["level"])
+	assert.Equal(t, "abc", msg["_request-id"])
+}
+
+func TestRegister(t *testing.T) {
+	addr, recv := listenGELF(t)
+
+	_ = Register() // may already be registered if tests run repeatedly
+	assert.Error(t, Register(), "Expected the gelf scheme to be registered once.")
+
+	ws, closeAll, err := zap.Open("gelf://" + addr)
+	require.NoError(t, err, "Failed to open registered GELF sink.")
+	defer closeAll()
+	_, err = ws.Write([]byte(`{"short_message":"hi"}` + "\n"))
+	require.NoError(t, err, "Failed to write.")
+	assert.Equal(t, `{"short_message":"hi"}`, string(recv()), "Unexpected message.")
+}
+
+func TestGELFSinkChunking(t *testing.T) {
+	tests := []struct {
+		desc     string
+		query    string
+		compress bool
+	}{
+		{desc: "uncompressed", query: "?chunkSize=100"},
+		{desc: "gzip", query: "?chunkSize=100&compress=gzip", compress: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			addr, recv := listenGELF(t)
+			sink, err := openGELF(t, addr+tt.query)
+			require.NoError(t, err, "Failed to open GELF sink.")
+
+			// Use a message that gzip can't shrink below a single chunk.
+			var want strings.Builder
+			for i := 0; want.Len() < 1000; i++ {
+				want.WriteString(time.Duration(i * 7919).String())
+			}
+			n, err := sink.Write([]byte(want.String() + "\n"))
+			require.NoError(t, err, "Failed to write.")
+			assert.Equal(t, want.Len()+1, n, "Unexpected write length.")
+
+			var (
+				got   []byte
+				id    []byte
+				count int
+			)
+			for seq := 0; count == 0 || seq < count; seq++ {
+				chunk := recv()
+				require.True(t, len(chunk) > 12 && len(chunk) <= 100, "Unexpected chunk size %d.", len(chunk))
+				assert.Equal(t, []byte{0x1e, 0x0f}, chunk[:2], "Missing chunk magic bytes.")
+				if id == nil {
+					id, count = chunk[2:10], int(chunk[11])
+				}
+				assert.Equal(t, id, chunk[2:10], "Chunks have different message IDs.")
+				assert.Equal(t, seq, int(chunk[10]), "Unexpected sequence number.")
+				assert.Equal(t, count, int(chunk[11]), "Unexpected sequence count.")
+				got = append(got, chunk[12:]...)
+			}
+			assert.Greater(t, count, 1, "Expected multiple chunks.")
+
+			if tt.compress {
+				zr, err := gzip.NewReader(bytes.NewReader(got))
+				require.NoError(t, err, "Invalid gzip stream.")
+				got, err = io.ReadAll(zr)
+				require.NoError(t, err, "Invalid gzip stream.")
+			}
+			assert.Equal(t, want.String(), string(got), "Unexpected reassembled message.")
+		})
+	}
+}
+
+func TestGELFSinkTooManyChunks(t *testing.T) {
+	addr, _ := listenGELF(t)
+	sink, err := openGELF(t, addr+"?chunkSize=13")
+	require.NoError(t, err, "Failed to open GELF sink.")
+
+	_, err = sink.Write(bytes.Repeat([]byte("x"), 129))
+	assert.ErrorContains(t, err, "more than the maximum of 128")
+}
+
+func TestGELFSinkErrors(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"", "must set a host"},
+		{"user@localhost", "may only set a host"},
+		{"localhost/path", "may only set a host"},
+		{"localhost?compress=zlib", "unsupported gelf compression"},
+		{"localhost?chunkSize=12", "chunkSize must be an integer"},
+		{"localhost?chunkSize=big", "chunkSize must be an integer"},
+	}
+
+	for _, tt := range tests {
+		_, err := openGELF(t, tt.url)
+		assert.ErrorContains(t, err, tt.want, "Unexpected error for %q.", tt.url)
+	}
+}
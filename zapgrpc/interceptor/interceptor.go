@@ -0,0 +1,359 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package interceptor provides gRPC interceptors that log every unary and
+// streaming call to a zap.Logger. It lives in its own module so that
+// importing zap doesn't pull in gRPC.
+package interceptor // import "go.uber.org/zap/zapgrpc/interceptor"
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// An InterceptorOption configures the interceptors returned by
+// UnaryServerInterceptor, StreamServerInterceptor, UnaryClientInterceptor,
+// and StreamClientInterceptor.
+type InterceptorOption interface {
+	applyInterceptor(*interceptorConfig)
+}
+
+type interceptorOptionFunc func(*interceptorConfig)
+
+func (f interceptorOptionFunc) applyInterceptor(cfg *interceptorConfig) {
+	f(cfg)
+}
+
+// WithCodeLevels sets the function used to pick the level at which a
+// finished RPC is logged from its status code. By default, OK is logged at
+// InfoLevel, codes that usually indicate a caller error at WarnLevel, and
+// all other codes at ErrorLevel; see DefaultCodeToLevel.
+func WithCodeLevels(f func(codes.Code) zapcore.Level) InterceptorOption {
+	return interceptorOptionFunc(func(cfg *interceptorConfig) {
+		cfg.codeToLevel = f
+	})
+}
+
+// WithMethodLevel overrides the level at which calls to the given method are
+// logged, regardless of their status code. The method is the full gRPC
+// method name, as in "/pkg.Service/Method". Passing a level below the
+// logger's enabled level effectively silences the method, which is useful
+// for health checks.
+func WithMethodLevel(method string, lvl zapcore.Level) InterceptorOption {
+	return interceptorOptionFunc(func(cfg *interceptorConfig) {
+		if cfg.methodLevels == nil {
+			cfg.methodLevels = make(map[string]zapcore.Level)
+		}
+		cfg.methodLevels[method] = lvl
+	})
+}
+
+// WithMetadata logs the values of the given metadata keys as fields named
+// "grpc.metadata.<key>". Servers read incoming metadata and clients read
+// outgoing metadata. Keys are matched case-insensitively.
+func WithMetadata(keys ...string) InterceptorOption {
+	return interceptorOptionFunc(func(cfg *interceptorConfig) {
+		for _, k := range keys {
+			cfg.metadataKeys = append(cfg.metadataKeys, strings.ToLower(k))
+		}
+	})
+}
+
+// WithPayloads enables logging of request and response messages. Unary
+// calls attach them to the finished-call entry as "grpc.request" and
+// "grpc.response"; streaming calls log each message as a separate entry at
+// DebugLevel.
+//
+// Payloads may contain sensitive data and are expensive to encode, so this
+// is off by default.
+func WithPayloads() InterceptorOption {
+	return interceptorOptionFunc(func(cfg *interceptorConfig) {
+		cfg.payloads = true
+	})
+}
+
+// withInterceptorClock overrides the clock used to measure latency. This is
+// intentionally unexported.
+func withInterceptorClock(clock zapcore.Clock) InterceptorOption {
+	return interceptorOptionFunc(func(cfg *interceptorConfig) {
+		cfg.clock = clock
+	})
+}
+
+// DefaultCodeToLevel is the default mapping from gRPC status codes to log
+// levels used by the interceptors in this package.
+func DefaultCodeToLevel(code codes.Code) zapcore.Level {
+	switch code {
+	case codes.OK:
+		return zapcore.InfoLevel
+	case codes.Canceled, codes.InvalidArgument, codes.NotFound,
+		codes.AlreadyExists, codes.PermissionDenied, codes.Unauthenticated,
+		codes.ResourceExhausted, codes.FailedPrecondition, codes.Aborted,
+		codes.OutOfRange:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.ErrorLevel
+	}
+}
+
+type interceptorConfig struct {
+	logger       *zap.Logger
+	codeToLevel  func(codes.Code) zapcore.Level
+	methodLevels map[string]zapcore.Level
+	metadataKeys []string
+	payloads     bool
+	clock        zapcore.Clock
+}
+
+func newInterceptorConfig(logger *zap.Logger, opts []InterceptorOption) *interceptorConfig {
+	cfg := &interceptorConfig{
+		logger:      logger,
+		codeToLevel: DefaultCodeToLevel,
+		clock:       zapcore.DefaultClock,
+	}
+	for _, opt := range opts {
+		opt.applyInterceptor(cfg)
+	}
+	return cfg
+}
+
+func (cfg *interceptorConfig) level(method string, code codes.Code) zapcore.Level {
+	if lvl, ok := cfg.methodLevels[method]; ok {
+		return lvl
+	}
+	return cfg.codeToLevel(code)
+}
+
+// callFields returns the fields describing an RPC that are known before it
+// starts.
+func (cfg *interceptorConfig) callFields(ctx context.Context, kind, method string, md metadata.MD) []zap.Field {
+	service, name := splitMethod(method)
+	fields := make([]zap.Field, 0, 8+len(cfg.metadataKeys))
+	fields = append(fields,
+		zap.String("grpc.kind", kind),
+		zap.String("grpc.service", service),
+		zap.String("grpc.method", name),
+	)
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		fields = append(fields, zap.String("peer.address", p.Addr.String()))
+	}
+	for _, k := range cfg.metadataKeys {
+		if vals := md.Get(k); len(vals) > 0 {
+			fields = append(fields, zap.Strings("grpc.metadata."+k, vals))
+		}
+	}
+	return fields
+}
+
+// finish logs the end of an RPC.
+func (cfg *interceptorConfig) finish(msg, method string, start time.Time, err error, fields []zap.Field) {
+	code := status.Code(err)
+	ce := cfg.logger.Check(cfg.level(method, code), msg)
+	if ce == nil {
+		return
+	}
+	fields = append(fields,
+		zap.String("grpc.code", code.String()),
+		zap.Duration("grpc.duration", cfg.clock.Now().Sub(start)),
+	)
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+	ce.Write(fields...)
+}
+
+// UnaryServerInterceptor returns a grpc.UnaryServerInterceptor that logs
+// every unary RPC handled by the server once it finishes.
+func UnaryServerInterceptor(logger *zap.Logger, opts ...InterceptorOption) grpc.UnaryServerInterceptor {
+	cfg := newInterceptorConfig(logger, opts)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := cfg.clock.Now()
+		md, _ := metadata.FromIncomingContext(ctx)
+		fields := cfg.callFields(ctx, "server", info.FullMethod, md)
+
+		resp, err := handler(ctx, req)
+
+		if cfg.payloads {
+			fields = append(fields, zap.Any("grpc.request", req))
+			if err == nil {
+				fields = append(fields, zap.Any("grpc.response", resp))
+			}
+		}
+		cfg.finish("finished unary call", info.FullMethod, start, err, fields)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a grpc.StreamServerInterceptor that logs
+// every streaming RPC handled by the server once it finishes.
+func StreamServerInterceptor(logger *zap.Logger, opts ...InterceptorOption) grpc.StreamServerInterceptor {
+	cfg := newInterceptorConfig(logger, opts)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := cfg.clock.Now()
+		ctx := ss.Context()
+		md, _ := metadata.FromIncomingContext(ctx)
+		fields := cfg.callFields(ctx, "server", info.FullMethod, md)
+
+		if cfg.payloads {
+			ss = &loggedServerStream{
+				ServerStream: ss,
+				logger:       cfg.logger.With(fields...),
+			}
+		}
+		err := handler(srv, ss)
+
+		cfg.finish("finished streaming call", info.FullMethod, start, err, fields)
+		return err
+	}
+}
+
+// UnaryClientInterceptor returns a grpc.UnaryClientInterceptor that logs
+// every unary RPC made by the client once it finishes.
+func UnaryClientInterceptor(logger *zap.Logger, opts ...InterceptorOption) grpc.UnaryClientInterceptor {
+	cfg := newInterceptorConfig(logger, opts)
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		start := cfg.clock.Now()
+		md, _ := metadata.FromOutgoingContext(ctx)
+		fields := cfg.callFields(ctx, "client", method, md)
+
+		err := invoker(ctx, method, req, reply, cc, callOpts...)
+
+		if cfg.payloads {
+			fields = append(fields, zap.Any("grpc.request", req))
+			if err == nil {
+				fields = append(fields, zap.Any("grpc.response", reply))
+			}
+		}
+		cfg.finish("finished unary call", method, start, err, fields)
+		return err
+	}
+}
+
+// StreamClientInterceptor returns a grpc.StreamClientInterceptor that logs
+// every streaming RPC made by the client. The call is logged when the
+// stream cannot be established, or when receiving from it first fails;
+// io.EOF is reported as OK.
+func StreamClientInterceptor(logger *zap.Logger, opts ...InterceptorOption) grpc.StreamClientInterceptor {
+	cfg := newInterceptorConfig(logger, opts)
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := cfg.clock.Now()
+		md, _ := metadata.FromOutgoingContext(ctx)
+		fields := cfg.callFields(ctx, "client", method, md)
+
+		cs, err := streamer(ctx, desc, cc, method, callOpts...)
+		if err != nil {
+			cfg.finish("finished streaming call", method, start, err, fields)
+			return nil, err
+		}
+		lcs := &loggedClientStream{
+			ClientStream: cs,
+			finish: func(err error) {
+				cfg.finish("finished streaming call", method, start, err, fields)
+			},
+		}
+		if cfg.payloads {
+			lcs.logger = cfg.logger.With(fields...)
+		}
+		return lcs, nil
+	}
+}
+
+// loggedServerStream logs every message sent and received on a server
+// stream.
+type loggedServerStream struct {
+	grpc.ServerStream
+
+	logger *zap.Logger
+}
+
+func (s *loggedServerStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.logger.Debug("sent message", zap.Any("grpc.response", m))
+	}
+	return err
+}
+
+func (s *loggedServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.logger.Debug("received message", zap.Any("grpc.request", m))
+	}
+	return err
+}
+
+// loggedClientStream reports the end of a client stream and, if logger is
+// set, logs every message sent and received on it.
+type loggedClientStream struct {
+	grpc.ClientStream
+
+	logger *zap.Logger // nil unless payloads are logged
+	once   sync.Once
+	finish func(error)
+}
+
+func (s *loggedClientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil && s.logger != nil {
+		s.logger.Debug("sent message", zap.Any("grpc.request", m))
+	}
+	return err
+}
+
+func (s *loggedClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil {
+		if s.logger != nil {
+			s.logger.Debug("received message", zap.Any("grpc.response", m))
+		}
+		return nil
+	}
+
+	s.once.Do(func() {
+		if errors.Is(err, io.EOF) {
+			s.finish(nil)
+		} else {
+			s.finish(err)
+		}
+	})
+	return err
+}
+
+// splitMethod splits a full gRPC method name of the form
+// "/pkg.Service/Method" into its service and method parts.
+func splitMethod(fullMethod string) (service, method string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndexByte(fullMethod, '/'); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
@@ -0,0 +1,319 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// stepClock advances by a fixed step every time it's read.
+type stepClock struct {
+	now  time.Time
+	step time.Duration
+}
+
+func (c *stepClock) Now() time.Time {
+	t := c.now
+	c.now = c.now.Add(c.step)
+	return t
+}
+
+func (c *stepClock) NewTicker(d time.Duration) *time.Ticker {
+	return time.NewTicker(d)
+}
+
+func newObservedInterceptorLogger() (*zap.Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	return zap.New(core), logs
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	logger, logs := newObservedInterceptorLogger()
+	intercept := UnaryServerInterceptor(logger,
+		WithMetadata("X-Request-ID"),
+		withInterceptorClock(&stepClock{step: time.Second}),
+	)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "abc"))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1234}})
+	info := &grpc.UnaryServerInfo{FullMethod: "/pkg.Service/Get"}
+
+	resp, err := intercept(ctx, "req", info, func(context.Context, interface{}) (interface{}, error) {
+		return "resp", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "resp", resp)
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 1)
+	assert.Equal(t, zapcore.InfoLevel, entries[0].Level)
+	assert.Equal(t, "finished unary call", entries[0].Message)
+	assert.Equal(t, map[string]interface{}{
+		"grpc.kind":                  "server",
+		"grpc.service":               "pkg.Service",
+		"grpc.method":                "Get",
+		"peer.address":               "127.0.0.1:1234",
+		"grpc.metadata.x-request-id": []interface{}{"abc"},
+		"grpc.code":                  "OK",
+		"grpc.duration":              time.Second,
+	}, entries[0].ContextMap())
+}
+
+func TestUnaryServerInterceptorLevels(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []InterceptorOption
+		method string
+		err    error
+		want   zapcore.Level
+	}{
+		{
+			desc:   "caller error",
+			method: "/pkg.Service/Get",
+			err:    status.Error(codes.NotFound, "missing"),
+			want:   zapcore.WarnLevel,
+		},
+		{
+			desc:   "server error",
+			method: "/pkg.Service/Get",
+			err:    errors.New("boom"),
+			want:   zapcore.ErrorLevel,
+		},
+		{
+			desc:   "method override",
+			opts:   []InterceptorOption{WithMethodLevel("/grpc.health.v1.Health/Check", zapcore.DebugLevel)},
+			method: "/grpc.health.v1.Health/Check",
+			err:    status.Error(codes.Unavailable, "down"),
+			want:   zapcore.DebugLevel,
+		},
+		{
+			desc: "custom code levels",
+			opts: []InterceptorOption{WithCodeLevels(func(codes.Code) zapcore.Level {
+				return zapcore.DPanicLevel
+			})},
+			method: "/pkg.Service/Get",
+			want:   zapcore.DPanicLevel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+			intercept := UnaryServerInterceptor(zap.New(core), tt.opts...)
+			info := &grpc.UnaryServerInfo{FullMethod: tt.method}
+
+			_, err := intercept(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+				return nil, tt.err
+			})
+			assert.Equal(t, tt.err, err)
+
+			entries := logs.AllUntimed()
+			require.Len(t, entries, 1)
+			assert.Equal(t, tt.want, entries[0].Level)
+			if tt.err != nil {
+				assert.Equal(t, tt.err.Error(), entries[0].ContextMap()["error"])
+			}
+		})
+	}
+}
+
+func TestUnaryInterceptorPayloads(t *testing.T) {
+	logger, logs := newObservedInterceptorLogger()
+	server := UnaryServerInterceptor(logger, WithPayloads())
+	client := UnaryClientInterceptor(logger, WithPayloads())
+
+	_, err := server(context.Background(), "ping", &grpc.UnaryServerInfo{FullMethod: "/pkg.Service/Ping"},
+		func(context.Context, interface{}) (interface{}, error) {
+			return "pong", nil
+		})
+	require.NoError(t, err)
+
+	err = client(context.Background(), "/pkg.Service/Ping", "ping", "pong", nil,
+		func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+			return nil
+		})
+	require.NoError(t, err)
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2)
+	for _, e := range entries {
+		fields := e.ContextMap()
+		assert.Equal(t, "ping", fields["grpc.request"])
+		assert.Equal(t, "pong", fields["grpc.response"])
+	}
+	assert.Equal(t, "server", entries[0].ContextMap()["grpc.kind"])
+	assert.Equal(t, "client", entries[1].ContextMap()["grpc.kind"])
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	logger, logs := newObservedInterceptorLogger()
+	intercept := UnaryClientInterceptor(logger, WithMetadata("authority"))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "Authority", "example.com")
+	err := intercept(ctx, "/pkg.Service/Put", nil, nil, nil,
+		func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+			return status.Error(codes.Internal, "broken")
+		})
+	require.Error(t, err)
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 1)
+	assert.Equal(t, zapcore.ErrorLevel, entries[0].Level)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "client", fields["grpc.kind"])
+	assert.Equal(t, "Put", fields["grpc.method"])
+	assert.Equal(t, "Internal", fields["grpc.code"])
+	assert.Equal(t, []interface{}{"example.com"}, fields["grpc.metadata.authority"])
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+
+	ctx  context.Context
+	recv []string
+	sent []string
+}
+
+func (s *fakeServerStream) Context() context.Context { return s.ctx }
+
+func (s *fakeServerStream) SendMsg(m interface{}) error {
+	s.sent = append(s.sent, m.(string))
+	return nil
+}
+
+func (s *fakeServerStream) RecvMsg(m interface{}) error {
+	if len(s.recv) == 0 {
+		return io.EOF
+	}
+	*m.(*string), s.recv = s.recv[0], s.recv[1:]
+	return nil
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	logger, logs := newObservedInterceptorLogger()
+	intercept := StreamServerInterceptor(logger, WithPayloads())
+
+	ss := &fakeServerStream{ctx: context.Background(), recv: []string{"a"}}
+	err := intercept(nil, ss, &grpc.StreamServerInfo{FullMethod: "/pkg.Service/Echo"},
+		func(_ interface{}, stream grpc.ServerStream) error {
+			var msg string
+			for stream.RecvMsg(&msg) == nil {
+				if err := stream.SendMsg(msg); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, ss.sent)
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 3)
+	assert.Equal(t, "received message", entries[0].Message)
+	assert.Equal(t, zapcore.DebugLevel, entries[0].Level)
+	assert.Equal(t, "sent message", entries[1].Message)
+	assert.Equal(t, "a", entries[1].ContextMap()["grpc.response"])
+	assert.Equal(t, "Echo", entries[1].ContextMap()["grpc.method"])
+	assert.Equal(t, "finished streaming call", entries[2].Message)
+	assert.Equal(t, "OK", entries[2].ContextMap()["grpc.code"])
+}
+
+type fakeClientStream struct {
+	grpc.ClientStream
+
+	err error
+}
+
+func (s *fakeClientStream) RecvMsg(interface{}) error { return s.err }
+
+func TestStreamClientInterceptor(t *testing.T) {
+	tests := []struct {
+		desc      string
+		streamErr error
+		recvErr   error
+		wantCode  string
+	}{
+		{desc: "EOF", recvErr: io.EOF, wantCode: "OK"},
+		{desc: "recv error", recvErr: status.Error(codes.Unavailable, "gone"), wantCode: "Unavailable"},
+		{desc: "stream error", streamErr: status.Error(codes.PermissionDenied, "no"), wantCode: "PermissionDenied"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			logger, logs := newObservedInterceptorLogger()
+			intercept := StreamClientInterceptor(logger)
+
+			cs, err := intercept(context.Background(), &grpc.StreamDesc{}, nil, "/pkg.Service/Watch",
+				func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+					if tt.streamErr != nil {
+						return nil, tt.streamErr
+					}
+					return &fakeClientStream{err: tt.recvErr}, nil
+				})
+			if tt.streamErr != nil {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Empty(t, logs.AllUntimed(), "Logged before the stream finished.")
+				assert.Equal(t, tt.recvErr, cs.RecvMsg(nil))
+				assert.Equal(t, tt.recvErr, cs.RecvMsg(nil))
+			}
+
+			entries := logs.AllUntimed()
+			require.Len(t, entries, 1, "Expected exactly one entry per stream.")
+			assert.Equal(t, tt.wantCode, entries[0].ContextMap()["grpc.code"])
+		})
+	}
+}
+
+func TestSplitMethod(t *testing.T) {
+	tests := []struct {
+		give        string
+		wantService string
+		wantMethod  string
+	}{
+		{"/pkg.Service/Method", "pkg.Service", "Method"},
+		{"pkg.Service/Method", "pkg.Service", "Method"},
+		{"Method", "unknown", "Method"},
+	}
+
+	for _, tt := range tests {
+		service, method := splitMethod(tt.give)
+		assert.Equal(t, tt.wantService, service, "Unexpected service for %q.", tt.give)
+		assert.Equal(t, tt.wantMethod, method, "Unexpected method for %q.", tt.give)
+	}
+}
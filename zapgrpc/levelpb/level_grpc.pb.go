@@ -0,0 +1,239 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: level.proto
+
+package levelpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	LevelService_GetLevel_FullMethodName   = "/zap.level.v1.LevelService/GetLevel"
+	LevelService_SetLevel_FullMethodName   = "/zap.level.v1.LevelService/SetLevel"
+	LevelService_WatchLevel_FullMethodName = "/zap.level.v1.LevelService/WatchLevel"
+)
+
+// LevelServiceClient is the client API for LevelService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LevelServiceClient interface {
+	// GetLevel returns the current level of a logger.
+	GetLevel(ctx context.Context, in *GetLevelRequest, opts ...grpc.CallOption) (*LoggerLevel, error)
+	// SetLevel changes the level of a logger and returns its new level.
+	SetLevel(ctx context.Context, in *SetLevelRequest, opts ...grpc.CallOption) (*LoggerLevel, error)
+	// WatchLevel streams the level of a logger, starting with its current
+	// level and followed by every change, until the call is cancelled.
+	WatchLevel(ctx context.Context, in *WatchLevelRequest, opts ...grpc.CallOption) (LevelService_WatchLevelClient, error)
+}
+
+type levelServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLevelServiceClient(cc grpc.ClientConnInterface) LevelServiceClient {
+	return &levelServiceClient{cc}
+}
+
+func (c *levelServiceClient) GetLevel(ctx context.Context, in *GetLevelRequest, opts ...grpc.CallOption) (*LoggerLevel, error) {
+	out := new(LoggerLevel)
+	err := c.cc.Invoke(ctx, LevelService_GetLevel_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *levelServiceClient) SetLevel(ctx context.Context, in *SetLevelRequest, opts ...grpc.CallOption) (*LoggerLevel, error) {
+	out := new(LoggerLevel)
+	err := c.cc.Invoke(ctx, LevelService_SetLevel_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *levelServiceClient) WatchLevel(ctx context.Context, in *WatchLevelRequest, opts ...grpc.CallOption) (LevelService_WatchLevelClient, error) {
+	stream, err := c.cc.NewStream(ctx, &LevelService_ServiceDesc.Streams[0], LevelService_WatchLevel_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &levelServiceWatchLevelClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type LevelService_WatchLevelClient interface {
+	Recv() (*LoggerLevel, error)
+	grpc.ClientStream
+}
+
+type levelServiceWatchLevelClient struct {
+	grpc.ClientStream
+}
+
+func (x *levelServiceWatchLevelClient) Recv() (*LoggerLevel, error) {
+	m := new(LoggerLevel)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// LevelServiceServer is the server API for LevelService service.
+// All implementations must embed UnimplementedLevelServiceServer
+// for forward compatibility
+type LevelServiceServer interface {
+	// GetLevel returns the current level of a logger.
+	GetLevel(context.Context, *GetLevelRequest) (*LoggerLevel, error)
+	// SetLevel changes the level of a logger and returns its new level.
+	SetLevel(context.Context, *SetLevelRequest) (*LoggerLevel, error)
+	// WatchLevel streams the level of a logger, starting with its current
+	// level and followed by every change, until the call is cancelled.
+	WatchLevel(*WatchLevelRequest, LevelService_WatchLevelServer) error
+	mustEmbedUnimplementedLevelServiceServer()
+}
+
+// UnimplementedLevelServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedLevelServiceServer struct {
+}
+
+func (UnimplementedLevelServiceServer) GetLevel(context.Context, *GetLevelRequest) (*LoggerLevel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLevel not implemented")
+}
+func (UnimplementedLevelServiceServer) SetLevel(context.Context, *SetLevelRequest) (*LoggerLevel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLevel not implemented")
+}
+func (UnimplementedLevelServiceServer) WatchLevel(*WatchLevelRequest, LevelService_WatchLevelServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchLevel not implemented")
+}
+func (UnimplementedLevelServiceServer) mustEmbedUnimplementedLevelServiceServer() {}
+
+// UnsafeLevelServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LevelServiceServer will
+// result in compilation errors.
+type UnsafeLevelServiceServer interface {
+	mustEmbedUnimplementedLevelServiceServer()
+}
+
+func RegisterLevelServiceServer(s grpc.ServiceRegistrar, srv LevelServiceServer) {
+	s.RegisterService(&LevelService_ServiceDesc, srv)
+}
+
+func _LevelService_GetLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LevelServiceServer).GetLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LevelService_GetLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LevelServiceServer).GetLevel(ctx, req.(*GetLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LevelService_SetLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LevelServiceServer).SetLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LevelService_SetLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LevelServiceServer).SetLevel(ctx, req.(*SetLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LevelService_WatchLevel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchLevelRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LevelServiceServer).WatchLevel(m, &levelServiceWatchLevelServer{stream})
+}
+
+type LevelService_WatchLevelServer interface {
+	Send(*LoggerLevel) error
+	grpc.ServerStream
+}
+
+type levelServiceWatchLevelServer struct {
+	grpc.ServerStream
+}
+
+func (x *levelServiceWatchLevelServer) Send(m *LoggerLevel) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// LevelService_ServiceDesc is the grpc.ServiceDesc for LevelService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LevelService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "zap.level.v1.LevelService",
+	HandlerType: (*LevelServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLevel",
+			Handler:    _LevelService_GetLevel_Handler,
+		},
+		{
+			MethodName: "SetLevel",
+			Handler:    _LevelService_SetLevel_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchLevel",
+			Handler:       _LevelService_WatchLevel_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "level.proto",
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package levelpb
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// _levelPollInterval is how often WatchLevel checks for level changes made
+// outside the Server, such as through AtomicLevel's HTTP handler.
+const _levelPollInterval = time.Second
+
+// A Server exposes a registry of named AtomicLevels over gRPC through
+// the LevelService, so that platforms with gRPC-only admin planes can
+// adjust logging verbosity at runtime. It's the gRPC counterpart of
+// AtomicLevel's ServeHTTP.
+//
+//	levels := levelpb.NewServer(cfg.Level)
+//	levels.Register("db", dbLevel)
+//	levelpb.RegisterLevelServiceServer(grpcServer, levels)
+type Server struct {
+	UnimplementedLevelServiceServer
+
+	mu      sync.RWMutex
+	levels  map[string]zap.AtomicLevel
+	changed chan struct{} // closed and replaced whenever SetLevel succeeds
+
+	pollInterval time.Duration
+}
+
+var _ LevelServiceServer = (*Server)(nil)
+
+// NewServer builds a Server that controls root, the level of the
+// root logger, which callers address with an empty logger name. Register
+// more levels with Register.
+func NewServer(root zap.AtomicLevel) *Server {
+	return &Server{
+		levels:       map[string]zap.AtomicLevel{"": root},
+		changed:      make(chan struct{}),
+		pollInterval: _levelPollInterval,
+	}
+}
+
+// Register makes lvl available to remote callers under the given name,
+// replacing any level previously registered with that name. Names typically
+// match those passed to Logger.Named.
+func (s *Server) Register(name string, lvl zap.AtomicLevel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.levels[name] = lvl
+}
+
+// Names returns the names of all registered levels in sorted order,
+// including the empty name of the root logger.
+func (s *Server) Names() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := make([]string, 0, len(s.levels))
+	for name := range s.levels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *Server) lookup(name string) (zap.AtomicLevel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	lvl, ok := s.levels[name]
+	if !ok {
+		return zap.AtomicLevel{}, status.Errorf(codes.NotFound, "no logger named %q", name)
+	}
+	return lvl, nil
+}
+
+// GetLevel implements LevelServiceServer.
+func (s *Server) GetLevel(_ context.Context, req *GetLevelRequest) (*LoggerLevel, error) {
+	lvl, err := s.lookup(req.GetLogger())
+	if err != nil {
+		return nil, err
+	}
+	return loggerLevel(req.GetLogger(), lvl.Level()), nil
+}
+
+// SetLevel implements LevelServiceServer.
+func (s *Server) SetLevel(_ context.Context, req *SetLevelRequest) (*LoggerLevel, error) {
+	lvl, err := s.lookup(req.GetLogger())
+	if err != nil {
+		return nil, err
+	}
+	l, err := zapcore.ParseLevel(req.GetLevel())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	lvl.SetLevel(l)
+
+	s.mu.Lock()
+	close(s.changed)
+	s.changed = make(chan struct{})
+	s.mu.Unlock()
+
+	return loggerLevel(req.GetLogger(), l), nil
+}
+
+// WatchLevel implements LevelServiceServer. It sends the logger's
+// current level, then its new level after every change. Changes made
+// through SetLevel are sent immediately; others are noticed within a
+// second.
+func (s *Server) WatchLevel(req *WatchLevelRequest, stream LevelService_WatchLevelServer) error {
+	lvl, err := s.lookup(req.GetLogger())
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	sent := false
+	var last zapcore.Level
+	for {
+		// Grab the channel before reading the level so that changes made
+		// in between aren't missed.
+		s.mu.RLock()
+		changed := s.changed
+		s.mu.RUnlock()
+
+		if l := lvl.Level(); !sent || l != last {
+			if err := stream.Send(loggerLevel(req.GetLogger(), l)); err != nil {
+				return err
+			}
+			sent, last = true, l
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-changed:
+		case <-ticker.C:
+		}
+	}
+}
+
+func loggerLevel(name string, lvl zapcore.Level) *LoggerLevel {
+	return &LoggerLevel{Logger: name, Level: lvl.String()}
+}
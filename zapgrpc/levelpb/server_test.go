@@ -0,0 +1,118 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package levelpb
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newLevelClient(t *testing.T, srv *Server) LevelServiceClient {
+	lis := bufconn.Listen(1 << 16)
+	server := grpc.NewServer()
+	RegisterLevelServiceServer(server, srv)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err, "Failed to dial level server.")
+	t.Cleanup(func() { assert.NoError(t, conn.Close()) })
+	return NewLevelServiceClient(conn)
+}
+
+func TestLevelServerGetSet(t *testing.T) {
+	root := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	db := zap.NewAtomicLevelAt(zapcore.WarnLevel)
+	srv := NewServer(root)
+	srv.Register("db", db)
+	assert.Equal(t, []string{"", "db"}, srv.Names())
+
+	client := newLevelClient(t, srv)
+	ctx := context.Background()
+
+	got, err := client.GetLevel(ctx, &GetLevelRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "info", got.GetLevel())
+
+	got, err = client.SetLevel(ctx, &SetLevelRequest{Logger: "db", Level: "DEBUG"})
+	require.NoError(t, err)
+	assert.Equal(t, "db", got.GetLogger())
+	assert.Equal(t, "debug", got.GetLevel())
+	assert.Equal(t, zapcore.DebugLevel, db.Level(), "Expected SetLevel to change the registered level.")
+	assert.Equal(t, zapcore.InfoLevel, root.Level(), "Unexpected change to another logger's level.")
+
+	_, err = client.GetLevel(ctx, &GetLevelRequest{Logger: "cache"})
+	assert.Equal(t, codes.NotFound, status.Code(err), "Unexpected error for an unknown logger.")
+
+	_, err = client.SetLevel(ctx, &SetLevelRequest{Level: "loud"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Unexpected error for an unknown level.")
+}
+
+func TestLevelServerWatch(t *testing.T) {
+	root := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	srv := NewServer(root)
+	srv.pollInterval = 10 * time.Millisecond
+	client := newLevelClient(t, srv)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.WatchLevel(ctx, &WatchLevelRequest{})
+	require.NoError(t, err)
+
+	recv := func() string {
+		got, err := stream.Recv()
+		require.NoError(t, err)
+		return got.GetLevel()
+	}
+	assert.Equal(t, "info", recv(), "Expected the current level first.")
+
+	_, err = client.SetLevel(ctx, &SetLevelRequest{Level: "error"})
+	require.NoError(t, err)
+	assert.Equal(t, "error", recv(), "Expected changes through SetLevel.")
+
+	root.SetLevel(zapcore.DebugLevel)
+	assert.Equal(t, "debug", recv(), "Expected changes made outside the server.")
+}
+
+func TestLevelServerWatchUnknown(t *testing.T) {
+	client := newLevelClient(t, NewServer(zap.NewAtomicLevel()))
+	stream, err := client.WatchLevel(context.Background(), &WatchLevelRequest{Logger: "nope"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zaphttp provides net/http middleware that logs requests through Zap.
+package zaphttp // import "go.uber.org/zap/zaphttp"
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// An Option configures the middleware returned by Handler.
+type Option interface {
+	apply(*handlerConfig)
+}
+
+type optionFunc func(*handlerConfig)
+
+func (f optionFunc) apply(cfg *handlerConfig) {
+	f(cfg)
+}
+
+// WithHeaders logs the values of the given request headers as fields named
+// "http.header.<name>", where name is the lower-cased header name. Headers
+// absent from a request are omitted.
+func WithHeaders(names ...string) Option {
+	return optionFunc(func(cfg *handlerConfig) {
+		for _, name := range names {
+			cfg.headers = append(cfg.headers, http.CanonicalHeaderKey(name))
+		}
+	})
+}
+
+// WithSuccessSampling logs only one of every n requests that finish with a
+// 2xx status. Requests with any other status are always logged. Values of n
+// below 2 disable sampling.
+func WithSuccessSampling(n int) Option {
+	return optionFunc(func(cfg *handlerConfig) {
+		cfg.sampleEvery = uint64(n)
+	})
+}
+
+// WithStatusLevels sets the function used to pick the level at which a
+// finished request is logged from its status code. By default, 5xx
+// responses are logged at ErrorLevel, 4xx responses at WarnLevel, and all
+// others at InfoLevel.
+func WithStatusLevels(f func(status int) zapcore.Level) Option {
+	return optionFunc(func(cfg *handlerConfig) {
+		cfg.statusToLevel = f
+	})
+}
+
+// withClock overrides the clock used to measure latency. This is
+// intentionally unexported.
+func withClock(clock zapcore.Clock) Option {
+	return optionFunc(func(cfg *handlerConfig) {
+		cfg.clock = clock
+	})
+}
+
+type handlerConfig struct {
+	headers       []string
+	sampleEvery   uint64
+	statusToLevel func(int) zapcore.Level
+	clock         zapcore.Clock
+}
+
+func defaultStatusToLevel(status int) zapcore.Level {
+	switch {
+	case status >= 500:
+		return zapcore.ErrorLevel
+	case status >= 400:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
+
+// Handler returns middleware that logs every request served by the wrapped
+// handler once it completes. Each entry records the method, path, status
+// code, number of response bytes written, latency, and remote address.
+//
+// The wrapped handler receives a request whose context carries a logger
+// annotated with the request's method and path; retrieve it with
+// FromContext.
+//
+//	mux := http.NewServeMux()
+//	http.ListenAndServe(addr, zaphttp.Handler(logger)(mux))
+func Handler(logger *zap.Logger, opts ...Option) func(http.Handler) http.Handler {
+	cfg := handlerConfig{
+		statusToLevel: defaultStatusToLevel,
+		clock:         zapcore.DefaultClock,
+	}
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return &handler{
+			logger: logger,
+			next:   next,
+			cfg:    cfg,
+		}
+	}
+}
+
+type handler struct {
+	logger    *zap.Logger
+	next      http.Handler
+	cfg       handlerConfig
+	successes atomic.Uint64
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := h.cfg.clock.Now()
+
+	fields := make([]zap.Field, 0, 7+len(h.cfg.headers))
+	fields = append(fields,
+		zap.String("http.method", r.Method),
+		zap.String("http.path", r.URL.Path),
+	)
+	reqLogger := h.logger.With(fields...)
+
+	rw := &responseWriter{ResponseWriter: w}
+	h.next.ServeHTTP(rw, r.WithContext(NewContext(r.Context(), reqLogger)))
+
+	status := rw.status
+	if status == 0 {
+		// The handler never wrote anything, so net/http will reply with 200.
+		status = http.StatusOK
+	}
+	if !h.sampled(status) {
+		return
+	}
+
+	ce := h.logger.Check(h.cfg.statusToLevel(status), "handled request")
+	if ce == nil {
+		return
+	}
+	fields = append(fields,
+		zap.Int("http.status", status),
+		zap.Int64("http.bytes", rw.bytes),
+		zap.Duration("http.duration", h.cfg.clock.Now().Sub(start)),
+		zap.String("http.remote_addr", r.RemoteAddr),
+	)
+	for _, name := range h.cfg.headers {
+		if vals := r.Header.Values(name); len(vals) > 0 {
+			fields = append(fields, zap.Strings("http.header."+strings.ToLower(name), vals))
+		}
+	}
+	ce.Write(fields...)
+}
+
+// sampled reports whether a request that finished with the given status
+// should be logged.
+func (h *handler) sampled(status int) bool {
+	if h.cfg.sampleEvery < 2 || status < 200 || status >= 300 {
+		return true
+	}
+	return (h.successes.Add(1)-1)%h.cfg.sampleEvery == 0
+}
+
+// responseWriter records the status code and number of bytes written
+// through an http.ResponseWriter.
+type responseWriter struct {
+	http.ResponseWriter
+
+	status int
+	bytes  int64
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(bs []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(bs)
+	w.bytes += int64(n)
+	return n, err
+}
+
+// Flush implements http.Flusher if the underlying ResponseWriter does.
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		f.Flush()
+	}
+}
+
+// Unwrap returns the underlying ResponseWriter for use with
+// http.ResponseController.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx that carries the given logger.
+func NewContext(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx. Inside a handler wrapped by
+// Handler, this is annotated with the request's method and path. If ctx
+// carries no logger, FromContext returns the global logger; see zap.L.
+func FromContext(ctx context.Context) *zap.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*zap.Logger); ok {
+		return logger
+	}
+	return zap.L()
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zaphttp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// stepClock advances by a fixed step every time it's read.
+type stepClock struct {
+	now  time.Time
+	step time.Duration
+}
+
+func (c *stepClock) Now() time.Time {
+	t := c.now
+	c.now = c.now.Add(c.step)
+	return t
+}
+
+func (c *stepClock) NewTicker(d time.Duration) *time.Ticker {
+	return time.NewTicker(d)
+}
+
+func serve(h http.Handler, r *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+	return rec
+}
+
+func TestHandler(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	mw := Handler(zap.New(core),
+		WithHeaders("user-agent", "X-Missing"),
+		withClock(&stepClock{step: 5 * time.Millisecond}),
+	)
+	h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		FromContext(r.Context()).Info("inside")
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, "hello")
+	}))
+
+	r := httptest.NewRequest("POST", "/users?id=1", nil)
+	r.Header.Set("User-Agent", "test")
+	rec := serve(h, r)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "hello", rec.Body.String())
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "inside", entries[0].Message)
+	assert.Equal(t, map[string]interface{}{
+		"http.method": "POST",
+		"http.path":   "/users",
+	}, entries[0].ContextMap(), "Request-scoped logger should carry method and path.")
+
+	assert.Equal(t, "handled request", entries[1].Message)
+	assert.Equal(t, zapcore.InfoLevel, entries[1].Level)
+	assert.Equal(t, map[string]interface{}{
+		"http.method":            "POST",
+		"http.path":              "/users",
+		"http.status":            int64(201),
+		"http.bytes":             int64(5),
+		"http.duration":          5 * time.Millisecond,
+		"http.remote_addr":       "192.0.2.1:1234",
+		"http.header.user-agent": []interface{}{"test"},
+	}, entries[1].ContextMap())
+}
+
+func TestHandlerStatusLevels(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []Option
+		status int
+		want   zapcore.Level
+	}{
+		{desc: "implicit OK", want: zapcore.InfoLevel},
+		{desc: "redirect", status: http.StatusFound, want: zapcore.InfoLevel},
+		{desc: "client error", status: http.StatusNotFound, want: zapcore.WarnLevel},
+		{desc: "server error", status: http.StatusBadGateway, want: zapcore.ErrorLevel},
+		{
+			desc: "custom",
+			opts: []Option{WithStatusLevels(func(int) zapcore.Level {
+				return zapcore.DebugLevel
+			})},
+			status: http.StatusInternalServerError,
+			want:   zapcore.DebugLevel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+			h := Handler(zap.New(core), tt.opts...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.status != 0 {
+					w.WriteHeader(tt.status)
+				}
+			}))
+			serve(h, httptest.NewRequest("GET", "/", nil))
+
+			entries := logs.AllUntimed()
+			require.Len(t, entries, 1)
+			assert.Equal(t, tt.want, entries[0].Level)
+		})
+	}
+}
+
+func TestHandlerSuccessSampling(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	h := Handler(zap.New(core), WithSuccessSampling(3))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+
+	for i := 0; i < 7; i++ {
+		serve(h, httptest.NewRequest("GET", "/ok", nil))
+	}
+	serve(h, httptest.NewRequest("GET", "/fail", nil))
+	serve(h, httptest.NewRequest("GET", "/fail", nil))
+
+	assert.Equal(t, 3, logs.FilterField(zap.Int("http.status", 200)).Len(), "Expected 1 in 3 successes to be logged.")
+	assert.Equal(t, 2, logs.FilterField(zap.Int("http.status", 500)).Len(), "Expected all failures to be logged.")
+}
+
+func TestResponseWriterFlush(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	h := Handler(zap.New(core))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f, ok := w.(http.Flusher)
+		require.True(t, ok, "Wrapped ResponseWriter must implement http.Flusher.")
+		f.Flush()
+		w.WriteHeader(http.StatusTeapot) // ignored after the flush
+	}))
+
+	rec := serve(h, httptest.NewRequest("GET", "/", nil))
+	assert.True(t, rec.Flushed)
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, int64(200), logs.All()[0].ContextMap()["http.status"])
+}
+
+func TestFromContextDefault(t *testing.T) {
+	assert.Equal(t, zap.L(), FromContext(httptest.NewRequest("GET", "/", nil).Context()))
+}
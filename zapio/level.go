@@ -0,0 +1,156 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapio
+
+import (
+	"bytes"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// _levelPrefixes are the level names that DetectLevel recognizes. The most
+// severe levels map to ErrorLevel so that writing to a Writer can't exit or
+// panic.
+var _levelPrefixes = []struct {
+	name []byte
+	lvl  zapcore.Level
+}{
+	{[]byte("DEBUG"), zapcore.DebugLevel},
+	{[]byte("TRACE"), zapcore.TraceLevel},
+	{[]byte("INFO"), zapcore.InfoLevel},
+	{[]byte("NOTICE"), zapcore.InfoLevel},
+	{[]byte("WARN"), zapcore.WarnLevel},
+	{[]byte("WARNING"), zapcore.WarnLevel},
+	{[]byte("ERROR"), zapcore.ErrorLevel},
+	{[]byte("ERR"), zapcore.ErrorLevel},
+	{[]byte("CRIT"), zapcore.ErrorLevel},
+	{[]byte("CRITICAL"), zapcore.ErrorLevel},
+	{[]byte("DPANIC"), zapcore.ErrorLevel},
+	{[]byte("PANIC"), zapcore.ErrorLevel},
+	{[]byte("FATAL"), zapcore.ErrorLevel},
+}
+
+// detectLevel looks for a level at the start of line, returning it along
+// with the rest of the line. If there's none, it returns lvl and the line
+// unchanged.
+func detectLevel(line []byte, lvl zapcore.Level) (zapcore.Level, []byte) {
+	if l, rest, ok := parseKlogHeader(line); ok {
+		return l, rest
+	}
+	if l, rest, ok := parseLevelName(line); ok {
+		return l, rest
+	}
+	return lvl, line
+}
+
+// parseKlogHeader parses the header that klog and glog put at the start of
+// each line:
+//
+//	Lmmdd hh:mm:ss.uuuuuu threadid file:line] msg
+//
+// where L is one of I, W, E, or F.
+func parseKlogHeader(line []byte) (zapcore.Level, []byte, bool) {
+	if len(line) < 9 || !isDigits(line[1:5]) || line[5] != ' ' || !isDigits(line[6:8]) || line[8] != ':' {
+		return 0, nil, false
+	}
+	var lvl zapcore.Level
+	switch line[0] {
+	case 'I':
+		lvl = zapcore.InfoLevel
+	case 'W':
+		lvl = zapcore.WarnLevel
+	case 'E', 'F':
+		lvl = zapcore.ErrorLevel
+	default:
+		return 0, nil, false
+	}
+	end := bytes.IndexByte(line, ']')
+	if end < 0 {
+		return 0, nil, false
+	}
+	return lvl, bytes.TrimLeft(line[end+1:], " "), true
+}
+
+// parseLevelName parses a level name at the start of a line. Names may be
+// in brackets, as in "[warn] msg", or followed by a colon, as in "Error:
+// msg", in which case they're matched regardless of case. Names followed by
+// a space, as in "WARN msg", must be in upper case, so that ordinary
+// sentences like "Info about..." aren't mistaken for levels.
+func parseLevelName(line []byte) (zapcore.Level, []byte, bool) {
+	rest := line
+	bracketed := len(rest) > 0 && rest[0] == '['
+	if bracketed {
+		rest = rest[1:]
+	}
+	n := 0
+	for n < len(rest) && isLetter(rest[n]) {
+		n++
+	}
+	word, rest := rest[:n], rest[n:]
+
+	switch {
+	case bracketed:
+		if len(rest) == 0 || rest[0] != ']' {
+			return 0, nil, false
+		}
+		rest = rest[1:]
+		if len(rest) > 0 && rest[0] == ':' {
+			rest = rest[1:]
+		}
+	case len(rest) > 0 && rest[0] == ':':
+		rest = rest[1:]
+	case len(rest) > 0 && (rest[0] == ' ' || rest[0] == '\t'):
+		if !isUpper(word) {
+			return 0, nil, false
+		}
+	default:
+		return 0, nil, false
+	}
+
+	for _, p := range _levelPrefixes {
+		if bytes.EqualFold(word, p.name) {
+			return p.lvl, bytes.TrimLeft(rest, " \t"), true
+		}
+	}
+	return 0, nil, false
+}
+
+func isDigits(bs []byte) bool {
+	for _, b := range bs {
+		if b < '0' || b > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isLetter(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
+
+func isUpper(bs []byte) bool {
+	for _, b := range bs {
+		if b < 'A' || b > 'Z' {
+			return false
+		}
+	}
+	return true
+}
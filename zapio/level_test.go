@@ -0,0 +1,64 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestDetectLevel(t *testing.T) {
+	tests := []struct {
+		give     string
+		wantLvl  zapcore.Level
+		wantRest string
+	}{
+		{"ERROR: boom", zapcore.ErrorLevel, "boom"},
+		{"error: boom", zapcore.ErrorLevel, "boom"},
+		{"WARN  careful", zapcore.WarnLevel, "careful"},
+		{"WARNING: careful", zapcore.WarnLevel, "careful"},
+		{"[info] hello", zapcore.InfoLevel, "hello"},
+		{"[DEBUG]: hello", zapcore.DebugLevel, "hello"},
+		{"PANIC oh no", zapcore.ErrorLevel, "oh no"},
+		{"I0102 15:04:05.000000    1234 main.go:42] hello", zapcore.InfoLevel, "hello"},
+		{"E0102 15:04:05.000000 1 x.go:1] bad", zapcore.ErrorLevel, "bad"},
+		{"F0102 15:04:05.000000 1 x.go:1] dead", zapcore.ErrorLevel, "dead"},
+
+		// Not levels.
+		{"Info about the build", zapcore.PanicLevel, "Info about the build"},
+		{"INFORMATION: nope", zapcore.PanicLevel, "INFORMATION: nope"},
+		{"http://example.com", zapcore.PanicLevel, "http://example.com"},
+		{"[warn no bracket", zapcore.PanicLevel, "[warn no bracket"},
+		{"ERROR", zapcore.PanicLevel, "ERROR"},
+		{"X0102 15:04:05.000000 1 x.go:1] hi", zapcore.PanicLevel, "X0102 15:04:05.000000 1 x.go:1] hi"},
+		{"I0102 15:04:05 no bracket", zapcore.PanicLevel, "I0102 15:04:05 no bracket"},
+		{"", zapcore.PanicLevel, ""},
+	}
+
+	for _, tt := range tests {
+		// PanicLevel is never detected, so it marks lines without a level.
+		lvl, rest := detectLevel([]byte(tt.give), zapcore.PanicLevel)
+		assert.Equal(t, tt.wantLvl, lvl, "Unexpected level for %q.", tt.give)
+		assert.Equal(t, tt.wantRest, string(rest), "Unexpected message for %q.", tt.give)
+	}
+}
@@ -24,6 +24,9 @@ package zapio
 import (
 	"bytes"
 	"io"
+	"sync"
+	"time"
+	"unicode/utf8"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -59,7 +62,40 @@ type Writer struct {
 	// If unspecified, defaults to Info.
 	Level zapcore.Level
 
+	// DetectLevel makes the Writer look for a level at the start of each
+	// line, logging the line at that level with the prefix removed. It
+	// recognizes level names such as "ERROR:", "WARN ", and "[debug]", as
+	// well as the headers of klog and glog, like
+	//
+	//	E0102 15:04:05.000000    1234 main.go:42] message
+	//
+	// Lines without a recognized prefix are logged at Level. Fatal and panic
+	// levels are logged at ErrorLevel, so that the Writer never exits or
+	// panics.
+	DetectLevel bool
+
+	// MaxLineLength, if positive, limits the size of each message. Longer
+	// lines are split into several entries, and every entry after the first
+	// carries a "continued": true field. Splitting also bounds the memory
+	// the Writer uses to buffer lines that have no newline yet.
+	MaxLineLength int
+
+	// IdleTimeout, if positive, flushes a partial line once the Writer has
+	// gone this long without receiving more data, instead of holding it
+	// until a newline arrives. If the line does continue later, the rest of
+	// it is logged with a "continued": true field.
+	IdleTimeout time.Duration
+
+	mu   sync.Mutex
 	buff bytes.Buffer
+
+	// continued is set when the data that follows continues a line that's
+	// already been partly logged at lineLevel.
+	continued bool
+	lineLevel zapcore.Level
+
+	idle    *time.Timer
+	idleGen uint64 // identifies the latest idle timer
 }
 
 var (
@@ -73,15 +109,20 @@ var (
 // Write will split the input on newlines and post each line as a new log entry
 // to the logger.
 func (w *Writer) Write(bs []byte) (n int, err error) {
-	// Skip all checks if the level isn't enabled.
-	if !w.Log.Core().Enabled(w.Level) {
+	// Skip all checks if the level isn't enabled. Lines may carry their own
+	// levels if DetectLevel is set, so we can't skip those.
+	if !w.DetectLevel && !w.Log.Core().Enabled(w.Level) {
 		return len(bs), nil
 	}
 
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
 	n = len(bs)
 	for len(bs) > 0 {
 		bs = w.writeLine(bs)
 	}
+	w.resetIdleTimer()
 
 	return n, nil
 }
@@ -93,6 +134,7 @@ func (w *Writer) writeLine(line []byte) (remaining []byte) {
 	if idx < 0 {
 		// If there are no newlines, buffer the entire string.
 		w.buff.Write(line)
+		w.logOversized()
 		return nil
 	}
 
@@ -102,7 +144,7 @@ func (w *Writer) writeLine(line []byte) (remaining []byte) {
 	// Fast path: if we don't have a partial message from a previous write
 	// in the buffer, skip the buffer and log directly.
 	if w.buff.Len() == 0 {
-		w.log(line)
+		w.logLine(line)
 		return
 	}
 
@@ -126,24 +168,125 @@ func (w *Writer) Close() error {
 // Sync flushes buffered data to the logger as a new log entry even if it
 // doesn't contain a newline.
 func (w *Writer) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
 	// Don't allow empty messages on explicit Sync calls or on Close
 	// because we don't want an extraneous empty message at the end of the
 	// stream -- it's common for files to end with a newline.
 	w.flush(false /* allowEmpty */)
+	w.resetIdleTimer()
 	return nil
 }
 
 // flush flushes the buffered data to the logger, allowing empty messages only
-// if the bool is set.
+// if the bool is set. It ends the current line.
 func (w *Writer) flush(allowEmpty bool) {
 	if allowEmpty || w.buff.Len() > 0 {
-		w.log(w.buff.Bytes())
+		w.logLine(w.buff.Bytes())
 	}
 	w.buff.Reset()
+	w.continued = false
 }
 
+// logLine logs the end of a line, splitting it if it's longer than
+// MaxLineLength. If the line continues one that's already been partly
+// logged, an empty remainder isn't logged.
+func (w *Writer) logLine(line []byte) {
+	line = w.startLine(line)
+	for w.MaxLineLength > 0 && len(line) > w.MaxLineLength {
+		cut := splitPoint(line, w.MaxLineLength)
+		w.log(line[:cut])
+		line = line[cut:]
+	}
+	if len(line) > 0 || !w.continued {
+		w.log(line)
+	}
+	w.continued = false
+}
+
+// logOversized logs the start of a buffered partial line while it's longer
+// than MaxLineLength.
+func (w *Writer) logOversized() {
+	if w.MaxLineLength <= 0 || w.buff.Len() <= w.MaxLineLength {
+		return
+	}
+	rest := w.startLine(w.buff.Bytes())
+	w.buff.Next(w.buff.Len() - len(rest)) // drop any level prefix
+	for w.buff.Len() > w.MaxLineLength {
+		w.log(w.buff.Next(splitPoint(w.buff.Bytes(), w.MaxLineLength)))
+	}
+}
+
+// startLine sets the level of a new line, detecting it from the line's
+// prefix if DetectLevel is set, and returns the line without that prefix.
+// Lines that continue one that's already been partly logged are returned
+// unchanged.
+func (w *Writer) startLine(line []byte) []byte {
+	if w.continued {
+		return line
+	}
+	w.lineLevel = w.Level
+	if w.DetectLevel {
+		w.lineLevel, line = detectLevel(line, w.Level)
+	}
+	return line
+}
+
+// log writes a single entry at the level of the current line. Entries after
+// the first of a line carry the continued field.
 func (w *Writer) log(b []byte) {
-	if ce := w.Log.Check(w.Level, string(b)); ce != nil {
+	continued := w.continued
+	w.continued = true
+
+	ce := w.Log.Check(w.lineLevel, string(b))
+	if ce == nil {
+		return
+	}
+	if continued {
+		ce.Write(zap.Bool("continued", true))
+	} else {
 		ce.Write()
 	}
 }
+
+// resetIdleTimer restarts the countdown to flushing a partial line, or stops
+// it if there's nothing to flush.
+func (w *Writer) resetIdleTimer() {
+	if w.idle != nil {
+		w.idle.Stop()
+		w.idle = nil
+	}
+	if w.IdleTimeout <= 0 || w.buff.Len() == 0 {
+		return
+	}
+	w.idleGen++
+	gen := w.idleGen
+	w.idle = time.AfterFunc(w.IdleTimeout, func() { w.flushIdle(gen) })
+}
+
+// flushIdle logs a partial line that's been buffered for IdleTimeout,
+// unless more data has arrived since the timer identified by gen was set.
+func (w *Writer) flushIdle(gen uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if gen != w.idleGen || w.buff.Len() == 0 {
+		return
+	}
+	w.log(w.startLine(w.buff.Bytes()))
+	w.buff.Reset()
+	w.idle = nil
+}
+
+// splitPoint returns the index at which to split b so that the first part
+// is at most max bytes long, without splitting a UTF-8 sequence if
+// possible.
+func splitPoint(b []byte, max int) int {
+	for cut := max; cut > 0; cut-- {
+		if utf8.RuneStart(b[cut]) {
+			return cut
+		}
+	}
+	return max
+}
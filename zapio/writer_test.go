@@ -23,6 +23,7 @@ package zapio
 import (
 	"io"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -190,6 +191,90 @@ func TestWrite_Sync(t *testing.T) {
 	})
 }
 
+func TestWriterDetectLevel(t *testing.T) {
+	t.Parallel()
+
+	core, observed := observer.New(zap.DebugLevel)
+	w := Writer{
+		Log:         zap.New(core),
+		Level:       zap.InfoLevel,
+		DetectLevel: true,
+	}
+
+	_, err := io.WriteString(&w, "ERROR: disk full\n"+
+		"[debug] starting\n"+
+		"W0102 15:04:05.000000    1234 main.go:42] slow\n"+
+		"FATAL exiting\n"+
+		"plain\n")
+	require.NoError(t, err, "Writer.Write failed.")
+	require.NoError(t, w.Close(), "Writer.Close failed.")
+
+	got := make([]zapcore.Entry, observed.Len())
+	for i, ent := range observed.AllUntimed() {
+		got[i] = ent.Entry
+	}
+	assert.Equal(t, []zapcore.Entry{
+		{Level: zap.ErrorLevel, Message: "disk full"},
+		{Level: zap.DebugLevel, Message: "starting"},
+		{Level: zap.WarnLevel, Message: "slow"},
+		{Level: zap.ErrorLevel, Message: "exiting"},
+		{Level: zap.InfoLevel, Message: "plain"},
+	}, got, "Logged entries do not match.")
+}
+
+func TestWriterMaxLineLength(t *testing.T) {
+	t.Parallel()
+
+	core, observed := observer.New(zap.InfoLevel)
+	w := Writer{
+		Log:           zap.New(core),
+		DetectLevel:   true,
+		MaxLineLength: 5,
+	}
+
+	for _, s := range []string{"WARN abcdefghij\nshort\n", "0123", "456789", "\n", "€€\n"} {
+		_, err := io.WriteString(&w, s)
+		require.NoError(t, err, "Writer.Write failed.")
+	}
+	require.NoError(t, w.Close(), "Writer.Close failed.")
+
+	continued := []zapcore.Field{zap.Bool("continued", true)}
+	assert.Equal(t, []observer.LoggedEntry{
+		{Entry: zapcore.Entry{Level: zap.WarnLevel, Message: "abcde"}, Context: []zapcore.Field{}},
+		{Entry: zapcore.Entry{Level: zap.WarnLevel, Message: "fghij"}, Context: continued},
+		{Entry: zapcore.Entry{Message: "short"}, Context: []zapcore.Field{}},
+		{Entry: zapcore.Entry{Message: "01234"}, Context: []zapcore.Field{}},
+		{Entry: zapcore.Entry{Message: "56789"}, Context: continued},
+		{Entry: zapcore.Entry{Message: "€"}, Context: []zapcore.Field{}},
+		{Entry: zapcore.Entry{Message: "€"}, Context: continued},
+	}, observed.AllUntimed(), "Logged entries do not match.")
+}
+
+func TestWriterIdleTimeout(t *testing.T) {
+	t.Parallel()
+
+	core, observed := observer.New(zap.InfoLevel)
+	w := Writer{
+		Log:         zap.New(core),
+		IdleTimeout: 10 * time.Millisecond,
+	}
+
+	_, err := io.WriteString(&w, "Password: ")
+	require.NoError(t, err, "Writer.Write failed.")
+	require.Eventually(t, func() bool { return observed.Len() == 1 }, time.Second, time.Millisecond,
+		"Expected the partial line to be flushed.")
+
+	_, err = io.WriteString(&w, "ok\nnext\n")
+	require.NoError(t, err, "Writer.Write failed.")
+	require.NoError(t, w.Close(), "Writer.Close failed.")
+
+	assert.Equal(t, []observer.LoggedEntry{
+		{Entry: zapcore.Entry{Message: "Password: "}, Context: []zapcore.Field{}},
+		{Entry: zapcore.Entry{Message: "ok"}, Context: []zapcore.Field{zap.Bool("continued", true)}},
+		{Entry: zapcore.Entry{Message: "next"}, Context: []zapcore.Field{}},
+	}, observed.AllUntimed(), "Logged entries do not match.")
+}
+
 func BenchmarkWriter(b *testing.B) {
 	tests := []struct {
 		name   string
@@ -0,0 +1,334 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapkafka
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap/zapcore"
+)
+
+// Core is a zapcore.Core that encodes entries and produces them to Kafka
+// from a background goroutine. Writes never block on Kafka: entries wait in
+// a bounded local queue, and are dropped if it's full.
+//
+// Cores derived from a Core with With share its queue. Call Stop once
+// logging is done to flush the queue and release the background goroutine.
+type Core struct {
+	zapcore.LevelEnabler
+
+	enc zapcore.Encoder
+	cfg *config
+	q   *queue
+
+	// Values of the topic and key fields added with With, if any.
+	topic  string
+	key    []byte
+	hasKey bool
+}
+
+var _ zapcore.Core = (*Core)(nil)
+
+// NewCore builds a Core that writes entries at enabled levels to Kafka
+// through the given Producer. Entries are encoded with enc; any trailing
+// line ending is trimmed from each message.
+func NewCore(enc zapcore.Encoder, producer Producer, enab zapcore.LevelEnabler, opts ...Option) *Core {
+	cfg := &config{
+		topic:         _defaultTopic,
+		levelTopics:   make(map[zapcore.Level]string),
+		batchSize:     _defaultBatchSize,
+		queueSize:     _defaultQueueSize,
+		flushInterval: _defaultFlushInterval,
+		clock:         zapcore.DefaultClock,
+	}
+	for _, opt := range opts {
+		opt.apply(cfg)
+	}
+	if cfg.batchSize < 1 {
+		cfg.batchSize = 1
+	}
+	if cfg.queueSize < 1 {
+		cfg.queueSize = 1
+	}
+
+	return &Core{
+		LevelEnabler: enab,
+		enc:          enc,
+		cfg:          cfg,
+		q:            newQueue(producer, cfg),
+	}
+}
+
+// Level returns the minimum enabled level for this core.
+func (c *Core) Level() zapcore.Level {
+	return zapcore.LevelOf(c.LevelEnabler)
+}
+
+// With adds structured context to the Core.
+func (c *Core) With(fields []zapcore.Field) zapcore.Core {
+	clone := c.clone()
+	for i := range fields {
+		fields[i].AddTo(clone.enc)
+	}
+	if topic, ok := findField(fields, c.cfg.topicField); ok {
+		clone.topic = topic
+	}
+	if key, ok := findField(fields, c.cfg.keyField); ok {
+		clone.key, clone.hasKey = []byte(key), true
+	}
+	return clone
+}
+
+// Check adds the Core to the CheckedEntry if the entry's level is enabled.
+func (c *Core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write encodes the entry and queues it for Kafka. Entries above ErrorLevel
+// are flushed before Write returns, since the process may be about to exit.
+func (c *Core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	value := bytes.TrimRight(buf.Bytes(), "\r\n")
+	msg := Message{
+		Topic: c.topicFor(ent.Level, fields),
+		Value: append(make([]byte, 0, len(value)), value...),
+		Time:  ent.Time,
+	}
+	buf.Free()
+
+	if key, ok := findField(fields, c.cfg.keyField); ok {
+		msg.Key = []byte(key)
+	} else if c.hasKey {
+		msg.Key = c.key
+	}
+
+	c.q.enqueue(msg)
+	if ent.Level > zapcore.ErrorLevel {
+		return c.Sync()
+	}
+	return nil
+}
+
+// Sync produces every queued message, and returns any errors reported by
+// the Producer since the previous Sync.
+func (c *Core) Sync() error {
+	return c.q.flush()
+}
+
+// Stop flushes the queue and stops the background goroutine. Entries
+// written after Stop are dropped. Stop is safe to call more than once; later
+// calls do nothing and return nil.
+func (c *Core) Stop() error {
+	return c.q.close()
+}
+
+// Stats reports the number of messages handled so far by this Core and
+// every Core that shares its queue.
+func (c *Core) Stats() Stats {
+	return Stats{
+		Enqueued: c.q.enqueued.Load(),
+		Dropped:  c.q.dropped.Load(),
+		Sent:     c.q.sent.Load(),
+		Failed:   c.q.failed.Load(),
+	}
+}
+
+func (c *Core) clone() *Core {
+	clone := *c
+	clone.enc = c.enc.Clone()
+	return &clone
+}
+
+func (c *Core) topicFor(lvl zapcore.Level, fields []zapcore.Field) string {
+	if topic, ok := findField(fields, c.cfg.topicField); ok {
+		return topic
+	}
+	if c.topic != "" {
+		return c.topic
+	}
+	if topic, ok := c.cfg.levelTopics[lvl]; ok {
+		return topic
+	}
+	return c.cfg.topic
+}
+
+// findField returns the value of the last field with the given key,
+// rendered as a string.
+func findField(fields []zapcore.Field, key string) (string, bool) {
+	if key == "" {
+		return "", false
+	}
+	for i := len(fields) - 1; i >= 0; i-- {
+		f := fields[i]
+		if f.Key != key {
+			continue
+		}
+		if f.Type == zapcore.StringType {
+			return f.String, true
+		}
+		enc := zapcore.NewMapObjectEncoder()
+		f.AddTo(enc)
+		if v, ok := enc.Fields[key]; ok {
+			return fmt.Sprint(v), true
+		}
+	}
+	return "", false
+}
+
+// queue batches messages for a Producer on a background goroutine.
+type queue struct {
+	producer  Producer
+	batchSize int
+
+	msgs    chan Message
+	flushes chan chan error
+	stop    chan struct{} // closed when run should stop
+	done    chan struct{} // closed when run has stopped
+
+	mu      sync.RWMutex // guards stopped against concurrent enqueues
+	stopped bool
+	stopErr error // set by run before done is closed
+
+	enqueued, dropped, sent, failed atomic.Uint64
+}
+
+func newQueue(producer Producer, cfg *config) *queue {
+	q := &queue{
+		producer:  producer,
+		batchSize: cfg.batchSize,
+		msgs:      make(chan Message, cfg.queueSize),
+		flushes:   make(chan chan error),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go q.run(cfg.clock.NewTicker(cfg.flushInterval))
+	return q
+}
+
+func (q *queue) enqueue(msg Message) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.stopped {
+		q.dropped.Add(1)
+		return
+	}
+	select {
+	case q.msgs <- msg:
+		q.enqueued.Add(1)
+	default:
+		q.dropped.Add(1)
+	}
+}
+
+func (q *queue) flush() error {
+	errc := make(chan error, 1)
+	select {
+	case q.flushes <- errc:
+		return <-errc
+	case <-q.done:
+		return nil
+	}
+}
+
+func (q *queue) close() error {
+	q.mu.Lock()
+	if q.stopped {
+		q.mu.Unlock()
+		return nil
+	}
+	q.stopped = true
+	q.mu.Unlock()
+
+	close(q.stop)
+	<-q.done
+	return q.stopErr
+}
+
+func (q *queue) run(ticker *time.Ticker) {
+	defer close(q.done)
+	defer ticker.Stop()
+
+	var (
+		batch = make([]Message, 0, q.batchSize)
+		err   error // since the last flush
+	)
+	send := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if perr := q.producer.Produce(batch); perr != nil {
+			q.failed.Add(uint64(len(batch)))
+			err = multierr.Append(err, perr)
+		} else {
+			q.sent.Add(uint64(len(batch)))
+		}
+		for i := range batch {
+			batch[i] = Message{} // don't pin the encoded entries
+		}
+		batch = batch[:0]
+	}
+	add := func(msg Message) {
+		batch = append(batch, msg)
+		if len(batch) >= q.batchSize {
+			send()
+		}
+	}
+	drain := func() {
+		for {
+			select {
+			case msg := <-q.msgs:
+				add(msg)
+			default:
+				send()
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case msg := <-q.msgs:
+			add(msg)
+		case <-ticker.C:
+			send()
+		case errc := <-q.flushes:
+			drain()
+			errc <- err
+			err = nil
+		case <-q.stop:
+			drain()
+			q.stopErr = err
+			return
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapkafka
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/internal/ztest"
+	"go.uber.org/zap/zapcore"
+)
+
+// fakeProducer records every batch it's asked to produce.
+type fakeProducer struct {
+	mu      sync.Mutex
+	batches [][]Message
+	err     error
+
+	// If non-nil, Produce signals started and then waits for release.
+	started chan struct{}
+	release chan struct{}
+}
+
+func (p *fakeProducer) Produce(msgs []Message) error {
+	if p.started != nil {
+		p.started <- struct{}{}
+		<-p.release
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.batches = append(p.batches, append([]Message(nil), msgs...))
+	return p.err
+}
+
+func (p *fakeProducer) Batches() [][]Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.batches
+}
+
+func newTestEncoder() zapcore.Encoder {
+	return zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+		MessageKey: "msg",
+		LineEnding: zapcore.DefaultLineEnding,
+	})
+}
+
+func TestCoreRouting(t *testing.T) {
+	producer := &fakeProducer{}
+	core := NewCore(newTestEncoder(), producer, zapcore.DebugLevel,
+		Topic("app"),
+		TopicByLevel(map[zapcore.Level]string{zapcore.ErrorLevel: "errors"}),
+		TopicField("topic"),
+		KeyField("user"),
+	)
+	defer func() { assert.NoError(t, core.Stop()) }()
+	logger := zap.New(core)
+
+	logger.Info("default")
+	logger.Error("by level", zap.Int("user", 42))
+	logger.With(zap.String("topic", "audit"), zap.String("user", "jane")).Error("by context")
+	logger.With(zap.String("topic", "audit")).Info("by field", zap.String("topic", "billing"))
+	require.NoError(t, logger.Sync())
+
+	batches := producer.Batches()
+	require.Len(t, batches, 1, "Expected a single batch.")
+	msgs := batches[0]
+	require.Len(t, msgs, 4)
+	for i := range msgs {
+		assert.False(t, msgs[i].Time.IsZero(), "Expected entry time on message %d.", i)
+		msgs[i].Time = time.Time{}
+	}
+
+	assert.Equal(t, Message{Topic: "app", Value: []byte(`{"msg":"default"}`)}, msgs[0])
+	assert.Equal(t, Message{Topic: "errors", Key: []byte("42"), Value: []byte(`{"msg":"by level","user":42}`)}, msgs[1])
+	assert.Equal(t, "audit", msgs[2].Topic, "Topic field from With should take precedence over the level.")
+	assert.Equal(t, []byte("jane"), msgs[2].Key)
+	assert.Equal(t, "billing", msgs[3].Topic, "Topic field at the log site should take precedence over With.")
+	assert.Nil(t, msgs[3].Key)
+
+	assert.Equal(t, Stats{Enqueued: 4, Sent: 4}, core.Stats())
+}
+
+func TestCoreBatchSize(t *testing.T) {
+	producer := &fakeProducer{}
+	core := NewCore(newTestEncoder(), producer, zapcore.DebugLevel, BatchSize(2))
+	logger := zap.New(core)
+
+	for i := 0; i < 5; i++ {
+		logger.Info("hello")
+	}
+	require.NoError(t, core.Stop())
+
+	var sizes []int
+	for _, b := range producer.Batches() {
+		sizes = append(sizes, len(b))
+	}
+	assert.Equal(t, []int{2, 2, 1}, sizes)
+}
+
+func TestCoreFlushInterval(t *testing.T) {
+	clock := ztest.NewMockClock()
+	producer := &fakeProducer{}
+	core := NewCore(newTestEncoder(), producer, zapcore.DebugLevel,
+		FlushInterval(time.Minute),
+		WithClock(clock),
+	)
+	defer core.Stop()
+
+	zap.New(core).Info("hello")
+	assert.Never(t, func() bool { return len(producer.Batches()) > 0 },
+		10*time.Millisecond, time.Millisecond, "Produced before the flush interval.")
+
+	clock.Add(time.Minute)
+	assert.Eventually(t, func() bool { return len(producer.Batches()) == 1 },
+		time.Second, time.Millisecond, "Expected a flush after the interval.")
+}
+
+func TestCoreDropsWhenFull(t *testing.T) {
+	producer := &fakeProducer{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	core := NewCore(newTestEncoder(), producer, zapcore.DebugLevel, BatchSize(1), QueueSize(1))
+	logger := zap.New(core)
+
+	logger.Info("in flight")
+	<-producer.started // the first message has left the queue
+	logger.Info("queued")
+	logger.Info("dropped")
+	assert.Equal(t, Stats{Enqueued: 2, Dropped: 1}, core.Stats())
+
+	go func() {
+		for range producer.started {
+		}
+	}()
+	close(producer.release)
+	require.NoError(t, core.Stop())
+	close(producer.started)
+
+	assert.Equal(t, Stats{Enqueued: 2, Dropped: 1, Sent: 2}, core.Stats())
+}
+
+func TestCoreProducerErrors(t *testing.T) {
+	producer := &fakeProducer{err: errors.New("broker unavailable")}
+	core := NewCore(newTestEncoder(), producer, zapcore.DebugLevel)
+	logger := zap.New(core)
+
+	logger.Info("hello")
+	assert.EqualError(t, core.Sync(), "broker unavailable")
+	assert.NoError(t, core.Sync(), "Errors should be reported only once.")
+
+	logger.Info("again")
+	assert.EqualError(t, core.Stop(), "broker unavailable")
+	assert.Equal(t, Stats{Enqueued: 2, Failed: 2}, core.Stats())
+}
+
+func TestCoreStop(t *testing.T) {
+	producer := &fakeProducer{}
+	core := NewCore(newTestEncoder(), producer, zapcore.InfoLevel)
+	logger := zap.New(core)
+
+	logger.Info("before")
+	require.NoError(t, core.Stop())
+	assert.NoError(t, core.Stop(), "Stop should be idempotent.")
+
+	logger.Info("after")
+	assert.NoError(t, core.Sync(), "Sync after Stop should be a no-op.")
+	assert.Equal(t, Stats{Enqueued: 1, Dropped: 1, Sent: 1}, core.Stats())
+	assert.Equal(t, zapcore.InfoLevel, zapcore.LevelOf(core))
+	assert.Nil(t, core.Check(zapcore.Entry{Level: zapcore.DebugLevel}, nil), "Debug should be disabled.")
+}
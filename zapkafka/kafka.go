@@ -0,0 +1,168 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zapkafka provides a zapcore.Core that ships encoded entries to
+// Kafka in batches.
+//
+// Zap doesn't depend on any particular Kafka client. Instead, the Core hands
+// batches of messages to a Producer, which applications implement with the
+// client of their choice:
+//
+//	type producer struct{ w *kafka.Writer }
+//
+//	func (p producer) Produce(msgs []zapkafka.Message) error {
+//		...
+//	}
+//
+//	core := zapkafka.NewCore(enc, producer{w}, zapcore.InfoLevel,
+//		zapkafka.Topic("logs"),
+//		zapkafka.KeyField("request_id"),
+//	)
+//	defer core.Stop()
+//	logger := zap.New(core)
+package zapkafka // import "go.uber.org/zap/zapkafka"
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// A Message is a single encoded entry bound for Kafka.
+type Message struct {
+	Topic string
+	Key   []byte // nil if the entry has no key
+	Value []byte
+	Time  time.Time
+}
+
+// A Producer sends batches of messages to Kafka, ideally as a single produce
+// request. Batches may mix topics.
+//
+// Produce is only ever called from one goroutine at a time, and must not
+// retain msgs after it returns.
+type Producer interface {
+	Produce(msgs []Message) error
+}
+
+// Stats counts the messages handled by a Core.
+type Stats struct {
+	Enqueued uint64 // accepted into the local queue
+	Dropped  uint64 // discarded because the queue was full or the Core was stopped
+	Sent     uint64 // produced successfully
+	Failed   uint64 // rejected by the Producer
+}
+
+// An Option configures a Core.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (f optionFunc) apply(cfg *config) {
+	f(cfg)
+}
+
+const (
+	_defaultTopic         = "logs"
+	_defaultBatchSize     = 100
+	_defaultQueueSize     = 10000
+	_defaultFlushInterval = time.Second
+)
+
+type config struct {
+	topic         string
+	levelTopics   map[zapcore.Level]string
+	topicField    string
+	keyField      string
+	batchSize     int
+	queueSize     int
+	flushInterval time.Duration
+	clock         zapcore.Clock
+}
+
+// Topic sets the topic for entries that no other option assigns a topic
+// to. Defaults to "logs".
+func Topic(name string) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.topic = name
+	})
+}
+
+// TopicByLevel routes entries at the given levels to their own topics,
+// overriding the default topic.
+func TopicByLevel(topics map[zapcore.Level]string) Option {
+	return optionFunc(func(cfg *config) {
+		for lvl, topic := range topics {
+			cfg.levelTopics[lvl] = topic
+		}
+	})
+}
+
+// TopicField routes entries that have a field with the given key, whether
+// added with With or at the log site, to the topic named by the field's
+// value. It takes precedence over TopicByLevel and Topic.
+func TopicField(key string) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.topicField = key
+	})
+}
+
+// KeyField uses the value of the field with the given key as the partition
+// key of each message. Entries without the field are sent without a key.
+func KeyField(key string) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.keyField = key
+	})
+}
+
+// BatchSize sets the maximum number of messages passed to each Produce call.
+// Defaults to 100.
+func BatchSize(n int) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.batchSize = n
+	})
+}
+
+// QueueSize sets how many messages may wait to be produced. Once the queue
+// is full, further entries are dropped rather than blocking the caller, and
+// counted in Stats. Defaults to 10,000.
+func QueueSize(n int) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.queueSize = n
+	})
+}
+
+// FlushInterval sets how long a partial batch may wait before it's
+// produced. Defaults to one second.
+func FlushInterval(d time.Duration) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.flushInterval = d
+	})
+}
+
+// WithClock sets the clock used to schedule flushes. Defaults to the system
+// clock.
+func WithClock(clock zapcore.Clock) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.clock = clock
+	})
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zapmetrics counts log entries by level and logger name, so that
+// services can alert on error-log rates without writing a custom Core.
+//
+// Wrap a Core with NewCore and a Counter that exports the counts, either to
+// Prometheus or to expvar:
+//
+//	counter, err := zapmetrics.Prometheus(prometheus.DefaultRegisterer)
+//	if err != nil {
+//	  return err
+//	}
+//	logger := zap.New(zapmetrics.NewCore(core, counter))
+package zapmetrics // import "go.uber.org/zap/zapmetrics"
+
+import "go.uber.org/zap/zapcore"
+
+// MetricName is the name under which entry counts are exported. The counts
+// are labeled by level and logger name.
+const MetricName = "log_entries_total"
+
+// A Counter counts log entries. Implementations must be safe for concurrent
+// use.
+type Counter interface {
+	Inc(lvl zapcore.Level, loggerName string)
+}
+
+type core struct {
+	zapcore.Core
+
+	counter Counter
+}
+
+// NewCore wraps a Core so that every entry it writes is counted by the given
+// Counter. Entries the wrapped Core declines to log aren't counted.
+func NewCore(c zapcore.Core, counter Counter) zapcore.Core {
+	return &core{Core: c, counter: counter}
+}
+
+// Middleware returns a zapcore.Middleware that wraps a Core with NewCore, for
+// use with zapcore.Chain.
+func Middleware(counter Counter) zapcore.Middleware {
+	return func(c zapcore.Core) zapcore.Core {
+		return NewCore(c, counter)
+	}
+}
+
+func (c *core) Level() zapcore.Level {
+	return zapcore.LevelOf(c.Core)
+}
+
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	return &core{Core: c.Core.With(fields), counter: c.counter}
+}
+
+func (c *core) AccumulatedFields() []zapcore.Field {
+	return zapcore.AccumulatedFields(c.Core)
+}
+
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	// Let the wrapped Core decide whether to log this entry, registering
+	// itself directly with the CheckedEntry.
+	if downstream := c.Core.Check(ent, ce); downstream != nil {
+		return downstream.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *core) Write(ent zapcore.Entry, _ []zapcore.Field) error {
+	// The wrapped Core registered itself with the CheckedEntry, so there's
+	// nothing to write here.
+	c.counter.Inc(ent.Level, ent.LoggerName)
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapmetrics
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type countKey struct {
+	level  zapcore.Level
+	logger string
+}
+
+type mapCounter map[countKey]int
+
+func (m mapCounter) Inc(lvl zapcore.Level, loggerName string) {
+	m[countKey{lvl, loggerName}]++
+}
+
+func TestCore(t *testing.T) {
+	obs, logs := observer.New(zapcore.InfoLevel)
+	counts := mapCounter{}
+	logger := zap.New(NewCore(obs, counts)).With(zap.String("k", "v"))
+
+	logger.Debug("disabled")
+	logger.Info("root")
+	logger.Named("http").Error("failed")
+	logger.Named("http").Error("failed again")
+
+	assert.Equal(t, mapCounter{
+		{zapcore.InfoLevel, ""}:      1,
+		{zapcore.ErrorLevel, "http"}: 2,
+	}, counts, "Unexpected counts.")
+	assert.Equal(t, 3, logs.Len(), "Expected entries to reach the wrapped core.")
+	assert.Equal(t, zapcore.InfoLevel, zapcore.LevelOf(NewCore(obs, counts)), "Unexpected level.")
+	for _, ent := range logs.AllUntimed() {
+		assert.Equal(t, map[string]interface{}{"k": "v"}, ent.ContextMap(), "Expected fields to reach the wrapped core.")
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	obs, logs := observer.New(zapcore.InfoLevel)
+	counts := mapCounter{}
+	logger := zap.New(zapcore.Chain(obs, Middleware(counts)))
+
+	logger.Info("counted")
+	assert.Equal(t, mapCounter{{zapcore.InfoLevel, ""}: 1}, counts, "Unexpected counts.")
+	assert.Equal(t, 1, logs.Len(), "Expected entry to reach the wrapped core.")
+}
+
+func TestPrometheus(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	counter, err := Prometheus(reg)
+	require.NoError(t, err, "Unexpected error registering counter.")
+
+	obs, _ := observer.New(zapcore.DebugLevel)
+	logger := zap.New(NewCore(obs, counter))
+	logger.Warn("first")
+	logger.Named("db").Warn("second")
+	logger.Named("db").Warn("third")
+
+	// A second Counter registered with the same Registerer shares the metric.
+	again, err := Prometheus(reg)
+	require.NoError(t, err, "Expected re-registration to reuse the collector.")
+	again.Inc(zapcore.ErrorLevel, "db")
+
+	vec := counter.(*promCounter).vec
+	assert.Equal(t, 1.0, testutil.ToFloat64(vec.WithLabelValues("warn", "")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(vec.WithLabelValues("warn", "db")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(vec.WithLabelValues("error", "db")))
+
+	conflicting := prometheus.NewRegistry()
+	conflicting.MustRegister(prometheus.NewCounter(prometheus.CounterOpts{Name: MetricName, Help: "other"}))
+	_, err = Prometheus(conflicting)
+	assert.Error(t, err, "Expected an error when the name is taken by an incompatible collector.")
+}
+
+func TestExpvar(t *testing.T) {
+	counter := Expvar("zapmetrics_test_entries")
+	obs, _ := observer.New(zapcore.DebugLevel)
+	logger := zap.New(NewCore(obs, counter))
+
+	logger.Info("root")
+	logger.Named("http").Error("failed")
+	counter.Inc(zapcore.Level(42), "custom")
+
+	var got map[string]map[string]int
+	require.NoError(t, json.Unmarshal([]byte(counter.(*expvarCounter).root.String()), &got))
+	assert.Equal(t, 1, got["info"][""], "Unexpected info count.")
+	assert.Equal(t, 1, got["error"]["http"], "Unexpected error count.")
+	assert.Equal(t, 1, got[zapcore.Level(42).String()]["custom"], "Unexpected count for a custom level.")
+	assert.Empty(t, got["debug"], "Expected no debug entries.")
+
+	assert.Panics(t, func() { Expvar("zapmetrics_test_entries") }, "Expected duplicate names to panic.")
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapmetrics
+
+import (
+	"expvar"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type expvarCounter struct {
+	root *expvar.Map
+
+	mu     sync.RWMutex
+	levels map[zapcore.Level]*expvar.Map
+}
+
+// Expvar returns a Counter that publishes entry counts as an expvar.Map
+// with the given name, nested by level and then by logger name:
+//
+//	{"error": {"": 2, "http": 1}, "info": {"http": 40}}
+//
+// Like expvar.Publish, it panics if a variable with the same name is already
+// published.
+func Expvar(name string) Counter {
+	c := &expvarCounter{
+		root:   expvar.NewMap(name),
+		levels: make(map[zapcore.Level]*expvar.Map),
+	}
+	for lvl := zapcore.TraceLevel; lvl <= zapcore.FatalLevel; lvl++ {
+		c.levelMap(lvl)
+	}
+	return c
+}
+
+func (c *expvarCounter) Inc(lvl zapcore.Level, loggerName string) {
+	c.levelMap(lvl).Add(loggerName, 1)
+}
+
+// levelMap returns the map that holds the counts for lvl, creating it if
+// necessary.
+func (c *expvarCounter) levelMap(lvl zapcore.Level) *expvar.Map {
+	c.mu.RLock()
+	m, ok := c.levels[lvl]
+	c.mu.RUnlock()
+	if ok {
+		return m
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if m, ok := c.levels[lvl]; ok {
+		return m
+	}
+	m = new(expvar.Map).Init()
+	c.levels[lvl] = m
+	c.root.Set(lvl.String(), m)
+	return m
+}
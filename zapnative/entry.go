@@ -0,0 +1,180 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapnative
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// The native sinks receive encoded entries, like any other zap.Sink. To map
+// entries onto each system's native severities, they scan entries produced
+// by the JSON encoder; other encodings are passed through as plain messages.
+const (
+	_defaultLevelKey   = "level"
+	_defaultMessageKey = "msg"
+)
+
+// entryKeys are the keys under which a native sink finds the level and
+// message of JSON-encoded entries.
+type entryKeys struct {
+	Level   string
+	Message string
+}
+
+// newEntryKeys reads the levelKey and messageKey query parameters of a sink
+// URL, falling back to the keys used by zap.NewProductionEncoderConfig.
+func newEntryKeys(q url.Values) entryKeys {
+	keys := entryKeys{Level: _defaultLevelKey, Message: _defaultMessageKey}
+	if k := q.Get("levelKey"); k != "" {
+		keys.Level = k
+	}
+	if k := q.Get("messageKey"); k != "" {
+		keys.Message = k
+	}
+	return keys
+}
+
+// parse scans a single encoded entry, returning its level and message and
+// calling fn, if it's non-nil, with every other member in the order they
+// were encoded. Strings are unquoted and everything else is kept in its
+// JSON form; the values may alias bs, so fn mustn't retain them.
+//
+// If bs isn't a JSON object, the whole of bs (less any trailing line ending)
+// is the message and the level is InfoLevel.
+func (k entryKeys) parse(bs []byte, fn func(key, value []byte)) (zapcore.Level, []byte) {
+	bs = bytes.TrimRight(bs, "\r\n")
+	lvl, msg := zapcore.InfoLevel, bs
+	if len(bs) == 0 || bs[0] != '{' || !json.Valid(bs) {
+		return lvl, msg
+	}
+
+	msg = nil
+	eachMember(bs, func(rawKey, rawValue []byte) {
+		key, value := unquote(rawKey), unquote(rawValue)
+		switch string(key) {
+		case k.Level:
+			var l zapcore.Level
+			if l.UnmarshalText(value) == nil {
+				lvl = l
+				return
+			}
+		case k.Message:
+			msg = value
+			return
+		}
+		if fn != nil {
+			fn(key, value)
+		}
+	})
+	return lvl, msg
+}
+
+// eachMember calls fn with the raw key and value of each member of the JSON
+// object in bs, which must be valid.
+func eachMember(bs []byte, fn func(key, value []byte)) {
+	i := skipSpace(bs, 1) // past the opening brace
+	for i < len(bs) && bs[i] != '}' {
+		keyEnd := skipValue(bs, i)
+		valueStart := skipSpace(bs, skipSpace(bs, keyEnd)+1) // past the colon
+		valueEnd := skipValue(bs, valueStart)
+		fn(bs[i:keyEnd], bs[valueStart:valueEnd])
+
+		i = skipSpace(bs, valueEnd)
+		if i < len(bs) && bs[i] == ',' {
+			i = skipSpace(bs, i+1)
+		}
+	}
+}
+
+// skipValue returns the index just past the JSON value starting at bs[i].
+func skipValue(bs []byte, i int) int {
+	depth := 0
+	for ; i < len(bs); i++ {
+		switch bs[i] {
+		case '"':
+			i = skipString(bs, i)
+			if depth == 0 {
+				return i
+			}
+			i-- // the loop advances past the closing quote
+		case '{', '[':
+			depth++
+		case '}', ']':
+			if depth == 0 {
+				return i
+			}
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		case ',', ' ', '\t', '\r', '\n':
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return i
+}
+
+// skipString returns the index just past the JSON string starting at bs[i].
+func skipString(bs []byte, i int) int {
+	for i++; i < len(bs); i++ {
+		switch bs[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return i
+}
+
+func skipSpace(bs []byte, i int) int {
+	for i < len(bs) {
+		switch bs[i] {
+		case ' ', '\t', '\r', '\n':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// unquote returns the contents of a JSON string, or raw unchanged if it
+// isn't one. Only strings with escape sequences are copied.
+func unquote(raw []byte) []byte {
+	if len(raw) < 2 || raw[0] != '"' {
+		return raw
+	}
+	if bytes.IndexByte(raw, '\\') < 0 {
+		return raw[1 : len(raw)-1]
+	}
+	var s string
+	if json.Unmarshal(raw, &s) != nil {
+		return raw
+	}
+	return []byte(s)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapnative
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestEntryKeysParse(t *testing.T) {
+	type member struct{ Key, Value string }
+	tests := []struct {
+		desc        string
+		keys        url.Values
+		give        string
+		wantLevel   zapcore.Level
+		wantMessage string
+		wantMembers []member
+	}{
+		{
+			desc:        "JSON",
+			give:        `{"level":"warn","ts":1.5,"msg":"hello","user":{"id":42,"tags":["a","}"]},"name":"jane"}` + "\n",
+			wantLevel:   zapcore.WarnLevel,
+			wantMessage: "hello",
+			wantMembers: []member{
+				{"ts", "1.5"},
+				{"user", `{"id":42,"tags":["a","}"]}`},
+				{"name", "jane"},
+			},
+		},
+		{
+			desc:        "escapes and whitespace",
+			give:        `{ "msg" : "say \"hi\"\n", "key" : [ 1, 2 ] , "ok": true }`,
+			wantLevel:   zapcore.InfoLevel,
+			wantMessage: "say \"hi\"\n",
+			wantMembers: []member{{"key", "[ 1, 2 ]"}, {"ok", "true"}},
+		},
+		{
+			desc:        "custom keys and capitalized level",
+			keys:        url.Values{"levelKey": {"severity"}, "messageKey": {"message"}},
+			give:        `{"severity":"ERROR","message":"oops","msg":"kept"}`,
+			wantLevel:   zapcore.ErrorLevel,
+			wantMessage: "oops",
+			wantMembers: []member{{"msg", "kept"}},
+		},
+		{
+			desc:        "unrecognized level",
+			give:        `{"level":"loud","msg":"hi"}`,
+			wantLevel:   zapcore.InfoLevel,
+			wantMessage: "hi",
+			wantMembers: []member{{"level", "loud"}},
+		},
+		{
+			desc:        "console",
+			give:        "2026-01-01T00:00:00Z\tERROR\toops\n",
+			wantLevel:   zapcore.InfoLevel,
+			wantMessage: "2026-01-01T00:00:00Z\tERROR\toops",
+		},
+		{
+			desc:        "invalid JSON",
+			give:        `{"level":`,
+			wantLevel:   zapcore.InfoLevel,
+			wantMessage: `{"level":`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var members []member
+			lvl, msg := newEntryKeys(tt.keys).parse([]byte(tt.give), func(key, value []byte) {
+				members = append(members, member{string(key), string(value)})
+			})
+			assert.Equal(t, tt.wantLevel, lvl, "Unexpected level.")
+			assert.Equal(t, tt.wantMessage, string(msg), "Unexpected message.")
+			assert.Equal(t, tt.wantMembers, members, "Unexpected members.")
+		})
+	}
+}
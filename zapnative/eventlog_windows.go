@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build windows
+
+package zapnative
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/sys/windows/svc/eventlog"
+)
+
+const schemeEventLog = "eventlog"
+
+func nativeSinks() map[string]func(*url.URL) (zap.Sink, error) {
+	return map[string]func(*url.URL) (zap.Sink, error){
+		schemeEventLog: newEventLogSink,
+	}
+}
+
+// eventLogSink reports entries to the Windows Event Log. Each entry becomes
+// one event whose type follows the entry's level and whose message is the
+// encoded entry.
+//
+// eventlog URLs have the form
+//
+//	eventlog://[source][?eventID=1&levelKey=level]
+//
+// The source defaults to the name of the executable. Sources should be
+// registered ahead of time, for example with eventlog.InstallAsEventCreate,
+// for the Event Viewer to display their messages cleanly.
+type eventLogSink struct {
+	log     *eventlog.Log
+	keys    entryKeys
+	eventID uint32
+}
+
+func newEventLogSink(u *url.URL) (zap.Sink, error) {
+	if u.User != nil || u.Port() != "" || u.Fragment != "" || strings.Trim(u.Path, "/") != "" {
+		return nil, fmt.Errorf("eventlog URLs may only set a source and query parameters: got %v", u)
+	}
+	source := u.Hostname()
+	if source == "" {
+		source = strings.TrimSuffix(filepath.Base(os.Args[0]), filepath.Ext(os.Args[0]))
+	}
+
+	q := u.Query()
+	eventID := uint64(1)
+	if s := q.Get("eventID"); s != "" {
+		var err error
+		if eventID, err = strconv.ParseUint(s, 10, 32); err != nil {
+			return nil, fmt.Errorf("invalid eventlog event ID %q: %v", s, err)
+		}
+	}
+
+	log, err := eventlog.Open(source)
+	if err != nil {
+		return nil, fmt.Errorf("can't open event log source %q: %v", source, err)
+	}
+	return &eventLogSink{
+		log:     log,
+		keys:    newEntryKeys(q),
+		eventID: uint32(eventID),
+	}, nil
+}
+
+func (s *eventLogSink) Write(bs []byte) (int, error) {
+	msg := strings.TrimRight(string(bs), "\r\n")
+
+	var err error
+	switch lvl, _ := s.keys.parse(bs, nil); {
+	case lvl >= zapcore.ErrorLevel:
+		err = s.log.Error(s.eventID, msg)
+	case lvl == zapcore.WarnLevel:
+		err = s.log.Warning(s.eventID, msg)
+	default:
+		err = s.log.Info(s.eventID, msg)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(bs), nil
+}
+
+// Sync is a no-op: every entry is reported as soon as it's written.
+func (s *eventLogSink) Sync() error {
+	return nil
+}
+
+func (s *eventLogSink) Close() error {
+	return s.log.Close()
+}
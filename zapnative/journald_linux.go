@@ -0,0 +1,266 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build linux
+
+package zapnative
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	schemeJournald = "journald"
+
+	_journaldSocket = "/run/systemd/journal/socket"
+)
+
+// _journaldTempDirs are the directories journald accepts file descriptors
+// from, in order of preference.
+var _journaldTempDirs = []string{"/dev/shm", "/tmp", "/var/tmp"}
+
+var _bufferPool = buffer.NewPool()
+
+func nativeSinks() map[string]func(*url.URL) (zap.Sink, error) {
+	return map[string]func(*url.URL) (zap.Sink, error){
+		schemeJournald: newJournaldSink,
+	}
+}
+
+// journaldSink sends entries to systemd-journald using its native protocol,
+// which preserves each member of a JSON-encoded entry as a separate journal
+// field.
+//
+// journald URLs have the form
+//
+//	journald://[/path/to/socket][?identifier=name&levelKey=level&messageKey=msg]
+//
+// The socket defaults to /run/systemd/journal/socket, and the
+// SYSLOG_IDENTIFIER of every entry defaults to the name of the executable.
+// Entries too large for a single datagram are passed to journald through a
+// temporary file, as sd_journal_send does.
+type journaldSink struct {
+	conn       *net.UnixConn
+	keys       entryKeys
+	identifier string
+}
+
+func newJournaldSink(u *url.URL) (zap.Sink, error) {
+	if u.User != nil || u.Host != "" || u.Fragment != "" {
+		return nil, fmt.Errorf("journald URLs may only set a socket path and query parameters: got %v", u)
+	}
+	path := u.Path
+	if path == "" || path == "/" {
+		path = _journaldSocket
+	}
+	q := u.Query()
+	identifier := q.Get("identifier")
+	if identifier == "" {
+		identifier = filepath.Base(os.Args[0])
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to journald: %v", err)
+	}
+	return &journaldSink{
+		conn:       conn,
+		keys:       newEntryKeys(q),
+		identifier: identifier,
+	}, nil
+}
+
+func (s *journaldSink) Write(bs []byte) (int, error) {
+	buf := _bufferPool.Get()
+	defer buf.Free()
+
+	lvl, msg := s.keys.parse(bs, func(key, value []byte) {
+		appendJournaldField(buf, key, value)
+	})
+	appendJournaldValue(buf, "PRIORITY", []byte(journaldPriority(lvl)))
+	appendJournaldValue(buf, "SYSLOG_IDENTIFIER", []byte(s.identifier))
+	appendJournaldValue(buf, "MESSAGE", msg)
+
+	_, err := s.conn.Write(buf.Bytes())
+	if errors.Is(err, syscall.EMSGSIZE) || errors.Is(err, syscall.ENOBUFS) {
+		err = s.sendFile(buf.Bytes())
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(bs), nil
+}
+
+// sendFile passes an entry to journald through the descriptor of an unlinked
+// temporary file. journald reads such files only from /dev/shm, /tmp, and
+// /var/tmp.
+func (s *journaldSink) sendFile(bs []byte) error {
+	var (
+		f   *os.File
+		err error
+	)
+	for _, dir := range _journaldTempDirs {
+		if f, err = os.CreateTemp(dir, "zap-journald-"); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("can't create a file for a large journald entry: %v", err)
+	}
+	defer f.Close()
+
+	if err := os.Remove(f.Name()); err != nil {
+		return err
+	}
+	if _, err := f.Write(bs); err != nil {
+		return err
+	}
+	return s.sendRights(syscall.UnixRights(int(f.Fd())))
+}
+
+// sendRights sends a datagram carrying only the given control message. The
+// net package doesn't send control messages on connected datagram sockets,
+// so we make the call ourselves.
+func (s *journaldSink) sendRights(oob []byte) error {
+	rc, err := s.conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var sendErr error
+	if err := rc.Write(func(fd uintptr) bool {
+		sendErr = syscall.Sendmsg(int(fd), nil, oob, nil, 0)
+		return sendErr != syscall.EAGAIN
+	}); err != nil {
+		return err
+	}
+	return sendErr
+}
+
+// Sync is a no-op: every entry is sent to journald as soon as it's written.
+func (s *journaldSink) Sync() error {
+	return nil
+}
+
+func (s *journaldSink) Close() error {
+	return s.conn.Close()
+}
+
+// journaldPriority maps a level onto a syslog priority. Zap's most severe
+// levels all map to LOG_CRIT, since journald broadcasts anything higher to
+// every logged-in user.
+func journaldPriority(lvl zapcore.Level) string {
+	switch {
+	case lvl <= zapcore.DebugLevel:
+		return "7" // LOG_DEBUG
+	case lvl == zapcore.InfoLevel:
+		return "6" // LOG_INFO
+	case lvl == zapcore.WarnLevel:
+		return "4" // LOG_WARNING
+	case lvl == zapcore.ErrorLevel:
+		return "3" // LOG_ERR
+	default:
+		return "2" // LOG_CRIT
+	}
+}
+
+// appendJournaldField appends a member of an entry as a journal field,
+// unless its name would shadow one of the fields the sink sets itself.
+func appendJournaldField(buf *buffer.Buffer, key, value []byte) {
+	start := buf.Len()
+	appendJournaldFieldName(buf, key)
+	switch string(buf.Bytes()[start:]) {
+	case "PRIORITY", "SYSLOG_IDENTIFIER", "MESSAGE":
+		buf.Truncate(start)
+		return
+	}
+	appendJournaldFieldValue(buf, value)
+}
+
+// appendJournaldValue appends a field with a name that's already valid.
+func appendJournaldValue(buf *buffer.Buffer, name string, value []byte) {
+	buf.AppendString(name)
+	appendJournaldFieldValue(buf, value)
+}
+
+// appendJournaldFieldValue appends the value of a field in journald's native
+// format. Values containing newlines, such as stacktraces, use the
+// length-prefixed binary form.
+func appendJournaldFieldValue(buf *buffer.Buffer, value []byte) {
+	if bytes.IndexByte(value, '\n') < 0 {
+		buf.AppendByte('=')
+		_, _ = buf.Write(value)
+		buf.AppendByte('\n')
+		return
+	}
+	buf.AppendByte('\n')
+	var size [8]byte
+	binary.LittleEndian.PutUint64(size[:], uint64(len(value)))
+	_, _ = buf.Write(size[:])
+	_, _ = buf.Write(value)
+	buf.AppendByte('\n')
+}
+
+// _maxJournaldFieldName is the longest field name journald accepts.
+const _maxJournaldFieldName = 64
+
+// appendJournaldFieldName converts a key into a valid journal field name:
+// at most 64 upper-case letters, digits, and underscores, not starting with
+// an underscore or a digit.
+func appendJournaldFieldName(buf *buffer.Buffer, key []byte) {
+	// Leading characters that would become underscores are dropped.
+	for len(key) > 0 && journaldNameByte(key[0]) == '_' {
+		key = key[1:]
+	}
+	n := 0
+	if len(key) == 0 || ('0' <= key[0] && key[0] <= '9') {
+		buf.AppendString("FIELD_")
+		n = len("FIELD_")
+	}
+	for _, c := range key {
+		if n == _maxJournaldFieldName {
+			break
+		}
+		buf.AppendByte(journaldNameByte(c))
+		n++
+	}
+}
+
+func journaldNameByte(c byte) byte {
+	switch {
+	case 'a' <= c && c <= 'z':
+		return c - 'a' + 'A'
+	case 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return c
+	default:
+		return '_'
+	}
+}
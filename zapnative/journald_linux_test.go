@@ -0,0 +1,238 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build linux
+
+package zapnative
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// listenJournald starts a fake journald socket and returns its path along
+// with a function that receives the next entry sent to it, reading it from
+// the passed file descriptor if the datagram carries one.
+func listenJournald(t *testing.T) (path string, recv func() string) {
+	path = filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err, "Failed to listen on fake journald socket.")
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return path, func() string {
+		buf := make([]byte, 4096)
+		oob := make([]byte, syscall.CmsgSpace(4))
+		n, oobn, _, _, err := conn.ReadMsgUnix(buf, oob)
+		require.NoError(t, err, "Failed to read from fake journald socket.")
+		if oobn == 0 {
+			return string(buf[:n])
+		}
+
+		msgs, err := syscall.ParseSocketControlMessage(oob[:oobn])
+		require.NoError(t, err, "Failed to parse control message.")
+		require.Len(t, msgs, 1, "Expected a single control message.")
+		fds, err := syscall.ParseUnixRights(&msgs[0])
+		require.NoError(t, err, "Failed to parse passed descriptors.")
+		require.Len(t, fds, 1, "Expected a single descriptor.")
+
+		f := os.NewFile(uintptr(fds[0]), "entry")
+		defer f.Close()
+		info, err := f.Stat()
+		require.NoError(t, err, "Failed to stat passed file.")
+		assert.Zero(t, info.Sys().(*syscall.Stat_t).Nlink, "Expected passed file to be unlinked.")
+		bs, err := io.ReadAll(io.NewSectionReader(f, 0, info.Size()))
+		require.NoError(t, err, "Failed to read passed file.")
+		return string(bs)
+	}
+}
+
+func newTestJournaldSink(t *testing.T, rawURL string) zap.Sink {
+	u, err := url.Parse(rawURL)
+	require.NoError(t, err, "Failed to parse URL.")
+	sink, err := newJournaldSink(u)
+	require.NoError(t, err, "Failed to open journald sink.")
+	t.Cleanup(func() { assert.NoError(t, sink.Close()) })
+	return sink
+}
+
+func newJournaldLogger(sink zapcore.WriteSyncer) *zap.Logger {
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+		MessageKey:    "msg",
+		LevelKey:      "level",
+		StacktraceKey: "stacktrace",
+		EncodeLevel:   zapcore.LowercaseLevelEncoder,
+	})
+	return zap.New(zapcore.NewCore(enc, sink, zapcore.DebugLevel))
+}
+
+func TestJournaldSink(t *testing.T) {
+	path, recv := listenJournald(t)
+	sink := newTestJournaldSink(t, "journald://"+path+"?identifier=myapp")
+	logger := newJournaldLogger(sink)
+
+	logger.Warn("careful", zap.String("request-id", "abc"), zap.Int("2xx", 3))
+	assert.Equal(t,
+		"REQUEST_ID=abc\n"+
+			"FIELD_2XX=3\n"+
+			"PRIORITY=4\n"+
+			"SYSLOG_IDENTIFIER=myapp\n"+
+			"MESSAGE=careful\n",
+		recv())
+
+	logger.Error("failed", zap.String("stacktrace", "a\nb"), zap.String("priority", "0"))
+	size := make([]byte, 8)
+	binary.LittleEndian.PutUint64(size, 3)
+	assert.Equal(t,
+		"STACKTRACE\n"+string(size)+"a\nb\n"+
+			"PRIORITY=3\n"+
+			"SYSLOG_IDENTIFIER=myapp\n"+
+			"MESSAGE=failed\n",
+		recv(), "Fields may not shadow PRIORITY, and multi-line values should be length-prefixed.")
+
+	assert.NoError(t, sink.Sync())
+}
+
+func TestJournaldSinkLargeEntry(t *testing.T) {
+	path, recv := listenJournald(t)
+	sink := newTestJournaldSink(t, "journald://"+path+"?identifier=myapp")
+
+	msg := strings.Repeat("x", 1<<20) // larger than any datagram
+	newJournaldLogger(sink).Info(msg)
+	assert.Equal(t,
+		"PRIORITY=6\n"+
+			"SYSLOG_IDENTIFIER=myapp\n"+
+			"MESSAGE="+msg+"\n",
+		recv(), "Expected large entry to be passed through a file.")
+}
+
+func TestJournaldSinkAllocs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err, "Failed to listen on fake journald socket.")
+	defer conn.Close()
+	go func() {
+		// Drain the socket without allocating.
+		buf := make([]byte, 4096)
+		for {
+			if _, err := conn.Read(buf); err != nil {
+				return
+			}
+		}
+	}()
+
+	sink := newTestJournaldSink(t, "journald://"+path)
+	entry := []byte(`{"level":"info","msg":"hello","user":"jane","attempt":2}` + "\n")
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, err := sink.Write(entry); err != nil {
+			t.Fatalf("Unexpected write error: %v", err)
+		}
+	})
+	assert.Zero(t, allocs, "Expected writes not to allocate.")
+}
+
+func TestJournaldSinkErrors(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.sock")
+	tests := []struct {
+		desc    string
+		url     string
+		wantErr string
+	}{
+		{"host", "journald://localhost/sock", "journald URLs may only set a socket path"},
+		{"user", "journald://user@/sock", "journald URLs may only set a socket path"},
+		{"missing socket", "journald://" + missing, "can't connect to journald"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			require.NoError(t, err, "Failed to parse URL.")
+			_, err = newJournaldSink(u)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+// _registerOnce guards Register, which can only succeed once per process.
+var _registerOnce sync.Once
+
+func TestRegister(t *testing.T) {
+	path, recv := listenJournald(t)
+
+	_registerOnce.Do(func() {
+		require.NoError(t, Register(), "Failed to register native sinks.")
+	})
+	ws, closeOut, err := zap.Open("journald://" + path + "?identifier=myapp")
+	require.NoError(t, err, "Failed to open registered journald sink.")
+	defer closeOut()
+
+	_, err = ws.Write([]byte(`{"level":"error","msg":"hi"}`))
+	require.NoError(t, err, "Unexpected write error.")
+	assert.Equal(t, "PRIORITY=3\nSYSLOG_IDENTIFIER=myapp\nMESSAGE=hi\n", recv())
+}
+
+func TestJournaldPriority(t *testing.T) {
+	want := map[zapcore.Level]string{
+		zapcore.DebugLevel:  "7",
+		zapcore.InfoLevel:   "6",
+		zapcore.WarnLevel:   "4",
+		zapcore.ErrorLevel:  "3",
+		zapcore.DPanicLevel: "2",
+		zapcore.PanicLevel:  "2",
+		zapcore.FatalLevel:  "2",
+	}
+	for lvl, prio := range want {
+		assert.Equal(t, prio, journaldPriority(lvl), "Unexpected priority for %v.", lvl)
+	}
+}
+
+func TestJournaldFieldName(t *testing.T) {
+	tests := map[string]string{
+		"user_id":                             "USER_ID",
+		"http.status":                         "HTTP_STATUS",
+		"_private":                            "PRIVATE",
+		"42":                                  "FIELD_42",
+		"":                                    "FIELD_",
+		string(bytes.Repeat([]byte("k"), 70)): string(bytes.Repeat([]byte("K"), 64)),
+		"9" + strings.Repeat("k", 70):         "FIELD_9" + strings.Repeat("K", 57),
+	}
+	buf := buffer.NewPool().Get()
+	defer buf.Free()
+	for give, want := range tests {
+		buf.Reset()
+		appendJournaldFieldName(buf, []byte(give))
+		assert.Equal(t, want, buf.String(), "Unexpected name for %q.", give)
+	}
+}
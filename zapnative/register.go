@@ -0,0 +1,50 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zapnative provides zap sinks that write to the operating system's
+// native log service: systemd-journald on Linux and the Event Log on
+// Windows. It lives in its own module so that zap itself doesn't depend on
+// golang.org/x/sys.
+//
+// The sinks aren't available until Register is called:
+//
+//	if err := zapnative.Register(); err != nil {
+//		return err
+//	}
+//	cfg := zap.NewProductionConfig()
+//	cfg.OutputPaths = []string{"journald://"}
+//
+// Both sinks map the level of each JSON-encoded entry onto the service's
+// severities; entries in other encodings are logged at InfoLevel.
+package zapnative // import "go.uber.org/zap/zapnative"
+
+import "go.uber.org/zap"
+
+// Register registers the sink for this platform's native log service with
+// zap: "journald" on Linux and "eventlog" on Windows. On other platforms, it
+// does nothing. See the sink types' documentation for their URL formats.
+func Register() error {
+	for scheme, factory := range nativeSinks() {
+		if err := zap.RegisterSink(scheme, factory); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapstorage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// keyParams holds the values substituted into a key template.
+type keyParams struct {
+	time  time.Time // in UTC
+	host  string
+	nonce string
+	seq   uint64
+}
+
+var _keyPlaceholders = map[string]func(*strings.Builder, keyParams){
+	"year":   timeLayout("2006"),
+	"month":  timeLayout("01"),
+	"day":    timeLayout("02"),
+	"hour":   timeLayout("15"),
+	"minute": timeLayout("04"),
+	"date":   timeLayout("2006-01-02"),
+	"time":   timeLayout("20060102T150405Z"),
+	"host": func(sb *strings.Builder, p keyParams) {
+		sb.WriteString(p.host)
+	},
+	"nonce": func(sb *strings.Builder, p keyParams) {
+		sb.WriteString(p.nonce)
+	},
+	"seq": func(sb *strings.Builder, p keyParams) {
+		sb.WriteString(strconv.FormatUint(p.seq, 10))
+	},
+}
+
+func timeLayout(layout string) func(*strings.Builder, keyParams) {
+	return func(sb *strings.Builder, p keyParams) {
+		sb.WriteString(p.time.Format(layout))
+	}
+}
+
+// A keyTemplate is a parsed key template: literal text alternating with
+// placeholders.
+type keyTemplate []keySegment
+
+type keySegment struct {
+	literal string
+	expand  func(*strings.Builder, keyParams) // nil for literal text
+}
+
+func parseKeyTemplate(tmpl string) (keyTemplate, error) {
+	var t keyTemplate
+	for rest := tmpl; rest != ""; {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			t = append(t, keySegment{literal: rest})
+			break
+		}
+		if open > 0 {
+			t = append(t, keySegment{literal: rest[:open]})
+		}
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated placeholder in key template %q", tmpl)
+		}
+		name := rest[open+1 : open+end]
+		expand, ok := _keyPlaceholders[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown placeholder {%s} in key template %q", name, tmpl)
+		}
+		t = append(t, keySegment{expand: expand})
+		rest = rest[open+end+1:]
+	}
+	return t, nil
+}
+
+func (t keyTemplate) expand(p keyParams) string {
+	var sb strings.Builder
+	for _, seg := range t {
+		if seg.expand != nil {
+			seg.expand(&sb, p)
+		} else {
+			sb.WriteString(seg.literal)
+		}
+	}
+	return sb.String()
+}
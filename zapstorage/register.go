@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapstorage
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// RegisterS3 registers a zap sink for URLs with the "s3" scheme that
+// uploads objects with uploader. See RegisterScheme for the URL format.
+func RegisterS3(uploader Uploader) error {
+	return RegisterScheme("s3", uploader)
+}
+
+// RegisterGCS registers a zap sink for URLs with the "gs" scheme that
+// uploads objects with uploader. See RegisterScheme for the URL format.
+func RegisterGCS(uploader Uploader) error {
+	return RegisterScheme("gs", uploader)
+}
+
+// RegisterScheme registers a zap sink for URLs with the given scheme that
+// uploads objects with uploader. The URL's host names the bucket and its
+// path, if any, prefixes each object's key:
+//
+//	s3://bucket/logs/api?maxAge=1m&spill=/var/spool/api
+//
+// The following query parameters correspond to Options:
+//
+//	key           KeyTemplate
+//	host          Host
+//	maxSize       MaxObjectSize, in bytes
+//	maxAge        MaxObjectAge, as a duration like "30s"
+//	retries       the number of retries passed to Retries
+//	retryBackoff  the backoff passed to Retries, as a duration
+//	timeout       UploadTimeout, as a duration
+//	queueSize     QueueSize
+//	spill         SpillDir
+//
+// Unknown parameters are rejected.
+func RegisterScheme(scheme string, uploader Uploader) error {
+	return zap.RegisterSinkFactory(scheme, func(_ context.Context, u *url.URL, opts zap.SinkOptions) (zap.Sink, error) {
+		return newSinkFromURL(uploader, u, opts)
+	})
+}
+
+func newSinkFromURL(uploader Uploader, u *url.URL, opts zap.SinkOptions) (*Sink, error) {
+	if u.User != nil || u.Fragment != "" || u.Port() != "" {
+		return nil, fmt.Errorf("%s URLs may only set a bucket, a key prefix, and query parameters: got %v", u.Scheme, u)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("%s URLs must name a bucket: got %v", u.Scheme, u)
+	}
+	for _, k := range opts.Keys() {
+		switch k {
+		case "key", "host", "maxSize", "maxAge", "retries", "retryBackoff", "timeout", "queueSize", "spill":
+		default:
+			return nil, fmt.Errorf("unknown %s sink option %q", u.Scheme, k)
+		}
+	}
+
+	var options []Option
+	if prefix := strings.TrimPrefix(u.Path, "/"); prefix != "" {
+		if !strings.HasSuffix(prefix, "/") {
+			prefix += "/"
+		}
+		options = append(options, Prefix(prefix))
+	}
+	if tmpl := opts.String("key", ""); tmpl != "" {
+		options = append(options, KeyTemplate(tmpl))
+	}
+	if host := opts.String("host", ""); host != "" {
+		options = append(options, Host(host))
+	}
+	if dir := opts.String("spill", ""); dir != "" {
+		options = append(options, SpillDir(dir))
+	}
+
+	maxSize, err := opts.Int("maxSize", _defaultMaxSize)
+	if err != nil {
+		return nil, err
+	}
+	maxAge, err := opts.Duration("maxAge", _defaultMaxAge)
+	if err != nil {
+		return nil, err
+	}
+	retries, err := opts.Int("retries", _defaultRetries)
+	if err != nil {
+		return nil, err
+	}
+	backoff, err := opts.Duration("retryBackoff", _defaultRetryBackoff)
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := opts.Duration("timeout", _defaultTimeout)
+	if err != nil {
+		return nil, err
+	}
+	queueSize, err := opts.Int("queueSize", _defaultQueueSize)
+	if err != nil {
+		return nil, err
+	}
+	options = append(options,
+		MaxObjectSize(maxSize),
+		MaxObjectAge(maxAge),
+		Retries(retries, backoff),
+		UploadTimeout(timeout),
+		QueueSize(queueSize),
+	)
+
+	return NewSink(uploader, u.Host, options...)
+}
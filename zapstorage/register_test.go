@@ -0,0 +1,90 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapstorage
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap"
+)
+
+var _testSchemes atomic.Int64
+
+// testScheme returns a scheme that hasn't been registered yet, since zap's
+// sink registry is global.
+func testScheme() string {
+	return fmt.Sprintf("zapstorage%d", _testSchemes.Add(1))
+}
+
+func TestRegisterScheme(t *testing.T) {
+	u := &fakeUploader{}
+	scheme := testScheme()
+	require.NoError(t, RegisterScheme(scheme, u), "Unexpected error registering scheme.")
+	assert.Error(t, RegisterScheme(scheme, u), "Expected schemes to be registered once.")
+
+	ws, closeAll, err := zap.Open(scheme + "://bucket/logs/api?key={host}-{seq}.log&host=h&maxSize=1024")
+	require.NoError(t, err, "Unexpected error opening sink.")
+	_, err = ws.Write([]byte("hello\n"))
+	require.NoError(t, err, "Unexpected write error.")
+	closeAll()
+
+	keys, bodies := u.Objects()
+	assert.Equal(t, []string{"bucket/logs/api/h-1.log"}, keys, "Unexpected object keys.")
+	assert.Equal(t, []string{"hello\n"}, bodies, "Unexpected object bodies.")
+}
+
+func TestRegisterSchemeURLs(t *testing.T) {
+	scheme := testScheme()
+	require.NoError(t, RegisterScheme(scheme, &fakeUploader{}), "Unexpected error registering scheme.")
+
+	tests := []struct {
+		url     string
+		wantErr string
+	}{
+		{url: "://bucket"},
+		{url: "://bucket/prefix/?maxAge=1m&retries=1&retryBackoff=1s&timeout=5s&queueSize=2"},
+		{url: ":///prefix", wantErr: "must name a bucket"},
+		{url: "://user@bucket", wantErr: "may only set"},
+		{url: "://bucket:9000", wantErr: "may only set"},
+		{url: "://bucket?region=us", wantErr: `sink option "region"`},
+		{url: "://bucket?maxAge=soon", wantErr: "must be a duration"},
+		{url: "://bucket?maxSize=big", wantErr: "must be an integer"},
+		{url: "://bucket?key={bogus}", wantErr: "unknown placeholder"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			_, closeAll, err := zap.Open(scheme + tt.url)
+			if tt.wantErr != "" {
+				require.Error(t, err, "Expected an error.")
+				assert.Contains(t, err.Error(), tt.wantErr, "Unexpected error.")
+				return
+			}
+			require.NoError(t, err, "Unexpected error.")
+			closeAll()
+		})
+	}
+}
@@ -0,0 +1,380 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapstorage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	errClosed    = errors.New("zapstorage: sink is closed")
+	errQueueFull = errors.New("zapstorage: upload queue is full")
+)
+
+// _spillSuffix marks complete files in the spill directory.
+const _spillSuffix = ".spill"
+
+// object is a batch of entries bound for the bucket.
+type object struct {
+	key  string
+	body []byte
+}
+
+// A Sink is a zap.Sink that batches the entries written to it into objects
+// and uploads them from a background goroutine. An object is uploaded once
+// it reaches MaxObjectSize, once it's MaxObjectAge old, when an hour ends,
+// and on Sync. Writes never wait for uploads.
+//
+// Failed uploads are retried, and then spilled to disk if a SpillDir is
+// set. Call Close once logging is done to upload the last object and
+// release the background goroutines.
+type Sink struct {
+	uploader Uploader
+	bucket   string
+	cfg      config
+	key      keyTemplate
+	nonce    string
+
+	mu       sync.Mutex
+	cond     *sync.Cond // signaled when inflight decreases
+	buf      []byte     // the current object
+	started  time.Time  // time of the current object's first write
+	seq      uint64     // number of objects closed so far
+	inflight int        // objects queued or being uploaded
+	err      error      // errors since the last Sync
+	closed   bool
+
+	queue    chan object
+	stop     chan struct{} // closed by Close
+	done     chan struct{} // closed when uploadLoop exits
+	tickDone chan struct{} // closed when ageLoop exits
+}
+
+// NewSink builds a Sink that uploads objects to the given bucket with
+// uploader.
+func NewSink(uploader Uploader, bucket string, opts ...Option) (*Sink, error) {
+	cfg := config{
+		keyTemplate:  _defaultKeyTemplate,
+		maxSize:      _defaultMaxSize,
+		maxAge:       _defaultMaxAge,
+		retries:      _defaultRetries,
+		retryBackoff: _defaultRetryBackoff,
+		timeout:      _defaultTimeout,
+		queueSize:    _defaultQueueSize,
+		clock:        zapcore.DefaultClock,
+	}
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+	if bucket == "" {
+		return nil, errors.New("zapstorage: bucket must not be empty")
+	}
+	if cfg.maxSize < 1 {
+		cfg.maxSize = 1
+	}
+	if cfg.maxAge <= 0 {
+		cfg.maxAge = _defaultMaxAge
+	}
+	if cfg.queueSize < 1 {
+		cfg.queueSize = 1
+	}
+	if cfg.host == "" {
+		cfg.host, _ = os.Hostname()
+	}
+	key, err := parseKeyTemplate(cfg.prefix + cfg.keyTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.spillDir != "" {
+		if err := os.MkdirAll(cfg.spillDir, 0o755); err != nil {
+			return nil, fmt.Errorf("zapstorage: can't create spill directory: %v", err)
+		}
+	}
+
+	var nonce [4]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, fmt.Errorf("zapstorage: can't generate nonce: %v", err)
+	}
+
+	s := &Sink{
+		uploader: uploader,
+		bucket:   bucket,
+		cfg:      cfg,
+		key:      key,
+		nonce:    hex.EncodeToString(nonce[:]),
+		queue:    make(chan object, cfg.queueSize),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+		tickDone: make(chan struct{}),
+	}
+	s.cond = sync.NewCond(&s.mu)
+	go s.uploadLoop()
+	go s.ageLoop(cfg.clock.NewTicker(cfg.maxAge))
+	return s, nil
+}
+
+// Write appends bs, which should hold whole entries, to the current
+// object.
+func (s *Sink) Write(bs []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return 0, errClosed
+	}
+	now := s.cfg.clock.Now()
+	if len(s.buf) > 0 &&
+		(len(s.buf)+len(bs) > s.cfg.maxSize || !now.Truncate(time.Hour).Equal(s.started.Truncate(time.Hour))) {
+		s.closeObject()
+	}
+	if len(s.buf) == 0 {
+		s.started = now
+	}
+	s.buf = append(s.buf, bs...)
+	if len(s.buf) >= s.cfg.maxSize {
+		s.closeObject()
+	}
+	return len(bs), nil
+}
+
+// Sync uploads the current object, waits for every queued object to be
+// uploaded or spilled, and returns the errors encountered since the
+// previous Sync.
+func (s *Sink) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed {
+		s.closeObject()
+	}
+	for s.inflight > 0 {
+		s.cond.Wait()
+	}
+	err := s.err
+	s.err = nil
+	return err
+}
+
+// Close uploads the current object and stops the background goroutines.
+// Uploads still being retried are abandoned and spilled. Close is safe to
+// call more than once; later calls do nothing and return nil.
+func (s *Sink) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closeObject()
+	s.closed = true
+	close(s.queue)
+	close(s.stop)
+	s.mu.Unlock()
+
+	<-s.tickDone
+	<-s.done
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.err
+	s.err = nil
+	return err
+}
+
+// closeObject queues the current object for upload. It must be called with
+// s.mu held, and not after Close.
+func (s *Sink) closeObject() {
+	if len(s.buf) == 0 {
+		return
+	}
+	s.seq++
+	obj := object{
+		key: s.key.expand(keyParams{
+			time:  s.started.UTC(),
+			host:  s.cfg.host,
+			nonce: s.nonce,
+			seq:   s.seq,
+		}),
+		body: s.buf,
+	}
+	s.buf = nil
+
+	select {
+	case s.queue <- obj:
+		s.inflight++
+	default:
+		s.record(s.spill(obj, errQueueFull))
+	}
+}
+
+// record stores err for the next Sync and reports it. It must be called
+// with s.mu held.
+func (s *Sink) record(err error) {
+	if err == nil {
+		return
+	}
+	s.err = multierr.Append(s.err, err)
+	if s.cfg.onError != nil {
+		s.cfg.onError(err)
+	}
+}
+
+// ageLoop closes the current object every MaxObjectAge, so that no object
+// collects entries for longer.
+func (s *Sink) ageLoop(ticker *time.Ticker) {
+	defer close(s.tickDone)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.mu.Lock()
+			if !s.closed {
+				s.closeObject()
+			}
+			s.mu.Unlock()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// uploadLoop uploads queued objects until Close.
+func (s *Sink) uploadLoop() {
+	defer close(s.done)
+
+	// Objects spilled by earlier processes are waiting.
+	spilled := s.cfg.spillDir != ""
+	for obj := range s.queue {
+		err := s.upload(obj)
+		if err != nil {
+			err = s.spill(obj, err)
+			spilled = spilled || s.cfg.spillDir != ""
+		} else if spilled {
+			spilled, err = s.uploadSpilled()
+		}
+
+		s.mu.Lock()
+		s.inflight--
+		s.record(err)
+		s.cond.Broadcast()
+		s.mu.Unlock()
+	}
+}
+
+// upload uploads an object, retrying failures.
+func (s *Sink) upload(obj object) error {
+	backoff := s.cfg.retryBackoff
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), s.cfg.timeout)
+		err := s.uploader.Upload(ctx, s.bucket, obj.key, obj.body)
+		cancel()
+		if err == nil || attempt >= s.cfg.retries || !s.wait(backoff) {
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+// wait waits for d, returning false if the Sink is closed in the meantime.
+func (s *Sink) wait(d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+	ticker := s.cfg.clock.NewTicker(d)
+	defer ticker.Stop()
+	select {
+	case <-ticker.C:
+		return true
+	case <-s.stop:
+		return false
+	}
+}
+
+// spill saves an object that couldn't be uploaded because of cause to the
+// spill directory. It returns cause, annotated with the outcome.
+func (s *Sink) spill(obj object, cause error) error {
+	if s.cfg.spillDir == "" {
+		return fmt.Errorf("zapstorage: dropped object %q: %w", obj.key, cause)
+	}
+
+	name := filepath.Join(s.cfg.spillDir, url.PathEscape(obj.key))
+	// Write to a temporary file first, so that a crash never leaves a
+	// partial object behind to be uploaded later.
+	if err := os.WriteFile(name+".tmp", obj.body, 0o644); err != nil {
+		return fmt.Errorf("zapstorage: dropped object %q: %w", obj.key, multierr.Append(cause, err))
+	}
+	if err := os.Rename(name+".tmp", name+_spillSuffix); err != nil {
+		return fmt.Errorf("zapstorage: dropped object %q: %w", obj.key, multierr.Append(cause, err))
+	}
+	return fmt.Errorf("zapstorage: spilled object %q to disk: %w", obj.key, cause)
+}
+
+// uploadSpilled uploads the objects in the spill directory, oldest key
+// first, removing each once it's uploaded. It reports whether any objects
+// remain.
+func (s *Sink) uploadSpilled() (bool, error) {
+	entries, err := os.ReadDir(s.cfg.spillDir)
+	if err != nil {
+		return true, fmt.Errorf("zapstorage: can't read spill directory: %v", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if name := e.Name(); !e.IsDir() && strings.HasSuffix(name, _spillSuffix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		key, err := url.PathUnescape(strings.TrimSuffix(name, _spillSuffix))
+		if err != nil {
+			continue // not ours
+		}
+		path := filepath.Join(s.cfg.spillDir, name)
+		body, err := os.ReadFile(path)
+		if err != nil {
+			return true, fmt.Errorf("zapstorage: can't read spilled object %q: %v", key, err)
+		}
+		if err := s.upload(object{key: key, body: body}); err != nil {
+			// Still failing; try again after the next successful upload.
+			return true, nil
+		}
+		if err := os.Remove(path); err != nil {
+			return true, fmt.Errorf("zapstorage: can't remove spilled object %q: %v", key, err)
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapstorage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap/internal/ztest"
+)
+
+// fakeUploader records uploaded objects, failing while down is set.
+type fakeUploader struct {
+	mu       sync.Mutex
+	objects  map[string]string
+	down     bool
+	attempts int
+}
+
+func (u *fakeUploader) Upload(_ context.Context, bucket, key string, body []byte) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.attempts++
+	if u.down {
+		return errors.New("service unavailable")
+	}
+	if u.objects == nil {
+		u.objects = make(map[string]string)
+	}
+	u.objects[bucket+"/"+key] = string(body)
+	return nil
+}
+
+func (u *fakeUploader) setDown(down bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.down = down
+}
+
+// Objects returns the bodies of the uploaded objects, sorted by key.
+func (u *fakeUploader) Objects() (keys, bodies []string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	for k := range u.objects {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		bodies = append(bodies, u.objects[k])
+	}
+	return keys, bodies
+}
+
+// testClock is a MockClock that starts at a fixed time.
+type testClock struct {
+	*ztest.MockClock
+
+	offset time.Duration
+}
+
+func newTestClock() *testClock {
+	c := ztest.NewMockClock()
+	return &testClock{
+		MockClock: c,
+		offset:    time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC).Sub(c.Now()),
+	}
+}
+
+func (c *testClock) Now() time.Time {
+	return c.MockClock.Now().Add(c.offset)
+}
+
+func newTestSink(t testing.TB, u Uploader, opts ...Option) (*Sink, *testClock) {
+	clock := newTestClock()
+	opts = append([]Option{
+		WithClock(clock),
+		Host("web1"),
+		KeyTemplate("{date}/{hour}/{host}-{seq}.log"),
+		MaxObjectAge(time.Hour),
+		Retries(0, 0),
+	}, opts...)
+	s, err := NewSink(u, "bucket", opts...)
+	require.NoError(t, err, "Unexpected error building Sink.")
+	return s, clock
+}
+
+func write(t testing.TB, s *Sink, line string) {
+	n, err := s.Write([]byte(line))
+	require.NoError(t, err, "Unexpected write error.")
+	require.Equal(t, len(line), n, "Unexpected number of bytes written.")
+}
+
+func TestSinkBatches(t *testing.T) {
+	u := &fakeUploader{}
+	s, _ := newTestSink(t, u, MaxObjectSize(8))
+
+	write(t, s, "one\n")
+	write(t, s, "two\n")   // fills the first object
+	write(t, s, "three\n") // held until Sync
+	require.NoError(t, s.Sync(), "Unexpected error syncing.")
+	require.NoError(t, s.Close(), "Unexpected error closing.")
+
+	keys, bodies := u.Objects()
+	assert.Equal(t, []string{
+		"bucket/2026-03-04/05/web1-1.log",
+		"bucket/2026-03-04/05/web1-2.log",
+	}, keys, "Unexpected object keys.")
+	assert.Equal(t, []string{"one\ntwo\n", "three\n"}, bodies, "Unexpected object bodies.")
+
+	_, err := s.Write([]byte("four\n"))
+	assert.Error(t, err, "Expected writes after Close to fail.")
+	assert.NoError(t, s.Close(), "Expected Close to be idempotent.")
+}
+
+func TestSinkPartitionsByHour(t *testing.T) {
+	u := &fakeUploader{}
+	s, clock := newTestSink(t, u, MaxObjectAge(24*time.Hour))
+
+	write(t, s, "before\n")
+	clock.Add(time.Hour)
+	write(t, s, "after\n")
+	require.NoError(t, s.Close(), "Unexpected error closing.")
+
+	keys, bodies := u.Objects()
+	assert.Equal(t, []string{
+		"bucket/2026-03-04/05/web1-1.log",
+		"bucket/2026-03-04/06/web1-2.log",
+	}, keys, "Expected a new object for each hour.")
+	assert.Equal(t, []string{"before\n", "after\n"}, bodies, "Unexpected object bodies.")
+}
+
+func TestSinkMaxAge(t *testing.T) {
+	u := &fakeUploader{}
+	s, clock := newTestSink(t, u, MaxObjectAge(time.Minute))
+	defer func() { assert.NoError(t, s.Close(), "Unexpected error closing.") }()
+
+	write(t, s, "one\n")
+	clock.Add(time.Minute)
+	assert.Eventually(t, func() bool {
+		_, bodies := u.Objects()
+		return len(bodies) == 1
+	}, time.Second, time.Millisecond, "Expected the object to be uploaded once it's old enough.")
+}
+
+func TestSinkRetries(t *testing.T) {
+	u := &fakeUploader{down: true}
+	var reported []error
+	s, _ := newTestSink(t, u, Retries(2, 0), OnError(func(err error) {
+		reported = append(reported, err)
+	}))
+
+	write(t, s, "one\n")
+	err := s.Sync()
+	require.Error(t, err, "Expected failed uploads to be reported.")
+	assert.Contains(t, err.Error(), "service unavailable", "Unexpected error.")
+	assert.Equal(t, 3, u.attempts, "Expected the upload to be retried.")
+	assert.Len(t, reported, 1, "Expected the error to be reported.")
+	assert.NoError(t, s.Sync(), "Expected errors to be reported once.")
+
+	u.setDown(false)
+	write(t, s, "two\n")
+	require.NoError(t, s.Close(), "Unexpected error closing.")
+	_, bodies := u.Objects()
+	assert.Equal(t, []string{"two\n"}, bodies, "Expected the failed object to be dropped.")
+}
+
+func TestSinkSpill(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "spill")
+	u := &fakeUploader{down: true}
+	s, _ := newTestSink(t, u, SpillDir(dir))
+
+	write(t, s, "one\n")
+	assert.Error(t, s.Sync(), "Expected the failed upload to be reported.")
+	require.NoError(t, s.Close(), "Unexpected error closing.")
+	spilled, err := os.ReadDir(dir)
+	require.NoError(t, err, "Failed to read spill directory.")
+	require.Len(t, spilled, 1, "Expected the object to be spilled.")
+
+	// A later Sink uploads the spilled object once uploads succeed.
+	u.setDown(false)
+	s, _ = newTestSink(t, u, SpillDir(dir), KeyTemplate("later-{seq}.log"))
+	write(t, s, "two\n")
+	require.NoError(t, s.Sync(), "Unexpected error syncing.")
+	require.NoError(t, s.Close(), "Unexpected error closing.")
+
+	keys, bodies := u.Objects()
+	assert.Equal(t, []string{"bucket/2026-03-04/05/web1-1.log", "bucket/later-1.log"}, keys, "Unexpected object keys.")
+	assert.Equal(t, []string{"one\n", "two\n"}, bodies, "Unexpected object bodies.")
+	spilled, err = os.ReadDir(dir)
+	require.NoError(t, err, "Failed to read spill directory.")
+	assert.Empty(t, spilled, "Expected uploaded objects to be removed from the spill directory.")
+}
+
+func TestKeyTemplate(t *testing.T) {
+	tmpl, err := parseKeyTemplate("logs/year={year}/month={month}/day={day}/{hour}{minute}/{time}-{host}-{nonce}-{seq}.json")
+	require.NoError(t, err, "Unexpected error parsing template.")
+	assert.Equal(t,
+		"logs/year=2026/month=01/day=02/0304/20260102T030405Z-h-abc-7.json",
+		tmpl.expand(keyParams{
+			time:  time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			host:  "h",
+			nonce: "abc",
+			seq:   7,
+		}),
+		"Unexpected key.",
+	)
+
+	for _, bad := range []string{"{nope}", "logs/{date"} {
+		_, err := parseKeyTemplate(bad)
+		assert.Error(t, err, "Expected %q to be rejected.", bad)
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zapstorage provides a zap Sink that batches encoded entries into
+// objects and uploads them to object storage, such as Amazon S3, Google
+// Cloud Storage, or any S3-compatible service.
+//
+// Zap doesn't depend on any storage client. Instead, the Sink hands each
+// object to an Uploader, which applications implement with the SDK of
+// their choice:
+//
+//	uploader := zapstorage.UploaderFunc(func(ctx context.Context, bucket, key string, body []byte) error {
+//		_, err := client.PutObject(ctx, &s3.PutObjectInput{
+//			Bucket: &bucket,
+//			Key:    &key,
+//			Body:   bytes.NewReader(body),
+//		})
+//		return err
+//	})
+//
+// The Sink can be used directly, or registered for URLs like
+// "s3://bucket/prefix" so that it can be named in zap.Config's OutputPaths:
+//
+//	if err := zapstorage.RegisterS3(uploader); err != nil {
+//		...
+//	}
+//	cfg := zap.NewProductionConfig()
+//	cfg.OutputPaths = []string{"stderr", "s3://logs/api?maxAge=1m&spill=/var/spool/api"}
+package zapstorage // import "go.uber.org/zap/zapstorage"
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// An Uploader stores an object in a bucket, replacing any object with the
+// same key. Upload is only ever called from one goroutine at a time. It may
+// retain body.
+type Uploader interface {
+	Upload(ctx context.Context, bucket, key string, body []byte) error
+}
+
+// UploaderFunc is a function that implements Uploader.
+type UploaderFunc func(ctx context.Context, bucket, key string, body []byte) error
+
+// Upload calls f.
+func (f UploaderFunc) Upload(ctx context.Context, bucket, key string, body []byte) error {
+	return f(ctx, bucket, key, body)
+}
+
+// An Option configures a Sink.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (f optionFunc) apply(cfg *config) {
+	f(cfg)
+}
+
+const (
+	_defaultKeyTemplate  = "{date}/{hour}/{host}-{time}-{nonce}-{seq}.log"
+	_defaultMaxSize      = 8 * 1024 * 1024 // 8 MB
+	_defaultMaxAge       = 5 * time.Minute
+	_defaultRetries      = 3
+	_defaultRetryBackoff = time.Second
+	_defaultTimeout      = 30 * time.Second
+	_defaultQueueSize    = 16
+)
+
+type config struct {
+	prefix       string
+	keyTemplate  string
+	host         string
+	maxSize      int
+	maxAge       time.Duration
+	retries      int
+	retryBackoff time.Duration
+	timeout      time.Duration
+	queueSize    int
+	spillDir     string
+	onError      func(error)
+	clock        zapcore.Clock
+}
+
+// Prefix is prepended to the key of every object, as in "logs/api/".
+func Prefix(prefix string) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.prefix = prefix
+	})
+}
+
+// KeyTemplate sets the template for object keys. The following
+// placeholders are replaced, using the UTC time of each object's first
+// entry:
+//
+//	{year}, {month}, {day}, {hour}, {minute}
+//	    the parts of the time, zero-padded, as in "2026", "01", or "09"
+//	{date}
+//	    the date, as in "2026-01-02"
+//	{time}
+//	    the time to the second, as in "20260102T150405Z"
+//	{host}
+//	    the host name; see Host
+//	{nonce}
+//	    eight random hexadecimal digits, fixed for each Sink
+//	{seq}
+//	    the number of the object among those uploaded by the Sink
+//
+// Keys must be unique, so templates should include {nonce} and {seq}, or
+// objects may be overwritten. Partitioning by {date} or {hour} lets query
+// engines skip old objects. Defaults to
+// "{date}/{hour}/{host}-{time}-{nonce}-{seq}.log".
+func KeyTemplate(tmpl string) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.keyTemplate = tmpl
+	})
+}
+
+// Host sets the value of the {host} placeholder in object keys. Defaults
+// to the name reported by os.Hostname.
+func Host(name string) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.host = name
+	})
+}
+
+// MaxObjectSize sets the size in bytes at which an object is closed and
+// uploaded. Entries are never split across objects, so an object may
+// exceed the size by part of one entry. Defaults to 8 MB.
+func MaxObjectSize(n int) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.maxSize = n
+	})
+}
+
+// MaxObjectAge sets how long an object may collect entries before it's
+// uploaded, bounding how far the bucket lags behind the application.
+// Defaults to five minutes.
+func MaxObjectAge(d time.Duration) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.maxAge = d
+	})
+}
+
+// Retries sets how many times a failed upload is retried, waiting twice as
+// long before each retry as before the last, starting at backoff. Defaults
+// to three retries, starting after one second.
+func Retries(n int, backoff time.Duration) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.retries = n
+		cfg.retryBackoff = backoff
+	})
+}
+
+// UploadTimeout sets the deadline for each call to Upload. Defaults to 30
+// seconds.
+func UploadTimeout(d time.Duration) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.timeout = d
+	})
+}
+
+// QueueSize sets how many objects may wait to be uploaded. Once the queue
+// is full, further objects are spilled to disk, or dropped if there's no
+// spill directory. Defaults to 16.
+func QueueSize(n int) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.queueSize = n
+	})
+}
+
+// SpillDir sets a directory in which to save objects that can't be
+// uploaded, for example during an outage, rather than dropping them.
+// Spilled objects are uploaded, and removed from the directory, once
+// uploads succeed again, including by later processes using the same
+// directory.
+func SpillDir(dir string) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.spillDir = dir
+	})
+}
+
+// OnError registers a function called with every error the Sink handles in
+// the background, such as failed uploads, as soon as it occurs. Errors are
+// also returned by the next call to Sync.
+func OnError(f func(error)) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.onError = f
+	})
+}
+
+// WithClock sets the clock used to time objects and schedule uploads.
+// Defaults to the system clock.
+func WithClock(clock zapcore.Clock) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.clock = clock
+	})
+}
@@ -22,6 +22,7 @@ package zaptest
 
 import (
 	"bytes"
+	"sync"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -34,6 +35,8 @@ type LoggerOption interface {
 
 type loggerOptions struct {
 	Level      zapcore.LevelEnabler
+	FailOn     zapcore.LevelEnabler
+	Buffered   bool
 	zapOptions []zap.Option
 }
 
@@ -44,7 +47,7 @@ func (f loggerOptionFunc) applyLoggerOption(opts *loggerOptions) {
 }
 
 // Level controls which messages are logged by a test Logger built by
-// NewLogger.
+// NewLogger. Pass a zap.AtomicLevel to change the level during the test.
 func Level(enab zapcore.LevelEnabler) LoggerOption {
 	return loggerOptionFunc(func(opts *loggerOptions) {
 		opts.Level = enab
@@ -58,6 +61,32 @@ func WrapOptions(zapOpts ...zap.Option) LoggerOption {
 	})
 }
 
+// FailOnLevel makes a test Logger built by NewLogger fail the test with
+// t.Errorf whenever it logs an entry at a level enabled by enab, such as
+// zap.ErrorLevel to treat unexpected errors as test failures.
+//
+//	logger := zaptest.NewLogger(t, zaptest.FailOnLevel(zap.ErrorLevel))
+//
+// Only entries that are logged count: entries below the Logger's Level
+// never fail the test.
+func FailOnLevel(enab zapcore.LevelEnabler) LoggerOption {
+	return loggerOptionFunc(func(opts *loggerOptions) {
+		opts.FailOn = enab
+	})
+}
+
+// BufferUntilFailure makes a test Logger built by NewLogger hold its output
+// in memory and print it only if the test has failed by the time it
+// finishes, keeping the output of passing tests clean even with go test -v.
+//
+// It relies on the TestingT's Cleanup method, which *testing.T and
+// *testing.B provide; with other TestingTs, output isn't buffered.
+func BufferUntilFailure() LoggerOption {
+	return loggerOptionFunc(func(opts *loggerOptions) {
+		opts.Buffered = true
+	})
+}
+
 // NewLogger builds a new Logger that logs all messages to the given
 // testing.TB.
 //
@@ -82,11 +111,29 @@ func NewLogger(t TestingT, opts ...LoggerOption) *zap.Logger {
 		o.applyLoggerOption(&cfg)
 	}
 
-	writer := NewTestingWriter(t)
+	var (
+		writer    zapcore.WriteSyncer = NewTestingWriter(t)
+		errWriter zapcore.WriteSyncer = NewTestingWriter(t).WithMarkFailed(true)
+	)
+	if c, ok := t.(interface{ Cleanup(func()) }); ok && cfg.Buffered {
+		buf := &testLogBuffer{t: t}
+		c.Cleanup(buf.flush)
+		writer = bufferedTestingWriter{NewTestingWriter(t), buf}
+		errWriter = bufferedTestingWriter{NewTestingWriter(t).WithMarkFailed(true), buf}
+	}
+
 	zapOptions := []zap.Option{
 		// Send zap errors to the same writer and mark the test as failed if
 		// that happens.
-		zap.ErrorOutput(writer.WithMarkFailed(true)),
+		zap.ErrorOutput(errWriter),
+	}
+	if cfg.FailOn != nil {
+		zapOptions = append(zapOptions, zap.Hooks(func(ent zapcore.Entry) error {
+			if cfg.FailOn.Enabled(ent.Level) {
+				t.Errorf("unexpected %v log entry: %s", ent.Level.CapitalString(), ent.Message)
+			}
+			return nil
+		}))
 	}
 	zapOptions = append(zapOptions, cfg.zapOptions...)
 
@@ -155,3 +202,61 @@ func (w TestingWriter) Write(p []byte) (n int, err error) {
 func (w TestingWriter) Sync() error {
 	return nil
 }
+
+// testLogBuffer holds the output of a test Logger built with
+// BufferUntilFailure until the test finishes.
+type testLogBuffer struct {
+	t TestingT
+
+	mu      sync.Mutex
+	writes  [][]byte
+	flushed bool // set once the test has finished
+}
+
+// add buffers a copy of p, reporting false if the buffer has already been
+// flushed.
+func (b *testLogBuffer) add(p []byte) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.flushed {
+		return false
+	}
+	b.writes = append(b.writes, append([]byte(nil), p...))
+	return true
+}
+
+// flush prints the buffered output if the test failed, and discards it
+// otherwise. Later writes go straight to the test.
+func (b *testLogBuffer) flush() {
+	b.mu.Lock()
+	writes := b.writes
+	b.writes = nil
+	b.flushed = true
+	b.mu.Unlock()
+
+	if !b.t.Failed() {
+		return
+	}
+	w := NewTestingWriter(b.t)
+	for _, p := range writes {
+		_, _ = w.Write(p)
+	}
+}
+
+// bufferedTestingWriter is a TestingWriter that writes to a testLogBuffer
+// until it's flushed.
+type bufferedTestingWriter struct {
+	TestingWriter
+
+	buf *testLogBuffer
+}
+
+func (w bufferedTestingWriter) Write(p []byte) (int, error) {
+	if !w.buf.add(p) {
+		return w.TestingWriter.Write(p)
+	}
+	if w.markFailed {
+		w.t.Fail()
+	}
+	return len(p), nil
+}
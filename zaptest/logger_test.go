@@ -138,12 +138,79 @@ func TestTestLoggerErrorOutput(t *testing.T) {
 	}
 }
 
-// testLogSpy is a testing.TB that captures logged messages.
+func TestTestLoggerFailOnLevel(t *testing.T) {
+	ts := newTestLogSpy(t)
+	log := NewLogger(ts, FailOnLevel(zap.ErrorLevel), Level(zap.InfoLevel))
+
+	log.Debug("not logged")
+	log.Warn("work may fail")
+	ts.AssertPassed()
+
+	log.Error("work failed")
+	ts.AssertFailed()
+	assert.Equal(t, []string{"unexpected ERROR log entry: work failed"}, ts.Errors, "Unexpected test errors.")
+	ts.AssertMessages("WARN\twork may fail", "ERROR\twork failed")
+}
+
+func TestTestLoggerBufferUntilFailure(t *testing.T) {
+	t.Run("passing", func(t *testing.T) {
+		ts := newTestLogSpy(t)
+		log := NewLogger(ts, BufferUntilFailure())
+
+		log.Info("received work order")
+		assert.Empty(t, ts.Messages, "Expected output to be buffered.")
+
+		ts.RunCleanups()
+		ts.AssertPassed()
+		assert.Empty(t, ts.Messages, "Expected buffered output of a passing test to be discarded.")
+
+		log.Info("after the test")
+		ts.AssertMessages("INFO\tafter the test")
+	})
+
+	t.Run("failing", func(t *testing.T) {
+		ts := newTestLogSpy(t)
+		log := NewLogger(ts, BufferUntilFailure())
+
+		log.Info("received work order")
+		log.Warn("work may fail")
+		ts.Fail()
+		assert.Empty(t, ts.Messages, "Expected output to be buffered until the test finishes.")
+
+		ts.RunCleanups()
+		ts.AssertMessages("INFO\treceived work order", "WARN\twork may fail")
+	})
+
+	t.Run("error output", func(t *testing.T) {
+		ts := newTestLogSpy(t)
+		log := NewLogger(ts, BufferUntilFailure()).WithOptions(zap.WrapCore(func(zapcore.Core) zapcore.Core {
+			return zapcore.NewCore(
+				zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig()),
+				zapcore.Lock(zapcore.AddSync(ztest.FailWriter{})),
+				zapcore.DebugLevel,
+			)
+		}))
+
+		log.Info("foo") // this fails
+		ts.AssertFailed()
+		assert.Empty(t, ts.Messages, "Expected internal errors to be buffered too.")
+
+		ts.RunCleanups()
+		if assert.Len(t, ts.Messages, 1, "expected a log message") {
+			assert.Regexp(t, `write error: failed`, ts.Messages[0])
+		}
+	})
+}
+
+// testLogSpy is a testing.TB that captures logged messages, errors, and
+// cleanup functions.
 type testLogSpy struct {
 	testing.TB
 
 	failed   bool
 	Messages []string
+	Errors   []string
+	cleanups []func()
 }
 
 func newTestLogSpy(t testing.TB) *testLogSpy {
@@ -158,6 +225,24 @@ func (t *testLogSpy) Failed() bool {
 	return t.failed
 }
 
+func (t *testLogSpy) Errorf(format string, args ...interface{}) {
+	t.Errors = append(t.Errors, fmt.Sprintf(format, args...))
+	t.Fail()
+}
+
+func (t *testLogSpy) Cleanup(f func()) {
+	t.cleanups = append(t.cleanups, f)
+}
+
+// RunCleanups runs the functions registered with Cleanup, last first, as
+// the testing package does when a test finishes.
+func (t *testLogSpy) RunCleanups() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+	t.cleanups = nil
+}
+
 func (t *testLogSpy) FailNow() {
 	t.Fail()
 	t.TB.FailNow()
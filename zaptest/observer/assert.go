@@ -0,0 +1,274 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package observer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// UpdateGoldenEnv is the environment variable that makes AssertGolden
+// rewrite golden files with the observed entries instead of comparing
+// against them. Set it to any non-empty value, e.g.
+//
+//	ZAPTEST_UPDATE_GOLDEN=1 go test ./...
+const UpdateGoldenEnv = "ZAPTEST_UPDATE_GOLDEN"
+
+// TestingT is the subset of *testing.T and *testing.B used by the assertion
+// helpers on ObservedLogs.
+type TestingT interface {
+	Errorf(string, ...interface{})
+	FailNow()
+}
+
+// helper marks the calling function as a test helper if t supports it.
+func helper(t TestingT) {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+}
+
+// A FieldMatcher checks the fields of an observed entry. Build one with
+// HasField, FieldEquals, or FieldMatches.
+type FieldMatcher struct {
+	desc  string
+	match func(LoggedEntry) bool
+}
+
+// String describes the matcher in failure messages.
+func (m FieldMatcher) String() string {
+	return m.desc
+}
+
+// HasField matches entries with a field named key.
+func HasField(key string) FieldMatcher {
+	return FieldMatcher{
+		desc: fmt.Sprintf("has field %q", key),
+		match: func(e LoggedEntry) bool {
+			for _, f := range e.Context {
+				if f.Key == key {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+// FieldEquals matches entries with a field equal to the given one, as
+// reported by zapcore.Field.Equals.
+//
+//	logs.MustContain(t, zap.InfoLevel, "served", observer.FieldEquals(zap.Int("status", 200)))
+func FieldEquals(field zapcore.Field) FieldMatcher {
+	return FieldMatcher{
+		desc: fmt.Sprintf("field %q equals %v", field.Key, fieldValue(field)),
+		match: func(e LoggedEntry) bool {
+			for _, f := range e.Context {
+				if f.Equals(field) {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+// FieldMatches matches entries with a field named key whose value, as it
+// appears in LoggedEntry.ContextMap, satisfies match.
+func FieldMatches(key string, match func(value interface{}) bool) FieldMatcher {
+	return FieldMatcher{
+		desc: fmt.Sprintf("field %q matches", key),
+		match: func(e LoggedEntry) bool {
+			v, ok := e.ContextMap()[key]
+			return ok && match(v)
+		},
+	}
+}
+
+func fieldValue(f zapcore.Field) interface{} {
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+	return enc.Fields[f.Key]
+}
+
+// An Expectation describes an entry expected among the observed logs. See
+// MustContainSequence.
+type Expectation struct {
+	// Level the entry must be logged at.
+	Level zapcore.Level
+	// MessageSnippet must be contained in the entry's message.
+	MessageSnippet string
+	// Fields must all match the entry.
+	Fields []FieldMatcher
+}
+
+// Expect builds an Expectation for an entry at the given level, with a
+// message containing msgSnippet and fields matched by all of fields.
+func Expect(level zapcore.Level, msgSnippet string, fields ...FieldMatcher) Expectation {
+	return Expectation{Level: level, MessageSnippet: msgSnippet, Fields: fields}
+}
+
+func (x Expectation) matches(e LoggedEntry) bool {
+	if e.Level != x.Level || !strings.Contains(e.Message, x.MessageSnippet) {
+		return false
+	}
+	for _, m := range x.Fields {
+		if !m.match(e) {
+			return false
+		}
+	}
+	return true
+}
+
+func (x Expectation) String() string {
+	s := fmt.Sprintf("%v entry containing %q", x.Level, x.MessageSnippet)
+	if len(x.Fields) > 0 {
+		descs := make([]string, len(x.Fields))
+		for i, m := range x.Fields {
+			descs[i] = m.desc
+		}
+		s += " where " + strings.Join(descs, ", ")
+	}
+	return s
+}
+
+// MustContain asserts that an entry was logged at the given level, with a
+// message containing msgSnippet and fields matched by all of fields. It
+// returns the first such entry. Otherwise, it reports the observed entries
+// and stops the test with t.FailNow.
+func (o *ObservedLogs) MustContain(t TestingT, level zapcore.Level, msgSnippet string, fields ...FieldMatcher) LoggedEntry {
+	helper(t)
+	found := o.MustContainSequence(t, Expect(level, msgSnippet, fields...))
+	if len(found) == 0 {
+		return LoggedEntry{}
+	}
+	return found[0]
+}
+
+// MustContainSequence asserts that entries matching each of the expectations
+// were logged in the given order, though not necessarily consecutively. It
+// returns the matching entries. Otherwise, it reports the first expectation
+// that wasn't met along with the observed entries, and stops the test with
+// t.FailNow.
+func (o *ObservedLogs) MustContainSequence(t TestingT, expected ...Expectation) []LoggedEntry {
+	helper(t)
+
+	all := o.All()
+	found := make([]LoggedEntry, 0, len(expected))
+	next := 0
+	for _, x := range expected {
+		for next < len(all) && !x.matches(all[next]) {
+			next++
+		}
+		if next == len(all) {
+			msg := fmt.Sprintf("no %v", x)
+			if len(found) > 0 {
+				msg += fmt.Sprintf(" after %d matched entries", len(found))
+			}
+			t.Errorf("%s; observed:\n%s", msg, formatEntries(all))
+			t.FailNow()
+			return nil
+		}
+		found = append(found, all[next])
+		next++
+	}
+	return found
+}
+
+// Golden serializes the observed entries deterministically, one per line,
+// for comparison with a golden file. Timestamps, callers, and stack traces
+// are omitted, and fields are sorted by key.
+func (o *ObservedLogs) Golden() []byte {
+	return []byte(formatEntries(o.All()))
+}
+
+// AssertGolden asserts that the observed entries, serialized with Golden,
+// match the contents of the file at path. If the UpdateGoldenEnv
+// environment variable is set, it writes the file instead.
+func (o *ObservedLogs) AssertGolden(t TestingT, path string) {
+	helper(t)
+
+	got := o.Golden()
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Errorf("can't create golden file directory: %v", err)
+			t.FailNow()
+			return
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Errorf("can't update golden file: %v", err)
+			t.FailNow()
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Errorf("can't read golden file (set %s=1 to create it): %v", UpdateGoldenEnv, err)
+		t.FailNow()
+		return
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("observed entries don't match golden file %s (set %s=1 to update it)\nwant:\n%s\ngot:\n%s",
+			path, UpdateGoldenEnv, want, got)
+	}
+}
+
+// formatEntries formats entries one per line as
+//
+//	level logger "message" {"field":"value"}
+//
+// where the logger is omitted for unnamed loggers.
+func formatEntries(entries []LoggedEntry) string {
+	var sb strings.Builder
+	for _, e := range entries {
+		sb.WriteString(e.Level.String())
+		if e.LoggerName != "" {
+			sb.WriteByte(' ')
+			sb.WriteString(e.LoggerName)
+		}
+		fmt.Fprintf(&sb, " %q", e.Message)
+		if len(e.Context) > 0 {
+			sb.WriteByte(' ')
+			sb.WriteString(formatContext(e.ContextMap()))
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// formatContext formats fields as JSON, which sorts them by key. Values that
+// can't be represented in JSON are formatted with fmt.
+func formatContext(fields map[string]interface{}) string {
+	for k, v := range fields {
+		if _, err := json.Marshal(v); err != nil {
+			fields[k] = fmt.Sprintf("%+v", v)
+		}
+	}
+	bs, _ := json.Marshal(fields) // every value marshals now
+	return string(bs)
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package observer_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zaptest/observer"
+)
+
+// recordingT records failures instead of failing the test.
+type recordingT struct {
+	errors []string
+	failed bool
+}
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *recordingT) FailNow() { t.failed = true }
+
+func observedLogger() (*zap.Logger, *ObservedLogs) {
+	core, logs := New(zapcore.DebugLevel)
+	return zap.New(core), logs
+}
+
+func TestMustContain(t *testing.T) {
+	logger, logs := observedLogger()
+	logger.Info("served request", zap.Int("status", 200), zap.String("path", "/users"))
+	logger.Named("db").Warn("slow query", zap.Duration("took", 2e9))
+
+	got := logs.MustContain(t, zapcore.InfoLevel, "served",
+		FieldEquals(zap.Int("status", 200)),
+		HasField("path"),
+		FieldMatches("path", func(v interface{}) bool { return v == "/users" }),
+	)
+	assert.Equal(t, "served request", got.Message, "Unexpected entry returned.")
+
+	tests := []struct {
+		desc    string
+		level   zapcore.Level
+		snippet string
+		fields  []FieldMatcher
+		wantMsg string
+	}{
+		{
+			desc:    "wrong level",
+			level:   zapcore.ErrorLevel,
+			snippet: "served",
+			wantMsg: `no error entry containing "served"`,
+		},
+		{
+			desc:    "wrong field value",
+			level:   zapcore.InfoLevel,
+			snippet: "served",
+			fields:  []FieldMatcher{FieldEquals(zap.Int("status", 500))},
+			wantMsg: `no info entry containing "served" where field "status" equals 500`,
+		},
+		{
+			desc:    "missing field",
+			level:   zapcore.WarnLevel,
+			snippet: "slow",
+			fields:  []FieldMatcher{HasField("query")},
+			wantMsg: `no warn entry containing "slow" where has field "query"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			rt := &recordingT{}
+			logs.MustContain(rt, tt.level, tt.snippet, tt.fields...)
+			assert.True(t, rt.failed, "Expected FailNow to be called.")
+			require.Len(t, rt.errors, 1, "Expected one error.")
+			assert.Contains(t, rt.errors[0], tt.wantMsg, "Unexpected failure message.")
+			assert.Contains(t, rt.errors[0], `warn db "slow query" {"took":2000000000}`,
+				"Expected the failure to list observed entries.")
+		})
+	}
+}
+
+func TestMustContainSequence(t *testing.T) {
+	logger, logs := observedLogger()
+	logger.Info("starting")
+	logger.Debug("noise")
+	logger.Info("listening", zap.Int("port", 8080))
+	logger.Warn("shutting down")
+
+	got := logs.MustContainSequence(t,
+		Expect(zapcore.InfoLevel, "start"),
+		Expect(zapcore.InfoLevel, "listen", FieldEquals(zap.Int("port", 8080))),
+		Expect(zapcore.WarnLevel, "shutting"),
+	)
+	require.Len(t, got, 3, "Expected an entry per expectation.")
+	assert.Equal(t, "listening", got[1].Message, "Unexpected entry returned.")
+
+	rt := &recordingT{}
+	logs.MustContainSequence(rt,
+		Expect(zapcore.WarnLevel, "shutting"),
+		Expect(zapcore.InfoLevel, "starting"),
+	)
+	assert.True(t, rt.failed, "Expected out-of-order entries to fail.")
+	require.Len(t, rt.errors, 1, "Expected one error.")
+	assert.Contains(t, rt.errors[0], `no info entry containing "starting" after 1 matched entries`)
+}
+
+func TestGolden(t *testing.T) {
+	logger, logs := observedLogger()
+	logger.With(zap.String("b", "bound")).Info("first", zap.Int("a", 1), zap.Error(errors.New("boom")))
+	logger.Named("sub").Error(`with "quotes"`, zap.Float64("nan", math.NaN()))
+	logger.Debug("bare")
+
+	want := `info "first" {"a":1,"b":"bound","error":"boom"}` + "\n" +
+		`error sub "with \"quotes\"" {"nan":"NaN"}` + "\n" +
+		`debug "bare"` + "\n"
+	assert.Equal(t, want, string(logs.Golden()), "Unexpected golden serialization.")
+
+	logs.AssertGolden(t, filepath.Join("testdata", "golden.txt"))
+
+	t.Run("mismatch", func(t *testing.T) {
+		t.Setenv(UpdateGoldenEnv, "")
+		path := filepath.Join(t.TempDir(), "golden.txt")
+		require.NoError(t, os.WriteFile(path, []byte("other\n"), 0o644))
+
+		rt := &recordingT{}
+		logs.AssertGolden(rt, path)
+		require.Len(t, rt.errors, 1, "Expected a mismatch to be reported.")
+		assert.Contains(t, rt.errors[0], "don't match golden file")
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		t.Setenv(UpdateGoldenEnv, "")
+		rt := &recordingT{}
+		logs.AssertGolden(rt, filepath.Join(t.TempDir(), "missing.txt"))
+		assert.True(t, rt.failed, "Expected a missing golden file to fail the test.")
+	})
+
+	t.Run("update", func(t *testing.T) {
+		t.Setenv(UpdateGoldenEnv, "1")
+		path := filepath.Join(t.TempDir(), "nested", "golden.txt")
+		logs.AssertGolden(t, path)
+
+		contents, err := os.ReadFile(path)
+		require.NoError(t, err, "Expected the golden file to be written.")
+		assert.Equal(t, want, string(contents), "Unexpected golden file contents.")
+	})
+}